@@ -0,0 +1,35 @@
+package cff
+
+import (
+	"context"
+	"time"
+)
+
+// BudgetContext returns a copy of ctx for a task with Budget
+// that expires once the task's share of the time left before
+// the deadline of ctx has passed.
+// The share of the task is weight/pathWeight,
+// where pathWeight is the weight of the task and of the heaviest chain of
+// tasks with Budget that run after it.
+//
+// If ctx has no deadline, BudgetContext returns ctx unchanged.
+//
+// This is intended to be used by cff's generated code.
+// Do not use directly.
+// This can change without warning.
+func BudgetContext(ctx context.Context, weight, pathWeight float64) (context.Context, context.CancelFunc) {
+	deadline, ok := ctx.Deadline()
+	if !ok || weight <= 0 || pathWeight <= 0 || weight >= pathWeight {
+		// Without a deadline, there is nothing to divide,
+		// and the last task of a chain receives all the time left.
+		return ctx, func() {}
+	}
+
+	remaining := time.Until(deadline)
+	if remaining <= 0 {
+		return ctx, func() {}
+	}
+
+	share := time.Duration(float64(remaining) * weight / pathWeight)
+	return context.WithTimeout(ctx, share)
+}
@@ -0,0 +1,53 @@
+package cff
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBudgetContext(t *testing.T) {
+	t.Run("no deadline", func(t *testing.T) {
+		ctx := context.Background()
+		got, cancel := BudgetContext(ctx, 0.3, 1)
+		defer cancel()
+
+		assert.Equal(t, ctx, got)
+	})
+
+	t.Run("share", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Hour)
+		defer cancel()
+
+		got, cancel := BudgetContext(ctx, 1, 4)
+		defer cancel()
+
+		deadline, ok := got.Deadline()
+		require.True(t, ok)
+		assert.WithinDuration(t, time.Now().Add(15*time.Minute), deadline, time.Minute)
+	})
+
+	t.Run("last task", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Hour)
+		defer cancel()
+
+		got, cancel := BudgetContext(ctx, 0.5, 0.5)
+		defer cancel()
+
+		assert.Equal(t, ctx, got, "must receive all the time left")
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+		defer cancel()
+
+		got, cancel := BudgetContext(ctx, 1, 2)
+		defer cancel()
+
+		assert.Equal(t, ctx, got)
+		assert.Error(t, got.Err())
+	})
+}
@@ -0,0 +1,8 @@
+package cff
+
+// TaskBundle is a group of tasks and parameters built with [Bundle].
+// Add it to a [Flow] with [Use].
+//
+// A TaskBundle holds no state.
+// It's used only to identify bundles during code generation.
+type TaskBundle struct{}
@@ -15,7 +15,10 @@
 //	cff ./...
 package cff
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 const _noGenMsg = `If you're seeing this error, you probably built code that uses cff without processing it with cff.
 Ensure that .go files that use cff have '//go:build cff' on top and run 'cff ./...'`
@@ -58,6 +61,10 @@ func Params(args ...interface{}) Option {
 //		// ...
 //	)
 //
+// A pointer to a [Maybe][T] may be used in place of a pointer to T
+// to find out whether the result was produced.
+// See [Maybe] and [ContinueOnError] for details.
+//
 // This is a code generation directive.
 func Results(results ...interface{}) Option {
 	panic(_noGenMsg)
@@ -81,6 +88,27 @@ func WithEmitter(Emitter) Option {
 	panic(_noGenMsg)
 }
 
+// WithInterceptor specifies an [Interceptor] that wraps every invocation
+// of the tasks of a [Flow] or [Parallel],
+// including the elements of [Slice] and [Map].
+//
+//	cff.WithInterceptor(func(ctx context.Context, info *cff.TaskInfo, next func(context.Context) error) error {
+//		ctx = log.WithFields(ctx, "task", info.Name)
+//		return next(ctx)
+//	})
+//
+// Provide this option multiple times to use multiple interceptors.
+// They're applied in the order they were provided,
+// the first being the outermost.
+// Interceptors installed with [RegisterInterceptor] wrap all of them.
+//
+// WithInterceptor cannot be used in a [Subflow].
+//
+// This is a code generation directive.
+func WithInterceptor(Interceptor) Option {
+	panic(_noGenMsg)
+}
+
 // Task specifies a task for execution with a [Flow] or [Parallel].
 // A task can be a reference to:
 //
@@ -140,9 +168,169 @@ func Concurrency(n int) Option {
 	panic(_noGenMsg)
 }
 
-// ContinueOnError configures a [Parallel] to keep running all other tasks
-// despite errors returned by tasks over the course of its execution.
-// By default, Parallel will stop execution at the first error it encounters.
+// RateLimitOption is an option accepted by [Parallel], [Slice], [Map],
+// and [Task].
+// It is returned by [RateLimit].
+type RateLimitOption interface {
+	Option
+	TaskOption
+	SliceOption
+	MapOption
+}
+
+// RateLimit specifies a [Limiter] that limits the rate at which tasks run.
+// Each task waits for the limiter before it runs.
+// If the context ends while waiting, the task fails with the context's
+// error.
+//
+//	limiter := cff.NewTokenBucket(200, 1) // 200 requests per second
+//	err := cff.Parallel(ctx,
+//		cff.Slice(
+//			func(ctx context.Context, id UserID) error {
+//				return client.Refresh(ctx, id)
+//			},
+//			ids,
+//			cff.RateLimit(limiter),
+//		),
+//	)
+//
+// The rate limit is independent of [Concurrency]:
+// Concurrency limits how many tasks run at the same time,
+// and RateLimit limits how often they start.
+//
+// RateLimit may be used with:
+//
+//   - [Parallel], to limit all its tasks, including elements of Slices
+//     and Maps
+//   - [Slice] and [Map], to limit their elements
+//   - [Task], to limit the task in a [Parallel] or [Flow]
+//
+// With [Batch], the limiter is consulted once for each batch.
+// The limiter may be shared between Flows and Parallels.
+//
+// This is a code generation directive.
+func RateLimit(limiter Limiter) RateLimitOption {
+	panic(_noGenMsg)
+}
+
+// Group declares a named group of tasks of a [Flow]
+// of which at most n run at the same time.
+// Tasks join the group with [InGroup].
+//
+//	cff.Flow(ctx,
+//		cff.Group("db", 2),
+//		cff.Task(getUser, cff.InGroup("db")),
+//		cff.Task(getOrders, cff.InGroup("db")),
+//		cff.Task(getPrices, cff.InGroup("db")),
+//		cff.Task(getRecommendations),
+//		// ...
+//	)
+//
+// Tasks of a group that are ready to run wait for a task of the group to
+// finish without occupying any of the Flow's goroutines,
+// so other tasks keep using the full [Concurrency] of the Flow.
+// Values of n less than 1 impose no limit.
+//
+// The name must be a constant string, unique within the Flow.
+// The occupancy of each group is reported to [SchedulerEmitter]
+// in [SchedulerState].
+//
+// This is a code generation directive.
+func Group(name string, n int) Option {
+	panic(_noGenMsg)
+}
+
+// RethrowPanics configures a [Flow] or [Parallel] to re-panic on the calling
+// goroutine if one of its tasks panics.
+// By default, panics in tasks are recovered and returned as a [PanicError].
+//
+//	err := cff.Flow(ctx,
+//		cff.Task(
+//			// ...
+//		),
+//		cff.RethrowPanics(),
+//	)
+//
+// The panic is rethrown after the Flow or Parallel has finished running
+// and after the [TaskEmitter] has been informed of the panic with
+// TaskPanic.
+// The rethrown value is the value the task panicked with,
+// so callers may recover it as usual.
+// The rethrown value doesn't carry the stack trace of the task that
+// panicked: use [SetRethrowPanicHook] to record it.
+//
+// Use [SetRethrowPanics] to enable this behavior for all Flows and Parallels
+// in the process.
+//
+// This is a code generation directive.
+func RethrowPanics() Option {
+	panic(_noGenMsg)
+}
+
+// AllowAssignable configures a [Flow] to resolve task inputs and [Results]
+// by assignability when no task provides the exact type.
+// By default, a Flow only connects a task to another if the input type of
+// one is identical to the output type of the other.
+//
+//	// Given,
+//	//   func newBuffer() *bytes.Buffer
+//	//   func parse(io.Reader) (*Config, error)
+//	cff.Flow(ctx,
+//		cff.Results(&cfg),
+//		cff.Task(newBuffer),
+//		cff.Task(parse), // receives the *bytes.Buffer as an io.Reader
+//		cff.AllowAssignable(),
+//	)
+//
+// Exact matches always take precedence.
+// It is an error for more than one provider to be assignable to the same
+// type: declare the exact type on one of the providers to disambiguate.
+//
+// AllowAssignable is incompatible with [Parallel].
+//
+// This is a code generation directive.
+func AllowAssignable() Option {
+	panic(_noGenMsg)
+}
+
+// Lazy configures a [Flow] to run only the tasks needed to produce its
+// [Results].
+// By default, a Flow runs all its tasks,
+// and it is an error for a task to produce a value that is not consumed.
+//
+//	// Given,
+//	//   func loadUser(UserID) (*User, error)
+//	//   func loadOrders(UserID) ([]*Order, error)
+//	var user *User
+//	cff.Flow(ctx,
+//		cff.Params(id),
+//		cff.Results(&user),
+//		cff.Task(loadUser),
+//		cff.Task(loadOrders), // omitted
+//		cff.Lazy(),
+//	)
+//
+// With Lazy, tasks that don't contribute to the Results
+// directly or indirectly are omitted from the Flow
+// unless they are run for side effects with [Invoke].
+// Params consumed only by omitted tasks are ignored,
+// and tasks may produce values that are not consumed.
+//
+// This allows a single Flow definition, or tasks shared with [Bundle],
+// to serve requests for different subsets of results.
+//
+// Lazy is incompatible with [Parallel].
+//
+// This is a code generation directive.
+func Lazy() Option {
+	panic(_noGenMsg)
+}
+
+// ContinueOnError configures a [Flow] or [Parallel] to keep running all
+// other tasks despite errors returned by tasks over the course of its
+// execution.
+// By default, Flow and Parallel will stop execution at the first error they
+// encounter.
 //
 //	err = cff.Parallel(ctx,
 //		cff.Task(task1),
@@ -152,17 +340,103 @@ func Concurrency(n int) Option {
 //	)
 //
 // If one or more tasks return errors with ContinueOnError(true),
-// Parallel will still run all the other tasks,
+// Flow and Parallel will still run all the other tasks,
 // and accumulate and combine the errors together into a single error object.
 // You can access the full list of errors with [go.uber.org/multierr.Errors].
 //
-// ContinueOnError(true) is incompatible with [Flow], [SliceEnd] and [MapEnd].
+// In a Flow, tasks that depend on the results of a failed task are skipped.
+// All other tasks run to completion,
+// and the Flow fills the [Results] that were produced
+// even though it returns an error.
+// Results that were not produced are left unchanged.
+// Use pointers to [Maybe] values in Results to find out
+// which results were produced.
+//
+//	var (
+//		user    *User
+//		profile cff.Maybe[*Profile]
+//	)
+//	err := cff.Flow(ctx,
+//		cff.Results(&user, &profile),
+//		// ...
+//		cff.ContinueOnError(true),
+//	)
+//	if profile.Valid {
+//		// ...
+//	}
+//
+// Results are not filled if the Flow fails because its context
+// was cancelled.
+//
+// With ContinueOnError(true), the functions passed to [SliceEnd] and
+// [MapEnd] run after all elements were attempted, even if some of them
+// failed.
 //
 // This is a code generation directive.
 func ContinueOnError(bool) Option {
 	panic(_noGenMsg)
 }
 
+// FirstSuccess specifies that a [Parallel] should succeed as soon as any of
+// its tasks succeeds. This is the same as Quorum(1).
+//
+//	err := cff.Parallel(ctx,
+//		cff.Task(func(ctx context.Context) error {
+//			return readFrom(ctx, cache)
+//		}),
+//		cff.Task(func(ctx context.Context) error {
+//			return readFrom(ctx, origin)
+//		}),
+//		cff.FirstSuccess(),
+//	)
+//
+// See [Quorum] for details.
+//
+// This is a code generation directive.
+func FirstSuccess() Option {
+	panic(_noGenMsg)
+}
+
+// Quorum specifies that a [Parallel] should succeed as soon as k of its
+// tasks succeed.
+//
+//	err := cff.Parallel(ctx,
+//		cff.Slice(
+//			func(ctx context.Context, r *Replica) error {
+//				return r.Write(ctx, record)
+//			},
+//			replicas,
+//		),
+//		cff.Quorum(2),
+//	)
+//
+// Each [Task], and each element of a [Slice] or a [Map] counts as a task.
+// With [Batch], each batch counts as a single task.
+// Only tasks that ran and returned no error count towards the quorum:
+// tasks skipped by a [Predicate], and failures recovered by
+// [FallbackWith], [SliceFallback], or [MapFallback] don't.
+// k must be at least 1. If it isn't a constant,
+// the Parallel fails at run time when it's less than 1.
+//
+// The context of the Parallel is cancelled once the quorum is reached,
+// and the Parallel returns without waiting for the tasks that are still
+// running. Tasks that didn't start are reported to [TaskEmitter] as
+// skipped with [SkipQuorumReached]. Tasks that are still running keep
+// running with the cancelled context, and report their outcome to
+// [TaskEmitter] when they finish, after the Parallel has returned.
+//
+// Failed tasks don't stop the Parallel until it's no longer possible for
+// k tasks to succeed. The Parallel then fails with a [QuorumError]
+// that combines the errors of the tasks that failed.
+//
+// Quorum cannot be used with [ContinueOnError], [SliceEnd], or [MapEnd].
+// Quorum is incompatible with [Flow].
+//
+// This is a code generation directive.
+func Quorum(k int) Option {
+	panic(_noGenMsg)
+}
+
 // Flow specifies a single Flow for execution with cff.
 // A child of the provided context is made available to all tasks in the Flow
 // if they request it.
@@ -215,6 +489,13 @@ func ContinueOnError(bool) Option {
 //
 //	func(I1, I2, ...) (R1, R2, ..., error)
 //
+// Tasks that may legitimately not produce a value
+// may report whether they did with a boolean result after the value,
+// or by returning a [Maybe].
+// Tasks that depend on a value that was not produced are skipped.
+//
+//	func(I1, I2, ...) (R, bool, [error])
+//
 // Task behaviors may further be customized with [TaskOption].
 //
 // This is a code generation directive.
@@ -256,7 +537,7 @@ func FallbackWith(results ...interface{}) TaskOption {
 //
 // The predicate function has the following signature:
 //
-//	func(I1, I2, ...) bool
+//	func([context.Context], I1, I2, ...) (bool, [error])
 //
 // Where the arguments I1, I2, ... are inputs similar to a task.
 // Arguments added to the predicate become a dependency of the task,
@@ -269,6 +550,10 @@ func FallbackWith(results ...interface{}) TaskOption {
 // If any other tasks depend on this task,
 // cff will give them zero values of the outputs of this task.
 //
+// If the function fails with an error,
+// the task fails with a *[PredicateError].
+// See [PredicateOnError] to use a default value instead.
+//
 // For example:
 //
 //	cff.Task(
@@ -283,6 +568,29 @@ func Predicate(fn interface{}) TaskOption {
 	panic(_noGenMsg)
 }
 
+// PredicateOnError specifies the value to use for the [Predicate] of a task
+// if the predicate fails with an error.
+//
+//	cff.Task(
+//		sendEmail,
+//		cff.Predicate(flags.EmailsEnabled), // func(context.Context) (bool, error)
+//		cff.PredicateOnError(false),
+//	)
+//
+// By default, if a predicate fails,
+// the task fails with a *[PredicateError] wrapping the error,
+// unless the task uses [FallbackWith] or [Optional],
+// in which case the failure is recovered as it would be for the task.
+// With PredicateOnError, the failure is always recovered,
+// and the task runs only if the provided value is true.
+// Recovered failures are reported to the [TaskEmitter] with
+// TaskErrorRecovered.
+//
+// This is a code generation directive.
+func PredicateOnError(value bool) TaskOption {
+	panic(_noGenMsg)
+}
+
 // Instrument specifies that this Task should be instrumented for
 // observability.
 // The provided name will be passed to the [Emitter] you passed into
@@ -316,6 +624,306 @@ func Invoke(enable bool) TaskOption {
 	panic(_noGenMsg)
 }
 
+// Optional specifies that a [Flow] task is not critical to the Flow:
+// if the task fails with an error or panics,
+// the failure is reported to the [TaskEmitter] as a recovered error or panic
+// and the Flow continues as if the task had succeeded.
+//
+//	cff.Task(fetchAvatar, cff.Optional())
+//
+// Consumers of the outputs of a failed optional task receive zero values.
+// To distinguish a zero value from a failure,
+// consumers may accept a [Maybe] of the output type instead.
+//
+//	cff.Task(func(avatar cff.Maybe[*Avatar]) *Profile {
+//		if a, ok := avatar.Get(); ok {
+//			// ...
+//		}
+//		// ...
+//	})
+//
+// Optional cannot be combined with [FallbackWith].
+//
+// This is a code generation directive.
+func Optional() TaskOption {
+	panic(_noGenMsg)
+}
+
+// Collect specifies that the values produced by a [Flow] task should be
+// collected into slices instead of being provided on their own.
+// This allows many tasks to produce values of the same type,
+// and another task to consume all of them.
+//
+//	cff.Task(checkQuota, cff.Collect()),   // returns Signal
+//	cff.Task(checkAbuse, cff.Collect()),   // returns Signal
+//	cff.Task(func(signals []Signal) *Report {
+//		// ...
+//	}),
+//
+// For each type T produced by tasks marked with Collect,
+// the Flow provides a []T that holds the values produced by these tasks
+// in the order the tasks were specified.
+// Values of tasks that did not produce them are omitted from the slice:
+// for example, tasks skipped because of a [Predicate],
+// and [Optional] tasks that failed.
+//
+// Collect cannot be combined with [Invoke],
+// or used with the tasks of a [Switch].
+//
+// This is a code generation directive.
+func Collect() TaskOption {
+	panic(_noGenMsg)
+}
+
+// Ref attaches a [TaskRef] to a [Flow] task
+// so that other tasks may refer to it with [After].
+//
+//	var commit cff.TaskRef
+//	cff.Task(commitTx, cff.Invoke(true), cff.Ref(&commit))
+//
+// Each TaskRef may be attached to only one task.
+// Ref cannot be used with the tasks of a [Switch].
+//
+// This is a code generation directive.
+func Ref(ref *TaskRef) TaskOption {
+	panic(_noGenMsg)
+}
+
+// After specifies that a [Flow] task must not run until the given tasks
+// have finished, even if it doesn't consume any of their outputs.
+// Tasks may be referred to by a [TaskRef] attached with [Ref],
+// or by the constant name passed to [Instrument].
+//
+//	cff.Task(writeAuditLog, cff.Invoke(true), cff.After(commit)),
+//	cff.Task(notify, cff.Invoke(true), cff.After("commit")),
+//
+// If one of the referenced tasks fails, the task does not run.
+// Tasks skipped because of a [Predicate] count as finished.
+//
+// After cannot be used with the tasks of a [Switch].
+//
+// This is a code generation directive.
+func After(refs ...interface{}) TaskOption {
+	panic(_noGenMsg)
+}
+
+// InGroup adds a [Flow] task to the group declared with [Group]
+// under the given constant name.
+//
+//	cff.Task(getUser, cff.InGroup("db")),
+//
+// InGroup cannot be used with the tasks of a [Switch].
+//
+// This is a code generation directive.
+func InGroup(name string) TaskOption {
+	panic(_noGenMsg)
+}
+
+// Hedge runs another copy of a task if it hasn't finished after the given
+// duration, up to max copies of the task in total.
+// Use this for read-only tasks with a long tail latency.
+//
+//	cff.Task(
+//		func(ctx context.Context, id UserID) (*User, error) {
+//			return client.GetUser(ctx, id)
+//		},
+//		cff.Hedge(50*time.Millisecond, 2),
+//	)
+//
+// Each copy receives its own context.
+// The first copy to succeed wins:
+// its results are used and the contexts of the other copies are cancelled.
+// If all copies that started fail, the task fails with the error of the
+// first one to fail; no more copies are started after that.
+// The copy that won is reported with TaskHedged to task emitters
+// that implement [HedgeEmitter].
+//
+// Copies of the task run concurrently on separate goroutines,
+// so the task must be safe to run more than once at the same time.
+//
+// This is a code generation directive.
+func Hedge(after time.Duration, max int) TaskOption {
+	panic(_noGenMsg)
+}
+
+// Budget gives a [Flow] task a share of the time left before the deadline
+// of the flow's context, in proportion to the given constant weight.
+// The task must accept a context.Context.
+//
+//	cff.Task(getUser, cff.Budget(0.3)),
+//	cff.Task(getFeed, cff.Budget(0.7)),
+//
+// When a task with a Budget starts, cff compares its weight to the weights
+// of the tasks with a Budget that must run after it.
+// The task receives a context that expires once its share of the remaining
+// time has passed, where its share is
+//
+//	weight / (weight + weight of the heaviest chain of tasks after it)
+//
+// This keeps a slow task from using up the time of the tasks that depend
+// on it. The last task of a chain receives all the remaining time.
+// Tasks without a Budget don't count towards the weights.
+//
+// If the flow's context has no deadline, Budget has no effect.
+//
+// Budget cannot be used with the tasks of a [Switch].
+//
+// This is a code generation directive.
+func Budget(weight float64) TaskOption {
+	panic(_noGenMsg)
+}
+
+// Switch specifies alternative tasks for a [Flow] that provide the same
+// values. Only one of these tasks runs.
+//
+//	cff.Switch(
+//		cff.Case(isCached, readFromCache),
+//		cff.Case(isStale, refreshAndRead),
+//		cff.Default(readFromDB),
+//	)
+//
+// Each [Case] pairs a predicate with a task.
+// The Switch runs the task of the first Case whose predicate returns true.
+// If no predicate returns true, the Switch runs the task passed to
+// [Default], if any.
+// If there is no Default, none of the tasks run,
+// and the values provided by the Switch are left as zero values,
+// similarly to a task skipped by a [Predicate].
+// Tasks that are not selected are reported to the [TaskEmitter] as skipped.
+//
+// All tasks of a Switch must produce the same set of types.
+// They may consume different types:
+// the inputs of all tasks and predicates of a Switch are computed
+// before a task is selected.
+//
+// A Switch must have at least one Case.
+// Default, if specified, must be the last argument.
+//
+// Switch is incompatible with [Parallel].
+//
+// This is a code generation directive.
+func Switch(cases ...SwitchCase) Option {
+	panic(_noGenMsg)
+}
+
+// SwitchCase is an alternative of a [Switch].
+type SwitchCase interface {
+	cffSwitchCase()
+}
+
+// Case specifies a task for a [Switch] and the predicate that selects it.
+//
+//	cff.Case(
+//		func(req *Request) bool { return req.UseCache },
+//		readFromCache,
+//	)
+//
+// The predicate has the same form as the function passed to [Predicate],
+// and the task and its options have the same form as the arguments of
+// [Task].
+// [Predicate] and [Invoke] may not be used with the task of a Case.
+//
+// This is a code generation directive.
+func Case(pred, fn interface{}, opts ...TaskOption) SwitchCase {
+	panic(_noGenMsg)
+}
+
+// Default specifies a task for a [Switch] that runs
+// if no other [Case] is selected.
+//
+//	cff.Default(readFromDB)
+//
+// The task and its options have the same form as the arguments of [Task].
+// [Predicate] and [Invoke] may not be used with the task of a Default.
+//
+// This is a code generation directive.
+func Default(fn interface{}, opts ...TaskOption) SwitchCase {
+	panic(_noGenMsg)
+}
+
+// Bundle groups [Task] and [Params] options so that they may be shared
+// between flows with [Use].
+// A Bundle must be used to initialize a package-level variable.
+//
+//	var userBundle = cff.Bundle(
+//		cff.Task(loadUser),
+//		cff.Task(loadOrg),
+//		cff.Task(loadPermissions),
+//	)
+//
+// The variable may be declared in any file of the package
+// that has the 'cff' build constraint.
+//
+// This is a code generation directive.
+func Bundle(opts ...Option) TaskBundle {
+	panic(_noGenMsg)
+}
+
+// Use adds the tasks and parameters of a [Bundle] to a [Flow].
+//
+//	cff.Flow(ctx,
+//		cff.Params(req),
+//		cff.Results(&perms),
+//		cff.Use(userBundle),
+//	)
+//
+// Tasks of the bundle that don't contribute to the results of the Flow,
+// directly or indirectly, are left out of the Flow.
+// Tasks that use [Invoke] always run.
+// Similarly, parameters of the bundle that are not consumed are ignored.
+//
+// Use is incompatible with [Parallel].
+//
+// This is a code generation directive.
+func Use(bundle TaskBundle) Option {
+	panic(_noGenMsg)
+}
+
+// Subflow groups tasks of a [Flow] that are configured together.
+//
+//	cff.Flow(ctx,
+//		cff.Params(req),
+//		cff.Results(&resp),
+//		cff.Subflow(
+//			cff.InstrumentFlow("auth"),
+//			cff.Concurrency(2),
+//			cff.ContinueOnError(true),
+//			cff.Task(loadUser),
+//			cff.Task(loadOrg),
+//			cff.Task(checkPermissions),
+//		),
+//		cff.Task(buildResponse),
+//	)
+//
+// The tasks of a Subflow run on the same goroutines as the other tasks of
+// the Flow, and are part of the same dependency graph:
+// the values that they consume and don't produce are the inputs of the
+// Subflow, and the values that they produce are its outputs.
+//
+// A Subflow accepts [Task] and the following options,
+// which apply only to its tasks:
+//
+//   - [Concurrency] limits the number of tasks of the Subflow
+//     that run at the same time.
+//     The Flow's concurrency still applies.
+//   - [WithEmitter] adds emitters for the tasks of the Subflow
+//     in addition to the emitters of the Flow.
+//   - [InstrumentFlow] names the Subflow.
+//     The [DirectiveInfo] of instrumented tasks of the Subflow
+//     reports this name with the Flow as its Parent.
+//   - [ContinueOnError] keeps the Flow running if a task of the Subflow
+//     fails. Tasks that depend on the failed task, directly or indirectly,
+//     are skipped. The Flow returns the errors after all other tasks have
+//     finished.
+//
+// Subflows cannot be nested.
+// Subflow is incompatible with [Parallel].
+//
+// This is a code generation directive.
+func Subflow(opts ...Option) Option {
+	panic(_noGenMsg)
+}
+
 // Parallel specifies a parallel operation for execution with cff.
 //
 // A Parallel must have at least one [Task], [Tasks], [Map], or [Slice].
@@ -361,6 +969,22 @@ func Invoke(enable bool) TaskOption {
 // Fallible tasks may return a non-nil error to signal failure.
 //
 // Task behaviors may further be customized with [TaskOption].
+// Tasks of a Parallel support [Instrument], [Predicate], [PredicateOnError],
+// and [FallbackWith].
+// Because these tasks don't depend on other tasks,
+// their predicates accept only an optional context.Context.
+//
+//	cff.Task(
+//		sendEmail,
+//		cff.Predicate(func(ctx context.Context) (bool, error) {
+//			return flags.EmailsEnabled(ctx)
+//		}),
+//		cff.FallbackWith(),
+//	)
+//
+// Parallel tasks don't produce values,
+// so FallbackWith doesn't accept any for them:
+// it only recovers from the failure of the task.
 //
 // This is a code generation directive.
 func Parallel(ctx context.Context, opts ...Option) error {
@@ -447,13 +1071,85 @@ type SliceOption interface {
 //	cff.SliceEnd(func(ctx context.Context) {...})
 //	cff.SliceEnd(func(ctx context.Context) error {...})
 //
-// SliceEnd cannot be used with [ContinueOnError].
+// With [ContinueOnError], the function runs after every element was
+// attempted, even if some of them failed.
+//
+// The function may also accept the errors of the elements that failed
+// as a [ElementErrors] keyed by their index, after the optional context:
+//
+//	cff.SliceEnd(func(ctx context.Context, errs cff.ElementErrors[int]) error {...})
+//
+// Errors of elements then don't fail the Parallel operation:
+// the function decides whether it fails by returning an error.
 //
 // This is a code generation directive.
 func SliceEnd(fn interface{}) SliceOption {
 	panic(_noGenMsg)
 }
 
+// SliceFallback specifies a function that recovers from the failure of an
+// element of a [Slice] operation.
+// If the function passed to Slice fails with an error or panics for an
+// element, the fallback is called with that element and the error,
+// and the element is considered processed successfully.
+// The failure doesn't halt the Parallel operation,
+// even without [ContinueOnError].
+//
+// For a slice []T, the fallback has the following signature:
+//
+//	func([ctx context.Context,] [idx int,] value T, err error)
+//
+// Panics are passed to the fallback as a *[PanicError].
+//
+//	cff.Slice(
+//		func(ctx context.Context, idx int, id UserID) error {
+//			u, err := client.GetUser(ctx, id)
+//			users[idx] = u
+//			return err
+//		},
+//		ids,
+//		cff.SliceFallback(func(idx int, id UserID, err error) {
+//			users[idx] = &User{ID: id, Name: "unknown"}
+//		}),
+//	)
+//
+// If the Parallel is instrumented with [InstrumentParallel],
+// recovered failures are reported to the [TaskEmitter] initialized
+// with the name of the Parallel and the position of the Slice.
+//
+// This is a code generation directive.
+func SliceFallback(fn interface{}) SliceOption {
+	panic(_noGenMsg)
+}
+
+// Batch specifies that the elements of a [Slice] are processed in batches
+// of up to n elements each, rather than one at a time.
+// The elements of a batch are processed one after the other,
+// and batches are processed in parallel.
+//
+//	cff.Slice(
+//		func(ctx context.Context, idx int, id UserID) error {
+//			return backfill(ctx, id)
+//		},
+//		ids,
+//		cff.Batch(1000),
+//	)
+//
+// This reduces the overhead of scheduling very large slices
+// whose elements are quick to process.
+// The function passed to Slice is still called once per element,
+// with the index of the element, and [SliceFallback], [SliceEnd],
+// and [ElementErrors] still report failures for individual elements.
+//
+// A failed element halts the remaining elements of its batch,
+// unless [ContinueOnError] is used.
+// Values of n less than 1 are treated as 1.
+//
+// This is a code generation directive.
+func Batch(n int) SliceOption {
+	panic(_noGenMsg)
+}
+
 // Map runs fn in parallel on elements of the provided map
 // with a bounded number of goroutines.
 //
@@ -506,9 +1202,42 @@ type MapOption interface {
 //	cff.MapEnd(func(ctx context.Context) {...})
 //	cff.MapEnd(func(ctx context.Context) error {...})
 //
-// MapEnd cannot be used with [ContinueOnError].
+// With [ContinueOnError], the function runs after every element was
+// attempted, even if some of them failed.
+//
+// For a map map[K]V, the function may also accept the errors of the
+// elements that failed as a [ElementErrors] keyed by their key,
+// after the optional context:
+//
+//	cff.MapEnd(func(ctx context.Context, errs cff.ElementErrors[K]) error {...})
+//
+// Errors of elements then don't fail the Parallel operation:
+// the function decides whether it fails by returning an error.
 //
 // This is a code generation directive.
 func MapEnd(fn interface{}) MapOption {
 	panic(_noGenMsg)
 }
+
+// MapFallback specifies a function that recovers from the failure of an
+// element of a [Map] operation.
+// If the function passed to Map fails with an error or panics for a key,
+// the fallback is called with that key, its value, and the error,
+// and the element is considered processed successfully.
+// The failure doesn't halt the Parallel operation,
+// even without [ContinueOnError].
+//
+// For a map map[K]V, the fallback has the following signature:
+//
+//	func([ctx context.Context,] k K, v V, err error)
+//
+// Panics are passed to the fallback as a *[PanicError].
+//
+// If the Parallel is instrumented with [InstrumentParallel],
+// recovered failures are reported to the [TaskEmitter] initialized
+// with the name of the Parallel and the position of the Map.
+//
+// This is a code generation directive.
+func MapFallback(fn interface{}) MapOption {
+	panic(_noGenMsg)
+}
@@ -9,6 +9,7 @@
 package main
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"go/token"
@@ -28,6 +29,7 @@ type params struct {
 	Files          []flag.InOutPair
 	AutoInstrument bool
 	GenMode        flag.Mode
+	Format         flag.Format
 	Quiet          bool
 	ImportPath     string
 }
@@ -35,6 +37,7 @@ type params struct {
 func parseArgs(stderr io.Writer, args []string) (pkg.Loader, *params, error) {
 	opts := params{
 		GenMode: flag.BaseMode,
+		Format:  flag.TextFormat,
 	}
 	fset := flag.NewSet("cff")
 	fset.SetOutput(stderr)
@@ -51,6 +54,10 @@ func parseArgs(stderr io.Writer, args []string) (pkg.Loader, *params, error) {
 	fset.Var(&opts.GenMode, "genmode", "Use the specified cff code generation mode.\n"+
 		"Valid values are: base, modifier, source-map. Defaults to base.")
 
+	fset.Var(&opts.Format, "format", "Report diagnostics in the specified format.\n"+
+		"Valid values are: text, json. Defaults to text.\n"+
+		"With json, diagnostics are written to stdout as a JSON array.")
+
 	fset.BoolVar(&opts.AutoInstrument, "auto-instrument", false,
 		"Infer a name for tasks that do not specify cff.Instrument and opt-in "+
 			"to instrumentation by default.")
@@ -88,6 +95,9 @@ var (
 	// Whether files that use cff.Flow/cff.Parallel must have a 'cff' build
 	// constraint.
 	_requireBuildTag = true
+
+	// Where diagnostics are written with -format=json.
+	_stdout io.Writer = os.Stdout
 )
 
 func run(args []string) error {
@@ -122,7 +132,10 @@ func run(args []string) error {
 	// If --file was provided, only the requested files will be processed.
 	// Otherwise all files will be processed.
 	hadFiles := len(f.Files) > 0
-	var processed, errored int
+	var (
+		processed, errored int
+		diags              []*internal.Diagnostic
+	)
 	for _, pkg := range pkgs {
 		for i, path := range pkg.CompiledGoFiles {
 			name := filepath.Base(path)
@@ -139,7 +152,11 @@ func run(args []string) error {
 			processed++
 			if perr := processor.Process(pkg, pkg.Syntax[i], output); perr != nil {
 				errored++
-				err = multierr.Append(err, perr)
+				if f.Format == flag.JSONFormat {
+					diags = append(diags, internal.Diagnostics(perr)...)
+				} else {
+					err = multierr.Append(err, perr)
+				}
 			}
 		}
 	}
@@ -147,6 +164,20 @@ func run(args []string) error {
 	if !f.Quiet {
 		log.Printf("Processed %d files with %d errors", processed, errored)
 	}
+
+	if f.Format == flag.JSONFormat {
+		if diags == nil {
+			diags = []*internal.Diagnostic{} // print "[]" instead of "null"
+		}
+		enc := json.NewEncoder(_stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(diags); err != nil {
+			return fmt.Errorf("write diagnostics: %w", err)
+		}
+		if errored > 0 {
+			return fmt.Errorf("%d files had errors", errored)
+		}
+	}
 	return err
 }
 
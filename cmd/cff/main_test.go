@@ -1,7 +1,10 @@
 package main
 
 import (
+	"bytes"
+	"encoding/json"
 	"io"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -26,6 +29,7 @@ func TestParseArgs(t *testing.T) {
 			want: params{
 				ImportPath: "example.com/foo",
 				GenMode:    flag.BaseMode,
+				Format:     flag.TextFormat,
 			},
 		},
 		{
@@ -38,6 +42,7 @@ func TestParseArgs(t *testing.T) {
 			give: []string{"-file", "foo.go", "-file", "bar.go=baz.go", "example.com/foo"},
 			want: params{
 				GenMode: flag.BaseMode,
+				Format:  flag.TextFormat,
 				Files: []flag.InOutPair{
 					{Input: "foo.go"},
 					{Input: "bar.go", Output: "baz.go"},
@@ -50,6 +55,7 @@ func TestParseArgs(t *testing.T) {
 			give: []string{"-auto-instrument", "example.com/foo"},
 			want: params{
 				GenMode:        flag.BaseMode,
+				Format:         flag.TextFormat,
 				AutoInstrument: true,
 				ImportPath:     "example.com/foo",
 			},
@@ -59,6 +65,16 @@ func TestParseArgs(t *testing.T) {
 			give: []string{"-genmode", "source-map", "example.com/foo"},
 			want: params{
 				GenMode:    flag.SourceMapMode,
+				Format:     flag.TextFormat,
+				ImportPath: "example.com/foo",
+			},
+		},
+		{
+			desc: "json format",
+			give: []string{"-format", "json", "example.com/foo"},
+			want: params{
+				GenMode:    flag.BaseMode,
+				Format:     flag.JSONFormat,
 				ImportPath: "example.com/foo",
 			},
 		},
@@ -67,6 +83,7 @@ func TestParseArgs(t *testing.T) {
 			give: []string{"-quiet", "example.com/foo"},
 			want: params{
 				GenMode:    flag.BaseMode,
+				Format:     flag.TextFormat,
 				Quiet:      true,
 				ImportPath: "example.com/foo",
 			},
@@ -94,6 +111,34 @@ func TestMain_ErrorNoPanic(t *testing.T) {
 	})
 }
 
+func TestMain_JSONFormat(t *testing.T) {
+	var buf bytes.Buffer
+	defer func(w io.Writer) { _stdout = w }(_stdout)
+	_stdout = &buf
+
+	err := run([]string{"-format=json", "-quiet", "./testdata/noflowtasks"})
+	assert.EqualError(t, err, "1 files had errors")
+
+	var diags []struct {
+		File     string `json:"file"`
+		Line     int    `json:"line"`
+		Column   int    `json:"column"`
+		Severity string `json:"severity"`
+		Code     string `json:"code"`
+		Message  string `json:"message"`
+	}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &diags), "decode %q", buf.String())
+	require.Len(t, diags, 1)
+
+	d := diags[0]
+	assert.Equal(t, "noflowtasks.go", filepath.Base(d.File))
+	assert.Equal(t, 15, d.Line)
+	assert.Equal(t, 9, d.Column)
+	assert.Equal(t, "error", d.Severity)
+	assert.Equal(t, "CFF0003 no-tasks", d.Code)
+	assert.Equal(t, "cff.Flow expects at least one function", d.Message)
+}
+
 func TestGenFilename(t *testing.T) {
 	tests := []struct {
 		desc string
@@ -0,0 +1,16 @@
+//go:build cff
+// +build cff
+
+// Package noflowtasks holds a cff.Flow that fails to compile.
+package noflowtasks
+
+import (
+	"context"
+
+	"go.uber.org/cff"
+)
+
+// NoTasks is a cff.Flow without any tasks.
+func NoTasks() error {
+	return cff.Flow(context.Background())
+}
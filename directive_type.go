@@ -13,6 +13,9 @@ const (
 
 	// ParallelDirective marks a Parallel.
 	ParallelDirective
+
+	// SubflowDirective marks a Subflow.
+	SubflowDirective
 )
 
 // String returns the directive string.
@@ -22,6 +25,8 @@ func (d DirectiveType) String() string {
 		return "flow"
 	case ParallelDirective:
 		return "parallel"
+	case SubflowDirective:
+		return "subflow"
 	}
 	return "unknown"
 }
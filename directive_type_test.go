@@ -24,6 +24,10 @@ func TestDirectiveTypeString(t *testing.T) {
 			desc: "parallel",
 			give: ParallelDirective,
 		},
+		{
+			desc: "subflow",
+			give: SubflowDirective,
+		},
 	}
 
 	for _, tt := range tests {
@@ -75,6 +75,7 @@ func main() {
 		ctx := _42_18
 		var v1 int = _49_14
 		emitter := cff.NopEmitter()
+		interceptor := cff.InterceptorStack()
 
 		var (
 			flowInfo = &cff.FlowInfo{
@@ -112,11 +113,13 @@ func main() {
 			ran     cff.AtomicBool
 			run     func(context.Context) error
 			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
 		}
 		defer func() {
 			for _, t := range tasks {
 				if !t.ran.Load() {
-					t.emitter.TaskSkipped(ctx, err)
+					t.emitter.TaskSkipped(ctx, t.outcome.SkipReason(ctx, err))
 				}
 			}
 		}()
@@ -125,17 +128,26 @@ func main() {
 		var (
 			v2 *Trip
 		)
+
 		task0 := new(struct {
 			emitter cff.TaskEmitter
 			ran     cff.AtomicBool
 			run     func(context.Context) error
 			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
 		})
+		task0.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/docs/ex/get-started/flow/main.go",
+			Line:   53,
+			Column: 12,
+		}
 		task0.emitter = cff.NopTaskEmitter()
 		task0.run = func(ctx context.Context) (err error) {
 			taskEmitter := task0.emitter
 			startTime := time.Now()
 			defer func() {
+				task0.outcome.Finish(err)
 				if task0.ran.Load() {
 					taskEmitter.TaskDone(ctx, time.Since(startTime))
 				}
@@ -154,8 +166,10 @@ func main() {
 
 			defer task0.ran.Store(true)
 
-			v2, err = _53_12(v1)
-
+			err = cff.Intercept(ctx, interceptor, task0.outcome.Info, func(ctx context.Context) (err error) {
+				v2, err = _53_12(v1)
+				return
+			})
 			if err != nil {
 				taskEmitter.TaskError(ctx, err)
 				return err
@@ -175,17 +189,29 @@ func main() {
 		var (
 			v3 *Driver
 		)
+
 		task1 := new(struct {
 			emitter cff.TaskEmitter
 			ran     cff.AtomicBool
 			run     func(context.Context) error
 			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
 		})
+		task1.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/docs/ex/get-started/flow/main.go",
+			Line:   59,
+			Column: 12,
+		}
+		task1.outcome.Dependencies = []*cff.TaskOutcome{
+			&task0.outcome,
+		}
 		task1.emitter = cff.NopTaskEmitter()
 		task1.run = func(ctx context.Context) (err error) {
 			taskEmitter := task1.emitter
 			startTime := time.Now()
 			defer func() {
+				task1.outcome.Finish(err)
 				if task1.ran.Load() {
 					taskEmitter.TaskDone(ctx, time.Since(startTime))
 				}
@@ -204,8 +230,10 @@ func main() {
 
 			defer task1.ran.Store(true)
 
-			v3, err = _59_12(v2)
-
+			err = cff.Intercept(ctx, interceptor, task1.outcome.Info, func(ctx context.Context) (err error) {
+				v3, err = _59_12(v2)
+				return
+			})
 			if err != nil {
 				taskEmitter.TaskError(ctx, err)
 				return err
@@ -228,17 +256,29 @@ func main() {
 		var (
 			v4 *Rider
 		)
+
 		task2 := new(struct {
 			emitter cff.TaskEmitter
 			ran     cff.AtomicBool
 			run     func(context.Context) error
 			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
 		})
+		task2.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/docs/ex/get-started/flow/main.go",
+			Line:   64,
+			Column: 12,
+		}
+		task2.outcome.Dependencies = []*cff.TaskOutcome{
+			&task0.outcome,
+		}
 		task2.emitter = cff.NopTaskEmitter()
 		task2.run = func(ctx context.Context) (err error) {
 			taskEmitter := task2.emitter
 			startTime := time.Now()
 			defer func() {
+				task2.outcome.Finish(err)
 				if task2.ran.Load() {
 					taskEmitter.TaskDone(ctx, time.Since(startTime))
 				}
@@ -257,8 +297,10 @@ func main() {
 
 			defer task2.ran.Store(true)
 
-			v4, err = _64_12(v2)
-
+			err = cff.Intercept(ctx, interceptor, task2.outcome.Info, func(ctx context.Context) (err error) {
+				v4, err = _64_12(v2)
+				return
+			})
 			if err != nil {
 				taskEmitter.TaskError(ctx, err)
 				return err
@@ -281,17 +323,29 @@ func main() {
 		var (
 			v5 *Location
 		)
+
 		task3 := new(struct {
 			emitter cff.TaskEmitter
 			ran     cff.AtomicBool
 			run     func(context.Context) error
 			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
 		})
+		task3.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/docs/ex/get-started/flow/main.go",
+			Line:   69,
+			Column: 12,
+		}
+		task3.outcome.Dependencies = []*cff.TaskOutcome{
+			&task2.outcome,
+		}
 		task3.emitter = cff.NopTaskEmitter()
 		task3.run = func(ctx context.Context) (err error) {
 			taskEmitter := task3.emitter
 			startTime := time.Now()
 			defer func() {
+				task3.outcome.Finish(err)
 				if task3.ran.Load() {
 					taskEmitter.TaskDone(ctx, time.Since(startTime))
 				}
@@ -310,8 +364,10 @@ func main() {
 
 			defer task3.ran.Store(true)
 
-			v5, err = _69_12(v4)
-
+			err = cff.Intercept(ctx, interceptor, task3.outcome.Info, func(ctx context.Context) (err error) {
+				v5, err = _69_12(v4)
+				return
+			})
 			if err != nil {
 				taskEmitter.TaskError(ctx, err)
 				return err
@@ -334,17 +390,31 @@ func main() {
 		var (
 			v6 *Response
 		)
+
 		task4 := new(struct {
 			emitter cff.TaskEmitter
 			ran     cff.AtomicBool
 			run     func(context.Context) error
 			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
 		})
+		task4.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/docs/ex/get-started/flow/main.go",
+			Line:   75,
+			Column: 12,
+		}
+		task4.outcome.Dependencies = []*cff.TaskOutcome{
+			&task2.outcome,
+			&task1.outcome,
+			&task3.outcome,
+		}
 		task4.emitter = cff.NopTaskEmitter()
 		task4.run = func(ctx context.Context) (err error) {
 			taskEmitter := task4.emitter
 			startTime := time.Now()
 			defer func() {
+				task4.outcome.Finish(err)
 				if task4.ran.Load() {
 					taskEmitter.TaskDone(ctx, time.Since(startTime))
 				}
@@ -363,9 +433,16 @@ func main() {
 
 			defer task4.ran.Store(true)
 
-			v6 = _75_12(v4, v3, v5)
-
-			taskEmitter.TaskSuccess(ctx)
+			err = cff.Intercept(ctx, interceptor, task4.outcome.Info, func(ctx context.Context) (err error) {
+				v6 = _75_12(v4, v3, v5)
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return err
+			} else {
+				taskEmitter.TaskSuccess(ctx)
+			}
 
 			return
 		}
@@ -382,6 +459,7 @@ func main() {
 
 		if err := sched.Wait(ctx); err != nil {
 			flowEmitter.FlowError(ctx, err)
+			cff.RethrowPanic(err, false)
 			return err
 		}
 
@@ -62,6 +62,10 @@ type DirectiveInfo struct {
 	Directive    DirectiveType
 	File         string
 	Line, Column int
+
+	// Parent is the directive that contains this directive, if any.
+	// For example, the Flow that contains a Subflow.
+	Parent *DirectiveInfo
 }
 
 // TaskInfo provides information to uniquely identify a task.
@@ -121,6 +125,7 @@ type TaskEmitter interface {
 	TaskErrorRecovered(context.Context, error)
 	// TaskSkipped is called when a task is skipped due to predicate or an
 	// earlier task error.
+	// The error is a *SkipReason that describes why the task was skipped.
 	TaskSkipped(context.Context, error)
 	// TaskPanic is called when a task panics.
 	TaskPanic(context.Context, interface{})
@@ -130,3 +135,15 @@ type TaskEmitter interface {
 	// TaskDone is called when a task finishes.
 	TaskDone(context.Context, time.Duration)
 }
+
+// HedgeEmitter may be implemented by a [TaskEmitter] to receive events
+// for tasks that use [Hedge].
+//
+// WARNING: Do not use this API.
+// We intend to replace it in an upcoming release.
+type HedgeEmitter interface {
+	// TaskHedged is called when a task with Hedge succeeds after starting
+	// more than one attempt.
+	// The attempt that won is numbered from 1 for the first attempt.
+	TaskHedged(ctx context.Context, attempt int)
+}
@@ -86,6 +86,17 @@ func (ts taskEmitterStack) TaskPanicRecovered(ctx context.Context, pv interface{
 	}
 }
 
+// TaskHedged is called when a task with Hedge succeeds after starting
+// more than one attempt.
+// It's forwarded only to emitters that implement HedgeEmitter.
+func (ts taskEmitterStack) TaskHedged(ctx context.Context, attempt int) {
+	for _, e := range ts {
+		if he, ok := e.(HedgeEmitter); ok {
+			he.TaskHedged(ctx, attempt)
+		}
+	}
+}
+
 // TaskDone is called when a task finishes.
 func (ts taskEmitterStack) TaskDone(ctx context.Context, d time.Duration) {
 	for _, e := range ts {
@@ -22,6 +22,9 @@ package cff
 
 import (
 	"fmt"
+
+	"go.uber.org/cff/scheduler"
+	"go.uber.org/multierr"
 )
 
 // PanicError is an error that is thrown when a task panics. It contains the value
@@ -60,3 +63,74 @@ var _ error = (*PanicError)(nil)
 func (pe *PanicError) Error() string {
 	return fmt.Sprintf("panic: %v\n%s", pe.Value, pe.Stacktrace)
 }
+
+// QuorumError is returned by a [Parallel] with [Quorum] or [FirstSuccess]
+// if not enough of its tasks succeeded.
+// Use errors.As to inspect it.
+//
+//	var qerr *cff.QuorumError
+//	if errors.As(err, &qerr) {
+//		log.Printf("only %v of %v replicas responded", qerr.Succeeded, qerr.Quorum)
+//	}
+type QuorumError = scheduler.QuorumError
+
+// PredicateError is an error that is returned when the [Predicate] of a task
+// fails with an error. It identifies the predicate by its position.
+//
+//	var predErr *cff.PredicateError
+//	if errors.As(err, &predErr) {
+//		fmt.Printf("predicate at %v:%v failed\n", predErr.File, predErr.Line)
+//	}
+type PredicateError struct {
+	// Err is the error returned by the predicate.
+	Err error
+
+	// File, Line, and Column identify the cff.Predicate call
+	// that specified the predicate.
+	File         string
+	Line, Column int
+}
+
+var _ error = (*PredicateError)(nil)
+
+func (pe *PredicateError) Error() string {
+	return fmt.Sprintf("predicate at %v:%d:%d failed: %v", pe.File, pe.Line, pe.Column, pe.Err)
+}
+
+// Unwrap returns the error returned by the predicate.
+func (pe *PredicateError) Unwrap() error {
+	return pe.Err
+}
+
+// ElementErrors holds the errors of the elements of a [Slice] or [Map]
+// that failed, keyed by their index in the slice or their key in the map.
+// Panics are recorded as a *[PanicError].
+//
+// A [SliceEnd] or [MapEnd] function that accepts ElementErrors receives
+// the errors of all elements that failed, and decides whether the
+// Parallel fails.
+//
+//	cff.Slice(
+//		func(ctx context.Context, id UserID) error {
+//			return client.Notify(ctx, id)
+//		},
+//		ids,
+//		cff.SliceEnd(func(errs cff.ElementErrors[int]) error {
+//			if len(errs) > len(ids)/2 {
+//				return fmt.Errorf("failed to notify %v users", len(errs))
+//			}
+//			return nil
+//		}),
+//	)
+type ElementErrors[K comparable] map[K]error
+
+// AppendError combines the errors of elements of a [Batch],
+// flattening them in the same way the errors of jobs are combined
+// with [ContinueOnError].
+//
+// This is intended to be used by cff's generated code.
+// Do not use directly.
+// This can change without warning.
+func AppendError(left, right error) error {
+	return multierr.Append(left, right)
+}
@@ -88,6 +88,7 @@ func (h *FooHandler) HandleFoo(ctx context.Context, req *Request) (*Response, er
 		ctx := _34_18
 		var v1 *Request = _35_14
 		emitter := cff.NopEmitter()
+		interceptor := cff.InterceptorStack()
 
 		var (
 			flowInfo = &cff.FlowInfo{
@@ -125,11 +126,13 @@ func (h *FooHandler) HandleFoo(ctx context.Context, req *Request) (*Response, er
 			ran     cff.AtomicBool
 			run     func(context.Context) error
 			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
 		}
 		defer func() {
 			for _, t := range tasks {
 				if !t.ran.Load() {
-					t.emitter.TaskSkipped(ctx, err)
+					t.emitter.TaskSkipped(ctx, t.outcome.SkipReason(ctx, err))
 				}
 			}
 		}()
@@ -139,17 +142,26 @@ func (h *FooHandler) HandleFoo(ctx context.Context, req *Request) (*Response, er
 			v2 *GetManagerRequest
 			v3 *ListUsersRequest
 		)
+
 		task0 := new(struct {
 			emitter cff.TaskEmitter
 			ran     cff.AtomicBool
 			run     func(context.Context) error
 			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
 		})
+		task0.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/examples/magic.go",
+			Line:   40,
+			Column: 4,
+		}
 		task0.emitter = cff.NopTaskEmitter()
 		task0.run = func(ctx context.Context) (err error) {
 			taskEmitter := task0.emitter
 			startTime := time.Now()
 			defer func() {
+				task0.outcome.Finish(err)
 				if task0.ran.Load() {
 					taskEmitter.TaskDone(ctx, time.Since(startTime))
 				}
@@ -168,9 +180,16 @@ func (h *FooHandler) HandleFoo(ctx context.Context, req *Request) (*Response, er
 
 			defer task0.ran.Store(true)
 
-			v2, v3 = _40_4(v1)
-
-			taskEmitter.TaskSuccess(ctx)
+			err = cff.Intercept(ctx, interceptor, task0.outcome.Info, func(ctx context.Context) (err error) {
+				v2, v3 = _40_4(v1)
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return err
+			} else {
+				taskEmitter.TaskSuccess(ctx)
+			}
 
 			return
 		}
@@ -184,17 +203,29 @@ func (h *FooHandler) HandleFoo(ctx context.Context, req *Request) (*Response, er
 		var (
 			v4 *GetManagerResponse
 		)
+
 		task1 := new(struct {
 			emitter cff.TaskEmitter
 			ran     cff.AtomicBool
 			run     func(context.Context) error
 			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
 		})
+		task1.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/examples/magic.go",
+			Line:   48,
+			Column: 4,
+		}
+		task1.outcome.Dependencies = []*cff.TaskOutcome{
+			&task0.outcome,
+		}
 		task1.emitter = cff.NopTaskEmitter()
 		task1.run = func(ctx context.Context) (err error) {
 			taskEmitter := task1.emitter
 			startTime := time.Now()
 			defer func() {
+				task1.outcome.Finish(err)
 				if task1.ran.Load() {
 					taskEmitter.TaskDone(ctx, time.Since(startTime))
 				}
@@ -213,8 +244,10 @@ func (h *FooHandler) HandleFoo(ctx context.Context, req *Request) (*Response, er
 
 			defer task1.ran.Store(true)
 
-			v4, err = _48_4(v2)
-
+			err = cff.Intercept(ctx, interceptor, task1.outcome.Info, func(ctx context.Context) (err error) {
+				v4, err = _48_4(v2)
+				return
+			})
 			if err != nil {
 				taskEmitter.TaskError(ctx, err)
 				return err
@@ -265,17 +298,29 @@ func (h *FooHandler) HandleFoo(ctx context.Context, req *Request) (*Response, er
 		var (
 			v5 *ListUsersResponse
 		)
+
 		task4 := new(struct {
 			emitter cff.TaskEmitter
 			ran     cff.AtomicBool
 			run     func(context.Context) error
 			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
 		})
+		task4.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/examples/magic.go",
+			Line:   60,
+			Column: 4,
+		}
+		task4.outcome.Dependencies = []*cff.TaskOutcome{
+			&task0.outcome,
+		}
 		task4.emitter = cff.NopTaskEmitter()
 		task4.run = func(ctx context.Context) (err error) {
 			taskEmitter := task4.emitter
 			startTime := time.Now()
 			defer func() {
+				task4.outcome.Finish(err)
 				if task4.ran.Load() {
 					taskEmitter.TaskDone(ctx, time.Since(startTime))
 				}
@@ -294,13 +339,16 @@ func (h *FooHandler) HandleFoo(ctx context.Context, req *Request) (*Response, er
 			}()
 
 			if !p0 {
+				task4.outcome.Skip(cff.SkipPredicateFalse)
 				return nil
 			}
 
 			defer task4.ran.Store(true)
 
-			v5, err = _60_4(v3)
-
+			err = cff.Intercept(ctx, interceptor, task4.outcome.Info, func(ctx context.Context) (err error) {
+				v5, err = _60_4(v3)
+				return
+			})
 			if err != nil {
 				taskEmitter.TaskErrorRecovered(ctx, err)
 				v5, err = _64_21, nil
@@ -352,17 +400,31 @@ func (h *FooHandler) HandleFoo(ctx context.Context, req *Request) (*Response, er
 		var (
 			v6 []*SendEmailRequest
 		)
+
 		task5 := new(struct {
 			emitter cff.TaskEmitter
 			ran     cff.AtomicBool
 			run     func(context.Context) error
 			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
 		})
+		task5.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/examples/magic.go",
+			Line:   67,
+			Column: 4,
+		}
+		task5.outcome.Dependencies = []*cff.TaskOutcome{
+			&task1.outcome,
+			&task4.outcome,
+			&task0.outcome,
+		}
 		task5.emitter = cff.NopTaskEmitter()
 		task5.run = func(ctx context.Context) (err error) {
 			taskEmitter := task5.emitter
 			startTime := time.Now()
 			defer func() {
+				task5.outcome.Finish(err)
 				if task5.ran.Load() {
 					taskEmitter.TaskDone(ctx, time.Since(startTime))
 				}
@@ -388,14 +450,22 @@ func (h *FooHandler) HandleFoo(ctx context.Context, req *Request) (*Response, er
 			}()
 
 			if !p1 {
+				task5.outcome.Skip(cff.SkipPredicateFalse)
 				return nil
 			}
 
 			defer task5.ran.Store(true)
 
-			v6 = _67_4(v4, v5)
-
-			taskEmitter.TaskSuccess(ctx)
+			err = cff.Intercept(ctx, interceptor, task5.outcome.Info, func(ctx context.Context) (err error) {
+				v6 = _67_4(v4, v5)
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return err
+			} else {
+				taskEmitter.TaskSuccess(ctx)
+			}
 
 			return
 		}
@@ -414,17 +484,29 @@ func (h *FooHandler) HandleFoo(ctx context.Context, req *Request) (*Response, er
 		var (
 			v7 []*SendEmailResponse
 		)
+
 		task2 := new(struct {
 			emitter cff.TaskEmitter
 			ran     cff.AtomicBool
 			run     func(context.Context) error
 			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
 		})
+		task2.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/examples/magic.go",
+			Line:   49,
+			Column: 12,
+		}
+		task2.outcome.Dependencies = []*cff.TaskOutcome{
+			&task5.outcome,
+		}
 		task2.emitter = cff.NopTaskEmitter()
 		task2.run = func(ctx context.Context) (err error) {
 			taskEmitter := task2.emitter
 			startTime := time.Now()
 			defer func() {
+				task2.outcome.Finish(err)
 				if task2.ran.Load() {
 					taskEmitter.TaskDone(ctx, time.Since(startTime))
 				}
@@ -443,8 +525,10 @@ func (h *FooHandler) HandleFoo(ctx context.Context, req *Request) (*Response, er
 
 			defer task2.ran.Store(true)
 
-			v7, err = _49_12(v6)
-
+			err = cff.Intercept(ctx, interceptor, task2.outcome.Info, func(ctx context.Context) (err error) {
+				v7, err = _49_12(v6)
+				return
+			})
 			if err != nil {
 				taskEmitter.TaskError(ctx, err)
 				return err
@@ -467,17 +551,29 @@ func (h *FooHandler) HandleFoo(ctx context.Context, req *Request) (*Response, er
 		var (
 			v8 *Response
 		)
+
 		task3 := new(struct {
 			emitter cff.TaskEmitter
 			ran     cff.AtomicBool
 			run     func(context.Context) error
 			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
 		})
+		task3.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/examples/magic.go",
+			Line:   51,
+			Column: 4,
+		}
+		task3.outcome.Dependencies = []*cff.TaskOutcome{
+			&task2.outcome,
+		}
 		task3.emitter = cff.NopTaskEmitter()
 		task3.run = func(ctx context.Context) (err error) {
 			taskEmitter := task3.emitter
 			startTime := time.Now()
 			defer func() {
+				task3.outcome.Finish(err)
 				if task3.ran.Load() {
 					taskEmitter.TaskDone(ctx, time.Since(startTime))
 				}
@@ -496,9 +592,16 @@ func (h *FooHandler) HandleFoo(ctx context.Context, req *Request) (*Response, er
 
 			defer task3.ran.Store(true)
 
-			v8 = _51_4(v7)
-
-			taskEmitter.TaskSuccess(ctx)
+			err = cff.Intercept(ctx, interceptor, task3.outcome.Info, func(ctx context.Context) (err error) {
+				v8 = _51_4(v7)
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return err
+			} else {
+				taskEmitter.TaskSuccess(ctx)
+			}
 
 			return
 		}
@@ -513,6 +616,7 @@ func (h *FooHandler) HandleFoo(ctx context.Context, req *Request) (*Response, er
 
 		if err := sched.Wait(ctx); err != nil {
 			flowEmitter.FlowError(ctx, err)
+			cff.RethrowPanic(err, false)
 			return err
 		}
 
@@ -582,9 +686,10 @@ func (h *FooHandler) HandleFoo(ctx context.Context, req *Request) (*Response, er
 		/*line magic.go:135:4*/
 		_135_4 := map[string]int{"a": 1, "b": 2, "c": 3}
 
-		/*line magic_gen.go:586*/
+		/*line magic_gen.go:690*/
 		ctx := _84_3
 		emitter := cff.NopEmitter()
+		interceptor := cff.InterceptorStack()
 
 		var (
 			parallelInfo = &cff.ParallelInfo{
@@ -630,11 +735,15 @@ func (h *FooHandler) HandleFoo(ctx context.Context, req *Request) (*Response, er
 			emitter cff.TaskEmitter
 			fn      func(context.Context) error
 			ran     cff.AtomicBool
+
+			outcome cff.TaskOutcome // reports why the task was skipped
 		}
 		defer func() {
 			for _, t := range tasks {
-				if !t.ran.Load() {
-					t.emitter.TaskSkipped(ctx, err)
+				// Tasks that are still running report their own outcome
+				// once they finish.
+				if !t.ran.Load() && !t.outcome.Running() {
+					t.emitter.TaskSkipped(ctx, t.outcome.SkipReason(ctx, err))
 				}
 			}
 		}()
@@ -644,12 +753,21 @@ func (h *FooHandler) HandleFoo(ctx context.Context, req *Request) (*Response, er
 			emitter cff.TaskEmitter
 			fn      func(context.Context) error
 			ran     cff.AtomicBool
+
+			outcome cff.TaskOutcome // reports why the task was skipped
 		})
+		task6.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/examples/magic.go",
+			Line:   88,
+			Column: 4,
+		}
 		task6.emitter = cff.NopTaskEmitter()
 		task6.fn = func(ctx context.Context) (err error) {
+			task6.outcome.Start()
 			taskEmitter := task6.emitter
 			startTime := time.Now()
 			defer func() {
+				task6.outcome.Finish(err)
 				if task6.ran.Load() {
 					taskEmitter.TaskDone(ctx, time.Since(startTime))
 				}
@@ -668,8 +786,10 @@ func (h *FooHandler) HandleFoo(ctx context.Context, req *Request) (*Response, er
 
 			defer task6.ran.Store(true)
 
-			err = _88_4(ctx)
-
+			err = cff.Intercept(ctx, interceptor, task6.outcome.Info, func(ctx context.Context) (err error) {
+				err = _88_4(ctx)
+				return
+			})
 			if err != nil {
 				taskEmitter.TaskError(ctx, err)
 				return
@@ -688,12 +808,21 @@ func (h *FooHandler) HandleFoo(ctx context.Context, req *Request) (*Response, er
 			emitter cff.TaskEmitter
 			fn      func(context.Context) error
 			ran     cff.AtomicBool
+
+			outcome cff.TaskOutcome // reports why the task was skipped
 		})
+		task7.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/examples/magic.go",
+			Line:   91,
+			Column: 4,
+		}
 		task7.emitter = cff.NopTaskEmitter()
 		task7.fn = func(ctx context.Context) (err error) {
+			task7.outcome.Start()
 			taskEmitter := task7.emitter
 			startTime := time.Now()
 			defer func() {
+				task7.outcome.Finish(err)
 				if task7.ran.Load() {
 					taskEmitter.TaskDone(ctx, time.Since(startTime))
 				}
@@ -712,8 +841,10 @@ func (h *FooHandler) HandleFoo(ctx context.Context, req *Request) (*Response, er
 
 			defer task7.ran.Store(true)
 
-			err = _91_4()
-
+			err = cff.Intercept(ctx, interceptor, task7.outcome.Info, func(ctx context.Context) (err error) {
+				err = _91_4()
+				return
+			})
 			if err != nil {
 				taskEmitter.TaskError(ctx, err)
 				return
@@ -732,12 +863,21 @@ func (h *FooHandler) HandleFoo(ctx context.Context, req *Request) (*Response, er
 			emitter cff.TaskEmitter
 			fn      func(context.Context) error
 			ran     cff.AtomicBool
+
+			outcome cff.TaskOutcome // reports why the task was skipped
 		})
+		task8.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/examples/magic.go",
+			Line:   94,
+			Column: 4,
+		}
 		task8.emitter = cff.NopTaskEmitter()
 		task8.fn = func(ctx context.Context) (err error) {
+			task8.outcome.Start()
 			taskEmitter := task8.emitter
 			startTime := time.Now()
 			defer func() {
+				task8.outcome.Finish(err)
 				if task8.ran.Load() {
 					taskEmitter.TaskDone(ctx, time.Since(startTime))
 				}
@@ -756,8 +896,10 @@ func (h *FooHandler) HandleFoo(ctx context.Context, req *Request) (*Response, er
 
 			defer task8.ran.Store(true)
 
-			err = _94_4()
-
+			err = cff.Intercept(ctx, interceptor, task8.outcome.Info, func(ctx context.Context) (err error) {
+				err = _94_4()
+				return
+			})
 			if err != nil {
 				taskEmitter.TaskError(ctx, err)
 				return
@@ -773,6 +915,12 @@ func (h *FooHandler) HandleFoo(ctx context.Context, req *Request) (*Response, er
 
 		// go.uber.org/cff/examples/magic.go:98:3
 		sliceTask9Slice := _104_4
+		sliceTask9Info := &cff.TaskInfo{
+			Name:   parallelInfo.Name,
+			File:   "go.uber.org/cff/examples/magic.go",
+			Line:   98,
+			Column: 3,
+		}
 		for idx, val := range sliceTask9Slice {
 			idx := idx
 			val := val
@@ -780,6 +928,8 @@ func (h *FooHandler) HandleFoo(ctx context.Context, req *Request) (*Response, er
 				emitter cff.TaskEmitter
 				fn      func(context.Context) error
 				ran     cff.AtomicBool
+
+				outcome cff.TaskOutcome // reports why the task was skipped
 			})
 			sliceTask9.fn = func(ctx context.Context) (err error) {
 				defer func() {
@@ -791,8 +941,10 @@ func (h *FooHandler) HandleFoo(ctx context.Context, req *Request) (*Response, er
 						}
 					}
 				}()
-				err = _99_4(ctx, idx, val)
-				return
+				return cff.Intercept(ctx, interceptor, sliceTask9Info, func(ctx context.Context) (err error) {
+					err = _99_4(ctx, idx, val)
+					return
+				})
 			}
 			sched.Enqueue(ctx, cff.Job{
 				Run: sliceTask9.fn,
@@ -801,6 +953,12 @@ func (h *FooHandler) HandleFoo(ctx context.Context, req *Request) (*Response, er
 
 		// go.uber.org/cff/examples/magic.go:106:3
 		sliceTask10Slice := _112_4
+		sliceTask10Info := &cff.TaskInfo{
+			Name:   parallelInfo.Name,
+			File:   "go.uber.org/cff/examples/magic.go",
+			Line:   106,
+			Column: 3,
+		}
 		for _, val := range sliceTask10Slice {
 
 			val := val
@@ -808,6 +966,8 @@ func (h *FooHandler) HandleFoo(ctx context.Context, req *Request) (*Response, er
 				emitter cff.TaskEmitter
 				fn      func(context.Context) error
 				ran     cff.AtomicBool
+
+				outcome cff.TaskOutcome // reports why the task was skipped
 			})
 			sliceTask10.fn = func(ctx context.Context) (err error) {
 				defer func() {
@@ -819,8 +979,10 @@ func (h *FooHandler) HandleFoo(ctx context.Context, req *Request) (*Response, er
 						}
 					}
 				}()
-				err = _107_4(ctx, val)
-				return
+				return cff.Intercept(ctx, interceptor, sliceTask10Info, func(ctx context.Context) (err error) {
+					err = _107_4(ctx, val)
+					return
+				})
 			}
 			sched.Enqueue(ctx, cff.Job{
 				Run: sliceTask10.fn,
@@ -829,6 +991,12 @@ func (h *FooHandler) HandleFoo(ctx context.Context, req *Request) (*Response, er
 
 		// go.uber.org/cff/examples/magic.go:114:3
 		sliceTask11Slice := _120_4
+		sliceTask11Info := &cff.TaskInfo{
+			Name:   parallelInfo.Name,
+			File:   "go.uber.org/cff/examples/magic.go",
+			Line:   114,
+			Column: 3,
+		}
 		for idx, val := range sliceTask11Slice {
 			idx := idx
 			val := val
@@ -836,6 +1004,8 @@ func (h *FooHandler) HandleFoo(ctx context.Context, req *Request) (*Response, er
 				emitter cff.TaskEmitter
 				fn      func(context.Context) error
 				ran     cff.AtomicBool
+
+				outcome cff.TaskOutcome // reports why the task was skipped
 			})
 			sliceTask11.fn = func(ctx context.Context) (err error) {
 				defer func() {
@@ -847,14 +1017,22 @@ func (h *FooHandler) HandleFoo(ctx context.Context, req *Request) (*Response, er
 						}
 					}
 				}()
-				err = _115_4(ctx, idx, val)
-				return
+				return cff.Intercept(ctx, interceptor, sliceTask11Info, func(ctx context.Context) (err error) {
+					err = _115_4(ctx, idx, val)
+					return
+				})
 			}
 			sched.Enqueue(ctx, cff.Job{
 				Run: sliceTask11.fn,
 			})
 		}
 
+		mapTask12Info := &cff.TaskInfo{
+			Name:   parallelInfo.Name,
+			File:   "go.uber.org/cff/examples/magic.go",
+			Line:   122,
+			Column: 3,
+		}
 		// go.uber.org/cff/examples/magic.go:122:3
 		for key, val := range _128_4 {
 			key := key
@@ -863,6 +1041,8 @@ func (h *FooHandler) HandleFoo(ctx context.Context, req *Request) (*Response, er
 				emitter cff.TaskEmitter
 				fn      func(context.Context) error
 				ran     cff.AtomicBool
+
+				outcome cff.TaskOutcome // reports why the task was skipped
 			})
 			mapTask12.fn = func(ctx context.Context) (err error) {
 				defer func() {
@@ -875,8 +1055,10 @@ func (h *FooHandler) HandleFoo(ctx context.Context, req *Request) (*Response, er
 					}
 				}()
 
-				err = _123_4(ctx, key, val)
-				return
+				return cff.Intercept(ctx, interceptor, mapTask12Info, func(ctx context.Context) (err error) {
+					err = _123_4(ctx, key, val)
+					return
+				})
 			}
 
 			sched.Enqueue(ctx, cff.Job{
@@ -884,6 +1066,12 @@ func (h *FooHandler) HandleFoo(ctx context.Context, req *Request) (*Response, er
 			})
 		}
 
+		mapTask13Info := &cff.TaskInfo{
+			Name:   parallelInfo.Name,
+			File:   "go.uber.org/cff/examples/magic.go",
+			Line:   130,
+			Column: 3,
+		}
 		// go.uber.org/cff/examples/magic.go:130:3
 		for key, val := range _135_4 {
 			key := key
@@ -892,6 +1080,8 @@ func (h *FooHandler) HandleFoo(ctx context.Context, req *Request) (*Response, er
 				emitter cff.TaskEmitter
 				fn      func(context.Context) error
 				ran     cff.AtomicBool
+
+				outcome cff.TaskOutcome // reports why the task was skipped
 			})
 			mapTask13.fn = func(ctx context.Context) (err error) {
 				defer func() {
@@ -904,8 +1094,10 @@ func (h *FooHandler) HandleFoo(ctx context.Context, req *Request) (*Response, er
 					}
 				}()
 
-				err = _131_4(ctx, key, val)
-				return
+				return cff.Intercept(ctx, interceptor, mapTask13Info, func(ctx context.Context) (err error) {
+					err = _131_4(ctx, key, val)
+					return
+				})
 			}
 
 			sched.Enqueue(ctx, cff.Job{
@@ -915,6 +1107,7 @@ func (h *FooHandler) HandleFoo(ctx context.Context, req *Request) (*Response, er
 
 		if err := sched.Wait(ctx); err != nil {
 			parallelEmitter.ParallelError(ctx, err)
+			cff.RethrowPanic(err, false)
 			return err
 		}
 		parallelEmitter.ParallelSuccess(ctx)
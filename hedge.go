@@ -0,0 +1,170 @@
+package cff
+
+import (
+	"context"
+	"runtime/debug"
+	"time"
+)
+
+// hedgeResult is the outcome of one attempt of a task with Hedge.
+type hedgeResult struct {
+	attempt int    // 1 for the first attempt
+	commit  func() // stores the results of the attempt, if any
+	err     error
+
+	panicked   bool
+	recovered  interface{}
+	stacktrace []byte // stack of the attempt that panicked
+}
+
+// HedgedPanic is the value that RunHedged panics with
+// if an attempt of the task panics.
+// It carries the stack trace of the attempt,
+// which the panic on the calling goroutine would otherwise lose.
+//
+// This is intended to be used by cff's generated code.
+// Do not use directly.
+// This can change without warning.
+type HedgedPanic struct {
+	Value      interface{} // value the attempt panicked with
+	Stacktrace []byte      // stack trace of the attempt when it panicked
+}
+
+// UnwrapHedgedPanic returns the original value and stack trace of a panic
+// recovered from RunHedged.
+// Other recovered values are returned as-is with the current stack trace,
+// which must be taken while the panic is being recovered.
+//
+// This is intended to be used by cff's generated code.
+// Do not use directly.
+// This can change without warning.
+func UnwrapHedgedPanic(recovered interface{}) (value interface{}, stacktrace []byte) {
+	if hp, ok := recovered.(*HedgedPanic); ok {
+		return hp.Value, hp.Stacktrace
+	}
+	if recovered == nil {
+		return nil, nil
+	}
+	return recovered, debug.Stack()
+}
+
+// RunHedged runs attempt, and starts another copy of it each time the given
+// duration passes without an attempt succeeding,
+// up to max attempts in total.
+// If after is not positive, all attempts start at once.
+// Each attempt receives its own context,
+// and the contexts of all attempts are cancelled when RunHedged returns.
+//
+// The first attempt to succeed wins:
+// RunHedged calls the commit function returned by it, if any, and returns nil.
+// If all attempts that started fail, RunHedged returns the error of the
+// first one to fail without starting more attempts.
+// If an attempt panics, RunHedged panics on the calling goroutine
+// with a *HedgedPanic holding the value and the stack trace of the attempt.
+//
+// If more than one attempt started, and the emitter implements
+// HedgeEmitter, the attempt that won is reported to it with TaskHedged.
+//
+// This is intended to be used by cff's generated code.
+// Do not use directly.
+// This can change without warning.
+func RunHedged(
+	ctx context.Context,
+	after time.Duration,
+	max int,
+	emitter TaskEmitter,
+	attempt func(context.Context) (commit func(), err error),
+) error {
+	if max < 1 {
+		max = 1
+	}
+
+	hedgeCtx, cancel := context.WithCancel(ctx)
+	defer cancel() // cancels attempts that lost
+
+	// Buffered so that attempts that lost never block.
+	results := make(chan hedgeResult, max)
+	started := 0
+	start := func() {
+		started++
+		go runHedgeAttempt(hedgeCtx, started, attempt, results)
+	}
+
+	start()
+
+	// hedgec is nil once no more attempts may start.
+	var hedgec <-chan time.Time
+	timer := time.NewTimer(after)
+	defer timer.Stop()
+	if max > 1 {
+		hedgec = timer.C
+	}
+
+	var (
+		failed   int
+		firstErr error
+	)
+	for {
+		select {
+		case <-hedgec:
+			if ctx.Err() != nil {
+				// New attempts would fail right away.
+				hedgec = nil
+				continue
+			}
+			start()
+			if started < max {
+				timer.Reset(after)
+			} else {
+				hedgec = nil
+			}
+
+		case r := <-results:
+			if r.panicked {
+				panic(&HedgedPanic{Value: r.recovered, Stacktrace: r.stacktrace})
+			}
+
+			if r.err == nil {
+				if r.commit != nil {
+					r.commit()
+				}
+				if he, ok := emitter.(HedgeEmitter); ok && started > 1 {
+					he.TaskHedged(ctx, r.attempt)
+				}
+				return nil
+			}
+
+			if firstErr == nil {
+				firstErr = r.err
+			}
+			failed++
+			if failed == started {
+				return firstErr
+			}
+		}
+	}
+}
+
+// runHedgeAttempt runs the given attempt of RunHedged with its own context,
+// and posts the outcome to results.
+func runHedgeAttempt(
+	ctx context.Context,
+	n int,
+	attempt func(context.Context) (func(), error),
+	results chan<- hedgeResult,
+) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	r := hedgeResult{attempt: n}
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			r.panicked = true
+			r.recovered = recovered
+			r.stacktrace = debug.Stack()
+		}
+		results <- r
+	}()
+
+	r.commit, r.err = attempt(ctx)
+}
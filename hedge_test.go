@@ -0,0 +1,155 @@
+package cff
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// hedgeEmitter records the attempts reported to TaskHedged.
+type hedgeEmitter struct {
+	TaskEmitter
+
+	winners []int
+}
+
+func newHedgeEmitter() *hedgeEmitter {
+	return &hedgeEmitter{TaskEmitter: NopTaskEmitter()}
+}
+
+func (e *hedgeEmitter) TaskHedged(_ context.Context, attempt int) {
+	e.winners = append(e.winners, attempt)
+}
+
+func TestRunHedged(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("first attempt wins", func(t *testing.T) {
+		e := newHedgeEmitter()
+		var got int
+		err := RunHedged(ctx, time.Hour, 3, e, func(context.Context) (func(), error) {
+			return func() { got = 42 }, nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 42, got)
+		assert.Empty(t, e.winners, "no attempts were hedged")
+	})
+
+	t.Run("hedge wins", func(t *testing.T) {
+		e := newHedgeEmitter()
+		var (
+			attempts  atomic.Int32
+			cancelled = make(chan struct{})
+			got       int
+		)
+		err := RunHedged(ctx, time.Millisecond, 2, e, func(ctx context.Context) (func(), error) {
+			n := int(attempts.Add(1))
+			if n == 1 {
+				// The first attempt is slow and cancelled once
+				// the second one wins.
+				<-ctx.Done()
+				close(cancelled)
+				return func() { got = n }, ctx.Err()
+			}
+			return func() { got = n }, nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 2, got)
+		assert.Equal(t, []int{2}, e.winners)
+
+		select {
+		case <-cancelled:
+		case <-time.After(time.Second):
+			t.Fatal("first attempt was not cancelled")
+		}
+	})
+
+	t.Run("emitter without TaskHedged", func(t *testing.T) {
+		var attempts atomic.Int32
+		err := RunHedged(ctx, time.Millisecond, 2, NopTaskEmitter(), func(ctx context.Context) (func(), error) {
+			if attempts.Add(1) == 1 {
+				<-ctx.Done()
+				return nil, ctx.Err()
+			}
+			return nil, nil
+		})
+		require.NoError(t, err)
+	})
+
+	t.Run("max attempts", func(t *testing.T) {
+		e := newHedgeEmitter()
+		var attempts atomic.Int32
+		err := RunHedged(ctx, 0, 3, e, func(ctx context.Context) (func(), error) {
+			if attempts.Add(1) < 3 {
+				<-ctx.Done()
+				return nil, ctx.Err()
+			}
+			return nil, nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, int32(3), attempts.Load())
+		assert.Len(t, e.winners, 1, "attempts may start in any order")
+	})
+
+	t.Run("all attempts fail", func(t *testing.T) {
+		e := newHedgeEmitter()
+		var attempts atomic.Int32
+		lastStarted := make(chan struct{})
+		err := RunHedged(ctx, time.Millisecond, 3, e, func(context.Context) (func(), error) {
+			switch attempts.Add(1) {
+			case 1:
+				// Fails last so that all attempts start.
+				<-lastStarted
+				return nil, errors.New("great sadness")
+			case 3:
+				close(lastStarted)
+			}
+			return nil, errors.New("more sadness")
+		})
+		assert.EqualError(t, err, "more sadness", "must return the first error")
+		assert.Equal(t, int32(3), attempts.Load())
+		assert.Empty(t, e.winners)
+	})
+
+	t.Run("panic", func(t *testing.T) {
+		var recovered interface{}
+		func() {
+			defer func() { recovered = recover() }()
+			_ = RunHedged(ctx, time.Hour, 2, NopTaskEmitter(), func(context.Context) (func(), error) {
+				panicInAttempt()
+				return nil, nil
+			})
+		}()
+
+		require.IsType(t, &HedgedPanic{}, recovered)
+		value, stack := UnwrapHedgedPanic(recovered)
+		assert.Equal(t, "great sadness", value)
+		assert.Contains(t, string(stack), "panicInAttempt",
+			"stack must point at the attempt that panicked")
+	})
+}
+
+func TestUnwrapHedgedPanic(t *testing.T) {
+	t.Run("nil", func(t *testing.T) {
+		value, stack := UnwrapHedgedPanic(nil)
+		assert.Nil(t, value)
+		assert.Nil(t, stack)
+	})
+
+	t.Run("other value", func(t *testing.T) {
+		value, stack := UnwrapHedgedPanic("great sadness")
+		assert.Equal(t, "great sadness", value)
+		assert.NotEmpty(t, stack)
+	})
+}
+
+// panicInAttempt panics in an attempt of RunHedged.
+// It's a named function to be found in stack traces.
+func panicInAttempt() {
+	panic("great sadness")
+}
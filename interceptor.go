@@ -0,0 +1,160 @@
+package cff
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+)
+
+// Interceptor wraps the invocation of a task of a [Flow] or [Parallel].
+// It receives the context that the task would run with,
+// information about the task, and next, which runs the task.
+//
+// An Interceptor may run code before and after the task,
+// replace the context passed to it, change the error it returns,
+// or not run it at all.
+//
+//	func withRequestID(ctx context.Context, info *cff.TaskInfo, next func(context.Context) error) error {
+//		ctx = context.WithValue(ctx, requestIDKey, newRequestID())
+//		return next(ctx)
+//	}
+//
+// If an Interceptor returns an error, the task fails with that error,
+// even if the task itself doesn't return errors.
+// next returns nil for such tasks.
+//
+// Install interceptors for a Flow or Parallel with [WithInterceptor],
+// or for all of them with [RegisterInterceptor].
+// Unlike emitters, which only observe tasks,
+// interceptors may change how the task runs.
+type Interceptor func(ctx context.Context, info *TaskInfo, next func(context.Context) error) error
+
+type interceptorStack []Interceptor
+
+// InterceptorStack combines multiple interceptors together into one.
+//
+// Interceptors are applied in the order given:
+// the first interceptor is the outermost,
+// and the last interceptor calls the task.
+// Nil interceptors are ignored.
+func InterceptorStack(interceptors ...Interceptor) Interceptor {
+	stack := make(interceptorStack, 0, len(interceptors))
+	for _, i := range interceptors {
+		if i != nil {
+			stack = append(stack, i)
+		}
+	}
+
+	switch len(stack) {
+	case 0:
+		return nil
+	case 1:
+		return stack[0]
+	default:
+		return stack.intercept
+	}
+}
+
+func (is interceptorStack) intercept(ctx context.Context, info *TaskInfo, next func(context.Context) error) error {
+	if len(is) == 0 {
+		return next(ctx)
+	}
+	return is[0](ctx, info, func(ctx context.Context) error {
+		return is[1:].intercept(ctx, info, next)
+	})
+}
+
+// interceptorRegistry holds the interceptors installed with
+// RegisterInterceptor.
+type interceptorRegistry struct {
+	mu      sync.Mutex
+	entries []*Interceptor // in order of registration
+
+	// stack combines all entries.
+	// It's read without holding mu.
+	stack atomic.Pointer[Interceptor]
+}
+
+var _globalInterceptors interceptorRegistry
+
+// RegisterInterceptor installs an interceptor for all tasks of all Flows
+// and Parallels in the program.
+// It returns a function that removes the interceptor.
+//
+// Interceptors installed with RegisterInterceptor wrap the interceptors
+// passed to [WithInterceptor].
+// They're applied in the order they were installed,
+// the first being the outermost.
+//
+// Use RegisterInterceptor during program initialization.
+// It's safe to call concurrently with running Flows and Parallels,
+// but tasks that are already running are not affected.
+func RegisterInterceptor(i Interceptor) (unregister func()) {
+	if i == nil {
+		return func() {}
+	}
+	return _globalInterceptors.add(i)
+}
+
+func (r *interceptorRegistry) add(i Interceptor) (remove func()) {
+	entry := &i
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.entries = append(r.entries, entry)
+	r.update()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() { r.remove(entry) })
+	}
+}
+
+func (r *interceptorRegistry) remove(entry *Interceptor) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for idx, e := range r.entries {
+		if e == entry {
+			r.entries = append(r.entries[:idx:idx], r.entries[idx+1:]...)
+			break
+		}
+	}
+	r.update()
+}
+
+// update rebuilds the stack of interceptors.
+// It must be called with mu held.
+func (r *interceptorRegistry) update() {
+	interceptors := make([]Interceptor, len(r.entries))
+	for idx, e := range r.entries {
+		interceptors[idx] = *e
+	}
+
+	if stack := InterceptorStack(interceptors...); stack != nil {
+		r.stack.Store(&stack)
+	} else {
+		r.stack.Store(nil)
+	}
+}
+
+// Intercept runs next for the given task with the interceptors installed
+// with RegisterInterceptor, followed by the given interceptor, if any.
+//
+// This is intended to be used by cff's generated code.
+// Do not use directly.
+// This can change without warning.
+func Intercept(ctx context.Context, interceptor Interceptor, info *TaskInfo, next func(context.Context) error) error {
+	if global := _globalInterceptors.stack.Load(); global != nil {
+		if interceptor == nil {
+			interceptor = *global
+		} else {
+			interceptor = interceptorStack{*global, interceptor}.intercept
+		}
+	}
+
+	if interceptor == nil {
+		return next(ctx)
+	}
+	return interceptor(ctx, info, next)
+}
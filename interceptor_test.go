@@ -0,0 +1,133 @@
+package cff
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type interceptorKey struct{}
+
+// recordInterceptor returns an interceptor that appends its name to calls
+// around the task, and adds it to the context of the task.
+func recordInterceptor(name string, calls *[]string) Interceptor {
+	return func(ctx context.Context, info *TaskInfo, next func(context.Context) error) error {
+		*calls = append(*calls, name+" before "+info.Name)
+		prev, _ := ctx.Value(interceptorKey{}).(string)
+		err := next(context.WithValue(ctx, interceptorKey{}, prev+name))
+		*calls = append(*calls, name+" after")
+		return err
+	}
+}
+
+func TestInterceptorStack(t *testing.T) {
+	t.Run("empty", func(t *testing.T) {
+		assert.Nil(t, InterceptorStack())
+		assert.Nil(t, InterceptorStack(nil, nil))
+	})
+
+	t.Run("order", func(t *testing.T) {
+		var calls []string
+		stack := InterceptorStack(
+			recordInterceptor("a", &calls),
+			nil,
+			recordInterceptor("b", &calls),
+			recordInterceptor("c", &calls),
+		)
+
+		err := stack(context.Background(), &TaskInfo{Name: "foo"}, func(ctx context.Context) error {
+			calls = append(calls, "task with "+ctx.Value(interceptorKey{}).(string))
+			return errors.New("great sadness")
+		})
+		assert.EqualError(t, err, "great sadness")
+		assert.Equal(t, []string{
+			"a before foo",
+			"b before foo",
+			"c before foo",
+			"task with abc",
+			"c after",
+			"b after",
+			"a after",
+		}, calls)
+	})
+
+	t.Run("short circuit", func(t *testing.T) {
+		var ran bool
+		stack := InterceptorStack(
+			func(context.Context, *TaskInfo, func(context.Context) error) error {
+				return errors.New("denied")
+			},
+			func(ctx context.Context, _ *TaskInfo, next func(context.Context) error) error {
+				ran = true
+				return next(ctx)
+			},
+		)
+
+		err := stack(context.Background(), &TaskInfo{}, func(context.Context) error {
+			ran = true
+			return nil
+		})
+		assert.EqualError(t, err, "denied")
+		assert.False(t, ran)
+	})
+}
+
+func TestIntercept(t *testing.T) {
+	info := &TaskInfo{Name: "foo"}
+
+	t.Run("none", func(t *testing.T) {
+		var ran bool
+		err := Intercept(context.Background(), nil, info, func(context.Context) error {
+			ran = true
+			return nil
+		})
+		require.NoError(t, err)
+		assert.True(t, ran)
+	})
+
+	t.Run("global", func(t *testing.T) {
+		var calls []string
+		unregisterA := RegisterInterceptor(recordInterceptor("a", &calls))
+		defer unregisterA()
+		unregisterB := RegisterInterceptor(recordInterceptor("b", &calls))
+		defer unregisterB()
+
+		err := Intercept(context.Background(), recordInterceptor("c", &calls), info, func(ctx context.Context) error {
+			calls = append(calls, "task with "+ctx.Value(interceptorKey{}).(string))
+			return nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []string{
+			"a before foo",
+			"b before foo",
+			"c before foo",
+			"task with abc",
+			"c after",
+			"b after",
+			"a after",
+		}, calls)
+
+		calls = nil
+		unregisterA()
+		unregisterA() // no-op
+		err = Intercept(context.Background(), nil, info, func(ctx context.Context) error {
+			calls = append(calls, "task with "+ctx.Value(interceptorKey{}).(string))
+			return nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"b before foo", "task with b", "b after"}, calls)
+	})
+
+	t.Run("unregistered", func(t *testing.T) {
+		unregister := RegisterInterceptor(func(context.Context, *TaskInfo, func(context.Context) error) error {
+			return errors.New("great sadness")
+		})
+		unregister()
+
+		err := Intercept(context.Background(), nil, info, func(context.Context) error { return nil })
+		assert.NoError(t, err)
+	})
+}
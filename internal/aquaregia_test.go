@@ -37,6 +37,11 @@ var codeGenerateFailCases = map[string][]errorCase{
 			ErrorMatches: "type string already provided at",
 			TestFuncs:    []string{"AlreadyProvidedTaskParam"},
 		},
+		{
+			File:         "already-provided.go",
+			ErrorMatches: `type string already provided at .*\n\t.*already-provided.go:\d+:\d+: type string first provided here\n\t.*declare a distinct named type for each value, e.g. 'type myValue string'`,
+			TestFuncs:    []string{"AlreadyProvidedTaskParam"},
+		},
 		{
 			File:         "already-provided.go",
 			ErrorMatches: "type int already provided to cff.Params at",
@@ -67,11 +72,6 @@ var codeGenerateFailCases = map[string][]errorCase{
 			ErrorMatches: "expected a function call, got identifier",
 			TestFuncs:    []string{"FlowArgumentsCallExpression"},
 		},
-		{
-			File:         "cff-flow-arguments.go",
-			ErrorMatches: `"ContinueOnError" is an invalid cff.Flow Option`,
-			TestFuncs:    []string{"DisallowContinueOnError"},
-		},
 		{
 			File:         "cff-flow-arguments.go",
 			ErrorMatches: `"Slice" is an invalid cff.Flow Option`,
@@ -198,11 +198,226 @@ var codeGenerateFailCases = map[string][]errorCase{
 			TestFuncs:    []string{"MissingCffLoggerAndMetrics"},
 		},
 
+		{
+			File:         "assignable.go",
+			ErrorMatches: `multiple providers are assignable to io.Reader\n\t.*: \*bytes.Buffer is provided here\n\t.*: \*strings.Reader is provided here`,
+			TestFuncs:    []string{"AmbiguousAssignable"},
+		},
+		{
+			File:         "assignable.go",
+			ErrorMatches: `"AllowAssignable" is an invalid cff.Parallel Option`,
+			TestFuncs:    []string{"ParallelAllowAssignable"},
+		},
+		{
+			File:         "inout.go",
+			ErrorMatches: `field Inner of cff.In struct .*nestedParams may not be a cff.In or cff.Out`,
+			TestFuncs:    []string{"NestedInParams"},
+		},
+		{
+			File:         "inout.go",
+			ErrorMatches: `cff.In and cff.Out are only supported by cff.Flow tasks`,
+			TestFuncs:    []string{"SliceInParams"},
+		},
+		{
+			File:         "optional.go",
+			ErrorMatches: `cff.Optional cannot be used with cff.FallbackWith`,
+			TestFuncs:    []string{"OptionalWithFallback"},
+		},
+		{
+			File:         "optional.go",
+			ErrorMatches: `cff.Optional is only supported by cff.Flow tasks`,
+			TestFuncs:    []string{"ParallelOptional"},
+		},
+		{
+			File:         "switch.go",
+			ErrorMatches: `cff.Switch requires at least one cff.Case`,
+			TestFuncs:    []string{"SwitchWithoutCase"},
+		},
+		{
+			File:         "switch.go",
+			ErrorMatches: `cff.Default must be the last argument of cff.Switch`,
+			TestFuncs:    []string{"SwitchDefaultNotLast"},
+		},
+		{
+			File:         "switch.go",
+			ErrorMatches: `all tasks of cff.Switch must produce the same types: expected \[string\], got \[string int\]`,
+			TestFuncs:    []string{"SwitchIncompatibleTasks"},
+		},
+		{
+			File:         "switch.go",
+			ErrorMatches: `cff.Predicate cannot be used with a task of cff.Switch: use cff.Case instead`,
+			TestFuncs:    []string{"SwitchCaseWithPredicate"},
+		},
+		{
+			File:         "switch.go",
+			ErrorMatches: `cff.Invoke cannot be used with a task of cff.Switch`,
+			TestFuncs:    []string{"SwitchCaseWithInvoke"},
+		},
+		{
+			File:         "switch.go",
+			ErrorMatches: `"Switch" is an invalid cff.Parallel Option`,
+			TestFuncs:    []string{"ParallelSwitch"},
+		},
+		{
+			File:         "collect.go",
+			ErrorMatches: `cff.Collect cannot be used with cff.Invoke`,
+			TestFuncs:    []string{"CollectWithInvoke"},
+		},
+		{
+			File:         "collect.go",
+			ErrorMatches: `unused output type \[\]int`,
+			TestFuncs:    []string{"CollectUnused", "CollectNotProvided"},
+		},
+		{
+			File:         "collect.go",
+			ErrorMatches: `no provider found for int`,
+			TestFuncs:    []string{"CollectNotProvided"},
+		},
+		{
+			File:         "collect.go",
+			ErrorMatches: `type \[\]int already provided at`,
+			TestFuncs:    []string{"CollectAlreadyProvided"},
+		},
+		{
+			File:         "collect.go",
+			ErrorMatches: `cff.Collect cannot be used with a task of cff.Switch`,
+			TestFuncs:    []string{"CollectInSwitch"},
+		},
+		{
+			File:         "collect.go",
+			ErrorMatches: `cff.Collect is only supported by cff.Flow tasks`,
+			TestFuncs:    []string{"ParallelCollect"},
+		},
+		{
+			File:         "after.go",
+			ErrorMatches: `cff.TaskRef ref is not attached to any task: use cff.Ref`,
+			TestFuncs:    []string{"AfterUnattachedRef"},
+		},
+		{
+			File:         "after.go",
+			ErrorMatches: `no task is instrumented with the name "bar"`,
+			TestFuncs:    []string{"AfterUnknownName"},
+		},
+		{
+			File:         "after.go",
+			ErrorMatches: `more than one task is instrumented with the name "foo": use cff.Ref`,
+			TestFuncs:    []string{"AfterAmbiguousName"},
+		},
+		{
+			File:         "after.go",
+			ErrorMatches: `cff.After expects a cff.TaskRef or a constant instrumentation name, got string`,
+			TestFuncs:    []string{"AfterNotConstant"},
+		},
+		{
+			File:         "after.go",
+			ErrorMatches: `cff.TaskRef ref is already attached to a task at`,
+			TestFuncs:    []string{"RefAttachedTwice"},
+		},
+		{
+			File:         "after.go",
+			ErrorMatches: `cff.Ref expects a pointer to a cff.TaskRef variable`,
+			TestFuncs:    []string{"RefNotPointer"},
+		},
+		{
+			File:         "after.go",
+			ErrorMatches: `a task cannot run after itself`,
+			TestFuncs:    []string{"AfterSelf"},
+		},
+		{
+			File:         "after.go",
+			ErrorMatches: `cycle detected: need to run \[func\(int\) string\] before a task that uses cff.After`,
+			TestFuncs:    []string{"AfterCycle"},
+		},
+		{
+			File:         "after.go",
+			ErrorMatches: `cff.After cannot be used with a task of cff.Switch`,
+			TestFuncs:    []string{"AfterInSwitch"},
+		},
+		{
+			File:         "after.go",
+			ErrorMatches: `cff.Ref is only supported by cff.Flow tasks`,
+			TestFuncs:    []string{"ParallelAfter"},
+		},
+		{
+			File:         "bundle.go",
+			ErrorMatches: `cff.Bundle may only contain cff.Task and cff.Params, got cff.Concurrency`,
+			TestFuncs:    []string{"UseInvalidBundle"},
+		},
+		{
+			File:         "bundle.go",
+			ErrorMatches: `cff.Use expects a package-level variable initialized with cff.Bundle, got identifier`,
+			TestFuncs:    []string{"UseNotBundle"},
+		},
+		{
+			File:         "bundle.go",
+			ErrorMatches: `cff.Bundle may only be used to initialize a package-level variable`,
+			TestFuncs:    []string{"LocalBundle"},
+		},
+		{
+			File:         "bundle.go",
+			ErrorMatches: `"Use" is an invalid cff.Parallel Option`,
+			TestFuncs:    []string{"ParallelUse"},
+		},
+		{
+			File:         "subflow.go",
+			ErrorMatches: `cff.Subflow cannot be nested`,
+			TestFuncs:    []string{"NestedSubflow"},
+		},
+		{
+			File:         "subflow.go",
+			ErrorMatches: `cff.Params cannot be used in cff.Subflow`,
+			TestFuncs:    []string{"SubflowInvalidOption"},
+		},
+		{
+			File:         "subflow.go",
+			ErrorMatches: `cff.Subflow expects at least one cff.Task`,
+			TestFuncs:    []string{"EmptySubflow"},
+		},
+		{
+			File:         "subflow.go",
+			ErrorMatches: `"Subflow" is an invalid cff.Parallel Option`,
+			TestFuncs:    []string{"SubflowInParallel"},
+		},
+		{
+			File:         "subflow.go",
+			ErrorMatches: `cff.WithInterceptor cannot be used in cff.Subflow`,
+			TestFuncs:    []string{"SubflowInterceptor"},
+		},
+		{
+			File:         "lazy.go",
+			ErrorMatches: `unused input type int`,
+			TestFuncs:    []string{"LazyUnusedParam"},
+		},
+		{
+			File:         "lazy.go",
+			ErrorMatches: `"Lazy" is an invalid cff.Parallel Option`,
+			TestFuncs:    []string{"ParallelLazy"},
+		},
+		{
+			File:         "conditional.go",
+			ErrorMatches: `a function that returns go.uber.org/cff.Maybe\[string\] cannot return other values`,
+			TestFuncs:    []string{"MaybeWithOtherResults"},
+		},
 		{
 			File:         "missing-provider.go",
 			ErrorMatches: "no provider found for float64",
 			TestFuncs:    []string{"MissingProvider"},
 		},
+		{
+			File:         "missing-provider.go",
+			ErrorMatches: `no provider found for bytes.Buffer\n\t.*did you mean \*bytes.Buffer\? it is provided here and is a pointer to bytes.Buffer`,
+			TestFuncs:    []string{"MissingProviderPointer"},
+		},
+		{
+			File:         "missing-provider.go",
+			ErrorMatches: `no provider found for io.Reader\n\t.*did you mean \*bytes.Buffer\? it is provided here and is assignable to io.Reader`,
+			TestFuncs:    []string{"MissingProviderAssignable"},
+		},
+		{
+			File:         "missing-provider.go",
+			ErrorMatches: `no provider found for bytes.Buffer\n\t.*did you mean .*bad-inputs.Buffer\? it is provided here and has the same name`,
+			TestFuncs:    []string{"MissingProviderSameName"},
+		},
 		{
 			File:         "nonpointer-result.go",
 			ErrorMatches: "invalid parameter to cff.Results: expected pointer, got bool",
@@ -216,16 +431,15 @@ var codeGenerateFailCases = map[string][]errorCase{
 				"ParallelInvalidFuncVar",
 				"ParallelTaskInvalidParamsType",
 				"ParallelTaskInvalidParamsMultiple",
-				"ParallelSliceEndTooManyArguments",
-				"ParallelSliceEndWithInvalidArgument",
 			},
 		},
 		{
 			File:         "parallel.go",
-			ErrorMatches: `"cff.SliceEnd" is an invalid option when "ContinueOnError" is used`,
+			ErrorMatches: `SliceEnd functions may accept only a context.Context and a cff.ElementErrors\[int\]`,
 			TestFuncs: []string{
-				"ParallelSliceEndWithContinueOnError",
-				"ParallelSliceEndWithContinueOnErrorAndInstrument",
+				"ParallelSliceEndTooManyArguments",
+				"ParallelSliceEndWithInvalidArgument",
+				"ParallelSliceEndWithWrongElementErrors",
 			},
 		},
 		{
@@ -260,6 +474,11 @@ var codeGenerateFailCases = map[string][]errorCase{
 			ErrorMatches: "cff.Slice accepts at most one cff.SliceEnd option",
 			TestFuncs:    []string{"ParallelSliceWithTwoSliceEnds"},
 		},
+		{
+			File:         "parallel.go",
+			ErrorMatches: "cff.Slice accepts at most one cff.Batch option",
+			TestFuncs:    []string{"ParallelSliceWithTwoBatches"},
+		},
 		{
 			File:         "parallel.go",
 			ErrorMatches: `"InstrumentFlow" is an invalid cff.Parallel Option`,
@@ -327,6 +546,181 @@ var codeGenerateFailCases = map[string][]errorCase{
 			ErrorMatches: "the function must return a single boolean result",
 			TestFuncs:    []string{"PredicateReturnsNonbool", "PredicateReturnsMultipleValues"},
 		},
+		{
+			File:         "predicate-error.go",
+			ErrorMatches: "cff.PredicateOnError requires cff.Predicate",
+			TestFuncs:    []string{"PredicateOnErrorWithoutPredicate"},
+		},
+		{
+			File:         "predicate-error.go",
+			ErrorMatches: "cff.PredicateOnError requires a predicate that returns an error",
+			TestFuncs:    []string{"PredicateOnErrorWithoutError", "PredicateOnErrorParallel"},
+		},
+		{
+			File:         "predicate-error.go",
+			ErrorMatches: "cff.PredicateOnError cannot be used with cff.Default",
+			TestFuncs:    []string{"PredicateOnErrorDefault"},
+		},
+		{
+			File:         "parallel-options.go",
+			ErrorMatches: "predicates of cff.Parallel tasks may only accept a context.Context",
+			TestFuncs:    []string{"ParallelPredicateParams"},
+		},
+		{
+			File:         "parallel-options.go",
+			ErrorMatches: "cff.FallbackWith must produce the same number of results as the task: expected 0, got 1",
+			TestFuncs:    []string{"ParallelFallbackWithValues"},
+		},
+		{
+			File:         "parallel-options.go",
+			ErrorMatches: "Task must return an error for FallbackWith to be used",
+			TestFuncs:    []string{"ParallelFallbackWithoutError"},
+		},
+		{
+			File:         "parallel-options.go",
+			ErrorMatches: "cff.PredicateOnError requires cff.Predicate",
+			TestFuncs:    []string{"ParallelPredicateOnErrorWithoutPredicate"},
+		},
+		{
+			File:         "parallel-options.go",
+			ErrorMatches: "SliceFallback functions must accept the slice index \\(optional\\), a slice element of type string, and an error",
+			TestFuncs:    []string{"SliceFallbackWrongElement"},
+		},
+		{
+			File:         "parallel-options.go",
+			ErrorMatches: "SliceFallback functions must not return any values",
+			TestFuncs:    []string{"SliceFallbackResults"},
+		},
+		{
+			File:         "parallel-options.go",
+			ErrorMatches: "cff.Slice accepts at most one cff.SliceFallback option",
+			TestFuncs:    []string{"SliceFallbackMultiple"},
+		},
+		{
+			File:         "parallel-options.go",
+			ErrorMatches: "MapFallback functions must accept a key of type string, a value of type int, and an error",
+			TestFuncs:    []string{"MapFallbackMissingError"},
+		},
+		{
+			File:         "rate-limit.go",
+			ErrorMatches: `"RateLimit" is an invalid cff.Flow Option`,
+			TestFuncs:    []string{"RateLimitFlow"},
+		},
+		{
+			File:         "rate-limit.go",
+			ErrorMatches: "cff.RateLimit cannot be used with a task of cff.Switch",
+			TestFuncs:    []string{"RateLimitSwitch"},
+		},
+		{
+			File:         "group.go",
+			ErrorMatches: "cff.Group expects a constant string name",
+			TestFuncs:    []string{"GroupNameNotConstant"},
+		},
+		{
+			File:         "group.go",
+			ErrorMatches: "cff.Group \"db\" is already declared at",
+			TestFuncs:    []string{"GroupDeclaredTwice"},
+		},
+		{
+			File:         "group.go",
+			ErrorMatches: "no cff.Group is declared with the name \"cache\"",
+			TestFuncs:    []string{"InGroupUnknown"},
+		},
+		{
+			File:         "group.go",
+			ErrorMatches: "a task may be added to only one cff.Group",
+			TestFuncs:    []string{"InGroupTwice"},
+		},
+		{
+			File:         "group.go",
+			ErrorMatches: `"Group" is an invalid cff.Parallel Option`,
+			TestFuncs:    []string{"GroupParallel"},
+		},
+		{
+			File:         "group.go",
+			ErrorMatches: "cff.InGroup is only supported by cff.Flow tasks",
+			TestFuncs:    []string{"InGroupParallel"},
+		},
+		{
+			File:         "group.go",
+			ErrorMatches: "cff.InGroup cannot be used with a task of cff.Switch",
+			TestFuncs:    []string{"InGroupSwitch"},
+		},
+		{
+			File:         "group.go",
+			ErrorMatches: "cff.Group cannot be used in cff.Subflow",
+			TestFuncs:    []string{"GroupSubflow"},
+		},
+		{
+			File:         "hedge.go",
+			ErrorMatches: "cff.Task accepts at most one cff.Hedge option",
+			TestFuncs:    []string{"HedgeTwice", "HedgeTwiceParallel"},
+		},
+		{
+			File:         "quorum.go",
+			ErrorMatches: `"Quorum" is an invalid cff.Flow Option`,
+			TestFuncs:    []string{"QuorumFlow"},
+		},
+		{
+			File:         "quorum.go",
+			ErrorMatches: `"FirstSuccess" is an invalid cff.Flow Option`,
+			TestFuncs:    []string{"FirstSuccessFlow"},
+		},
+		{
+			File:         "quorum.go",
+			ErrorMatches: "cff.Parallel accepts at most one cff.Quorum or cff.FirstSuccess option",
+			TestFuncs:    []string{"QuorumTwice"},
+		},
+		{
+			File:         "quorum.go",
+			ErrorMatches: "cff.Quorum cannot be used with cff.ContinueOnError",
+			TestFuncs:    []string{"QuorumContinueOnError"},
+		},
+		{
+			File:         "quorum.go",
+			ErrorMatches: "cff.SliceEnd cannot be used with cff.FirstSuccess",
+			TestFuncs:    []string{"QuorumSliceEnd"},
+		},
+		{
+			File:         "quorum.go",
+			ErrorMatches: "cff.MapEnd cannot be used with cff.Quorum",
+			TestFuncs:    []string{"QuorumMapEnd"},
+		},
+		{
+			File:         "quorum.go",
+			ErrorMatches: "cff.Quorum expects at least 1 task, got 0",
+			TestFuncs:    []string{"QuorumZero"},
+		},
+		{
+			File:         "budget.go",
+			ErrorMatches: "cff.Task accepts at most one cff.Budget option",
+			TestFuncs:    []string{"BudgetTwice"},
+		},
+		{
+			File:         "budget.go",
+			ErrorMatches: "cff.Budget expects a constant weight",
+			TestFuncs:    []string{"BudgetNotConstant"},
+		},
+		{
+			File:         "budget.go",
+			ErrorMatches: "cff.Budget expects a positive weight, got 0",
+			TestFuncs:    []string{"BudgetNotPositive"},
+		},
+		{
+			File:         "budget.go",
+			ErrorMatches: "cff.Budget requires a task that accepts a context.Context",
+			TestFuncs:    []string{"BudgetWithoutContext"},
+		},
+		{
+			File:         "budget.go",
+			ErrorMatches: "cff.Budget is only supported by cff.Flow tasks",
+			TestFuncs:    []string{"BudgetParallel"},
+		},
+		{
+			File:         "budget.go",
+			ErrorMatches: "cff.Budget cannot be used with a task of cff.Switch",
+			TestFuncs:    []string{"BudgetSwitch"},
+		},
 		{
 			File:         "predicate-params.go",
 			ErrorMatches: "cff.Predicate expected a function but received",
@@ -379,22 +773,14 @@ var codeGenerateFailCases = map[string][]errorCase{
 		},
 		{
 			File:         "parallel.go",
-			ErrorMatches: "MapEnd functions should accept at most one context.Context parameter",
-			TestFuncs:    []string{"ParallelMapEndWithNonContextArgument"},
+			ErrorMatches: `MapEnd functions should accept only a context.Context and a cff.ElementErrors\[string\]`,
+			TestFuncs:    []string{"ParallelMapEndWithNonContextArgument", "ParallelMapEndWithWrongElementErrors"},
 		},
 		{
 			File:         "parallel.go",
 			ErrorMatches: "MapEnd functions should return an error or nothing",
 			TestFuncs:    []string{"ParallelMapEndWithNonErrorResult"},
 		},
-		{
-			File:         "parallel.go",
-			ErrorMatches: `"cff.MapEnd" is an invalid option when "ContinueOnError" is used`,
-			TestFuncs: []string{
-				"ParallelMapEndWithContinueOnError",
-				"ParallelMapEndWithContinueOnErrorAndInstrument",
-			},
-		},
 		{
 			File:         "missing-tag.go",
 			ErrorMatches: `files that use cff.(Flow|Parallel) must be tagged with the 'cff' constraint`,
@@ -35,6 +35,9 @@ type compiler struct {
 	taskSerial int
 	errors     []error
 
+	bundles     map[*types.Var]*ast.CallExpr // cff.Bundle calls by variable
+	usedBundles map[*ast.CallExpr]struct{}   // cff.Bundle calls passed to cff.Use
+
 	requireBuildTag    bool
 	instrumentAllTasks bool
 }
@@ -57,12 +60,28 @@ func newCompiler(opts compilerOpts) *compiler {
 	}
 }
 
-func (c *compiler) errf(pos token.Position, msg string, args ...interface{}) {
-	formattedMsg := fmt.Sprintf("%v: ", pos) + fmt.Sprintf(msg, args...)
-
-	c.errors = append(c.errors, errors.New(formattedMsg))
+// errf reports an error-level Diagnostic with the given code spanning the
+// provided node. The Diagnostic is returned so that callers may attach
+// related positions to it.
+func (c *compiler) errf(code Code, n ast.Node, msg string, args ...interface{}) *Diagnostic {
+	d := &Diagnostic{
+		Pos:      c.position(n.Pos()),
+		End:      c.position(n.End()),
+		Severity: SeverityError,
+		Code:     code,
+		Message:  fmt.Sprintf(msg, args...),
+	}
+	c.errors = append(c.errors, d)
+	return d
 }
 
+// posNode is an ast.Node for a single position.
+// Use it to report errors for objects that aren't backed by a syntax node.
+type posNode token.Pos
+
+func (p posNode) Pos() token.Pos { return token.Pos(p) }
+func (p posNode) End() token.Pos { return token.NoPos }
+
 func (c *compiler) position(pos token.Pos) token.Position {
 	return c.fset.Position(pos)
 }
@@ -89,6 +108,10 @@ type file struct {
 	Parallels  []*parallel
 	Generators []directiveGenerator
 
+	// BundleOnlyImports are imports referenced only by the cff.Bundle
+	// declarations of the file.
+	BundleOnlyImports []*ast.ImportSpec
+
 	modifiers []modifier.Modifier
 }
 
@@ -105,6 +128,9 @@ func (c *compiler) compileFile(astFile *ast.File, pkg *pkg.Package) *file {
 		Imports:        make(map[string][]string),
 		UnnamedImports: make(map[string]struct{}),
 	}
+	if pkg != nil {
+		c.indexBundles(pkg.Syntax)
+	}
 
 	astWalk(astFile, func(n ast.Node) bool {
 		switch n := n.(type) {
@@ -189,8 +215,15 @@ func (c *compiler) compileFile(astFile *ast.File, pkg *pkg.Package) *file {
 					},
 				)
 
+			case fn.Name() == "Bundle":
+				if !c.isBundleDecl(n) {
+					c.errf(CodeUnexpectedDirective, n, "cff.Bundle may only be used to initialize a package-level variable")
+					break
+				}
+				file.Generators = append(file.Generators, bundleGenerator{call: n})
+
 			case IsCodegenDirective(fn.Name()):
-				c.errf(c.nodePosition(n), "unexpected code generation directive %q: "+"only cff.Flow or cff.Parallel may be called at the top-level", fn.Name())
+				c.errf(CodeUnexpectedDirective, n, "unexpected code generation directive %q: "+"only cff.Flow or cff.Parallel may be called at the top-level", fn.Name())
 			default:
 				// Calls to functions that are not code
 				// generation directives (EmitterStack, etc.)
@@ -203,14 +236,16 @@ func (c *compiler) compileFile(astFile *ast.File, pkg *pkg.Package) *file {
 		}
 	})
 
+	file.BundleOnlyImports = c.bundleOnlyImports(astFile)
+
 	if c.requireBuildTag && !fileHasCffTag(astFile) {
 		msgfmt := "files that use %v must be tagged with the 'cff' constraint: " +
 			"fix by adding '//go:build cff' to the top of this file"
 		for _, f := range file.Flows {
-			c.errf(c.nodePosition(f.Node), msgfmt, "cff.Flow")
+			c.errf(CodeMissingBuildTag, f.Node, msgfmt, "cff.Flow")
 		}
 		for _, p := range file.Parallels {
-			c.errf(c.nodePosition(p.Node), msgfmt, "cff.Parallel")
+			c.errf(CodeMissingBuildTag, p.Node, msgfmt, "cff.Parallel")
 		}
 	}
 
@@ -220,11 +255,14 @@ func (c *compiler) compileFile(astFile *ast.File, pkg *pkg.Package) *file {
 type flow struct {
 	ast.Node
 
-	Ctx         ast.Expr // initial ctx argument to cff.Flow(...)
-	Concurrency ast.Expr // argument to cff.Concurrency, if any.
+	Ctx             ast.Expr // initial ctx argument to cff.Flow(...)
+	Concurrency     ast.Expr // argument to cff.Concurrency, if any.
+	ContinueOnError ast.Expr // argument to cff.ContinueOnError, if any.
 
 	Emitters []ast.Expr // zero or more expressions of the type cff.Emitter.
 
+	Interceptors []ast.Expr // zero or more expressions of the type cff.Interceptor.
+
 	Inputs  []*input
 	Outputs []*output
 	Tasks   []*task
@@ -238,6 +276,13 @@ type flow struct {
 
 	Instrument *instrument
 
+	// AllowAssignable is true if cff.AllowAssignable was provided.
+	AllowAssignable bool
+
+	RethrowPanics bool // whether cff.RethrowPanics was provided
+
+	Lazy bool // whether cff.Lazy was provided
+
 	providers *typeutil.Map // map[types.Type]int (index in Tasks)
 	receivers *typeutil.Map // map[types.Type][]funcIndex tracks types needed to detect unused inputs
 
@@ -247,6 +292,27 @@ type flow struct {
 	predicateTypeCnt int                // input to make unique predicateType sentinels.
 	predicateTypes   []*predicateOutput // tracks cff.Predicate sentinel types.
 
+	collectTypeCnt int // input to make unique collectType sentinels.
+
+	// BundleImports are packages referenced by tasks added with cff.Use
+	// that the file doesn't import.
+	BundleImports []*types.PkgName
+	usesBundles   bool // whether cff.Use was provided
+
+	// TaskRefs are expressions of cff.TaskRef variables passed to
+	// cff.Ref and cff.After.
+	TaskRefs []ast.Expr
+
+	Subflows []*subflow // groups of tasks specified with cff.Subflow
+	Groups   []*group   // named groups of tasks declared with cff.Group
+
+	// Omitted are expressions of tasks and parameters that were removed
+	// from the flow because they don't contribute to its results.
+	Omitted []ast.Expr
+
+	refs       map[*types.Var]*task // tasks by cff.TaskRef
+	orderTypes []*orderOutput       // tracks cff.After sentinel types.
+
 	modifiers []modifier.Modifier
 
 	PosInfo *PosInfo // Used to pass information to uniquely identify a task.
@@ -296,9 +362,24 @@ func (f *flow) addPredicateOutput() *predicateOutput {
 	return np
 }
 
+// allFuncs returns the functions of the flow,
+// followed by the functions of the tasks of all cff.Switches.
+func (f *flow) allFuncs() []*function {
+	funcs := append([]*function(nil), f.Funcs...)
+	for _, fn := range f.Funcs {
+		if fn.Task == nil || fn.Task.Switch == nil {
+			continue
+		}
+		for _, t := range fn.Task.Switch.tasks() {
+			funcs = append(funcs, t.Function)
+		}
+	}
+	return funcs
+}
+
 func (c *compiler) compileFlow(file *ast.File, call *ast.CallExpr) *flow {
 	if len(call.Args) == 1 {
-		c.errf(c.nodePosition(call), "cff.Flow expects at least one function")
+		c.errf(CodeNoTasks, call, "cff.Flow expects at least one function")
 		return nil
 	}
 
@@ -308,6 +389,7 @@ func (c *compiler) compileFlow(file *ast.File, call *ast.CallExpr) *flow {
 		PosInfo:   c.getPosInfo(call),
 		providers: new(typeutil.Map),
 		receivers: new(typeutil.Map),
+		refs:      make(map[*types.Var]*task),
 	}
 
 	for _, arg := range call.Args[1:] {
@@ -315,31 +397,22 @@ func (c *compiler) compileFlow(file *ast.File, call *ast.CallExpr) *flow {
 
 		ce, ok := arg.(*ast.CallExpr)
 		if !ok {
-			c.errf(c.nodePosition(arg), "expected a function call, got %v", astutil.NodeDescription(arg))
+			c.errf(CodeExpectedDirective, arg, "expected a function call, got %v", astutil.NodeDescription(arg))
 			continue
 		}
 
 		f := typeutil.StaticCallee(c.info, ce)
 		if f == nil || !isPackagePathEquivalent(f.Pkg(), cffImportPath) {
-			c.errf(c.nodePosition(arg), "expected cff call but got %v", typeutil.Callee(c.info, ce))
+			c.errf(CodeExpectedDirective, arg, "expected cff call but got %v", typeutil.Callee(c.info, ce))
 			continue
 		}
 
 		switch f.Name() {
-		case "ContinueOnError", "Slice", "Map", "InstrumentParallel", "Tasks":
-			c.errf(c.nodePosition(arg), "%q is an invalid cff.Flow Option", f.Name())
+		case "Slice", "Map", "InstrumentParallel", "Tasks", "RateLimit", "Quorum", "FirstSuccess":
+			c.errf(CodeInvalidOption, arg, "%q is an invalid cff.Flow Option", f.Name())
 			continue
 		case "Params":
-			provided := new(typeutil.Map) // *type => *input
-			for _, i := range ce.Args {
-				in := c.compileInput(i)
-				if other, _ := provided.At(in.Type).(*input); other != nil {
-					c.errf(c.nodePosition(i), "type %v already provided to cff.Params at %v", other.Type, c.nodePosition(other.Node))
-					continue
-				}
-				flow.Inputs = append(flow.Inputs, in)
-				provided.Set(in.Type, in)
-			}
+			c.compileParams(&flow, ce)
 			flow.modifiers = append(flow.modifiers, modifier.NewModifier(
 				modifier.Params{
 					Name:     modifier.ParamsName,
@@ -390,16 +463,51 @@ func (c *compiler) compileFlow(file *ast.File, call *ast.CallExpr) *flow {
 					Info:     c.info,
 				}),
 			)
+		case "WithInterceptor":
+			flow.Interceptors = append(flow.Interceptors, ce.Args[0])
+			flow.modifiers = append(flow.modifiers, modifier.Placeholder(ce))
+		case "AllowAssignable":
+			flow.AllowAssignable = true
+			flow.modifiers = append(flow.modifiers, modifier.Placeholder(ce))
+		case "Lazy":
+			flow.Lazy = true
+			flow.modifiers = append(flow.modifiers, modifier.Placeholder(ce))
+		case "RethrowPanics":
+			flow.RethrowPanics = true
+			flow.modifiers = append(flow.modifiers, modifier.Placeholder(ce))
 		case "Concurrency":
 			flow.Concurrency = ce.Args[0]
 			flow.modifiers = append(
 				flow.modifiers,
 				modifier.NewConcurrencyModifier(c.fset, ce.Fun, flow.Concurrency),
 			)
-		case "Task":
-			if task := c.compileTask(&flow, ce.Args[0], ce.Args[1:]); task != nil {
+		case "ContinueOnError":
+			flow.ContinueOnError = ce.Args[0]
+			flow.modifiers = append(flow.modifiers, modifier.Placeholder(ce))
+		case "Group":
+			c.compileGroup(&flow, ce)
+			flow.modifiers = append(flow.modifiers, modifier.Placeholder(ce))
+		case "Switch":
+			if task := c.compileSwitch(&flow, ce); task != nil {
 				flow.Tasks = append(flow.Tasks, task)
+				flow.Tasks = append(flow.Tasks, task.Switch.tasks()...)
 				flow.Funcs = append(flow.Funcs, task.Function)
+				for _, sc := range task.Switch.Cases {
+					flow.Funcs = append(flow.Funcs, sc.Predicate.Function)
+					flow.Predicates = append(flow.Predicates, sc.Predicate)
+				}
+				flow.modifiers = append(flow.modifiers, modifier.Placeholder(ce))
+			}
+		case "Use":
+			c.compileUse(&flow, file, ce)
+			flow.modifiers = append(flow.modifiers, modifier.Placeholder(ce))
+		case "Subflow":
+			if sf := c.compileSubflow(&flow, ce); sf != nil {
+				flow.Subflows = append(flow.Subflows, sf)
+				flow.modifiers = append(flow.modifiers, modifier.Placeholder(ce))
+			}
+		case "Task":
+			if task := c.compileFlowTask(&flow, ce); task != nil {
 				flow.modifiers = append(flow.modifiers, modifier.NewModifier(
 					modifier.Params{
 						Name:     modifier.TaskName,
@@ -409,9 +517,7 @@ func (c *compiler) compileFlow(file *ast.File, call *ast.CallExpr) *flow {
 						Info:     c.info,
 					}),
 				)
-				if task.Predicate != nil {
-					flow.Funcs = append(flow.Funcs, task.Predicate.Function)
-					flow.Predicates = append(flow.Predicates, task.Predicate)
+				if task.Predicate != nil || task.Conditional {
 					flow.modifiers = append(flow.modifiers, modifier.Placeholder(ce))
 				}
 			}
@@ -420,6 +526,13 @@ func (c *compiler) compileFlow(file *ast.File, call *ast.CallExpr) *flow {
 
 	// At this point, c.errors may be non-empty but we are continuing with more checks to catch all
 	// possible errors prior to scheduling attempt and return them at once.
+	c.addCollectors(&flow)
+	c.linkAfter(&flow)
+	c.linkGroups(&flow)
+	if flow.AllowAssignable {
+		c.resolveAssignable(&flow)
+	}
+	c.pruneTasks(&flow)
 	c.validateInstrument(&flow)
 
 	for i, fn := range flow.Funcs {
@@ -436,7 +549,11 @@ func (c *compiler) compileFlow(file *ast.File, call *ast.CallExpr) *flow {
 			if prev != nil {
 				pIdx := prev.(int)
 				p := flow.Funcs[pIdx]
-				c.errf(c.nodePosition(fn), "type %v already provided at %v", o, c.nodePosition(p))
+				c.errf(CodeDuplicateProvider, fn, "type %v already provided at %v", o, c.nodePosition(p)).
+					relatef(c.nodePosition(p), "type %v first provided here", o).
+					relatef(token.Position{}, "each type may have only one provider: "+
+						"to produce more than one %v, declare a distinct named type for each value, e.g. 'type myValue %v'",
+						o, types.TypeString(o, types.RelativeTo(c.pkg)))
 				continue
 			}
 		}
@@ -447,11 +564,13 @@ func (c *compiler) compileFlow(file *ast.File, call *ast.CallExpr) *flow {
 		// as part of a function's Dependencies.
 	}
 
+	c.linkMaybeParams(&flow)
+	c.linkConditions(&flow)
+	c.linkOutputs(&flow)
 	c.validateNoUnusedOutputTypes(&flow)
 	c.validateFuncs(&flow)
 	// At this point we may have already found some errors in c.errors.
-	if err := validateFlowCycles(&flow, c.fset); err != nil {
-		c.errors = append(c.errors, err)
+	if err := c.validateFlowCycles(&flow); err != nil {
 		return nil
 	}
 	if len(c.errors) > 0 {
@@ -459,10 +578,177 @@ func (c *compiler) compileFlow(file *ast.File, call *ast.CallExpr) *flow {
 	}
 
 	c.scheduleFlowAndToposort(&flow)
+	c.allocateBudgets(&flow)
 
 	return &flow
 }
 
+// compileParams adds the values passed to cff.Params to the inputs of
+// the flow.
+func (c *compiler) compileParams(f *flow, call *ast.CallExpr) {
+	provided := new(typeutil.Map) // *type => *input
+	for _, i := range call.Args {
+		in := c.compileInput(i)
+		if other, _ := provided.At(in.Type).(*input); other != nil {
+			c.errf(CodeDuplicateParam, i, "type %v already provided to cff.Params at %v", other.Type, c.nodePosition(other.Node)).
+				relatef(c.nodePosition(other.Node), "type %v first provided here", other.Type)
+			continue
+		}
+		f.Inputs = append(f.Inputs, in)
+		provided.Set(in.Type, in)
+	}
+}
+
+// compileFlowTask compiles a cff.Task call and adds it to the flow along
+// with its predicate, if any.
+func (c *compiler) compileFlowTask(f *flow, call *ast.CallExpr) *task {
+	task := c.compileTask(f, call.Args[0], call.Args[1:])
+	if task == nil {
+		return nil
+	}
+	if task.PredicateOnError != nil && task.Predicate == nil {
+		c.errf(CodeInvalidPredicate, task.PredicateOnError, "cff.PredicateOnError requires cff.Predicate")
+	}
+
+	f.Tasks = append(f.Tasks, task)
+	f.Funcs = append(f.Funcs, task.Function)
+	if task.Predicate != nil {
+		f.Funcs = append(f.Funcs, task.Predicate.Function)
+		f.Predicates = append(f.Predicates, task.Predicate)
+	}
+	return task
+}
+
+// resolveAssignable rewrites task inputs and flow results that don't have an
+// exact provider to the type of the only provider assignable to them.
+//
+// Generated code passes values of the provided type where the assignable
+// type was requested, so no explicit conversion is needed.
+func (c *compiler) resolveAssignable(f *flow) {
+	type provider struct {
+		Type types.Type
+		Node ast.Node
+	}
+
+	var (
+		exact     typeutil.Map // map[types.Type]struct{}
+		provided  []provider
+		resolved  typeutil.Map // map[types.Type]types.Type
+		ambiguous typeutil.Map // map[types.Type]struct{}
+	)
+	for _, i := range f.Inputs {
+		exact.Set(i.Type, struct{}{})
+		provided = append(provided, provider{Type: i.Type, Node: i.Node})
+	}
+	for _, fn := range f.Funcs {
+		if fn.Task == nil || fn.Task.Collect {
+			continue
+		}
+		for _, o := range fn.Task.Outputs {
+			exact.Set(o, struct{}{})
+			provided = append(provided, provider{Type: o, Node: fn.Node})
+		}
+	}
+
+	// resolve returns the provided type that should be used in place of t,
+	// reporting an error at n if more than one type matches.
+	resolve := func(n ast.Node, t types.Type) types.Type {
+		if exact.At(t) != nil {
+			return t
+		}
+		if r, ok := resolved.At(t).(types.Type); ok {
+			return r
+		}
+		if ambiguous.At(t) != nil {
+			return t
+		}
+
+		var matches []provider
+		for _, p := range provided {
+			if types.AssignableTo(p.Type, t) {
+				matches = append(matches, p)
+			}
+		}
+
+		switch len(matches) {
+		case 0:
+			return t // reported as a missing provider
+		case 1:
+			resolved.Set(t, matches[0].Type)
+			return matches[0].Type
+		}
+
+		ambiguous.Set(t, struct{}{})
+		d := c.errf(CodeAmbiguousProvider, n, "multiple providers are assignable to %v", t)
+		for _, m := range matches {
+			d.relatef(c.nodePosition(m.Node), "%v is provided here", m.Type)
+		}
+		return t
+	}
+
+	for _, fn := range f.allFuncs() {
+		inputs := fn.inputs()
+		for i, in := range inputs {
+			inputs[i] = resolve(fn.Node, in)
+		}
+		for i, dep := range fn.Dependencies {
+			if r, ok := resolved.At(dep).(types.Type); ok {
+				fn.Dependencies[i] = r
+			}
+		}
+	}
+
+	for _, o := range f.Outputs {
+		r := resolve(o.Node, o.Type)
+		if r == o.Type {
+			continue
+		}
+		f.receivers.Delete(o.Type)
+		f.receivers.Set(r, []funcIndex{funcIndexResult})
+		o.Type = r
+	}
+}
+
+// linkMaybeParams records the providers of the inputs of cff.Maybe
+// parameters so that generated code can determine whether they were produced.
+func (c *compiler) linkMaybeParams(f *flow) {
+	for _, fn := range f.allFuncs() {
+		var params []*funcObject
+		if fn.Predicate != nil {
+			params = fn.Predicate.Params
+		} else {
+			params = fn.Task.Params
+		}
+
+		inputs := fn.inputs()
+		for _, p := range params {
+			if !p.Maybe {
+				continue
+			}
+			if idx, ok := f.providers.At(inputs[p.Index]).(int); ok {
+				p.Provider = f.Funcs[idx].Task
+				p.Provider.trackProduced()
+			}
+		}
+	}
+}
+
+// linkOutputs records the providers of the flow's results
+// if generated code must determine whether they were produced:
+// for cff.Maybe results, and for all results if the flow uses
+// cff.ContinueOnError.
+func (c *compiler) linkOutputs(f *flow) {
+	for _, o := range f.Outputs {
+		if !o.Maybe && f.ContinueOnError == nil {
+			continue
+		}
+		if idx, ok := f.providers.At(o.Type).(int); ok {
+			o.Provider = f.Funcs[idx].Task
+			o.Provider.trackProduced()
+		}
+	}
+}
+
 type validateVisitedType struct {
 	Type types.Type
 
@@ -472,11 +758,20 @@ type validateVisitedType struct {
 
 // validateNoUnusedOutputTypes ensures that every output type is consumed by either a cff.Results or another task
 // or a predicate of another task.
+//
+// Tasks added with cff.Use and tasks of flows that use cff.Lazy may have
+// unused outputs.
 func (c *compiler) validateNoUnusedOutputTypes(f *flow) {
+	if f.Lazy {
+		return
+	}
 	for _, t := range f.Funcs {
+		if t.bundled() {
+			continue
+		}
 		for _, o := range t.outputs() {
 			if f.receivers.At(o) == nil {
-				c.errf(c.nodePosition(t.Node), "unused output type %v", o)
+				c.errf(CodeUnusedOutput, t.Node, "unused output type %v", o)
 			}
 		}
 	}
@@ -534,7 +829,8 @@ func (c *compiler) validateFuncs(f *flow) {
 			continue
 		}
 
-		c.errf(c.nodePosition(t.Node), "no provider found for %v", t.Type)
+		d := c.errf(CodeNoProvider, t.Node, "no provider found for %v", t.Type)
+		c.suggestProviders(d, f, t.Type)
 	}
 
 	if flowInputs.Len() > 0 {
@@ -542,16 +838,79 @@ func (c *compiler) validateFuncs(f *flow) {
 		for _, inputType := range inputs {
 			inputUntyped := flowInputs.At(inputType)
 			input := inputUntyped.(*input)
-			c.errf(c.nodePosition(input.Node), "unused input type %v", input.Type)
+			c.errf(CodeUnusedInput, input.Node, "unused input type %v", input.Type)
+		}
+	}
+}
+
+// suggestProviders attaches "did you mean" hints to a "no provider" diagnostic
+// for types that are available in the flow and look similar to want.
+func (c *compiler) suggestProviders(d *Diagnostic, f *flow, want types.Type) {
+	suggest := func(n ast.Node, have types.Type) {
+		if reason := similarType(have, want); len(reason) > 0 {
+			d.relatef(c.nodePosition(n), "did you mean %v? it is provided here and %v", have, reason)
+		}
+	}
+
+	for _, i := range f.Inputs {
+		suggest(i.Node, i.Type)
+	}
+	for _, fn := range f.Funcs {
+		if fn.Task == nil || fn.Task.Collect {
+			continue // predicates and cff.Collect tasks don't provide values
 		}
+		for _, o := range fn.Task.Outputs {
+			suggest(fn.Node, o)
+		}
+	}
+}
+
+// similarType reports why have could have been intended in place of want,
+// or an empty string if the two types are unrelated.
+func similarType(have, want types.Type) string {
+	if types.Identical(have, want) {
+		return ""
+	}
+
+	if ptr, ok := have.(*types.Pointer); ok && types.Identical(ptr.Elem(), want) {
+		return "is a pointer to " + want.String()
+	}
+	if ptr, ok := want.(*types.Pointer); ok && types.Identical(ptr.Elem(), have) {
+		return "is the value type of " + want.String()
+	}
+	if types.AssignableTo(have, want) {
+		return "is assignable to " + want.String() + " but cff matches types exactly unless cff.AllowAssignable is used"
+	}
+
+	haveObj, wantObj := namedObj(have), namedObj(want)
+	if haveObj != nil && wantObj != nil && haveObj.Name() == wantObj.Name() &&
+		haveObj.Pkg() != nil && wantObj.Pkg() != nil &&
+		haveObj.Pkg().Path() != wantObj.Pkg().Path() {
+		return "has the same name as " + want.String() + " but is from a different package"
+	}
+
+	return ""
+}
+
+// namedObj returns the type name of a named type or a pointer to a named
+// type.
+func namedObj(t types.Type) *types.TypeName {
+	if ptr, ok := t.(*types.Pointer); ok {
+		t = ptr.Elem()
+	}
+	if named, ok := t.(*types.Named); ok {
+		return named.Obj()
 	}
+	return nil
 }
 
 func (c *compiler) validateInstrument(f *flow) {
 	instrumented := f.Instrument != nil
 	if !instrumented {
 		for _, t := range f.Tasks {
-			if t.Instrument != nil {
+			// Tasks of a subflow with its own emitters don't need
+			// the flow's emitters.
+			if t.Instrument != nil && (t.Subflow == nil || len(t.Subflow.Emitters) == 0) {
 				instrumented = true
 				break
 			}
@@ -565,7 +924,7 @@ func (c *compiler) validateInstrument(f *flow) {
 	}
 
 	if len(f.Emitters) == 0 {
-		c.errf(c.nodePosition(f.Node), "cff.Instrument requires a cff.Emitter to be provided: use cff.WithEmitter")
+		c.errf(CodeMissingEmitter, f.Node, "cff.Instrument requires a cff.Emitter to be provided: use cff.WithEmitter")
 	}
 }
 
@@ -619,6 +978,11 @@ type task struct {
 	Inputs  []types.Type // non ctx params
 	Outputs []types.Type // non error results
 
+	// Params and Results map the function's parameters and results to
+	// Inputs and Outputs.
+	Params  []*funcObject
+	Results []*funcObject
+
 	// A task has at most one predicate.
 	Predicate  *predicate  // non-nil if Predicate was provided
 	Instrument *instrument // non-nil if instrumentation was enabled
@@ -626,11 +990,113 @@ type task struct {
 	FallbackWith        bool       // whether we should ignore errors from this function
 	FallbackWithResults []ast.Expr // expressions that return a value for each return type of this function
 
-	invokeType *noOutput // non-nil if there are no non-error results
+	Optional bool // whether failures of this task should be recovered with zero values
+
+	// PredicateOnError is the argument to cff.PredicateOnError, if any:
+	// the value to use for the predicate of the task if it fails.
+	PredicateOnError ast.Expr
+
+	// TrackProduced is true if generated code must track whether this task
+	// produced its outputs.
+	TrackProduced bool
+
+	// Switch is non-nil if this task is a cff.Switch.
+	// Such a task runs one of the tasks of the Switch
+	// instead of a function of its own.
+	Switch *switchTask
+
+	// InSwitch is true if this task is one of the tasks of a cff.Switch.
+	InSwitch bool
+
+	// Collect is true if the outputs of this task are collected into
+	// slices by collectors instead of being provided directly.
+	Collect bool
+
+	// Collector is non-nil if this task collects the outputs of cff.Collect
+	// tasks into a slice.
+	Collector *collector
+
+	// After lists the tasks that must finish before this task runs.
+	After []*afterRef
+
+	// RateLimit is the argument to cff.RateLimit, if any.
+	RateLimit ast.Expr
+
+	// Group is the group the task was added to with cff.InGroup, if any.
+	Group *group
+
+	// Hedge is non-nil if cff.Hedge was provided.
+	Hedge *hedge
+
+	// Budget is non-nil if cff.Budget was provided.
+	Budget *budget
+
+	// Bundled is true if the task was added to the flow with cff.Use.
+	Bundled bool
+
+	// Subflow is non-nil if the task was specified inside a cff.Subflow.
+	Subflow *subflow
+
+	// Lazy is true if the task belongs to a flow that uses cff.Lazy.
+	// Not all outputs of such tasks are consumed.
+	Lazy bool
+
+	// Conditional is true if the task reports whether it produced its
+	// outputs by returning (T, bool) or cff.Maybe[T].
+	Conditional bool
+
+	// Conditions are conditional tasks that provide inputs to this task
+	// or its predicate.
+	// This task is skipped if any of them didn't produce its outputs.
+	Conditions []*task
+
+	invokeType  *noOutput      // non-nil if there are no non-error results
+	collectType *collectOutput // non-nil if Collect is true
+	orderType   *orderOutput   // non-nil if another task runs after this one
+	inGroup     *groupRef      // argument to cff.InGroup, if any
 
 	PosInfo *PosInfo // Used to pass information to uniquely identify a task.
 }
 
+// HasResultObjects reports whether this task returns a cff.Out result object
+// or a cff.Maybe.
+func (t *task) HasResultObjects() bool {
+	for _, r := range t.Results {
+		if r.Fields != nil || r.Maybe {
+			return true
+		}
+	}
+	return false
+}
+
+// trackProduced requests that generated code track whether this task
+// produced its outputs.
+func (t *task) trackProduced() {
+	t.TrackProduced = true
+	if t.Switch != nil {
+		for _, st := range t.Switch.tasks() {
+			st.TrackProduced = true
+		}
+	}
+}
+
+// DependsOnTasks returns the tasks that must run successfully before this
+// task may run, including the tasks that its predicate depends on.
+// Generated code uses these to report why the task was skipped.
+func (t *task) DependsOnTasks() []*task {
+	var tasks []*task
+	for _, fn := range t.Function.DependsOn {
+		if fn.Predicate != nil {
+			for _, pfn := range fn.Predicate.Function.DependsOn {
+				tasks = appendTask(tasks, pfn.Task)
+			}
+		} else {
+			tasks = appendTask(tasks, fn.Task)
+		}
+	}
+	return tasks
+}
+
 // invokeType is a sentinel return type for tasks that have no non-error results.
 // It can not be custom defined type, otherwise it won't work with typeutil.Map.
 type noOutput = types.Struct
@@ -661,7 +1127,11 @@ func (c *compiler) compileTask(flow *flow, expr ast.Expr, opts []ast.Expr) *task
 		Serial:   c.taskSerial,
 		Inputs:   compiledFunc.Inputs,
 		Outputs:  compiledFunc.Outputs,
+		Params:   compiledFunc.Params,
+		Results:  compiledFunc.Results,
 		PosInfo:  c.getPosInfo(expr),
+
+		Conditional: compiledFunc.Conditional,
 	}
 
 	taskFunc.Task = &t
@@ -674,10 +1144,10 @@ func (c *compiler) compileTask(flow *flow, expr ast.Expr, opts []ast.Expr) *task
 
 	// Check if we return nothing and we don't have an Invoke call.
 	if len(t.Outputs) == 0 && t.invokeType == nil {
-		c.errf(c.nodePosition(expr), "task must return at least one non-error value but currently produces zero."+" Did you intend to use cff.Invoke(true)?")
+		c.errf(CodeInvalidInvoke, expr, "task must return at least one non-error value but currently produces zero."+" Did you intend to use cff.Invoke(true)?")
 	}
 	if len(t.Outputs) > 0 && t.invokeType != nil {
-		c.errf(c.nodePosition(expr), "cff.Invoke cannot be provided on a Task that produces values besides errors")
+		c.errf(CodeInvalidInvoke, expr, "cff.Invoke cannot be provided on a Task that produces values besides errors")
 	}
 
 	// Create an implied Instrument(...) annotation for all tasks if the
@@ -731,7 +1201,14 @@ func (f *function) outputs() []types.Type {
 	if f.Predicate != nil {
 		return []types.Type{f.Predicate.SentinelOutput}
 	}
-	return f.Task.Outputs
+	outputs := f.Task.Outputs
+	if f.Task.Collect {
+		outputs = []types.Type{f.Task.collectType}
+	}
+	if f.Task.orderType != nil {
+		outputs = append(append([]types.Type(nil), outputs...), f.Task.orderType)
+	}
+	return outputs
 }
 
 // compiledFunc is a compiled function expression.
@@ -749,19 +1226,79 @@ type compiledFunc struct {
 	Inputs  []types.Type // non ctx params
 	Outputs []types.Type // non error results
 
+	// Params and Results map the non-context parameters and non-error
+	// results of the function to Inputs and Outputs.
+	Params  []*funcObject
+	Results []*funcObject
+
+	// Conditional is true if the function reports whether it produced
+	// its result by returning (T, bool) or cff.Maybe[T].
+	Conditional bool
+
 	PosInfo *PosInfo // Used to pass information to uniquely identify a function.
 }
 
+// hasObjects reports whether the function accepts a cff.In parameter
+// object or returns a cff.Out result object.
+func (f *compiledFunc) hasObjects() bool {
+	for _, o := range f.Params {
+		if o.Fields != nil {
+			return true
+		}
+	}
+	for _, o := range f.Results {
+		if o.Fields != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// funcObject is a single non-context parameter or non-error result of a
+// function.
+//
+// Structs that embed cff.In or cff.Out are expanded into one input or output
+// per field.
+type funcObject struct {
+	Type types.Type // type of the parameter or result
+
+	// Index of the corresponding input or output.
+	// Unused if Fields is non-nil.
+	Index int
+
+	// Fields of a cff.In or cff.Out struct.
+	Fields []*funcObjectField
+
+	// Maybe is true if this is a cff.Maybe[T] parameter or result.
+	// The corresponding input or output is T.
+	Maybe bool
+
+	// Ok is true if this is the boolean result of a function that returns
+	// (T, bool) to report whether it produced T.
+	// It does not correspond to an output.
+	Ok bool
+
+	// Provider is the task that provides the input of a cff.Maybe
+	// parameter, or nil if the input is provided by cff.Params.
+	Provider *task
+}
+
+// funcObjectField is a field of a cff.In or cff.Out struct.
+type funcObjectField struct {
+	Name  string
+	Index int // index of the corresponding input or output
+}
+
 func (c *compiler) compileFunction(expr ast.Expr) *compiledFunc {
 	typ := c.info.TypeOf(expr)
 	sig, ok := typ.Underlying().(*types.Signature)
 	if !ok {
-		c.errf(c.nodePosition(expr), "expected function, got %v", typ)
+		c.errf(CodeInvalidFunction, expr, "expected function, got %v", typ)
 		return nil
 	}
 
 	if sig.Variadic() {
-		c.errf(c.nodePosition(expr), "variadic functions are not yet supported")
+		c.errf(CodeInvalidFunction, expr, "variadic functions are not yet supported")
 		return nil
 	}
 
@@ -776,12 +1313,16 @@ func (c *compiler) compileFunction(expr ast.Expr) *compiledFunc {
 		param := params.At(i)
 		ptype := param.Type()
 		if !isContext(ptype) {
-			f.Inputs = append(f.Inputs, ptype)
+			obj, ok := c.compileObject(expr, ptype, "In", &f.Inputs)
+			if !ok {
+				return nil
+			}
+			f.Params = append(f.Params, obj)
 			continue
 		}
 
 		if i != 0 {
-			c.errf(c.position(param.Pos()), "only the first argument may be context.Context")
+			c.errf(CodeInvalidFunction, posNode(param.Pos()), "only the first argument may be context.Context")
 			return nil
 		}
 		f.WantCtx = true
@@ -792,20 +1333,71 @@ func (c *compiler) compileFunction(expr ast.Expr) *compiledFunc {
 		result := results.At(i)
 		rtype := result.Type()
 		if !isError(rtype) {
-			f.Outputs = append(f.Outputs, rtype)
+			obj, ok := c.compileObject(expr, rtype, "Out", &f.Outputs)
+			if !ok {
+				return nil
+			}
+			f.Results = append(f.Results, obj)
 			continue
 		}
 		// Error case.
 		if i != results.Len()-1 {
-			c.errf(c.position(result.Pos()), "only the last result may be an error")
+			c.errf(CodeInvalidFunction, posNode(result.Pos()), "only the last result may be an error")
 			return nil
 		}
 		f.HasError = true
 	}
 
+	c.compileConditional(expr, &f)
 	return &f
 }
 
+// compileObject compiles a parameter or result of type t,
+// adding its types to list.
+// If t is a struct embedding cff.In or cff.Out (as specified by marker),
+// each of its fields is added to the list separately.
+func (c *compiler) compileObject(expr ast.Expr, t types.Type, marker string, list *[]types.Type) (*funcObject, bool) {
+	obj := &funcObject{Type: t}
+	if isCffType(t, "Maybe") {
+		obj.Maybe = true
+		obj.Index = len(*list)
+		*list = append(*list, t.(*types.Named).TypeArgs().At(0))
+		return obj, true
+	}
+
+	st, ok := t.Underlying().(*types.Struct)
+	if !ok || !embedsCffType(st, marker) {
+		obj.Index = len(*list)
+		*list = append(*list, t)
+		return obj, true
+	}
+
+	obj.Fields = []*funcObjectField{} // non-nil even if empty
+	for i := 0; i < st.NumFields(); i++ {
+		field := st.Field(i)
+		if field.Embedded() && isCffType(field.Type(), marker) {
+			continue
+		}
+		if !field.Exported() && field.Pkg() != c.pkg {
+			c.errf(CodeInvalidFunction, expr,
+				"field %v of cff.%v struct %v must be exported", field.Name(), marker, t)
+			return nil, false
+		}
+		if fst, ok := field.Type().Underlying().(*types.Struct); ok &&
+			(embedsCffType(fst, "In") || embedsCffType(fst, "Out")) {
+			c.errf(CodeInvalidFunction, expr,
+				"field %v of cff.%v struct %v may not be a cff.In or cff.Out", field.Name(), marker, t)
+			return nil, false
+		}
+		obj.Fields = append(obj.Fields, &funcObjectField{
+			Name:  field.Name(),
+			Index: len(*list),
+		})
+		*list = append(*list, field.Type())
+	}
+	return obj, true
+}
+
 func (c *compiler) getPosInfo(n ast.Node) *PosInfo {
 	pos := c.nodePosition(n)
 	posInfo := &PosInfo{
@@ -818,41 +1410,27 @@ func (c *compiler) getPosInfo(n ast.Node) *PosInfo {
 }
 
 func (c *compiler) interpretTaskOptions(flow *flow, t *task, opts []ast.Expr) {
+	var fallbackOpt, predicateOnErrorOpt ast.Expr
 	for _, opt := range opts {
 		call, fn, err := c.identifyOption(opt)
 		if err != nil {
-			c.errf(c.nodePosition(opt), err.Error())
+			c.errf(CodeInvalidOption, opt, err.Error())
 			continue
 		}
 
 		switch fn.Name() {
 		case "FallbackWith":
 			errResults := call.Args
-			if len(errResults) != len(t.Outputs) {
-				c.errf(c.nodePosition(opt), "cff.FallbackWith must produce the same number of results as the task: "+"expected %v, got %v", len(t.Outputs), len(errResults))
-				continue
-			}
-			// Verify that Task returns an error for FallbackWith to be used.
-			hasError := false
-			results := t.Function.Sig.Results()
-			for i := 0; i < results.Len(); i++ {
-				result := results.At(i)
-				rtype := result.Type()
-				if isError(rtype) {
-					// Found error.
-					hasError = true
-				}
-			}
-			if !hasError {
-				c.errf(c.nodePosition(opt), "Task must return an error for FallbackWith to be used")
+			if len(errResults) != len(t.Results) {
+				c.errf(CodeInvalidFallback, opt, "cff.FallbackWith must produce the same number of results as the task: "+"expected %v, got %v", len(t.Results), len(errResults))
 				continue
 			}
 			for i, er := range errResults {
 				give := c.info.TypeOf(er)
-				want := t.Outputs[i]
+				want := t.Results[i].Type
 				if !types.AssignableTo(give, want) {
 					c.errf(
-						c.nodePosition(er),
+						CodeInvalidFallback, er,
 						"cff.FallbackWith result at position %v of type %v cannot be used as %v",
 						i+1, give, want)
 				}
@@ -860,14 +1438,60 @@ func (c *compiler) interpretTaskOptions(flow *flow, t *task, opts []ast.Expr) {
 
 			t.FallbackWith = true
 			t.FallbackWithResults = call.Args
+			fallbackOpt = opt
 		case "Predicate":
 			t.Predicate = c.compilePredicate(flow, t, call)
+		case "PredicateOnError":
+			t.PredicateOnError = call.Args[0]
+			predicateOnErrorOpt = opt
 		case "Instrument":
 			t.Instrument = c.compileInstrument(call)
 		case "Invoke":
 			t.invokeType = c.compileInvoke(flow, call)
+		case "Optional":
+			t.Optional = true
+		case "Collect":
+			t.Collect = true
+			t.collectType = flow.addCollectOutput()
+		case "Ref":
+			c.compileRef(flow, t, call.Args[0])
+		case "After":
+			for _, arg := range call.Args {
+				c.compileAfter(flow, t, arg)
+			}
+		case "RateLimit":
+			t.RateLimit = call.Args[0]
+		case "InGroup":
+			c.compileInGroup(t, call)
+		case "Hedge":
+			t.Hedge = c.compileHedge(t.Hedge, call)
+		case "Budget":
+			t.Budget = c.compileBudget(t, call)
 		}
 	}
+
+	// Verify that Task or its Predicate returns an error
+	// for FallbackWith to be used.
+	predicateHasError := t.Predicate != nil && t.Predicate.Function.HasError
+	if t.FallbackWith && !t.Function.HasError && !predicateHasError {
+		c.errf(CodeInvalidFallback, fallbackOpt, "Task must return an error for FallbackWith to be used")
+		t.FallbackWith = false
+		t.FallbackWithResults = nil
+	}
+
+	// Predicates of tasks of a cff.Switch are compiled separately
+	// and verified by compileSwitch.
+	if t.PredicateOnError != nil && t.Predicate != nil && !predicateHasError {
+		c.errf(CodeInvalidPredicate, predicateOnErrorOpt, "cff.PredicateOnError requires a predicate that returns an error")
+	}
+
+	if t.Collect && t.invokeType != nil {
+		c.errf(CodeInvalidOption, t, "cff.Collect cannot be used with cff.Invoke")
+	}
+
+	if t.Optional && t.FallbackWith {
+		c.errf(CodeInvalidOption, t, "cff.Optional cannot be used with cff.FallbackWith")
+	}
 }
 
 func (c *compiler) identifyOption(opt ast.Expr) (*ast.CallExpr, types.Object, error) {
@@ -900,6 +1524,9 @@ type predicate struct {
 
 	Inputs []types.Type // non ctx params
 
+	// Params maps the function's parameters to Inputs.
+	Params []*funcObject
+
 	// Output is the parsed return type from the cff.Predicate invocation.
 	// SentinelOutput should be used when there is a need to uniquely
 	// identify the output of the predicate.
@@ -916,32 +1543,17 @@ type predicate struct {
 	// Task that predicate stops.
 	Task *task
 
+	// Conditions are conditional tasks that provide inputs to this
+	// predicate.
+	// The predicate doesn't run if any of them didn't produce its outputs.
+	Conditions []*task
+
 	PosInfo *PosInfo // Used to pass information to uniquely identify a predicate.
 }
 
 func (c *compiler) compilePredicate(f *flow, t *task, call *ast.CallExpr) *predicate {
 	fn := call.Args[0]
-	fnType := c.info.TypeOf(fn)
-
-	sig, ok := fnType.(*types.Signature)
-	if !ok {
-		c.errf(c.nodePosition(fn), "cff.Predicate expected a function but received %v", fnType)
-		return nil
-	}
-
-	if sig.Variadic() {
-		c.errf(c.nodePosition(fn), "variadic functions are not yet supported")
-		return nil
-	}
-
-	results := sig.Results()
-	if results.Len() != 1 {
-		c.errf(c.nodePosition(fn), "the function must return a single boolean result")
-		return nil
-	}
-
-	if rtype, ok := results.At(0).Type().(*types.Basic); !ok || rtype.Kind() != types.Bool {
-		c.errf(c.nodePosition(fn), "the function must return a single boolean result")
+	if !c.validatePredicateSignature(fn) {
 		return nil
 	}
 
@@ -964,6 +1576,7 @@ func (c *compiler) compilePredicate(f *flow, t *task, call *ast.CallExpr) *predi
 		PosInfo:        c.getPosInfo(call),
 		Function:       predFunc,
 		Inputs:         compiledFunc.Inputs,
+		Params:         compiledFunc.Params,
 		Output:         compiledFunc.Outputs[0], // Predicates must have one output.
 		SentinelOutput: f.addPredicateOutput(),
 		Serial:         f.predicateTypeCnt,
@@ -973,6 +1586,38 @@ func (c *compiler) compilePredicate(f *flow, t *task, call *ast.CallExpr) *predi
 	return p
 }
 
+// validatePredicateSignature reports whether fn, the argument to
+// cff.Predicate, is a function that returns a boolean and optionally,
+// an error.
+func (c *compiler) validatePredicateSignature(fn ast.Expr) bool {
+	fnType := c.info.TypeOf(fn)
+	sig, ok := fnType.(*types.Signature)
+	if !ok {
+		c.errf(CodeInvalidPredicate, fn, "cff.Predicate expected a function but received %v", fnType)
+		return false
+	}
+
+	if sig.Variadic() {
+		c.errf(CodeInvalidFunction, fn, "variadic functions are not yet supported")
+		return false
+	}
+
+	results := sig.Results()
+	if results.Len() == 2 && isError(results.At(1).Type()) {
+		results = types.NewTuple(results.At(0))
+	}
+	if results.Len() != 1 {
+		c.errf(CodeInvalidPredicate, fn, "the function must return a single boolean result, optionally followed by an error")
+		return false
+	}
+
+	if rtype, ok := results.At(0).Type().(*types.Basic); !ok || rtype.Kind() != types.Bool {
+		c.errf(CodeInvalidPredicate, fn, "the function must return a single boolean result, optionally followed by an error")
+		return false
+	}
+	return true
+}
+
 type instrument struct {
 	Name ast.Expr // name to use in metrics for this task
 }
@@ -982,6 +1627,22 @@ func (c *compiler) compileInstrument(call *ast.CallExpr) *instrument {
 	return &instrument{Name: name}
 }
 
+// hedge holds the arguments to cff.Hedge.
+type hedge struct {
+	After ast.Expr // how long to wait before starting another copy
+	Max   ast.Expr // maximum number of copies
+}
+
+// compileHedge interprets cff.Hedge for a task,
+// given the hedge previously specified for it, if any.
+func (c *compiler) compileHedge(prev *hedge, call *ast.CallExpr) *hedge {
+	if prev != nil {
+		c.errf(CodeInvalidOption, call, "cff.Task accepts at most one cff.Hedge option")
+		return prev
+	}
+	return &hedge{After: call.Args[0], Max: call.Args[1]}
+}
+
 func (c *compiler) compileInstrumentName(name string) *instrument {
 	return &instrument{
 		Name: &ast.BasicLit{
@@ -994,11 +1655,11 @@ func (c *compiler) compileInstrumentName(name string) *instrument {
 func (c *compiler) compileInvoke(flow *flow, o *ast.CallExpr) *noOutput {
 	// Bool type checking is satisfied by cff.Invoke interface.
 	if len(o.Args) != 1 {
-		c.errf(c.nodePosition(o.Fun), "invoke expects exactly one argument")
+		c.errf(CodeInvalidInvoke, o.Fun, "invoke expects exactly one argument")
 	}
 	val, ok := c.info.Types[o.Args[0]]
 	if !ok {
-		c.errf(c.nodePosition(o), "expected to find a bool, found %v instead", astutil.NodeDescription(o.Args[0]))
+		c.errf(CodeInvalidInvoke, o, "expected to find a bool, found %v instead", astutil.NodeDescription(o.Args[0]))
 		return nil
 	}
 	if constant.BoolVal(val.Value) {
@@ -1013,6 +1674,8 @@ type input struct {
 
 	// Type of the value.
 	Type types.Type
+
+	bundled bool // whether the value was provided by a cff.Bundle
 }
 
 func (c *compiler) compileInput(i ast.Expr) *input {
@@ -1027,21 +1690,35 @@ type output struct {
 	Node ast.Expr
 
 	// Type of the target value, not the pointer.
+	// For a pointer to cff.Maybe[T], this is T.
 	Type types.Type
+
+	// Maybe is true if the target is a cff.Maybe[T].
+	Maybe bool
+
+	// Provider is the task that provides this result, if generated code
+	// must track whether it was produced.
+	// This is nil if the result is provided by cff.Params.
+	Provider *task
 }
 
 func (c *compiler) compileOutput(o ast.Expr) *output {
 	t := c.info.TypeOf(o)
 	p, ok := t.(*types.Pointer)
 	if !ok {
-		c.errf(c.nodePosition(o), "invalid parameter to cff.Results: "+"expected pointer, got %v", t)
+		c.errf(CodeInvalidResults, o, "invalid parameter to cff.Results: "+"expected pointer, got %v", t)
 		return nil
 	}
 
-	return &output{
+	out := &output{
 		Node: o,
 		Type: p.Elem(),
 	}
+	if isCffType(out.Type, "Maybe") {
+		out.Maybe = true
+		out.Type = out.Type.(*types.Named).TypeArgs().At(0)
+	}
+	return out
 }
 
 // isPackagePathEquivalent returns whether the path of the package is exactly equal to the path given or is equivalent due to vendoring.
@@ -0,0 +1,165 @@
+package internal
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"strconv"
+
+	"golang.org/x/tools/go/ast/astutil"
+)
+
+// orderOutput is a sentinel return type for tasks referenced by cff.After.
+// Tasks that must run after such a task depend on this sentinel.
+type orderOutput = types.Struct
+
+// afterRef is a reference to a task passed to cff.After.
+type afterRef struct {
+	Node ast.Expr
+
+	Ref  *types.Var // variable holding the cff.TaskRef, if any
+	Name string     // instrumentation name of the task if Ref is nil
+}
+
+// addOrderOutput creates a unique sentinel type for a task referenced by
+// cff.After.
+func (f *flow) addOrderOutput() *orderOutput {
+	name := "after" + strconv.Itoa(len(f.orderTypes)+1)
+	field := types.NewVar(0, nil, name, &types.Struct{})
+	o := types.NewStruct([]*types.Var{field}, nil)
+	f.orderTypes = append(f.orderTypes, o)
+	return o
+}
+
+// isOrderType reports whether t is a sentinel type created by
+// addOrderOutput.
+func (f *flow) isOrderType(t types.Type) bool {
+	for _, o := range f.orderTypes {
+		if o == t {
+			return true
+		}
+	}
+	return false
+}
+
+// taskRefVar returns the variable referenced by an expression
+// of type cff.TaskRef.
+func (c *compiler) taskRefVar(expr ast.Expr) (*types.Var, bool) {
+	var obj types.Object
+	switch e := astutil.Unparen(expr).(type) {
+	case *ast.Ident:
+		obj = c.info.Uses[e]
+	case *ast.SelectorExpr:
+		obj = c.info.Uses[e.Sel]
+	}
+
+	v, ok := obj.(*types.Var)
+	if !ok || v.IsField() {
+		return nil, false
+	}
+	return v, true
+}
+
+// compileRef interprets cff.Ref(&ref) for task t.
+func (c *compiler) compileRef(f *flow, t *task, arg ast.Expr) {
+	ue, ok := astutil.Unparen(arg).(*ast.UnaryExpr)
+	if !ok || ue.Op != token.AND {
+		c.errf(CodeInvalidArgument, arg, "cff.Ref expects a pointer to a cff.TaskRef variable, got %v", astutil.NodeDescription(arg))
+		return
+	}
+
+	ref, ok := c.taskRefVar(ue.X)
+	if !ok {
+		c.errf(CodeInvalidArgument, arg, "cff.Ref expects a pointer to a cff.TaskRef variable, got %v", astutil.NodeDescription(arg))
+		return
+	}
+
+	if other, ok := f.refs[ref]; ok {
+		c.errf(CodeInvalidOption, arg, "cff.TaskRef %v is already attached to a task at %v", ref.Name(), c.nodePosition(other)).
+			relatef(c.nodePosition(other), "cff.TaskRef %v first attached here", ref.Name())
+		return
+	}
+
+	f.refs[ref] = t
+	f.TaskRefs = append(f.TaskRefs, arg)
+}
+
+// compileAfter interprets an argument of cff.After for task t.
+func (c *compiler) compileAfter(f *flow, t *task, arg ast.Expr) {
+	tv := c.info.Types[arg]
+	if isCffType(tv.Type, "TaskRef") {
+		ref, ok := c.taskRefVar(arg)
+		if !ok {
+			c.errf(CodeInvalidArgument, arg, "cff.After expects a cff.TaskRef variable, got %v", astutil.NodeDescription(arg))
+			return
+		}
+		t.After = append(t.After, &afterRef{Node: arg, Ref: ref})
+		f.TaskRefs = append(f.TaskRefs, arg)
+		return
+	}
+
+	if tv.Value != nil && tv.Value.Kind() == constant.String {
+		t.After = append(t.After, &afterRef{Node: arg, Name: constant.StringVal(tv.Value)})
+		return
+	}
+
+	c.errf(CodeInvalidArgument, arg, "cff.After expects a cff.TaskRef or a constant instrumentation name, got %v", tv.Type)
+}
+
+// linkAfter adds the ordering dependencies requested with cff.After to the
+// tasks of the flow.
+func (c *compiler) linkAfter(f *flow) {
+	// Tasks by constant instrumentation name.
+	// nil if more than one task uses the same name.
+	named := make(map[string]*task)
+	for _, t := range f.Tasks {
+		if t.Instrument == nil {
+			continue
+		}
+		tv := c.info.Types[t.Instrument.Name]
+		if tv.Value == nil || tv.Value.Kind() != constant.String {
+			continue
+		}
+		name := constant.StringVal(tv.Value)
+		if _, ok := named[name]; ok {
+			named[name] = nil
+		} else {
+			named[name] = t
+		}
+	}
+
+	for _, t := range f.Tasks {
+		for _, a := range t.After {
+			var target *task
+			if a.Ref != nil {
+				target = f.refs[a.Ref]
+				if target == nil {
+					c.errf(CodeUnknownTaskRef, a.Node, "cff.TaskRef %v is not attached to any task: use cff.Ref", a.Ref.Name())
+					continue
+				}
+			} else {
+				var ok bool
+				target, ok = named[a.Name]
+				if !ok {
+					c.errf(CodeUnknownTaskRef, a.Node, "no task is instrumented with the name %q", a.Name)
+					continue
+				}
+				if target == nil {
+					c.errf(CodeUnknownTaskRef, a.Node, "more than one task is instrumented with the name %q: use cff.Ref", a.Name)
+					continue
+				}
+			}
+
+			if target == t {
+				c.errf(CodeInvalidOption, a.Node, "a task cannot run after itself")
+				continue
+			}
+
+			if target.orderType == nil {
+				target.orderType = f.addOrderOutput()
+			}
+			t.Function.Dependencies = append(t.Function.Dependencies, target.orderType)
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package internal
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"strconv"
+)
+
+// budget holds the argument to cff.Budget.
+type budget struct {
+	ast.Node
+
+	weight constant.Value // constant weight of the task
+	path   constant.Value // weight of the task and the heaviest chain after it
+}
+
+// Weight is the weight of the task.
+func (b *budget) Weight() ast.Expr { return floatLit(b.weight) }
+
+// PathWeight is the weight of the task and of the heaviest chain of tasks
+// with a budget that run after it.
+func (b *budget) PathWeight() ast.Expr { return floatLit(b.path) }
+
+func floatLit(v constant.Value) ast.Expr {
+	f, _ := constant.Float64Val(v)
+	return &ast.BasicLit{
+		Kind:  token.FLOAT,
+		Value: strconv.FormatFloat(f, 'g', -1, 64),
+	}
+}
+
+// compileBudget interprets cff.Budget for task t.
+func (c *compiler) compileBudget(t *task, call *ast.CallExpr) *budget {
+	if t.Budget != nil {
+		c.errf(CodeInvalidOption, call, "cff.Task accepts at most one cff.Budget option")
+		return t.Budget
+	}
+
+	arg := call.Args[0]
+	tv := c.info.Types[arg]
+	if tv.Value == nil || (tv.Value.Kind() != constant.Int && tv.Value.Kind() != constant.Float) {
+		c.errf(CodeInvalidArgument, arg, "cff.Budget expects a constant weight")
+		return nil
+	}
+
+	weight := constant.ToFloat(tv.Value)
+	if constant.Sign(weight) <= 0 {
+		c.errf(CodeInvalidArgument, arg, "cff.Budget expects a positive weight, got %v", tv.Value)
+		return nil
+	}
+
+	if !t.Function.WantCtx {
+		c.errf(CodeInvalidOption, call, "cff.Budget requires a task that accepts a context.Context")
+		return nil
+	}
+
+	return &budget{Node: call, weight: weight, path: weight}
+}
+
+// allocateBudgets computes the weight of the heaviest chain of tasks with
+// cff.Budget that starts at each task with cff.Budget.
+// It must be called after the flow is sorted.
+func (c *compiler) allocateBudgets(f *flow) {
+	dependents := make(map[*task][]*task)
+	for _, t := range f.Tasks {
+		for _, dep := range t.DependsOnTasks() {
+			dependents[dep] = append(dependents[dep], t)
+		}
+	}
+
+	// Weight of the heaviest chain that starts at each task,
+	// including the task itself.
+	chain := make(map[*task]constant.Value, len(f.Tasks))
+	zero := constant.MakeInt64(0)
+	for i := len(f.TopoFuncs) - 1; i >= 0; i-- {
+		t := f.TopoFuncs[i].Task
+		if t == nil {
+			continue
+		}
+
+		after := zero
+		for _, d := range dependents[t] {
+			if w, ok := chain[d]; ok && constant.Compare(w, token.GTR, after) {
+				after = w
+			}
+		}
+
+		if t.Budget == nil {
+			chain[t] = after
+			continue
+		}
+
+		t.Budget.path = constant.BinaryOp(t.Budget.weight, token.ADD, after)
+		chain[t] = t.Budget.path
+	}
+}
@@ -0,0 +1,217 @@
+package internal
+
+import (
+	"go/ast"
+	"go/types"
+	"strconv"
+
+	"golang.org/x/tools/go/ast/astutil"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+// indexBundles records the cff.Bundle calls used to initialize package-level
+// variables in the given files.
+func (c *compiler) indexBundles(files []*ast.File) {
+	c.bundles = make(map[*types.Var]*ast.CallExpr)
+	c.usedBundles = make(map[*ast.CallExpr]struct{})
+	for _, file := range files {
+		for _, decl := range file.Decls {
+			gd, ok := decl.(*ast.GenDecl)
+			if !ok {
+				continue
+			}
+			for _, spec := range gd.Specs {
+				vs, ok := spec.(*ast.ValueSpec)
+				if !ok || len(vs.Names) != len(vs.Values) {
+					continue
+				}
+				for i, value := range vs.Values {
+					call, ok := astutil.Unparen(value).(*ast.CallExpr)
+					if !ok || !c.isCffCall(call, "Bundle") {
+						continue
+					}
+					if v, ok := c.info.Defs[vs.Names[i]].(*types.Var); ok {
+						c.bundles[v] = call
+					}
+				}
+			}
+		}
+	}
+}
+
+// isCffCall reports whether call is a call to the named cff function.
+func (c *compiler) isCffCall(call *ast.CallExpr, name string) bool {
+	fn := typeutil.StaticCallee(c.info, call)
+	return fn != nil && fn.Name() == name && isPackagePathEquivalent(fn.Pkg(), cffImportPath)
+}
+
+// isBundleDecl reports whether call is a cff.Bundle call used to initialize
+// a package-level variable.
+func (c *compiler) isBundleDecl(call *ast.CallExpr) bool {
+	for _, b := range c.bundles {
+		if b == call {
+			return true
+		}
+	}
+	return false
+}
+
+// bundleOnlyImports returns the imports of the file that are referenced
+// only inside cff.Bundle declarations.
+//
+// Bundles used by flows of the same file are not considered because the
+// generated code of these flows references the same imports.
+func (c *compiler) bundleOnlyImports(file *ast.File) []*ast.ImportSpec {
+	var decls []*ast.CallExpr
+	for _, call := range c.bundles {
+		if _, used := c.usedBundles[call]; used {
+			continue
+		}
+		if call.Pos() >= file.Pos() && call.End() <= file.End() {
+			decls = append(decls, call)
+		}
+	}
+	if len(decls) == 0 {
+		return nil
+	}
+
+	// Whether id is inside a cff.Bundle call.
+	// The cff package referenced by the call itself remains in use because
+	// the call is replaced with a cff.TaskBundle.
+	inBundle := func(id *ast.Ident) bool {
+		for _, call := range decls {
+			if sel, ok := call.Fun.(*ast.SelectorExpr); ok && sel.X == id {
+				return false
+			}
+			if call.Pos() <= id.Pos() && id.End() <= call.End() {
+				return true
+			}
+		}
+		return false
+	}
+
+	usedOutside := make(map[*types.PkgName]bool)
+	ast.Inspect(file, func(n ast.Node) bool {
+		id, ok := n.(*ast.Ident)
+		if !ok {
+			return true
+		}
+		if pn, ok := c.info.Uses[id].(*types.PkgName); ok {
+			usedOutside[pn] = usedOutside[pn] || !inBundle(id)
+		}
+		return true
+	})
+
+	var imports []*ast.ImportSpec
+	for _, spec := range file.Imports {
+		var obj types.Object
+		if spec.Name != nil {
+			obj = c.info.Defs[spec.Name]
+		} else {
+			obj = c.info.Implicits[spec]
+		}
+		pn, ok := obj.(*types.PkgName)
+		if !ok {
+			continue
+		}
+		if outside, used := usedOutside[pn]; used && !outside {
+			imports = append(imports, spec)
+		}
+	}
+	return imports
+}
+
+// compileUse adds the tasks and parameters of the bundle passed to cff.Use
+// to the flow.
+func (c *compiler) compileUse(f *flow, file *ast.File, call *ast.CallExpr) {
+	f.usesBundles = true
+	arg := astutil.Unparen(call.Args[0])
+
+	var obj types.Object
+	switch e := arg.(type) {
+	case *ast.Ident:
+		obj = c.info.Uses[e]
+	case *ast.SelectorExpr:
+		obj = c.info.Uses[e.Sel]
+	}
+
+	v, _ := obj.(*types.Var)
+	bundle, ok := c.bundles[v]
+	if !ok {
+		c.errf(CodeInvalidArgument, arg, "cff.Use expects a package-level variable initialized with cff.Bundle, got %v", astutil.NodeDescription(arg))
+		return
+	}
+	c.usedBundles[bundle] = struct{}{}
+
+	for _, opt := range bundle.Args {
+		ce, fn, err := c.identifyOption(opt)
+		if err != nil {
+			c.errf(CodeExpectedDirective, opt, err.Error())
+			continue
+		}
+
+		switch fn.Name() {
+		case "Params":
+			n := len(f.Inputs)
+			c.compileParams(f, ce)
+			for _, in := range f.Inputs[n:] {
+				in.bundled = true
+			}
+		case "Task":
+			if t := c.compileFlowTask(f, ce); t != nil {
+				t.Bundled = true
+			}
+		default:
+			c.errf(CodeInvalidOption, opt, "cff.Bundle may only contain cff.Task and cff.Params, got cff.%v", fn.Name())
+		}
+	}
+
+	c.addBundleImports(f, file, v, bundle)
+}
+
+// addBundleImports records the packages referenced by a bundle that the
+// file using it doesn't import, so that generated code may import them.
+func (c *compiler) addBundleImports(f *flow, file *ast.File, v *types.Var, bundle *ast.CallExpr) {
+	imported := make(map[string]string) // local name => import path
+	for _, spec := range file.Imports {
+		importPath, _ := strconv.Unquote(spec.Path.Value)
+		if spec.Name != nil {
+			imported[spec.Name.Name] = importPath
+		} else if pn, ok := c.info.Implicits[spec].(*types.PkgName); ok {
+			imported[pn.Name()] = importPath
+		}
+	}
+	for _, pn := range f.BundleImports {
+		imported[pn.Name()] = pn.Imported().Path()
+	}
+
+	ast.Inspect(bundle, func(n ast.Node) bool {
+		id, ok := n.(*ast.Ident)
+		if !ok {
+			return true
+		}
+		pn, ok := c.info.Uses[id].(*types.PkgName)
+		if !ok {
+			return true
+		}
+
+		importPath := pn.Imported().Path()
+		switch other, ok := imported[pn.Name()]; {
+		case !ok:
+			imported[pn.Name()] = importPath
+			f.BundleImports = append(f.BundleImports, pn)
+		case other != importPath:
+			c.errf(CodeInvalidOption, id, "bundle %v refers to %q as %v, but %v refers to %q in %v",
+				v.Name(), importPath, pn.Name(), pn.Name(), other, c.fset.File(file.Pos()).Name())
+		}
+		return true
+	})
+}
+
+// bundled reports whether the function was added to the flow with cff.Use.
+func (f *function) bundled() bool {
+	if f.Predicate != nil {
+		return f.Predicate.Task.Bundled
+	}
+	return f.Task != nil && f.Task.Bundled
+}
@@ -0,0 +1,91 @@
+package internal
+
+import (
+	"go/types"
+	"strconv"
+
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+// collectOutput is a sentinel return type for tasks marked with cff.Collect.
+// Such tasks don't provide their outputs directly: a collector depends on
+// this sentinel instead.
+type collectOutput = types.Struct
+
+// collector gathers the values of a type produced by cff.Collect tasks
+// into a slice.
+type collector struct {
+	Elem    types.Type // type of the collected values
+	Sources []*collectSource
+}
+
+// collectSource is a value collected by a collector.
+type collectSource struct {
+	Task  *task
+	Index int // index of the value in the task's Outputs
+}
+
+// addCollectOutput creates a unique sentinel type for a cff.Collect task.
+func (f *flow) addCollectOutput() *collectOutput {
+	f.collectTypeCnt++
+	name := "collect" + strconv.Itoa(f.collectTypeCnt)
+	field := types.NewVar(0, nil, name, &types.Struct{})
+	return types.NewStruct([]*types.Var{field}, nil)
+}
+
+// addCollectors adds a task to the flow for each type produced by
+// cff.Collect tasks. The task provides a slice of that type.
+func (c *compiler) addCollectors(f *flow) {
+	var (
+		collectors []*collector
+		byElem     typeutil.Map // map[types.Type]*collector
+	)
+	for _, t := range f.Tasks {
+		if !t.Collect {
+			continue
+		}
+
+		for i, o := range t.Outputs {
+			col, ok := byElem.At(o).(*collector)
+			if !ok {
+				col = &collector{Elem: o}
+				byElem.Set(o, col)
+				collectors = append(collectors, col)
+			}
+			col.Sources = append(col.Sources, &collectSource{Task: t, Index: i})
+		}
+	}
+
+	for _, col := range collectors {
+		first := col.Sources[0].Task
+
+		var deps []types.Type
+		bundled := true // whether all sources were added with cff.Use
+		for _, src := range col.Sources {
+			src.Task.TrackProduced = true
+			deps = append(deps, src.Task.collectType)
+			bundled = bundled && src.Task.Bundled
+		}
+
+		outputs := []types.Type{types.NewSlice(col.Elem)}
+		t := &task{
+			Node:      first.Node,
+			Serial:    c.taskSerial,
+			Outputs:   outputs,
+			Collector: col,
+			Bundled:   bundled,
+			PosInfo:   first.PosInfo,
+		}
+		c.taskSerial++
+
+		t.Function = &function{
+			Node:         first.Node,
+			Sig:          types.NewSignatureType(nil, nil, nil, nil, tuple(outputs), false),
+			Dependencies: deps,
+			Task:         t,
+			PosInfo:      t.PosInfo,
+		}
+		f.Tasks = append(f.Tasks, t)
+		f.Funcs = append(f.Funcs, t.Function)
+	}
+}
@@ -0,0 +1,97 @@
+package internal
+
+import (
+	"go/ast"
+	"go/types"
+)
+
+// compileConditional determines whether a function reports whether it
+// produced its result, either by returning (T, bool) or cff.Maybe[T].
+//
+// The boolean result of a (T, bool) function doesn't correspond to an
+// output, so it is removed from the function's outputs.
+func (c *compiler) compileConditional(expr ast.Expr, f *compiledFunc) {
+	for _, r := range f.Results {
+		if r.Maybe && len(f.Results) > 1 {
+			c.errf(CodeInvalidFunction, expr, "a function that returns %v cannot return other values", r.Type)
+			return
+		}
+	}
+
+	switch len(f.Results) {
+	case 1:
+		f.Conditional = f.Results[0].Maybe
+	case 2:
+		value, ok := f.Results[0], f.Results[1]
+		if value.Fields != nil || ok.Fields != nil || !types.Identical(ok.Type, types.Typ[types.Bool]) {
+			return
+		}
+		ok.Ok = true
+		f.Outputs = f.Outputs[:len(f.Outputs)-1]
+		f.Conditional = true
+	}
+}
+
+// linkConditions records the conditional tasks that provide the inputs of
+// each task and predicate so that generated code can skip them if a value
+// they depend on was not produced.
+//
+// Parameters of the type cff.Maybe[T] don't need the value to be produced.
+func (c *compiler) linkConditions(f *flow) {
+	for _, fn := range f.allFuncs() {
+		var (
+			params []*funcObject
+			conds  []*task
+		)
+		if fn.Predicate != nil {
+			params = fn.Predicate.Params
+		} else {
+			params = fn.Task.Params
+		}
+
+		inputs := fn.inputs()
+		for _, p := range params {
+			if p.Maybe {
+				continue
+			}
+
+			indexes := []int{p.Index}
+			if p.Fields != nil {
+				indexes = indexes[:0]
+				for _, field := range p.Fields {
+					indexes = append(indexes, field.Index)
+				}
+			}
+
+			for _, i := range indexes {
+				idx, ok := f.providers.At(inputs[i]).(int)
+				if !ok {
+					continue
+				}
+				if provider := f.Funcs[idx].Task; provider != nil && provider.Conditional {
+					conds = appendTask(conds, provider)
+				}
+			}
+		}
+
+		for _, t := range conds {
+			t.trackProduced()
+			if fn.Predicate != nil {
+				fn.Predicate.Conditions = appendTask(fn.Predicate.Conditions, t)
+				fn.Predicate.Task.Conditions = appendTask(fn.Predicate.Task.Conditions, t)
+			} else {
+				fn.Task.Conditions = appendTask(fn.Task.Conditions, t)
+			}
+		}
+	}
+}
+
+// appendTask appends t to tasks if it isn't already present.
+func appendTask(tasks []*task, t *task) []*task {
+	for _, other := range tasks {
+		if other == t {
+			return tasks
+		}
+	}
+	return append(tasks, t)
+}
@@ -0,0 +1,95 @@
+package internal
+
+import (
+	"go/ast"
+	"go/constant"
+)
+
+// group is a named group of tasks of a flow declared with cff.Group.
+type group struct {
+	ast.Node
+
+	// Serial is a unique serially incrementing number for each group
+	// of a flow.
+	Serial int
+
+	Name        string   // constant name of the group
+	Concurrency ast.Expr // maximum number of tasks of the group that run at once
+}
+
+// groupRef is a reference to a group passed to cff.InGroup.
+type groupRef struct {
+	Node ast.Expr
+	Name string
+}
+
+// groupName returns the value of a constant string argument of cff.Group
+// or cff.InGroup.
+func (c *compiler) groupName(directive string, arg ast.Expr) (string, bool) {
+	tv := c.info.Types[arg]
+	if tv.Value == nil || tv.Value.Kind() != constant.String {
+		c.errf(CodeInvalidArgument, arg, "cff.%v expects a constant string name", directive)
+		return "", false
+	}
+	return constant.StringVal(tv.Value), true
+}
+
+// compileGroup interprets cff.Group(name, n) for the flow.
+func (c *compiler) compileGroup(f *flow, call *ast.CallExpr) {
+	name, ok := c.groupName("Group", call.Args[0])
+	if !ok {
+		return
+	}
+
+	for _, other := range f.Groups {
+		if other.Name == name {
+			c.errf(CodeInvalidOption, call, "cff.Group %q is already declared at %v", name, c.nodePosition(other)).
+				relatef(c.nodePosition(other), "cff.Group %q first declared here", name)
+			return
+		}
+	}
+
+	f.Groups = append(f.Groups, &group{
+		Node:        call,
+		Serial:      len(f.Groups),
+		Name:        name,
+		Concurrency: call.Args[1],
+	})
+}
+
+// compileInGroup interprets cff.InGroup(name) for task t.
+// The group is resolved by linkGroups once all groups of the flow are known.
+func (c *compiler) compileInGroup(t *task, call *ast.CallExpr) {
+	if t.inGroup != nil {
+		c.errf(CodeInvalidOption, call, "a task may be added to only one cff.Group")
+		return
+	}
+
+	name, ok := c.groupName("InGroup", call.Args[0])
+	if !ok {
+		return
+	}
+	t.inGroup = &groupRef{Node: call.Args[0], Name: name}
+}
+
+// linkGroups resolves the groups that tasks were added to with cff.InGroup.
+func (c *compiler) linkGroups(f *flow) {
+	named := make(map[string]*group, len(f.Groups))
+	for _, g := range f.Groups {
+		named[g.Name] = g
+	}
+
+	for _, t := range f.Tasks {
+		ref := t.inGroup
+		if ref == nil {
+			continue
+		}
+
+		g, ok := named[ref.Name]
+		if !ok {
+			c.errf(CodeUnknownGroup, ref.Node, "no cff.Group is declared with the name %q", ref.Name)
+			continue
+		}
+		t.Group = g
+	}
+}
@@ -0,0 +1,190 @@
+package internal
+
+import (
+	"go/ast"
+
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+// pruneTasks removes the tasks and parameters of the flow that don't
+// contribute to it.
+//
+// Tasks are kept if they run for side effects (cff.Invoke), or if they
+// provide values consumed by the flow's results or by other tasks that are
+// kept. Unless the flow uses cff.Lazy, only tasks added with cff.Use may be
+// removed.
+//
+// Expressions of the removed tasks and parameters that were specified in the
+// flow are recorded in Omitted so that the variables they reference remain
+// in use in the generated code.
+func (c *compiler) pruneTasks(f *flow) {
+	if !f.Lazy && !f.usesBundles {
+		return
+	}
+
+	var (
+		providers      typeutil.Map // map[types.Type]*function
+		consumedBefore typeutil.Map // map[types.Type]struct{}
+	)
+	for _, fn := range f.Funcs {
+		for _, o := range fn.outputs() {
+			if providers.At(o) == nil {
+				providers.Set(o, fn)
+			}
+		}
+	}
+	for _, fn := range f.allFuncs() {
+		for _, dep := range fn.Dependencies {
+			consumedBefore.Set(dep, struct{}{})
+		}
+	}
+
+	var (
+		needed = make(map[*function]struct{})
+		queue  []*function
+	)
+	visit := func(fn *function) {
+		if _, ok := needed[fn]; !ok {
+			needed[fn] = struct{}{}
+			queue = append(queue, fn)
+		}
+	}
+	for _, fn := range f.Funcs {
+		invoke := fn.Task != nil && fn.Task.invokeType != nil
+		if invoke || (!f.Lazy && !fn.bundled()) {
+			visit(fn)
+		}
+	}
+	for _, o := range f.Outputs {
+		if fn, ok := providers.At(o.Type).(*function); ok {
+			visit(fn)
+		}
+	}
+	for len(queue) > 0 {
+		fn := queue[0]
+		queue = queue[1:]
+		for _, dep := range fn.Dependencies {
+			if p, ok := providers.At(dep).(*function); ok {
+				visit(p)
+			}
+		}
+	}
+
+	var funcs []*function
+	for _, fn := range f.Funcs {
+		if _, ok := needed[fn]; ok {
+			funcs = append(funcs, fn)
+		}
+	}
+	f.Funcs = funcs
+
+	// Tasks of a cff.Switch are kept with the Switch.
+	kept := make(map[*task]struct{})
+	for fn := range needed {
+		if fn.Task == nil {
+			continue
+		}
+		kept[fn.Task] = struct{}{}
+		if fn.Task.Switch != nil {
+			for _, t := range fn.Task.Switch.tasks() {
+				kept[t] = struct{}{}
+			}
+		}
+	}
+
+	var tasks []*task
+	for _, t := range f.Tasks {
+		if _, ok := kept[t]; ok {
+			t.Lazy = f.Lazy
+			tasks = append(tasks, t)
+		} else if !t.Bundled {
+			f.Omitted = append(f.Omitted, t.exprs()...)
+		}
+	}
+	f.Tasks = tasks
+
+	var preds []*predicate
+	for _, p := range f.Predicates {
+		if _, ok := needed[p.Function]; ok {
+			preds = append(preds, p)
+		} else if !p.Task.Bundled {
+			f.Omitted = append(f.Omitted, p.Function.Node.(ast.Expr))
+		}
+	}
+	f.Predicates = preds
+
+	var subflows []*subflow
+	for _, sf := range f.Subflows {
+		var sfTasks []*task
+		for _, t := range sf.Tasks {
+			if _, ok := kept[t]; ok {
+				sfTasks = append(sfTasks, t)
+			}
+		}
+		sf.Tasks = sfTasks
+		if len(sfTasks) > 0 {
+			subflows = append(subflows, sf)
+		} else {
+			f.Omitted = append(f.Omitted, sf.exprs()...)
+		}
+	}
+	f.Subflows = subflows
+
+	var consumed typeutil.Map // map[types.Type]struct{}
+	for _, fn := range f.allFuncs() {
+		for _, dep := range fn.Dependencies {
+			consumed.Set(dep, struct{}{})
+		}
+	}
+	for _, o := range f.Outputs {
+		consumed.Set(o.Type, struct{}{})
+	}
+
+	// Parameters that were consumed only by removed tasks are removed.
+	// Parameters that were never consumed remain to be reported as unused.
+	var inputs []*input
+	for _, in := range f.Inputs {
+		switch {
+		case consumed.At(in.Type) != nil:
+			inputs = append(inputs, in)
+		case in.bundled:
+			// Parameters of bundles are always optional.
+		case f.Lazy && consumedBefore.At(in.Type) != nil:
+			f.Omitted = append(f.Omitted, in.Node)
+		default:
+			inputs = append(inputs, in)
+		}
+	}
+	f.Inputs = inputs
+}
+
+// exprs returns the user-provided expressions of a task,
+// excluding those of its predicate.
+func (t *task) exprs() []ast.Expr {
+	if t.Switch != nil || t.Collector != nil {
+		// The tasks and predicates of a cff.Switch are separate
+		// from the Switch, and collectors have no expressions.
+		return nil
+	}
+
+	exprs := []ast.Expr{t.Node.(ast.Expr)}
+	if t.Instrument != nil {
+		exprs = append(exprs, t.Instrument.Name)
+	}
+	return append(exprs, t.FallbackWithResults...)
+}
+
+// exprs returns the user-provided expressions of a subflow,
+// excluding those of its tasks.
+func (sf *subflow) exprs() []ast.Expr {
+	var exprs []ast.Expr
+	for _, e := range []ast.Expr{sf.Concurrency, sf.ContinueOnError} {
+		if e != nil {
+			exprs = append(exprs, e)
+		}
+	}
+	if sf.Instrument != nil {
+		exprs = append(exprs, sf.Instrument.Name)
+	}
+	return append(exprs, sf.Emitters...)
+}
@@ -3,6 +3,8 @@ package internal
 import (
 	"errors"
 	"go/ast"
+	"go/constant"
+	"go/token"
 	"go/types"
 
 	"go.uber.org/cff/internal/modifier"
@@ -18,8 +20,21 @@ type parallel struct {
 
 	ContinueOnError ast.Expr // argument to cff.ContinueOnError.
 
+	RateLimit ast.Expr // argument to cff.RateLimit, if any.
+
+	// Quorum is the number of tasks that must succeed for the Parallel to
+	// stop early: the argument to cff.Quorum, or 1 for cff.FirstSuccess.
+	Quorum ast.Expr
+	// QuorumDynamic is true if Quorum is not a constant,
+	// and must be verified at run time.
+	QuorumDynamic bool
+
+	RethrowPanics bool // whether cff.RethrowPanics was provided
+
 	Emitters []ast.Expr // zero or more expressions of the type cff.Emitter.
 
+	Interceptors []ast.Expr // zero or more expressions of the type cff.Interceptor.
+
 	Tasks []*parallelTask
 
 	SliceTasks []*sliceTask
@@ -40,12 +55,27 @@ type parallelTask struct {
 
 	Instrument *instrument
 
+	Predicate        *parallelPredicate // non-nil if cff.Predicate was provided
+	PredicateOnError ast.Expr           // argument to cff.PredicateOnError, if any
+	FallbackWith     bool               // whether cff.FallbackWith was provided
+	RateLimit        ast.Expr           // argument to cff.RateLimit, if any
+	Hedge            *hedge             // non-nil if cff.Hedge was provided
+	Quorum           bool               // whether the Parallel has a quorum
+
 	PosInfo *PosInfo // Used to pass information to uniquely identify a task.
 }
 
+// parallelPredicate is a cff.Predicate of a task of a cff.Parallel.
+// It runs as part of the task.
+type parallelPredicate struct {
+	Function *compiledFunc
+
+	PosInfo *PosInfo // position of the cff.Predicate call
+}
+
 func (c *compiler) compileParallel(file *ast.File, call *ast.CallExpr) *parallel {
 	if len(call.Args) == 1 {
-		c.errf(c.nodePosition(call), "cff.Parallel expects at least one function")
+		c.errf(CodeNoTasks, call, "cff.Parallel expects at least one function")
 		return nil
 	}
 
@@ -54,24 +84,25 @@ func (c *compiler) compileParallel(file *ast.File, call *ast.CallExpr) *parallel
 		Node:    call,
 		PosInfo: c.getPosInfo(call),
 	}
+	var quorumOpt *ast.CallExpr // cff.Quorum or cff.FirstSuccess, if any
 	for _, arg := range call.Args[1:] {
 		arg := astutil.Unparen(arg)
 
 		ce, ok := arg.(*ast.CallExpr)
 		if !ok {
-			c.errf(c.nodePosition(arg), "expected a function call, got %v", astutil.NodeDescription(arg))
+			c.errf(CodeExpectedDirective, arg, "expected a function call, got %v", astutil.NodeDescription(arg))
 			continue
 		}
 
 		f := typeutil.StaticCallee(c.info, ce)
 		if f == nil || !isPackagePathEquivalent(f.Pkg(), cffImportPath) {
-			c.errf(c.nodePosition(arg), "expected cff call but got %v", typeutil.Callee(c.info, ce))
+			c.errf(CodeExpectedDirective, arg, "expected cff call but got %v", typeutil.Callee(c.info, ce))
 			continue
 		}
 
 		switch f.Name() {
-		case "InstrumentFlow":
-			c.errf(c.nodePosition(arg), "%q is an invalid cff.Parallel Option", f.Name())
+		case "InstrumentFlow", "AllowAssignable", "Lazy", "Switch", "Use", "Subflow", "Group":
+			c.errf(CodeInvalidOption, arg, "%q is an invalid cff.Parallel Option", f.Name())
 			continue
 		case "Task":
 			if t := c.compileParallelTask(parallel, ce.Args[0], ce.Args[1:]); t != nil {
@@ -84,6 +115,21 @@ func (c *compiler) compileParallel(file *ast.File, call *ast.CallExpr) *parallel
 			parallel.modifiers = append(parallel.modifiers, modifier.NewConcurrencyModifier(c.fset, ce.Fun, parallel.Concurrency))
 		case "ContinueOnError":
 			parallel.ContinueOnError = ce.Args[0]
+		case "RateLimit":
+			parallel.RateLimit = ce.Args[0]
+		case "Quorum", "FirstSuccess":
+			if quorumOpt != nil {
+				c.errf(CodeInvalidOption, ce, "cff.Parallel accepts at most one cff.Quorum or cff.FirstSuccess option")
+				continue
+			}
+			quorumOpt = ce
+			if f.Name() == "FirstSuccess" {
+				parallel.Quorum = &ast.BasicLit{Kind: token.INT, Value: "1"}
+			} else {
+				parallel.Quorum = ce.Args[0]
+			}
+		case "RethrowPanics":
+			parallel.RethrowPanics = true
 		case "InstrumentParallel":
 			parallel.Instrument = c.compileInstrument(ce)
 		case "Slice":
@@ -92,6 +138,8 @@ func (c *compiler) compileParallel(file *ast.File, call *ast.CallExpr) *parallel
 			}
 		case "WithEmitter":
 			parallel.Emitters = append(parallel.Emitters, ce.Args[0])
+		case "WithInterceptor":
+			parallel.Interceptors = append(parallel.Interceptors, ce.Args[0])
 		case "Map":
 			if mt := c.compileMap(ce); mt != nil {
 				parallel.MapTasks = append(parallel.MapTasks, mt)
@@ -99,22 +147,60 @@ func (c *compiler) compileParallel(file *ast.File, call *ast.CallExpr) *parallel
 		}
 	}
 	c.validateParallelInstrument(parallel)
+	if quorumOpt != nil {
+		c.validateQuorum(parallel, quorumOpt)
+	}
 
+	// Recovered failures of elements of Slices and Maps are reported to
+	// emitters only if the Parallel is instrumented.
+	// Tasks that were skipped or recovered from failures don't count
+	// towards the quorum.
+	quorum := parallel.Quorum != nil
+	for _, t := range parallel.Tasks {
+		t.Quorum = quorum
+	}
 	for _, s := range parallel.SliceTasks {
-		if s.SliceEndFn != nil && parallel.ContinueOnError != nil {
-			c.errf(c.nodePosition(parallel.Node), `"cff.SliceEnd" is an invalid option when "ContinueOnError" is used`)
-		}
+		s.Instrumented = parallel.Instrument != nil
+		s.ContinueOnError = parallel.ContinueOnError
+		s.Quorum = quorum
 	}
-
 	for _, m := range parallel.MapTasks {
-		if m.MapEndFn != nil && parallel.ContinueOnError != nil {
-			c.errf(c.nodePosition(parallel.Node), `"cff.MapEnd" is an invalid option when "ContinueOnError" is used`)
-		}
+		m.Instrumented = parallel.Instrument != nil
+		m.Quorum = quorum
 	}
 
 	return parallel
 }
 
+// validateQuorum verifies that the options of a Parallel are compatible
+// with cff.Quorum or cff.FirstSuccess.
+// Tasks of such a Parallel don't all run,
+// so nothing may run after all of them.
+func (c *compiler) validateQuorum(p *parallel, opt *ast.CallExpr) {
+	name := typeutil.StaticCallee(c.info, opt).Name()
+	if name == "Quorum" {
+		k := opt.Args[0]
+		if v := c.info.Types[k].Value; v == nil {
+			p.QuorumDynamic = true
+		} else if constant.Compare(v, token.LSS, constant.MakeInt64(1)) {
+			c.errf(CodeInvalidOption, k, "cff.Quorum expects at least 1 task, got %v", v)
+		}
+	}
+	if p.ContinueOnError != nil {
+		c.errf(CodeInvalidOption, opt, "cff.%v cannot be used with cff.ContinueOnError", name)
+	}
+	for _, s := range p.SliceTasks {
+		if s.SliceEndFn != nil {
+			c.errf(CodeInvalidOption, s.SliceEndFn, "cff.SliceEnd cannot be used with cff.%v", name)
+		}
+	}
+	for _, m := range p.MapTasks {
+		if m.MapEndFn != nil {
+			c.errf(CodeInvalidOption, m.MapEndFn, "cff.MapEnd cannot be used with cff.%v", name)
+		}
+	}
+}
+
 func (c *compiler) validateParallelInstrument(p *parallel) {
 	// If the directive, or any task in the directive were instrumented, we require
 	// at least one emitter to be provided.
@@ -123,12 +209,12 @@ func (c *compiler) validateParallelInstrument(p *parallel) {
 	}
 
 	if p.Instrument != nil {
-		c.errf(c.nodePosition(p.Node), "cff.InstrumentParallel requires a cff.Emitter to be provided: use cff.WithEmitter")
+		c.errf(CodeMissingEmitter, p.Node, "cff.InstrumentParallel requires a cff.Emitter to be provided: use cff.WithEmitter")
 	}
 
 	for _, t := range p.Tasks {
 		if t.Instrument != nil {
-			c.errf(c.nodePosition(p.Node), "cff.Instrument requires a cff.Emitter to be provided: use cff.WithEmitter")
+			c.errf(CodeMissingEmitter, p.Node, "cff.Instrument requires a cff.Emitter to be provided: use cff.WithEmitter")
 		}
 	}
 }
@@ -136,23 +222,82 @@ func (c *compiler) validateParallelInstrument(p *parallel) {
 func (c *compiler) compileParallelTask(p *parallel, call ast.Expr, opts []ast.Expr) *parallelTask {
 	t := c.compileParallelTaskFn(p, call)
 	if t == nil {
-		c.errf(c.nodePosition(call), "parallel task failed to compile")
+		c.errf(CodeInvalidFunction, call, "parallel task failed to compile")
 		return nil
 	}
+	var fallbackOpt, predicateOnErrorOpt ast.Expr
 	for _, opt := range opts {
 		call, fn, err := c.identifyOption(opt)
 		if err != nil {
-			c.errf(c.nodePosition(opt), err.Error())
+			c.errf(CodeInvalidOption, opt, err.Error())
 			continue
 		}
 		switch fn.Name() {
 		case "Instrument":
 			t.Instrument = c.compileInstrument(call)
+		case "Predicate":
+			t.Predicate = c.compileParallelPredicate(call)
+		case "PredicateOnError":
+			t.PredicateOnError = call.Args[0]
+			predicateOnErrorOpt = opt
+		case "FallbackWith":
+			if len(call.Args) > 0 {
+				c.errf(CodeInvalidFallback, opt, "cff.FallbackWith must produce the same number of results as the task: "+"expected 0, got %v", len(call.Args))
+				continue
+			}
+			t.FallbackWith = true
+			fallbackOpt = opt
+		case "RateLimit":
+			t.RateLimit = call.Args[0]
+		case "Hedge":
+			t.Hedge = c.compileHedge(t.Hedge, call)
+		case "Optional":
+			c.errf(CodeInvalidOption, opt, "cff.Optional is only supported by cff.Flow tasks")
+		case "Collect", "Ref", "After", "InGroup", "Budget":
+			c.errf(CodeInvalidOption, opt, "cff.%v is only supported by cff.Flow tasks", fn.Name())
+		}
+	}
+
+	predicateHasError := t.Predicate != nil && t.Predicate.Function.HasError
+	if t.FallbackWith && !t.Function.HasError && !predicateHasError {
+		c.errf(CodeInvalidFallback, fallbackOpt, "Task must return an error for FallbackWith to be used")
+		t.FallbackWith = false
+	}
+
+	if t.PredicateOnError != nil {
+		switch {
+		case t.Predicate == nil:
+			c.errf(CodeInvalidPredicate, predicateOnErrorOpt, "cff.PredicateOnError requires cff.Predicate")
+		case !predicateHasError:
+			c.errf(CodeInvalidPredicate, predicateOnErrorOpt, "cff.PredicateOnError requires a predicate that returns an error")
 		}
 	}
 	return t
 }
 
+// compileParallelPredicate compiles the cff.Predicate of a task of
+// a cff.Parallel.
+func (c *compiler) compileParallelPredicate(call *ast.CallExpr) *parallelPredicate {
+	fn := call.Args[0]
+	if !c.validatePredicateSignature(fn) {
+		return nil
+	}
+
+	compiledFunc := c.compileFunction(fn)
+	if compiledFunc == nil {
+		return nil
+	}
+	if len(compiledFunc.Params) != 0 {
+		c.errf(CodeInvalidPredicate, fn, "predicates of cff.Parallel tasks may only accept a context.Context")
+		return nil
+	}
+
+	return &parallelPredicate{
+		Function: compiledFunc,
+		PosInfo:  c.getPosInfo(call),
+	}
+}
+
 func (c *compiler) compileParallelTasks(p *parallel, call *ast.CallExpr) []*parallelTask {
 	var tasks []*parallelTask
 	for _, arg := range call.Args {
@@ -167,11 +312,11 @@ func (c *compiler) compileParallelTasks(p *parallel, call *ast.CallExpr) []*para
 func (c *compiler) compileParallelTaskFn(p *parallel, arg ast.Expr) *parallelTask {
 	taskF := c.compileFunction(arg)
 	if taskF == nil {
-		c.errf(c.nodePosition(arg), "parallel tasks function failed to compile")
+		c.errf(CodeInvalidFunction, arg, "parallel tasks function failed to compile")
 		return nil
 	}
 	if err := checkParallelTask(taskF); err != nil {
-		c.errf(c.nodePosition(arg), "parallel tasks function is invalid: %v", err)
+		c.errf(CodeInvalidFunction, arg, "parallel tasks function is invalid: %v", err)
 		return nil
 	}
 	fn := &function{
@@ -192,9 +337,9 @@ func (c *compiler) compileParallelTaskFn(p *parallel, arg ast.Expr) *parallelTas
 
 func checkParallelTask(fn *compiledFunc) error {
 	switch {
-	case len(fn.Inputs) != 0:
+	case len(fn.Params) != 0:
 		return errors.New("the only allowed argument is a single context.Context parameter")
-	case len(fn.Outputs) != 0:
+	case len(fn.Results) != 0:
 		return errors.New("the only allowed return value is an error")
 	default:
 		return nil
@@ -207,6 +352,35 @@ type sliceTask struct {
 	ElemType   types.Type
 	SliceEndFn *compiledFunc
 
+	// EndWantsErrors is true if SliceEndFn accepts cff.ElementErrors.
+	// Errors of elements are then passed to it instead of failing the
+	// Parallel.
+	EndWantsErrors bool
+
+	// Fallback is the argument to cff.SliceFallback, if any.
+	Fallback *compiledFunc
+	// FallbackHasIndex is true if the fallback accepts the element index.
+	FallbackHasIndex bool
+
+	// Instrumented is true if recovered failures should be reported to
+	// the emitter of the Parallel.
+	Instrumented bool
+
+	// Quorum is true if the Parallel has a quorum.
+	// Elements recovered by the fallback then don't count towards it.
+	Quorum bool
+
+	// Batch is the argument to cff.Batch, if any.
+	Batch ast.Expr
+
+	// RateLimit is the argument to cff.RateLimit, if any.
+	RateLimit ast.Expr
+
+	// ContinueOnError is the argument to cff.ContinueOnError of the
+	// Parallel, if any.
+	// It decides whether a batch stops at the first failed element.
+	ContinueOnError ast.Expr
+
 	// Serial is a unique serially incrementing number for each sliceTask.
 	Serial int
 
@@ -219,39 +393,112 @@ func (c *compiler) applySliceOptions(t *sliceTask, opts []ast.Expr) {
 	for _, opt := range opts {
 		ce, fn, err := c.identifyOption(opt)
 		if err != nil {
-			c.errf(c.nodePosition(opt), err.Error())
+			c.errf(CodeInvalidOption, opt, err.Error())
 			continue
 		}
 
 		switch fn.Name() {
 		case "SliceEnd":
-			sliceEndFn := c.compileSliceEnd(opt, ce)
+			sliceEndFn, wantsErrors := c.compileSliceEnd(opt, ce)
 			if sliceEndFn == nil {
 				continue
 			}
 			if t.SliceEndFn != nil {
-				c.errf(c.nodePosition(opt), "cff.Slice accepts at most one cff.SliceEnd option")
+				c.errf(CodeInvalidOption, opt, "cff.Slice accepts at most one cff.SliceEnd option")
 				continue
 			}
 			t.SliceEndFn = sliceEndFn
+			t.EndWantsErrors = wantsErrors
+		case "SliceFallback":
+			if t.Fallback != nil {
+				c.errf(CodeInvalidOption, opt, "cff.Slice accepts at most one cff.SliceFallback option")
+				continue
+			}
+			c.compileSliceFallback(t, opt, ce)
+		case "Batch":
+			if t.Batch != nil {
+				c.errf(CodeInvalidOption, opt, "cff.Slice accepts at most one cff.Batch option")
+				continue
+			}
+			t.Batch = ce.Args[0]
+		case "RateLimit":
+			t.RateLimit = ce.Args[0]
 		}
 	}
 }
 
-func (c *compiler) compileSliceEnd(opt ast.Expr, ce *ast.CallExpr) *compiledFunc {
+// compileSliceFallback compiles the cff.SliceFallback of t.
+// The fallback accepts the same arguments as the function of the Slice,
+// followed by an error.
+func (c *compiler) compileSliceFallback(t *sliceTask, opt ast.Expr, ce *ast.CallExpr) {
+	fn := c.compileFunction(ce.Args[0])
+	if fn == nil {
+		c.errf(CodeInvalidFunction, opt, "SliceFallback function failed to compile")
+		return
+	}
+	if len(fn.Results) != 0 || fn.HasError {
+		c.errf(CodeInvalidFunction, opt, "SliceFallback functions must not return any values")
+		return
+	}
+
+	want := []types.Type{t.ElemType, errorType}
+	hasIndex := len(fn.Inputs) == 3
+	if hasIndex {
+		want = append([]types.Type{types.Typ[types.Int]}, want...)
+	}
+	if !acceptsTypes(fn, want) {
+		c.errf(CodeInvalidFunction, opt, "SliceFallback functions must accept the slice index (optional), a slice element of type %v, and an error", t.ElemType)
+		return
+	}
+
+	t.Fallback = fn
+	t.FallbackHasIndex = hasIndex
+}
+
+// acceptsTypes reports whether the non-context parameters of fn accept
+// values of the given types.
+func acceptsTypes(fn *compiledFunc, want []types.Type) bool {
+	if len(fn.Inputs) != len(want) {
+		return false
+	}
+	for i, t := range want {
+		if !types.AssignableTo(t, fn.Inputs[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// compileSliceEnd compiles a cff.SliceEnd function,
+// reporting whether it accepts the cff.ElementErrors of the Slice.
+func (c *compiler) compileSliceEnd(opt ast.Expr, ce *ast.CallExpr) (*compiledFunc, bool) {
 	fn := c.compileFunction(ce.Args[0])
 	switch {
 	case fn == nil:
-		c.errf(c.nodePosition(opt), "SliceEnd function failed to compile")
-		return nil
-	case len(fn.Inputs) != 0:
-		c.errf(c.nodePosition(opt), "the only allowed argument is a single context.Context parameter")
-		return nil
-	case len(fn.Outputs) != 0:
-		c.errf(c.nodePosition(opt), "the only allowed return value is an error")
-		return nil
+		c.errf(CodeInvalidFunction, opt, "SliceEnd function failed to compile")
+		return nil, false
+	case !acceptsElementErrors(fn, types.Typ[types.Int]):
+		c.errf(CodeInvalidFunction, opt, "SliceEnd functions may accept only a context.Context and a cff.ElementErrors[int]")
+		return nil, false
+	case len(fn.Results) != 0:
+		c.errf(CodeInvalidFunction, opt, "the only allowed return value is an error")
+		return nil, false
 	default:
-		return fn
+		return fn, len(fn.Inputs) == 1
+	}
+}
+
+// acceptsElementErrors reports whether the non-context parameters of fn
+// are empty or a single cff.ElementErrors keyed by the given type.
+func acceptsElementErrors(fn *compiledFunc, key types.Type) bool {
+	switch len(fn.Inputs) {
+	case 0:
+		return true
+	case 1:
+		t := fn.Inputs[0]
+		return isCffType(t, "ElementErrors") && types.Identical(t.(*types.Named).TypeArgs().At(0), key)
+	default:
+		return false
 	}
 }
 
@@ -259,31 +506,36 @@ func (c *compiler) compileSlice(p *parallel, ce *ast.CallExpr) *sliceTask {
 	sliceFn, slce := ce.Args[0], ce.Args[1]
 	fn := c.compileFunction(sliceFn)
 	if fn == nil {
-		c.errf(c.nodePosition(sliceFn), "slice function failed to compile")
+		c.errf(CodeInvalidFunction, sliceFn, "slice function failed to compile")
+		return nil
+	}
+
+	if fn.hasObjects() {
+		c.errf(CodeInvalidFunction, sliceFn, "cff.In and cff.Out are only supported by cff.Flow tasks")
 		return nil
 	}
 
 	if len(fn.Outputs) != 0 {
-		c.errf(c.nodePosition(sliceFn), "the only allowed return value is an error")
+		c.errf(CodeInvalidFunction, sliceFn, "the only allowed return value is an error")
 		return nil
 	}
 
 	inputsLen := len(fn.Inputs)
 	if inputsLen != 2 && inputsLen != 1 {
-		c.errf(c.nodePosition(slce), "slice function expects one or two non-context arguments: slice index (optional) and slice element")
+		c.errf(CodeInvalidFunction, slce, "slice function expects one or two non-context arguments: slice index (optional) and slice element")
 		return nil
 	}
 
 	if inputsLen == 2 {
 		if t, ok := fn.Inputs[0].(*types.Basic); !ok || t.Kind() != types.Int {
-			c.errf(c.nodePosition(slce), "the first non-context argument of the slice function must be an int, got %v", fn.Inputs[0])
+			c.errf(CodeInvalidFunction, slce, "the first non-context argument of the slice function must be an int, got %v", fn.Inputs[0])
 			return nil
 		}
 	}
 
 	typ := c.info.TypeOf(slce)
 	if typ == nil {
-		c.errf(c.nodePosition(slce), "type of the slice argument is not found")
+		c.errf(CodeInvalidArgument, slce, "type of the slice argument is not found")
 		return nil
 	}
 
@@ -296,7 +548,7 @@ func (c *compiler) compileSlice(p *parallel, ce *ast.CallExpr) *sliceTask {
 	}
 
 	if !ok {
-		c.errf(c.nodePosition(slce), "the underlying type of the second argument to cff.Slice must be a slice, got %v", typ)
+		c.errf(CodeInvalidArgument, slce, "the underlying type of the second argument to cff.Slice must be a slice, got %v", typ)
 		return nil
 	}
 
@@ -307,7 +559,7 @@ func (c *compiler) compileSlice(p *parallel, ce *ast.CallExpr) *sliceTask {
 	}
 
 	if !types.AssignableTo(fn.Inputs[elemParamPos], slc.Elem()) {
-		c.errf(c.nodePosition(slce), "slice element of type %v cannot be passed as a parameter to function expecting %v", slc.Elem(), fn.Inputs[elemParamPos])
+		c.errf(CodeInvalidArgument, slce, "slice element of type %v cannot be passed as a parameter to function expecting %v", slc.Elem(), fn.Inputs[elemParamPos])
 		return nil
 	}
 
@@ -331,6 +583,25 @@ type mapTask struct {
 	ElemType types.Type
 	MapEndFn *compiledFunc
 
+	// EndWantsErrors is true if MapEndFn accepts cff.ElementErrors.
+	// Errors of elements are then passed to it instead of failing the
+	// Parallel.
+	EndWantsErrors bool
+
+	// Fallback is the argument to cff.MapFallback, if any.
+	Fallback *compiledFunc
+
+	// RateLimit is the argument to cff.RateLimit, if any.
+	RateLimit ast.Expr
+
+	// Instrumented is true if recovered failures should be reported to
+	// the emitter of the Parallel.
+	Instrumented bool
+
+	// Quorum is true if the Parallel has a quorum.
+	// Elements recovered by the fallback then don't count towards it.
+	Quorum bool
+
 	// Serial is a unique serially incrementing number for each mapTask.
 	Serial int
 
@@ -341,34 +612,39 @@ func (c *compiler) compileMap(ce *ast.CallExpr) *mapTask {
 	mapFun, mmap := ce.Args[0], ce.Args[1]
 	fn := c.compileFunction(mapFun)
 	if fn == nil {
-		c.errf(c.nodePosition(mapFun), "map function failed to compile")
+		c.errf(CodeInvalidFunction, mapFun, "map function failed to compile")
+		return nil
+	}
+
+	if fn.hasObjects() {
+		c.errf(CodeInvalidFunction, mapFun, "cff.In and cff.Out are only supported by cff.Flow tasks")
 		return nil
 	}
 
 	if len(fn.Outputs) != 0 {
-		c.errf(c.nodePosition(mapFun), "the only allowed return value is an error")
+		c.errf(CodeInvalidFunction, mapFun, "the only allowed return value is an error")
 		return nil
 	}
 
 	if len(fn.Inputs) != 2 {
-		c.errf(c.nodePosition(mmap), "map function expects two non-context arguments: key and value elements from a map")
+		c.errf(CodeInvalidFunction, mmap, "map function expects two non-context arguments: key and value elements from a map")
 		return nil
 	}
 
 	typ := c.info.TypeOf(mmap)
 	mtype, ok := typ.(*types.Map)
 	if !ok {
-		c.errf(c.nodePosition(mmap), "the second argument to cff.Map must be a map, got %v", typ)
+		c.errf(CodeInvalidArgument, mmap, "the second argument to cff.Map must be a map, got %v", typ)
 		return nil
 	}
 
 	if !types.AssignableTo(fn.Inputs[0], mtype.Key()) {
-		c.errf(c.nodePosition(mmap), "key element of type %v cannot be passed as a parameter to function expecting %v", mtype.Key(), fn.Inputs[0])
+		c.errf(CodeInvalidArgument, mmap, "key element of type %v cannot be passed as a parameter to function expecting %v", mtype.Key(), fn.Inputs[0])
 		return nil
 	}
 
 	if !types.AssignableTo(fn.Inputs[1], mtype.Elem()) {
-		c.errf(c.nodePosition(mmap), "value element of type %v cannot be passed as a parameter to function expecting %v", mtype.Elem(), fn.Inputs[1])
+		c.errf(CodeInvalidArgument, mmap, "value element of type %v cannot be passed as a parameter to function expecting %v", mtype.Elem(), fn.Inputs[1])
 		return nil
 	}
 
@@ -386,42 +662,73 @@ func (c *compiler) compileMap(ce *ast.CallExpr) *mapTask {
 	for _, opt := range ce.Args[2:] {
 		ce, fn, err := c.identifyOption(opt)
 		if err != nil {
-			c.errf(c.nodePosition(opt), err.Error())
+			c.errf(CodeInvalidOption, opt, err.Error())
 			continue
 		}
 
 		switch fn.Name() {
 		case "MapEnd":
-			mapEndFn := c.compileMapEnd(opt, ce)
+			mapEndFn, wantsErrors := c.compileMapEnd(m, opt, ce)
 			if mapEndFn == nil {
 				continue
 			}
 
 			if m.MapEndFn != nil {
-				c.errf(c.nodePosition(opt), "cff.Map accepts at most one cff.MapEnd option")
+				c.errf(CodeInvalidOption, opt, "cff.Map accepts at most one cff.MapEnd option")
 				continue
 			}
 
 			m.MapEndFn = mapEndFn
+			m.EndWantsErrors = wantsErrors
+		case "MapFallback":
+			if m.Fallback != nil {
+				c.errf(CodeInvalidOption, opt, "cff.Map accepts at most one cff.MapFallback option")
+				continue
+			}
+			m.Fallback = c.compileMapFallback(m, opt, ce)
+		case "RateLimit":
+			m.RateLimit = ce.Args[0]
 		default:
-			c.errf(c.nodePosition(opt), "unrecognized cff.Map option %q", fn.Name())
+			c.errf(CodeInvalidOption, opt, "unrecognized cff.Map option %q", fn.Name())
 		}
 	}
 
 	return m
 }
 
-func (c *compiler) compileMapEnd(opt ast.Expr, ce *ast.CallExpr) *compiledFunc {
+// compileMapEnd compiles a cff.MapEnd function,
+// reporting whether it accepts the cff.ElementErrors of the Map.
+func (c *compiler) compileMapEnd(m *mapTask, opt ast.Expr, ce *ast.CallExpr) (*compiledFunc, bool) {
+	fn := c.compileFunction(ce.Args[0])
+	switch {
+	case fn == nil:
+		c.errf(CodeInvalidFunction, opt, "MapEnd function failed to compile")
+		return nil, false
+	case !acceptsElementErrors(fn, m.KeyType):
+		c.errf(CodeInvalidFunction, opt, "MapEnd functions should accept only a context.Context and a cff.ElementErrors[%v]", m.KeyType)
+		return nil, false
+	case len(fn.Results) != 0:
+		c.errf(CodeInvalidFunction, opt, "MapEnd functions should return an error or nothing")
+		return nil, false
+	default:
+		return fn, len(fn.Inputs) == 1
+	}
+}
+
+// compileMapFallback compiles the cff.MapFallback of m.
+// The fallback accepts the same arguments as the function of the Map,
+// followed by an error.
+func (c *compiler) compileMapFallback(m *mapTask, opt ast.Expr, ce *ast.CallExpr) *compiledFunc {
 	fn := c.compileFunction(ce.Args[0])
 	switch {
 	case fn == nil:
-		c.errf(c.nodePosition(opt), "MapEnd function failed to compile")
+		c.errf(CodeInvalidFunction, opt, "MapFallback function failed to compile")
 		return nil
-	case len(fn.Inputs) != 0:
-		c.errf(c.nodePosition(opt), "MapEnd functions should accept at most one context.Context parameter")
+	case len(fn.Results) != 0 || fn.HasError:
+		c.errf(CodeInvalidFunction, opt, "MapFallback functions must not return any values")
 		return nil
-	case len(fn.Outputs) != 0:
-		c.errf(c.nodePosition(opt), "MapEnd functions should return an error or nothing")
+	case !acceptsTypes(fn, []types.Type{m.KeyType, m.ElemType, errorType}):
+		c.errf(CodeInvalidFunction, opt, "MapFallback functions must accept a key of type %v, a value of type %v, and an error", m.KeyType, m.ElemType)
 		return nil
 	default:
 		return fn
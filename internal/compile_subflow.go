@@ -0,0 +1,79 @@
+package internal
+
+import (
+	"go/ast"
+)
+
+// subflow is a group of tasks of a flow specified with cff.Subflow.
+//
+// Tasks of a subflow are part of the flow's graph like any other task.
+// The subflow only changes how they're scheduled and instrumented.
+type subflow struct {
+	ast.Node
+
+	// Serial is a unique serially incrementing number for each subflow
+	// of a flow.
+	Serial int
+
+	Tasks []*task
+
+	Concurrency     ast.Expr    // argument to cff.Concurrency, if any
+	ContinueOnError ast.Expr    // argument to cff.ContinueOnError, if any
+	Emitters        []ast.Expr  // arguments to cff.WithEmitter
+	Instrument      *instrument // non-nil if cff.InstrumentFlow was provided
+
+	PosInfo *PosInfo
+}
+
+// compileSubflow compiles a cff.Subflow, adding its tasks to the flow.
+func (c *compiler) compileSubflow(f *flow, call *ast.CallExpr) *subflow {
+	sf := &subflow{
+		Node:    call,
+		Serial:  len(f.Subflows),
+		PosInfo: c.getPosInfo(call),
+	}
+
+	for _, arg := range call.Args {
+		ce, fn, err := c.identifyOption(arg)
+		if err != nil {
+			c.errf(CodeExpectedDirective, arg, err.Error())
+			continue
+		}
+
+		switch fn.Name() {
+		case "Task":
+			if t := c.compileFlowTask(f, ce); t != nil {
+				t.Subflow = sf
+				sf.Tasks = append(sf.Tasks, t)
+			}
+		case "Concurrency":
+			sf.Concurrency = ce.Args[0]
+		case "ContinueOnError":
+			sf.ContinueOnError = ce.Args[0]
+		case "WithEmitter":
+			sf.Emitters = append(sf.Emitters, ce.Args[0])
+		case "InstrumentFlow":
+			sf.Instrument = c.compileInstrument(ce)
+		case "Subflow":
+			c.errf(CodeInvalidOption, arg, "cff.Subflow cannot be nested")
+		default:
+			c.errf(CodeInvalidOption, arg, "cff.%v cannot be used in cff.Subflow", fn.Name())
+		}
+	}
+
+	if len(sf.Tasks) == 0 {
+		c.errf(CodeNoTasks, call, "cff.Subflow expects at least one cff.Task")
+		return nil
+	}
+	return sf
+}
+
+// Instrumented reports whether any task of the subflow is instrumented.
+func (sf *subflow) Instrumented() bool {
+	for _, t := range sf.Tasks {
+		if t.Instrument != nil {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,233 @@
+package internal
+
+import (
+	"go/ast"
+	"go/types"
+
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+// switchTask holds the alternatives of a cff.Switch.
+type switchTask struct {
+	// Cases in the order they were specified.
+	Cases []*switchCase
+
+	// Default is the task of the cff.Default case, if any.
+	Default *task
+}
+
+// switchCase is a cff.Case inside a cff.Switch.
+type switchCase struct {
+	Predicate *predicate // selects this case
+	Task      *task      // runs if this case is selected
+}
+
+// tasks returns the tasks of all alternatives of the switch,
+// including the default.
+func (s *switchTask) tasks() []*task {
+	tasks := make([]*task, 0, len(s.Cases)+1)
+	for _, sc := range s.Cases {
+		tasks = append(tasks, sc.Task)
+	}
+	if s.Default != nil {
+		tasks = append(tasks, s.Default)
+	}
+	return tasks
+}
+
+// compileSwitch compiles a cff.Switch into a task that provides the outputs
+// of its cases. The task depends on the inputs of all cases, and on the
+// predicates of all cases.
+func (c *compiler) compileSwitch(f *flow, call *ast.CallExpr) *task {
+	var (
+		sw     switchTask
+		failed bool
+	)
+	for i, arg := range call.Args {
+		ce, fn, err := c.identifyOption(arg)
+		if err != nil {
+			c.errf(CodeInvalidSwitch, arg, err.Error())
+			failed = true
+			continue
+		}
+
+		var (
+			fnExpr ast.Expr
+			opts   []ast.Expr
+		)
+		switch fn.Name() {
+		case "Case":
+			fnExpr, opts = ce.Args[1], ce.Args[2:]
+		case "Default":
+			if i != len(call.Args)-1 {
+				c.errf(CodeInvalidSwitch, arg, "cff.Default must be the last argument of cff.Switch")
+				failed = true
+				continue
+			}
+			fnExpr, opts = ce.Args[0], ce.Args[1:]
+		default:
+			c.errf(CodeInvalidSwitch, arg, "expected cff.Case or cff.Default, got cff.%v", fn.Name())
+			failed = true
+			continue
+		}
+
+		if !c.validateSwitchTaskOptions(opts) {
+			failed = true
+			continue
+		}
+
+		t := c.compileTask(f, fnExpr, opts)
+		if t == nil {
+			failed = true
+			continue
+		}
+		t.InSwitch = true
+
+		if fn.Name() == "Default" {
+			if t.PredicateOnError != nil {
+				c.errf(CodeInvalidSwitch, t.PredicateOnError, "cff.PredicateOnError cannot be used with cff.Default")
+				failed = true
+				continue
+			}
+			sw.Default = t
+			continue
+		}
+
+		pred := c.compilePredicate(f, t, ce)
+		if pred == nil {
+			failed = true
+			continue
+		}
+		if t.PredicateOnError != nil && !pred.Function.HasError {
+			c.errf(CodeInvalidPredicate, t.PredicateOnError, "cff.PredicateOnError requires a predicate that returns an error")
+			failed = true
+			continue
+		}
+		sw.Cases = append(sw.Cases, &switchCase{Predicate: pred, Task: t})
+	}
+
+	if len(sw.Cases) == 0 {
+		if !failed {
+			c.errf(CodeInvalidSwitch, call, "cff.Switch requires at least one cff.Case")
+		}
+		return nil
+	}
+	if failed {
+		return nil
+	}
+
+	tasks := sw.tasks()
+	first := tasks[0]
+	for _, t := range tasks[1:] {
+		if !sameTypes(first.Outputs, t.Outputs) {
+			c.errf(CodeInvalidSwitch, t, "all tasks of cff.Switch must produce the same types: "+
+				"expected %v, got %v", first.Outputs, t.Outputs).
+				relatef(c.nodePosition(first), "first task produces %v", first.Outputs)
+			failed = true
+		}
+	}
+	if failed {
+		return nil
+	}
+
+	// The switch consumes the inputs of all its tasks, and the outputs of
+	// all its predicates.
+	var (
+		inputs []types.Type
+		seen   typeutil.Map // map[types.Type]struct{}
+	)
+	for _, t := range tasks {
+		for _, in := range t.Inputs {
+			if seen.At(in) == nil {
+				seen.Set(in, struct{}{})
+				inputs = append(inputs, in)
+			}
+		}
+	}
+	deps := append([]types.Type(nil), inputs...)
+	for _, sc := range sw.Cases {
+		deps = append(deps, sc.Predicate.SentinelOutput)
+	}
+
+	outputs := append([]types.Type(nil), first.Outputs...)
+	t := &task{
+		Node:    call,
+		Serial:  c.taskSerial,
+		Inputs:  inputs,
+		Outputs: outputs,
+		Switch:  &sw,
+		PosInfo: c.getPosInfo(call),
+	}
+	c.taskSerial++
+
+	// The Switch produces its outputs only if the selected task does.
+	for _, st := range tasks {
+		t.Conditional = t.Conditional || st.Conditional
+	}
+
+	t.Function = &function{
+		Node:         call,
+		Sig:          types.NewSignatureType(nil, nil, nil, tuple(inputs), tuple(outputs), false),
+		Dependencies: deps,
+		Task:         t,
+		PosInfo:      t.PosInfo,
+	}
+	return t
+}
+
+// validateSwitchTaskOptions reports whether the options of a task of
+// a cff.Switch are valid.
+// Predicates, invocations, ordering, rate limits, and groups
+// are decided by the Switch,
+// and the Switch provides the outputs of its tasks,
+// so tasks of a Switch may not specify these options.
+func (c *compiler) validateSwitchTaskOptions(opts []ast.Expr) bool {
+	ok := true
+	for _, opt := range opts {
+		_, fn, err := c.identifyOption(opt)
+		if err != nil {
+			continue // reported by compileTask
+		}
+
+		switch fn.Name() {
+		case "Predicate":
+			c.errf(CodeInvalidSwitch, opt, "cff.Predicate cannot be used with a task of cff.Switch: use cff.Case instead")
+			ok = false
+		case "Invoke":
+			c.errf(CodeInvalidSwitch, opt, "cff.Invoke cannot be used with a task of cff.Switch")
+			ok = false
+		case "Collect", "Ref", "After", "RateLimit", "InGroup", "Budget":
+			c.errf(CodeInvalidSwitch, opt, "cff.%v cannot be used with a task of cff.Switch", fn.Name())
+			ok = false
+		}
+	}
+	return ok
+}
+
+// sameTypes reports whether the two lists hold the same types,
+// regardless of order.
+func sameTypes(a, b []types.Type) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	var set typeutil.Map // map[types.Type]struct{}
+	for _, t := range a {
+		set.Set(t, struct{}{})
+	}
+	for _, t := range b {
+		if !set.Delete(t) {
+			return false
+		}
+	}
+	return set.Len() == 0
+}
+
+// tuple builds a tuple of unnamed variables with the given types.
+func tuple(ts []types.Type) *types.Tuple {
+	vars := make([]*types.Var, len(ts))
+	for i, t := range ts {
+		vars[i] = types.NewParam(0, nil, "", t)
+	}
+	return types.NewTuple(vars...)
+}
@@ -1,18 +1,35 @@
 package internal
 
 import (
-	"fmt"
-	"go/token"
 	"go/types"
 
 	"golang.org/x/tools/go/types/typeutil"
 )
 
-func validateFlowCycles(f *flow, fset *token.FileSet) error {
+// validateFlowCycles reports a Diagnostic if the flow has a cycle.
+func (c *compiler) validateFlowCycles(f *flow) *Diagnostic {
 	// Whether we've checked the subtree starting at this type for cycles
 	// already.
 	var visited typeutil.Map // map[types.Type]struct{}
-	return findFlowCycles(f, &visited, fset)
+	path := findFlowCycles(f, &visited)
+	if len(path) == 0 {
+		return nil
+	}
+
+	d := c.errf(CodeCycle, path[len(path)-1].Func,
+		"cycle detected: need to run [%v] %v (output)", path[0].Func.Sig, describeCycleType(f, path[0].Type))
+	for _, item := range path[1:] {
+		d.relatef(c.nodePosition(item.Func), "need to run [%v] %v", item.Func.Sig, describeCycleType(f, item.Type))
+	}
+	return d
+}
+
+// describeCycleType describes why a function providing t is needed.
+func describeCycleType(f *flow, t types.Type) string {
+	if f.isOrderType(t) {
+		return "before a task that uses cff.After"
+	}
+	return "to provide " + t.String()
 }
 
 // funcCyclePathEntry is an entry in the path as we walked the graph to detect cycles
@@ -24,29 +41,21 @@ type funcCyclePathEntry struct {
 	Type types.Type
 }
 
-func prettyPrintFuncCycle(path []funcCyclePathEntry) string {
-	str := fmt.Sprintf("need to run [%v] to provide %v (output)", path[0].Func.Sig, path[0].Type)
-	for _, item := range path[1:] {
-		str += fmt.Sprintf("\n\tneed to run [%v] to provide %v", item.Func.Sig, item.Type)
-	}
-	return str
-}
-
-func findFlowCycles(f *flow, visited *typeutil.Map, fset *token.FileSet) error {
+// findFlowCycles returns the path that forms a cycle in the flow, if any.
+func findFlowCycles(f *flow, visited *typeutil.Map) []funcCyclePathEntry {
 	// If a flow has no Results eg a heatpipe flow, we need to do a DFS across all funcs. We are not
 	// concerned with performance as this only happens once during compilation phase.
 	for _, t := range f.Funcs {
 		for _, dep := range t.Dependencies {
-			if err := findFlowCyclesForFunc(f, nil /* path */, dep, visited, fset); err != nil {
-				return err
+			if cycle := findFlowCyclesForFunc(f, nil /* path */, dep, visited); len(cycle) > 0 {
+				return cycle
 			}
 		}
 	}
 	return nil
 }
 
-func findFlowCyclesForFunc(f *flow, path []funcCyclePathEntry, t types.Type, visited *typeutil.Map,
-	fset *token.FileSet) error {
+func findFlowCyclesForFunc(f *flow, path []funcCyclePathEntry, t types.Type, visited *typeutil.Map) []funcCyclePathEntry {
 	funcIdx, ok := f.providers.At(t).(int)
 	if !ok {
 		// This can happen if either cff.Params provides the type, but it can't introduce a cycle,
@@ -62,10 +71,7 @@ func findFlowCyclesForFunc(f *flow, path []funcCyclePathEntry, t types.Type, vis
 	if len(path) > 0 {
 		for _, p := range path {
 			if types.Identical(p.Type, t) {
-				return fmt.Errorf(
-					"%v: cycle detected: %v",
-					fset.Position(fn.Node.Pos()),
-					prettyPrintFuncCycle(append(path, entry)))
+				return append(path, entry)
 			}
 		}
 	}
@@ -76,8 +82,8 @@ func findFlowCyclesForFunc(f *flow, path []funcCyclePathEntry, t types.Type, vis
 	}
 
 	for _, dep := range fn.Dependencies {
-		if err := findFlowCyclesForFunc(f, append(path, entry), dep, visited, fset); err != nil {
-			return err
+		if cycle := findFlowCyclesForFunc(f, append(path, entry), dep, visited); len(cycle) > 0 {
+			return cycle
 		}
 	}
 
@@ -0,0 +1,225 @@
+package internal
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go/token"
+	"strings"
+
+	"go.uber.org/multierr"
+)
+
+// Severity is the severity of a Diagnostic.
+type Severity int
+
+const (
+	// SeverityError marks diagnostics that prevent code generation.
+	SeverityError Severity = iota + 1
+)
+
+func (s Severity) String() string {
+	switch s {
+	case SeverityError:
+		return "error"
+	default:
+		return "unknown"
+	}
+}
+
+// MarshalText marshals a Severity into its string form.
+func (s Severity) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// Code is a stable identifier for a class of diagnostics.
+//
+// Codes are part of cff's machine-readable output.
+// Existing codes must never be renumbered or reused:
+// add new codes at the end of the list.
+type Code int
+
+// List of diagnostic codes reported by the cff compiler.
+const (
+	CodeUnexpectedDirective Code = 1
+	CodeMissingBuildTag     Code = 2
+	CodeNoTasks             Code = 3
+	CodeExpectedDirective   Code = 4
+	CodeInvalidOption       Code = 5
+	CodeDuplicateParam      Code = 6
+	CodeDuplicateProvider   Code = 7
+	CodeCycle               Code = 8
+	CodeUnusedOutput        Code = 9
+	CodeUnusedInput         Code = 10
+	CodeMissingEmitter      Code = 11
+	CodeNoProvider          Code = 12
+	CodeInvalidFunction     Code = 13
+	CodeInvalidInvoke       Code = 14
+	CodeInvalidFallback     Code = 15
+	CodeInvalidPredicate    Code = 16
+	CodeInvalidResults      Code = 17
+	CodeInvalidArgument     Code = 18
+	CodeAmbiguousProvider   Code = 19
+	CodeInvalidSwitch       Code = 20
+	CodeUnknownTaskRef      Code = 21
+	CodeUnknownGroup        Code = 22
+)
+
+var _codeNames = map[Code]string{
+	CodeUnexpectedDirective: "unexpected-directive",
+	CodeMissingBuildTag:     "missing-build-tag",
+	CodeNoTasks:             "no-tasks",
+	CodeExpectedDirective:   "expected-directive",
+	CodeInvalidOption:       "invalid-option",
+	CodeDuplicateParam:      "duplicate-param",
+	CodeDuplicateProvider:   "duplicate-provider",
+	CodeCycle:               "cycle",
+	CodeUnusedOutput:        "unused-output",
+	CodeUnusedInput:         "unused-input",
+	CodeMissingEmitter:      "missing-emitter",
+	CodeNoProvider:          "no-provider",
+	CodeInvalidFunction:     "invalid-function",
+	CodeInvalidInvoke:       "invalid-invoke",
+	CodeInvalidFallback:     "invalid-fallback",
+	CodeInvalidPredicate:    "invalid-predicate",
+	CodeInvalidResults:      "invalid-results",
+	CodeInvalidArgument:     "invalid-argument",
+	CodeAmbiguousProvider:   "ambiguous-provider",
+	CodeInvalidSwitch:       "invalid-switch",
+	CodeUnknownTaskRef:      "unknown-task-ref",
+	CodeUnknownGroup:        "unknown-group",
+}
+
+// String returns the code in the form "CFF0012 no-provider".
+func (c Code) String() string {
+	name, ok := _codeNames[c]
+	if !ok {
+		name = "unknown"
+	}
+	return fmt.Sprintf("CFF%04d %v", int(c), name)
+}
+
+// MarshalText marshals a Code into its string form.
+func (c Code) MarshalText() ([]byte, error) {
+	return []byte(c.String()), nil
+}
+
+// Related is a secondary position attached to a Diagnostic.
+// For example, the position of the other provider of a type.
+type Related struct {
+	Pos     token.Position
+	Message string
+}
+
+// Diagnostic is a single problem found by the cff compiler.
+//
+// Diagnostics implement error. Errors returned by Processor.Process are
+// either Diagnostics or errors combined with multierr that contain them.
+type Diagnostic struct {
+	// Pos and End delimit the source range the diagnostic applies to.
+	// End may be invalid if the range is unknown.
+	Pos, End token.Position
+
+	Severity Severity
+	Code     Code
+	Message  string
+
+	// Related holds additional positions relevant to this diagnostic.
+	Related []Related
+}
+
+var _ error = (*Diagnostic)(nil)
+
+// Error formats the diagnostic as "file:line:col: message", followed by
+// related positions, one per line.
+func (d *Diagnostic) Error() string {
+	var sb strings.Builder
+	if d.Pos.IsValid() {
+		fmt.Fprintf(&sb, "%v: ", d.Pos)
+	}
+	sb.WriteString(d.Message)
+	for _, r := range d.Related {
+		sb.WriteString("\n\t")
+		if r.Pos.IsValid() {
+			fmt.Fprintf(&sb, "%v: ", r.Pos)
+		}
+		sb.WriteString(r.Message)
+	}
+	return sb.String()
+}
+
+// relatef attaches a related position to this diagnostic.
+func (d *Diagnostic) relatef(pos token.Position, msg string, args ...interface{}) *Diagnostic {
+	d.Related = append(d.Related, Related{
+		Pos:     pos,
+		Message: fmt.Sprintf(msg, args...),
+	})
+	return d
+}
+
+type jsonRelated struct {
+	File    string `json:"file,omitempty"`
+	Line    int    `json:"line,omitempty"`
+	Column  int    `json:"column,omitempty"`
+	Message string `json:"message"`
+}
+
+type jsonDiagnostic struct {
+	File      string        `json:"file,omitempty"`
+	Line      int           `json:"line,omitempty"`
+	Column    int           `json:"column,omitempty"`
+	EndLine   int           `json:"endLine,omitempty"`
+	EndColumn int           `json:"endColumn,omitempty"`
+	Severity  Severity      `json:"severity"`
+	Code      *Code         `json:"code,omitempty"`
+	Message   string        `json:"message"`
+	Related   []jsonRelated `json:"related,omitempty"`
+}
+
+// MarshalJSON marshals a Diagnostic into a flat JSON object.
+func (d *Diagnostic) MarshalJSON() ([]byte, error) {
+	jd := jsonDiagnostic{
+		File:     d.Pos.Filename,
+		Line:     d.Pos.Line,
+		Column:   d.Pos.Column,
+		Severity: d.Severity,
+		Message:  d.Message,
+	}
+	if d.End.IsValid() {
+		jd.EndLine = d.End.Line
+		jd.EndColumn = d.End.Column
+	}
+	if d.Code != 0 {
+		code := d.Code
+		jd.Code = &code
+	}
+	for _, r := range d.Related {
+		jd.Related = append(jd.Related, jsonRelated{
+			File:    r.Pos.Filename,
+			Line:    r.Pos.Line,
+			Column:  r.Pos.Column,
+			Message: r.Message,
+		})
+	}
+	return json.Marshal(jd)
+}
+
+// Diagnostics extracts the list of Diagnostics from an error returned by
+// Processor.Process.
+//
+// Errors that are not Diagnostics are reported as error-level Diagnostics
+// without a position or code.
+func Diagnostics(err error) []*Diagnostic {
+	var diags []*Diagnostic
+	for _, err := range multierr.Errors(err) {
+		var d *Diagnostic
+		if !errors.As(err, &d) {
+			d = &Diagnostic{
+				Severity: SeverityError,
+				Message:  err.Error(),
+			}
+		}
+		diags = append(diags, d)
+	}
+	return diags
+}
@@ -0,0 +1,106 @@
+package internal
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go/token"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/multierr"
+)
+
+func TestCodeString(t *testing.T) {
+	tests := []struct {
+		give Code
+		want string
+	}{
+		{give: CodeNoProvider, want: "CFF0012 no-provider"},
+		{give: CodeCycle, want: "CFF0008 cycle"},
+		{give: Code(9999), want: "CFF9999 unknown"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.give.String())
+		})
+	}
+}
+
+func TestCodeNamesUnique(t *testing.T) {
+	seen := make(map[string]Code)
+	for code, name := range _codeNames {
+		if other, ok := seen[name]; ok {
+			t.Errorf("codes %d and %d share the name %q", code, other, name)
+		}
+		seen[name] = code
+	}
+}
+
+func TestDiagnosticError(t *testing.T) {
+	d := &Diagnostic{
+		Pos:      token.Position{Filename: "foo.go", Line: 10, Column: 3},
+		Severity: SeverityError,
+		Code:     CodeDuplicateProvider,
+		Message:  "type string already provided",
+	}
+	assert.Equal(t, "foo.go:10:3: type string already provided", d.Error())
+
+	d.relatef(token.Position{Filename: "foo.go", Line: 5, Column: 2}, "type %v first provided here", "string")
+	assert.Equal(t,
+		"foo.go:10:3: type string already provided\n"+
+			"\tfoo.go:5:2: type string first provided here",
+		d.Error())
+}
+
+func TestDiagnosticMarshalJSON(t *testing.T) {
+	d := &Diagnostic{
+		Pos:      token.Position{Filename: "foo.go", Offset: 1, Line: 10, Column: 3},
+		End:      token.Position{Filename: "foo.go", Offset: 5, Line: 10, Column: 7},
+		Severity: SeverityError,
+		Code:     CodeNoProvider,
+		Message:  "no provider found for float64",
+		Related: []Related{
+			{
+				Pos:     token.Position{Filename: "bar.go", Line: 1, Column: 1},
+				Message: "see here",
+			},
+		},
+	}
+
+	got, err := json.Marshal(d)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"file": "foo.go",
+		"line": 10,
+		"column": 3,
+		"endLine": 10,
+		"endColumn": 7,
+		"severity": "error",
+		"code": "CFF0012 no-provider",
+		"message": "no provider found for float64",
+		"related": [
+			{"file": "bar.go", "line": 1, "column": 1, "message": "see here"}
+		]
+	}`, string(got))
+}
+
+func TestDiagnostics(t *testing.T) {
+	d1 := &Diagnostic{Severity: SeverityError, Code: CodeCycle, Message: "cycle"}
+	d2 := &Diagnostic{Severity: SeverityError, Code: CodeNoTasks, Message: "no tasks"}
+
+	got := Diagnostics(multierr.Combine(
+		d1,
+		fmt.Errorf("wrapped: %w", d2),
+		errors.New("great sadness"),
+	))
+	require.Len(t, got, 3)
+	assert.Same(t, d1, got[0])
+	assert.Same(t, d2, got[1])
+	assert.Equal(t, &Diagnostic{
+		Severity: SeverityError,
+		Message:  "great sadness",
+	}, got[2])
+}
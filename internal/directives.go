@@ -5,22 +5,47 @@ var _codegenDirectives = map[string]struct{}{
 	"Params":             {},
 	"Results":            {},
 	"WithEmitter":        {},
+	"WithInterceptor":    {},
 	"Task":               {},
 	"InstrumentFlow":     {},
 	"Concurrency":        {},
+	"RateLimit":          {},
+	"Group":              {},
+	"RethrowPanics":      {},
+	"AllowAssignable":    {},
+	"Lazy":               {},
 	"ContinueOnError":    {},
+	"FirstSuccess":       {},
+	"Quorum":             {},
 	"Flow":               {},
 	"FallbackWith":       {},
 	"Predicate":          {},
+	"PredicateOnError":   {},
 	"Instrument":         {},
 	"Invoke":             {},
+	"Optional":           {},
+	"Collect":            {},
+	"Ref":                {},
+	"After":              {},
+	"InGroup":            {},
+	"Hedge":              {},
+	"Budget":             {},
+	"Switch":             {},
+	"Case":               {},
+	"Default":            {},
+	"Bundle":             {},
+	"Use":                {},
+	"Subflow":            {},
 	"Parallel":           {},
 	"InstrumentParallel": {},
 	"Tasks":              {},
 	"Slice":              {},
 	"SliceEnd":           {},
+	"SliceFallback":      {},
+	"Batch":              {},
 	"Map":                {},
 	"MapEnd":             {},
+	"MapFallback":        {},
 }
 
 // IsCodegenDirective reports whether the function with the given name in the
@@ -1,5 +1,5 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: go.uber.org/cff (interfaces: Emitter,TaskEmitter,FlowEmitter,ParallelEmitter,SchedulerEmitter)
+// Source: go.uber.org/cff (interfaces: Emitter,TaskEmitter,FlowEmitter,ParallelEmitter,SchedulerEmitter,HedgeEmitter)
 
 // Package emittertest is a generated GoMock package.
 package emittertest
@@ -352,3 +352,38 @@ func (mr *MockSchedulerEmitterMockRecorder) EmitScheduler(arg0 interface{}) *gom
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EmitScheduler", reflect.TypeOf((*MockSchedulerEmitter)(nil).EmitScheduler), arg0)
 }
+
+// MockHedgeEmitter is a mock of HedgeEmitter interface.
+type MockHedgeEmitter struct {
+	ctrl     *gomock.Controller
+	recorder *MockHedgeEmitterMockRecorder
+}
+
+// MockHedgeEmitterMockRecorder is the mock recorder for MockHedgeEmitter.
+type MockHedgeEmitterMockRecorder struct {
+	mock *MockHedgeEmitter
+}
+
+// NewMockHedgeEmitter creates a new mock instance.
+func NewMockHedgeEmitter(ctrl *gomock.Controller) *MockHedgeEmitter {
+	mock := &MockHedgeEmitter{ctrl: ctrl}
+	mock.recorder = &MockHedgeEmitterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockHedgeEmitter) EXPECT() *MockHedgeEmitterMockRecorder {
+	return m.recorder
+}
+
+// TaskHedged mocks base method.
+func (m *MockHedgeEmitter) TaskHedged(arg0 context.Context, arg1 int) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "TaskHedged", arg0, arg1)
+}
+
+// TaskHedged indicates an expected call of TaskHedged.
+func (mr *MockHedgeEmitterMockRecorder) TaskHedged(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TaskHedged", reflect.TypeOf((*MockHedgeEmitter)(nil).TaskHedged), arg0, arg1)
+}
@@ -0,0 +1,187 @@
+package emittertest
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"go.uber.org/cff"
+)
+
+// EventKind identifies a method of cff.TaskEmitter or cff.HedgeEmitter.
+type EventKind string
+
+// Kinds of events recorded by Recorder.
+const (
+	TaskInit           EventKind = "TaskInit"
+	TaskSuccess        EventKind = "TaskSuccess"
+	TaskError          EventKind = "TaskError"
+	TaskErrorRecovered EventKind = "TaskErrorRecovered"
+	TaskSkipped        EventKind = "TaskSkipped"
+	TaskPanic          EventKind = "TaskPanic"
+	TaskPanicRecovered EventKind = "TaskPanicRecovered"
+	TaskHedged         EventKind = "TaskHedged"
+)
+
+// Event is a call to a task emitter recorded by Recorder.
+type Event struct {
+	Kind      EventKind
+	Task      *cff.TaskInfo
+	Directive *cff.DirectiveInfo
+
+	Err     error       // for TaskError, TaskErrorRecovered, and TaskSkipped
+	Value   interface{} // for TaskPanic and TaskPanicRecovered
+	Attempt int         // for TaskHedged
+}
+
+// Recorder is a cff.Emitter that records the events reported to the
+// emitters of tasks, in the order they were reported.
+// Events that are not specific to a task are dropped.
+//
+// Recorder is safe for concurrent use.
+type Recorder struct {
+	cff.Emitter
+
+	mu     sync.Mutex
+	events []Event
+}
+
+var _ cff.Emitter = (*Recorder)(nil)
+
+// NewRecorder builds a new Recorder with no events.
+func NewRecorder() *Recorder {
+	return &Recorder{Emitter: cff.NopEmitter()}
+}
+
+// TaskInit records the initialization of a task and returns an emitter
+// that records its events.
+func (r *Recorder) TaskInit(task *cff.TaskInfo, d *cff.DirectiveInfo) cff.TaskEmitter {
+	te := &taskRecorder{r: r, task: task, directive: d}
+	te.record(Event{Kind: TaskInit})
+	return te
+}
+
+// Events returns all events recorded so far.
+func (r *Recorder) Events() []Event {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]Event(nil), r.events...)
+}
+
+// EventsOf returns the events of the given kind recorded so far.
+func (r *Recorder) EventsOf(kind EventKind) []Event {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var events []Event
+	for _, e := range r.events {
+		if e.Kind == kind {
+			events = append(events, e)
+		}
+	}
+	return events
+}
+
+// Names returns the names of the tasks that reported events of the given
+// kind, in the order they were reported.
+func (r *Recorder) Names(kind EventKind) []string {
+	var names []string
+	for _, e := range r.EventsOf(kind) {
+		names = append(names, e.Task.Name)
+	}
+	return names
+}
+
+// Errors returns the errors reported with events of the given kind.
+func (r *Recorder) Errors(kind EventKind) []error {
+	var errs []error
+	for _, e := range r.EventsOf(kind) {
+		errs = append(errs, e.Err)
+	}
+	return errs
+}
+
+// Values returns the panic values reported with events of the given kind.
+func (r *Recorder) Values(kind EventKind) []interface{} {
+	var values []interface{}
+	for _, e := range r.EventsOf(kind) {
+		values = append(values, e.Value)
+	}
+	return values
+}
+
+// SkipReasons returns the reasons tasks were skipped for by task name.
+func (r *Recorder) SkipReasons() map[string]*cff.SkipReason {
+	reasons := make(map[string]*cff.SkipReason)
+	for _, e := range r.EventsOf(TaskSkipped) {
+		var reason *cff.SkipReason
+		if errors.As(e.Err, &reason) {
+			reasons[e.Task.Name] = reason
+		}
+	}
+	return reasons
+}
+
+// SkipKinds returns the kinds of the reasons tasks were skipped for
+// by task name.
+func (r *Recorder) SkipKinds() map[string]cff.SkipKind {
+	kinds := make(map[string]cff.SkipKind)
+	for name, reason := range r.SkipReasons() {
+		kinds[name] = reason.Kind
+	}
+	return kinds
+}
+
+// taskRecorder records the events of a single task in a Recorder.
+type taskRecorder struct {
+	r         *Recorder
+	task      *cff.TaskInfo
+	directive *cff.DirectiveInfo
+}
+
+var (
+	_ cff.TaskEmitter  = (*taskRecorder)(nil)
+	_ cff.HedgeEmitter = (*taskRecorder)(nil)
+)
+
+func (te *taskRecorder) record(e Event) {
+	e.Task = te.task
+	e.Directive = te.directive
+
+	te.r.mu.Lock()
+	defer te.r.mu.Unlock()
+
+	te.r.events = append(te.r.events, e)
+}
+
+func (te *taskRecorder) TaskSuccess(context.Context) {
+	te.record(Event{Kind: TaskSuccess})
+}
+
+func (te *taskRecorder) TaskError(_ context.Context, err error) {
+	te.record(Event{Kind: TaskError, Err: err})
+}
+
+func (te *taskRecorder) TaskErrorRecovered(_ context.Context, err error) {
+	te.record(Event{Kind: TaskErrorRecovered, Err: err})
+}
+
+func (te *taskRecorder) TaskSkipped(_ context.Context, err error) {
+	te.record(Event{Kind: TaskSkipped, Err: err})
+}
+
+func (te *taskRecorder) TaskPanic(_ context.Context, v interface{}) {
+	te.record(Event{Kind: TaskPanic, Value: v})
+}
+
+func (te *taskRecorder) TaskPanicRecovered(_ context.Context, v interface{}) {
+	te.record(Event{Kind: TaskPanicRecovered, Value: v})
+}
+
+func (te *taskRecorder) TaskHedged(_ context.Context, attempt int) {
+	te.record(Event{Kind: TaskHedged, Attempt: attempt})
+}
+
+func (te *taskRecorder) TaskDone(context.Context, time.Duration) {}
@@ -0,0 +1,57 @@
+package emittertest
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/cff"
+)
+
+func TestRecorder(t *testing.T) {
+	ctx := context.Background()
+	r := NewRecorder()
+	d := &cff.DirectiveInfo{Name: "flow", Directive: cff.FlowDirective}
+
+	foo := r.TaskInit(&cff.TaskInfo{Name: "foo"}, d)
+	bar := r.TaskInit(&cff.TaskInfo{Name: "bar"}, d)
+
+	sadness := errors.New("great sadness")
+	foo.TaskSuccess(ctx)
+	bar.TaskErrorRecovered(ctx, sadness)
+	bar.TaskPanicRecovered(ctx, "oops")
+	bar.TaskSkipped(ctx, &cff.SkipReason{Kind: cff.SkipPredicateFalse})
+	foo.(cff.HedgeEmitter).TaskHedged(ctx, 2)
+
+	assert.Len(t, r.Events(), 7)
+	assert.Equal(t, []string{"foo", "bar"}, r.Names(TaskInit))
+	assert.Equal(t, []string{"foo"}, r.Names(TaskSuccess))
+	assert.Equal(t, []error{sadness}, r.Errors(TaskErrorRecovered))
+	assert.Equal(t, []interface{}{"oops"}, r.Values(TaskPanicRecovered))
+	assert.Equal(t, map[string]cff.SkipKind{"bar": cff.SkipPredicateFalse}, r.SkipKinds())
+
+	hedged := r.EventsOf(TaskHedged)
+	if assert.Len(t, hedged, 1) {
+		assert.Equal(t, 2, hedged[0].Attempt)
+		assert.Same(t, d, hedged[0].Directive)
+	}
+}
+
+func TestRecorderConcurrent(t *testing.T) {
+	r := NewRecorder()
+	te := r.TaskInit(&cff.TaskInfo{Name: "foo"}, &cff.DirectiveInfo{})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			te.TaskSuccess(context.Background())
+		}()
+	}
+	wg.Wait()
+
+	assert.Len(t, r.EventsOf(TaskSuccess), 10)
+}
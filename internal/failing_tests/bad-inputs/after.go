@@ -0,0 +1,113 @@
+//go:build cff && failing
+// +build cff,failing
+
+package badinputs
+
+import (
+	"context"
+
+	"go.uber.org/cff"
+)
+
+// AfterUnattachedRef is a flow that orders a task after a cff.TaskRef
+// that isn't attached to any task.
+func AfterUnattachedRef() {
+	var ref cff.TaskRef
+	cff.Flow(context.Background(),
+		cff.Task(func() {}, cff.Invoke(true)),
+		cff.Task(func() {}, cff.Invoke(true), cff.After(ref)),
+	)
+}
+
+// AfterUnknownName is a flow that orders a task after an instrumentation
+// name that no task uses.
+func AfterUnknownName() {
+	cff.Flow(context.Background(),
+		cff.WithEmitter(cff.NopEmitter()),
+		cff.Task(func() {}, cff.Invoke(true), cff.Instrument("foo")),
+		cff.Task(func() {}, cff.Invoke(true), cff.After("bar")),
+	)
+}
+
+// AfterAmbiguousName is a flow that orders a task after an instrumentation
+// name used by more than one task.
+func AfterAmbiguousName() {
+	cff.Flow(context.Background(),
+		cff.WithEmitter(cff.NopEmitter()),
+		cff.Task(func() {}, cff.Invoke(true), cff.Instrument("foo")),
+		cff.Task(func() error { return nil }, cff.Invoke(true), cff.Instrument("foo")),
+		cff.Task(func() {}, cff.Invoke(true), cff.After("foo")),
+	)
+}
+
+// AfterNotConstant is a flow that orders a task after a value that is
+// neither a cff.TaskRef nor a constant string.
+func AfterNotConstant() {
+	name := "foo"
+	cff.Flow(context.Background(),
+		cff.Task(func() {}, cff.Invoke(true), cff.After(name)),
+	)
+}
+
+// RefAttachedTwice is a flow that attaches the same cff.TaskRef to two
+// tasks.
+func RefAttachedTwice() {
+	var ref cff.TaskRef
+	cff.Flow(context.Background(),
+		cff.Task(func() {}, cff.Invoke(true), cff.Ref(&ref)),
+		cff.Task(func() error { return nil }, cff.Invoke(true), cff.Ref(&ref)),
+	)
+}
+
+// RefNotPointer is a flow that passes something other than a pointer to
+// a variable to cff.Ref.
+func RefNotPointer() {
+	cff.Flow(context.Background(),
+		cff.Task(func() {}, cff.Invoke(true), cff.Ref(new(cff.TaskRef))),
+	)
+}
+
+// AfterSelf is a flow with a task that runs after itself.
+func AfterSelf() {
+	var ref cff.TaskRef
+	cff.Flow(context.Background(),
+		cff.Task(func() {}, cff.Invoke(true), cff.Ref(&ref), cff.After(ref)),
+	)
+}
+
+// AfterCycle is a flow with a task that runs after a task that
+// consumes its output.
+func AfterCycle() {
+	var ref cff.TaskRef
+	var s string
+	cff.Flow(context.Background(),
+		cff.Results(&s),
+		cff.Task(func() int { return 42 }, cff.After(ref)),
+		cff.Task(func(int) string { return "foo" }, cff.Ref(&ref)),
+	)
+}
+
+// AfterInSwitch is a flow with a cff.Switch task that uses cff.After.
+func AfterInSwitch() {
+	var ref cff.TaskRef
+	var s string
+	cff.Flow(context.Background(),
+		cff.Results(&s),
+		cff.Task(func() {}, cff.Invoke(true), cff.Ref(&ref)),
+		cff.Switch(
+			cff.Case(
+				func() bool { return true },
+				func() string { return "foo" },
+				cff.After(ref),
+			),
+		),
+	)
+}
+
+// ParallelAfter is a parallel with a task that uses cff.Ref.
+func ParallelAfter() {
+	var ref cff.TaskRef
+	cff.Parallel(context.Background(),
+		cff.Task(func() {}, cff.Ref(&ref)),
+	)
+}
@@ -0,0 +1,46 @@
+//go:build cff && failing
+// +build cff,failing
+
+package badinputs
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strings"
+
+	"go.uber.org/cff"
+)
+
+// AmbiguousAssignable is a flow where more than one provider is assignable
+// to an input.
+func AmbiguousAssignable() {
+	var b []byte
+	cff.Flow(context.Background(),
+		cff.Results(&b),
+		cff.AllowAssignable(),
+		cff.Task(
+			func() *bytes.Buffer {
+				return new(bytes.Buffer)
+			},
+		),
+		cff.Task(
+			func() *strings.Reader {
+				return strings.NewReader("")
+			},
+		),
+		cff.Task(
+			func(r io.Reader) ([]byte, error) {
+				return io.ReadAll(r)
+			},
+		),
+	)
+}
+
+// ParallelAllowAssignable is a parallel that uses cff.AllowAssignable.
+func ParallelAllowAssignable() {
+	cff.Parallel(context.Background(),
+		cff.AllowAssignable(),
+		cff.Task(func() {}),
+	)
+}
@@ -0,0 +1,86 @@
+//go:build cff && failing
+// +build cff,failing
+
+package badinputs
+
+import (
+	"context"
+
+	"go.uber.org/cff"
+)
+
+// BudgetTwice uses cff.Budget twice on the same task.
+func BudgetTwice() {
+	var s string
+	cff.Flow(context.Background(),
+		cff.Results(&s),
+		cff.Task(
+			func(context.Context) string { return "foo" },
+			cff.Budget(0.5),
+			cff.Budget(0.3),
+		),
+	)
+}
+
+// BudgetNotConstant passes a variable weight to cff.Budget.
+func BudgetNotConstant() {
+	var s string
+	weight := 0.5
+	cff.Flow(context.Background(),
+		cff.Results(&s),
+		cff.Task(
+			func(context.Context) string { return "foo" },
+			cff.Budget(weight),
+		),
+	)
+}
+
+// BudgetNotPositive passes a weight of zero to cff.Budget.
+func BudgetNotPositive() {
+	var s string
+	cff.Flow(context.Background(),
+		cff.Results(&s),
+		cff.Task(
+			func(context.Context) string { return "foo" },
+			cff.Budget(0),
+		),
+	)
+}
+
+// BudgetWithoutContext uses cff.Budget with a task that doesn't accept
+// a context.
+func BudgetWithoutContext() {
+	var s string
+	cff.Flow(context.Background(),
+		cff.Results(&s),
+		cff.Task(
+			func() string { return "foo" },
+			cff.Budget(1),
+		),
+	)
+}
+
+// BudgetParallel uses cff.Budget with a task of a cff.Parallel.
+func BudgetParallel() {
+	cff.Parallel(context.Background(),
+		cff.Task(
+			func(context.Context) {},
+			cff.Budget(1),
+		),
+	)
+}
+
+// BudgetSwitch uses cff.Budget with a task of a cff.Switch.
+func BudgetSwitch() {
+	var s string
+	cff.Flow(context.Background(),
+		cff.Results(&s),
+		cff.Switch(
+			cff.Case(
+				func() bool { return true },
+				func(context.Context) string { return "foo" },
+				cff.Budget(1),
+			),
+		),
+	)
+}
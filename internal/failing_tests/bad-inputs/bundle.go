@@ -0,0 +1,51 @@
+//go:build cff && failing
+// +build cff,failing
+
+package badinputs
+
+import (
+	"context"
+
+	"go.uber.org/cff"
+)
+
+var _badBundle = cff.Bundle(
+	cff.Task(func() int { return 42 }),
+	cff.Concurrency(2),
+)
+
+var _notBundle cff.TaskBundle
+
+// UseInvalidBundle is a flow that uses a bundle with options other than
+// cff.Task and cff.Params.
+func UseInvalidBundle() {
+	var i int
+	cff.Flow(context.Background(),
+		cff.Results(&i),
+		cff.Use(_badBundle),
+	)
+}
+
+// UseNotBundle is a flow that uses a variable not initialized with
+// cff.Bundle.
+func UseNotBundle() {
+	var s string
+	cff.Flow(context.Background(),
+		cff.Results(&s),
+		cff.Task(func() string { return "foo" }),
+		cff.Use(_notBundle),
+	)
+}
+
+// LocalBundle declares a bundle inside a function.
+func LocalBundle() {
+	b := cff.Bundle(cff.Task(func() int { return 42 }))
+	_ = b
+}
+
+// ParallelUse is a parallel that uses a bundle.
+func ParallelUse() {
+	cff.Parallel(context.Background(),
+		cff.Use(_badBundle),
+	)
+}
@@ -5,7 +5,6 @@ package badinputs
 
 import (
 	"context"
-	"errors"
 
 	"go.uber.org/cff"
 )
@@ -38,17 +37,6 @@ func FlowArgumentNonCff() {
 	)
 }
 
-// DisallowContinueOnError is a function that provides cff.ContinueOnError
-// to cff.Flow.
-func DisallowContinueOnError() {
-	cff.Flow(context.Background(),
-		cff.ContinueOnError(true),
-		cff.Task(
-			func() error { return errors.New("sad times") },
-		),
-	)
-}
-
 // DisallowSlice is a function that provides cff.Slice
 // to cff.Flow.
 func DisallowSlice() {
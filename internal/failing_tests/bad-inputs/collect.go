@@ -0,0 +1,75 @@
+//go:build cff && failing
+// +build cff,failing
+
+package badinputs
+
+import (
+	"context"
+
+	"go.uber.org/cff"
+)
+
+// CollectWithInvoke is a flow with a task that uses both cff.Collect
+// and cff.Invoke.
+func CollectWithInvoke() {
+	cff.Flow(context.Background(),
+		cff.Task(
+			func() error { return nil },
+			cff.Collect(),
+			cff.Invoke(true),
+		),
+	)
+}
+
+// CollectUnused is a flow with collected values that are never consumed.
+func CollectUnused() {
+	var s string
+	cff.Flow(context.Background(),
+		cff.Results(&s),
+		cff.Task(func() int { return 42 }, cff.Collect()),
+		cff.Task(func() string { return "foo" }),
+	)
+}
+
+// CollectNotProvided is a flow that consumes a collected type directly.
+func CollectNotProvided() {
+	var s string
+	cff.Flow(context.Background(),
+		cff.Results(&s),
+		cff.Task(func() int { return 42 }, cff.Collect()),
+		cff.Task(func(int) string { return "foo" }),
+	)
+}
+
+// CollectAlreadyProvided is a flow with a collected type whose slice is
+// also provided by another task.
+func CollectAlreadyProvided() {
+	var ints []int
+	cff.Flow(context.Background(),
+		cff.Results(&ints),
+		cff.Task(func() int { return 42 }, cff.Collect()),
+		cff.Task(func() []int { return nil }),
+	)
+}
+
+// CollectInSwitch is a flow with a cff.Switch task marked with cff.Collect.
+func CollectInSwitch() {
+	var ints []int
+	cff.Flow(context.Background(),
+		cff.Results(&ints),
+		cff.Switch(
+			cff.Case(
+				func() bool { return true },
+				func() int { return 42 },
+				cff.Collect(),
+			),
+		),
+	)
+}
+
+// ParallelCollect is a parallel with a task marked with cff.Collect.
+func ParallelCollect() {
+	cff.Parallel(context.Background(),
+		cff.Task(func() {}, cff.Collect()),
+	)
+}
@@ -0,0 +1,25 @@
+//go:build cff && failing
+// +build cff,failing
+
+package badinputs
+
+import (
+	"context"
+
+	"go.uber.org/cff"
+)
+
+// MaybeWithOtherResults is a flow with a task that returns a cff.Maybe
+// alongside other values.
+func MaybeWithOtherResults() {
+	var (
+		s string
+		i int
+	)
+	cff.Flow(context.Background(),
+		cff.Results(&s, &i),
+		cff.Task(func() (cff.Maybe[string], int) {
+			return cff.Some("foo"), 42
+		}),
+	)
+}
@@ -0,0 +1,95 @@
+//go:build cff && failing
+// +build cff,failing
+
+package badinputs
+
+import (
+	"context"
+
+	"go.uber.org/cff"
+)
+
+// GroupNameNotConstant declares a cff.Group with a name that isn't
+// a constant.
+func GroupNameNotConstant(name string) {
+	var s string
+	cff.Flow(context.Background(),
+		cff.Results(&s),
+		cff.Group(name, 1),
+		cff.Task(func() string { return "foo" }),
+	)
+}
+
+// GroupDeclaredTwice declares two cff.Groups with the same name.
+func GroupDeclaredTwice() {
+	var s string
+	cff.Flow(context.Background(),
+		cff.Results(&s),
+		cff.Group("db", 1),
+		cff.Group("db", 2),
+		cff.Task(func() string { return "foo" }, cff.InGroup("db")),
+	)
+}
+
+// InGroupUnknown adds a task to a group that isn't declared.
+func InGroupUnknown() {
+	var s string
+	cff.Flow(context.Background(),
+		cff.Results(&s),
+		cff.Group("db", 1),
+		cff.Task(func() string { return "foo" }, cff.InGroup("cache")),
+	)
+}
+
+// InGroupTwice adds a task to two groups.
+func InGroupTwice() {
+	var s string
+	cff.Flow(context.Background(),
+		cff.Results(&s),
+		cff.Group("db", 1),
+		cff.Group("cache", 1),
+		cff.Task(func() string { return "foo" }, cff.InGroup("db"), cff.InGroup("cache")),
+	)
+}
+
+// GroupParallel uses cff.Group as an option of a cff.Parallel.
+func GroupParallel() {
+	cff.Parallel(context.Background(),
+		cff.Group("db", 1),
+		cff.Task(func() {}),
+	)
+}
+
+// InGroupParallel uses cff.InGroup on a task of a cff.Parallel.
+func InGroupParallel() {
+	cff.Parallel(context.Background(),
+		cff.Task(func() {}, cff.InGroup("db")),
+	)
+}
+
+// InGroupSwitch uses cff.InGroup on a task of a cff.Switch.
+func InGroupSwitch() {
+	var s string
+	cff.Flow(context.Background(),
+		cff.Results(&s),
+		cff.Group("db", 1),
+		cff.Switch(
+			cff.Default(
+				func() string { return "foo" },
+				cff.InGroup("db"),
+			),
+		),
+	)
+}
+
+// GroupSubflow declares a cff.Group inside a cff.Subflow.
+func GroupSubflow() {
+	var s string
+	cff.Flow(context.Background(),
+		cff.Results(&s),
+		cff.Subflow(
+			cff.Group("db", 1),
+			cff.Task(func() string { return "foo" }),
+		),
+	)
+}
@@ -0,0 +1,36 @@
+//go:build cff && failing
+// +build cff,failing
+
+package badinputs
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/cff"
+)
+
+// HedgeTwice uses cff.Hedge twice on the same task.
+func HedgeTwice() {
+	var s string
+	cff.Flow(context.Background(),
+		cff.Results(&s),
+		cff.Task(
+			func() string { return "foo" },
+			cff.Hedge(time.Millisecond, 2),
+			cff.Hedge(time.Second, 3),
+		),
+	)
+}
+
+// HedgeTwiceParallel uses cff.Hedge twice on the same task of
+// a cff.Parallel.
+func HedgeTwiceParallel() {
+	cff.Parallel(context.Background(),
+		cff.Task(
+			func() {},
+			cff.Hedge(time.Millisecond, 2),
+			cff.Hedge(time.Second, 3),
+		),
+	)
+}
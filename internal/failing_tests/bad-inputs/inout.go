@@ -0,0 +1,44 @@
+//go:build cff && failing
+// +build cff,failing
+
+package badinputs
+
+import (
+	"context"
+
+	"go.uber.org/cff"
+)
+
+type nestedParams struct {
+	cff.In
+
+	Inner struct {
+		cff.In
+
+		Value string
+	}
+}
+
+// NestedInParams is a flow with a cff.In parameter object that nests
+// another parameter object.
+func NestedInParams() {
+	var i int
+	cff.Flow(context.Background(),
+		cff.Results(&i),
+		cff.Task(func(nestedParams) int { return 0 }),
+	)
+}
+
+type sliceParams struct {
+	cff.In
+
+	Value string
+}
+
+// SliceInParams is a cff.Slice whose function accepts a cff.In parameter
+// object.
+func SliceInParams() {
+	cff.Parallel(context.Background(),
+		cff.Slice(func(sliceParams) {}, []sliceParams{}),
+	)
+}
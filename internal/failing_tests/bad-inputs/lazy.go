@@ -0,0 +1,29 @@
+//go:build cff && failing
+// +build cff,failing
+
+package badinputs
+
+import (
+	"context"
+
+	"go.uber.org/cff"
+)
+
+// LazyUnusedParam is a lazy flow with a parameter that no task consumes.
+func LazyUnusedParam() {
+	var s string
+	cff.Flow(context.Background(),
+		cff.Params(42),
+		cff.Results(&s),
+		cff.Task(func() string { return "foo" }),
+		cff.Lazy(),
+	)
+}
+
+// ParallelLazy is a parallel that uses cff.Lazy.
+func ParallelLazy() {
+	cff.Parallel(context.Background(),
+		cff.Task(func() {}),
+		cff.Lazy(),
+	)
+}
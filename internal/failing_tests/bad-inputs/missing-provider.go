@@ -4,7 +4,9 @@
 package badinputs
 
 import (
+	"bytes"
 	"context"
+	"io"
 
 	"go.uber.org/cff"
 )
@@ -21,3 +23,58 @@ func MissingProvider() {
 		),
 	)
 }
+
+type Buffer struct{}
+
+// MissingProviderPointer is a flow that provides a pointer to the type
+// that it needs.
+func MissingProviderPointer() {
+	var s string
+	cff.Flow(context.Background(),
+		cff.Results(&s),
+		cff.Task(
+			func() *bytes.Buffer {
+				return new(bytes.Buffer)
+			},
+		),
+		cff.Task(
+			func(bytes.Buffer) string {
+				return ""
+			},
+		),
+	)
+}
+
+// MissingProviderAssignable is a flow that provides a type assignable to
+// the type that it needs.
+func MissingProviderAssignable() {
+	var s string
+	cff.Flow(context.Background(),
+		cff.Results(&s),
+		cff.Task(
+			func() *bytes.Buffer {
+				return new(bytes.Buffer)
+			},
+		),
+		cff.Task(
+			func(io.Reader) string {
+				return ""
+			},
+		),
+	)
+}
+
+// MissingProviderSameName is a flow that provides a type with the same name
+// as the type it needs, but from a different package.
+func MissingProviderSameName() {
+	var s string
+	cff.Flow(context.Background(),
+		cff.Params(Buffer{}),
+		cff.Results(&s),
+		cff.Task(
+			func(bytes.Buffer) string {
+				return ""
+			},
+		),
+	)
+}
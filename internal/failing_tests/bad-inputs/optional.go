@@ -0,0 +1,34 @@
+//go:build cff && failing
+// +build cff,failing
+
+package badinputs
+
+import (
+	"context"
+	"errors"
+
+	"go.uber.org/cff"
+)
+
+// OptionalWithFallback is a flow with a task that is both optional and
+// has a fallback.
+func OptionalWithFallback() {
+	var s string
+	cff.Flow(context.Background(),
+		cff.Results(&s),
+		cff.Task(
+			func() (string, error) {
+				return "", errors.New("great sadness")
+			},
+			cff.Optional(),
+			cff.FallbackWith("foo"),
+		),
+	)
+}
+
+// ParallelOptional is a parallel with an optional task.
+func ParallelOptional() {
+	cff.Parallel(context.Background(),
+		cff.Task(func() {}, cff.Optional()),
+	)
+}
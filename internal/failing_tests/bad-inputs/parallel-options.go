@@ -0,0 +1,101 @@
+//go:build cff && failing
+// +build cff,failing
+
+package badinputs
+
+import (
+	"context"
+
+	"go.uber.org/cff"
+)
+
+// ParallelPredicateParams is a Parallel task with a predicate that accepts
+// something other than a context.
+func ParallelPredicateParams() {
+	cff.Parallel(context.Background(),
+		cff.Task(
+			func() {},
+			cff.Predicate(func(s string) bool { return s != "" }),
+		),
+	)
+}
+
+// ParallelFallbackWithValues is a Parallel task with a cff.FallbackWith that
+// provides values.
+func ParallelFallbackWithValues() {
+	cff.Parallel(context.Background(),
+		cff.Task(
+			func() error { return nil },
+			cff.FallbackWith("foo"),
+		),
+	)
+}
+
+// ParallelFallbackWithoutError is a Parallel task that uses
+// cff.FallbackWith but cannot fail.
+func ParallelFallbackWithoutError() {
+	cff.Parallel(context.Background(),
+		cff.Task(
+			func() {},
+			cff.FallbackWith(),
+		),
+	)
+}
+
+// ParallelPredicateOnErrorWithoutPredicate is a Parallel task that uses
+// cff.PredicateOnError without a predicate.
+func ParallelPredicateOnErrorWithoutPredicate() {
+	cff.Parallel(context.Background(),
+		cff.Task(
+			func() {},
+			cff.PredicateOnError(true),
+		),
+	)
+}
+
+// SliceFallbackWrongElement is a cff.SliceFallback that accepts the wrong
+// element type.
+func SliceFallbackWrongElement() {
+	cff.Parallel(context.Background(),
+		cff.Slice(
+			func(string) error { return nil },
+			[]string{"foo"},
+			cff.SliceFallback(func(int, error) {}),
+		),
+	)
+}
+
+// SliceFallbackResults is a cff.SliceFallback that returns a value.
+func SliceFallbackResults() {
+	cff.Parallel(context.Background(),
+		cff.Slice(
+			func(string) error { return nil },
+			[]string{"foo"},
+			cff.SliceFallback(func(string, error) error { return nil }),
+		),
+	)
+}
+
+// SliceFallbackMultiple is a cff.Slice with more than one cff.SliceFallback.
+func SliceFallbackMultiple() {
+	cff.Parallel(context.Background(),
+		cff.Slice(
+			func(string) error { return nil },
+			[]string{"foo"},
+			cff.SliceFallback(func(string, error) {}),
+			cff.SliceFallback(func(string, error) {}),
+		),
+	)
+}
+
+// MapFallbackMissingError is a cff.MapFallback that doesn't accept the
+// error.
+func MapFallbackMissingError() {
+	cff.Parallel(context.Background(),
+		cff.Map(
+			func(string, int) error { return nil },
+			map[string]int{"foo": 1},
+			cff.MapFallback(func(string, int) {}),
+		),
+	)
+}
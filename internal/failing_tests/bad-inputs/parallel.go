@@ -278,39 +278,38 @@ func ParallelSliceNonLastError() {
 	)
 }
 
-// ParallelSliceEndWithContinueOnError is a cff.Slice that uses cff.ContinueOnError
-// and cff.SliceEnd.
-func ParallelSliceEndWithContinueOnError() {
+// ParallelSliceEndTooManyArguments is a cff.Slice function that has too many arguments.
+func ParallelSliceEndTooManyArguments() {
 	cff.Parallel(
 		context.Background(),
-		cff.ContinueOnError(true),
 		cff.Slice(
-			func(int, string) error {
+			func(_ int, _ string) error {
 				return nil
 			},
 			[]string{"some", "thing"},
-			cff.SliceEnd(func(context.Context) {}),
+			cff.SliceEnd(func(context.Context, int) {}),
 		),
 	)
 }
 
-// ParallelSliceEndTooManyArguments is a cff.Slice function that has too many arguments.
-func ParallelSliceEndTooManyArguments() {
+// ParallelSliceEndWithInvalidArgument is a cff.Slice function that has invalid
+// argument in cff.SliceEnd.
+func ParallelSliceEndWithInvalidArgument() {
 	cff.Parallel(
 		context.Background(),
 		cff.Slice(
-			func(_ int, _ string) error {
+			func(int, string) error {
 				return nil
 			},
 			[]string{"some", "thing"},
-			cff.SliceEnd(func(context.Context, int) {}),
+			cff.SliceEnd(func(int) {}),
 		),
 	)
 }
 
-// ParallelSliceEndWithInvalidArgument is a cff.Slice function that has invalid
-// argument in cff.SliceEnd.
-func ParallelSliceEndWithInvalidArgument() {
+// ParallelSliceEndWithWrongElementErrors is a cff.SliceEnd function that
+// accepts cff.ElementErrors that aren't keyed by the slice index.
+func ParallelSliceEndWithWrongElementErrors() {
 	cff.Parallel(
 		context.Background(),
 		cff.Slice(
@@ -318,7 +317,7 @@ func ParallelSliceEndWithInvalidArgument() {
 				return nil
 			},
 			[]string{"some", "thing"},
-			cff.SliceEnd(func(int) {}),
+			cff.SliceEnd(func(cff.ElementErrors[string]) {}),
 		),
 	)
 }
@@ -357,53 +356,29 @@ func ParallelSliceEndWithInvalidReturn() {
 	)
 }
 
-// ParallelSliceEndWithContinueOnErrorAndInstrument is a cff.Slice function
-// that uses SliceEnd, ContinueOnError, and Instrument.
-//
-// This tests for a regression where SliceEnd-ContinueOnError incompatibility
-// was not verified if Instrument was used.
-func ParallelSliceEndWithContinueOnErrorAndInstrument() {
-	cff.Parallel(context.Background(),
-		cff.WithEmitter(cff.NopEmitter()),
-		cff.InstrumentParallel("myparallel"),
-		cff.ContinueOnError(true),
+// ParallelSliceWithTwoSliceEnds is a cff.Slice function that has more than
+// one cff.SliceEnd.
+func ParallelSliceWithTwoSliceEnds() {
+	cff.Parallel(
+		context.Background(),
 		cff.Slice(
-			func(int, string) {
-				// stuff
+			func(int, string) error {
+				return nil
 			},
-			[]string{"foo", "bar"},
+			[]string{"some", "thing"},
 			cff.SliceEnd(func() error {
 				return nil
 			}),
-		),
-	)
-}
-
-// ParallelSliceEndWithContinueOnErrorAndInstrument is a cff.Slice function
-// that uses SliceEnd, ContinueOnError, and Instrument.
-//
-// This tests for a regression where SliceEnd-ContinueOnError incompatibility
-// was not verified if Instrument was used.
-func ParallelMapEndWithContinueOnErrorAndInstrument() {
-	cff.Parallel(context.Background(),
-		cff.WithEmitter(cff.NopEmitter()),
-		cff.InstrumentParallel("myparallel"),
-		cff.ContinueOnError(true),
-		cff.Map(
-			func(string, string) {
-				// stuff
-			},
-			map[string]string{"foo": "bar"},
-			cff.MapEnd(func() error {
+			cff.SliceEnd(func() error {
 				return nil
 			}),
 		),
 	)
 }
 
-// ParallelSliceWithTwoSliceEnds is a cff.Slice function that has more than
-// one cff.SliceEnd.
-func ParallelSliceWithTwoSliceEnds() {
+// ParallelSliceWithTwoBatches is a cff.Slice function that has more than
+// one cff.Batch.
+func ParallelSliceWithTwoBatches() {
 	cff.Parallel(
 		context.Background(),
 		cff.Slice(
@@ -411,12 +386,8 @@ func ParallelSliceWithTwoSliceEnds() {
 				return nil
 			},
 			[]string{"some", "thing"},
-			cff.SliceEnd(func() error {
-				return nil
-			}),
-			cff.SliceEnd(func() error {
-				return nil
-			}),
+			cff.Batch(10),
+			cff.Batch(20),
 		),
 	)
 }
@@ -525,29 +496,29 @@ func ParallelMapEndWithNonContextArgument() {
 	)
 }
 
-// ParallelMapEndWithNonErrorResult has a cff.MapEnd call
-// with a function that returns a non-error result.
-func ParallelMapEndWithNonErrorResult() {
+// ParallelMapEndWithWrongElementErrors has a cff.MapEnd call
+// with a function that accepts cff.ElementErrors that aren't keyed by the
+// map key.
+func ParallelMapEndWithWrongElementErrors() {
 	cff.Parallel(
 		context.Background(),
 		cff.Map(
 			func(k string, v bool) (e error) { return },
 			map[string]bool{"true": true},
-			cff.MapEnd(func() int { return 0 }),
+			cff.MapEnd(func(cff.ElementErrors[int]) {}),
 		),
 	)
 }
 
-// ParallelMapEndWithContinueOnError has a cff.MapEnd call
-// with a ContinueOnError.
-func ParallelMapEndWithContinueOnError() {
+// ParallelMapEndWithNonErrorResult has a cff.MapEnd call
+// with a function that returns a non-error result.
+func ParallelMapEndWithNonErrorResult() {
 	cff.Parallel(
 		context.Background(),
-		cff.ContinueOnError(true),
 		cff.Map(
 			func(k string, v bool) (e error) { return },
 			map[string]bool{"true": true},
-			cff.MapEnd(func() {}),
+			cff.MapEnd(func() int { return 0 }),
 		),
 	)
 }
@@ -0,0 +1,66 @@
+//go:build cff && failing
+// +build cff,failing
+
+package badinputs
+
+import (
+	"context"
+
+	"go.uber.org/cff"
+)
+
+// PredicateOnErrorWithoutPredicate uses cff.PredicateOnError on a task
+// without a predicate.
+func PredicateOnErrorWithoutPredicate() {
+	cff.Flow(context.Background(),
+		cff.Task(
+			func() {},
+			cff.Invoke(true),
+			cff.PredicateOnError(false),
+		),
+	)
+}
+
+// PredicateOnErrorWithoutError uses cff.PredicateOnError with a predicate
+// that cannot fail.
+func PredicateOnErrorWithoutError() {
+	cff.Flow(context.Background(),
+		cff.Task(
+			func() {},
+			cff.Invoke(true),
+			cff.Predicate(func() bool { return true }),
+			cff.PredicateOnError(false),
+		),
+	)
+}
+
+// PredicateOnErrorDefault uses cff.PredicateOnError on the default case of
+// a cff.Switch.
+func PredicateOnErrorDefault() {
+	var s string
+	cff.Flow(context.Background(),
+		cff.Results(&s),
+		cff.Switch(
+			cff.Case(
+				func() (bool, error) { return true, nil },
+				func() string { return "foo" },
+			),
+			cff.Default(
+				func() string { return "bar" },
+				cff.PredicateOnError(false),
+			),
+		),
+	)
+}
+
+// PredicateOnErrorParallel uses cff.PredicateOnError in a cff.Parallel task
+// with a predicate that cannot fail.
+func PredicateOnErrorParallel() {
+	cff.Parallel(context.Background(),
+		cff.Task(
+			func() {},
+			cff.Predicate(func() bool { return true }),
+			cff.PredicateOnError(false),
+		),
+	)
+}
@@ -0,0 +1,81 @@
+//go:build cff && failing
+// +build cff,failing
+
+package badinputs
+
+import (
+	"context"
+
+	"go.uber.org/cff"
+)
+
+// QuorumFlow uses cff.Quorum as an option of a cff.Flow.
+func QuorumFlow() {
+	var s string
+	cff.Flow(context.Background(),
+		cff.Results(&s),
+		cff.Quorum(1),
+		cff.Task(func() string { return "foo" }),
+	)
+}
+
+// FirstSuccessFlow uses cff.FirstSuccess as an option of a cff.Flow.
+func FirstSuccessFlow() {
+	var s string
+	cff.Flow(context.Background(),
+		cff.Results(&s),
+		cff.FirstSuccess(),
+		cff.Task(func() string { return "foo" }),
+	)
+}
+
+// QuorumTwice uses both cff.Quorum and cff.FirstSuccess.
+func QuorumTwice() {
+	cff.Parallel(context.Background(),
+		cff.Task(func() {}),
+		cff.Task(func() {}),
+		cff.Quorum(2),
+		cff.FirstSuccess(),
+	)
+}
+
+// QuorumContinueOnError uses cff.Quorum with cff.ContinueOnError.
+func QuorumContinueOnError() {
+	cff.Parallel(context.Background(),
+		cff.Task(func() {}),
+		cff.Quorum(1),
+		cff.ContinueOnError(true),
+	)
+}
+
+// QuorumSliceEnd uses cff.FirstSuccess with cff.SliceEnd.
+func QuorumSliceEnd() {
+	cff.Parallel(context.Background(),
+		cff.Slice(
+			func(string) {},
+			[]string{"foo"},
+			cff.SliceEnd(func() {}),
+		),
+		cff.FirstSuccess(),
+	)
+}
+
+// QuorumMapEnd uses cff.Quorum with cff.MapEnd.
+func QuorumMapEnd() {
+	cff.Parallel(context.Background(),
+		cff.Map(
+			func(string, int) {},
+			map[string]int{"foo": 1},
+			cff.MapEnd(func() {}),
+		),
+		cff.Quorum(1),
+	)
+}
+
+// QuorumZero uses cff.Quorum with no tasks to succeed.
+func QuorumZero() {
+	cff.Parallel(context.Background(),
+		cff.Task(func() {}),
+		cff.Quorum(0),
+	)
+}
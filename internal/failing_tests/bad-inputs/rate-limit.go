@@ -0,0 +1,34 @@
+//go:build cff && failing
+// +build cff,failing
+
+package badinputs
+
+import (
+	"context"
+
+	"go.uber.org/cff"
+)
+
+// RateLimitFlow uses cff.RateLimit as an option of a cff.Flow.
+func RateLimitFlow() {
+	var s string
+	cff.Flow(context.Background(),
+		cff.Results(&s),
+		cff.RateLimit(cff.NewTokenBucket(1, 1)),
+		cff.Task(func() string { return "foo" }),
+	)
+}
+
+// RateLimitSwitch uses cff.RateLimit on a task of a cff.Switch.
+func RateLimitSwitch() {
+	var s string
+	cff.Flow(context.Background(),
+		cff.Results(&s),
+		cff.Switch(
+			cff.Default(
+				func() string { return "foo" },
+				cff.RateLimit(cff.NewTokenBucket(1, 1)),
+			),
+		),
+	)
+}
@@ -0,0 +1,68 @@
+//go:build cff && failing
+// +build cff,failing
+
+package badinputs
+
+import (
+	"context"
+
+	"go.uber.org/cff"
+)
+
+// NestedSubflow is a flow with a subflow inside another.
+func NestedSubflow() {
+	var s string
+	cff.Flow(context.Background(),
+		cff.Results(&s),
+		cff.Subflow(
+			cff.Task(func() int { return 42 }),
+			cff.Subflow(
+				cff.Task(func(int) string { return "foo" }),
+			),
+		),
+	)
+}
+
+// SubflowInvalidOption is a flow with a subflow that specifies
+// an option other than its tasks and settings.
+func SubflowInvalidOption() {
+	var s string
+	cff.Flow(context.Background(),
+		cff.Results(&s),
+		cff.Subflow(
+			cff.Task(func() string { return "foo" }),
+			cff.Params(42),
+		),
+	)
+}
+
+// EmptySubflow is a flow with a subflow without tasks.
+func EmptySubflow() {
+	var s string
+	cff.Flow(context.Background(),
+		cff.Results(&s),
+		cff.Task(func() string { return "foo" }),
+		cff.Subflow(cff.Concurrency(2)),
+	)
+}
+
+// SubflowInParallel is a parallel with a subflow.
+func SubflowInParallel() {
+	cff.Parallel(context.Background(),
+		cff.Subflow(
+			cff.Task(func() {}),
+		),
+	)
+}
+
+// SubflowInterceptor is a flow with a subflow that has its own interceptor.
+func SubflowInterceptor() {
+	var s string
+	cff.Flow(context.Background(),
+		cff.Results(&s),
+		cff.Subflow(
+			cff.WithInterceptor(nil),
+			cff.Task(func() string { return "foo" }),
+		),
+	)
+}
@@ -0,0 +1,95 @@
+//go:build cff && failing
+// +build cff,failing
+
+package badinputs
+
+import (
+	"context"
+
+	"go.uber.org/cff"
+)
+
+// SwitchWithoutCase is a flow with a cff.Switch that has only a default.
+func SwitchWithoutCase() {
+	var s string
+	cff.Flow(context.Background(),
+		cff.Results(&s),
+		cff.Switch(
+			cff.Default(func() string { return "foo" }),
+		),
+	)
+}
+
+// SwitchDefaultNotLast is a flow with a cff.Switch whose default is not
+// the last argument.
+func SwitchDefaultNotLast() {
+	var s string
+	cff.Flow(context.Background(),
+		cff.Results(&s),
+		cff.Switch(
+			cff.Default(func() string { return "foo" }),
+			cff.Case(
+				func() bool { return true },
+				func() string { return "bar" },
+			),
+		),
+	)
+}
+
+// SwitchIncompatibleTasks is a flow with a cff.Switch whose tasks produce
+// different types.
+func SwitchIncompatibleTasks() {
+	var s string
+	cff.Flow(context.Background(),
+		cff.Results(&s),
+		cff.Switch(
+			cff.Case(
+				func() bool { return true },
+				func() string { return "foo" },
+			),
+			cff.Default(func() (string, int) { return "bar", 42 }),
+		),
+	)
+}
+
+// SwitchCaseWithPredicate is a flow with a cff.Switch case that has a
+// cff.Predicate.
+func SwitchCaseWithPredicate() {
+	var s string
+	cff.Flow(context.Background(),
+		cff.Results(&s),
+		cff.Switch(
+			cff.Case(
+				func() bool { return true },
+				func() string { return "foo" },
+				cff.Predicate(func() bool { return true }),
+			),
+		),
+	)
+}
+
+// SwitchCaseWithInvoke is a flow with a cff.Switch case that uses
+// cff.Invoke.
+func SwitchCaseWithInvoke() {
+	cff.Flow(context.Background(),
+		cff.Switch(
+			cff.Case(
+				func() bool { return true },
+				func() error { return nil },
+				cff.Invoke(true),
+			),
+		),
+	)
+}
+
+// ParallelSwitch is a parallel with a cff.Switch.
+func ParallelSwitch() {
+	cff.Parallel(context.Background(),
+		cff.Switch(
+			cff.Case(
+				func() bool { return true },
+				func() {},
+			),
+		),
+	)
+}
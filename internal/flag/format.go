@@ -0,0 +1,57 @@
+package flag
+
+import (
+	"encoding"
+	"flag"
+	"fmt"
+)
+
+// Format specifies the output format for diagnostics reported by cff.
+type Format uint8
+
+const (
+	// TextFormat reports diagnostics as human-readable text.
+	TextFormat Format = iota + 1
+
+	// JSONFormat reports diagnostics as a JSON array on stdout.
+	JSONFormat
+)
+
+var (
+	_ encoding.TextUnmarshaler = (*Format)(nil)
+	_ flag.Getter              = (*Format)(nil)
+)
+
+func (f Format) String() string {
+	switch f {
+	case TextFormat:
+		return "text"
+	case JSONFormat:
+		return "json"
+	default:
+		return "unknown"
+	}
+}
+
+// UnmarshalText unmarshals a Format.
+func (f *Format) UnmarshalText(text []byte) error {
+	return f.Set(string(text))
+}
+
+// Get reports the current value of the flag.
+func (f *Format) Get() any {
+	return *f
+}
+
+// Set receives a flag value from the flag package.
+func (f *Format) Set(value string) error {
+	switch value {
+	case "text":
+		*f = TextFormat
+	case "json":
+		*f = JSONFormat
+	default:
+		return fmt.Errorf("unknown format %q", value)
+	}
+	return nil
+}
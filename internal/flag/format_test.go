@@ -0,0 +1,68 @@
+package flag
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormat(t *testing.T) {
+	tests := []struct {
+		name   string
+		format Format
+	}{
+		{
+			name:   "text",
+			format: TextFormat,
+		},
+		{
+			name:   "json",
+			format: JSONFormat,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got Format
+			fset := NewSet("foo")
+			fset.Var(&got, "x", "")
+			require.NoError(t, fset.Parse([]string{"-x", tt.name}))
+			assert.Equal(t, tt.format, got)
+
+			t.Run("String", func(t *testing.T) {
+				assert.Equal(t, tt.name, tt.format.String())
+			})
+
+			t.Run("Get", func(t *testing.T) {
+				assert.Equal(t, tt.format, got.Get())
+			})
+
+			t.Run("UnmarshalText", func(t *testing.T) {
+				var got Format
+				require.NoError(t, got.UnmarshalText([]byte(tt.name)))
+				assert.Equal(t, tt.format, got)
+			})
+		})
+	}
+}
+
+func TestFormatUnknown_String(t *testing.T) {
+	tests := []Format{0, 10, 20}
+	for _, tt := range tests {
+		t.Run(fmt.Sprint(int(tt)), func(t *testing.T) {
+			assert.Equal(t, "unknown", tt.String())
+		})
+	}
+}
+
+func TestFormatUnknown_Unmarshal(t *testing.T) {
+	tests := []string{"foo", "xml", "unknown"}
+	for _, tt := range tests {
+		t.Run(tt, func(t *testing.T) {
+			var f Format
+			assert.ErrorContains(t, f.Set(tt), "unknown format")
+		})
+	}
+}
@@ -15,7 +15,9 @@ import (
 	"path/filepath"
 	"sort"
 	"strconv"
+	"strings"
 	"text/template"
+	"unicode"
 
 	"go.uber.org/cff/internal/flag"
 	"golang.org/x/tools/go/ast/astutil"
@@ -157,6 +159,17 @@ func (g *generator) GenerateFile(f *file) error {
 
 		return err
 	}
+	// Imports referenced only by cff.Bundle declarations are unused once
+	// the declarations are replaced.
+	for _, imp := range f.BundleOnlyImports {
+		importPath, _ := strconv.Unquote(imp.Path.Value)
+		var name string
+		if imp.Name != nil {
+			name = imp.Name.Name
+		}
+		astutil.DeleteNamedImport(fset, file, name, importPath)
+	}
+
 	newImports := make([]string, 0, len(addImports))
 	for imp := range addImports {
 		newImports = append(newImports, imp)
@@ -237,6 +250,17 @@ func (g *generator) generateFlow(file *file, f *flow, w io.Writer, addImports ma
 	// in the order they were specified by the user.
 	exprs := make(map[ast.Expr]struct{})
 
+	// Expressions of tasks added with cff.Use may refer to packages
+	// that this file doesn't import.
+	for _, pn := range f.BundleImports {
+		name := pn.Name()
+		aliases[name] = struct{}{}
+		if name == pn.Imported().Name() {
+			name = "" // implicit name
+		}
+		addImports[pn.Imported().Path()] = name
+	}
+
 	fnMap := g.funcMap(file, addImports, aliases, exprs)
 	t := template.New(_flowRootTmpl).Funcs(fnMap)
 	tmpl, err := t.ParseFS(tmplFS, _flowTmplDir, _sharedTmplDir)
@@ -290,7 +314,7 @@ func (g *generator) funcMap(
 	aliases map[string]struct{},
 	exprs map[ast.Expr]struct{},
 ) template.FuncMap {
-	p := &exprPrinter{exprs: exprs, g: g, sourceMapped: g.sourceMapped}
+	p := &exprPrinter{exprs: exprs, g: g, filename: file.Filepath, sourceMapped: g.sourceMapped}
 	return template.FuncMap{
 		"type": g.typePrinter(file, addImports, aliases),
 		"typeName": func(t types.Type) string {
@@ -299,6 +323,7 @@ func (g *generator) funcMap(
 			return types.TypeString(t, nil)
 		},
 		"typeHash":    g.printTypeHash,
+		"outputVar":   g.printOutputVar,
 		"predHash":    g.printPredicateHash,
 		"isPredicate": g.isPredicate,
 		"expr":        p.printExpr,
@@ -360,6 +385,18 @@ func (g *generator) printTypeHash(t types.Type) string {
 	return strconv.Itoa(g.typeID(t))
 }
 
+// printOutputVar prints the name of the variable that holds the output of
+// task t at index i.
+//
+// Outputs of cff.Collect tasks are held in variables specific to the task
+// because other tasks may produce the same types.
+func (g *generator) printOutputVar(t *task, i int) string {
+	if t.Collect {
+		return fmt.Sprintf("task%dCollected%d", t.Serial, i)
+	}
+	return "v" + g.printTypeHash(t.Outputs[i])
+}
+
 func (g *generator) printPredicateHash(p *predicate) string {
 	return strconv.Itoa(g.predID(p))
 }
@@ -411,6 +448,7 @@ func (g *generator) printRawExpr(e ast.Expr) string {
 type exprPrinter struct {
 	exprs        map[ast.Expr]struct{}
 	g            *generator
+	filename     string // file for which code is being generated
 	sourceMapped bool
 }
 
@@ -445,10 +483,28 @@ func (p *exprPrinter) printExpr(e ast.Expr) string {
 		return p.g.printRawExpr(e)
 	}
 	p.exprs[e] = struct{}{}
-	pos := p.g.posInfo(e)
+	pos := p.g.fset.Position(e.Pos())
+	if pos.Filename != p.filename {
+		// Expressions of tasks added with cff.Use may be in other files
+		// of the package. Qualify them with the file name so that they
+		// don't collide with expressions of this file.
+		return fmt.Sprintf("_%v_%d_%d", fileIdent(pos.Filename), pos.Line, pos.Column)
+	}
 	return fmt.Sprintf("_%d_%d", pos.Line, pos.Column)
 }
 
+// fileIdent builds a string suitable for use in an identifier from the name
+// of a Go file.
+func fileIdent(path string) string {
+	name := strings.TrimSuffix(filepath.Base(path), ".go")
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return r
+		}
+		return -1
+	}, name)
+}
+
 // printLineDir prints a line directive for an ast.Expr. It looks up the
 // position of the expression in the original source, so that the generated
 // code can be mapped back to the original source.
@@ -561,6 +617,27 @@ func (g flowGenerator) Pos() token.Pos {
 	return g.flow.Pos()
 }
 
+// bundleGenerator replaces a cff.Bundle call with an empty cff.TaskBundle.
+// The tasks of the bundle are generated inside the flows that use it.
+type bundleGenerator struct {
+	call *ast.CallExpr
+}
+
+func (g bundleGenerator) generate(p genParams) error {
+	// compileFile only accepts cff.Bundle calls in the form pkg.Bundle(..).
+	sel := g.call.Fun.(*ast.SelectorExpr)
+	_, err := fmt.Fprintf(p.writer, "%v.TaskBundle{}", sel.X)
+	return err
+}
+
+func (g bundleGenerator) End() token.Pos {
+	return g.call.End()
+}
+
+func (g bundleGenerator) Pos() token.Pos {
+	return g.call.Pos()
+}
+
 type parallelGenerator struct {
 	parallel *parallel
 }
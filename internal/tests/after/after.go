@@ -0,0 +1,125 @@
+//go:build cff
+// +build cff
+
+// Package after tests flows with tasks ordered by cff.After.
+package after
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"go.uber.org/cff"
+)
+
+// Recorder records the order in which tasks ran.
+type Recorder struct {
+	mu    sync.Mutex
+	names []string
+}
+
+// Record records that the named task ran.
+func (r *Recorder) Record(name string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.names = append(r.names, name)
+}
+
+// Names returns the names of the tasks in the order they ran.
+func (r *Recorder) Names() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string(nil), r.names...)
+}
+
+// ByRef runs a task after another referred to by a cff.TaskRef.
+// The first task is slower so that without the ordering,
+// the second task would run first.
+func ByRef(ctx context.Context, r *Recorder) error {
+	var commit cff.TaskRef
+	return cff.Flow(ctx,
+		cff.Concurrency(2),
+		cff.Task(func() {
+			time.Sleep(10 * time.Millisecond)
+			r.Record("commit")
+		}, cff.Invoke(true), cff.Ref(&commit)),
+		cff.Task(func() {
+			r.Record("audit")
+		}, cff.Invoke(true), cff.After(commit)),
+	)
+}
+
+// ByName runs a task after another referred to by its instrumentation name.
+func ByName(ctx context.Context, r *Recorder) error {
+	return cff.Flow(ctx,
+		cff.Concurrency(2),
+		cff.WithEmitter(cff.NopEmitter()),
+		cff.Task(func() {
+			time.Sleep(10 * time.Millisecond)
+			r.Record("commit")
+		}, cff.Invoke(true), cff.Instrument("commit")),
+		cff.Task(func() {
+			r.Record("audit")
+		}, cff.Invoke(true), cff.After("commit")),
+	)
+}
+
+// Multiple runs a task after two others, one of which also provides
+// a value to the flow.
+func Multiple(ctx context.Context, r *Recorder) (string, error) {
+	var (
+		a, b cff.TaskRef
+		s    string
+	)
+	err := cff.Flow(ctx,
+		cff.Concurrency(3),
+		cff.Results(&s),
+		cff.Task(func() string {
+			time.Sleep(10 * time.Millisecond)
+			r.Record("a")
+			return "a"
+		}, cff.Ref(&a)),
+		cff.Task(func() {
+			time.Sleep(5 * time.Millisecond)
+			r.Record("b")
+		}, cff.Invoke(true), cff.Ref(&b)),
+		cff.Task(func() {
+			r.Record("c")
+		}, cff.Invoke(true), cff.After(a, b)),
+	)
+	return s, err
+}
+
+// SkippedTarget runs a task after another that may be skipped by
+// a predicate.
+func SkippedTarget(ctx context.Context, r *Recorder, run bool) error {
+	var first cff.TaskRef
+	return cff.Flow(ctx,
+		cff.Params(run),
+		cff.Task(func() {
+			r.Record("first")
+		},
+			cff.Invoke(true),
+			cff.Predicate(func(run bool) bool { return run }),
+			cff.Ref(&first),
+		),
+		cff.Task(func() {
+			r.Record("second")
+		}, cff.Invoke(true), cff.After(first)),
+	)
+}
+
+// FailedTarget runs a task after another that fails.
+func FailedTarget(ctx context.Context, r *Recorder) error {
+	var first cff.TaskRef
+	return cff.Flow(ctx,
+		cff.Task(func() error {
+			r.Record("first")
+			return errors.New("great sadness")
+		}, cff.Invoke(true), cff.Ref(&first)),
+		cff.Task(func() {
+			r.Record("second")
+		}, cff.Invoke(true), cff.After(first)),
+	)
+}
@@ -0,0 +1,1195 @@
+//go:build !cff
+// +build !cff
+
+// Package after tests flows with tasks ordered by cff.After.
+package after
+
+import (
+	"context"
+	"errors"
+	"runtime/debug"
+	"sync"
+	"time"
+
+	"go.uber.org/cff"
+)
+
+// Recorder records the order in which tasks ran.
+type Recorder struct {
+	mu    sync.Mutex
+	names []string
+}
+
+// Record records that the named task ran.
+func (r *Recorder) Record(name string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.names = append(r.names, name)
+}
+
+// Names returns the names of the tasks in the order they ran.
+func (r *Recorder) Names() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string(nil), r.names...)
+}
+
+// ByRef runs a task after another referred to by a cff.TaskRef.
+// The first task is slower so that without the ordering,
+// the second task would run first.
+func ByRef(ctx context.Context, r *Recorder) error {
+	var commit cff.TaskRef
+	return func() (err error) {
+
+		_41_18 := ctx
+
+		_42_19 := 2
+
+		_43_12 := func() {
+			time.Sleep(10 * time.Millisecond)
+			r.Record("commit")
+		}
+
+		_46_32 := &commit
+
+		_47_12 := func() {
+			r.Record("audit")
+		}
+
+		_49_34 := commit
+		ctx := _41_18
+		_ = _46_32 // cff.TaskRef
+		_ = _49_34 // cff.TaskRef
+		emitter := cff.NopEmitter()
+		interceptor := cff.InterceptorStack()
+
+		var (
+			flowInfo = &cff.FlowInfo{
+				File:   "go.uber.org/cff/internal/tests/after/after.go",
+				Line:   41,
+				Column: 9,
+			}
+			flowEmitter = cff.NopFlowEmitter()
+
+			schedInfo = &cff.SchedulerInfo{
+				Name:      flowInfo.Name,
+				Directive: cff.FlowDirective,
+				File:      flowInfo.File,
+				Line:      flowInfo.Line,
+				Column:    flowInfo.Column,
+			}
+
+			// possibly unused
+			_ = flowInfo
+		)
+
+		startTime := time.Now()
+		defer func() { flowEmitter.FlowDone(ctx, time.Since(startTime)) }()
+
+		schedEmitter := emitter.SchedulerInit(schedInfo)
+
+		sched := cff.NewScheduler(
+			cff.SchedulerParams{
+				Concurrency: _42_19, Emitter: schedEmitter,
+			},
+		)
+
+		var tasks []*struct {
+			emitter cff.TaskEmitter
+			ran     cff.AtomicBool
+			run     func(context.Context) error
+			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		}
+		defer func() {
+			for _, t := range tasks {
+				if !t.ran.Load() {
+					t.emitter.TaskSkipped(ctx, t.outcome.SkipReason(ctx, err))
+				}
+			}
+		}()
+
+		// go.uber.org/cff/internal/tests/after/after.go:43:12
+		task0 := new(struct {
+			emitter cff.TaskEmitter
+			ran     cff.AtomicBool
+			run     func(context.Context) error
+			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		})
+		task0.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/after/after.go",
+			Line:   43,
+			Column: 12,
+		}
+		task0.emitter = cff.NopTaskEmitter()
+		task0.run = func(ctx context.Context) (err error) {
+			taskEmitter := task0.emitter
+			startTime := time.Now()
+			defer func() {
+				task0.outcome.Finish(err)
+				if task0.ran.Load() {
+					taskEmitter.TaskDone(ctx, time.Since(startTime))
+				}
+			}()
+
+			defer func() {
+				recovered := recover()
+				if recovered != nil {
+					taskEmitter.TaskPanic(ctx, recovered)
+					err = &cff.PanicError{
+						Value:      recovered,
+						Stacktrace: debug.Stack(),
+					}
+				}
+			}()
+
+			defer task0.ran.Store(true)
+
+			err = cff.Intercept(ctx, interceptor, task0.outcome.Info, func(ctx context.Context) (err error) {
+				_43_12()
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return err
+			} else {
+				taskEmitter.TaskSuccess(ctx)
+			}
+
+			return
+		}
+
+		task0.job = sched.Enqueue(ctx, cff.Job{
+			Run: task0.run,
+		})
+		tasks = append(tasks, task0)
+
+		// go.uber.org/cff/internal/tests/after/after.go:47:12
+		task1 := new(struct {
+			emitter cff.TaskEmitter
+			ran     cff.AtomicBool
+			run     func(context.Context) error
+			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		})
+		task1.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/after/after.go",
+			Line:   47,
+			Column: 12,
+		}
+		task1.outcome.Dependencies = []*cff.TaskOutcome{
+			&task0.outcome,
+		}
+		task1.emitter = cff.NopTaskEmitter()
+		task1.run = func(ctx context.Context) (err error) {
+			taskEmitter := task1.emitter
+			startTime := time.Now()
+			defer func() {
+				task1.outcome.Finish(err)
+				if task1.ran.Load() {
+					taskEmitter.TaskDone(ctx, time.Since(startTime))
+				}
+			}()
+
+			defer func() {
+				recovered := recover()
+				if recovered != nil {
+					taskEmitter.TaskPanic(ctx, recovered)
+					err = &cff.PanicError{
+						Value:      recovered,
+						Stacktrace: debug.Stack(),
+					}
+				}
+			}()
+
+			defer task1.ran.Store(true)
+
+			err = cff.Intercept(ctx, interceptor, task1.outcome.Info, func(ctx context.Context) (err error) {
+				_47_12()
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return err
+			} else {
+				taskEmitter.TaskSuccess(ctx)
+			}
+
+			return
+		}
+
+		task1.job = sched.Enqueue(ctx, cff.Job{
+			Run: task1.run,
+			Dependencies: []*cff.ScheduledJob{
+				task0.job,
+			},
+		})
+		tasks = append(tasks, task1)
+
+		if err := sched.Wait(ctx); err != nil {
+			flowEmitter.FlowError(ctx, err)
+			cff.RethrowPanic(err, false)
+			return err
+		}
+
+		flowEmitter.FlowSuccess(ctx)
+		return nil
+	}()
+}
+
+// ByName runs a task after another referred to by its instrumentation name.
+func ByName(ctx context.Context, r *Recorder) error {
+	return func() (err error) {
+
+		_55_18 := ctx
+
+		_56_19 := 2
+
+		_57_19 := cff.NopEmitter()
+
+		_58_12 := func() {
+			time.Sleep(10 * time.Millisecond)
+			r.Record("commit")
+		}
+
+		_61_39 := "commit"
+
+		_62_12 := func() {
+			r.Record("audit")
+		}
+		ctx := _55_18
+		emitter := cff.EmitterStack(_57_19)
+		interceptor := cff.InterceptorStack()
+
+		var (
+			flowInfo = &cff.FlowInfo{
+				File:   "go.uber.org/cff/internal/tests/after/after.go",
+				Line:   55,
+				Column: 9,
+			}
+			flowEmitter = cff.NopFlowEmitter()
+
+			schedInfo = &cff.SchedulerInfo{
+				Name:      flowInfo.Name,
+				Directive: cff.FlowDirective,
+				File:      flowInfo.File,
+				Line:      flowInfo.Line,
+				Column:    flowInfo.Column,
+			}
+
+			// possibly unused
+			_ = flowInfo
+		)
+
+		startTime := time.Now()
+		defer func() { flowEmitter.FlowDone(ctx, time.Since(startTime)) }()
+
+		schedEmitter := emitter.SchedulerInit(schedInfo)
+
+		sched := cff.NewScheduler(
+			cff.SchedulerParams{
+				Concurrency: _56_19, Emitter: schedEmitter,
+			},
+		)
+
+		var tasks []*struct {
+			emitter cff.TaskEmitter
+			ran     cff.AtomicBool
+			run     func(context.Context) error
+			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		}
+		defer func() {
+			for _, t := range tasks {
+				if !t.ran.Load() {
+					t.emitter.TaskSkipped(ctx, t.outcome.SkipReason(ctx, err))
+				}
+			}
+		}()
+
+		// go.uber.org/cff/internal/tests/after/after.go:58:12
+		task2 := new(struct {
+			emitter cff.TaskEmitter
+			ran     cff.AtomicBool
+			run     func(context.Context) error
+			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		})
+		task2.outcome.Info = &cff.TaskInfo{
+			Name:   _61_39,
+			File:   "go.uber.org/cff/internal/tests/after/after.go",
+			Line:   58,
+			Column: 12,
+		}
+		task2.emitter = emitter.TaskInit(
+			task2.outcome.Info,
+			&cff.DirectiveInfo{
+				Name:      flowInfo.Name,
+				Directive: cff.FlowDirective,
+				File:      flowInfo.File,
+				Line:      flowInfo.Line,
+				Column:    flowInfo.Column,
+			},
+		)
+		task2.run = func(ctx context.Context) (err error) {
+			taskEmitter := task2.emitter
+			startTime := time.Now()
+			defer func() {
+				task2.outcome.Finish(err)
+				if task2.ran.Load() {
+					taskEmitter.TaskDone(ctx, time.Since(startTime))
+				}
+			}()
+
+			defer func() {
+				recovered := recover()
+				if recovered != nil {
+					taskEmitter.TaskPanic(ctx, recovered)
+					err = &cff.PanicError{
+						Value:      recovered,
+						Stacktrace: debug.Stack(),
+					}
+				}
+			}()
+
+			defer task2.ran.Store(true)
+
+			err = cff.Intercept(ctx, interceptor, task2.outcome.Info, func(ctx context.Context) (err error) {
+				_58_12()
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return err
+			} else {
+				taskEmitter.TaskSuccess(ctx)
+			}
+
+			return
+		}
+
+		task2.job = sched.Enqueue(ctx, cff.Job{
+			Run: task2.run,
+		})
+		tasks = append(tasks, task2)
+
+		// go.uber.org/cff/internal/tests/after/after.go:62:12
+		task3 := new(struct {
+			emitter cff.TaskEmitter
+			ran     cff.AtomicBool
+			run     func(context.Context) error
+			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		})
+		task3.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/after/after.go",
+			Line:   62,
+			Column: 12,
+		}
+		task3.outcome.Dependencies = []*cff.TaskOutcome{
+			&task2.outcome,
+		}
+		task3.emitter = cff.NopTaskEmitter()
+		task3.run = func(ctx context.Context) (err error) {
+			taskEmitter := task3.emitter
+			startTime := time.Now()
+			defer func() {
+				task3.outcome.Finish(err)
+				if task3.ran.Load() {
+					taskEmitter.TaskDone(ctx, time.Since(startTime))
+				}
+			}()
+
+			defer func() {
+				recovered := recover()
+				if recovered != nil {
+					taskEmitter.TaskPanic(ctx, recovered)
+					err = &cff.PanicError{
+						Value:      recovered,
+						Stacktrace: debug.Stack(),
+					}
+				}
+			}()
+
+			defer task3.ran.Store(true)
+
+			err = cff.Intercept(ctx, interceptor, task3.outcome.Info, func(ctx context.Context) (err error) {
+				_62_12()
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return err
+			} else {
+				taskEmitter.TaskSuccess(ctx)
+			}
+
+			return
+		}
+
+		task3.job = sched.Enqueue(ctx, cff.Job{
+			Run: task3.run,
+			Dependencies: []*cff.ScheduledJob{
+				task2.job,
+			},
+		})
+		tasks = append(tasks, task3)
+
+		if err := sched.Wait(ctx); err != nil {
+			flowEmitter.FlowError(ctx, err)
+			cff.RethrowPanic(err, false)
+			return err
+		}
+
+		flowEmitter.FlowSuccess(ctx)
+		return nil
+	}()
+}
+
+// Multiple runs a task after two others, one of which also provides
+// a value to the flow.
+func Multiple(ctx context.Context, r *Recorder) (string, error) {
+	var (
+		a, b cff.TaskRef
+		s    string
+	)
+	err := func() (err error) {
+
+		_75_18 := ctx
+
+		_76_19 := 3
+
+		_77_15 := &s
+
+		_78_12 := func() string {
+			time.Sleep(10 * time.Millisecond)
+			r.Record("a")
+			return "a"
+		}
+
+		_82_14 := &a
+
+		_83_12 := func() {
+			time.Sleep(5 * time.Millisecond)
+			r.Record("b")
+		}
+
+		_86_32 := &b
+
+		_87_12 := func() {
+			r.Record("c")
+		}
+
+		_89_34 := a
+
+		_89_37 := b
+		ctx := _75_18
+		_ = _82_14 // cff.TaskRef
+		_ = _86_32 // cff.TaskRef
+		_ = _89_34 // cff.TaskRef
+		_ = _89_37 // cff.TaskRef
+		emitter := cff.NopEmitter()
+		interceptor := cff.InterceptorStack()
+
+		var (
+			flowInfo = &cff.FlowInfo{
+				File:   "go.uber.org/cff/internal/tests/after/after.go",
+				Line:   75,
+				Column: 9,
+			}
+			flowEmitter = cff.NopFlowEmitter()
+
+			schedInfo = &cff.SchedulerInfo{
+				Name:      flowInfo.Name,
+				Directive: cff.FlowDirective,
+				File:      flowInfo.File,
+				Line:      flowInfo.Line,
+				Column:    flowInfo.Column,
+			}
+
+			// possibly unused
+			_ = flowInfo
+		)
+
+		startTime := time.Now()
+		defer func() { flowEmitter.FlowDone(ctx, time.Since(startTime)) }()
+
+		schedEmitter := emitter.SchedulerInit(schedInfo)
+
+		sched := cff.NewScheduler(
+			cff.SchedulerParams{
+				Concurrency: _76_19, Emitter: schedEmitter,
+			},
+		)
+
+		var tasks []*struct {
+			emitter cff.TaskEmitter
+			ran     cff.AtomicBool
+			run     func(context.Context) error
+			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		}
+		defer func() {
+			for _, t := range tasks {
+				if !t.ran.Load() {
+					t.emitter.TaskSkipped(ctx, t.outcome.SkipReason(ctx, err))
+				}
+			}
+		}()
+
+		// go.uber.org/cff/internal/tests/after/after.go:78:12
+		var (
+			v1 string
+		)
+
+		task4 := new(struct {
+			emitter cff.TaskEmitter
+			ran     cff.AtomicBool
+			run     func(context.Context) error
+			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		})
+		task4.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/after/after.go",
+			Line:   78,
+			Column: 12,
+		}
+		task4.emitter = cff.NopTaskEmitter()
+		task4.run = func(ctx context.Context) (err error) {
+			taskEmitter := task4.emitter
+			startTime := time.Now()
+			defer func() {
+				task4.outcome.Finish(err)
+				if task4.ran.Load() {
+					taskEmitter.TaskDone(ctx, time.Since(startTime))
+				}
+			}()
+
+			defer func() {
+				recovered := recover()
+				if recovered != nil {
+					taskEmitter.TaskPanic(ctx, recovered)
+					err = &cff.PanicError{
+						Value:      recovered,
+						Stacktrace: debug.Stack(),
+					}
+				}
+			}()
+
+			defer task4.ran.Store(true)
+
+			err = cff.Intercept(ctx, interceptor, task4.outcome.Info, func(ctx context.Context) (err error) {
+				v1 = _78_12()
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return err
+			} else {
+				taskEmitter.TaskSuccess(ctx)
+			}
+
+			return
+		}
+
+		task4.job = sched.Enqueue(ctx, cff.Job{
+			Run: task4.run,
+		})
+		tasks = append(tasks, task4)
+
+		// go.uber.org/cff/internal/tests/after/after.go:83:12
+		task5 := new(struct {
+			emitter cff.TaskEmitter
+			ran     cff.AtomicBool
+			run     func(context.Context) error
+			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		})
+		task5.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/after/after.go",
+			Line:   83,
+			Column: 12,
+		}
+		task5.emitter = cff.NopTaskEmitter()
+		task5.run = func(ctx context.Context) (err error) {
+			taskEmitter := task5.emitter
+			startTime := time.Now()
+			defer func() {
+				task5.outcome.Finish(err)
+				if task5.ran.Load() {
+					taskEmitter.TaskDone(ctx, time.Since(startTime))
+				}
+			}()
+
+			defer func() {
+				recovered := recover()
+				if recovered != nil {
+					taskEmitter.TaskPanic(ctx, recovered)
+					err = &cff.PanicError{
+						Value:      recovered,
+						Stacktrace: debug.Stack(),
+					}
+				}
+			}()
+
+			defer task5.ran.Store(true)
+
+			err = cff.Intercept(ctx, interceptor, task5.outcome.Info, func(ctx context.Context) (err error) {
+				_83_12()
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return err
+			} else {
+				taskEmitter.TaskSuccess(ctx)
+			}
+
+			return
+		}
+
+		task5.job = sched.Enqueue(ctx, cff.Job{
+			Run: task5.run,
+		})
+		tasks = append(tasks, task5)
+
+		// go.uber.org/cff/internal/tests/after/after.go:87:12
+		task6 := new(struct {
+			emitter cff.TaskEmitter
+			ran     cff.AtomicBool
+			run     func(context.Context) error
+			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		})
+		task6.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/after/after.go",
+			Line:   87,
+			Column: 12,
+		}
+		task6.outcome.Dependencies = []*cff.TaskOutcome{
+			&task4.outcome,
+			&task5.outcome,
+		}
+		task6.emitter = cff.NopTaskEmitter()
+		task6.run = func(ctx context.Context) (err error) {
+			taskEmitter := task6.emitter
+			startTime := time.Now()
+			defer func() {
+				task6.outcome.Finish(err)
+				if task6.ran.Load() {
+					taskEmitter.TaskDone(ctx, time.Since(startTime))
+				}
+			}()
+
+			defer func() {
+				recovered := recover()
+				if recovered != nil {
+					taskEmitter.TaskPanic(ctx, recovered)
+					err = &cff.PanicError{
+						Value:      recovered,
+						Stacktrace: debug.Stack(),
+					}
+				}
+			}()
+
+			defer task6.ran.Store(true)
+
+			err = cff.Intercept(ctx, interceptor, task6.outcome.Info, func(ctx context.Context) (err error) {
+				_87_12()
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return err
+			} else {
+				taskEmitter.TaskSuccess(ctx)
+			}
+
+			return
+		}
+
+		task6.job = sched.Enqueue(ctx, cff.Job{
+			Run: task6.run,
+			Dependencies: []*cff.ScheduledJob{
+				task4.job,
+				task5.job,
+			},
+		})
+		tasks = append(tasks, task6)
+
+		if err := sched.Wait(ctx); err != nil {
+			flowEmitter.FlowError(ctx, err)
+			cff.RethrowPanic(err, false)
+			return err
+		}
+
+		*(_77_15) = v1 // string
+
+		flowEmitter.FlowSuccess(ctx)
+		return nil
+	}()
+	return s, err
+}
+
+// SkippedTarget runs a task after another that may be skipped by
+// a predicate.
+func SkippedTarget(ctx context.Context, r *Recorder, run bool) error {
+	var first cff.TaskRef
+	return func() (err error) {
+
+		_98_18 := ctx
+
+		_99_14 := run
+
+		_100_12 := func() {
+			r.Record("first")
+		}
+
+		_104_18 := func(run bool) bool { return run }
+
+		_105_12 := &first
+
+		_107_12 := func() {
+			r.Record("second")
+		}
+
+		_109_34 := first
+		ctx := _98_18
+		var v2 bool = _99_14
+		_ = _105_12 // cff.TaskRef
+		_ = _109_34 // cff.TaskRef
+		emitter := cff.NopEmitter()
+		interceptor := cff.InterceptorStack()
+
+		var (
+			flowInfo = &cff.FlowInfo{
+				File:   "go.uber.org/cff/internal/tests/after/after.go",
+				Line:   98,
+				Column: 9,
+			}
+			flowEmitter = cff.NopFlowEmitter()
+
+			schedInfo = &cff.SchedulerInfo{
+				Name:      flowInfo.Name,
+				Directive: cff.FlowDirective,
+				File:      flowInfo.File,
+				Line:      flowInfo.Line,
+				Column:    flowInfo.Column,
+			}
+
+			// possibly unused
+			_ = flowInfo
+		)
+
+		startTime := time.Now()
+		defer func() { flowEmitter.FlowDone(ctx, time.Since(startTime)) }()
+
+		schedEmitter := emitter.SchedulerInit(schedInfo)
+
+		sched := cff.NewScheduler(
+			cff.SchedulerParams{
+				Emitter: schedEmitter,
+			},
+		)
+
+		var tasks []*struct {
+			emitter cff.TaskEmitter
+			ran     cff.AtomicBool
+			run     func(context.Context) error
+			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		}
+		defer func() {
+			for _, t := range tasks {
+				if !t.ran.Load() {
+					t.emitter.TaskSkipped(ctx, t.outcome.SkipReason(ctx, err))
+				}
+			}
+		}()
+
+		// go.uber.org/cff/internal/tests/after/after.go:104:4
+		var p0 bool
+		var p0PanicRecover interface{}
+		var p0PanicStacktrace []byte
+		_ = p0PanicStacktrace // possibly unused.
+		pred1 := new(struct {
+			ran cff.AtomicBool
+			run func(context.Context) error
+			job *cff.ScheduledJob
+		})
+		pred1.run = func(ctx context.Context) (err error) {
+			defer func() {
+				if recovered := recover(); recovered != nil {
+					p0PanicRecover = recovered
+					p0PanicStacktrace = debug.Stack()
+				}
+			}()
+			p0 = _104_18(v2)
+			return nil
+		}
+
+		pred1.job = sched.Enqueue(ctx, cff.Job{
+			Run: pred1.run,
+		})
+
+		// go.uber.org/cff/internal/tests/after/after.go:100:12
+		task7 := new(struct {
+			emitter cff.TaskEmitter
+			ran     cff.AtomicBool
+			run     func(context.Context) error
+			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		})
+		task7.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/after/after.go",
+			Line:   100,
+			Column: 12,
+		}
+		task7.emitter = cff.NopTaskEmitter()
+		task7.run = func(ctx context.Context) (err error) {
+			taskEmitter := task7.emitter
+			startTime := time.Now()
+			defer func() {
+				task7.outcome.Finish(err)
+				if task7.ran.Load() {
+					taskEmitter.TaskDone(ctx, time.Since(startTime))
+				}
+			}()
+
+			defer func() {
+				recovered := recover()
+				var stacktrace []byte
+				if recovered != nil {
+					stacktrace = debug.Stack()
+				}
+				if recovered == nil && p0PanicRecover != nil {
+					recovered = p0PanicRecover
+					stacktrace = p0PanicStacktrace
+				}
+				if recovered != nil {
+					taskEmitter.TaskPanic(ctx, recovered)
+					err = &cff.PanicError{
+						Value:      recovered,
+						Stacktrace: stacktrace,
+					}
+				}
+			}()
+
+			if !p0 {
+				task7.outcome.Skip(cff.SkipPredicateFalse)
+				return nil
+			}
+
+			defer task7.ran.Store(true)
+
+			err = cff.Intercept(ctx, interceptor, task7.outcome.Info, func(ctx context.Context) (err error) {
+				_100_12()
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return err
+			} else {
+				taskEmitter.TaskSuccess(ctx)
+			}
+
+			return
+		}
+
+		task7.job = sched.Enqueue(ctx, cff.Job{
+			Run: task7.run,
+			Dependencies: []*cff.ScheduledJob{
+				pred1.job,
+			},
+		})
+		tasks = append(tasks, task7)
+
+		// go.uber.org/cff/internal/tests/after/after.go:107:12
+		task8 := new(struct {
+			emitter cff.TaskEmitter
+			ran     cff.AtomicBool
+			run     func(context.Context) error
+			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		})
+		task8.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/after/after.go",
+			Line:   107,
+			Column: 12,
+		}
+		task8.outcome.Dependencies = []*cff.TaskOutcome{
+			&task7.outcome,
+		}
+		task8.emitter = cff.NopTaskEmitter()
+		task8.run = func(ctx context.Context) (err error) {
+			taskEmitter := task8.emitter
+			startTime := time.Now()
+			defer func() {
+				task8.outcome.Finish(err)
+				if task8.ran.Load() {
+					taskEmitter.TaskDone(ctx, time.Since(startTime))
+				}
+			}()
+
+			defer func() {
+				recovered := recover()
+				if recovered != nil {
+					taskEmitter.TaskPanic(ctx, recovered)
+					err = &cff.PanicError{
+						Value:      recovered,
+						Stacktrace: debug.Stack(),
+					}
+				}
+			}()
+
+			defer task8.ran.Store(true)
+
+			err = cff.Intercept(ctx, interceptor, task8.outcome.Info, func(ctx context.Context) (err error) {
+				_107_12()
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return err
+			} else {
+				taskEmitter.TaskSuccess(ctx)
+			}
+
+			return
+		}
+
+		task8.job = sched.Enqueue(ctx, cff.Job{
+			Run: task8.run,
+			Dependencies: []*cff.ScheduledJob{
+				task7.job,
+			},
+		})
+		tasks = append(tasks, task8)
+
+		if err := sched.Wait(ctx); err != nil {
+			flowEmitter.FlowError(ctx, err)
+			cff.RethrowPanic(err, false)
+			return err
+		}
+
+		flowEmitter.FlowSuccess(ctx)
+		return nil
+	}()
+}
+
+// FailedTarget runs a task after another that fails.
+func FailedTarget(ctx context.Context, r *Recorder) error {
+	var first cff.TaskRef
+	return func() (err error) {
+
+		_116_18 := ctx
+
+		_117_12 := func() error {
+			r.Record("first")
+			return errors.New("great sadness")
+		}
+
+		_120_32 := &first
+
+		_121_12 := func() {
+			r.Record("second")
+		}
+
+		_123_34 := first
+		ctx := _116_18
+		_ = _120_32 // cff.TaskRef
+		_ = _123_34 // cff.TaskRef
+		emitter := cff.NopEmitter()
+		interceptor := cff.InterceptorStack()
+
+		var (
+			flowInfo = &cff.FlowInfo{
+				File:   "go.uber.org/cff/internal/tests/after/after.go",
+				Line:   116,
+				Column: 9,
+			}
+			flowEmitter = cff.NopFlowEmitter()
+
+			schedInfo = &cff.SchedulerInfo{
+				Name:      flowInfo.Name,
+				Directive: cff.FlowDirective,
+				File:      flowInfo.File,
+				Line:      flowInfo.Line,
+				Column:    flowInfo.Column,
+			}
+
+			// possibly unused
+			_ = flowInfo
+		)
+
+		startTime := time.Now()
+		defer func() { flowEmitter.FlowDone(ctx, time.Since(startTime)) }()
+
+		schedEmitter := emitter.SchedulerInit(schedInfo)
+
+		sched := cff.NewScheduler(
+			cff.SchedulerParams{
+				Emitter: schedEmitter,
+			},
+		)
+
+		var tasks []*struct {
+			emitter cff.TaskEmitter
+			ran     cff.AtomicBool
+			run     func(context.Context) error
+			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		}
+		defer func() {
+			for _, t := range tasks {
+				if !t.ran.Load() {
+					t.emitter.TaskSkipped(ctx, t.outcome.SkipReason(ctx, err))
+				}
+			}
+		}()
+
+		// go.uber.org/cff/internal/tests/after/after.go:117:12
+		task9 := new(struct {
+			emitter cff.TaskEmitter
+			ran     cff.AtomicBool
+			run     func(context.Context) error
+			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		})
+		task9.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/after/after.go",
+			Line:   117,
+			Column: 12,
+		}
+		task9.emitter = cff.NopTaskEmitter()
+		task9.run = func(ctx context.Context) (err error) {
+			taskEmitter := task9.emitter
+			startTime := time.Now()
+			defer func() {
+				task9.outcome.Finish(err)
+				if task9.ran.Load() {
+					taskEmitter.TaskDone(ctx, time.Since(startTime))
+				}
+			}()
+
+			defer func() {
+				recovered := recover()
+				if recovered != nil {
+					taskEmitter.TaskPanic(ctx, recovered)
+					err = &cff.PanicError{
+						Value:      recovered,
+						Stacktrace: debug.Stack(),
+					}
+				}
+			}()
+
+			defer task9.ran.Store(true)
+
+			err = cff.Intercept(ctx, interceptor, task9.outcome.Info, func(ctx context.Context) (err error) {
+				err = _117_12()
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return err
+			} else {
+				taskEmitter.TaskSuccess(ctx)
+			}
+
+			return
+		}
+
+		task9.job = sched.Enqueue(ctx, cff.Job{
+			Run: task9.run,
+		})
+		tasks = append(tasks, task9)
+
+		// go.uber.org/cff/internal/tests/after/after.go:121:12
+		task10 := new(struct {
+			emitter cff.TaskEmitter
+			ran     cff.AtomicBool
+			run     func(context.Context) error
+			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		})
+		task10.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/after/after.go",
+			Line:   121,
+			Column: 12,
+		}
+		task10.outcome.Dependencies = []*cff.TaskOutcome{
+			&task9.outcome,
+		}
+		task10.emitter = cff.NopTaskEmitter()
+		task10.run = func(ctx context.Context) (err error) {
+			taskEmitter := task10.emitter
+			startTime := time.Now()
+			defer func() {
+				task10.outcome.Finish(err)
+				if task10.ran.Load() {
+					taskEmitter.TaskDone(ctx, time.Since(startTime))
+				}
+			}()
+
+			defer func() {
+				recovered := recover()
+				if recovered != nil {
+					taskEmitter.TaskPanic(ctx, recovered)
+					err = &cff.PanicError{
+						Value:      recovered,
+						Stacktrace: debug.Stack(),
+					}
+				}
+			}()
+
+			defer task10.ran.Store(true)
+
+			err = cff.Intercept(ctx, interceptor, task10.outcome.Info, func(ctx context.Context) (err error) {
+				_121_12()
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return err
+			} else {
+				taskEmitter.TaskSuccess(ctx)
+			}
+
+			return
+		}
+
+		task10.job = sched.Enqueue(ctx, cff.Job{
+			Run: task10.run,
+			Dependencies: []*cff.ScheduledJob{
+				task9.job,
+			},
+		})
+		tasks = append(tasks, task10)
+
+		if err := sched.Wait(ctx); err != nil {
+			flowEmitter.FlowError(ctx, err)
+			cff.RethrowPanic(err, false)
+			return err
+		}
+
+		flowEmitter.FlowSuccess(ctx)
+		return nil
+	}()
+}
@@ -0,0 +1,51 @@
+package after
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestByRef(t *testing.T) {
+	var r Recorder
+	require.NoError(t, ByRef(context.Background(), &r))
+	assert.Equal(t, []string{"commit", "audit"}, r.Names())
+}
+
+func TestByName(t *testing.T) {
+	var r Recorder
+	require.NoError(t, ByName(context.Background(), &r))
+	assert.Equal(t, []string{"commit", "audit"}, r.Names())
+}
+
+func TestMultiple(t *testing.T) {
+	var r Recorder
+	s, err := Multiple(context.Background(), &r)
+	require.NoError(t, err)
+	assert.Equal(t, "a", s)
+	assert.Equal(t, []string{"b", "a", "c"}, r.Names())
+}
+
+func TestSkippedTarget(t *testing.T) {
+	t.Run("run", func(t *testing.T) {
+		var r Recorder
+		require.NoError(t, SkippedTarget(context.Background(), &r, true))
+		assert.Equal(t, []string{"first", "second"}, r.Names())
+	})
+
+	t.Run("skip", func(t *testing.T) {
+		var r Recorder
+		require.NoError(t, SkippedTarget(context.Background(), &r, false))
+		assert.Equal(t, []string{"second"}, r.Names())
+	})
+}
+
+func TestFailedTarget(t *testing.T) {
+	var r Recorder
+	err := FailedTarget(context.Background(), &r)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "great sadness")
+	assert.Equal(t, []string{"first"}, r.Names())
+}
@@ -0,0 +1,70 @@
+//go:build cff
+// +build cff
+
+// Package assignable tests flows that resolve dependencies by
+// assignability with cff.AllowAssignable.
+package assignable
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"go.uber.org/cff"
+)
+
+// ReadAll provides a *bytes.Buffer holding the given string and consumes
+// it as an io.Reader.
+func ReadAll(s string) (string, error) {
+	var out []byte
+	err := cff.Flow(context.Background(),
+		cff.Params(s),
+		cff.Results(&out),
+		cff.AllowAssignable(),
+		cff.Task(func(s string) *bytes.Buffer {
+			return bytes.NewBufferString(s)
+		}),
+		cff.Task(func(r io.Reader) ([]byte, error) {
+			return io.ReadAll(r)
+		}),
+	)
+	return string(out), err
+}
+
+// Stringer returns the result of a flow as a fmt.Stringer
+// provided by a *bytes.Buffer.
+func Stringer(s string) (fmt.Stringer, error) {
+	var out fmt.Stringer
+	err := cff.Flow(context.Background(),
+		cff.Params(s),
+		cff.Results(&out),
+		cff.AllowAssignable(),
+		cff.Task(func(s string) *bytes.Buffer {
+			return bytes.NewBufferString(s)
+		}),
+	)
+	return out, err
+}
+
+// ExactMatch prefers the exact provider of io.Reader over the
+// *bytes.Buffer that is also assignable to it.
+func ExactMatch() (string, error) {
+	var out string
+	err := cff.Flow(context.Background(),
+		cff.Results(&out),
+		cff.AllowAssignable(),
+		cff.Task(func() *bytes.Buffer {
+			return bytes.NewBufferString("buffer")
+		}),
+		cff.Task(func(*bytes.Buffer) io.Reader {
+			return strings.NewReader("reader")
+		}),
+		cff.Task(func(r io.Reader) (string, error) {
+			b, err := io.ReadAll(r)
+			return string(b), err
+		}),
+	)
+	return out, err
+}
@@ -0,0 +1,655 @@
+//go:build !cff
+// +build !cff
+
+// Package assignable tests flows that resolve dependencies by
+// assignability with cff.AllowAssignable.
+package assignable
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"runtime/debug"
+	"strings"
+	"time"
+
+	"go.uber.org/cff"
+)
+
+// ReadAll provides a *bytes.Buffer holding the given string and consumes
+// it as an io.Reader.
+func ReadAll(s string) (string, error) {
+	var out []byte
+	err := func() (err error) {
+
+		_22_18 := context.Background()
+
+		_23_14 := s
+
+		_24_15 := &out
+
+		_26_12 := func(s string) *bytes.Buffer {
+			return bytes.NewBufferString(s)
+		}
+
+		_29_12 := func(r io.Reader) ([]byte, error) {
+			return io.ReadAll(r)
+		}
+		ctx := _22_18
+		var v1 string = _23_14
+		emitter := cff.NopEmitter()
+		interceptor := cff.InterceptorStack()
+
+		var (
+			flowInfo = &cff.FlowInfo{
+				File:   "go.uber.org/cff/internal/tests/assignable/assignable.go",
+				Line:   22,
+				Column: 9,
+			}
+			flowEmitter = cff.NopFlowEmitter()
+
+			schedInfo = &cff.SchedulerInfo{
+				Name:      flowInfo.Name,
+				Directive: cff.FlowDirective,
+				File:      flowInfo.File,
+				Line:      flowInfo.Line,
+				Column:    flowInfo.Column,
+			}
+
+			// possibly unused
+			_ = flowInfo
+		)
+
+		startTime := time.Now()
+		defer func() { flowEmitter.FlowDone(ctx, time.Since(startTime)) }()
+
+		schedEmitter := emitter.SchedulerInit(schedInfo)
+
+		sched := cff.NewScheduler(
+			cff.SchedulerParams{
+				Emitter: schedEmitter,
+			},
+		)
+
+		var tasks []*struct {
+			emitter cff.TaskEmitter
+			ran     cff.AtomicBool
+			run     func(context.Context) error
+			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		}
+		defer func() {
+			for _, t := range tasks {
+				if !t.ran.Load() {
+					t.emitter.TaskSkipped(ctx, t.outcome.SkipReason(ctx, err))
+				}
+			}
+		}()
+
+		// go.uber.org/cff/internal/tests/assignable/assignable.go:26:12
+		var (
+			v2 *bytes.Buffer
+		)
+
+		task0 := new(struct {
+			emitter cff.TaskEmitter
+			ran     cff.AtomicBool
+			run     func(context.Context) error
+			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		})
+		task0.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/assignable/assignable.go",
+			Line:   26,
+			Column: 12,
+		}
+		task0.emitter = cff.NopTaskEmitter()
+		task0.run = func(ctx context.Context) (err error) {
+			taskEmitter := task0.emitter
+			startTime := time.Now()
+			defer func() {
+				task0.outcome.Finish(err)
+				if task0.ran.Load() {
+					taskEmitter.TaskDone(ctx, time.Since(startTime))
+				}
+			}()
+
+			defer func() {
+				recovered := recover()
+				if recovered != nil {
+					taskEmitter.TaskPanic(ctx, recovered)
+					err = &cff.PanicError{
+						Value:      recovered,
+						Stacktrace: debug.Stack(),
+					}
+				}
+			}()
+
+			defer task0.ran.Store(true)
+
+			err = cff.Intercept(ctx, interceptor, task0.outcome.Info, func(ctx context.Context) (err error) {
+				v2 = _26_12(v1)
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return err
+			} else {
+				taskEmitter.TaskSuccess(ctx)
+			}
+
+			return
+		}
+
+		task0.job = sched.Enqueue(ctx, cff.Job{
+			Run: task0.run,
+		})
+		tasks = append(tasks, task0)
+
+		// go.uber.org/cff/internal/tests/assignable/assignable.go:29:12
+		var (
+			v3 []byte
+		)
+
+		task1 := new(struct {
+			emitter cff.TaskEmitter
+			ran     cff.AtomicBool
+			run     func(context.Context) error
+			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		})
+		task1.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/assignable/assignable.go",
+			Line:   29,
+			Column: 12,
+		}
+		task1.outcome.Dependencies = []*cff.TaskOutcome{
+			&task0.outcome,
+		}
+		task1.emitter = cff.NopTaskEmitter()
+		task1.run = func(ctx context.Context) (err error) {
+			taskEmitter := task1.emitter
+			startTime := time.Now()
+			defer func() {
+				task1.outcome.Finish(err)
+				if task1.ran.Load() {
+					taskEmitter.TaskDone(ctx, time.Since(startTime))
+				}
+			}()
+
+			defer func() {
+				recovered := recover()
+				if recovered != nil {
+					taskEmitter.TaskPanic(ctx, recovered)
+					err = &cff.PanicError{
+						Value:      recovered,
+						Stacktrace: debug.Stack(),
+					}
+				}
+			}()
+
+			defer task1.ran.Store(true)
+
+			err = cff.Intercept(ctx, interceptor, task1.outcome.Info, func(ctx context.Context) (err error) {
+				v3, err = _29_12(v2)
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return err
+			} else {
+				taskEmitter.TaskSuccess(ctx)
+			}
+
+			return
+		}
+
+		task1.job = sched.Enqueue(ctx, cff.Job{
+			Run: task1.run,
+			Dependencies: []*cff.ScheduledJob{
+				task0.job,
+			},
+		})
+		tasks = append(tasks, task1)
+
+		if err := sched.Wait(ctx); err != nil {
+			flowEmitter.FlowError(ctx, err)
+			cff.RethrowPanic(err, false)
+			return err
+		}
+
+		*(_24_15) = v3 // []byte
+
+		flowEmitter.FlowSuccess(ctx)
+		return nil
+	}()
+	return string(out), err
+}
+
+// Stringer returns the result of a flow as a fmt.Stringer
+// provided by a *bytes.Buffer.
+func Stringer(s string) (fmt.Stringer, error) {
+	var out fmt.Stringer
+	err := func() (err error) {
+
+		_40_18 := context.Background()
+
+		_41_14 := s
+
+		_42_15 := &out
+
+		_44_12 := func(s string) *bytes.Buffer {
+			return bytes.NewBufferString(s)
+		}
+		ctx := _40_18
+		var v1 string = _41_14
+		emitter := cff.NopEmitter()
+		interceptor := cff.InterceptorStack()
+
+		var (
+			flowInfo = &cff.FlowInfo{
+				File:   "go.uber.org/cff/internal/tests/assignable/assignable.go",
+				Line:   40,
+				Column: 9,
+			}
+			flowEmitter = cff.NopFlowEmitter()
+
+			schedInfo = &cff.SchedulerInfo{
+				Name:      flowInfo.Name,
+				Directive: cff.FlowDirective,
+				File:      flowInfo.File,
+				Line:      flowInfo.Line,
+				Column:    flowInfo.Column,
+			}
+
+			// possibly unused
+			_ = flowInfo
+		)
+
+		startTime := time.Now()
+		defer func() { flowEmitter.FlowDone(ctx, time.Since(startTime)) }()
+
+		schedEmitter := emitter.SchedulerInit(schedInfo)
+
+		sched := cff.NewScheduler(
+			cff.SchedulerParams{
+				Emitter: schedEmitter,
+			},
+		)
+
+		var tasks []*struct {
+			emitter cff.TaskEmitter
+			ran     cff.AtomicBool
+			run     func(context.Context) error
+			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		}
+		defer func() {
+			for _, t := range tasks {
+				if !t.ran.Load() {
+					t.emitter.TaskSkipped(ctx, t.outcome.SkipReason(ctx, err))
+				}
+			}
+		}()
+
+		// go.uber.org/cff/internal/tests/assignable/assignable.go:44:12
+		var (
+			v2 *bytes.Buffer
+		)
+
+		task2 := new(struct {
+			emitter cff.TaskEmitter
+			ran     cff.AtomicBool
+			run     func(context.Context) error
+			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		})
+		task2.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/assignable/assignable.go",
+			Line:   44,
+			Column: 12,
+		}
+		task2.emitter = cff.NopTaskEmitter()
+		task2.run = func(ctx context.Context) (err error) {
+			taskEmitter := task2.emitter
+			startTime := time.Now()
+			defer func() {
+				task2.outcome.Finish(err)
+				if task2.ran.Load() {
+					taskEmitter.TaskDone(ctx, time.Since(startTime))
+				}
+			}()
+
+			defer func() {
+				recovered := recover()
+				if recovered != nil {
+					taskEmitter.TaskPanic(ctx, recovered)
+					err = &cff.PanicError{
+						Value:      recovered,
+						Stacktrace: debug.Stack(),
+					}
+				}
+			}()
+
+			defer task2.ran.Store(true)
+
+			err = cff.Intercept(ctx, interceptor, task2.outcome.Info, func(ctx context.Context) (err error) {
+				v2 = _44_12(v1)
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return err
+			} else {
+				taskEmitter.TaskSuccess(ctx)
+			}
+
+			return
+		}
+
+		task2.job = sched.Enqueue(ctx, cff.Job{
+			Run: task2.run,
+		})
+		tasks = append(tasks, task2)
+
+		if err := sched.Wait(ctx); err != nil {
+			flowEmitter.FlowError(ctx, err)
+			cff.RethrowPanic(err, false)
+			return err
+		}
+
+		*(_42_15) = v2 // *bytes.Buffer
+
+		flowEmitter.FlowSuccess(ctx)
+		return nil
+	}()
+	return out, err
+}
+
+// ExactMatch prefers the exact provider of io.Reader over the
+// *bytes.Buffer that is also assignable to it.
+func ExactMatch() (string, error) {
+	var out string
+	err := func() (err error) {
+
+		_55_18 := context.Background()
+
+		_56_15 := &out
+
+		_58_12 := func() *bytes.Buffer {
+			return bytes.NewBufferString("buffer")
+		}
+
+		_61_12 := func(*bytes.Buffer) io.Reader {
+			return strings.NewReader("reader")
+		}
+
+		_64_12 := func(r io.Reader) (string, error) {
+			b, err := io.ReadAll(r)
+			return string(b), err
+		}
+		ctx := _55_18
+		emitter := cff.NopEmitter()
+		interceptor := cff.InterceptorStack()
+
+		var (
+			flowInfo = &cff.FlowInfo{
+				File:   "go.uber.org/cff/internal/tests/assignable/assignable.go",
+				Line:   55,
+				Column: 9,
+			}
+			flowEmitter = cff.NopFlowEmitter()
+
+			schedInfo = &cff.SchedulerInfo{
+				Name:      flowInfo.Name,
+				Directive: cff.FlowDirective,
+				File:      flowInfo.File,
+				Line:      flowInfo.Line,
+				Column:    flowInfo.Column,
+			}
+
+			// possibly unused
+			_ = flowInfo
+		)
+
+		startTime := time.Now()
+		defer func() { flowEmitter.FlowDone(ctx, time.Since(startTime)) }()
+
+		schedEmitter := emitter.SchedulerInit(schedInfo)
+
+		sched := cff.NewScheduler(
+			cff.SchedulerParams{
+				Emitter: schedEmitter,
+			},
+		)
+
+		var tasks []*struct {
+			emitter cff.TaskEmitter
+			ran     cff.AtomicBool
+			run     func(context.Context) error
+			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		}
+		defer func() {
+			for _, t := range tasks {
+				if !t.ran.Load() {
+					t.emitter.TaskSkipped(ctx, t.outcome.SkipReason(ctx, err))
+				}
+			}
+		}()
+
+		// go.uber.org/cff/internal/tests/assignable/assignable.go:58:12
+		var (
+			v2 *bytes.Buffer
+		)
+
+		task3 := new(struct {
+			emitter cff.TaskEmitter
+			ran     cff.AtomicBool
+			run     func(context.Context) error
+			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		})
+		task3.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/assignable/assignable.go",
+			Line:   58,
+			Column: 12,
+		}
+		task3.emitter = cff.NopTaskEmitter()
+		task3.run = func(ctx context.Context) (err error) {
+			taskEmitter := task3.emitter
+			startTime := time.Now()
+			defer func() {
+				task3.outcome.Finish(err)
+				if task3.ran.Load() {
+					taskEmitter.TaskDone(ctx, time.Since(startTime))
+				}
+			}()
+
+			defer func() {
+				recovered := recover()
+				if recovered != nil {
+					taskEmitter.TaskPanic(ctx, recovered)
+					err = &cff.PanicError{
+						Value:      recovered,
+						Stacktrace: debug.Stack(),
+					}
+				}
+			}()
+
+			defer task3.ran.Store(true)
+
+			err = cff.Intercept(ctx, interceptor, task3.outcome.Info, func(ctx context.Context) (err error) {
+				v2 = _58_12()
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return err
+			} else {
+				taskEmitter.TaskSuccess(ctx)
+			}
+
+			return
+		}
+
+		task3.job = sched.Enqueue(ctx, cff.Job{
+			Run: task3.run,
+		})
+		tasks = append(tasks, task3)
+
+		// go.uber.org/cff/internal/tests/assignable/assignable.go:61:12
+		var (
+			v4 io.Reader
+		)
+
+		task4 := new(struct {
+			emitter cff.TaskEmitter
+			ran     cff.AtomicBool
+			run     func(context.Context) error
+			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		})
+		task4.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/assignable/assignable.go",
+			Line:   61,
+			Column: 12,
+		}
+		task4.outcome.Dependencies = []*cff.TaskOutcome{
+			&task3.outcome,
+		}
+		task4.emitter = cff.NopTaskEmitter()
+		task4.run = func(ctx context.Context) (err error) {
+			taskEmitter := task4.emitter
+			startTime := time.Now()
+			defer func() {
+				task4.outcome.Finish(err)
+				if task4.ran.Load() {
+					taskEmitter.TaskDone(ctx, time.Since(startTime))
+				}
+			}()
+
+			defer func() {
+				recovered := recover()
+				if recovered != nil {
+					taskEmitter.TaskPanic(ctx, recovered)
+					err = &cff.PanicError{
+						Value:      recovered,
+						Stacktrace: debug.Stack(),
+					}
+				}
+			}()
+
+			defer task4.ran.Store(true)
+
+			err = cff.Intercept(ctx, interceptor, task4.outcome.Info, func(ctx context.Context) (err error) {
+				v4 = _61_12(v2)
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return err
+			} else {
+				taskEmitter.TaskSuccess(ctx)
+			}
+
+			return
+		}
+
+		task4.job = sched.Enqueue(ctx, cff.Job{
+			Run: task4.run,
+			Dependencies: []*cff.ScheduledJob{
+				task3.job,
+			},
+		})
+		tasks = append(tasks, task4)
+
+		// go.uber.org/cff/internal/tests/assignable/assignable.go:64:12
+		var (
+			v1 string
+		)
+
+		task5 := new(struct {
+			emitter cff.TaskEmitter
+			ran     cff.AtomicBool
+			run     func(context.Context) error
+			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		})
+		task5.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/assignable/assignable.go",
+			Line:   64,
+			Column: 12,
+		}
+		task5.outcome.Dependencies = []*cff.TaskOutcome{
+			&task4.outcome,
+		}
+		task5.emitter = cff.NopTaskEmitter()
+		task5.run = func(ctx context.Context) (err error) {
+			taskEmitter := task5.emitter
+			startTime := time.Now()
+			defer func() {
+				task5.outcome.Finish(err)
+				if task5.ran.Load() {
+					taskEmitter.TaskDone(ctx, time.Since(startTime))
+				}
+			}()
+
+			defer func() {
+				recovered := recover()
+				if recovered != nil {
+					taskEmitter.TaskPanic(ctx, recovered)
+					err = &cff.PanicError{
+						Value:      recovered,
+						Stacktrace: debug.Stack(),
+					}
+				}
+			}()
+
+			defer task5.ran.Store(true)
+
+			err = cff.Intercept(ctx, interceptor, task5.outcome.Info, func(ctx context.Context) (err error) {
+				v1, err = _64_12(v4)
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return err
+			} else {
+				taskEmitter.TaskSuccess(ctx)
+			}
+
+			return
+		}
+
+		task5.job = sched.Enqueue(ctx, cff.Job{
+			Run: task5.run,
+			Dependencies: []*cff.ScheduledJob{
+				task4.job,
+			},
+		})
+		tasks = append(tasks, task5)
+
+		if err := sched.Wait(ctx); err != nil {
+			flowEmitter.FlowError(ctx, err)
+			cff.RethrowPanic(err, false)
+			return err
+		}
+
+		*(_56_15) = v1 // string
+
+		flowEmitter.FlowSuccess(ctx)
+		return nil
+	}()
+	return out, err
+}
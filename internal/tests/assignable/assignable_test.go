@@ -0,0 +1,26 @@
+package assignable
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadAll(t *testing.T) {
+	got, err := ReadAll("hello")
+	require.NoError(t, err)
+	assert.Equal(t, "hello", got)
+}
+
+func TestStringer(t *testing.T) {
+	got, err := Stringer("hello")
+	require.NoError(t, err)
+	assert.Equal(t, "hello", got.String())
+}
+
+func TestExactMatch(t *testing.T) {
+	got, err := ExactMatch()
+	require.NoError(t, err)
+	assert.Equal(t, "reader", got)
+}
@@ -45,6 +45,7 @@ func SimpleFlow() (string, error) {
 		ctx := _20_18
 		var v1 int = _21_14
 		emitter := cff.NopEmitter()
+		interceptor := cff.InterceptorStack()
 
 		var (
 			flowInfo = &cff.FlowInfo{
@@ -82,11 +83,13 @@ func SimpleFlow() (string, error) {
 			ran     cff.AtomicBool
 			run     func(context.Context) error
 			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
 		}
 		defer func() {
 			for _, t := range tasks {
 				if !t.ran.Load() {
-					t.emitter.TaskSkipped(ctx, err)
+					t.emitter.TaskSkipped(ctx, t.outcome.SkipReason(ctx, err))
 				}
 			}
 		}()
@@ -95,17 +98,26 @@ func SimpleFlow() (string, error) {
 		var (
 			v2 int64
 		)
+
 		task0 := new(struct {
 			emitter cff.TaskEmitter
 			ran     cff.AtomicBool
 			run     func(context.Context) error
 			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
 		})
+		task0.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/basic/basic.go",
+			Line:   24,
+			Column: 4,
+		}
 		task0.emitter = cff.NopTaskEmitter()
 		task0.run = func(ctx context.Context) (err error) {
 			taskEmitter := task0.emitter
 			startTime := time.Now()
 			defer func() {
+				task0.outcome.Finish(err)
 				if task0.ran.Load() {
 					taskEmitter.TaskDone(ctx, time.Since(startTime))
 				}
@@ -124,9 +136,16 @@ func SimpleFlow() (string, error) {
 
 			defer task0.ran.Store(true)
 
-			v2 = _24_4(v1)
-
-			taskEmitter.TaskSuccess(ctx)
+			err = cff.Intercept(ctx, interceptor, task0.outcome.Info, func(ctx context.Context) (err error) {
+				v2 = _24_4(v1)
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return err
+			} else {
+				taskEmitter.TaskSuccess(ctx)
+			}
 
 			return
 		}
@@ -140,17 +159,26 @@ func SimpleFlow() (string, error) {
 		var (
 			v3 *foo
 		)
+
 		task1 := new(struct {
 			emitter cff.TaskEmitter
 			ran     cff.AtomicBool
 			run     func(context.Context) error
 			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
 		})
+		task1.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/basic/basic.go",
+			Line:   29,
+			Column: 4,
+		}
 		task1.emitter = cff.NopTaskEmitter()
 		task1.run = func(ctx context.Context) (err error) {
 			taskEmitter := task1.emitter
 			startTime := time.Now()
 			defer func() {
+				task1.outcome.Finish(err)
 				if task1.ran.Load() {
 					taskEmitter.TaskDone(ctx, time.Since(startTime))
 				}
@@ -169,8 +197,10 @@ func SimpleFlow() (string, error) {
 
 			defer task1.ran.Store(true)
 
-			v3, err = _29_4(v1)
-
+			err = cff.Intercept(ctx, interceptor, task1.outcome.Info, func(ctx context.Context) (err error) {
+				v3, err = _29_4(v1)
+				return
+			})
 			if err != nil {
 				taskEmitter.TaskError(ctx, err)
 				return err
@@ -190,17 +220,29 @@ func SimpleFlow() (string, error) {
 		var (
 			v4 *bar
 		)
+
 		task2 := new(struct {
 			emitter cff.TaskEmitter
 			ran     cff.AtomicBool
 			run     func(context.Context) error
 			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
 		})
+		task2.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/basic/basic.go",
+			Line:   33,
+			Column: 4,
+		}
+		task2.outcome.Dependencies = []*cff.TaskOutcome{
+			&task0.outcome,
+		}
 		task2.emitter = cff.NopTaskEmitter()
 		task2.run = func(ctx context.Context) (err error) {
 			taskEmitter := task2.emitter
 			startTime := time.Now()
 			defer func() {
+				task2.outcome.Finish(err)
 				if task2.ran.Load() {
 					taskEmitter.TaskDone(ctx, time.Since(startTime))
 				}
@@ -219,8 +261,10 @@ func SimpleFlow() (string, error) {
 
 			defer task2.ran.Store(true)
 
-			v4, err = _33_4(v2)
-
+			err = cff.Intercept(ctx, interceptor, task2.outcome.Info, func(ctx context.Context) (err error) {
+				v4, err = _33_4(v2)
+				return
+			})
 			if err != nil {
 				taskEmitter.TaskError(ctx, err)
 				return err
@@ -243,17 +287,30 @@ func SimpleFlow() (string, error) {
 		var (
 			v5 string
 		)
+
 		task3 := new(struct {
 			emitter cff.TaskEmitter
 			ran     cff.AtomicBool
 			run     func(context.Context) error
 			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
 		})
+		task3.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/basic/basic.go",
+			Line:   37,
+			Column: 4,
+		}
+		task3.outcome.Dependencies = []*cff.TaskOutcome{
+			&task1.outcome,
+			&task2.outcome,
+		}
 		task3.emitter = cff.NopTaskEmitter()
 		task3.run = func(ctx context.Context) (err error) {
 			taskEmitter := task3.emitter
 			startTime := time.Now()
 			defer func() {
+				task3.outcome.Finish(err)
 				if task3.ran.Load() {
 					taskEmitter.TaskDone(ctx, time.Since(startTime))
 				}
@@ -272,8 +329,10 @@ func SimpleFlow() (string, error) {
 
 			defer task3.ran.Store(true)
 
-			v5, err = _37_4(v3, v4)
-
+			err = cff.Intercept(ctx, interceptor, task3.outcome.Info, func(ctx context.Context) (err error) {
+				v5, err = _37_4(v3, v4)
+				return
+			})
 			if err != nil {
 				taskEmitter.TaskError(ctx, err)
 				return err
@@ -295,6 +354,7 @@ func SimpleFlow() (string, error) {
 
 		if err := sched.Wait(ctx); err != nil {
 			flowEmitter.FlowError(ctx, err)
+			cff.RethrowPanic(err, false)
 			return err
 		}
 
@@ -322,6 +382,7 @@ func NoParamsFlow(ctx context.Context) (io.Reader, error) {
 		_54_4 := func(b *bytes.Buffer) io.Reader { return b }
 		ctx := _47_18
 		emitter := cff.NopEmitter()
+		interceptor := cff.InterceptorStack()
 
 		var (
 			flowInfo = &cff.FlowInfo{
@@ -359,11 +420,13 @@ func NoParamsFlow(ctx context.Context) (io.Reader, error) {
 			ran     cff.AtomicBool
 			run     func(context.Context) error
 			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
 		}
 		defer func() {
 			for _, t := range tasks {
 				if !t.ran.Load() {
-					t.emitter.TaskSkipped(ctx, err)
+					t.emitter.TaskSkipped(ctx, t.outcome.SkipReason(ctx, err))
 				}
 			}
 		}()
@@ -372,17 +435,26 @@ func NoParamsFlow(ctx context.Context) (io.Reader, error) {
 		var (
 			v6 *bytes.Buffer
 		)
+
 		task4 := new(struct {
 			emitter cff.TaskEmitter
 			ran     cff.AtomicBool
 			run     func(context.Context) error
 			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
 		})
+		task4.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/basic/basic.go",
+			Line:   50,
+			Column: 4,
+		}
 		task4.emitter = cff.NopTaskEmitter()
 		task4.run = func(ctx context.Context) (err error) {
 			taskEmitter := task4.emitter
 			startTime := time.Now()
 			defer func() {
+				task4.outcome.Finish(err)
 				if task4.ran.Load() {
 					taskEmitter.TaskDone(ctx, time.Since(startTime))
 				}
@@ -401,9 +473,16 @@ func NoParamsFlow(ctx context.Context) (io.Reader, error) {
 
 			defer task4.ran.Store(true)
 
-			v6 = _50_4()
-
-			taskEmitter.TaskSuccess(ctx)
+			err = cff.Intercept(ctx, interceptor, task4.outcome.Info, func(ctx context.Context) (err error) {
+				v6 = _50_4()
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return err
+			} else {
+				taskEmitter.TaskSuccess(ctx)
+			}
 
 			return
 		}
@@ -417,17 +496,29 @@ func NoParamsFlow(ctx context.Context) (io.Reader, error) {
 		var (
 			v7 io.Reader
 		)
+
 		task5 := new(struct {
 			emitter cff.TaskEmitter
 			ran     cff.AtomicBool
 			run     func(context.Context) error
 			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
 		})
+		task5.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/basic/basic.go",
+			Line:   54,
+			Column: 4,
+		}
+		task5.outcome.Dependencies = []*cff.TaskOutcome{
+			&task4.outcome,
+		}
 		task5.emitter = cff.NopTaskEmitter()
 		task5.run = func(ctx context.Context) (err error) {
 			taskEmitter := task5.emitter
 			startTime := time.Now()
 			defer func() {
+				task5.outcome.Finish(err)
 				if task5.ran.Load() {
 					taskEmitter.TaskDone(ctx, time.Since(startTime))
 				}
@@ -446,9 +537,16 @@ func NoParamsFlow(ctx context.Context) (io.Reader, error) {
 
 			defer task5.ran.Store(true)
 
-			v7 = _54_4(v6)
-
-			taskEmitter.TaskSuccess(ctx)
+			err = cff.Intercept(ctx, interceptor, task5.outcome.Info, func(ctx context.Context) (err error) {
+				v7 = _54_4(v6)
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return err
+			} else {
+				taskEmitter.TaskSuccess(ctx)
+			}
 
 			return
 		}
@@ -463,6 +561,7 @@ func NoParamsFlow(ctx context.Context) (io.Reader, error) {
 
 		if err := sched.Wait(ctx); err != nil {
 			flowEmitter.FlowError(ctx, err)
+			cff.RethrowPanic(err, false)
 			return err
 		}
 
@@ -501,6 +600,7 @@ func SerialFailableFlow(ctx context.Context, f1, f2 func() error) error {
 		}
 		ctx := _69_3
 		emitter := cff.NopEmitter()
+		interceptor := cff.InterceptorStack()
 
 		var (
 			flowInfo = &cff.FlowInfo{
@@ -538,11 +638,13 @@ func SerialFailableFlow(ctx context.Context, f1, f2 func() error) error {
 			ran     cff.AtomicBool
 			run     func(context.Context) error
 			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
 		}
 		defer func() {
 			for _, t := range tasks {
 				if !t.ran.Load() {
-					t.emitter.TaskSkipped(ctx, err)
+					t.emitter.TaskSkipped(ctx, t.outcome.SkipReason(ctx, err))
 				}
 			}
 		}()
@@ -551,17 +653,26 @@ func SerialFailableFlow(ctx context.Context, f1, f2 func() error) error {
 		var (
 			v8 t1
 		)
+
 		task6 := new(struct {
 			emitter cff.TaskEmitter
 			ran     cff.AtomicBool
 			run     func(context.Context) error
 			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
 		})
+		task6.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/basic/basic.go",
+			Line:   72,
+			Column: 4,
+		}
 		task6.emitter = cff.NopTaskEmitter()
 		task6.run = func(ctx context.Context) (err error) {
 			taskEmitter := task6.emitter
 			startTime := time.Now()
 			defer func() {
+				task6.outcome.Finish(err)
 				if task6.ran.Load() {
 					taskEmitter.TaskDone(ctx, time.Since(startTime))
 				}
@@ -580,8 +691,10 @@ func SerialFailableFlow(ctx context.Context, f1, f2 func() error) error {
 
 			defer task6.ran.Store(true)
 
-			v8, err = _72_4()
-
+			err = cff.Intercept(ctx, interceptor, task6.outcome.Info, func(ctx context.Context) (err error) {
+				v8, err = _72_4()
+				return
+			})
 			if err != nil {
 				taskEmitter.TaskError(ctx, err)
 				return err
@@ -601,17 +714,29 @@ func SerialFailableFlow(ctx context.Context, f1, f2 func() error) error {
 		var (
 			v9 t2
 		)
+
 		task7 := new(struct {
 			emitter cff.TaskEmitter
 			ran     cff.AtomicBool
 			run     func(context.Context) error
 			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
 		})
+		task7.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/basic/basic.go",
+			Line:   76,
+			Column: 4,
+		}
+		task7.outcome.Dependencies = []*cff.TaskOutcome{
+			&task6.outcome,
+		}
 		task7.emitter = cff.NopTaskEmitter()
 		task7.run = func(ctx context.Context) (err error) {
 			taskEmitter := task7.emitter
 			startTime := time.Now()
 			defer func() {
+				task7.outcome.Finish(err)
 				if task7.ran.Load() {
 					taskEmitter.TaskDone(ctx, time.Since(startTime))
 				}
@@ -630,8 +755,10 @@ func SerialFailableFlow(ctx context.Context, f1, f2 func() error) error {
 
 			defer task7.ran.Store(true)
 
-			v9, err = _76_4(v8)
-
+			err = cff.Intercept(ctx, interceptor, task7.outcome.Info, func(ctx context.Context) (err error) {
+				v9, err = _76_4(v8)
+				return
+			})
 			if err != nil {
 				taskEmitter.TaskError(ctx, err)
 				return err
@@ -654,17 +781,29 @@ func SerialFailableFlow(ctx context.Context, f1, f2 func() error) error {
 		var (
 			v10 t3
 		)
+
 		task8 := new(struct {
 			emitter cff.TaskEmitter
 			ran     cff.AtomicBool
 			run     func(context.Context) error
 			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
 		})
+		task8.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/basic/basic.go",
+			Line:   80,
+			Column: 4,
+		}
+		task8.outcome.Dependencies = []*cff.TaskOutcome{
+			&task7.outcome,
+		}
 		task8.emitter = cff.NopTaskEmitter()
 		task8.run = func(ctx context.Context) (err error) {
 			taskEmitter := task8.emitter
 			startTime := time.Now()
 			defer func() {
+				task8.outcome.Finish(err)
 				if task8.ran.Load() {
 					taskEmitter.TaskDone(ctx, time.Since(startTime))
 				}
@@ -683,9 +822,16 @@ func SerialFailableFlow(ctx context.Context, f1, f2 func() error) error {
 
 			defer task8.ran.Store(true)
 
-			v10 = _80_4(v9)
-
-			taskEmitter.TaskSuccess(ctx)
+			err = cff.Intercept(ctx, interceptor, task8.outcome.Info, func(ctx context.Context) (err error) {
+				v10 = _80_4(v9)
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return err
+			} else {
+				taskEmitter.TaskSuccess(ctx)
+			}
 
 			return
 		}
@@ -700,6 +846,7 @@ func SerialFailableFlow(ctx context.Context, f1, f2 func() error) error {
 
 		if err := sched.Wait(ctx); err != nil {
 			flowEmitter.FlowError(ctx, err)
+			cff.RethrowPanic(err, false)
 			return err
 		}
 
@@ -744,6 +891,7 @@ func ProduceMultiple() error {
 		ctx := _104_3
 		var v11 t1 = _105_14
 		emitter := cff.NopEmitter()
+		interceptor := cff.InterceptorStack()
 
 		var (
 			flowInfo = &cff.FlowInfo{
@@ -781,11 +929,13 @@ func ProduceMultiple() error {
 			ran     cff.AtomicBool
 			run     func(context.Context) error
 			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
 		}
 		defer func() {
 			for _, t := range tasks {
 				if !t.ran.Load() {
-					t.emitter.TaskSkipped(ctx, err)
+					t.emitter.TaskSkipped(ctx, t.outcome.SkipReason(ctx, err))
 				}
 			}
 		}()
@@ -795,17 +945,26 @@ func ProduceMultiple() error {
 			v12 t2
 			v13 t3
 		)
+
 		task9 := new(struct {
 			emitter cff.TaskEmitter
 			ran     cff.AtomicBool
 			run     func(context.Context) error
 			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
 		})
+		task9.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/basic/basic.go",
+			Line:   108,
+			Column: 4,
+		}
 		task9.emitter = cff.NopTaskEmitter()
 		task9.run = func(ctx context.Context) (err error) {
 			taskEmitter := task9.emitter
 			startTime := time.Now()
 			defer func() {
+				task9.outcome.Finish(err)
 				if task9.ran.Load() {
 					taskEmitter.TaskDone(ctx, time.Since(startTime))
 				}
@@ -824,9 +983,16 @@ func ProduceMultiple() error {
 
 			defer task9.ran.Store(true)
 
-			v12, v13 = _108_4(v11)
-
-			taskEmitter.TaskSuccess(ctx)
+			err = cff.Intercept(ctx, interceptor, task9.outcome.Info, func(ctx context.Context) (err error) {
+				v12, v13 = _108_4(v11)
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return err
+			} else {
+				taskEmitter.TaskSuccess(ctx)
+			}
 
 			return
 		}
@@ -840,17 +1006,29 @@ func ProduceMultiple() error {
 		var (
 			v14 t4
 		)
+
 		task10 := new(struct {
 			emitter cff.TaskEmitter
 			ran     cff.AtomicBool
 			run     func(context.Context) error
 			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
 		})
+		task10.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/basic/basic.go",
+			Line:   112,
+			Column: 4,
+		}
+		task10.outcome.Dependencies = []*cff.TaskOutcome{
+			&task9.outcome,
+		}
 		task10.emitter = cff.NopTaskEmitter()
 		task10.run = func(ctx context.Context) (err error) {
 			taskEmitter := task10.emitter
 			startTime := time.Now()
 			defer func() {
+				task10.outcome.Finish(err)
 				if task10.ran.Load() {
 					taskEmitter.TaskDone(ctx, time.Since(startTime))
 				}
@@ -869,9 +1047,16 @@ func ProduceMultiple() error {
 
 			defer task10.ran.Store(true)
 
-			v14 = _112_4(v12, v13)
-
-			taskEmitter.TaskSuccess(ctx)
+			err = cff.Intercept(ctx, interceptor, task10.outcome.Info, func(ctx context.Context) (err error) {
+				v14 = _112_4(v12, v13)
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return err
+			} else {
+				taskEmitter.TaskSuccess(ctx)
+			}
 
 			return
 		}
@@ -887,6 +1072,7 @@ func ProduceMultiple() error {
 
 		if err := sched.Wait(ctx); err != nil {
 			flowEmitter.FlowError(ctx, err)
+			cff.RethrowPanic(err, false)
 			return err
 		}
 
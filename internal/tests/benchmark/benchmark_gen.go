@@ -41,6 +41,7 @@ func Baseline() float64 {
 		_34_12 := c
 		ctx := _30_3
 		emitter := cff.NopEmitter()
+		interceptor := cff.InterceptorStack()
 
 		var (
 			flowInfo = &cff.FlowInfo{
@@ -78,11 +79,13 @@ func Baseline() float64 {
 			ran     cff.AtomicBool
 			run     func(context.Context) error
 			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
 		}
 		defer func() {
 			for _, t := range tasks {
 				if !t.ran.Load() {
-					t.emitter.TaskSkipped(ctx, err)
+					t.emitter.TaskSkipped(ctx, t.outcome.SkipReason(ctx, err))
 				}
 			}
 		}()
@@ -91,17 +94,26 @@ func Baseline() float64 {
 		var (
 			v1 int64
 		)
+
 		task0 := new(struct {
 			emitter cff.TaskEmitter
 			ran     cff.AtomicBool
 			run     func(context.Context) error
 			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
 		})
+		task0.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/benchmark/benchmark.go",
+			Line:   32,
+			Column: 12,
+		}
 		task0.emitter = cff.NopTaskEmitter()
 		task0.run = func(ctx context.Context) (err error) {
 			taskEmitter := task0.emitter
 			startTime := time.Now()
 			defer func() {
+				task0.outcome.Finish(err)
 				if task0.ran.Load() {
 					taskEmitter.TaskDone(ctx, time.Since(startTime))
 				}
@@ -120,9 +132,16 @@ func Baseline() float64 {
 
 			defer task0.ran.Store(true)
 
-			v1 = _32_12()
-
-			taskEmitter.TaskSuccess(ctx)
+			err = cff.Intercept(ctx, interceptor, task0.outcome.Info, func(ctx context.Context) (err error) {
+				v1 = _32_12()
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return err
+			} else {
+				taskEmitter.TaskSuccess(ctx)
+			}
 
 			return
 		}
@@ -136,17 +155,26 @@ func Baseline() float64 {
 		var (
 			v2 int
 		)
+
 		task1 := new(struct {
 			emitter cff.TaskEmitter
 			ran     cff.AtomicBool
 			run     func(context.Context) error
 			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
 		})
+		task1.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/benchmark/benchmark.go",
+			Line:   33,
+			Column: 12,
+		}
 		task1.emitter = cff.NopTaskEmitter()
 		task1.run = func(ctx context.Context) (err error) {
 			taskEmitter := task1.emitter
 			startTime := time.Now()
 			defer func() {
+				task1.outcome.Finish(err)
 				if task1.ran.Load() {
 					taskEmitter.TaskDone(ctx, time.Since(startTime))
 				}
@@ -165,9 +193,16 @@ func Baseline() float64 {
 
 			defer task1.ran.Store(true)
 
-			v2 = _33_12()
-
-			taskEmitter.TaskSuccess(ctx)
+			err = cff.Intercept(ctx, interceptor, task1.outcome.Info, func(ctx context.Context) (err error) {
+				v2 = _33_12()
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return err
+			} else {
+				taskEmitter.TaskSuccess(ctx)
+			}
 
 			return
 		}
@@ -181,17 +216,30 @@ func Baseline() float64 {
 		var (
 			v3 float64
 		)
+
 		task2 := new(struct {
 			emitter cff.TaskEmitter
 			ran     cff.AtomicBool
 			run     func(context.Context) error
 			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
 		})
+		task2.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/benchmark/benchmark.go",
+			Line:   34,
+			Column: 12,
+		}
+		task2.outcome.Dependencies = []*cff.TaskOutcome{
+			&task0.outcome,
+			&task1.outcome,
+		}
 		task2.emitter = cff.NopTaskEmitter()
 		task2.run = func(ctx context.Context) (err error) {
 			taskEmitter := task2.emitter
 			startTime := time.Now()
 			defer func() {
+				task2.outcome.Finish(err)
 				if task2.ran.Load() {
 					taskEmitter.TaskDone(ctx, time.Since(startTime))
 				}
@@ -210,9 +258,16 @@ func Baseline() float64 {
 
 			defer task2.ran.Store(true)
 
-			v3 = _34_12(v1, v2)
-
-			taskEmitter.TaskSuccess(ctx)
+			err = cff.Intercept(ctx, interceptor, task2.outcome.Info, func(ctx context.Context) (err error) {
+				v3 = _34_12(v1, v2)
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return err
+			} else {
+				taskEmitter.TaskSuccess(ctx)
+			}
 
 			return
 		}
@@ -228,6 +283,7 @@ func Baseline() float64 {
 
 		if err := sched.Wait(ctx); err != nil {
 			flowEmitter.FlowError(ctx, err)
+			cff.RethrowPanic(err, false)
 			return err
 		}
 
@@ -55,6 +55,7 @@ func PredicateCombined() float64 {
 		}
 		ctx := _37_3
 		emitter := cff.NopEmitter()
+		interceptor := cff.InterceptorStack()
 
 		var (
 			flowInfo = &cff.FlowInfo{
@@ -92,11 +93,13 @@ func PredicateCombined() float64 {
 			ran     cff.AtomicBool
 			run     func(context.Context) error
 			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
 		}
 		defer func() {
 			for _, t := range tasks {
 				if !t.ran.Load() {
-					t.emitter.TaskSkipped(ctx, err)
+					t.emitter.TaskSkipped(ctx, t.outcome.SkipReason(ctx, err))
 				}
 			}
 		}()
@@ -105,17 +108,26 @@ func PredicateCombined() float64 {
 		var (
 			v1 int
 		)
+
 		task0 := new(struct {
 			emitter cff.TaskEmitter
 			ran     cff.AtomicBool
 			run     func(context.Context) error
 			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
 		})
+		task0.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/benchmark/benchmark_predicate.go",
+			Line:   41,
+			Column: 4,
+		}
 		task0.emitter = cff.NopTaskEmitter()
 		task0.run = func(ctx context.Context) (err error) {
 			taskEmitter := task0.emitter
 			startTime := time.Now()
 			defer func() {
+				task0.outcome.Finish(err)
 				if task0.ran.Load() {
 					taskEmitter.TaskDone(ctx, time.Since(startTime))
 				}
@@ -134,9 +146,16 @@ func PredicateCombined() float64 {
 
 			defer task0.ran.Store(true)
 
-			v1 = _41_4()
-
-			taskEmitter.TaskSuccess(ctx)
+			err = cff.Intercept(ctx, interceptor, task0.outcome.Info, func(ctx context.Context) (err error) {
+				v1 = _41_4()
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return err
+			} else {
+				taskEmitter.TaskSuccess(ctx)
+			}
 
 			return
 		}
@@ -150,17 +169,29 @@ func PredicateCombined() float64 {
 		var (
 			v2 float64
 		)
+
 		task1 := new(struct {
 			emitter cff.TaskEmitter
 			ran     cff.AtomicBool
 			run     func(context.Context) error
 			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
 		})
+		task1.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/benchmark/benchmark_predicate.go",
+			Line:   46,
+			Column: 4,
+		}
+		task1.outcome.Dependencies = []*cff.TaskOutcome{
+			&task0.outcome,
+		}
 		task1.emitter = cff.NopTaskEmitter()
 		task1.run = func(ctx context.Context) (err error) {
 			taskEmitter := task1.emitter
 			startTime := time.Now()
 			defer func() {
+				task1.outcome.Finish(err)
 				if task1.ran.Load() {
 					taskEmitter.TaskDone(ctx, time.Since(startTime))
 				}
@@ -179,9 +210,16 @@ func PredicateCombined() float64 {
 
 			defer task1.ran.Store(true)
 
-			v2 = _46_4(v1)
-
-			taskEmitter.TaskSuccess(ctx)
+			err = cff.Intercept(ctx, interceptor, task1.outcome.Info, func(ctx context.Context) (err error) {
+				v2 = _46_4(v1)
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return err
+			} else {
+				taskEmitter.TaskSuccess(ctx)
+			}
 
 			return
 		}
@@ -196,6 +234,7 @@ func PredicateCombined() float64 {
 
 		if err := sched.Wait(ctx); err != nil {
 			flowEmitter.FlowError(ctx, err)
+			cff.RethrowPanic(err, false)
 			return err
 		}
 
@@ -232,6 +271,7 @@ func PredicateSplit() float64 {
 		_78_5 := pred
 		ctx := _65_3
 		emitter := cff.NopEmitter()
+		interceptor := cff.InterceptorStack()
 
 		var (
 			flowInfo = &cff.FlowInfo{
@@ -269,11 +309,13 @@ func PredicateSplit() float64 {
 			ran     cff.AtomicBool
 			run     func(context.Context) error
 			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
 		}
 		defer func() {
 			for _, t := range tasks {
 				if !t.ran.Load() {
-					t.emitter.TaskSkipped(ctx, err)
+					t.emitter.TaskSkipped(ctx, t.outcome.SkipReason(ctx, err))
 				}
 			}
 		}()
@@ -282,17 +324,26 @@ func PredicateSplit() float64 {
 		var (
 			v1 int
 		)
+
 		task2 := new(struct {
 			emitter cff.TaskEmitter
 			ran     cff.AtomicBool
 			run     func(context.Context) error
 			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
 		})
+		task2.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/benchmark/benchmark_predicate.go",
+			Line:   69,
+			Column: 4,
+		}
 		task2.emitter = cff.NopTaskEmitter()
 		task2.run = func(ctx context.Context) (err error) {
 			taskEmitter := task2.emitter
 			startTime := time.Now()
 			defer func() {
+				task2.outcome.Finish(err)
 				if task2.ran.Load() {
 					taskEmitter.TaskDone(ctx, time.Since(startTime))
 				}
@@ -311,9 +362,16 @@ func PredicateSplit() float64 {
 
 			defer task2.ran.Store(true)
 
-			v1 = _69_4()
-
-			taskEmitter.TaskSuccess(ctx)
+			err = cff.Intercept(ctx, interceptor, task2.outcome.Info, func(ctx context.Context) (err error) {
+				v1 = _69_4()
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return err
+			} else {
+				taskEmitter.TaskSuccess(ctx)
+			}
 
 			return
 		}
@@ -352,17 +410,29 @@ func PredicateSplit() float64 {
 		var (
 			v2 float64
 		)
+
 		task3 := new(struct {
 			emitter cff.TaskEmitter
 			ran     cff.AtomicBool
 			run     func(context.Context) error
 			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
 		})
+		task3.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/benchmark/benchmark_predicate.go",
+			Line:   74,
+			Column: 4,
+		}
+		task3.outcome.Dependencies = []*cff.TaskOutcome{
+			&task2.outcome,
+		}
 		task3.emitter = cff.NopTaskEmitter()
 		task3.run = func(ctx context.Context) (err error) {
 			taskEmitter := task3.emitter
 			startTime := time.Now()
 			defer func() {
+				task3.outcome.Finish(err)
 				if task3.ran.Load() {
 					taskEmitter.TaskDone(ctx, time.Since(startTime))
 				}
@@ -388,14 +458,22 @@ func PredicateSplit() float64 {
 			}()
 
 			if !p0 {
+				task3.outcome.Skip(cff.SkipPredicateFalse)
 				return nil
 			}
 
 			defer task3.ran.Store(true)
 
-			v2 = _74_4(v1)
-
-			taskEmitter.TaskSuccess(ctx)
+			err = cff.Intercept(ctx, interceptor, task3.outcome.Info, func(ctx context.Context) (err error) {
+				v2 = _74_4(v1)
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return err
+			} else {
+				taskEmitter.TaskSuccess(ctx)
+			}
 
 			return
 		}
@@ -411,6 +489,7 @@ func PredicateSplit() float64 {
 
 		if err := sched.Wait(ctx); err != nil {
 			flowEmitter.FlowError(ctx, err)
+			cff.RethrowPanic(err, false)
 			return err
 		}
 
@@ -0,0 +1,87 @@
+//go:build cff
+// +build cff
+
+// Package budget tests tasks that receive a share of the flow's deadline
+// with cff.Budget.
+package budget
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/cff"
+)
+
+// Deadlines records the deadlines of the contexts received by tasks.
+type Deadlines struct {
+	Fetch, Render, Audit time.Time
+}
+
+// _renderWeight is a named constant weight passed to cff.Budget.
+const _renderWeight = 3
+
+type page string
+
+// Chain runs a slow fetch task, which blocks until its budget expires,
+// followed by render and audit tasks that consume its output.
+// The heaviest chain after fetch is render, so fetch receives
+// a quarter of the time left.
+func Chain(ctx context.Context) (*Deadlines, error) {
+	var (
+		d   Deadlines
+		out page
+	)
+	err := cff.Flow(ctx,
+		cff.Results(&out),
+		cff.Task(
+			func(ctx context.Context) string {
+				d.Fetch, _ = ctx.Deadline()
+				<-ctx.Done()
+				return "stale"
+			},
+			cff.Budget(1),
+		),
+		cff.Task(
+			func(ctx context.Context, s string) page {
+				d.Render, _ = ctx.Deadline()
+				return page(s)
+			},
+			cff.Budget(_renderWeight),
+		),
+		cff.Task(
+			func(ctx context.Context, s string) error {
+				d.Audit, _ = ctx.Deadline()
+				return nil
+			},
+			cff.Invoke(true),
+			cff.Budget(0.5),
+		),
+	)
+	return &d, err
+}
+
+// Unbudgeted runs a task with cff.Budget followed by a task without one.
+// The task with the budget receives all the time left.
+func Unbudgeted(ctx context.Context) (*Deadlines, error) {
+	var (
+		d   Deadlines
+		out page
+	)
+	err := cff.Flow(ctx,
+		cff.Results(&out),
+		cff.Task(
+			func(ctx context.Context) string {
+				d.Fetch, _ = ctx.Deadline()
+				return "fresh"
+			},
+			cff.Budget(0.2),
+		),
+		cff.Task(
+			func(ctx context.Context, s string) page {
+				d.Render, _ = ctx.Deadline()
+				return page(s)
+			},
+		),
+	)
+	return &d, err
+}
@@ -0,0 +1,537 @@
+//go:build !cff
+// +build !cff
+
+// Package budget tests tasks that receive a share of the flow's deadline
+// with cff.Budget.
+package budget
+
+import (
+	"context"
+	"runtime/debug"
+	"time"
+
+	"go.uber.org/cff"
+)
+
+// Deadlines records the deadlines of the contexts received by tasks.
+type Deadlines struct {
+	Fetch, Render, Audit time.Time
+}
+
+// _renderWeight is a named constant weight passed to cff.Budget.
+const _renderWeight = 3
+
+type page string
+
+// Chain runs a slow fetch task, which blocks until its budget expires,
+// followed by render and audit tasks that consume its output.
+// The heaviest chain after fetch is render, so fetch receives
+// a quarter of the time left.
+func Chain(ctx context.Context) (*Deadlines, error) {
+	var (
+		d   Deadlines
+		out page
+	)
+	err := func() (err error) {
+
+		_34_18 := ctx
+
+		_35_15 := &out
+
+		_37_4 := func(ctx context.Context) string {
+			d.Fetch, _ = ctx.Deadline()
+			<-ctx.Done()
+			return "stale"
+		}
+
+		_45_4 := func(ctx context.Context, s string) page {
+			d.Render, _ = ctx.Deadline()
+			return page(s)
+		}
+
+		_52_4 := func(ctx context.Context, s string) error {
+			d.Audit, _ = ctx.Deadline()
+			return nil
+		}
+		ctx := _34_18
+		emitter := cff.NopEmitter()
+		interceptor := cff.InterceptorStack()
+
+		var (
+			flowInfo = &cff.FlowInfo{
+				File:   "go.uber.org/cff/internal/tests/budget/budget.go",
+				Line:   34,
+				Column: 9,
+			}
+			flowEmitter = cff.NopFlowEmitter()
+
+			schedInfo = &cff.SchedulerInfo{
+				Name:      flowInfo.Name,
+				Directive: cff.FlowDirective,
+				File:      flowInfo.File,
+				Line:      flowInfo.Line,
+				Column:    flowInfo.Column,
+			}
+
+			// possibly unused
+			_ = flowInfo
+		)
+
+		startTime := time.Now()
+		defer func() { flowEmitter.FlowDone(ctx, time.Since(startTime)) }()
+
+		schedEmitter := emitter.SchedulerInit(schedInfo)
+
+		sched := cff.NewScheduler(
+			cff.SchedulerParams{
+				Emitter: schedEmitter,
+			},
+		)
+
+		var tasks []*struct {
+			emitter cff.TaskEmitter
+			ran     cff.AtomicBool
+			run     func(context.Context) error
+			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		}
+		defer func() {
+			for _, t := range tasks {
+				if !t.ran.Load() {
+					t.emitter.TaskSkipped(ctx, t.outcome.SkipReason(ctx, err))
+				}
+			}
+		}()
+
+		// go.uber.org/cff/internal/tests/budget/budget.go:37:4
+		var (
+			v1 string
+		)
+
+		task0 := new(struct {
+			emitter cff.TaskEmitter
+			ran     cff.AtomicBool
+			run     func(context.Context) error
+			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		})
+		task0.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/budget/budget.go",
+			Line:   37,
+			Column: 4,
+		}
+		task0.emitter = cff.NopTaskEmitter()
+		task0.run = func(ctx context.Context) (err error) {
+			taskEmitter := task0.emitter
+			startTime := time.Now()
+			defer func() {
+				task0.outcome.Finish(err)
+				if task0.ran.Load() {
+					taskEmitter.TaskDone(ctx, time.Since(startTime))
+				}
+			}()
+
+			defer func() {
+				recovered := recover()
+				if recovered != nil {
+					taskEmitter.TaskPanic(ctx, recovered)
+					err = &cff.PanicError{
+						Value:      recovered,
+						Stacktrace: debug.Stack(),
+					}
+				}
+			}()
+
+			defer task0.ran.Store(true)
+
+			err = cff.Intercept(ctx, interceptor, task0.outcome.Info, func(ctx context.Context) (err error) {
+				ctx, cancel := cff.BudgetContext(ctx, 1, 4)
+				defer cancel()
+
+				v1 = _37_4(ctx)
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return err
+			} else {
+				taskEmitter.TaskSuccess(ctx)
+			}
+
+			return
+		}
+
+		task0.job = sched.Enqueue(ctx, cff.Job{
+			Run: task0.run,
+		})
+		tasks = append(tasks, task0)
+
+		// go.uber.org/cff/internal/tests/budget/budget.go:45:4
+		var (
+			v2 page
+		)
+
+		task1 := new(struct {
+			emitter cff.TaskEmitter
+			ran     cff.AtomicBool
+			run     func(context.Context) error
+			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		})
+		task1.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/budget/budget.go",
+			Line:   45,
+			Column: 4,
+		}
+		task1.outcome.Dependencies = []*cff.TaskOutcome{
+			&task0.outcome,
+		}
+		task1.emitter = cff.NopTaskEmitter()
+		task1.run = func(ctx context.Context) (err error) {
+			taskEmitter := task1.emitter
+			startTime := time.Now()
+			defer func() {
+				task1.outcome.Finish(err)
+				if task1.ran.Load() {
+					taskEmitter.TaskDone(ctx, time.Since(startTime))
+				}
+			}()
+
+			defer func() {
+				recovered := recover()
+				if recovered != nil {
+					taskEmitter.TaskPanic(ctx, recovered)
+					err = &cff.PanicError{
+						Value:      recovered,
+						Stacktrace: debug.Stack(),
+					}
+				}
+			}()
+
+			defer task1.ran.Store(true)
+
+			err = cff.Intercept(ctx, interceptor, task1.outcome.Info, func(ctx context.Context) (err error) {
+				ctx, cancel := cff.BudgetContext(ctx, 3, 3)
+				defer cancel()
+
+				v2 = _45_4(ctx, v1)
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return err
+			} else {
+				taskEmitter.TaskSuccess(ctx)
+			}
+
+			return
+		}
+
+		task1.job = sched.Enqueue(ctx, cff.Job{
+			Run: task1.run,
+			Dependencies: []*cff.ScheduledJob{
+				task0.job,
+			},
+		})
+		tasks = append(tasks, task1)
+
+		// go.uber.org/cff/internal/tests/budget/budget.go:52:4
+		task2 := new(struct {
+			emitter cff.TaskEmitter
+			ran     cff.AtomicBool
+			run     func(context.Context) error
+			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		})
+		task2.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/budget/budget.go",
+			Line:   52,
+			Column: 4,
+		}
+		task2.outcome.Dependencies = []*cff.TaskOutcome{
+			&task0.outcome,
+		}
+		task2.emitter = cff.NopTaskEmitter()
+		task2.run = func(ctx context.Context) (err error) {
+			taskEmitter := task2.emitter
+			startTime := time.Now()
+			defer func() {
+				task2.outcome.Finish(err)
+				if task2.ran.Load() {
+					taskEmitter.TaskDone(ctx, time.Since(startTime))
+				}
+			}()
+
+			defer func() {
+				recovered := recover()
+				if recovered != nil {
+					taskEmitter.TaskPanic(ctx, recovered)
+					err = &cff.PanicError{
+						Value:      recovered,
+						Stacktrace: debug.Stack(),
+					}
+				}
+			}()
+
+			defer task2.ran.Store(true)
+
+			err = cff.Intercept(ctx, interceptor, task2.outcome.Info, func(ctx context.Context) (err error) {
+				ctx, cancel := cff.BudgetContext(ctx, 0.5, 0.5)
+				defer cancel()
+
+				err = _52_4(ctx, v1)
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return err
+			} else {
+				taskEmitter.TaskSuccess(ctx)
+			}
+
+			return
+		}
+
+		task2.job = sched.Enqueue(ctx, cff.Job{
+			Run: task2.run,
+			Dependencies: []*cff.ScheduledJob{
+				task0.job,
+			},
+		})
+		tasks = append(tasks, task2)
+
+		if err := sched.Wait(ctx); err != nil {
+			flowEmitter.FlowError(ctx, err)
+			cff.RethrowPanic(err, false)
+			return err
+		}
+
+		*(_35_15) = v2 // go.uber.org/cff/internal/tests/budget.page
+
+		flowEmitter.FlowSuccess(ctx)
+		return nil
+	}()
+	return &d, err
+}
+
+// Unbudgeted runs a task with cff.Budget followed by a task without one.
+// The task with the budget receives all the time left.
+func Unbudgeted(ctx context.Context) (*Deadlines, error) {
+	var (
+		d   Deadlines
+		out page
+	)
+	err := func() (err error) {
+
+		_70_18 := ctx
+
+		_71_15 := &out
+
+		_73_4 := func(ctx context.Context) string {
+			d.Fetch, _ = ctx.Deadline()
+			return "fresh"
+		}
+
+		_80_4 := func(ctx context.Context, s string) page {
+			d.Render, _ = ctx.Deadline()
+			return page(s)
+		}
+		ctx := _70_18
+		emitter := cff.NopEmitter()
+		interceptor := cff.InterceptorStack()
+
+		var (
+			flowInfo = &cff.FlowInfo{
+				File:   "go.uber.org/cff/internal/tests/budget/budget.go",
+				Line:   70,
+				Column: 9,
+			}
+			flowEmitter = cff.NopFlowEmitter()
+
+			schedInfo = &cff.SchedulerInfo{
+				Name:      flowInfo.Name,
+				Directive: cff.FlowDirective,
+				File:      flowInfo.File,
+				Line:      flowInfo.Line,
+				Column:    flowInfo.Column,
+			}
+
+			// possibly unused
+			_ = flowInfo
+		)
+
+		startTime := time.Now()
+		defer func() { flowEmitter.FlowDone(ctx, time.Since(startTime)) }()
+
+		schedEmitter := emitter.SchedulerInit(schedInfo)
+
+		sched := cff.NewScheduler(
+			cff.SchedulerParams{
+				Emitter: schedEmitter,
+			},
+		)
+
+		var tasks []*struct {
+			emitter cff.TaskEmitter
+			ran     cff.AtomicBool
+			run     func(context.Context) error
+			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		}
+		defer func() {
+			for _, t := range tasks {
+				if !t.ran.Load() {
+					t.emitter.TaskSkipped(ctx, t.outcome.SkipReason(ctx, err))
+				}
+			}
+		}()
+
+		// go.uber.org/cff/internal/tests/budget/budget.go:73:4
+		var (
+			v1 string
+		)
+
+		task3 := new(struct {
+			emitter cff.TaskEmitter
+			ran     cff.AtomicBool
+			run     func(context.Context) error
+			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		})
+		task3.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/budget/budget.go",
+			Line:   73,
+			Column: 4,
+		}
+		task3.emitter = cff.NopTaskEmitter()
+		task3.run = func(ctx context.Context) (err error) {
+			taskEmitter := task3.emitter
+			startTime := time.Now()
+			defer func() {
+				task3.outcome.Finish(err)
+				if task3.ran.Load() {
+					taskEmitter.TaskDone(ctx, time.Since(startTime))
+				}
+			}()
+
+			defer func() {
+				recovered := recover()
+				if recovered != nil {
+					taskEmitter.TaskPanic(ctx, recovered)
+					err = &cff.PanicError{
+						Value:      recovered,
+						Stacktrace: debug.Stack(),
+					}
+				}
+			}()
+
+			defer task3.ran.Store(true)
+
+			err = cff.Intercept(ctx, interceptor, task3.outcome.Info, func(ctx context.Context) (err error) {
+				ctx, cancel := cff.BudgetContext(ctx, 0.2, 0.2)
+				defer cancel()
+
+				v1 = _73_4(ctx)
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return err
+			} else {
+				taskEmitter.TaskSuccess(ctx)
+			}
+
+			return
+		}
+
+		task3.job = sched.Enqueue(ctx, cff.Job{
+			Run: task3.run,
+		})
+		tasks = append(tasks, task3)
+
+		// go.uber.org/cff/internal/tests/budget/budget.go:80:4
+		var (
+			v2 page
+		)
+
+		task4 := new(struct {
+			emitter cff.TaskEmitter
+			ran     cff.AtomicBool
+			run     func(context.Context) error
+			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		})
+		task4.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/budget/budget.go",
+			Line:   80,
+			Column: 4,
+		}
+		task4.outcome.Dependencies = []*cff.TaskOutcome{
+			&task3.outcome,
+		}
+		task4.emitter = cff.NopTaskEmitter()
+		task4.run = func(ctx context.Context) (err error) {
+			taskEmitter := task4.emitter
+			startTime := time.Now()
+			defer func() {
+				task4.outcome.Finish(err)
+				if task4.ran.Load() {
+					taskEmitter.TaskDone(ctx, time.Since(startTime))
+				}
+			}()
+
+			defer func() {
+				recovered := recover()
+				if recovered != nil {
+					taskEmitter.TaskPanic(ctx, recovered)
+					err = &cff.PanicError{
+						Value:      recovered,
+						Stacktrace: debug.Stack(),
+					}
+				}
+			}()
+
+			defer task4.ran.Store(true)
+
+			err = cff.Intercept(ctx, interceptor, task4.outcome.Info, func(ctx context.Context) (err error) {
+				v2 = _80_4(ctx, v1)
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return err
+			} else {
+				taskEmitter.TaskSuccess(ctx)
+			}
+
+			return
+		}
+
+		task4.job = sched.Enqueue(ctx, cff.Job{
+			Run: task4.run,
+			Dependencies: []*cff.ScheduledJob{
+				task3.job,
+			},
+		})
+		tasks = append(tasks, task4)
+
+		if err := sched.Wait(ctx); err != nil {
+			flowEmitter.FlowError(ctx, err)
+			cff.RethrowPanic(err, false)
+			return err
+		}
+
+		*(_71_15) = v2 // go.uber.org/cff/internal/tests/budget.page
+
+		flowEmitter.FlowSuccess(ctx)
+		return nil
+	}()
+	return &d, err
+}
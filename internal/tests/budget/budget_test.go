@@ -0,0 +1,44 @@
+package budget
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChain(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 400*time.Millisecond)
+	defer cancel()
+	deadline, _ := ctx.Deadline()
+
+	start := time.Now()
+	d, err := Chain(ctx)
+	require.NoError(t, err)
+
+	assert.WithinDuration(t, start.Add(100*time.Millisecond), d.Fetch, 50*time.Millisecond,
+		"fetch must receive a quarter of the time left")
+	assert.Equal(t, deadline, d.Render, "render is the last task of its chain")
+	assert.Equal(t, deadline, d.Audit, "audit is the last task of its chain")
+	assert.NoError(t, ctx.Err(), "slow fetch must not use up the flow's time")
+}
+
+func TestUnbudgeted(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	deadline, _ := ctx.Deadline()
+
+	d, err := Unbudgeted(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, deadline, d.Fetch)
+	assert.Equal(t, deadline, d.Render)
+}
+
+func TestUnbudgeted_NoDeadline(t *testing.T) {
+	d, err := Unbudgeted(context.Background())
+	require.NoError(t, err)
+	assert.True(t, d.Fetch.IsZero(), "budget must have no effect")
+	assert.True(t, d.Render.IsZero())
+}
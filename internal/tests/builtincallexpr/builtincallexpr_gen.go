@@ -29,6 +29,7 @@ func Flow(s string, buf io.Writer) {
 		ctx := _18_18
 		var v1 string = _19_14
 		emitter := cff.NopEmitter()
+		interceptor := cff.InterceptorStack()
 
 		var (
 			flowInfo = &cff.FlowInfo{
@@ -66,11 +67,13 @@ func Flow(s string, buf io.Writer) {
 			ran     cff.AtomicBool
 			run     func(context.Context) error
 			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
 		}
 		defer func() {
 			for _, t := range tasks {
 				if !t.ran.Load() {
-					t.emitter.TaskSkipped(ctx, err)
+					t.emitter.TaskSkipped(ctx, t.outcome.SkipReason(ctx, err))
 				}
 			}
 		}()
@@ -79,17 +82,26 @@ func Flow(s string, buf io.Writer) {
 		var (
 			v2 int
 		)
+
 		task0 := new(struct {
 			emitter cff.TaskEmitter
 			ran     cff.AtomicBool
 			run     func(context.Context) error
 			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
 		})
+		task0.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/builtincallexpr/builtincallexpr.go",
+			Line:   21,
+			Column: 12,
+		}
 		task0.emitter = cff.NopTaskEmitter()
 		task0.run = func(ctx context.Context) (err error) {
 			taskEmitter := task0.emitter
 			startTime := time.Now()
 			defer func() {
+				task0.outcome.Finish(err)
 				if task0.ran.Load() {
 					taskEmitter.TaskDone(ctx, time.Since(startTime))
 				}
@@ -108,8 +120,10 @@ func Flow(s string, buf io.Writer) {
 
 			defer task0.ran.Store(true)
 
-			v2, err = _21_12(v1)
-
+			err = cff.Intercept(ctx, interceptor, task0.outcome.Info, func(ctx context.Context) (err error) {
+				v2, err = _21_12(v1)
+				return
+			})
 			if err != nil {
 				taskEmitter.TaskError(ctx, err)
 				return err
@@ -127,6 +141,7 @@ func Flow(s string, buf io.Writer) {
 
 		if err := sched.Wait(ctx); err != nil {
 			flowEmitter.FlowError(ctx, err)
+			cff.RethrowPanic(err, false)
 			return err
 		}
 
@@ -0,0 +1,51 @@
+//go:build cff
+// +build cff
+
+// Package bundle tests flows that share tasks with cff.Bundle.
+package bundle
+
+import (
+	"context"
+
+	"go.uber.org/cff"
+)
+
+// Request is the input to the flows in this package.
+type Request struct {
+	UserName string
+	OrgName  string
+}
+
+// User is loaded by the bundle.
+type User struct{ Name string }
+
+// Org is loaded by the bundle.
+type Org struct{ Name string }
+
+// Permissions are computed by the bundle from a user and an org.
+type Permissions struct{ Admin bool }
+
+// Loads records the loaders that ran.
+type Loads struct{ Names []string }
+
+// Greeting is produced by a task of the bundle that is used only by some
+// flows.
+type Greeting string
+
+// Defaults is provided to the bundle with cff.Params.
+type Defaults struct{ Org string }
+
+// Permission uses all tasks of the bundle that lead to Permissions.
+func Permission(ctx context.Context, req *Request) (*Permissions, *Loads, error) {
+	var (
+		loads Loads
+		perms *Permissions
+	)
+	err := cff.Flow(ctx,
+		cff.Concurrency(1),
+		cff.Params(req, &loads),
+		cff.Results(&perms),
+		cff.Use(_users),
+	)
+	return perms, &loads, err
+}
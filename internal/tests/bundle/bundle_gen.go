@@ -0,0 +1,344 @@
+//go:build !cff
+// +build !cff
+
+// Package bundle tests flows that share tasks with cff.Bundle.
+package bundle
+
+import (
+	"context"
+	"runtime/debug"
+	"strings"
+	"time"
+
+	"go.uber.org/cff"
+)
+
+// Request is the input to the flows in this package.
+type Request struct {
+	UserName string
+	OrgName  string
+}
+
+// User is loaded by the bundle.
+type User struct{ Name string }
+
+// Org is loaded by the bundle.
+type Org struct{ Name string }
+
+// Permissions are computed by the bundle from a user and an org.
+type Permissions struct{ Admin bool }
+
+// Loads records the loaders that ran.
+type Loads struct{ Names []string }
+
+// Greeting is produced by a task of the bundle that is used only by some
+// flows.
+type Greeting string
+
+// Defaults is provided to the bundle with cff.Params.
+type Defaults struct{ Org string }
+
+// Permission uses all tasks of the bundle that lead to Permissions.
+func Permission(ctx context.Context, req *Request) (*Permissions, *Loads, error) {
+	var (
+		loads Loads
+		perms *Permissions
+	)
+	err := func() (err error) {
+
+		_users_16_13 := _defaults
+
+		_users_17_11 := func(req *Request, loads *Loads) *User {
+			loads.Names = append(loads.Names, "user")
+			return &User{Name: strings.ToLower(req.UserName)}
+		}
+
+		_users_21_11 := func(req *Request, d Defaults, loads *Loads) *Org {
+			loads.Names = append(loads.Names, "org")
+			if req.OrgName == "" {
+				return &Org{Name: d.Org}
+			}
+			return &Org{Name: req.OrgName}
+		}
+
+		_users_28_11 := func(u *User, o *Org, loads *Loads) *Permissions {
+			loads.Names = append(loads.Names, "permissions")
+			return &Permissions{Admin: u.Name == "admin" && o.Name == "uber"}
+		}
+
+		_44_18 := ctx
+
+		_45_19 := 1
+
+		_46_14 := req
+
+		_46_19 := &loads
+
+		_47_15 := &perms
+		ctx := _44_18
+		var v1 *Request = _46_14
+		var v2 *Loads = _46_19
+		var v3 Defaults = _users_16_13
+		emitter := cff.NopEmitter()
+		interceptor := cff.InterceptorStack()
+
+		var (
+			flowInfo = &cff.FlowInfo{
+				File:   "go.uber.org/cff/internal/tests/bundle/bundle.go",
+				Line:   44,
+				Column: 9,
+			}
+			flowEmitter = cff.NopFlowEmitter()
+
+			schedInfo = &cff.SchedulerInfo{
+				Name:      flowInfo.Name,
+				Directive: cff.FlowDirective,
+				File:      flowInfo.File,
+				Line:      flowInfo.Line,
+				Column:    flowInfo.Column,
+			}
+
+			// possibly unused
+			_ = flowInfo
+		)
+
+		startTime := time.Now()
+		defer func() { flowEmitter.FlowDone(ctx, time.Since(startTime)) }()
+
+		schedEmitter := emitter.SchedulerInit(schedInfo)
+
+		sched := cff.NewScheduler(
+			cff.SchedulerParams{
+				Concurrency: _45_19, Emitter: schedEmitter,
+			},
+		)
+
+		var tasks []*struct {
+			emitter cff.TaskEmitter
+			ran     cff.AtomicBool
+			run     func(context.Context) error
+			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		}
+		defer func() {
+			for _, t := range tasks {
+				if !t.ran.Load() {
+					t.emitter.TaskSkipped(ctx, t.outcome.SkipReason(ctx, err))
+				}
+			}
+		}()
+
+		// go.uber.org/cff/internal/tests/bundle/users.go:17:11
+		var (
+			v4 *User
+		)
+		// Outputs of tasks added with cff.Use or of lazy flows
+		// may be unused.
+		_ = v4
+
+		task0 := new(struct {
+			emitter cff.TaskEmitter
+			ran     cff.AtomicBool
+			run     func(context.Context) error
+			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		})
+		task0.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/bundle/users.go",
+			Line:   17,
+			Column: 11,
+		}
+		task0.emitter = cff.NopTaskEmitter()
+		task0.run = func(ctx context.Context) (err error) {
+			taskEmitter := task0.emitter
+			startTime := time.Now()
+			defer func() {
+				task0.outcome.Finish(err)
+				if task0.ran.Load() {
+					taskEmitter.TaskDone(ctx, time.Since(startTime))
+				}
+			}()
+
+			defer func() {
+				recovered := recover()
+				if recovered != nil {
+					taskEmitter.TaskPanic(ctx, recovered)
+					err = &cff.PanicError{
+						Value:      recovered,
+						Stacktrace: debug.Stack(),
+					}
+				}
+			}()
+
+			defer task0.ran.Store(true)
+
+			err = cff.Intercept(ctx, interceptor, task0.outcome.Info, func(ctx context.Context) (err error) {
+				v4 = _users_17_11(v1, v2)
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return err
+			} else {
+				taskEmitter.TaskSuccess(ctx)
+			}
+
+			return
+		}
+
+		task0.job = sched.Enqueue(ctx, cff.Job{
+			Run: task0.run,
+		})
+		tasks = append(tasks, task0)
+
+		// go.uber.org/cff/internal/tests/bundle/users.go:21:11
+		var (
+			v5 *Org
+		)
+		// Outputs of tasks added with cff.Use or of lazy flows
+		// may be unused.
+		_ = v5
+
+		task1 := new(struct {
+			emitter cff.TaskEmitter
+			ran     cff.AtomicBool
+			run     func(context.Context) error
+			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		})
+		task1.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/bundle/users.go",
+			Line:   21,
+			Column: 11,
+		}
+		task1.emitter = cff.NopTaskEmitter()
+		task1.run = func(ctx context.Context) (err error) {
+			taskEmitter := task1.emitter
+			startTime := time.Now()
+			defer func() {
+				task1.outcome.Finish(err)
+				if task1.ran.Load() {
+					taskEmitter.TaskDone(ctx, time.Since(startTime))
+				}
+			}()
+
+			defer func() {
+				recovered := recover()
+				if recovered != nil {
+					taskEmitter.TaskPanic(ctx, recovered)
+					err = &cff.PanicError{
+						Value:      recovered,
+						Stacktrace: debug.Stack(),
+					}
+				}
+			}()
+
+			defer task1.ran.Store(true)
+
+			err = cff.Intercept(ctx, interceptor, task1.outcome.Info, func(ctx context.Context) (err error) {
+				v5 = _users_21_11(v1, v3, v2)
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return err
+			} else {
+				taskEmitter.TaskSuccess(ctx)
+			}
+
+			return
+		}
+
+		task1.job = sched.Enqueue(ctx, cff.Job{
+			Run: task1.run,
+		})
+		tasks = append(tasks, task1)
+
+		// go.uber.org/cff/internal/tests/bundle/users.go:28:11
+		var (
+			v6 *Permissions
+		)
+		// Outputs of tasks added with cff.Use or of lazy flows
+		// may be unused.
+		_ = v6
+
+		task2 := new(struct {
+			emitter cff.TaskEmitter
+			ran     cff.AtomicBool
+			run     func(context.Context) error
+			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		})
+		task2.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/bundle/users.go",
+			Line:   28,
+			Column: 11,
+		}
+		task2.outcome.Dependencies = []*cff.TaskOutcome{
+			&task0.outcome,
+			&task1.outcome,
+		}
+		task2.emitter = cff.NopTaskEmitter()
+		task2.run = func(ctx context.Context) (err error) {
+			taskEmitter := task2.emitter
+			startTime := time.Now()
+			defer func() {
+				task2.outcome.Finish(err)
+				if task2.ran.Load() {
+					taskEmitter.TaskDone(ctx, time.Since(startTime))
+				}
+			}()
+
+			defer func() {
+				recovered := recover()
+				if recovered != nil {
+					taskEmitter.TaskPanic(ctx, recovered)
+					err = &cff.PanicError{
+						Value:      recovered,
+						Stacktrace: debug.Stack(),
+					}
+				}
+			}()
+
+			defer task2.ran.Store(true)
+
+			err = cff.Intercept(ctx, interceptor, task2.outcome.Info, func(ctx context.Context) (err error) {
+				v6 = _users_28_11(v4, v5, v2)
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return err
+			} else {
+				taskEmitter.TaskSuccess(ctx)
+			}
+
+			return
+		}
+
+		task2.job = sched.Enqueue(ctx, cff.Job{
+			Run: task2.run,
+			Dependencies: []*cff.ScheduledJob{
+				task0.job,
+				task1.job,
+			},
+		})
+		tasks = append(tasks, task2)
+
+		if err := sched.Wait(ctx); err != nil {
+			flowEmitter.FlowError(ctx, err)
+			cff.RethrowPanic(err, false)
+			return err
+		}
+
+		*(_47_15) = v6 // *go.uber.org/cff/internal/tests/bundle.Permissions
+
+		flowEmitter.FlowSuccess(ctx)
+		return nil
+	}()
+	return perms, &loads, err
+}
@@ -0,0 +1,31 @@
+package bundle
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPermission(t *testing.T) {
+	perms, loads, err := Permission(context.Background(), &Request{UserName: "Admin"})
+	require.NoError(t, err)
+	assert.Equal(t, &Permissions{Admin: true}, perms)
+	assert.ElementsMatch(t, []string{"user", "org", "permissions"}, loads.Names,
+		"greeting is not needed and should not run")
+}
+
+func TestGreet(t *testing.T) {
+	g, loads, err := Greet(context.Background(), &Request{UserName: "Foo"})
+	require.NoError(t, err)
+	assert.Equal(t, Greeting("hello foo"), g)
+	assert.Equal(t, []string{"user", "greeting"}, loads.Names,
+		"only the tasks needed for the greeting should run")
+}
+
+func TestShout(t *testing.T) {
+	s, err := Shout(context.Background(), &Request{UserName: "Foo", OrgName: "bar"})
+	require.NoError(t, err)
+	assert.Equal(t, "hello foo from bar!", s)
+}
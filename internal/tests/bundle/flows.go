@@ -0,0 +1,43 @@
+//go:build cff
+// +build cff
+
+package bundle
+
+import (
+	"context"
+
+	"go.uber.org/cff"
+)
+
+// Greet uses only the tasks of a bundle declared in another file that lead
+// to a Greeting.
+func Greet(ctx context.Context, req *Request) (Greeting, *Loads, error) {
+	var (
+		loads Loads
+		g     Greeting
+	)
+	err := cff.Flow(ctx,
+		cff.Params(req, &loads),
+		cff.Results(&g),
+		cff.Use(_users),
+	)
+	return g, &loads, err
+}
+
+// Shout combines a bundle with tasks of its own.
+func Shout(ctx context.Context, req *Request) (string, error) {
+	var (
+		loads Loads
+		s     string
+	)
+	err := cff.Flow(ctx,
+		cff.Concurrency(1),
+		cff.Params(req, &loads),
+		cff.Results(&s),
+		cff.Use(_users),
+		cff.Task(func(g Greeting, o *Org) string {
+			return string(g) + " from " + o.Name + "!"
+		}),
+	)
+	return s, err
+}
@@ -0,0 +1,616 @@
+//go:build !cff
+// +build !cff
+
+package bundle
+
+import (
+	"context"
+	"runtime/debug"
+	"strings"
+	"time"
+
+	"go.uber.org/cff"
+)
+
+// Greet uses only the tasks of a bundle declared in another file that lead
+// to a Greeting.
+func Greet(ctx context.Context, req *Request) (Greeting, *Loads, error) {
+	var (
+		loads Loads
+		g     Greeting
+	)
+	err := func() (err error) {
+
+		_users_17_11 := func(req *Request, loads *Loads) *User {
+			loads.Names = append(loads.Names, "user")
+			return &User{Name: strings.ToLower(req.UserName)}
+		}
+
+		_users_32_11 := func(u *User, loads *Loads) Greeting {
+			loads.Names = append(loads.Names, "greeting")
+			return Greeting("hello " + u.Name)
+		}
+
+		_19_18 := ctx
+
+		_20_14 := req
+
+		_20_19 := &loads
+
+		_21_15 := &g
+		ctx := _19_18
+		var v1 *Request = _20_14
+		var v2 *Loads = _20_19
+		emitter := cff.NopEmitter()
+		interceptor := cff.InterceptorStack()
+
+		var (
+			flowInfo = &cff.FlowInfo{
+				File:   "go.uber.org/cff/internal/tests/bundle/flows.go",
+				Line:   19,
+				Column: 9,
+			}
+			flowEmitter = cff.NopFlowEmitter()
+
+			schedInfo = &cff.SchedulerInfo{
+				Name:      flowInfo.Name,
+				Directive: cff.FlowDirective,
+				File:      flowInfo.File,
+				Line:      flowInfo.Line,
+				Column:    flowInfo.Column,
+			}
+
+			// possibly unused
+			_ = flowInfo
+		)
+
+		startTime := time.Now()
+		defer func() { flowEmitter.FlowDone(ctx, time.Since(startTime)) }()
+
+		schedEmitter := emitter.SchedulerInit(schedInfo)
+
+		sched := cff.NewScheduler(
+			cff.SchedulerParams{
+				Emitter: schedEmitter,
+			},
+		)
+
+		var tasks []*struct {
+			emitter cff.TaskEmitter
+			ran     cff.AtomicBool
+			run     func(context.Context) error
+			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		}
+		defer func() {
+			for _, t := range tasks {
+				if !t.ran.Load() {
+					t.emitter.TaskSkipped(ctx, t.outcome.SkipReason(ctx, err))
+				}
+			}
+		}()
+
+		// go.uber.org/cff/internal/tests/bundle/users.go:17:11
+		var (
+			v3 *User
+		)
+		// Outputs of tasks added with cff.Use or of lazy flows
+		// may be unused.
+		_ = v3
+
+		task0 := new(struct {
+			emitter cff.TaskEmitter
+			ran     cff.AtomicBool
+			run     func(context.Context) error
+			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		})
+		task0.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/bundle/users.go",
+			Line:   17,
+			Column: 11,
+		}
+		task0.emitter = cff.NopTaskEmitter()
+		task0.run = func(ctx context.Context) (err error) {
+			taskEmitter := task0.emitter
+			startTime := time.Now()
+			defer func() {
+				task0.outcome.Finish(err)
+				if task0.ran.Load() {
+					taskEmitter.TaskDone(ctx, time.Since(startTime))
+				}
+			}()
+
+			defer func() {
+				recovered := recover()
+				if recovered != nil {
+					taskEmitter.TaskPanic(ctx, recovered)
+					err = &cff.PanicError{
+						Value:      recovered,
+						Stacktrace: debug.Stack(),
+					}
+				}
+			}()
+
+			defer task0.ran.Store(true)
+
+			err = cff.Intercept(ctx, interceptor, task0.outcome.Info, func(ctx context.Context) (err error) {
+				v3 = _users_17_11(v1, v2)
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return err
+			} else {
+				taskEmitter.TaskSuccess(ctx)
+			}
+
+			return
+		}
+
+		task0.job = sched.Enqueue(ctx, cff.Job{
+			Run: task0.run,
+		})
+		tasks = append(tasks, task0)
+
+		// go.uber.org/cff/internal/tests/bundle/users.go:32:11
+		var (
+			v4 Greeting
+		)
+		// Outputs of tasks added with cff.Use or of lazy flows
+		// may be unused.
+		_ = v4
+
+		task3 := new(struct {
+			emitter cff.TaskEmitter
+			ran     cff.AtomicBool
+			run     func(context.Context) error
+			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		})
+		task3.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/bundle/users.go",
+			Line:   32,
+			Column: 11,
+		}
+		task3.outcome.Dependencies = []*cff.TaskOutcome{
+			&task0.outcome,
+		}
+		task3.emitter = cff.NopTaskEmitter()
+		task3.run = func(ctx context.Context) (err error) {
+			taskEmitter := task3.emitter
+			startTime := time.Now()
+			defer func() {
+				task3.outcome.Finish(err)
+				if task3.ran.Load() {
+					taskEmitter.TaskDone(ctx, time.Since(startTime))
+				}
+			}()
+
+			defer func() {
+				recovered := recover()
+				if recovered != nil {
+					taskEmitter.TaskPanic(ctx, recovered)
+					err = &cff.PanicError{
+						Value:      recovered,
+						Stacktrace: debug.Stack(),
+					}
+				}
+			}()
+
+			defer task3.ran.Store(true)
+
+			err = cff.Intercept(ctx, interceptor, task3.outcome.Info, func(ctx context.Context) (err error) {
+				v4 = _users_32_11(v3, v2)
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return err
+			} else {
+				taskEmitter.TaskSuccess(ctx)
+			}
+
+			return
+		}
+
+		task3.job = sched.Enqueue(ctx, cff.Job{
+			Run: task3.run,
+			Dependencies: []*cff.ScheduledJob{
+				task0.job,
+			},
+		})
+		tasks = append(tasks, task3)
+
+		if err := sched.Wait(ctx); err != nil {
+			flowEmitter.FlowError(ctx, err)
+			cff.RethrowPanic(err, false)
+			return err
+		}
+
+		*(_21_15) = v4 // go.uber.org/cff/internal/tests/bundle.Greeting
+
+		flowEmitter.FlowSuccess(ctx)
+		return nil
+	}()
+	return g, &loads, err
+}
+
+// Shout combines a bundle with tasks of its own.
+func Shout(ctx context.Context, req *Request) (string, error) {
+	var (
+		loads Loads
+		s     string
+	)
+	err := func() (err error) {
+
+		_users_16_13 := _defaults
+
+		_users_17_11 := func(req *Request, loads *Loads) *User {
+			loads.Names = append(loads.Names, "user")
+			return &User{Name: strings.ToLower(req.UserName)}
+		}
+
+		_users_21_11 := func(req *Request, d Defaults, loads *Loads) *Org {
+			loads.Names = append(loads.Names, "org")
+			if req.OrgName == "" {
+				return &Org{Name: d.Org}
+			}
+			return &Org{Name: req.OrgName}
+		}
+
+		_users_32_11 := func(u *User, loads *Loads) Greeting {
+			loads.Names = append(loads.Names, "greeting")
+			return Greeting("hello " + u.Name)
+		}
+
+		_33_18 := ctx
+
+		_34_19 := 1
+
+		_35_14 := req
+
+		_35_19 := &loads
+
+		_36_15 := &s
+
+		_38_12 := func(g Greeting, o *Org) string {
+			return string(g) + " from " + o.Name + "!"
+		}
+		ctx := _33_18
+		var v1 *Request = _35_14
+		var v2 *Loads = _35_19
+		var v5 Defaults = _users_16_13
+		emitter := cff.NopEmitter()
+		interceptor := cff.InterceptorStack()
+
+		var (
+			flowInfo = &cff.FlowInfo{
+				File:   "go.uber.org/cff/internal/tests/bundle/flows.go",
+				Line:   33,
+				Column: 9,
+			}
+			flowEmitter = cff.NopFlowEmitter()
+
+			schedInfo = &cff.SchedulerInfo{
+				Name:      flowInfo.Name,
+				Directive: cff.FlowDirective,
+				File:      flowInfo.File,
+				Line:      flowInfo.Line,
+				Column:    flowInfo.Column,
+			}
+
+			// possibly unused
+			_ = flowInfo
+		)
+
+		startTime := time.Now()
+		defer func() { flowEmitter.FlowDone(ctx, time.Since(startTime)) }()
+
+		schedEmitter := emitter.SchedulerInit(schedInfo)
+
+		sched := cff.NewScheduler(
+			cff.SchedulerParams{
+				Concurrency: _34_19, Emitter: schedEmitter,
+			},
+		)
+
+		var tasks []*struct {
+			emitter cff.TaskEmitter
+			ran     cff.AtomicBool
+			run     func(context.Context) error
+			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		}
+		defer func() {
+			for _, t := range tasks {
+				if !t.ran.Load() {
+					t.emitter.TaskSkipped(ctx, t.outcome.SkipReason(ctx, err))
+				}
+			}
+		}()
+
+		// go.uber.org/cff/internal/tests/bundle/users.go:17:11
+		var (
+			v3 *User
+		)
+		// Outputs of tasks added with cff.Use or of lazy flows
+		// may be unused.
+		_ = v3
+
+		task4 := new(struct {
+			emitter cff.TaskEmitter
+			ran     cff.AtomicBool
+			run     func(context.Context) error
+			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		})
+		task4.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/bundle/users.go",
+			Line:   17,
+			Column: 11,
+		}
+		task4.emitter = cff.NopTaskEmitter()
+		task4.run = func(ctx context.Context) (err error) {
+			taskEmitter := task4.emitter
+			startTime := time.Now()
+			defer func() {
+				task4.outcome.Finish(err)
+				if task4.ran.Load() {
+					taskEmitter.TaskDone(ctx, time.Since(startTime))
+				}
+			}()
+
+			defer func() {
+				recovered := recover()
+				if recovered != nil {
+					taskEmitter.TaskPanic(ctx, recovered)
+					err = &cff.PanicError{
+						Value:      recovered,
+						Stacktrace: debug.Stack(),
+					}
+				}
+			}()
+
+			defer task4.ran.Store(true)
+
+			err = cff.Intercept(ctx, interceptor, task4.outcome.Info, func(ctx context.Context) (err error) {
+				v3 = _users_17_11(v1, v2)
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return err
+			} else {
+				taskEmitter.TaskSuccess(ctx)
+			}
+
+			return
+		}
+
+		task4.job = sched.Enqueue(ctx, cff.Job{
+			Run: task4.run,
+		})
+		tasks = append(tasks, task4)
+
+		// go.uber.org/cff/internal/tests/bundle/users.go:21:11
+		var (
+			v6 *Org
+		)
+		// Outputs of tasks added with cff.Use or of lazy flows
+		// may be unused.
+		_ = v6
+
+		task5 := new(struct {
+			emitter cff.TaskEmitter
+			ran     cff.AtomicBool
+			run     func(context.Context) error
+			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		})
+		task5.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/bundle/users.go",
+			Line:   21,
+			Column: 11,
+		}
+		task5.emitter = cff.NopTaskEmitter()
+		task5.run = func(ctx context.Context) (err error) {
+			taskEmitter := task5.emitter
+			startTime := time.Now()
+			defer func() {
+				task5.outcome.Finish(err)
+				if task5.ran.Load() {
+					taskEmitter.TaskDone(ctx, time.Since(startTime))
+				}
+			}()
+
+			defer func() {
+				recovered := recover()
+				if recovered != nil {
+					taskEmitter.TaskPanic(ctx, recovered)
+					err = &cff.PanicError{
+						Value:      recovered,
+						Stacktrace: debug.Stack(),
+					}
+				}
+			}()
+
+			defer task5.ran.Store(true)
+
+			err = cff.Intercept(ctx, interceptor, task5.outcome.Info, func(ctx context.Context) (err error) {
+				v6 = _users_21_11(v1, v5, v2)
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return err
+			} else {
+				taskEmitter.TaskSuccess(ctx)
+			}
+
+			return
+		}
+
+		task5.job = sched.Enqueue(ctx, cff.Job{
+			Run: task5.run,
+		})
+		tasks = append(tasks, task5)
+
+		// go.uber.org/cff/internal/tests/bundle/users.go:32:11
+		var (
+			v4 Greeting
+		)
+		// Outputs of tasks added with cff.Use or of lazy flows
+		// may be unused.
+		_ = v4
+
+		task7 := new(struct {
+			emitter cff.TaskEmitter
+			ran     cff.AtomicBool
+			run     func(context.Context) error
+			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		})
+		task7.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/bundle/users.go",
+			Line:   32,
+			Column: 11,
+		}
+		task7.outcome.Dependencies = []*cff.TaskOutcome{
+			&task4.outcome,
+		}
+		task7.emitter = cff.NopTaskEmitter()
+		task7.run = func(ctx context.Context) (err error) {
+			taskEmitter := task7.emitter
+			startTime := time.Now()
+			defer func() {
+				task7.outcome.Finish(err)
+				if task7.ran.Load() {
+					taskEmitter.TaskDone(ctx, time.Since(startTime))
+				}
+			}()
+
+			defer func() {
+				recovered := recover()
+				if recovered != nil {
+					taskEmitter.TaskPanic(ctx, recovered)
+					err = &cff.PanicError{
+						Value:      recovered,
+						Stacktrace: debug.Stack(),
+					}
+				}
+			}()
+
+			defer task7.ran.Store(true)
+
+			err = cff.Intercept(ctx, interceptor, task7.outcome.Info, func(ctx context.Context) (err error) {
+				v4 = _users_32_11(v3, v2)
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return err
+			} else {
+				taskEmitter.TaskSuccess(ctx)
+			}
+
+			return
+		}
+
+		task7.job = sched.Enqueue(ctx, cff.Job{
+			Run: task7.run,
+			Dependencies: []*cff.ScheduledJob{
+				task4.job,
+			},
+		})
+		tasks = append(tasks, task7)
+
+		// go.uber.org/cff/internal/tests/bundle/flows.go:38:12
+		var (
+			v7 string
+		)
+
+		task8 := new(struct {
+			emitter cff.TaskEmitter
+			ran     cff.AtomicBool
+			run     func(context.Context) error
+			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		})
+		task8.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/bundle/flows.go",
+			Line:   38,
+			Column: 12,
+		}
+		task8.outcome.Dependencies = []*cff.TaskOutcome{
+			&task7.outcome,
+			&task5.outcome,
+		}
+		task8.emitter = cff.NopTaskEmitter()
+		task8.run = func(ctx context.Context) (err error) {
+			taskEmitter := task8.emitter
+			startTime := time.Now()
+			defer func() {
+				task8.outcome.Finish(err)
+				if task8.ran.Load() {
+					taskEmitter.TaskDone(ctx, time.Since(startTime))
+				}
+			}()
+
+			defer func() {
+				recovered := recover()
+				if recovered != nil {
+					taskEmitter.TaskPanic(ctx, recovered)
+					err = &cff.PanicError{
+						Value:      recovered,
+						Stacktrace: debug.Stack(),
+					}
+				}
+			}()
+
+			defer task8.ran.Store(true)
+
+			err = cff.Intercept(ctx, interceptor, task8.outcome.Info, func(ctx context.Context) (err error) {
+				v7 = _38_12(v4, v6)
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return err
+			} else {
+				taskEmitter.TaskSuccess(ctx)
+			}
+
+			return
+		}
+
+		task8.job = sched.Enqueue(ctx, cff.Job{
+			Run: task8.run,
+			Dependencies: []*cff.ScheduledJob{
+				task7.job,
+				task5.job,
+			},
+		})
+		tasks = append(tasks, task8)
+
+		if err := sched.Wait(ctx); err != nil {
+			flowEmitter.FlowError(ctx, err)
+			cff.RethrowPanic(err, false)
+			return err
+		}
+
+		*(_36_15) = v7 // string
+
+		flowEmitter.FlowSuccess(ctx)
+		return nil
+	}()
+	return s, err
+}
@@ -0,0 +1,36 @@
+//go:build cff
+// +build cff
+
+package bundle
+
+import (
+	"strings"
+
+	"go.uber.org/cff"
+)
+
+var _defaults = Defaults{Org: "uber"}
+
+// _users loads users, orgs, and permissions.
+var _users = cff.Bundle(
+	cff.Params(_defaults),
+	cff.Task(func(req *Request, loads *Loads) *User {
+		loads.Names = append(loads.Names, "user")
+		return &User{Name: strings.ToLower(req.UserName)}
+	}),
+	cff.Task(func(req *Request, d Defaults, loads *Loads) *Org {
+		loads.Names = append(loads.Names, "org")
+		if req.OrgName == "" {
+			return &Org{Name: d.Org}
+		}
+		return &Org{Name: req.OrgName}
+	}),
+	cff.Task(func(u *User, o *Org, loads *Loads) *Permissions {
+		loads.Names = append(loads.Names, "permissions")
+		return &Permissions{Admin: u.Name == "admin" && o.Name == "uber"}
+	}),
+	cff.Task(func(u *User, loads *Loads) Greeting {
+		loads.Names = append(loads.Names, "greeting")
+		return Greeting("hello " + u.Name)
+	}),
+)
@@ -0,0 +1,13 @@
+//go:build !cff
+// +build !cff
+
+package bundle
+
+import (
+	"go.uber.org/cff"
+)
+
+var _defaults = Defaults{Org: "uber"}
+
+// _users loads users, orgs, and permissions.
+var _users = cff.TaskBundle{}
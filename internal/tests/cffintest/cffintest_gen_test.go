@@ -42,6 +42,7 @@ func TestIsOdd(t *testing.T) {
 		}
 		ctx := _17_3
 		emitter := cff.NopEmitter()
+		interceptor := cff.InterceptorStack()
 
 		var (
 			parallelInfo = &cff.ParallelInfo{
@@ -86,11 +87,15 @@ func TestIsOdd(t *testing.T) {
 			emitter cff.TaskEmitter
 			fn      func(context.Context) error
 			ran     cff.AtomicBool
+
+			outcome cff.TaskOutcome // reports why the task was skipped
 		}
 		defer func() {
 			for _, t := range tasks {
-				if !t.ran.Load() {
-					t.emitter.TaskSkipped(ctx, err)
+				// Tasks that are still running report their own outcome
+				// once they finish.
+				if !t.ran.Load() && !t.outcome.Running() {
+					t.emitter.TaskSkipped(ctx, t.outcome.SkipReason(ctx, err))
 				}
 			}
 		}()
@@ -100,12 +105,21 @@ func TestIsOdd(t *testing.T) {
 			emitter cff.TaskEmitter
 			fn      func(context.Context) error
 			ran     cff.AtomicBool
+
+			outcome cff.TaskOutcome // reports why the task was skipped
 		})
+		task0.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/cffintest/cffintest_test.go",
+			Line:   20,
+			Column: 4,
+		}
 		task0.emitter = cff.NopTaskEmitter()
 		task0.fn = func(ctx context.Context) (err error) {
+			task0.outcome.Start()
 			taskEmitter := task0.emitter
 			startTime := time.Now()
 			defer func() {
+				task0.outcome.Finish(err)
 				if task0.ran.Load() {
 					taskEmitter.TaskDone(ctx, time.Since(startTime))
 				}
@@ -124,8 +138,10 @@ func TestIsOdd(t *testing.T) {
 
 			defer task0.ran.Store(true)
 
-			err = _20_4()
-
+			err = cff.Intercept(ctx, interceptor, task0.outcome.Info, func(ctx context.Context) (err error) {
+				err = _20_4()
+				return
+			})
 			if err != nil {
 				taskEmitter.TaskError(ctx, err)
 				return
@@ -144,12 +160,21 @@ func TestIsOdd(t *testing.T) {
 			emitter cff.TaskEmitter
 			fn      func(context.Context) error
 			ran     cff.AtomicBool
+
+			outcome cff.TaskOutcome // reports why the task was skipped
 		})
+		task1.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/cffintest/cffintest_test.go",
+			Line:   24,
+			Column: 4,
+		}
 		task1.emitter = cff.NopTaskEmitter()
 		task1.fn = func(ctx context.Context) (err error) {
+			task1.outcome.Start()
 			taskEmitter := task1.emitter
 			startTime := time.Now()
 			defer func() {
+				task1.outcome.Finish(err)
 				if task1.ran.Load() {
 					taskEmitter.TaskDone(ctx, time.Since(startTime))
 				}
@@ -168,8 +193,10 @@ func TestIsOdd(t *testing.T) {
 
 			defer task1.ran.Store(true)
 
-			err = _24_4()
-
+			err = cff.Intercept(ctx, interceptor, task1.outcome.Info, func(ctx context.Context) (err error) {
+				err = _24_4()
+				return
+			})
 			if err != nil {
 				taskEmitter.TaskError(ctx, err)
 				return
@@ -188,12 +215,21 @@ func TestIsOdd(t *testing.T) {
 			emitter cff.TaskEmitter
 			fn      func(context.Context) error
 			ran     cff.AtomicBool
+
+			outcome cff.TaskOutcome // reports why the task was skipped
 		})
+		task2.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/cffintest/cffintest_test.go",
+			Line:   28,
+			Column: 4,
+		}
 		task2.emitter = cff.NopTaskEmitter()
 		task2.fn = func(ctx context.Context) (err error) {
+			task2.outcome.Start()
 			taskEmitter := task2.emitter
 			startTime := time.Now()
 			defer func() {
+				task2.outcome.Finish(err)
 				if task2.ran.Load() {
 					taskEmitter.TaskDone(ctx, time.Since(startTime))
 				}
@@ -212,8 +248,10 @@ func TestIsOdd(t *testing.T) {
 
 			defer task2.ran.Store(true)
 
-			err = _28_4()
-
+			err = cff.Intercept(ctx, interceptor, task2.outcome.Info, func(ctx context.Context) (err error) {
+				err = _28_4()
+				return
+			})
 			if err != nil {
 				taskEmitter.TaskError(ctx, err)
 				return
@@ -232,12 +270,21 @@ func TestIsOdd(t *testing.T) {
 			emitter cff.TaskEmitter
 			fn      func(context.Context) error
 			ran     cff.AtomicBool
+
+			outcome cff.TaskOutcome // reports why the task was skipped
 		})
+		task3.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/cffintest/cffintest_test.go",
+			Line:   32,
+			Column: 4,
+		}
 		task3.emitter = cff.NopTaskEmitter()
 		task3.fn = func(ctx context.Context) (err error) {
+			task3.outcome.Start()
 			taskEmitter := task3.emitter
 			startTime := time.Now()
 			defer func() {
+				task3.outcome.Finish(err)
 				if task3.ran.Load() {
 					taskEmitter.TaskDone(ctx, time.Since(startTime))
 				}
@@ -256,8 +303,10 @@ func TestIsOdd(t *testing.T) {
 
 			defer task3.ran.Store(true)
 
-			err = _32_4()
-
+			err = cff.Intercept(ctx, interceptor, task3.outcome.Info, func(ctx context.Context) (err error) {
+				err = _32_4()
+				return
+			})
 			if err != nil {
 				taskEmitter.TaskError(ctx, err)
 				return
@@ -273,6 +322,7 @@ func TestIsOdd(t *testing.T) {
 
 		if err := sched.Wait(ctx); err != nil {
 			parallelEmitter.ParallelError(ctx, err)
+			cff.RethrowPanic(err, false)
 			return err
 		}
 		parallelEmitter.ParallelSuccess(ctx)
@@ -0,0 +1,104 @@
+//go:build cff
+// +build cff
+
+// Package collect tests flows with tasks marked with cff.Collect.
+package collect
+
+import (
+	"context"
+	"errors"
+
+	"go.uber.org/cff"
+)
+
+// Request is the input to the flows in this package.
+type Request struct {
+	SkipAbuse bool
+	FailSpam  bool
+}
+
+// Signal is collected by the flows in this package.
+type Signal struct {
+	Name  string
+	Score int
+}
+
+// Report is the result of the flows in this package.
+type Report struct {
+	Signals []Signal
+	Total   int
+}
+
+// Score runs several checks and collects their signals into a report.
+func Score(ctx context.Context, req *Request) (*Report, error) {
+	var r *Report
+	err := cff.Flow(ctx,
+		cff.Params(req),
+		cff.Results(&r),
+		cff.Task(func() Signal {
+			return Signal{Name: "quota", Score: 1}
+		}, cff.Collect()),
+		cff.Task(
+			func() Signal {
+				return Signal{Name: "abuse", Score: 2}
+			},
+			cff.Collect(),
+			cff.Predicate(func(req *Request) bool { return !req.SkipAbuse }),
+		),
+		cff.Task(
+			func(req *Request) (Signal, error) {
+				if req.FailSpam {
+					return Signal{Name: "spam"}, errors.New("great sadness")
+				}
+				return Signal{Name: "spam", Score: 3}, nil
+			},
+			cff.Collect(),
+			cff.Optional(),
+		),
+		cff.Task(func(signals []Signal) *Report {
+			r := &Report{Signals: signals}
+			for _, s := range signals {
+				r.Total += s.Score
+			}
+			return r
+		}),
+	)
+	return r, err
+}
+
+// Tag is collected by the flows in this package.
+type Tag string
+
+// Multiple is a flow with tasks that produce multiple collected types,
+// and results that are collected directly.
+func Multiple(ctx context.Context) (signals []Signal, tags []Tag, err error) {
+	err = cff.Flow(ctx,
+		cff.Results(&signals, &tags),
+		cff.Task(func() (Signal, Tag) {
+			return Signal{Name: "foo"}, "foo"
+		}, cff.Collect()),
+		cff.Task(func() Tag {
+			return "bar"
+		}, cff.Collect()),
+		cff.Task(func() (Tag, Signal, error) {
+			return "baz", Signal{Name: "baz"}, nil
+		}, cff.Collect()),
+	)
+	return signals, tags, err
+}
+
+// Empty is a flow that collects values from tasks
+// that are all skipped.
+func Empty(ctx context.Context) ([]Signal, error) {
+	var signals []Signal
+	err := cff.Flow(ctx,
+		cff.Params(false),
+		cff.Results(&signals),
+		cff.Task(
+			func() Signal { return Signal{Name: "foo"} },
+			cff.Collect(),
+			cff.Predicate(func(b bool) bool { return b }),
+		),
+	)
+	return signals, err
+}
@@ -0,0 +1,1080 @@
+//go:build !cff
+// +build !cff
+
+// Package collect tests flows with tasks marked with cff.Collect.
+package collect
+
+import (
+	"context"
+	"errors"
+	"runtime/debug"
+	"time"
+
+	"go.uber.org/cff"
+)
+
+// Request is the input to the flows in this package.
+type Request struct {
+	SkipAbuse bool
+	FailSpam  bool
+}
+
+// Signal is collected by the flows in this package.
+type Signal struct {
+	Name  string
+	Score int
+}
+
+// Report is the result of the flows in this package.
+type Report struct {
+	Signals []Signal
+	Total   int
+}
+
+// Score runs several checks and collects their signals into a report.
+func Score(ctx context.Context, req *Request) (*Report, error) {
+	var r *Report
+	err := func() (err error) {
+
+		_35_18 := ctx
+
+		_36_14 := req
+
+		_37_15 := &r
+
+		_38_12 := func() Signal {
+			return Signal{Name: "quota", Score: 1}
+		}
+
+		_42_4 := func() Signal {
+			return Signal{Name: "abuse", Score: 2}
+		}
+
+		_46_18 := func(req *Request) bool { return !req.SkipAbuse }
+
+		_49_4 := func(req *Request) (Signal, error) {
+			if req.FailSpam {
+				return Signal{Name: "spam"}, errors.New("great sadness")
+			}
+			return Signal{Name: "spam", Score: 3}, nil
+		}
+
+		_58_12 := func(signals []Signal) *Report {
+			r := &Report{Signals: signals}
+			for _, s := range signals {
+				r.Total += s.Score
+			}
+			return r
+		}
+		ctx := _35_18
+		var v1 *Request = _36_14
+		emitter := cff.NopEmitter()
+		interceptor := cff.InterceptorStack()
+
+		var (
+			flowInfo = &cff.FlowInfo{
+				File:   "go.uber.org/cff/internal/tests/collect/collect.go",
+				Line:   35,
+				Column: 9,
+			}
+			flowEmitter = cff.NopFlowEmitter()
+
+			schedInfo = &cff.SchedulerInfo{
+				Name:      flowInfo.Name,
+				Directive: cff.FlowDirective,
+				File:      flowInfo.File,
+				Line:      flowInfo.Line,
+				Column:    flowInfo.Column,
+			}
+
+			// possibly unused
+			_ = flowInfo
+		)
+
+		startTime := time.Now()
+		defer func() { flowEmitter.FlowDone(ctx, time.Since(startTime)) }()
+
+		schedEmitter := emitter.SchedulerInit(schedInfo)
+
+		sched := cff.NewScheduler(
+			cff.SchedulerParams{
+				Emitter: schedEmitter,
+			},
+		)
+
+		var tasks []*struct {
+			emitter cff.TaskEmitter
+			ran     cff.AtomicBool
+			run     func(context.Context) error
+			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		}
+		defer func() {
+			for _, t := range tasks {
+				if !t.ran.Load() {
+					t.emitter.TaskSkipped(ctx, t.outcome.SkipReason(ctx, err))
+				}
+			}
+		}()
+
+		// go.uber.org/cff/internal/tests/collect/collect.go:38:12
+		var (
+			task0Collected0 Signal
+		)
+
+		var task0Produced bool
+		task0 := new(struct {
+			emitter cff.TaskEmitter
+			ran     cff.AtomicBool
+			run     func(context.Context) error
+			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		})
+		task0.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/collect/collect.go",
+			Line:   38,
+			Column: 12,
+		}
+		task0.emitter = cff.NopTaskEmitter()
+		task0.run = func(ctx context.Context) (err error) {
+			taskEmitter := task0.emitter
+			startTime := time.Now()
+			defer func() {
+				task0.outcome.Finish(err)
+				if task0.ran.Load() {
+					taskEmitter.TaskDone(ctx, time.Since(startTime))
+				}
+			}()
+
+			defer func() {
+				recovered := recover()
+				if recovered != nil {
+					taskEmitter.TaskPanic(ctx, recovered)
+					err = &cff.PanicError{
+						Value:      recovered,
+						Stacktrace: debug.Stack(),
+					}
+				}
+			}()
+
+			defer task0.ran.Store(true)
+
+			err = cff.Intercept(ctx, interceptor, task0.outcome.Info, func(ctx context.Context) (err error) {
+				task0Collected0 = _38_12()
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return err
+			} else {
+				taskEmitter.TaskSuccess(ctx)
+			}
+
+			task0Produced = true
+
+			return
+		}
+
+		task0.job = sched.Enqueue(ctx, cff.Job{
+			Run: task0.run,
+		})
+		tasks = append(tasks, task0)
+
+		// go.uber.org/cff/internal/tests/collect/collect.go:46:4
+		var p0 bool
+		var p0PanicRecover interface{}
+		var p0PanicStacktrace []byte
+		_ = p0PanicStacktrace // possibly unused.
+		pred1 := new(struct {
+			ran cff.AtomicBool
+			run func(context.Context) error
+			job *cff.ScheduledJob
+		})
+		pred1.run = func(ctx context.Context) (err error) {
+			defer func() {
+				if recovered := recover(); recovered != nil {
+					p0PanicRecover = recovered
+					p0PanicStacktrace = debug.Stack()
+				}
+			}()
+			p0 = _46_18(v1)
+			return nil
+		}
+
+		pred1.job = sched.Enqueue(ctx, cff.Job{
+			Run: pred1.run,
+		})
+
+		// go.uber.org/cff/internal/tests/collect/collect.go:42:4
+		var (
+			task1Collected0 Signal
+		)
+
+		var task1Produced bool
+		task1 := new(struct {
+			emitter cff.TaskEmitter
+			ran     cff.AtomicBool
+			run     func(context.Context) error
+			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		})
+		task1.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/collect/collect.go",
+			Line:   42,
+			Column: 4,
+		}
+		task1.emitter = cff.NopTaskEmitter()
+		task1.run = func(ctx context.Context) (err error) {
+			taskEmitter := task1.emitter
+			startTime := time.Now()
+			defer func() {
+				task1.outcome.Finish(err)
+				if task1.ran.Load() {
+					taskEmitter.TaskDone(ctx, time.Since(startTime))
+				}
+			}()
+
+			defer func() {
+				recovered := recover()
+				var stacktrace []byte
+				if recovered != nil {
+					stacktrace = debug.Stack()
+				}
+				if recovered == nil && p0PanicRecover != nil {
+					recovered = p0PanicRecover
+					stacktrace = p0PanicStacktrace
+				}
+				if recovered != nil {
+					taskEmitter.TaskPanic(ctx, recovered)
+					err = &cff.PanicError{
+						Value:      recovered,
+						Stacktrace: stacktrace,
+					}
+				}
+			}()
+
+			if !p0 {
+				task1.outcome.Skip(cff.SkipPredicateFalse)
+				return nil
+			}
+
+			defer task1.ran.Store(true)
+
+			err = cff.Intercept(ctx, interceptor, task1.outcome.Info, func(ctx context.Context) (err error) {
+				task1Collected0 = _42_4()
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return err
+			} else {
+				taskEmitter.TaskSuccess(ctx)
+			}
+
+			task1Produced = true
+
+			return
+		}
+
+		task1.job = sched.Enqueue(ctx, cff.Job{
+			Run: task1.run,
+			Dependencies: []*cff.ScheduledJob{
+				pred1.job,
+			},
+		})
+		tasks = append(tasks, task1)
+
+		// go.uber.org/cff/internal/tests/collect/collect.go:49:4
+		var (
+			task2Collected0 Signal
+		)
+
+		var task2Produced bool
+		task2 := new(struct {
+			emitter cff.TaskEmitter
+			ran     cff.AtomicBool
+			run     func(context.Context) error
+			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		})
+		task2.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/collect/collect.go",
+			Line:   49,
+			Column: 4,
+		}
+		task2.emitter = cff.NopTaskEmitter()
+		task2.run = func(ctx context.Context) (err error) {
+			taskEmitter := task2.emitter
+			startTime := time.Now()
+			defer func() {
+				task2.outcome.Finish(err)
+				if task2.ran.Load() {
+					taskEmitter.TaskDone(ctx, time.Since(startTime))
+				}
+			}()
+
+			defer func() {
+				recovered := recover()
+				if recovered != nil {
+					taskEmitter.TaskPanicRecovered(ctx, recovered)
+					task2Collected0, err = *new(Signal), nil
+				}
+			}()
+
+			defer task2.ran.Store(true)
+
+			err = cff.Intercept(ctx, interceptor, task2.outcome.Info, func(ctx context.Context) (err error) {
+				task2Collected0, err = _49_4(v1)
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskErrorRecovered(ctx, err)
+				task2Collected0, err = *new(Signal), nil
+				return nil
+			} else {
+				taskEmitter.TaskSuccess(ctx)
+			}
+
+			task2Produced = true
+
+			return
+		}
+
+		task2.job = sched.Enqueue(ctx, cff.Job{
+			Run: task2.run,
+		})
+		tasks = append(tasks, task2)
+
+		// Collect go.uber.org/cff/internal/tests/collect.Signal from:
+		//   - go.uber.org/cff/internal/tests/collect/collect.go:38:12
+		//   - go.uber.org/cff/internal/tests/collect/collect.go:42:4
+		//   - go.uber.org/cff/internal/tests/collect/collect.go:49:4
+		var (
+			v2 []Signal
+		)
+
+		task4 := new(struct {
+			emitter cff.TaskEmitter
+			ran     cff.AtomicBool
+			run     func(context.Context) error
+			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		})
+		task4.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/collect/collect.go",
+			Line:   38,
+			Column: 12,
+		}
+		task4.outcome.Dependencies = []*cff.TaskOutcome{
+			&task0.outcome,
+			&task1.outcome,
+			&task2.outcome,
+		}
+		task4.run = func(ctx context.Context) (err error) {
+			defer task4.outcome.Finish(nil)
+			if task0Produced {
+				v2 = append(v2, task0Collected0)
+			}
+			if task1Produced {
+				v2 = append(v2, task1Collected0)
+			}
+			if task2Produced {
+				v2 = append(v2, task2Collected0)
+			}
+			return nil
+		}
+
+		task4.job = sched.Enqueue(ctx, cff.Job{
+			Run: task4.run,
+			Dependencies: []*cff.ScheduledJob{
+				task0.job,
+				task1.job,
+				task2.job,
+			},
+		})
+
+		// go.uber.org/cff/internal/tests/collect/collect.go:58:12
+		var (
+			v3 *Report
+		)
+
+		task3 := new(struct {
+			emitter cff.TaskEmitter
+			ran     cff.AtomicBool
+			run     func(context.Context) error
+			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		})
+		task3.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/collect/collect.go",
+			Line:   58,
+			Column: 12,
+		}
+		task3.outcome.Dependencies = []*cff.TaskOutcome{
+			&task4.outcome,
+		}
+		task3.emitter = cff.NopTaskEmitter()
+		task3.run = func(ctx context.Context) (err error) {
+			taskEmitter := task3.emitter
+			startTime := time.Now()
+			defer func() {
+				task3.outcome.Finish(err)
+				if task3.ran.Load() {
+					taskEmitter.TaskDone(ctx, time.Since(startTime))
+				}
+			}()
+
+			defer func() {
+				recovered := recover()
+				if recovered != nil {
+					taskEmitter.TaskPanic(ctx, recovered)
+					err = &cff.PanicError{
+						Value:      recovered,
+						Stacktrace: debug.Stack(),
+					}
+				}
+			}()
+
+			defer task3.ran.Store(true)
+
+			err = cff.Intercept(ctx, interceptor, task3.outcome.Info, func(ctx context.Context) (err error) {
+				v3 = _58_12(v2)
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return err
+			} else {
+				taskEmitter.TaskSuccess(ctx)
+			}
+
+			return
+		}
+
+		task3.job = sched.Enqueue(ctx, cff.Job{
+			Run: task3.run,
+			Dependencies: []*cff.ScheduledJob{
+				task4.job,
+			},
+		})
+		tasks = append(tasks, task3)
+
+		if err := sched.Wait(ctx); err != nil {
+			flowEmitter.FlowError(ctx, err)
+			cff.RethrowPanic(err, false)
+			return err
+		}
+
+		*(_37_15) = v3 // *go.uber.org/cff/internal/tests/collect.Report
+
+		flowEmitter.FlowSuccess(ctx)
+		return nil
+	}()
+	return r, err
+}
+
+// Tag is collected by the flows in this package.
+type Tag string
+
+// Multiple is a flow with tasks that produce multiple collected types,
+// and results that are collected directly.
+func Multiple(ctx context.Context) (signals []Signal, tags []Tag, err error) {
+	err = func() (err error) {
+
+		_75_17 := ctx
+
+		_76_15 := &signals
+
+		_76_25 := &tags
+
+		_77_12 := func() (Signal, Tag) {
+			return Signal{Name: "foo"}, "foo"
+		}
+
+		_80_12 := func() Tag {
+			return "bar"
+		}
+
+		_83_12 := func() (Tag, Signal, error) {
+			return "baz", Signal{Name: "baz"}, nil
+		}
+		ctx := _75_17
+		emitter := cff.NopEmitter()
+		interceptor := cff.InterceptorStack()
+
+		var (
+			flowInfo = &cff.FlowInfo{
+				File:   "go.uber.org/cff/internal/tests/collect/collect.go",
+				Line:   75,
+				Column: 8,
+			}
+			flowEmitter = cff.NopFlowEmitter()
+
+			schedInfo = &cff.SchedulerInfo{
+				Name:      flowInfo.Name,
+				Directive: cff.FlowDirective,
+				File:      flowInfo.File,
+				Line:      flowInfo.Line,
+				Column:    flowInfo.Column,
+			}
+
+			// possibly unused
+			_ = flowInfo
+		)
+
+		startTime := time.Now()
+		defer func() { flowEmitter.FlowDone(ctx, time.Since(startTime)) }()
+
+		schedEmitter := emitter.SchedulerInit(schedInfo)
+
+		sched := cff.NewScheduler(
+			cff.SchedulerParams{
+				Emitter: schedEmitter,
+			},
+		)
+
+		var tasks []*struct {
+			emitter cff.TaskEmitter
+			ran     cff.AtomicBool
+			run     func(context.Context) error
+			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		}
+		defer func() {
+			for _, t := range tasks {
+				if !t.ran.Load() {
+					t.emitter.TaskSkipped(ctx, t.outcome.SkipReason(ctx, err))
+				}
+			}
+		}()
+
+		// go.uber.org/cff/internal/tests/collect/collect.go:77:12
+		var (
+			task5Collected0 Signal
+			task5Collected1 Tag
+		)
+
+		var task5Produced bool
+		task5 := new(struct {
+			emitter cff.TaskEmitter
+			ran     cff.AtomicBool
+			run     func(context.Context) error
+			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		})
+		task5.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/collect/collect.go",
+			Line:   77,
+			Column: 12,
+		}
+		task5.emitter = cff.NopTaskEmitter()
+		task5.run = func(ctx context.Context) (err error) {
+			taskEmitter := task5.emitter
+			startTime := time.Now()
+			defer func() {
+				task5.outcome.Finish(err)
+				if task5.ran.Load() {
+					taskEmitter.TaskDone(ctx, time.Since(startTime))
+				}
+			}()
+
+			defer func() {
+				recovered := recover()
+				if recovered != nil {
+					taskEmitter.TaskPanic(ctx, recovered)
+					err = &cff.PanicError{
+						Value:      recovered,
+						Stacktrace: debug.Stack(),
+					}
+				}
+			}()
+
+			defer task5.ran.Store(true)
+
+			err = cff.Intercept(ctx, interceptor, task5.outcome.Info, func(ctx context.Context) (err error) {
+				task5Collected0, task5Collected1 = _77_12()
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return err
+			} else {
+				taskEmitter.TaskSuccess(ctx)
+			}
+
+			task5Produced = true
+
+			return
+		}
+
+		task5.job = sched.Enqueue(ctx, cff.Job{
+			Run: task5.run,
+		})
+		tasks = append(tasks, task5)
+
+		// go.uber.org/cff/internal/tests/collect/collect.go:80:12
+		var (
+			task6Collected0 Tag
+		)
+
+		var task6Produced bool
+		task6 := new(struct {
+			emitter cff.TaskEmitter
+			ran     cff.AtomicBool
+			run     func(context.Context) error
+			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		})
+		task6.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/collect/collect.go",
+			Line:   80,
+			Column: 12,
+		}
+		task6.emitter = cff.NopTaskEmitter()
+		task6.run = func(ctx context.Context) (err error) {
+			taskEmitter := task6.emitter
+			startTime := time.Now()
+			defer func() {
+				task6.outcome.Finish(err)
+				if task6.ran.Load() {
+					taskEmitter.TaskDone(ctx, time.Since(startTime))
+				}
+			}()
+
+			defer func() {
+				recovered := recover()
+				if recovered != nil {
+					taskEmitter.TaskPanic(ctx, recovered)
+					err = &cff.PanicError{
+						Value:      recovered,
+						Stacktrace: debug.Stack(),
+					}
+				}
+			}()
+
+			defer task6.ran.Store(true)
+
+			err = cff.Intercept(ctx, interceptor, task6.outcome.Info, func(ctx context.Context) (err error) {
+				task6Collected0 = _80_12()
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return err
+			} else {
+				taskEmitter.TaskSuccess(ctx)
+			}
+
+			task6Produced = true
+
+			return
+		}
+
+		task6.job = sched.Enqueue(ctx, cff.Job{
+			Run: task6.run,
+		})
+		tasks = append(tasks, task6)
+
+		// go.uber.org/cff/internal/tests/collect/collect.go:83:12
+		var (
+			task7Collected0 Tag
+			task7Collected1 Signal
+		)
+
+		var task7Produced bool
+		task7 := new(struct {
+			emitter cff.TaskEmitter
+			ran     cff.AtomicBool
+			run     func(context.Context) error
+			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		})
+		task7.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/collect/collect.go",
+			Line:   83,
+			Column: 12,
+		}
+		task7.emitter = cff.NopTaskEmitter()
+		task7.run = func(ctx context.Context) (err error) {
+			taskEmitter := task7.emitter
+			startTime := time.Now()
+			defer func() {
+				task7.outcome.Finish(err)
+				if task7.ran.Load() {
+					taskEmitter.TaskDone(ctx, time.Since(startTime))
+				}
+			}()
+
+			defer func() {
+				recovered := recover()
+				if recovered != nil {
+					taskEmitter.TaskPanic(ctx, recovered)
+					err = &cff.PanicError{
+						Value:      recovered,
+						Stacktrace: debug.Stack(),
+					}
+				}
+			}()
+
+			defer task7.ran.Store(true)
+
+			err = cff.Intercept(ctx, interceptor, task7.outcome.Info, func(ctx context.Context) (err error) {
+				task7Collected0, task7Collected1, err = _83_12()
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return err
+			} else {
+				taskEmitter.TaskSuccess(ctx)
+			}
+
+			task7Produced = true
+
+			return
+		}
+
+		task7.job = sched.Enqueue(ctx, cff.Job{
+			Run: task7.run,
+		})
+		tasks = append(tasks, task7)
+
+		// Collect go.uber.org/cff/internal/tests/collect.Signal from:
+		//   - go.uber.org/cff/internal/tests/collect/collect.go:77:12
+		//   - go.uber.org/cff/internal/tests/collect/collect.go:83:12
+		var (
+			v2 []Signal
+		)
+
+		task8 := new(struct {
+			emitter cff.TaskEmitter
+			ran     cff.AtomicBool
+			run     func(context.Context) error
+			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		})
+		task8.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/collect/collect.go",
+			Line:   77,
+			Column: 12,
+		}
+		task8.outcome.Dependencies = []*cff.TaskOutcome{
+			&task5.outcome,
+			&task7.outcome,
+		}
+		task8.run = func(ctx context.Context) (err error) {
+			defer task8.outcome.Finish(nil)
+			if task5Produced {
+				v2 = append(v2, task5Collected0)
+			}
+			if task7Produced {
+				v2 = append(v2, task7Collected1)
+			}
+			return nil
+		}
+
+		task8.job = sched.Enqueue(ctx, cff.Job{
+			Run: task8.run,
+			Dependencies: []*cff.ScheduledJob{
+				task5.job,
+				task7.job,
+			},
+		})
+
+		// Collect go.uber.org/cff/internal/tests/collect.Tag from:
+		//   - go.uber.org/cff/internal/tests/collect/collect.go:77:12
+		//   - go.uber.org/cff/internal/tests/collect/collect.go:80:12
+		//   - go.uber.org/cff/internal/tests/collect/collect.go:83:12
+		var (
+			v4 []Tag
+		)
+
+		task9 := new(struct {
+			emitter cff.TaskEmitter
+			ran     cff.AtomicBool
+			run     func(context.Context) error
+			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		})
+		task9.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/collect/collect.go",
+			Line:   77,
+			Column: 12,
+		}
+		task9.outcome.Dependencies = []*cff.TaskOutcome{
+			&task5.outcome,
+			&task6.outcome,
+			&task7.outcome,
+		}
+		task9.run = func(ctx context.Context) (err error) {
+			defer task9.outcome.Finish(nil)
+			if task5Produced {
+				v4 = append(v4, task5Collected1)
+			}
+			if task6Produced {
+				v4 = append(v4, task6Collected0)
+			}
+			if task7Produced {
+				v4 = append(v4, task7Collected0)
+			}
+			return nil
+		}
+
+		task9.job = sched.Enqueue(ctx, cff.Job{
+			Run: task9.run,
+			Dependencies: []*cff.ScheduledJob{
+				task5.job,
+				task6.job,
+				task7.job,
+			},
+		})
+
+		if err := sched.Wait(ctx); err != nil {
+			flowEmitter.FlowError(ctx, err)
+			cff.RethrowPanic(err, false)
+			return err
+		}
+
+		*(_76_15) = v2 // []go.uber.org/cff/internal/tests/collect.Signal
+		*(_76_25) = v4 // []go.uber.org/cff/internal/tests/collect.Tag
+
+		flowEmitter.FlowSuccess(ctx)
+		return nil
+	}()
+	return signals, tags, err
+}
+
+// Empty is a flow that collects values from tasks
+// that are all skipped.
+func Empty(ctx context.Context) ([]Signal, error) {
+	var signals []Signal
+	err := func() (err error) {
+
+		_94_18 := ctx
+
+		_95_14 := false
+
+		_96_15 := &signals
+
+		_98_4 := func() Signal { return Signal{Name: "foo"} }
+
+		_100_18 := func(b bool) bool { return b }
+		ctx := _94_18
+		var v5 bool = _95_14
+		emitter := cff.NopEmitter()
+		interceptor := cff.InterceptorStack()
+
+		var (
+			flowInfo = &cff.FlowInfo{
+				File:   "go.uber.org/cff/internal/tests/collect/collect.go",
+				Line:   94,
+				Column: 9,
+			}
+			flowEmitter = cff.NopFlowEmitter()
+
+			schedInfo = &cff.SchedulerInfo{
+				Name:      flowInfo.Name,
+				Directive: cff.FlowDirective,
+				File:      flowInfo.File,
+				Line:      flowInfo.Line,
+				Column:    flowInfo.Column,
+			}
+
+			// possibly unused
+			_ = flowInfo
+		)
+
+		startTime := time.Now()
+		defer func() { flowEmitter.FlowDone(ctx, time.Since(startTime)) }()
+
+		schedEmitter := emitter.SchedulerInit(schedInfo)
+
+		sched := cff.NewScheduler(
+			cff.SchedulerParams{
+				Emitter: schedEmitter,
+			},
+		)
+
+		var tasks []*struct {
+			emitter cff.TaskEmitter
+			ran     cff.AtomicBool
+			run     func(context.Context) error
+			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		}
+		defer func() {
+			for _, t := range tasks {
+				if !t.ran.Load() {
+					t.emitter.TaskSkipped(ctx, t.outcome.SkipReason(ctx, err))
+				}
+			}
+		}()
+
+		// go.uber.org/cff/internal/tests/collect/collect.go:100:4
+		var p0 bool
+		var p0PanicRecover interface{}
+		var p0PanicStacktrace []byte
+		_ = p0PanicStacktrace // possibly unused.
+		pred1 := new(struct {
+			ran cff.AtomicBool
+			run func(context.Context) error
+			job *cff.ScheduledJob
+		})
+		pred1.run = func(ctx context.Context) (err error) {
+			defer func() {
+				if recovered := recover(); recovered != nil {
+					p0PanicRecover = recovered
+					p0PanicStacktrace = debug.Stack()
+				}
+			}()
+			p0 = _100_18(v5)
+			return nil
+		}
+
+		pred1.job = sched.Enqueue(ctx, cff.Job{
+			Run: pred1.run,
+		})
+
+		// go.uber.org/cff/internal/tests/collect/collect.go:98:4
+		var (
+			task10Collected0 Signal
+		)
+
+		var task10Produced bool
+		task10 := new(struct {
+			emitter cff.TaskEmitter
+			ran     cff.AtomicBool
+			run     func(context.Context) error
+			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		})
+		task10.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/collect/collect.go",
+			Line:   98,
+			Column: 4,
+		}
+		task10.emitter = cff.NopTaskEmitter()
+		task10.run = func(ctx context.Context) (err error) {
+			taskEmitter := task10.emitter
+			startTime := time.Now()
+			defer func() {
+				task10.outcome.Finish(err)
+				if task10.ran.Load() {
+					taskEmitter.TaskDone(ctx, time.Since(startTime))
+				}
+			}()
+
+			defer func() {
+				recovered := recover()
+				var stacktrace []byte
+				if recovered != nil {
+					stacktrace = debug.Stack()
+				}
+				if recovered == nil && p0PanicRecover != nil {
+					recovered = p0PanicRecover
+					stacktrace = p0PanicStacktrace
+				}
+				if recovered != nil {
+					taskEmitter.TaskPanic(ctx, recovered)
+					err = &cff.PanicError{
+						Value:      recovered,
+						Stacktrace: stacktrace,
+					}
+				}
+			}()
+
+			if !p0 {
+				task10.outcome.Skip(cff.SkipPredicateFalse)
+				return nil
+			}
+
+			defer task10.ran.Store(true)
+
+			err = cff.Intercept(ctx, interceptor, task10.outcome.Info, func(ctx context.Context) (err error) {
+				task10Collected0 = _98_4()
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return err
+			} else {
+				taskEmitter.TaskSuccess(ctx)
+			}
+
+			task10Produced = true
+
+			return
+		}
+
+		task10.job = sched.Enqueue(ctx, cff.Job{
+			Run: task10.run,
+			Dependencies: []*cff.ScheduledJob{
+				pred1.job,
+			},
+		})
+		tasks = append(tasks, task10)
+
+		// Collect go.uber.org/cff/internal/tests/collect.Signal from:
+		//   - go.uber.org/cff/internal/tests/collect/collect.go:98:4
+		var (
+			v2 []Signal
+		)
+
+		task11 := new(struct {
+			emitter cff.TaskEmitter
+			ran     cff.AtomicBool
+			run     func(context.Context) error
+			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		})
+		task11.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/collect/collect.go",
+			Line:   98,
+			Column: 4,
+		}
+		task11.outcome.Dependencies = []*cff.TaskOutcome{
+			&task10.outcome,
+		}
+		task11.run = func(ctx context.Context) (err error) {
+			defer task11.outcome.Finish(nil)
+			if task10Produced {
+				v2 = append(v2, task10Collected0)
+			}
+			return nil
+		}
+
+		task11.job = sched.Enqueue(ctx, cff.Job{
+			Run: task11.run,
+			Dependencies: []*cff.ScheduledJob{
+				task10.job,
+			},
+		})
+
+		if err := sched.Wait(ctx); err != nil {
+			flowEmitter.FlowError(ctx, err)
+			cff.RethrowPanic(err, false)
+			return err
+		}
+
+		*(_96_15) = v2 // []go.uber.org/cff/internal/tests/collect.Signal
+
+		flowEmitter.FlowSuccess(ctx)
+		return nil
+	}()
+	return signals, err
+}
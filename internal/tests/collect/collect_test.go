@@ -0,0 +1,63 @@
+package collect
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScore(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("all", func(t *testing.T) {
+		r, err := Score(ctx, &Request{})
+		require.NoError(t, err)
+		assert.Equal(t, &Report{
+			Signals: []Signal{
+				{Name: "quota", Score: 1},
+				{Name: "abuse", Score: 2},
+				{Name: "spam", Score: 3},
+			},
+			Total: 6,
+		}, r)
+	})
+
+	t.Run("skipped", func(t *testing.T) {
+		r, err := Score(ctx, &Request{SkipAbuse: true})
+		require.NoError(t, err)
+		assert.Equal(t, &Report{
+			Signals: []Signal{
+				{Name: "quota", Score: 1},
+				{Name: "spam", Score: 3},
+			},
+			Total: 4,
+		}, r)
+	})
+
+	t.Run("optional failed", func(t *testing.T) {
+		r, err := Score(ctx, &Request{FailSpam: true})
+		require.NoError(t, err)
+		assert.Equal(t, &Report{
+			Signals: []Signal{
+				{Name: "quota", Score: 1},
+				{Name: "abuse", Score: 2},
+			},
+			Total: 3,
+		}, r)
+	})
+}
+
+func TestMultiple(t *testing.T) {
+	signals, tags, err := Multiple(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []Signal{{Name: "foo"}, {Name: "baz"}}, signals)
+	assert.Equal(t, []Tag{"foo", "bar", "baz"}, tags)
+}
+
+func TestEmpty(t *testing.T) {
+	signals, err := Empty(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, signals)
+}
@@ -0,0 +1,118 @@
+//go:build cff
+// +build cff
+
+// Package conditional tests flows with tasks that report whether they
+// produced their outputs.
+package conditional
+
+import (
+	"context"
+	"errors"
+
+	"go.uber.org/cff"
+)
+
+// User is a user of the system.
+type User struct {
+	Name      string
+	ManagerID int
+}
+
+// Manager is the manager of a user.
+type Manager struct {
+	Name string
+}
+
+// Report is built for a user.
+type Report struct {
+	User    string
+	Manager string
+
+	// HasManager reports whether the manager of the user was found.
+	HasManager bool
+}
+
+var _managers = map[int]*Manager{
+	1: {Name: "alice"},
+}
+
+func findManager(u *User) (*Manager, bool) {
+	m, ok := _managers[u.ManagerID]
+	return m, ok
+}
+
+// Lookup builds a report for a user.
+// The task that formats the manager's name is skipped if the user has no
+// manager, and the report is built with the zero value instead.
+func Lookup(ctx context.Context, emitter cff.Emitter, u *User) (*Report, error) {
+	var r *Report
+	err := cff.Flow(ctx,
+		cff.WithEmitter(emitter),
+		cff.Params(u),
+		cff.Results(&r),
+		cff.Task(findManager, cff.Instrument("findManager")),
+		cff.Task(func(m *Manager) string {
+			return "manager: " + m.Name
+		}, cff.Instrument("formatManager")),
+		cff.Task(func(u *User, name string, m cff.Maybe[*Manager]) *Report {
+			return &Report{User: u.Name, Manager: name, HasManager: m.Valid}
+		}, cff.Instrument("report")),
+	)
+	return r, err
+}
+
+// LookupMaybe looks up the manager of a user with a task that returns
+// a cff.Maybe, reporting whether it was found.
+func LookupMaybe(ctx context.Context, u *User) (cff.Maybe[*Manager], error) {
+	var m cff.Maybe[*Manager]
+	err := cff.Flow(ctx,
+		cff.Params(u),
+		cff.Results(&m),
+		cff.Task(func(u *User) cff.Maybe[*Manager] {
+			if m, ok := findManager(u); ok {
+				return cff.Some(m)
+			}
+			return cff.Maybe[*Manager]{}
+		}),
+	)
+	return m, err
+}
+
+// LookupErr looks up the manager of a user with a task that may fail.
+func LookupErr(ctx context.Context, u *User) (string, error) {
+	var s string
+	err := cff.Flow(ctx,
+		cff.Params(u),
+		cff.Results(&s),
+		cff.Task(func(u *User) (*Manager, bool, error) {
+			if u.ManagerID < 0 {
+				return nil, false, errors.New("invalid manager ID")
+			}
+			m, ok := findManager(u)
+			return m, ok, nil
+		}),
+		cff.Task(func(m *Manager) string {
+			return m.Name
+		}),
+	)
+	return s, err
+}
+
+// Notify notifies the manager of a user if they have one
+// and the predicate allows it.
+// The predicate does not run if the user has no manager.
+func Notify(ctx context.Context, u *User, notified *[]string) error {
+	return cff.Flow(ctx,
+		cff.Params(u),
+		cff.Task(findManager),
+		cff.Task(
+			func(m *Manager) {
+				*notified = append(*notified, m.Name)
+			},
+			cff.Invoke(true),
+			cff.Predicate(func(m *Manager) bool {
+				return m.Name != ""
+			}),
+		),
+	)
+}
@@ -0,0 +1,1028 @@
+//go:build !cff
+// +build !cff
+
+// Package conditional tests flows with tasks that report whether they
+// produced their outputs.
+package conditional
+
+import (
+	"context"
+	"errors"
+	"runtime/debug"
+	"time"
+
+	"go.uber.org/cff"
+)
+
+// User is a user of the system.
+type User struct {
+	Name      string
+	ManagerID int
+}
+
+// Manager is the manager of a user.
+type Manager struct {
+	Name string
+}
+
+// Report is built for a user.
+type Report struct {
+	User    string
+	Manager string
+
+	// HasManager reports whether the manager of the user was found.
+	HasManager bool
+}
+
+var _managers = map[int]*Manager{
+	1: {Name: "alice"},
+}
+
+func findManager(u *User) (*Manager, bool) {
+	m, ok := _managers[u.ManagerID]
+	return m, ok
+}
+
+// Lookup builds a report for a user.
+// The task that formats the manager's name is skipped if the user has no
+// manager, and the report is built with the zero value instead.
+func Lookup(ctx context.Context, emitter cff.Emitter, u *User) (*Report, error) {
+	var r *Report
+	err := func() (err error) {
+
+		_49_18 := ctx
+
+		_50_19 := emitter
+
+		_51_14 := u
+
+		_52_15 := &r
+
+		_53_12 := findManager
+
+		_53_40 := "findManager"
+
+		_54_12 := func(m *Manager) string {
+			return "manager: " + m.Name
+		}
+
+		_56_21 := "formatManager"
+
+		_57_12 := func(u *User, name string, m cff.Maybe[*Manager]) *Report {
+			return &Report{User: u.Name, Manager: name, HasManager: m.Valid}
+		}
+
+		_59_21 := "report"
+		ctx := _49_18
+		var v1 *User = _51_14
+		emitter := cff.EmitterStack(_50_19)
+		interceptor := cff.InterceptorStack()
+
+		var (
+			flowInfo = &cff.FlowInfo{
+				File:   "go.uber.org/cff/internal/tests/conditional/conditional.go",
+				Line:   49,
+				Column: 9,
+			}
+			flowEmitter = cff.NopFlowEmitter()
+
+			schedInfo = &cff.SchedulerInfo{
+				Name:      flowInfo.Name,
+				Directive: cff.FlowDirective,
+				File:      flowInfo.File,
+				Line:      flowInfo.Line,
+				Column:    flowInfo.Column,
+			}
+
+			// possibly unused
+			_ = flowInfo
+		)
+
+		startTime := time.Now()
+		defer func() { flowEmitter.FlowDone(ctx, time.Since(startTime)) }()
+
+		schedEmitter := emitter.SchedulerInit(schedInfo)
+
+		sched := cff.NewScheduler(
+			cff.SchedulerParams{
+				Emitter: schedEmitter,
+			},
+		)
+
+		var tasks []*struct {
+			emitter cff.TaskEmitter
+			ran     cff.AtomicBool
+			run     func(context.Context) error
+			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		}
+		defer func() {
+			for _, t := range tasks {
+				if !t.ran.Load() {
+					t.emitter.TaskSkipped(ctx, t.outcome.SkipReason(ctx, err))
+				}
+			}
+		}()
+
+		// go.uber.org/cff/internal/tests/conditional/conditional.go:53:12
+		var (
+			v2 *Manager
+		)
+
+		var task0Produced bool
+		task0 := new(struct {
+			emitter cff.TaskEmitter
+			ran     cff.AtomicBool
+			run     func(context.Context) error
+			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		})
+		task0.outcome.Info = &cff.TaskInfo{
+			Name:   _53_40,
+			File:   "go.uber.org/cff/internal/tests/conditional/conditional.go",
+			Line:   53,
+			Column: 12,
+		}
+		task0.emitter = emitter.TaskInit(
+			task0.outcome.Info,
+			&cff.DirectiveInfo{
+				Name:      flowInfo.Name,
+				Directive: cff.FlowDirective,
+				File:      flowInfo.File,
+				Line:      flowInfo.Line,
+				Column:    flowInfo.Column,
+			},
+		)
+		task0.run = func(ctx context.Context) (err error) {
+			taskEmitter := task0.emitter
+			startTime := time.Now()
+			defer func() {
+				task0.outcome.Finish(err)
+				if task0.ran.Load() {
+					taskEmitter.TaskDone(ctx, time.Since(startTime))
+				}
+			}()
+
+			var task0Ok bool
+
+			defer func() {
+				recovered := recover()
+				if recovered != nil {
+					taskEmitter.TaskPanic(ctx, recovered)
+					err = &cff.PanicError{
+						Value:      recovered,
+						Stacktrace: debug.Stack(),
+					}
+				}
+			}()
+
+			defer task0.ran.Store(true)
+
+			err = cff.Intercept(ctx, interceptor, task0.outcome.Info, func(ctx context.Context) (err error) {
+				v2, task0Ok = _53_12(v1)
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return err
+			} else {
+				taskEmitter.TaskSuccess(ctx)
+			}
+
+			task0Produced = task0Ok
+
+			return
+		}
+
+		task0.job = sched.Enqueue(ctx, cff.Job{
+			Run: task0.run,
+		})
+		tasks = append(tasks, task0)
+
+		// go.uber.org/cff/internal/tests/conditional/conditional.go:54:12
+		var (
+			v3 string
+		)
+
+		task1 := new(struct {
+			emitter cff.TaskEmitter
+			ran     cff.AtomicBool
+			run     func(context.Context) error
+			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		})
+		task1.outcome.Info = &cff.TaskInfo{
+			Name:   _56_21,
+			File:   "go.uber.org/cff/internal/tests/conditional/conditional.go",
+			Line:   54,
+			Column: 12,
+		}
+		task1.outcome.Dependencies = []*cff.TaskOutcome{
+			&task0.outcome,
+		}
+		task1.emitter = emitter.TaskInit(
+			task1.outcome.Info,
+			&cff.DirectiveInfo{
+				Name:      flowInfo.Name,
+				Directive: cff.FlowDirective,
+				File:      flowInfo.File,
+				Line:      flowInfo.Line,
+				Column:    flowInfo.Column,
+			},
+		)
+		task1.run = func(ctx context.Context) (err error) {
+			taskEmitter := task1.emitter
+			startTime := time.Now()
+			defer func() {
+				task1.outcome.Finish(err)
+				if task1.ran.Load() {
+					taskEmitter.TaskDone(ctx, time.Since(startTime))
+				}
+			}()
+
+			defer func() {
+				recovered := recover()
+				if recovered != nil {
+					taskEmitter.TaskPanic(ctx, recovered)
+					err = &cff.PanicError{
+						Value:      recovered,
+						Stacktrace: debug.Stack(),
+					}
+				}
+			}()
+
+			if !task0Produced {
+				// A value that the task depends on was not produced.
+				task1.outcome.Skip(cff.SkipNotProduced)
+				return nil
+			}
+
+			defer task1.ran.Store(true)
+
+			err = cff.Intercept(ctx, interceptor, task1.outcome.Info, func(ctx context.Context) (err error) {
+				v3 = _54_12(v2)
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return err
+			} else {
+				taskEmitter.TaskSuccess(ctx)
+			}
+
+			return
+		}
+
+		task1.job = sched.Enqueue(ctx, cff.Job{
+			Run: task1.run,
+			Dependencies: []*cff.ScheduledJob{
+				task0.job,
+			},
+		})
+		tasks = append(tasks, task1)
+
+		// go.uber.org/cff/internal/tests/conditional/conditional.go:57:12
+		var (
+			v4 *Report
+		)
+
+		task2 := new(struct {
+			emitter cff.TaskEmitter
+			ran     cff.AtomicBool
+			run     func(context.Context) error
+			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		})
+		task2.outcome.Info = &cff.TaskInfo{
+			Name:   _59_21,
+			File:   "go.uber.org/cff/internal/tests/conditional/conditional.go",
+			Line:   57,
+			Column: 12,
+		}
+		task2.outcome.Dependencies = []*cff.TaskOutcome{
+			&task1.outcome,
+			&task0.outcome,
+		}
+		task2.emitter = emitter.TaskInit(
+			task2.outcome.Info,
+			&cff.DirectiveInfo{
+				Name:      flowInfo.Name,
+				Directive: cff.FlowDirective,
+				File:      flowInfo.File,
+				Line:      flowInfo.Line,
+				Column:    flowInfo.Column,
+			},
+		)
+		task2.run = func(ctx context.Context) (err error) {
+			taskEmitter := task2.emitter
+			startTime := time.Now()
+			defer func() {
+				task2.outcome.Finish(err)
+				if task2.ran.Load() {
+					taskEmitter.TaskDone(ctx, time.Since(startTime))
+				}
+			}()
+
+			defer func() {
+				recovered := recover()
+				if recovered != nil {
+					taskEmitter.TaskPanic(ctx, recovered)
+					err = &cff.PanicError{
+						Value:      recovered,
+						Stacktrace: debug.Stack(),
+					}
+				}
+			}()
+
+			defer task2.ran.Store(true)
+
+			err = cff.Intercept(ctx, interceptor, task2.outcome.Info, func(ctx context.Context) (err error) {
+				v4 = _57_12(v1, v3, cff.Maybe[*Manager]{Value: v2, Valid: task0Produced})
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return err
+			} else {
+				taskEmitter.TaskSuccess(ctx)
+			}
+
+			return
+		}
+
+		task2.job = sched.Enqueue(ctx, cff.Job{
+			Run: task2.run,
+			Dependencies: []*cff.ScheduledJob{
+				task1.job,
+				task0.job,
+			},
+		})
+		tasks = append(tasks, task2)
+
+		if err := sched.Wait(ctx); err != nil {
+			flowEmitter.FlowError(ctx, err)
+			cff.RethrowPanic(err, false)
+			return err
+		}
+
+		*(_52_15) = v4 // *go.uber.org/cff/internal/tests/conditional.Report
+
+		flowEmitter.FlowSuccess(ctx)
+		return nil
+	}()
+	return r, err
+}
+
+// LookupMaybe looks up the manager of a user with a task that returns
+// a cff.Maybe, reporting whether it was found.
+func LookupMaybe(ctx context.Context, u *User) (cff.Maybe[*Manager], error) {
+	var m cff.Maybe[*Manager]
+	err := func() (err error) {
+
+		_68_18 := ctx
+
+		_69_14 := u
+
+		_70_15 := &m
+
+		_71_12 := func(u *User) cff.Maybe[*Manager] {
+			if m, ok := findManager(u); ok {
+				return cff.Some(m)
+			}
+			return cff.Maybe[*Manager]{}
+		}
+		ctx := _68_18
+		var v1 *User = _69_14
+		emitter := cff.NopEmitter()
+		interceptor := cff.InterceptorStack()
+
+		var (
+			flowInfo = &cff.FlowInfo{
+				File:   "go.uber.org/cff/internal/tests/conditional/conditional.go",
+				Line:   68,
+				Column: 9,
+			}
+			flowEmitter = cff.NopFlowEmitter()
+
+			schedInfo = &cff.SchedulerInfo{
+				Name:      flowInfo.Name,
+				Directive: cff.FlowDirective,
+				File:      flowInfo.File,
+				Line:      flowInfo.Line,
+				Column:    flowInfo.Column,
+			}
+
+			// possibly unused
+			_ = flowInfo
+		)
+
+		startTime := time.Now()
+		defer func() { flowEmitter.FlowDone(ctx, time.Since(startTime)) }()
+
+		schedEmitter := emitter.SchedulerInit(schedInfo)
+
+		sched := cff.NewScheduler(
+			cff.SchedulerParams{
+				Emitter: schedEmitter,
+			},
+		)
+
+		var tasks []*struct {
+			emitter cff.TaskEmitter
+			ran     cff.AtomicBool
+			run     func(context.Context) error
+			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		}
+		defer func() {
+			for _, t := range tasks {
+				if !t.ran.Load() {
+					t.emitter.TaskSkipped(ctx, t.outcome.SkipReason(ctx, err))
+				}
+			}
+		}()
+
+		// go.uber.org/cff/internal/tests/conditional/conditional.go:71:12
+		var (
+			v2 *Manager
+		)
+
+		var task3Produced bool
+		task3 := new(struct {
+			emitter cff.TaskEmitter
+			ran     cff.AtomicBool
+			run     func(context.Context) error
+			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		})
+		task3.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/conditional/conditional.go",
+			Line:   71,
+			Column: 12,
+		}
+		task3.emitter = cff.NopTaskEmitter()
+		task3.run = func(ctx context.Context) (err error) {
+			taskEmitter := task3.emitter
+			startTime := time.Now()
+			defer func() {
+				task3.outcome.Finish(err)
+				if task3.ran.Load() {
+					taskEmitter.TaskDone(ctx, time.Since(startTime))
+				}
+			}()
+
+			var task3Out0 cff.Maybe[*Manager]
+			// Unpack cff.Out and cff.Maybe results after recovering from panics.
+			defer func() {
+				if err != nil {
+					return
+				}
+				v2 = task3Out0.Value
+			}()
+
+			defer func() {
+				recovered := recover()
+				if recovered != nil {
+					taskEmitter.TaskPanic(ctx, recovered)
+					err = &cff.PanicError{
+						Value:      recovered,
+						Stacktrace: debug.Stack(),
+					}
+				}
+			}()
+
+			defer task3.ran.Store(true)
+
+			err = cff.Intercept(ctx, interceptor, task3.outcome.Info, func(ctx context.Context) (err error) {
+				task3Out0 = _71_12(v1)
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return err
+			} else {
+				taskEmitter.TaskSuccess(ctx)
+			}
+
+			task3Produced = task3Out0.Valid
+
+			return
+		}
+
+		task3.job = sched.Enqueue(ctx, cff.Job{
+			Run: task3.run,
+		})
+		tasks = append(tasks, task3)
+
+		if err := sched.Wait(ctx); err != nil {
+			flowEmitter.FlowError(ctx, err)
+			cff.RethrowPanic(err, false)
+			return err
+		}
+
+		*(_70_15) = cff.Maybe[*Manager]{
+			Value: v2,
+			Valid: task3Produced,
+		} // *go.uber.org/cff/internal/tests/conditional.Manager
+
+		flowEmitter.FlowSuccess(ctx)
+		return nil
+	}()
+	return m, err
+}
+
+// LookupErr looks up the manager of a user with a task that may fail.
+func LookupErr(ctx context.Context, u *User) (string, error) {
+	var s string
+	err := func() (err error) {
+
+		_84_18 := ctx
+
+		_85_14 := u
+
+		_86_15 := &s
+
+		_87_12 := func(u *User) (*Manager, bool, error) {
+			if u.ManagerID < 0 {
+				return nil, false, errors.New("invalid manager ID")
+			}
+			m, ok := findManager(u)
+			return m, ok, nil
+		}
+
+		_94_12 := func(m *Manager) string {
+			return m.Name
+		}
+		ctx := _84_18
+		var v1 *User = _85_14
+		emitter := cff.NopEmitter()
+		interceptor := cff.InterceptorStack()
+
+		var (
+			flowInfo = &cff.FlowInfo{
+				File:   "go.uber.org/cff/internal/tests/conditional/conditional.go",
+				Line:   84,
+				Column: 9,
+			}
+			flowEmitter = cff.NopFlowEmitter()
+
+			schedInfo = &cff.SchedulerInfo{
+				Name:      flowInfo.Name,
+				Directive: cff.FlowDirective,
+				File:      flowInfo.File,
+				Line:      flowInfo.Line,
+				Column:    flowInfo.Column,
+			}
+
+			// possibly unused
+			_ = flowInfo
+		)
+
+		startTime := time.Now()
+		defer func() { flowEmitter.FlowDone(ctx, time.Since(startTime)) }()
+
+		schedEmitter := emitter.SchedulerInit(schedInfo)
+
+		sched := cff.NewScheduler(
+			cff.SchedulerParams{
+				Emitter: schedEmitter,
+			},
+		)
+
+		var tasks []*struct {
+			emitter cff.TaskEmitter
+			ran     cff.AtomicBool
+			run     func(context.Context) error
+			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		}
+		defer func() {
+			for _, t := range tasks {
+				if !t.ran.Load() {
+					t.emitter.TaskSkipped(ctx, t.outcome.SkipReason(ctx, err))
+				}
+			}
+		}()
+
+		// go.uber.org/cff/internal/tests/conditional/conditional.go:87:12
+		var (
+			v2 *Manager
+		)
+
+		var task4Produced bool
+		task4 := new(struct {
+			emitter cff.TaskEmitter
+			ran     cff.AtomicBool
+			run     func(context.Context) error
+			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		})
+		task4.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/conditional/conditional.go",
+			Line:   87,
+			Column: 12,
+		}
+		task4.emitter = cff.NopTaskEmitter()
+		task4.run = func(ctx context.Context) (err error) {
+			taskEmitter := task4.emitter
+			startTime := time.Now()
+			defer func() {
+				task4.outcome.Finish(err)
+				if task4.ran.Load() {
+					taskEmitter.TaskDone(ctx, time.Since(startTime))
+				}
+			}()
+
+			var task4Ok bool
+
+			defer func() {
+				recovered := recover()
+				if recovered != nil {
+					taskEmitter.TaskPanic(ctx, recovered)
+					err = &cff.PanicError{
+						Value:      recovered,
+						Stacktrace: debug.Stack(),
+					}
+				}
+			}()
+
+			defer task4.ran.Store(true)
+
+			err = cff.Intercept(ctx, interceptor, task4.outcome.Info, func(ctx context.Context) (err error) {
+				v2, task4Ok, err = _87_12(v1)
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return err
+			} else {
+				taskEmitter.TaskSuccess(ctx)
+			}
+
+			task4Produced = task4Ok
+
+			return
+		}
+
+		task4.job = sched.Enqueue(ctx, cff.Job{
+			Run: task4.run,
+		})
+		tasks = append(tasks, task4)
+
+		// go.uber.org/cff/internal/tests/conditional/conditional.go:94:12
+		var (
+			v3 string
+		)
+
+		task5 := new(struct {
+			emitter cff.TaskEmitter
+			ran     cff.AtomicBool
+			run     func(context.Context) error
+			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		})
+		task5.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/conditional/conditional.go",
+			Line:   94,
+			Column: 12,
+		}
+		task5.outcome.Dependencies = []*cff.TaskOutcome{
+			&task4.outcome,
+		}
+		task5.emitter = cff.NopTaskEmitter()
+		task5.run = func(ctx context.Context) (err error) {
+			taskEmitter := task5.emitter
+			startTime := time.Now()
+			defer func() {
+				task5.outcome.Finish(err)
+				if task5.ran.Load() {
+					taskEmitter.TaskDone(ctx, time.Since(startTime))
+				}
+			}()
+
+			defer func() {
+				recovered := recover()
+				if recovered != nil {
+					taskEmitter.TaskPanic(ctx, recovered)
+					err = &cff.PanicError{
+						Value:      recovered,
+						Stacktrace: debug.Stack(),
+					}
+				}
+			}()
+
+			if !task4Produced {
+				// A value that the task depends on was not produced.
+				task5.outcome.Skip(cff.SkipNotProduced)
+				return nil
+			}
+
+			defer task5.ran.Store(true)
+
+			err = cff.Intercept(ctx, interceptor, task5.outcome.Info, func(ctx context.Context) (err error) {
+				v3 = _94_12(v2)
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return err
+			} else {
+				taskEmitter.TaskSuccess(ctx)
+			}
+
+			return
+		}
+
+		task5.job = sched.Enqueue(ctx, cff.Job{
+			Run: task5.run,
+			Dependencies: []*cff.ScheduledJob{
+				task4.job,
+			},
+		})
+		tasks = append(tasks, task5)
+
+		if err := sched.Wait(ctx); err != nil {
+			flowEmitter.FlowError(ctx, err)
+			cff.RethrowPanic(err, false)
+			return err
+		}
+
+		*(_86_15) = v3 // string
+
+		flowEmitter.FlowSuccess(ctx)
+		return nil
+	}()
+	return s, err
+}
+
+// Notify notifies the manager of a user if they have one
+// and the predicate allows it.
+// The predicate does not run if the user has no manager.
+func Notify(ctx context.Context, u *User, notified *[]string) error {
+	return func() (err error) {
+
+		_105_18 := ctx
+
+		_106_14 := u
+
+		_107_12 := findManager
+
+		_109_4 := func(m *Manager) {
+			*notified = append(*notified, m.Name)
+		}
+
+		_113_18 := func(m *Manager) bool {
+			return m.Name != ""
+		}
+		ctx := _105_18
+		var v1 *User = _106_14
+		emitter := cff.NopEmitter()
+		interceptor := cff.InterceptorStack()
+
+		var (
+			flowInfo = &cff.FlowInfo{
+				File:   "go.uber.org/cff/internal/tests/conditional/conditional.go",
+				Line:   105,
+				Column: 9,
+			}
+			flowEmitter = cff.NopFlowEmitter()
+
+			schedInfo = &cff.SchedulerInfo{
+				Name:      flowInfo.Name,
+				Directive: cff.FlowDirective,
+				File:      flowInfo.File,
+				Line:      flowInfo.Line,
+				Column:    flowInfo.Column,
+			}
+
+			// possibly unused
+			_ = flowInfo
+		)
+
+		startTime := time.Now()
+		defer func() { flowEmitter.FlowDone(ctx, time.Since(startTime)) }()
+
+		schedEmitter := emitter.SchedulerInit(schedInfo)
+
+		sched := cff.NewScheduler(
+			cff.SchedulerParams{
+				Emitter: schedEmitter,
+			},
+		)
+
+		var tasks []*struct {
+			emitter cff.TaskEmitter
+			ran     cff.AtomicBool
+			run     func(context.Context) error
+			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		}
+		defer func() {
+			for _, t := range tasks {
+				if !t.ran.Load() {
+					t.emitter.TaskSkipped(ctx, t.outcome.SkipReason(ctx, err))
+				}
+			}
+		}()
+
+		// go.uber.org/cff/internal/tests/conditional/conditional.go:107:12
+		var (
+			v2 *Manager
+		)
+
+		var task6Produced bool
+		task6 := new(struct {
+			emitter cff.TaskEmitter
+			ran     cff.AtomicBool
+			run     func(context.Context) error
+			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		})
+		task6.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/conditional/conditional.go",
+			Line:   107,
+			Column: 12,
+		}
+		task6.emitter = cff.NopTaskEmitter()
+		task6.run = func(ctx context.Context) (err error) {
+			taskEmitter := task6.emitter
+			startTime := time.Now()
+			defer func() {
+				task6.outcome.Finish(err)
+				if task6.ran.Load() {
+					taskEmitter.TaskDone(ctx, time.Since(startTime))
+				}
+			}()
+
+			var task6Ok bool
+
+			defer func() {
+				recovered := recover()
+				if recovered != nil {
+					taskEmitter.TaskPanic(ctx, recovered)
+					err = &cff.PanicError{
+						Value:      recovered,
+						Stacktrace: debug.Stack(),
+					}
+				}
+			}()
+
+			defer task6.ran.Store(true)
+
+			err = cff.Intercept(ctx, interceptor, task6.outcome.Info, func(ctx context.Context) (err error) {
+				v2, task6Ok = _107_12(v1)
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return err
+			} else {
+				taskEmitter.TaskSuccess(ctx)
+			}
+
+			task6Produced = task6Ok
+
+			return
+		}
+
+		task6.job = sched.Enqueue(ctx, cff.Job{
+			Run: task6.run,
+		})
+		tasks = append(tasks, task6)
+
+		// go.uber.org/cff/internal/tests/conditional/conditional.go:113:4
+		var p0 bool
+		var p0PanicRecover interface{}
+		var p0PanicStacktrace []byte
+		_ = p0PanicStacktrace // possibly unused.
+		pred1 := new(struct {
+			ran cff.AtomicBool
+			run func(context.Context) error
+			job *cff.ScheduledJob
+		})
+		pred1.run = func(ctx context.Context) (err error) {
+			defer func() {
+				if recovered := recover(); recovered != nil {
+					p0PanicRecover = recovered
+					p0PanicStacktrace = debug.Stack()
+				}
+			}()
+			if !task6Produced {
+				// A value that the predicate depends on was not produced.
+				return nil
+			}
+			p0 = _113_18(v2)
+			return nil
+		}
+
+		pred1.job = sched.Enqueue(ctx, cff.Job{
+			Run: pred1.run,
+			Dependencies: []*cff.ScheduledJob{
+				task6.job,
+			},
+		})
+
+		// go.uber.org/cff/internal/tests/conditional/conditional.go:109:4
+		task7 := new(struct {
+			emitter cff.TaskEmitter
+			ran     cff.AtomicBool
+			run     func(context.Context) error
+			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		})
+		task7.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/conditional/conditional.go",
+			Line:   109,
+			Column: 4,
+		}
+		task7.outcome.Dependencies = []*cff.TaskOutcome{
+			&task6.outcome,
+		}
+		task7.emitter = cff.NopTaskEmitter()
+		task7.run = func(ctx context.Context) (err error) {
+			taskEmitter := task7.emitter
+			startTime := time.Now()
+			defer func() {
+				task7.outcome.Finish(err)
+				if task7.ran.Load() {
+					taskEmitter.TaskDone(ctx, time.Since(startTime))
+				}
+			}()
+
+			defer func() {
+				recovered := recover()
+				var stacktrace []byte
+				if recovered != nil {
+					stacktrace = debug.Stack()
+				}
+				if recovered == nil && p0PanicRecover != nil {
+					recovered = p0PanicRecover
+					stacktrace = p0PanicStacktrace
+				}
+				if recovered != nil {
+					taskEmitter.TaskPanic(ctx, recovered)
+					err = &cff.PanicError{
+						Value:      recovered,
+						Stacktrace: stacktrace,
+					}
+				}
+			}()
+
+			if !task6Produced {
+				// A value that the task depends on was not produced.
+				task7.outcome.Skip(cff.SkipNotProduced)
+				return nil
+			}
+
+			if !p0 {
+				task7.outcome.Skip(cff.SkipPredicateFalse)
+				return nil
+			}
+
+			defer task7.ran.Store(true)
+
+			err = cff.Intercept(ctx, interceptor, task7.outcome.Info, func(ctx context.Context) (err error) {
+				_109_4(v2)
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return err
+			} else {
+				taskEmitter.TaskSuccess(ctx)
+			}
+
+			return
+		}
+
+		task7.job = sched.Enqueue(ctx, cff.Job{
+			Run: task7.run,
+			Dependencies: []*cff.ScheduledJob{
+				task6.job,
+				pred1.job,
+			},
+		})
+		tasks = append(tasks, task7)
+
+		if err := sched.Wait(ctx); err != nil {
+			flowEmitter.FlowError(ctx, err)
+			cff.RethrowPanic(err, false)
+			return err
+		}
+
+		flowEmitter.FlowSuccess(ctx)
+		return nil
+	}()
+}
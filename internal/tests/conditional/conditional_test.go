@@ -0,0 +1,76 @@
+package conditional
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/cff"
+	"go.uber.org/cff/internal/emittertest"
+)
+
+func TestLookup(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("produced", func(t *testing.T) {
+		e := emittertest.NewRecorder()
+		r, err := Lookup(ctx, e, &User{Name: "bob", ManagerID: 1})
+		require.NoError(t, err)
+		assert.Equal(t, &Report{User: "bob", Manager: "manager: alice", HasManager: true}, r)
+		assert.Empty(t, e.EventsOf(emittertest.TaskSkipped))
+	})
+
+	t.Run("not produced", func(t *testing.T) {
+		e := emittertest.NewRecorder()
+		r, err := Lookup(ctx, e, &User{Name: "carol", ManagerID: 2})
+		require.NoError(t, err)
+		assert.Equal(t, &Report{User: "carol"}, r)
+		skipped := e.EventsOf(emittertest.TaskSkipped)
+		require.Len(t, skipped, 1)
+		assert.Equal(t, "formatManager", skipped[0].Task.Name)
+		err = skipped[0].Err
+		assert.ErrorIs(t, err, cff.ErrNotProduced)
+
+		var reason *cff.SkipReason
+		require.ErrorAs(t, err, &reason)
+		assert.Equal(t, cff.SkipNotProduced, reason.Kind)
+		assert.True(t, reason.Expected())
+	})
+}
+
+func TestLookupMaybe(t *testing.T) {
+	ctx := context.Background()
+
+	m, err := LookupMaybe(ctx, &User{ManagerID: 1})
+	require.NoError(t, err)
+	assert.Equal(t, cff.Some(&Manager{Name: "alice"}), m)
+
+	m, err = LookupMaybe(ctx, &User{ManagerID: 2})
+	require.NoError(t, err)
+	assert.False(t, m.Valid)
+}
+
+func TestLookupErr(t *testing.T) {
+	ctx := context.Background()
+
+	s, err := LookupErr(ctx, &User{ManagerID: 1})
+	require.NoError(t, err)
+	assert.Equal(t, "alice", s)
+
+	s, err = LookupErr(ctx, &User{ManagerID: 2})
+	require.NoError(t, err)
+	assert.Empty(t, s)
+
+	_, err = LookupErr(ctx, &User{ManagerID: -1})
+	assert.ErrorContains(t, err, "invalid manager ID")
+}
+
+func TestNotify(t *testing.T) {
+	ctx := context.Background()
+
+	var notified []string
+	require.NoError(t, Notify(ctx, &User{ManagerID: 1}, &notified))
+	require.NoError(t, Notify(ctx, &User{ManagerID: 2}, &notified))
+	assert.Equal(t, []string{"alice"}, notified)
+}
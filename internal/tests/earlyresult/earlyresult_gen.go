@@ -53,6 +53,7 @@ func EarlyResult(ctx context.Context) error {
 		ctx := _23_3
 		var v1 int = _24_14
 		emitter := cff.NopEmitter()
+		interceptor := cff.InterceptorStack()
 
 		var (
 			flowInfo = &cff.FlowInfo{
@@ -90,11 +91,13 @@ func EarlyResult(ctx context.Context) error {
 			ran     cff.AtomicBool
 			run     func(context.Context) error
 			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
 		}
 		defer func() {
 			for _, t := range tasks {
 				if !t.ran.Load() {
-					t.emitter.TaskSkipped(ctx, err)
+					t.emitter.TaskSkipped(ctx, t.outcome.SkipReason(ctx, err))
 				}
 			}
 		}()
@@ -103,17 +106,26 @@ func EarlyResult(ctx context.Context) error {
 		var (
 			v2 *foo
 		)
+
 		task3 := new(struct {
 			emitter cff.TaskEmitter
 			ran     cff.AtomicBool
 			run     func(context.Context) error
 			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
 		})
+		task3.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/earlyresult/earlyresult.go",
+			Line:   39,
+			Column: 4,
+		}
 		task3.emitter = cff.NopTaskEmitter()
 		task3.run = func(ctx context.Context) (err error) {
 			taskEmitter := task3.emitter
 			startTime := time.Now()
 			defer func() {
+				task3.outcome.Finish(err)
 				if task3.ran.Load() {
 					taskEmitter.TaskDone(ctx, time.Since(startTime))
 				}
@@ -132,9 +144,16 @@ func EarlyResult(ctx context.Context) error {
 
 			defer task3.ran.Store(true)
 
-			v2 = _39_4(v1)
-
-			taskEmitter.TaskSuccess(ctx)
+			err = cff.Intercept(ctx, interceptor, task3.outcome.Info, func(ctx context.Context) (err error) {
+				v2 = _39_4(v1)
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return err
+			} else {
+				taskEmitter.TaskSuccess(ctx)
+			}
 
 			return
 		}
@@ -148,17 +167,29 @@ func EarlyResult(ctx context.Context) error {
 		var (
 			v3 *bar
 		)
+
 		task0 := new(struct {
 			emitter cff.TaskEmitter
 			ran     cff.AtomicBool
 			run     func(context.Context) error
 			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
 		})
+		task0.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/earlyresult/earlyresult.go",
+			Line:   27,
+			Column: 4,
+		}
+		task0.outcome.Dependencies = []*cff.TaskOutcome{
+			&task3.outcome,
+		}
 		task0.emitter = cff.NopTaskEmitter()
 		task0.run = func(ctx context.Context) (err error) {
 			taskEmitter := task0.emitter
 			startTime := time.Now()
 			defer func() {
+				task0.outcome.Finish(err)
 				if task0.ran.Load() {
 					taskEmitter.TaskDone(ctx, time.Since(startTime))
 				}
@@ -177,9 +208,16 @@ func EarlyResult(ctx context.Context) error {
 
 			defer task0.ran.Store(true)
 
-			v3 = _27_4(v2)
-
-			taskEmitter.TaskSuccess(ctx)
+			err = cff.Intercept(ctx, interceptor, task0.outcome.Info, func(ctx context.Context) (err error) {
+				v3 = _27_4(v2)
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return err
+			} else {
+				taskEmitter.TaskSuccess(ctx)
+			}
 
 			return
 		}
@@ -196,17 +234,29 @@ func EarlyResult(ctx context.Context) error {
 		var (
 			v4 *baz
 		)
+
 		task1 := new(struct {
 			emitter cff.TaskEmitter
 			ran     cff.AtomicBool
 			run     func(context.Context) error
 			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
 		})
+		task1.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/earlyresult/earlyresult.go",
+			Line:   31,
+			Column: 4,
+		}
+		task1.outcome.Dependencies = []*cff.TaskOutcome{
+			&task3.outcome,
+		}
 		task1.emitter = cff.NopTaskEmitter()
 		task1.run = func(ctx context.Context) (err error) {
 			taskEmitter := task1.emitter
 			startTime := time.Now()
 			defer func() {
+				task1.outcome.Finish(err)
 				if task1.ran.Load() {
 					taskEmitter.TaskDone(ctx, time.Since(startTime))
 				}
@@ -225,9 +275,16 @@ func EarlyResult(ctx context.Context) error {
 
 			defer task1.ran.Store(true)
 
-			v4 = _31_4(v2)
-
-			taskEmitter.TaskSuccess(ctx)
+			err = cff.Intercept(ctx, interceptor, task1.outcome.Info, func(ctx context.Context) (err error) {
+				v4 = _31_4(v2)
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return err
+			} else {
+				taskEmitter.TaskSuccess(ctx)
+			}
 
 			return
 		}
@@ -244,17 +301,30 @@ func EarlyResult(ctx context.Context) error {
 		var (
 			v5 *qux
 		)
+
 		task2 := new(struct {
 			emitter cff.TaskEmitter
 			ran     cff.AtomicBool
 			run     func(context.Context) error
 			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
 		})
+		task2.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/earlyresult/earlyresult.go",
+			Line:   35,
+			Column: 4,
+		}
+		task2.outcome.Dependencies = []*cff.TaskOutcome{
+			&task0.outcome,
+			&task1.outcome,
+		}
 		task2.emitter = cff.NopTaskEmitter()
 		task2.run = func(ctx context.Context) (err error) {
 			taskEmitter := task2.emitter
 			startTime := time.Now()
 			defer func() {
+				task2.outcome.Finish(err)
 				if task2.ran.Load() {
 					taskEmitter.TaskDone(ctx, time.Since(startTime))
 				}
@@ -273,9 +343,16 @@ func EarlyResult(ctx context.Context) error {
 
 			defer task2.ran.Store(true)
 
-			v5 = _35_4(v3, v4)
-
-			taskEmitter.TaskSuccess(ctx)
+			err = cff.Intercept(ctx, interceptor, task2.outcome.Info, func(ctx context.Context) (err error) {
+				v5 = _35_4(v3, v4)
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return err
+			} else {
+				taskEmitter.TaskSuccess(ctx)
+			}
 
 			return
 		}
@@ -295,12 +372,23 @@ func EarlyResult(ctx context.Context) error {
 			ran     cff.AtomicBool
 			run     func(context.Context) error
 			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
 		})
+		task4.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/earlyresult/earlyresult.go",
+			Line:   43,
+			Column: 4,
+		}
+		task4.outcome.Dependencies = []*cff.TaskOutcome{
+			&task2.outcome,
+		}
 		task4.emitter = cff.NopTaskEmitter()
 		task4.run = func(ctx context.Context) (err error) {
 			taskEmitter := task4.emitter
 			startTime := time.Now()
 			defer func() {
+				task4.outcome.Finish(err)
 				if task4.ran.Load() {
 					taskEmitter.TaskDone(ctx, time.Since(startTime))
 				}
@@ -319,8 +407,10 @@ func EarlyResult(ctx context.Context) error {
 
 			defer task4.ran.Store(true)
 
-			err = _43_4(v5)
-
+			err = cff.Intercept(ctx, interceptor, task4.outcome.Info, func(ctx context.Context) (err error) {
+				err = _43_4(v5)
+				return
+			})
 			if err != nil {
 				taskEmitter.TaskError(ctx, err)
 				return err
@@ -341,6 +431,7 @@ func EarlyResult(ctx context.Context) error {
 
 		if err := sched.Wait(ctx); err != nil {
 			flowEmitter.FlowError(ctx, err)
+			cff.RethrowPanic(err, false)
 			return err
 		}
 
@@ -398,6 +489,7 @@ func ConsumesResult() error {
 		ctx := _71_18
 		var v6 *t1 = _73_14
 		emitter := cff.NopEmitter()
+		interceptor := cff.InterceptorStack()
 
 		var (
 			flowInfo = &cff.FlowInfo{
@@ -435,11 +527,13 @@ func ConsumesResult() error {
 			ran     cff.AtomicBool
 			run     func(context.Context) error
 			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
 		}
 		defer func() {
 			for _, t := range tasks {
 				if !t.ran.Load() {
-					t.emitter.TaskSkipped(ctx, err)
+					t.emitter.TaskSkipped(ctx, t.outcome.SkipReason(ctx, err))
 				}
 			}
 		}()
@@ -448,17 +542,26 @@ func ConsumesResult() error {
 		var (
 			v7 *t2
 		)
+
 		task5 := new(struct {
 			emitter cff.TaskEmitter
 			ran     cff.AtomicBool
 			run     func(context.Context) error
 			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
 		})
+		task5.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/earlyresult/earlyresult.go",
+			Line:   76,
+			Column: 4,
+		}
 		task5.emitter = cff.NopTaskEmitter()
 		task5.run = func(ctx context.Context) (err error) {
 			taskEmitter := task5.emitter
 			startTime := time.Now()
 			defer func() {
+				task5.outcome.Finish(err)
 				if task5.ran.Load() {
 					taskEmitter.TaskDone(ctx, time.Since(startTime))
 				}
@@ -477,9 +580,16 @@ func ConsumesResult() error {
 
 			defer task5.ran.Store(true)
 
-			v7 = _76_4(v6)
-
-			taskEmitter.TaskSuccess(ctx)
+			err = cff.Intercept(ctx, interceptor, task5.outcome.Info, func(ctx context.Context) (err error) {
+				v7 = _76_4(v6)
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return err
+			} else {
+				taskEmitter.TaskSuccess(ctx)
+			}
 
 			return
 		}
@@ -493,17 +603,29 @@ func ConsumesResult() error {
 		var (
 			v8 *t4
 		)
+
 		task7 := new(struct {
 			emitter cff.TaskEmitter
 			ran     cff.AtomicBool
 			run     func(context.Context) error
 			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
 		})
+		task7.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/earlyresult/earlyresult.go",
+			Line:   84,
+			Column: 4,
+		}
+		task7.outcome.Dependencies = []*cff.TaskOutcome{
+			&task5.outcome,
+		}
 		task7.emitter = cff.NopTaskEmitter()
 		task7.run = func(ctx context.Context) (err error) {
 			taskEmitter := task7.emitter
 			startTime := time.Now()
 			defer func() {
+				task7.outcome.Finish(err)
 				if task7.ran.Load() {
 					taskEmitter.TaskDone(ctx, time.Since(startTime))
 				}
@@ -522,8 +644,10 @@ func ConsumesResult() error {
 
 			defer task7.ran.Store(true)
 
-			v8, err = _84_4(v7)
-
+			err = cff.Intercept(ctx, interceptor, task7.outcome.Info, func(ctx context.Context) (err error) {
+				v8, err = _84_4(v7)
+				return
+			})
 			if err != nil {
 				taskEmitter.TaskError(ctx, err)
 				return err
@@ -546,17 +670,29 @@ func ConsumesResult() error {
 		var (
 			v9 *t5
 		)
+
 		task6 := new(struct {
 			emitter cff.TaskEmitter
 			ran     cff.AtomicBool
 			run     func(context.Context) error
 			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
 		})
+		task6.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/earlyresult/earlyresult.go",
+			Line:   80,
+			Column: 4,
+		}
+		task6.outcome.Dependencies = []*cff.TaskOutcome{
+			&task7.outcome,
+		}
 		task6.emitter = cff.NopTaskEmitter()
 		task6.run = func(ctx context.Context) (err error) {
 			taskEmitter := task6.emitter
 			startTime := time.Now()
 			defer func() {
+				task6.outcome.Finish(err)
 				if task6.ran.Load() {
 					taskEmitter.TaskDone(ctx, time.Since(startTime))
 				}
@@ -575,9 +711,16 @@ func ConsumesResult() error {
 
 			defer task6.ran.Store(true)
 
-			v9 = _80_4(v8)
-
-			taskEmitter.TaskSuccess(ctx)
+			err = cff.Intercept(ctx, interceptor, task6.outcome.Info, func(ctx context.Context) (err error) {
+				v9 = _80_4(v8)
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return err
+			} else {
+				taskEmitter.TaskSuccess(ctx)
+			}
 
 			return
 		}
@@ -594,17 +737,29 @@ func ConsumesResult() error {
 		var (
 			v10 *t3
 		)
+
 		task8 := new(struct {
 			emitter cff.TaskEmitter
 			ran     cff.AtomicBool
 			run     func(context.Context) error
 			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
 		})
+		task8.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/earlyresult/earlyresult.go",
+			Line:   89,
+			Column: 4,
+		}
+		task8.outcome.Dependencies = []*cff.TaskOutcome{
+			&task6.outcome,
+		}
 		task8.emitter = cff.NopTaskEmitter()
 		task8.run = func(ctx context.Context) (err error) {
 			taskEmitter := task8.emitter
 			startTime := time.Now()
 			defer func() {
+				task8.outcome.Finish(err)
 				if task8.ran.Load() {
 					taskEmitter.TaskDone(ctx, time.Since(startTime))
 				}
@@ -623,8 +778,10 @@ func ConsumesResult() error {
 
 			defer task8.ran.Store(true)
 
-			v10, err = _89_4(v9)
-
+			err = cff.Intercept(ctx, interceptor, task8.outcome.Info, func(ctx context.Context) (err error) {
+				v10, err = _89_4(v9)
+				return
+			})
 			if err != nil {
 				taskEmitter.TaskError(ctx, err)
 				return err
@@ -647,17 +804,29 @@ func ConsumesResult() error {
 		var (
 			v11 *t6
 		)
+
 		task9 := new(struct {
 			emitter cff.TaskEmitter
 			ran     cff.AtomicBool
 			run     func(context.Context) error
 			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
 		})
+		task9.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/earlyresult/earlyresult.go",
+			Line:   94,
+			Column: 4,
+		}
+		task9.outcome.Dependencies = []*cff.TaskOutcome{
+			&task8.outcome,
+		}
 		task9.emitter = cff.NopTaskEmitter()
 		task9.run = func(ctx context.Context) (err error) {
 			taskEmitter := task9.emitter
 			startTime := time.Now()
 			defer func() {
+				task9.outcome.Finish(err)
 				if task9.ran.Load() {
 					taskEmitter.TaskDone(ctx, time.Since(startTime))
 				}
@@ -676,9 +845,16 @@ func ConsumesResult() error {
 
 			defer task9.ran.Store(true)
 
-			v11 = _94_4(v10)
-
-			taskEmitter.TaskSuccess(ctx)
+			err = cff.Intercept(ctx, interceptor, task9.outcome.Info, func(ctx context.Context) (err error) {
+				v11 = _94_4(v10)
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return err
+			} else {
+				taskEmitter.TaskSuccess(ctx)
+			}
 
 			return
 		}
@@ -695,17 +871,29 @@ func ConsumesResult() error {
 		var (
 			v12 *t7
 		)
+
 		task10 := new(struct {
 			emitter cff.TaskEmitter
 			ran     cff.AtomicBool
 			run     func(context.Context) error
 			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
 		})
+		task10.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/earlyresult/earlyresult.go",
+			Line:   98,
+			Column: 4,
+		}
+		task10.outcome.Dependencies = []*cff.TaskOutcome{
+			&task9.outcome,
+		}
 		task10.emitter = cff.NopTaskEmitter()
 		task10.run = func(ctx context.Context) (err error) {
 			taskEmitter := task10.emitter
 			startTime := time.Now()
 			defer func() {
+				task10.outcome.Finish(err)
 				if task10.ran.Load() {
 					taskEmitter.TaskDone(ctx, time.Since(startTime))
 				}
@@ -724,8 +912,10 @@ func ConsumesResult() error {
 
 			defer task10.ran.Store(true)
 
-			v12, err = _98_4(v11)
-
+			err = cff.Intercept(ctx, interceptor, task10.outcome.Info, func(ctx context.Context) (err error) {
+				v12, err = _98_4(v11)
+				return
+			})
 			if err != nil {
 				taskEmitter.TaskError(ctx, err)
 				return err
@@ -750,12 +940,23 @@ func ConsumesResult() error {
 			ran     cff.AtomicBool
 			run     func(context.Context) error
 			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
 		})
+		task11.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/earlyresult/earlyresult.go",
+			Line:   101,
+			Column: 4,
+		}
+		task11.outcome.Dependencies = []*cff.TaskOutcome{
+			&task10.outcome,
+		}
 		task11.emitter = cff.NopTaskEmitter()
 		task11.run = func(ctx context.Context) (err error) {
 			taskEmitter := task11.emitter
 			startTime := time.Now()
 			defer func() {
+				task11.outcome.Finish(err)
 				if task11.ran.Load() {
 					taskEmitter.TaskDone(ctx, time.Since(startTime))
 				}
@@ -774,8 +975,10 @@ func ConsumesResult() error {
 
 			defer task11.ran.Store(true)
 
-			err = _101_4(v12)
-
+			err = cff.Intercept(ctx, interceptor, task11.outcome.Info, func(ctx context.Context) (err error) {
+				err = _101_4(v12)
+				return
+			})
 			if err != nil {
 				taskEmitter.TaskError(ctx, err)
 				return err
@@ -796,6 +999,7 @@ func ConsumesResult() error {
 
 		if err := sched.Wait(ctx); err != nil {
 			flowEmitter.FlowError(ctx, err)
+			cff.RethrowPanic(err, false)
 			return err
 		}
 
@@ -0,0 +1,138 @@
+//go:build cff
+// +build cff
+
+// Package group tests flows with tasks grouped by cff.Group and cff.InGroup.
+package group
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/cff"
+)
+
+// tracker records the highest number of tasks that ran at the same time.
+type tracker struct {
+	running, peak int64
+}
+
+func (t *tracker) track() {
+	n := atomic.AddInt64(&t.running, 1)
+	defer atomic.AddInt64(&t.running, -1)
+	for {
+		p := atomic.LoadInt64(&t.peak)
+		if n <= p || atomic.CompareAndSwapInt64(&t.peak, p, n) {
+			break
+		}
+	}
+	time.Sleep(5 * time.Millisecond)
+}
+
+// Limited runs four tasks in a group limited to two tasks at a time,
+// alongside two tasks outside the group, in a flow that would otherwise
+// run them all concurrently.
+// It reports the highest number of tasks of the group that ran at the
+// same time.
+func Limited(ctx context.Context) (int, error) {
+	var db tracker
+
+	var s string
+	err := cff.Flow(ctx,
+		cff.Concurrency(6),
+		cff.Group("db", 2),
+		cff.Results(&s),
+		cff.Task(func() int8 {
+			db.track()
+			return 1
+		}, cff.InGroup("db")),
+		cff.Task(func() int16 {
+			db.track()
+			return 2
+		}, cff.InGroup("db")),
+		cff.Task(func() int32 {
+			db.track()
+			return 3
+		}, cff.InGroup("db")),
+		cff.Task(func() int64 {
+			db.track()
+			return 4
+		}, cff.InGroup("db")),
+		cff.Task(func() uint8 {
+			time.Sleep(5 * time.Millisecond)
+			return 5
+		}),
+		cff.Task(func() uint16 {
+			time.Sleep(5 * time.Millisecond)
+			return 6
+		}),
+		cff.Task(func(a int8, b int16, c int32, d int64, e uint8, f uint16) string {
+			return fmt.Sprint(int(a) + int(b) + int(c) + int(d) + int(e) + int(f))
+		}),
+	)
+	if err != nil {
+		return 0, err
+	}
+	if s != "21" {
+		return 0, fmt.Errorf("unexpected result: %v", s)
+	}
+	return int(db.peak), nil
+}
+
+// WithSubflow runs tasks of a group limited to one task at a time,
+// some of which belong to a subflow that would run them concurrently.
+// It reports the highest number of tasks of the group that ran at the
+// same time.
+func WithSubflow(ctx context.Context) (int, error) {
+	var db tracker
+
+	var s string
+	err := cff.Flow(ctx,
+		cff.Concurrency(4),
+		cff.Group("db", 1),
+		cff.Results(&s),
+		cff.Subflow(
+			cff.Concurrency(2),
+			cff.Task(func() int {
+				db.track()
+				return 1
+			}, cff.InGroup("db")),
+			cff.Task(func() int64 {
+				db.track()
+				return 2
+			}, cff.InGroup("db")),
+		),
+		cff.Task(func() uint {
+			db.track()
+			return 3
+		}, cff.InGroup("db")),
+		cff.Task(func(a int, b int64, c uint) string {
+			return fmt.Sprint(a + int(b) + int(c))
+		}),
+	)
+	if err != nil {
+		return 0, err
+	}
+	if s != "6" {
+		return 0, fmt.Errorf("unexpected result: %v", s)
+	}
+	return int(db.peak), nil
+}
+
+// Unlimited runs tasks of a group that imposes no limit.
+func Unlimited(ctx context.Context, n int) (int, error) {
+	var s string
+	err := cff.Flow(ctx,
+		cff.Group("db", n),
+		cff.Params(n),
+		cff.Results(&s),
+		cff.Task(func(n int) int64 {
+			return int64(n)
+		}, cff.InGroup("db")),
+		cff.Task(func(i int64) string {
+			return fmt.Sprint(i)
+		}, cff.InGroup("db")),
+	)
+	return len(s), err
+}
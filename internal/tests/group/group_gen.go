@@ -0,0 +1,1204 @@
+//go:build !cff
+// +build !cff
+
+// Package group tests flows with tasks grouped by cff.Group and cff.InGroup.
+package group
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/cff"
+)
+
+// tracker records the highest number of tasks that ran at the same time.
+type tracker struct {
+	running, peak int64
+}
+
+func (t *tracker) track() {
+	n := atomic.AddInt64(&t.running, 1)
+	defer atomic.AddInt64(&t.running, -1)
+	for {
+		p := atomic.LoadInt64(&t.peak)
+		if n <= p || atomic.CompareAndSwapInt64(&t.peak, p, n) {
+			break
+		}
+	}
+	time.Sleep(5 * time.Millisecond)
+}
+
+// Limited runs four tasks in a group limited to two tasks at a time,
+// alongside two tasks outside the group, in a flow that would otherwise
+// run them all concurrently.
+// It reports the highest number of tasks of the group that ran at the
+// same time.
+func Limited(ctx context.Context) (int, error) {
+	var db tracker
+
+	var s string
+	err := func() (err error) {
+
+		_42_18 := ctx
+
+		_43_19 := 6
+
+		_44_19 := 2
+
+		_45_15 := &s
+
+		_46_12 := func() int8 {
+			db.track()
+			return 1
+		}
+
+		_50_12 := func() int16 {
+			db.track()
+			return 2
+		}
+
+		_54_12 := func() int32 {
+			db.track()
+			return 3
+		}
+
+		_58_12 := func() int64 {
+			db.track()
+			return 4
+		}
+
+		_62_12 := func() uint8 {
+			time.Sleep(5 * time.Millisecond)
+			return 5
+		}
+
+		_66_12 := func() uint16 {
+			time.Sleep(5 * time.Millisecond)
+			return 6
+		}
+
+		_70_12 := func(a int8, b int16, c int32, d int64, e uint8, f uint16) string {
+			return fmt.Sprint(int(a) + int(b) + int(c) + int(d) + int(e) + int(f))
+		}
+		ctx := _42_18
+		emitter := cff.NopEmitter()
+		interceptor := cff.InterceptorStack()
+
+		var (
+			flowInfo = &cff.FlowInfo{
+				File:   "go.uber.org/cff/internal/tests/group/group.go",
+				Line:   42,
+				Column: 9,
+			}
+			flowEmitter = cff.NopFlowEmitter()
+
+			schedInfo = &cff.SchedulerInfo{
+				Name:      flowInfo.Name,
+				Directive: cff.FlowDirective,
+				File:      flowInfo.File,
+				Line:      flowInfo.Line,
+				Column:    flowInfo.Column,
+			}
+
+			// possibly unused
+			_ = flowInfo
+		)
+
+		startTime := time.Now()
+		defer func() { flowEmitter.FlowDone(ctx, time.Since(startTime)) }()
+
+		schedEmitter := emitter.SchedulerInit(schedInfo)
+
+		sched := cff.NewScheduler(
+			cff.SchedulerParams{
+				Concurrency: _43_19, Emitter: schedEmitter,
+			},
+		)
+
+		group0 := &cff.JobGroup{Name: "db", Concurrency: _44_19}
+		_ = group0 // possibly unused
+
+		var tasks []*struct {
+			emitter cff.TaskEmitter
+			ran     cff.AtomicBool
+			run     func(context.Context) error
+			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		}
+		defer func() {
+			for _, t := range tasks {
+				if !t.ran.Load() {
+					t.emitter.TaskSkipped(ctx, t.outcome.SkipReason(ctx, err))
+				}
+			}
+		}()
+
+		// go.uber.org/cff/internal/tests/group/group.go:46:12
+		var (
+			v1 int8
+		)
+
+		task0 := new(struct {
+			emitter cff.TaskEmitter
+			ran     cff.AtomicBool
+			run     func(context.Context) error
+			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		})
+		task0.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/group/group.go",
+			Line:   46,
+			Column: 12,
+		}
+		task0.emitter = cff.NopTaskEmitter()
+		task0.run = func(ctx context.Context) (err error) {
+			taskEmitter := task0.emitter
+			startTime := time.Now()
+			defer func() {
+				task0.outcome.Finish(err)
+				if task0.ran.Load() {
+					taskEmitter.TaskDone(ctx, time.Since(startTime))
+				}
+			}()
+
+			defer func() {
+				recovered := recover()
+				if recovered != nil {
+					taskEmitter.TaskPanic(ctx, recovered)
+					err = &cff.PanicError{
+						Value:      recovered,
+						Stacktrace: debug.Stack(),
+					}
+				}
+			}()
+
+			defer task0.ran.Store(true)
+
+			err = cff.Intercept(ctx, interceptor, task0.outcome.Info, func(ctx context.Context) (err error) {
+				v1 = _46_12()
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return err
+			} else {
+				taskEmitter.TaskSuccess(ctx)
+			}
+
+			return
+		}
+
+		task0.job = sched.Enqueue(ctx, cff.Job{
+			Run: task0.run,
+
+			Groups: []*cff.JobGroup{group0},
+		})
+		tasks = append(tasks, task0)
+
+		// go.uber.org/cff/internal/tests/group/group.go:50:12
+		var (
+			v2 int16
+		)
+
+		task1 := new(struct {
+			emitter cff.TaskEmitter
+			ran     cff.AtomicBool
+			run     func(context.Context) error
+			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		})
+		task1.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/group/group.go",
+			Line:   50,
+			Column: 12,
+		}
+		task1.emitter = cff.NopTaskEmitter()
+		task1.run = func(ctx context.Context) (err error) {
+			taskEmitter := task1.emitter
+			startTime := time.Now()
+			defer func() {
+				task1.outcome.Finish(err)
+				if task1.ran.Load() {
+					taskEmitter.TaskDone(ctx, time.Since(startTime))
+				}
+			}()
+
+			defer func() {
+				recovered := recover()
+				if recovered != nil {
+					taskEmitter.TaskPanic(ctx, recovered)
+					err = &cff.PanicError{
+						Value:      recovered,
+						Stacktrace: debug.Stack(),
+					}
+				}
+			}()
+
+			defer task1.ran.Store(true)
+
+			err = cff.Intercept(ctx, interceptor, task1.outcome.Info, func(ctx context.Context) (err error) {
+				v2 = _50_12()
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return err
+			} else {
+				taskEmitter.TaskSuccess(ctx)
+			}
+
+			return
+		}
+
+		task1.job = sched.Enqueue(ctx, cff.Job{
+			Run: task1.run,
+
+			Groups: []*cff.JobGroup{group0},
+		})
+		tasks = append(tasks, task1)
+
+		// go.uber.org/cff/internal/tests/group/group.go:54:12
+		var (
+			v3 int32
+		)
+
+		task2 := new(struct {
+			emitter cff.TaskEmitter
+			ran     cff.AtomicBool
+			run     func(context.Context) error
+			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		})
+		task2.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/group/group.go",
+			Line:   54,
+			Column: 12,
+		}
+		task2.emitter = cff.NopTaskEmitter()
+		task2.run = func(ctx context.Context) (err error) {
+			taskEmitter := task2.emitter
+			startTime := time.Now()
+			defer func() {
+				task2.outcome.Finish(err)
+				if task2.ran.Load() {
+					taskEmitter.TaskDone(ctx, time.Since(startTime))
+				}
+			}()
+
+			defer func() {
+				recovered := recover()
+				if recovered != nil {
+					taskEmitter.TaskPanic(ctx, recovered)
+					err = &cff.PanicError{
+						Value:      recovered,
+						Stacktrace: debug.Stack(),
+					}
+				}
+			}()
+
+			defer task2.ran.Store(true)
+
+			err = cff.Intercept(ctx, interceptor, task2.outcome.Info, func(ctx context.Context) (err error) {
+				v3 = _54_12()
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return err
+			} else {
+				taskEmitter.TaskSuccess(ctx)
+			}
+
+			return
+		}
+
+		task2.job = sched.Enqueue(ctx, cff.Job{
+			Run: task2.run,
+
+			Groups: []*cff.JobGroup{group0},
+		})
+		tasks = append(tasks, task2)
+
+		// go.uber.org/cff/internal/tests/group/group.go:58:12
+		var (
+			v4 int64
+		)
+
+		task3 := new(struct {
+			emitter cff.TaskEmitter
+			ran     cff.AtomicBool
+			run     func(context.Context) error
+			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		})
+		task3.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/group/group.go",
+			Line:   58,
+			Column: 12,
+		}
+		task3.emitter = cff.NopTaskEmitter()
+		task3.run = func(ctx context.Context) (err error) {
+			taskEmitter := task3.emitter
+			startTime := time.Now()
+			defer func() {
+				task3.outcome.Finish(err)
+				if task3.ran.Load() {
+					taskEmitter.TaskDone(ctx, time.Since(startTime))
+				}
+			}()
+
+			defer func() {
+				recovered := recover()
+				if recovered != nil {
+					taskEmitter.TaskPanic(ctx, recovered)
+					err = &cff.PanicError{
+						Value:      recovered,
+						Stacktrace: debug.Stack(),
+					}
+				}
+			}()
+
+			defer task3.ran.Store(true)
+
+			err = cff.Intercept(ctx, interceptor, task3.outcome.Info, func(ctx context.Context) (err error) {
+				v4 = _58_12()
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return err
+			} else {
+				taskEmitter.TaskSuccess(ctx)
+			}
+
+			return
+		}
+
+		task3.job = sched.Enqueue(ctx, cff.Job{
+			Run: task3.run,
+
+			Groups: []*cff.JobGroup{group0},
+		})
+		tasks = append(tasks, task3)
+
+		// go.uber.org/cff/internal/tests/group/group.go:62:12
+		var (
+			v5 uint8
+		)
+
+		task4 := new(struct {
+			emitter cff.TaskEmitter
+			ran     cff.AtomicBool
+			run     func(context.Context) error
+			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		})
+		task4.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/group/group.go",
+			Line:   62,
+			Column: 12,
+		}
+		task4.emitter = cff.NopTaskEmitter()
+		task4.run = func(ctx context.Context) (err error) {
+			taskEmitter := task4.emitter
+			startTime := time.Now()
+			defer func() {
+				task4.outcome.Finish(err)
+				if task4.ran.Load() {
+					taskEmitter.TaskDone(ctx, time.Since(startTime))
+				}
+			}()
+
+			defer func() {
+				recovered := recover()
+				if recovered != nil {
+					taskEmitter.TaskPanic(ctx, recovered)
+					err = &cff.PanicError{
+						Value:      recovered,
+						Stacktrace: debug.Stack(),
+					}
+				}
+			}()
+
+			defer task4.ran.Store(true)
+
+			err = cff.Intercept(ctx, interceptor, task4.outcome.Info, func(ctx context.Context) (err error) {
+				v5 = _62_12()
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return err
+			} else {
+				taskEmitter.TaskSuccess(ctx)
+			}
+
+			return
+		}
+
+		task4.job = sched.Enqueue(ctx, cff.Job{
+			Run: task4.run,
+		})
+		tasks = append(tasks, task4)
+
+		// go.uber.org/cff/internal/tests/group/group.go:66:12
+		var (
+			v6 uint16
+		)
+
+		task5 := new(struct {
+			emitter cff.TaskEmitter
+			ran     cff.AtomicBool
+			run     func(context.Context) error
+			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		})
+		task5.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/group/group.go",
+			Line:   66,
+			Column: 12,
+		}
+		task5.emitter = cff.NopTaskEmitter()
+		task5.run = func(ctx context.Context) (err error) {
+			taskEmitter := task5.emitter
+			startTime := time.Now()
+			defer func() {
+				task5.outcome.Finish(err)
+				if task5.ran.Load() {
+					taskEmitter.TaskDone(ctx, time.Since(startTime))
+				}
+			}()
+
+			defer func() {
+				recovered := recover()
+				if recovered != nil {
+					taskEmitter.TaskPanic(ctx, recovered)
+					err = &cff.PanicError{
+						Value:      recovered,
+						Stacktrace: debug.Stack(),
+					}
+				}
+			}()
+
+			defer task5.ran.Store(true)
+
+			err = cff.Intercept(ctx, interceptor, task5.outcome.Info, func(ctx context.Context) (err error) {
+				v6 = _66_12()
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return err
+			} else {
+				taskEmitter.TaskSuccess(ctx)
+			}
+
+			return
+		}
+
+		task5.job = sched.Enqueue(ctx, cff.Job{
+			Run: task5.run,
+		})
+		tasks = append(tasks, task5)
+
+		// go.uber.org/cff/internal/tests/group/group.go:70:12
+		var (
+			v7 string
+		)
+
+		task6 := new(struct {
+			emitter cff.TaskEmitter
+			ran     cff.AtomicBool
+			run     func(context.Context) error
+			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		})
+		task6.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/group/group.go",
+			Line:   70,
+			Column: 12,
+		}
+		task6.outcome.Dependencies = []*cff.TaskOutcome{
+			&task0.outcome,
+			&task1.outcome,
+			&task2.outcome,
+			&task3.outcome,
+			&task4.outcome,
+			&task5.outcome,
+		}
+		task6.emitter = cff.NopTaskEmitter()
+		task6.run = func(ctx context.Context) (err error) {
+			taskEmitter := task6.emitter
+			startTime := time.Now()
+			defer func() {
+				task6.outcome.Finish(err)
+				if task6.ran.Load() {
+					taskEmitter.TaskDone(ctx, time.Since(startTime))
+				}
+			}()
+
+			defer func() {
+				recovered := recover()
+				if recovered != nil {
+					taskEmitter.TaskPanic(ctx, recovered)
+					err = &cff.PanicError{
+						Value:      recovered,
+						Stacktrace: debug.Stack(),
+					}
+				}
+			}()
+
+			defer task6.ran.Store(true)
+
+			err = cff.Intercept(ctx, interceptor, task6.outcome.Info, func(ctx context.Context) (err error) {
+				v7 = _70_12(v1, v2, v3, v4, v5, v6)
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return err
+			} else {
+				taskEmitter.TaskSuccess(ctx)
+			}
+
+			return
+		}
+
+		task6.job = sched.Enqueue(ctx, cff.Job{
+			Run: task6.run,
+			Dependencies: []*cff.ScheduledJob{
+				task0.job,
+				task1.job,
+				task2.job,
+				task3.job,
+				task4.job,
+				task5.job,
+			},
+		})
+		tasks = append(tasks, task6)
+
+		if err := sched.Wait(ctx); err != nil {
+			flowEmitter.FlowError(ctx, err)
+			cff.RethrowPanic(err, false)
+			return err
+		}
+
+		*(_45_15) = v7 // string
+
+		flowEmitter.FlowSuccess(ctx)
+		return nil
+	}()
+	if err != nil {
+		return 0, err
+	}
+	if s != "21" {
+		return 0, fmt.Errorf("unexpected result: %v", s)
+	}
+	return int(db.peak), nil
+}
+
+// WithSubflow runs tasks of a group limited to one task at a time,
+// some of which belong to a subflow that would run them concurrently.
+// It reports the highest number of tasks of the group that ran at the
+// same time.
+func WithSubflow(ctx context.Context) (int, error) {
+	var db tracker
+
+	var s string
+	err := func() (err error) {
+
+		_91_18 := ctx
+
+		_92_19 := 4
+
+		_93_19 := 1
+
+		_94_15 := &s
+
+		_96_20 := 2
+
+		_97_13 := func() int {
+			db.track()
+			return 1
+		}
+
+		_101_13 := func() int64 {
+			db.track()
+			return 2
+		}
+
+		_106_12 := func() uint {
+			db.track()
+			return 3
+		}
+
+		_110_12 := func(a int, b int64, c uint) string {
+			return fmt.Sprint(a + int(b) + int(c))
+		}
+		ctx := _91_18
+		emitter := cff.NopEmitter()
+		interceptor := cff.InterceptorStack()
+
+		var (
+			flowInfo = &cff.FlowInfo{
+				File:   "go.uber.org/cff/internal/tests/group/group.go",
+				Line:   91,
+				Column: 9,
+			}
+			flowEmitter = cff.NopFlowEmitter()
+
+			schedInfo = &cff.SchedulerInfo{
+				Name:      flowInfo.Name,
+				Directive: cff.FlowDirective,
+				File:      flowInfo.File,
+				Line:      flowInfo.Line,
+				Column:    flowInfo.Column,
+			}
+
+			// possibly unused
+			_ = flowInfo
+		)
+
+		startTime := time.Now()
+		defer func() { flowEmitter.FlowDone(ctx, time.Since(startTime)) }()
+
+		schedEmitter := emitter.SchedulerInit(schedInfo)
+
+		sched := cff.NewScheduler(
+			cff.SchedulerParams{
+				Concurrency: _92_19, Emitter: schedEmitter,
+			},
+		)
+
+		// go.uber.org/cff/internal/tests/group/group.go:95:3
+		subflow0Group := &cff.JobGroup{Concurrency: _96_20}
+
+		group0 := &cff.JobGroup{Name: "db", Concurrency: _93_19}
+		_ = group0 // possibly unused
+
+		var tasks []*struct {
+			emitter cff.TaskEmitter
+			ran     cff.AtomicBool
+			run     func(context.Context) error
+			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		}
+		defer func() {
+			for _, t := range tasks {
+				if !t.ran.Load() {
+					t.emitter.TaskSkipped(ctx, t.outcome.SkipReason(ctx, err))
+				}
+			}
+		}()
+
+		// go.uber.org/cff/internal/tests/group/group.go:97:13
+		var (
+			v8 int
+		)
+
+		task7 := new(struct {
+			emitter cff.TaskEmitter
+			ran     cff.AtomicBool
+			run     func(context.Context) error
+			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		})
+		task7.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/group/group.go",
+			Line:   97,
+			Column: 13,
+		}
+		task7.emitter = cff.NopTaskEmitter()
+		task7.run = func(ctx context.Context) (err error) {
+			taskEmitter := task7.emitter
+			startTime := time.Now()
+			defer func() {
+				task7.outcome.Finish(err)
+				if task7.ran.Load() {
+					taskEmitter.TaskDone(ctx, time.Since(startTime))
+				}
+			}()
+
+			defer func() {
+				recovered := recover()
+				if recovered != nil {
+					taskEmitter.TaskPanic(ctx, recovered)
+					err = &cff.PanicError{
+						Value:      recovered,
+						Stacktrace: debug.Stack(),
+					}
+				}
+			}()
+
+			defer task7.ran.Store(true)
+
+			err = cff.Intercept(ctx, interceptor, task7.outcome.Info, func(ctx context.Context) (err error) {
+				v8 = _97_13()
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return err
+			} else {
+				taskEmitter.TaskSuccess(ctx)
+			}
+
+			return
+		}
+
+		task7.job = sched.Enqueue(ctx, cff.Job{
+			Run: task7.run,
+
+			Groups: []*cff.JobGroup{subflow0Group, group0},
+		})
+		tasks = append(tasks, task7)
+
+		// go.uber.org/cff/internal/tests/group/group.go:101:13
+		var (
+			v4 int64
+		)
+
+		task8 := new(struct {
+			emitter cff.TaskEmitter
+			ran     cff.AtomicBool
+			run     func(context.Context) error
+			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		})
+		task8.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/group/group.go",
+			Line:   101,
+			Column: 13,
+		}
+		task8.emitter = cff.NopTaskEmitter()
+		task8.run = func(ctx context.Context) (err error) {
+			taskEmitter := task8.emitter
+			startTime := time.Now()
+			defer func() {
+				task8.outcome.Finish(err)
+				if task8.ran.Load() {
+					taskEmitter.TaskDone(ctx, time.Since(startTime))
+				}
+			}()
+
+			defer func() {
+				recovered := recover()
+				if recovered != nil {
+					taskEmitter.TaskPanic(ctx, recovered)
+					err = &cff.PanicError{
+						Value:      recovered,
+						Stacktrace: debug.Stack(),
+					}
+				}
+			}()
+
+			defer task8.ran.Store(true)
+
+			err = cff.Intercept(ctx, interceptor, task8.outcome.Info, func(ctx context.Context) (err error) {
+				v4 = _101_13()
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return err
+			} else {
+				taskEmitter.TaskSuccess(ctx)
+			}
+
+			return
+		}
+
+		task8.job = sched.Enqueue(ctx, cff.Job{
+			Run: task8.run,
+
+			Groups: []*cff.JobGroup{subflow0Group, group0},
+		})
+		tasks = append(tasks, task8)
+
+		// go.uber.org/cff/internal/tests/group/group.go:106:12
+		var (
+			v9 uint
+		)
+
+		task9 := new(struct {
+			emitter cff.TaskEmitter
+			ran     cff.AtomicBool
+			run     func(context.Context) error
+			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		})
+		task9.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/group/group.go",
+			Line:   106,
+			Column: 12,
+		}
+		task9.emitter = cff.NopTaskEmitter()
+		task9.run = func(ctx context.Context) (err error) {
+			taskEmitter := task9.emitter
+			startTime := time.Now()
+			defer func() {
+				task9.outcome.Finish(err)
+				if task9.ran.Load() {
+					taskEmitter.TaskDone(ctx, time.Since(startTime))
+				}
+			}()
+
+			defer func() {
+				recovered := recover()
+				if recovered != nil {
+					taskEmitter.TaskPanic(ctx, recovered)
+					err = &cff.PanicError{
+						Value:      recovered,
+						Stacktrace: debug.Stack(),
+					}
+				}
+			}()
+
+			defer task9.ran.Store(true)
+
+			err = cff.Intercept(ctx, interceptor, task9.outcome.Info, func(ctx context.Context) (err error) {
+				v9 = _106_12()
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return err
+			} else {
+				taskEmitter.TaskSuccess(ctx)
+			}
+
+			return
+		}
+
+		task9.job = sched.Enqueue(ctx, cff.Job{
+			Run: task9.run,
+
+			Groups: []*cff.JobGroup{group0},
+		})
+		tasks = append(tasks, task9)
+
+		// go.uber.org/cff/internal/tests/group/group.go:110:12
+		var (
+			v7 string
+		)
+
+		task10 := new(struct {
+			emitter cff.TaskEmitter
+			ran     cff.AtomicBool
+			run     func(context.Context) error
+			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		})
+		task10.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/group/group.go",
+			Line:   110,
+			Column: 12,
+		}
+		task10.outcome.Dependencies = []*cff.TaskOutcome{
+			&task7.outcome,
+			&task8.outcome,
+			&task9.outcome,
+		}
+		task10.emitter = cff.NopTaskEmitter()
+		task10.run = func(ctx context.Context) (err error) {
+			taskEmitter := task10.emitter
+			startTime := time.Now()
+			defer func() {
+				task10.outcome.Finish(err)
+				if task10.ran.Load() {
+					taskEmitter.TaskDone(ctx, time.Since(startTime))
+				}
+			}()
+
+			defer func() {
+				recovered := recover()
+				if recovered != nil {
+					taskEmitter.TaskPanic(ctx, recovered)
+					err = &cff.PanicError{
+						Value:      recovered,
+						Stacktrace: debug.Stack(),
+					}
+				}
+			}()
+
+			defer task10.ran.Store(true)
+
+			err = cff.Intercept(ctx, interceptor, task10.outcome.Info, func(ctx context.Context) (err error) {
+				v7 = _110_12(v8, v4, v9)
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return err
+			} else {
+				taskEmitter.TaskSuccess(ctx)
+			}
+
+			return
+		}
+
+		task10.job = sched.Enqueue(ctx, cff.Job{
+			Run: task10.run,
+			Dependencies: []*cff.ScheduledJob{
+				task7.job,
+				task8.job,
+				task9.job,
+			},
+		})
+		tasks = append(tasks, task10)
+
+		if err := sched.Wait(ctx); err != nil {
+			flowEmitter.FlowError(ctx, err)
+			cff.RethrowPanic(err, false)
+			return err
+		}
+
+		*(_94_15) = v7 // string
+
+		flowEmitter.FlowSuccess(ctx)
+		return nil
+	}()
+	if err != nil {
+		return 0, err
+	}
+	if s != "6" {
+		return 0, fmt.Errorf("unexpected result: %v", s)
+	}
+	return int(db.peak), nil
+}
+
+// Unlimited runs tasks of a group that imposes no limit.
+func Unlimited(ctx context.Context, n int) (int, error) {
+	var s string
+	err := func() (err error) {
+
+		_126_18 := ctx
+
+		_127_19 := n
+
+		_128_14 := n
+
+		_129_15 := &s
+
+		_130_12 := func(n int) int64 {
+			return int64(n)
+		}
+
+		_133_12 := func(i int64) string {
+			return fmt.Sprint(i)
+		}
+		ctx := _126_18
+		var v8 int = _128_14
+		emitter := cff.NopEmitter()
+		interceptor := cff.InterceptorStack()
+
+		var (
+			flowInfo = &cff.FlowInfo{
+				File:   "go.uber.org/cff/internal/tests/group/group.go",
+				Line:   126,
+				Column: 9,
+			}
+			flowEmitter = cff.NopFlowEmitter()
+
+			schedInfo = &cff.SchedulerInfo{
+				Name:      flowInfo.Name,
+				Directive: cff.FlowDirective,
+				File:      flowInfo.File,
+				Line:      flowInfo.Line,
+				Column:    flowInfo.Column,
+			}
+
+			// possibly unused
+			_ = flowInfo
+		)
+
+		startTime := time.Now()
+		defer func() { flowEmitter.FlowDone(ctx, time.Since(startTime)) }()
+
+		schedEmitter := emitter.SchedulerInit(schedInfo)
+
+		sched := cff.NewScheduler(
+			cff.SchedulerParams{
+				Emitter: schedEmitter,
+			},
+		)
+
+		group0 := &cff.JobGroup{Name: "db", Concurrency: _127_19}
+		_ = group0 // possibly unused
+
+		var tasks []*struct {
+			emitter cff.TaskEmitter
+			ran     cff.AtomicBool
+			run     func(context.Context) error
+			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		}
+		defer func() {
+			for _, t := range tasks {
+				if !t.ran.Load() {
+					t.emitter.TaskSkipped(ctx, t.outcome.SkipReason(ctx, err))
+				}
+			}
+		}()
+
+		// go.uber.org/cff/internal/tests/group/group.go:130:12
+		var (
+			v4 int64
+		)
+
+		task11 := new(struct {
+			emitter cff.TaskEmitter
+			ran     cff.AtomicBool
+			run     func(context.Context) error
+			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		})
+		task11.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/group/group.go",
+			Line:   130,
+			Column: 12,
+		}
+		task11.emitter = cff.NopTaskEmitter()
+		task11.run = func(ctx context.Context) (err error) {
+			taskEmitter := task11.emitter
+			startTime := time.Now()
+			defer func() {
+				task11.outcome.Finish(err)
+				if task11.ran.Load() {
+					taskEmitter.TaskDone(ctx, time.Since(startTime))
+				}
+			}()
+
+			defer func() {
+				recovered := recover()
+				if recovered != nil {
+					taskEmitter.TaskPanic(ctx, recovered)
+					err = &cff.PanicError{
+						Value:      recovered,
+						Stacktrace: debug.Stack(),
+					}
+				}
+			}()
+
+			defer task11.ran.Store(true)
+
+			err = cff.Intercept(ctx, interceptor, task11.outcome.Info, func(ctx context.Context) (err error) {
+				v4 = _130_12(v8)
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return err
+			} else {
+				taskEmitter.TaskSuccess(ctx)
+			}
+
+			return
+		}
+
+		task11.job = sched.Enqueue(ctx, cff.Job{
+			Run: task11.run,
+
+			Groups: []*cff.JobGroup{group0},
+		})
+		tasks = append(tasks, task11)
+
+		// go.uber.org/cff/internal/tests/group/group.go:133:12
+		var (
+			v7 string
+		)
+
+		task12 := new(struct {
+			emitter cff.TaskEmitter
+			ran     cff.AtomicBool
+			run     func(context.Context) error
+			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		})
+		task12.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/group/group.go",
+			Line:   133,
+			Column: 12,
+		}
+		task12.outcome.Dependencies = []*cff.TaskOutcome{
+			&task11.outcome,
+		}
+		task12.emitter = cff.NopTaskEmitter()
+		task12.run = func(ctx context.Context) (err error) {
+			taskEmitter := task12.emitter
+			startTime := time.Now()
+			defer func() {
+				task12.outcome.Finish(err)
+				if task12.ran.Load() {
+					taskEmitter.TaskDone(ctx, time.Since(startTime))
+				}
+			}()
+
+			defer func() {
+				recovered := recover()
+				if recovered != nil {
+					taskEmitter.TaskPanic(ctx, recovered)
+					err = &cff.PanicError{
+						Value:      recovered,
+						Stacktrace: debug.Stack(),
+					}
+				}
+			}()
+
+			defer task12.ran.Store(true)
+
+			err = cff.Intercept(ctx, interceptor, task12.outcome.Info, func(ctx context.Context) (err error) {
+				v7 = _133_12(v4)
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return err
+			} else {
+				taskEmitter.TaskSuccess(ctx)
+			}
+
+			return
+		}
+
+		task12.job = sched.Enqueue(ctx, cff.Job{
+			Run: task12.run,
+			Dependencies: []*cff.ScheduledJob{
+				task11.job,
+			},
+			Groups: []*cff.JobGroup{group0},
+		})
+		tasks = append(tasks, task12)
+
+		if err := sched.Wait(ctx); err != nil {
+			flowEmitter.FlowError(ctx, err)
+			cff.RethrowPanic(err, false)
+			return err
+		}
+
+		*(_129_15) = v7 // string
+
+		flowEmitter.FlowSuccess(ctx)
+		return nil
+	}()
+	return len(s), err
+}
@@ -0,0 +1,27 @@
+package group
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLimited(t *testing.T) {
+	peak, err := Limited(context.Background())
+	require.NoError(t, err)
+	assert.LessOrEqual(t, peak, 2)
+}
+
+func TestWithSubflow(t *testing.T) {
+	peak, err := WithSubflow(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, peak)
+}
+
+func TestUnlimited(t *testing.T) {
+	n, err := Unlimited(context.Background(), 0)
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+}
@@ -0,0 +1,118 @@
+//go:build cff
+// +build cff
+
+// Package hedge tests tasks that run more than one copy with cff.Hedge.
+package hedge
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/cff"
+)
+
+// slowFirst returns a function that blocks the first time it's called
+// until its context is cancelled, and returns the number of the call
+// otherwise.
+func slowFirst() func(context.Context) (int, error) {
+	var calls int32
+	return func(ctx context.Context) (int, error) {
+		n := atomic.AddInt32(&calls, 1)
+		if n == 1 {
+			<-ctx.Done()
+			return 0, ctx.Err()
+		}
+		return int(n), nil
+	}
+}
+
+// label is a value produced alongside the result of a hedged task.
+type label string
+
+// Flow runs a task whose first copy hangs until it's cancelled,
+// and reports the result of the copy that won.
+func Flow(ctx context.Context, e cff.Emitter) (string, error) {
+	get := slowFirst()
+
+	var s string
+	err := cff.Flow(ctx,
+		cff.WithEmitter(e),
+		cff.Results(&s),
+		cff.Task(
+			func(ctx context.Context) (int, label, error) {
+				n, err := get(ctx)
+				return n, "attempt", err
+			},
+			cff.Instrument("get"),
+			cff.Hedge(time.Millisecond, 3),
+		),
+		cff.Task(func(n int, prefix label) string {
+			return fmt.Sprintf("%v %v", prefix, n)
+		}),
+	)
+	return s, err
+}
+
+// FlowInvoke runs a hedged task that has no results.
+func FlowInvoke(ctx context.Context) (int, error) {
+	get := slowFirst()
+
+	var won int32
+	err := cff.Flow(ctx,
+		cff.Task(
+			func(ctx context.Context) error {
+				n, err := get(ctx)
+				if err == nil {
+					atomic.StoreInt32(&won, int32(n))
+				}
+				return err
+			},
+			cff.Invoke(true),
+			cff.Hedge(time.Millisecond, 2),
+		),
+	)
+	return int(atomic.LoadInt32(&won)), err
+}
+
+// FlowFails runs a hedged task of which every copy fails.
+func FlowFails(ctx context.Context) (calls int, err error) {
+	var n int32
+	var s string
+	err = cff.Flow(ctx,
+		cff.Results(&s),
+		cff.Task(
+			func() (string, error) {
+				atomic.AddInt32(&n, 1)
+				return "", errors.New("great sadness")
+			},
+			cff.Hedge(time.Hour, 3),
+		),
+	)
+	return int(atomic.LoadInt32(&n)), err
+}
+
+// Parallel runs a task of a Parallel whose first copy hangs until it's
+// cancelled.
+func Parallel(ctx context.Context, e cff.Emitter) (int, error) {
+	get := slowFirst()
+
+	var won int32
+	err := cff.Parallel(ctx,
+		cff.WithEmitter(e),
+		cff.Task(
+			func(ctx context.Context) error {
+				n, err := get(ctx)
+				if err == nil {
+					atomic.StoreInt32(&won, int32(n))
+				}
+				return err
+			},
+			cff.Instrument("get"),
+			cff.Hedge(time.Millisecond, 2),
+		),
+	)
+	return int(atomic.LoadInt32(&won)), err
+}
@@ -0,0 +1,726 @@
+//go:build !cff
+// +build !cff
+
+// Package hedge tests tasks that run more than one copy with cff.Hedge.
+package hedge
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/cff"
+)
+
+// slowFirst returns a function that blocks the first time it's called
+// until its context is cancelled, and returns the number of the call
+// otherwise.
+func slowFirst() func(context.Context) (int, error) {
+	var calls int32
+	return func(ctx context.Context) (int, error) {
+		n := atomic.AddInt32(&calls, 1)
+		if n == 1 {
+			<-ctx.Done()
+			return 0, ctx.Err()
+		}
+		return int(n), nil
+	}
+}
+
+// label is a value produced alongside the result of a hedged task.
+type label string
+
+// Flow runs a task whose first copy hangs until it's cancelled,
+// and reports the result of the copy that won.
+func Flow(ctx context.Context, e cff.Emitter) (string, error) {
+	get := slowFirst()
+
+	var s string
+	err := func() (err error) {
+
+		_41_18 := ctx
+
+		_42_19 := e
+
+		_43_15 := &s
+
+		_45_4 := func(ctx context.Context) (int, label, error) {
+			n, err := get(ctx)
+			return n, "attempt", err
+		}
+
+		_49_19 := "get"
+
+		_50_14 := time.Millisecond
+
+		_50_32 := 3
+
+		_52_12 := func(n int, prefix label) string {
+			return fmt.Sprintf("%v %v", prefix, n)
+		}
+		ctx := _41_18
+		emitter := cff.EmitterStack(_42_19)
+		interceptor := cff.InterceptorStack()
+
+		var (
+			flowInfo = &cff.FlowInfo{
+				File:   "go.uber.org/cff/internal/tests/hedge/hedge.go",
+				Line:   41,
+				Column: 9,
+			}
+			flowEmitter = cff.NopFlowEmitter()
+
+			schedInfo = &cff.SchedulerInfo{
+				Name:      flowInfo.Name,
+				Directive: cff.FlowDirective,
+				File:      flowInfo.File,
+				Line:      flowInfo.Line,
+				Column:    flowInfo.Column,
+			}
+
+			// possibly unused
+			_ = flowInfo
+		)
+
+		startTime := time.Now()
+		defer func() { flowEmitter.FlowDone(ctx, time.Since(startTime)) }()
+
+		schedEmitter := emitter.SchedulerInit(schedInfo)
+
+		sched := cff.NewScheduler(
+			cff.SchedulerParams{
+				Emitter: schedEmitter,
+			},
+		)
+
+		var tasks []*struct {
+			emitter cff.TaskEmitter
+			ran     cff.AtomicBool
+			run     func(context.Context) error
+			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		}
+		defer func() {
+			for _, t := range tasks {
+				if !t.ran.Load() {
+					t.emitter.TaskSkipped(ctx, t.outcome.SkipReason(ctx, err))
+				}
+			}
+		}()
+
+		// go.uber.org/cff/internal/tests/hedge/hedge.go:45:4
+		var (
+			v1 int
+			v2 label
+		)
+
+		task0 := new(struct {
+			emitter cff.TaskEmitter
+			ran     cff.AtomicBool
+			run     func(context.Context) error
+			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		})
+		task0.outcome.Info = &cff.TaskInfo{
+			Name:   _49_19,
+			File:   "go.uber.org/cff/internal/tests/hedge/hedge.go",
+			Line:   45,
+			Column: 4,
+		}
+		task0.emitter = emitter.TaskInit(
+			task0.outcome.Info,
+			&cff.DirectiveInfo{
+				Name:      flowInfo.Name,
+				Directive: cff.FlowDirective,
+				File:      flowInfo.File,
+				Line:      flowInfo.Line,
+				Column:    flowInfo.Column,
+			},
+		)
+		task0.run = func(ctx context.Context) (err error) {
+			taskEmitter := task0.emitter
+			startTime := time.Now()
+			defer func() {
+				task0.outcome.Finish(err)
+				if task0.ran.Load() {
+					taskEmitter.TaskDone(ctx, time.Since(startTime))
+				}
+			}()
+
+			defer func() {
+				recovered := recover()
+				// Panics of copies of the task carry their own stack.
+				recovered, hedgeStacktrace := cff.UnwrapHedgedPanic(recovered)
+				if recovered != nil {
+					taskEmitter.TaskPanic(ctx, recovered)
+					err = &cff.PanicError{
+						Value:      recovered,
+						Stacktrace: hedgeStacktrace,
+					}
+				}
+			}()
+
+			defer task0.ran.Store(true)
+
+			err = cff.Intercept(ctx, interceptor, task0.outcome.Info, func(ctx context.Context) (err error) {
+				err = cff.RunHedged(ctx, _50_14, _50_32, taskEmitter, func(ctx context.Context) (func(), error) {
+					r0, r1, err := _45_4(ctx)
+					return func() {
+						v1, v2 = r0, r1
+					}, err
+				})
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return err
+			} else {
+				taskEmitter.TaskSuccess(ctx)
+			}
+
+			return
+		}
+
+		task0.job = sched.Enqueue(ctx, cff.Job{
+			Run: task0.run,
+		})
+		tasks = append(tasks, task0)
+
+		// go.uber.org/cff/internal/tests/hedge/hedge.go:52:12
+		var (
+			v3 string
+		)
+
+		task1 := new(struct {
+			emitter cff.TaskEmitter
+			ran     cff.AtomicBool
+			run     func(context.Context) error
+			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		})
+		task1.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/hedge/hedge.go",
+			Line:   52,
+			Column: 12,
+		}
+		task1.outcome.Dependencies = []*cff.TaskOutcome{
+			&task0.outcome,
+		}
+		task1.emitter = cff.NopTaskEmitter()
+		task1.run = func(ctx context.Context) (err error) {
+			taskEmitter := task1.emitter
+			startTime := time.Now()
+			defer func() {
+				task1.outcome.Finish(err)
+				if task1.ran.Load() {
+					taskEmitter.TaskDone(ctx, time.Since(startTime))
+				}
+			}()
+
+			defer func() {
+				recovered := recover()
+				if recovered != nil {
+					taskEmitter.TaskPanic(ctx, recovered)
+					err = &cff.PanicError{
+						Value:      recovered,
+						Stacktrace: debug.Stack(),
+					}
+				}
+			}()
+
+			defer task1.ran.Store(true)
+
+			err = cff.Intercept(ctx, interceptor, task1.outcome.Info, func(ctx context.Context) (err error) {
+				v3 = _52_12(v1, v2)
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return err
+			} else {
+				taskEmitter.TaskSuccess(ctx)
+			}
+
+			return
+		}
+
+		task1.job = sched.Enqueue(ctx, cff.Job{
+			Run: task1.run,
+			Dependencies: []*cff.ScheduledJob{
+				task0.job,
+				task0.job,
+			},
+		})
+		tasks = append(tasks, task1)
+
+		if err := sched.Wait(ctx); err != nil {
+			flowEmitter.FlowError(ctx, err)
+			cff.RethrowPanic(err, false)
+			return err
+		}
+
+		*(_43_15) = v3 // string
+
+		flowEmitter.FlowSuccess(ctx)
+		return nil
+	}()
+	return s, err
+}
+
+// FlowInvoke runs a hedged task that has no results.
+func FlowInvoke(ctx context.Context) (int, error) {
+	get := slowFirst()
+
+	var won int32
+	err := func() (err error) {
+
+		_64_18 := ctx
+
+		_66_4 := func(ctx context.Context) error {
+			n, err := get(ctx)
+			if err == nil {
+				atomic.StoreInt32(&won, int32(n))
+			}
+			return err
+		}
+
+		_74_14 := time.Millisecond
+
+		_74_32 := 2
+		ctx := _64_18
+		emitter := cff.NopEmitter()
+		interceptor := cff.InterceptorStack()
+
+		var (
+			flowInfo = &cff.FlowInfo{
+				File:   "go.uber.org/cff/internal/tests/hedge/hedge.go",
+				Line:   64,
+				Column: 9,
+			}
+			flowEmitter = cff.NopFlowEmitter()
+
+			schedInfo = &cff.SchedulerInfo{
+				Name:      flowInfo.Name,
+				Directive: cff.FlowDirective,
+				File:      flowInfo.File,
+				Line:      flowInfo.Line,
+				Column:    flowInfo.Column,
+			}
+
+			// possibly unused
+			_ = flowInfo
+		)
+
+		startTime := time.Now()
+		defer func() { flowEmitter.FlowDone(ctx, time.Since(startTime)) }()
+
+		schedEmitter := emitter.SchedulerInit(schedInfo)
+
+		sched := cff.NewScheduler(
+			cff.SchedulerParams{
+				Emitter: schedEmitter,
+			},
+		)
+
+		var tasks []*struct {
+			emitter cff.TaskEmitter
+			ran     cff.AtomicBool
+			run     func(context.Context) error
+			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		}
+		defer func() {
+			for _, t := range tasks {
+				if !t.ran.Load() {
+					t.emitter.TaskSkipped(ctx, t.outcome.SkipReason(ctx, err))
+				}
+			}
+		}()
+
+		// go.uber.org/cff/internal/tests/hedge/hedge.go:66:4
+		task2 := new(struct {
+			emitter cff.TaskEmitter
+			ran     cff.AtomicBool
+			run     func(context.Context) error
+			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		})
+		task2.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/hedge/hedge.go",
+			Line:   66,
+			Column: 4,
+		}
+		task2.emitter = cff.NopTaskEmitter()
+		task2.run = func(ctx context.Context) (err error) {
+			taskEmitter := task2.emitter
+			startTime := time.Now()
+			defer func() {
+				task2.outcome.Finish(err)
+				if task2.ran.Load() {
+					taskEmitter.TaskDone(ctx, time.Since(startTime))
+				}
+			}()
+
+			defer func() {
+				recovered := recover()
+				// Panics of copies of the task carry their own stack.
+				recovered, hedgeStacktrace := cff.UnwrapHedgedPanic(recovered)
+				if recovered != nil {
+					taskEmitter.TaskPanic(ctx, recovered)
+					err = &cff.PanicError{
+						Value:      recovered,
+						Stacktrace: hedgeStacktrace,
+					}
+				}
+			}()
+
+			defer task2.ran.Store(true)
+
+			err = cff.Intercept(ctx, interceptor, task2.outcome.Info, func(ctx context.Context) (err error) {
+				err = cff.RunHedged(ctx, _74_14, _74_32, taskEmitter, func(ctx context.Context) (func(), error) {
+					err := _66_4(ctx)
+					return nil, err
+				})
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return err
+			} else {
+				taskEmitter.TaskSuccess(ctx)
+			}
+
+			return
+		}
+
+		task2.job = sched.Enqueue(ctx, cff.Job{
+			Run: task2.run,
+		})
+		tasks = append(tasks, task2)
+
+		if err := sched.Wait(ctx); err != nil {
+			flowEmitter.FlowError(ctx, err)
+			cff.RethrowPanic(err, false)
+			return err
+		}
+
+		flowEmitter.FlowSuccess(ctx)
+		return nil
+	}()
+	return int(atomic.LoadInt32(&won)), err
+}
+
+// FlowFails runs a hedged task of which every copy fails.
+func FlowFails(ctx context.Context) (calls int, err error) {
+	var n int32
+	var s string
+	err = func() (err error) {
+
+		_84_17 := ctx
+
+		_85_15 := &s
+
+		_87_4 := func() (string, error) {
+			atomic.AddInt32(&n, 1)
+			return "", errors.New("great sadness")
+		}
+
+		_91_14 := time.Hour
+
+		_91_25 := 3
+		ctx := _84_17
+		emitter := cff.NopEmitter()
+		interceptor := cff.InterceptorStack()
+
+		var (
+			flowInfo = &cff.FlowInfo{
+				File:   "go.uber.org/cff/internal/tests/hedge/hedge.go",
+				Line:   84,
+				Column: 8,
+			}
+			flowEmitter = cff.NopFlowEmitter()
+
+			schedInfo = &cff.SchedulerInfo{
+				Name:      flowInfo.Name,
+				Directive: cff.FlowDirective,
+				File:      flowInfo.File,
+				Line:      flowInfo.Line,
+				Column:    flowInfo.Column,
+			}
+
+			// possibly unused
+			_ = flowInfo
+		)
+
+		startTime := time.Now()
+		defer func() { flowEmitter.FlowDone(ctx, time.Since(startTime)) }()
+
+		schedEmitter := emitter.SchedulerInit(schedInfo)
+
+		sched := cff.NewScheduler(
+			cff.SchedulerParams{
+				Emitter: schedEmitter,
+			},
+		)
+
+		var tasks []*struct {
+			emitter cff.TaskEmitter
+			ran     cff.AtomicBool
+			run     func(context.Context) error
+			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		}
+		defer func() {
+			for _, t := range tasks {
+				if !t.ran.Load() {
+					t.emitter.TaskSkipped(ctx, t.outcome.SkipReason(ctx, err))
+				}
+			}
+		}()
+
+		// go.uber.org/cff/internal/tests/hedge/hedge.go:87:4
+		var (
+			v3 string
+		)
+
+		task3 := new(struct {
+			emitter cff.TaskEmitter
+			ran     cff.AtomicBool
+			run     func(context.Context) error
+			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		})
+		task3.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/hedge/hedge.go",
+			Line:   87,
+			Column: 4,
+		}
+		task3.emitter = cff.NopTaskEmitter()
+		task3.run = func(ctx context.Context) (err error) {
+			taskEmitter := task3.emitter
+			startTime := time.Now()
+			defer func() {
+				task3.outcome.Finish(err)
+				if task3.ran.Load() {
+					taskEmitter.TaskDone(ctx, time.Since(startTime))
+				}
+			}()
+
+			defer func() {
+				recovered := recover()
+				// Panics of copies of the task carry their own stack.
+				recovered, hedgeStacktrace := cff.UnwrapHedgedPanic(recovered)
+				if recovered != nil {
+					taskEmitter.TaskPanic(ctx, recovered)
+					err = &cff.PanicError{
+						Value:      recovered,
+						Stacktrace: hedgeStacktrace,
+					}
+				}
+			}()
+
+			defer task3.ran.Store(true)
+
+			err = cff.Intercept(ctx, interceptor, task3.outcome.Info, func(ctx context.Context) (err error) {
+				err = cff.RunHedged(ctx, _91_14, _91_25, taskEmitter, func(ctx context.Context) (func(), error) {
+					r0, err := _87_4()
+					return func() {
+						v3 = r0
+					}, err
+				})
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return err
+			} else {
+				taskEmitter.TaskSuccess(ctx)
+			}
+
+			return
+		}
+
+		task3.job = sched.Enqueue(ctx, cff.Job{
+			Run: task3.run,
+		})
+		tasks = append(tasks, task3)
+
+		if err := sched.Wait(ctx); err != nil {
+			flowEmitter.FlowError(ctx, err)
+			cff.RethrowPanic(err, false)
+			return err
+		}
+
+		*(_85_15) = v3 // string
+
+		flowEmitter.FlowSuccess(ctx)
+		return nil
+	}()
+	return int(atomic.LoadInt32(&n)), err
+}
+
+// Parallel runs a task of a Parallel whose first copy hangs until it's
+// cancelled.
+func Parallel(ctx context.Context, e cff.Emitter) (int, error) {
+	get := slowFirst()
+
+	var won int32
+	err := func() (err error) {
+
+		_103_22 := ctx
+
+		_104_19 := e
+
+		_106_4 := func(ctx context.Context) error {
+			n, err := get(ctx)
+			if err == nil {
+				atomic.StoreInt32(&won, int32(n))
+			}
+			return err
+		}
+
+		_113_19 := "get"
+
+		_114_14 := time.Millisecond
+
+		_114_32 := 2
+		ctx := _103_22
+		emitter := cff.EmitterStack(_104_19)
+		interceptor := cff.InterceptorStack()
+
+		var (
+			parallelInfo = &cff.ParallelInfo{
+				File:   "go.uber.org/cff/internal/tests/hedge/hedge.go",
+				Line:   103,
+				Column: 9,
+			}
+			directiveInfo = &cff.DirectiveInfo{
+				Name:      parallelInfo.Name,
+				Directive: cff.ParallelDirective,
+				File:      parallelInfo.File,
+				Line:      parallelInfo.Line,
+				Column:    parallelInfo.Column,
+			}
+			parallelEmitter = cff.NopParallelEmitter()
+
+			schedInfo = &cff.SchedulerInfo{
+				Name:      parallelInfo.Name,
+				Directive: cff.ParallelDirective,
+				File:      parallelInfo.File,
+				Line:      parallelInfo.Line,
+				Column:    parallelInfo.Column,
+			}
+
+			// possibly unused
+			_ = parallelInfo
+			_ = directiveInfo
+		)
+
+		startTime := time.Now()
+		defer func() { parallelEmitter.ParallelDone(ctx, time.Since(startTime)) }()
+
+		schedEmitter := emitter.SchedulerInit(schedInfo)
+
+		sched := cff.NewScheduler(
+			cff.SchedulerParams{
+				Emitter: schedEmitter,
+			},
+		)
+
+		var tasks []*struct {
+			emitter cff.TaskEmitter
+			fn      func(context.Context) error
+			ran     cff.AtomicBool
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		}
+		defer func() {
+			for _, t := range tasks {
+				// Tasks that are still running report their own outcome
+				// once they finish.
+				if !t.ran.Load() && !t.outcome.Running() {
+					t.emitter.TaskSkipped(ctx, t.outcome.SkipReason(ctx, err))
+				}
+			}
+		}()
+
+		// go.uber.org/cff/internal/tests/hedge/hedge.go:106:4
+		task4 := new(struct {
+			emitter cff.TaskEmitter
+			fn      func(context.Context) error
+			ran     cff.AtomicBool
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		})
+		task4.outcome.Info = &cff.TaskInfo{
+			Name:   _113_19,
+			File:   "go.uber.org/cff/internal/tests/hedge/hedge.go",
+			Line:   106,
+			Column: 4,
+		}
+		task4.emitter = emitter.TaskInit(task4.outcome.Info, directiveInfo)
+		task4.fn = func(ctx context.Context) (err error) {
+			task4.outcome.Start()
+			taskEmitter := task4.emitter
+			startTime := time.Now()
+			defer func() {
+				task4.outcome.Finish(err)
+				if task4.ran.Load() {
+					taskEmitter.TaskDone(ctx, time.Since(startTime))
+				}
+			}()
+
+			defer func() {
+				recovered := recover()
+				// Panics of copies of the task carry their own stack.
+				recovered, hedgeStacktrace := cff.UnwrapHedgedPanic(recovered)
+				if recovered != nil {
+					taskEmitter.TaskPanic(ctx, recovered)
+					err = &cff.PanicError{
+						Value:      recovered,
+						Stacktrace: hedgeStacktrace,
+					}
+				}
+			}()
+
+			defer task4.ran.Store(true)
+
+			err = cff.Intercept(ctx, interceptor, task4.outcome.Info, func(ctx context.Context) (err error) {
+				err = cff.RunHedged(ctx, _114_14, _114_32, taskEmitter, func(ctx context.Context) (func(), error) {
+					return nil, _106_4(ctx)
+				})
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return
+			}
+			taskEmitter.TaskSuccess(ctx)
+			return
+		}
+
+		sched.Enqueue(ctx, cff.Job{
+			Run: task4.fn,
+		})
+		tasks = append(tasks, task4)
+
+		if err := sched.Wait(ctx); err != nil {
+			parallelEmitter.ParallelError(ctx, err)
+			cff.RethrowPanic(err, false)
+			return err
+		}
+		parallelEmitter.ParallelSuccess(ctx)
+		return nil /*line hedge.go:115*/
+	}()
+	return int(atomic.LoadInt32(&won)), err
+}
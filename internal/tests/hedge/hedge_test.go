@@ -0,0 +1,49 @@
+package hedge
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/cff/internal/emittertest"
+)
+
+// winners returns the copies of tasks that won by task name.
+func winners(e *emittertest.Recorder) map[string][]int {
+	ws := make(map[string][]int)
+	for _, ev := range e.EventsOf(emittertest.TaskHedged) {
+		ws[ev.Task.Name] = append(ws[ev.Task.Name], ev.Attempt)
+	}
+	return ws
+}
+
+func TestFlow(t *testing.T) {
+	e := emittertest.NewRecorder()
+	s, err := Flow(context.Background(), e)
+	require.NoError(t, err)
+	assert.Contains(t, []string{"attempt 2", "attempt 3"}, s)
+	won := winners(e)["get"]
+	require.Len(t, won, 1)
+	assert.Greater(t, won[0], 1, "a hedged copy must win")
+}
+
+func TestFlowInvoke(t *testing.T) {
+	won, err := FlowInvoke(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 2, won)
+}
+
+func TestFlowFails(t *testing.T) {
+	calls, err := FlowFails(context.Background())
+	assert.EqualError(t, err, "great sadness")
+	assert.Equal(t, 1, calls, "no copies may start after all copies failed")
+}
+
+func TestParallel(t *testing.T) {
+	e := emittertest.NewRecorder()
+	won, err := Parallel(context.Background(), e)
+	require.NoError(t, err)
+	assert.Equal(t, 2, won)
+	assert.Equal(t, map[string][]int{"get": {2}}, winners(e))
+}
@@ -37,6 +37,7 @@ func Flow() (string, error) {
 		_25_13 := template.GetError
 		ctx := _19_3
 		emitter := cff2.NopEmitter()
+		interceptor := cff2.InterceptorStack()
 
 		var (
 			flowInfo = &cff2.FlowInfo{
@@ -74,11 +75,13 @@ func Flow() (string, error) {
 			ran     cff2.AtomicBool
 			run     func(context.Context) error
 			job     *cff2.ScheduledJob
+
+			outcome cff2.TaskOutcome // reports why the task was skipped
 		}
 		defer func() {
 			for _, t := range tasks {
 				if !t.ran.Load() {
-					t.emitter.TaskSkipped(ctx, err)
+					t.emitter.TaskSkipped(ctx, t.outcome.SkipReason(ctx, err))
 				}
 			}
 		}()
@@ -87,17 +90,26 @@ func Flow() (string, error) {
 		var (
 			v1 *_template.Template
 		)
+
 		task0 := new(struct {
 			emitter cff2.TaskEmitter
 			ran     cff2.AtomicBool
 			run     func(context.Context) error
 			job     *cff2.ScheduledJob
+
+			outcome cff2.TaskOutcome // reports why the task was skipped
 		})
+		task0.outcome.Info = &cff2.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/importcollision/import_collision.go",
+			Line:   21,
+			Column: 13,
+		}
 		task0.emitter = cff2.NopTaskEmitter()
 		task0.run = func(ctx context.Context) (err error) {
 			taskEmitter := task0.emitter
 			startTime := time.Now()
 			defer func() {
+				task0.outcome.Finish(err)
 				if task0.ran.Load() {
 					taskEmitter.TaskDone(ctx, time.Since(startTime))
 				}
@@ -116,9 +128,16 @@ func Flow() (string, error) {
 
 			defer task0.ran.Store(true)
 
-			v1 = _21_13()
-
-			taskEmitter.TaskSuccess(ctx)
+			err = cff2.Intercept(ctx, interceptor, task0.outcome.Info, func(ctx context.Context) (err error) {
+				v1 = _21_13()
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return err
+			} else {
+				taskEmitter.TaskSuccess(ctx)
+			}
 
 			return
 		}
@@ -132,17 +151,26 @@ func Flow() (string, error) {
 		var (
 			v2 *__template.Template
 		)
+
 		task1 := new(struct {
 			emitter cff2.TaskEmitter
 			ran     cff2.AtomicBool
 			run     func(context.Context) error
 			job     *cff2.ScheduledJob
+
+			outcome cff2.TaskOutcome // reports why the task was skipped
 		})
+		task1.outcome.Info = &cff2.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/importcollision/import_collision.go",
+			Line:   22,
+			Column: 13,
+		}
 		task1.emitter = cff2.NopTaskEmitter()
 		task1.run = func(ctx context.Context) (err error) {
 			taskEmitter := task1.emitter
 			startTime := time.Now()
 			defer func() {
+				task1.outcome.Finish(err)
 				if task1.ran.Load() {
 					taskEmitter.TaskDone(ctx, time.Since(startTime))
 				}
@@ -161,9 +189,16 @@ func Flow() (string, error) {
 
 			defer task1.ran.Store(true)
 
-			v2 = _22_13()
-
-			taskEmitter.TaskSuccess(ctx)
+			err = cff2.Intercept(ctx, interceptor, task1.outcome.Info, func(ctx context.Context) (err error) {
+				v2 = _22_13()
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return err
+			} else {
+				taskEmitter.TaskSuccess(ctx)
+			}
 
 			return
 		}
@@ -177,17 +212,26 @@ func Flow() (string, error) {
 		var (
 			v3 packagewithdash.Foo
 		)
+
 		task2 := new(struct {
 			emitter cff2.TaskEmitter
 			ran     cff2.AtomicBool
 			run     func(context.Context) error
 			job     *cff2.ScheduledJob
+
+			outcome cff2.TaskOutcome // reports why the task was skipped
 		})
+		task2.outcome.Info = &cff2.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/importcollision/import_collision.go",
+			Line:   23,
+			Column: 13,
+		}
 		task2.emitter = cff2.NopTaskEmitter()
 		task2.run = func(ctx context.Context) (err error) {
 			taskEmitter := task2.emitter
 			startTime := time.Now()
 			defer func() {
+				task2.outcome.Finish(err)
 				if task2.ran.Load() {
 					taskEmitter.TaskDone(ctx, time.Since(startTime))
 				}
@@ -206,9 +250,16 @@ func Flow() (string, error) {
 
 			defer task2.ran.Store(true)
 
-			v3 = _23_13()
-
-			taskEmitter.TaskSuccess(ctx)
+			err = cff2.Intercept(ctx, interceptor, task2.outcome.Info, func(ctx context.Context) (err error) {
+				v3 = _23_13()
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return err
+			} else {
+				taskEmitter.TaskSuccess(ctx)
+			}
 
 			return
 		}
@@ -222,17 +273,31 @@ func Flow() (string, error) {
 		var (
 			v4 string
 		)
+
 		task3 := new(struct {
 			emitter cff2.TaskEmitter
 			ran     cff2.AtomicBool
 			run     func(context.Context) error
 			job     *cff2.ScheduledJob
+
+			outcome cff2.TaskOutcome // reports why the task was skipped
 		})
+		task3.outcome.Info = &cff2.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/importcollision/import_collision.go",
+			Line:   24,
+			Column: 13,
+		}
+		task3.outcome.Dependencies = []*cff2.TaskOutcome{
+			&task0.outcome,
+			&task1.outcome,
+			&task2.outcome,
+		}
 		task3.emitter = cff2.NopTaskEmitter()
 		task3.run = func(ctx context.Context) (err error) {
 			taskEmitter := task3.emitter
 			startTime := time.Now()
 			defer func() {
+				task3.outcome.Finish(err)
 				if task3.ran.Load() {
 					taskEmitter.TaskDone(ctx, time.Since(startTime))
 				}
@@ -251,9 +316,16 @@ func Flow() (string, error) {
 
 			defer task3.ran.Store(true)
 
-			v4 = _24_13(v1, v2, v3)
-
-			taskEmitter.TaskSuccess(ctx)
+			err = cff2.Intercept(ctx, interceptor, task3.outcome.Info, func(ctx context.Context) (err error) {
+				v4 = _24_13(v1, v2, v3)
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return err
+			} else {
+				taskEmitter.TaskSuccess(ctx)
+			}
 
 			return
 		}
@@ -274,12 +346,20 @@ func Flow() (string, error) {
 			ran     cff2.AtomicBool
 			run     func(context.Context) error
 			job     *cff2.ScheduledJob
+
+			outcome cff2.TaskOutcome // reports why the task was skipped
 		})
+		task4.outcome.Info = &cff2.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/importcollision/import_collision.go",
+			Line:   25,
+			Column: 13,
+		}
 		task4.emitter = cff2.NopTaskEmitter()
 		task4.run = func(ctx context.Context) (err error) {
 			taskEmitter := task4.emitter
 			startTime := time.Now()
 			defer func() {
+				task4.outcome.Finish(err)
 				if task4.ran.Load() {
 					taskEmitter.TaskDone(ctx, time.Since(startTime))
 				}
@@ -298,8 +378,10 @@ func Flow() (string, error) {
 
 			defer task4.ran.Store(true)
 
-			err = _25_13()
-
+			err = cff2.Intercept(ctx, interceptor, task4.outcome.Info, func(ctx context.Context) (err error) {
+				err = _25_13()
+				return
+			})
 			if err != nil {
 				taskEmitter.TaskError(ctx, err)
 				return err
@@ -317,6 +399,7 @@ func Flow() (string, error) {
 
 		if err := sched.Wait(ctx); err != nil {
 			flowEmitter.FlowError(ctx, err)
+			cff2.RethrowPanic(err, false)
 			return err
 		}
 
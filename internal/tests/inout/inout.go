@@ -0,0 +1,106 @@
+//go:build cff
+// +build cff
+
+// Package inout tests tasks that use cff.In parameter objects and cff.Out
+// result objects.
+package inout
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"go.uber.org/cff"
+)
+
+// Params is a parameter object.
+type Params struct {
+	cff.In
+
+	Name  string
+	Count int
+}
+
+// Greeting is the greeting built by Greet.
+type Greeting string
+
+// Results is a result object.
+type Results struct {
+	cff.Out
+
+	Greeting Greeting
+	Length   int64
+}
+
+// Greet builds a greeting with a task that accepts a parameter object and
+// returns a result object.
+func Greet(name string, count int) (greeting Greeting, length int64, err error) {
+	err = cff.Flow(context.Background(),
+		cff.Params(name, count),
+		cff.Results(&greeting, &length),
+		cff.Task(func(p Params) Results {
+			g := "hello " + p.Name + " x" + strconv.Itoa(p.Count)
+			return Results{Greeting: Greeting(g), Length: int64(len(g))}
+		}),
+	)
+	return greeting, length, err
+}
+
+type unexportedParams struct {
+	cff.In
+
+	name string
+}
+
+// Unexported resolves unexported fields of a parameter object declared in
+// this package, alongside a context and other parameters.
+func Unexported(name string) (out []byte, err error) {
+	err = cff.Flow(context.Background(),
+		cff.Params(name),
+		cff.Results(&out),
+		cff.Task(func(context.Context) int { return 3 }),
+		cff.Task(func(ctx context.Context, p unexportedParams, n int) ([]byte, error) {
+			return []byte(p.name[:n]), ctx.Err()
+		}),
+	)
+	return out, err
+}
+
+// FallbackResults is a result object with a fallback.
+type FallbackResults struct {
+	cff.Out
+
+	Value string
+	Ok    bool
+}
+
+// Fallback runs a failing task that returns a result object with
+// cff.FallbackWith.
+func Fallback(fail bool) (value string, ok bool, err error) {
+	err = cff.Flow(context.Background(),
+		cff.Results(&value, &ok),
+		cff.Task(
+			func() (FallbackResults, error) {
+				if fail {
+					return FallbackResults{}, errors.New("great sadness")
+				}
+				return FallbackResults{Value: "value", Ok: true}, nil
+			},
+			cff.FallbackWith(FallbackResults{Value: "fallback"}),
+		),
+	)
+	return value, ok, err
+}
+
+// PredicateParams uses a parameter object in a predicate.
+func PredicateParams(name string, count int) (n int64, err error) {
+	err = cff.Flow(context.Background(),
+		cff.Params(name, count),
+		cff.Results(&n),
+		cff.Task(
+			func(p Params) int64 { return int64(len(p.Name) * p.Count) },
+			cff.Predicate(func(p Params) bool { return p.Count > 0 }),
+		),
+	)
+	return n, err
+}
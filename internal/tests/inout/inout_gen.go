@@ -0,0 +1,756 @@
+//go:build !cff
+// +build !cff
+
+// Package inout tests tasks that use cff.In parameter objects and cff.Out
+// result objects.
+package inout
+
+import (
+	"context"
+	"errors"
+	"runtime/debug"
+	"strconv"
+	"time"
+
+	"go.uber.org/cff"
+)
+
+// Params is a parameter object.
+type Params struct {
+	cff.In
+
+	Name  string
+	Count int
+}
+
+// Greeting is the greeting built by Greet.
+type Greeting string
+
+// Results is a result object.
+type Results struct {
+	cff.Out
+
+	Greeting Greeting
+	Length   int64
+}
+
+// Greet builds a greeting with a task that accepts a parameter object and
+// returns a result object.
+func Greet(name string, count int) (greeting Greeting, length int64, err error) {
+	err = func() (err error) {
+
+		_38_17 := context.Background()
+
+		_39_14 := name
+
+		_39_20 := count
+
+		_40_15 := &greeting
+
+		_40_26 := &length
+
+		_41_12 := func(p Params) Results {
+			g := "hello " + p.Name + " x" + strconv.Itoa(p.Count)
+			return Results{Greeting: Greeting(g), Length: int64(len(g))}
+		}
+		ctx := _38_17
+		var v1 string = _39_14
+		var v2 int = _39_20
+		emitter := cff.NopEmitter()
+		interceptor := cff.InterceptorStack()
+
+		var (
+			flowInfo = &cff.FlowInfo{
+				File:   "go.uber.org/cff/internal/tests/inout/inout.go",
+				Line:   38,
+				Column: 8,
+			}
+			flowEmitter = cff.NopFlowEmitter()
+
+			schedInfo = &cff.SchedulerInfo{
+				Name:      flowInfo.Name,
+				Directive: cff.FlowDirective,
+				File:      flowInfo.File,
+				Line:      flowInfo.Line,
+				Column:    flowInfo.Column,
+			}
+
+			// possibly unused
+			_ = flowInfo
+		)
+
+		startTime := time.Now()
+		defer func() { flowEmitter.FlowDone(ctx, time.Since(startTime)) }()
+
+		schedEmitter := emitter.SchedulerInit(schedInfo)
+
+		sched := cff.NewScheduler(
+			cff.SchedulerParams{
+				Emitter: schedEmitter,
+			},
+		)
+
+		var tasks []*struct {
+			emitter cff.TaskEmitter
+			ran     cff.AtomicBool
+			run     func(context.Context) error
+			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		}
+		defer func() {
+			for _, t := range tasks {
+				if !t.ran.Load() {
+					t.emitter.TaskSkipped(ctx, t.outcome.SkipReason(ctx, err))
+				}
+			}
+		}()
+
+		// go.uber.org/cff/internal/tests/inout/inout.go:41:12
+		var (
+			v3 Greeting
+			v4 int64
+		)
+
+		task0 := new(struct {
+			emitter cff.TaskEmitter
+			ran     cff.AtomicBool
+			run     func(context.Context) error
+			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		})
+		task0.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/inout/inout.go",
+			Line:   41,
+			Column: 12,
+		}
+		task0.emitter = cff.NopTaskEmitter()
+		task0.run = func(ctx context.Context) (err error) {
+			taskEmitter := task0.emitter
+			startTime := time.Now()
+			defer func() {
+				task0.outcome.Finish(err)
+				if task0.ran.Load() {
+					taskEmitter.TaskDone(ctx, time.Since(startTime))
+				}
+			}()
+
+			var task0Out0 Results
+			// Unpack cff.Out and cff.Maybe results after recovering from panics.
+			defer func() {
+				if err != nil {
+					return
+				}
+				v3 = task0Out0.Greeting
+				v4 = task0Out0.Length
+			}()
+
+			defer func() {
+				recovered := recover()
+				if recovered != nil {
+					taskEmitter.TaskPanic(ctx, recovered)
+					err = &cff.PanicError{
+						Value:      recovered,
+						Stacktrace: debug.Stack(),
+					}
+				}
+			}()
+
+			defer task0.ran.Store(true)
+
+			err = cff.Intercept(ctx, interceptor, task0.outcome.Info, func(ctx context.Context) (err error) {
+				task0Out0 = _41_12(Params{Name: v1, Count: v2})
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return err
+			} else {
+				taskEmitter.TaskSuccess(ctx)
+			}
+
+			return
+		}
+
+		task0.job = sched.Enqueue(ctx, cff.Job{
+			Run: task0.run,
+		})
+		tasks = append(tasks, task0)
+
+		if err := sched.Wait(ctx); err != nil {
+			flowEmitter.FlowError(ctx, err)
+			cff.RethrowPanic(err, false)
+			return err
+		}
+
+		*(_40_15) = v3 // go.uber.org/cff/internal/tests/inout.Greeting
+		*(_40_26) = v4 // int64
+
+		flowEmitter.FlowSuccess(ctx)
+		return nil
+	}()
+	return greeting, length, err
+}
+
+type unexportedParams struct {
+	cff.In
+
+	name string
+}
+
+// Unexported resolves unexported fields of a parameter object declared in
+// this package, alongside a context and other parameters.
+func Unexported(name string) (out []byte, err error) {
+	err = func() (err error) {
+
+		_58_17 := context.Background()
+
+		_59_14 := name
+
+		_60_15 := &out
+
+		_61_12 := func(context.Context) int { return 3 }
+
+		_62_12 := func(ctx context.Context, p unexportedParams, n int) ([]byte, error) {
+			return []byte(p.name[:n]), ctx.Err()
+		}
+		ctx := _58_17
+		var v1 string = _59_14
+		emitter := cff.NopEmitter()
+		interceptor := cff.InterceptorStack()
+
+		var (
+			flowInfo = &cff.FlowInfo{
+				File:   "go.uber.org/cff/internal/tests/inout/inout.go",
+				Line:   58,
+				Column: 8,
+			}
+			flowEmitter = cff.NopFlowEmitter()
+
+			schedInfo = &cff.SchedulerInfo{
+				Name:      flowInfo.Name,
+				Directive: cff.FlowDirective,
+				File:      flowInfo.File,
+				Line:      flowInfo.Line,
+				Column:    flowInfo.Column,
+			}
+
+			// possibly unused
+			_ = flowInfo
+		)
+
+		startTime := time.Now()
+		defer func() { flowEmitter.FlowDone(ctx, time.Since(startTime)) }()
+
+		schedEmitter := emitter.SchedulerInit(schedInfo)
+
+		sched := cff.NewScheduler(
+			cff.SchedulerParams{
+				Emitter: schedEmitter,
+			},
+		)
+
+		var tasks []*struct {
+			emitter cff.TaskEmitter
+			ran     cff.AtomicBool
+			run     func(context.Context) error
+			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		}
+		defer func() {
+			for _, t := range tasks {
+				if !t.ran.Load() {
+					t.emitter.TaskSkipped(ctx, t.outcome.SkipReason(ctx, err))
+				}
+			}
+		}()
+
+		// go.uber.org/cff/internal/tests/inout/inout.go:61:12
+		var (
+			v2 int
+		)
+
+		task1 := new(struct {
+			emitter cff.TaskEmitter
+			ran     cff.AtomicBool
+			run     func(context.Context) error
+			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		})
+		task1.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/inout/inout.go",
+			Line:   61,
+			Column: 12,
+		}
+		task1.emitter = cff.NopTaskEmitter()
+		task1.run = func(ctx context.Context) (err error) {
+			taskEmitter := task1.emitter
+			startTime := time.Now()
+			defer func() {
+				task1.outcome.Finish(err)
+				if task1.ran.Load() {
+					taskEmitter.TaskDone(ctx, time.Since(startTime))
+				}
+			}()
+
+			defer func() {
+				recovered := recover()
+				if recovered != nil {
+					taskEmitter.TaskPanic(ctx, recovered)
+					err = &cff.PanicError{
+						Value:      recovered,
+						Stacktrace: debug.Stack(),
+					}
+				}
+			}()
+
+			defer task1.ran.Store(true)
+
+			err = cff.Intercept(ctx, interceptor, task1.outcome.Info, func(ctx context.Context) (err error) {
+				v2 = _61_12(ctx)
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return err
+			} else {
+				taskEmitter.TaskSuccess(ctx)
+			}
+
+			return
+		}
+
+		task1.job = sched.Enqueue(ctx, cff.Job{
+			Run: task1.run,
+		})
+		tasks = append(tasks, task1)
+
+		// go.uber.org/cff/internal/tests/inout/inout.go:62:12
+		var (
+			v5 []byte
+		)
+
+		task2 := new(struct {
+			emitter cff.TaskEmitter
+			ran     cff.AtomicBool
+			run     func(context.Context) error
+			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		})
+		task2.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/inout/inout.go",
+			Line:   62,
+			Column: 12,
+		}
+		task2.outcome.Dependencies = []*cff.TaskOutcome{
+			&task1.outcome,
+		}
+		task2.emitter = cff.NopTaskEmitter()
+		task2.run = func(ctx context.Context) (err error) {
+			taskEmitter := task2.emitter
+			startTime := time.Now()
+			defer func() {
+				task2.outcome.Finish(err)
+				if task2.ran.Load() {
+					taskEmitter.TaskDone(ctx, time.Since(startTime))
+				}
+			}()
+
+			defer func() {
+				recovered := recover()
+				if recovered != nil {
+					taskEmitter.TaskPanic(ctx, recovered)
+					err = &cff.PanicError{
+						Value:      recovered,
+						Stacktrace: debug.Stack(),
+					}
+				}
+			}()
+
+			defer task2.ran.Store(true)
+
+			err = cff.Intercept(ctx, interceptor, task2.outcome.Info, func(ctx context.Context) (err error) {
+				v5, err = _62_12(ctx, unexportedParams{name: v1}, v2)
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return err
+			} else {
+				taskEmitter.TaskSuccess(ctx)
+			}
+
+			return
+		}
+
+		task2.job = sched.Enqueue(ctx, cff.Job{
+			Run: task2.run,
+			Dependencies: []*cff.ScheduledJob{
+				task1.job,
+			},
+		})
+		tasks = append(tasks, task2)
+
+		if err := sched.Wait(ctx); err != nil {
+			flowEmitter.FlowError(ctx, err)
+			cff.RethrowPanic(err, false)
+			return err
+		}
+
+		*(_60_15) = v5 // []byte
+
+		flowEmitter.FlowSuccess(ctx)
+		return nil
+	}()
+	return out, err
+}
+
+// FallbackResults is a result object with a fallback.
+type FallbackResults struct {
+	cff.Out
+
+	Value string
+	Ok    bool
+}
+
+// Fallback runs a failing task that returns a result object with
+// cff.FallbackWith.
+func Fallback(fail bool) (value string, ok bool, err error) {
+	err = func() (err error) {
+
+		_80_17 := context.Background()
+
+		_81_15 := &value
+
+		_81_23 := &ok
+
+		_83_4 := func() (FallbackResults, error) {
+			if fail {
+				return FallbackResults{}, errors.New("great sadness")
+			}
+			return FallbackResults{Value: "value", Ok: true}, nil
+		}
+
+		_89_21 := FallbackResults{Value: "fallback"}
+		ctx := _80_17
+		emitter := cff.NopEmitter()
+		interceptor := cff.InterceptorStack()
+
+		var (
+			flowInfo = &cff.FlowInfo{
+				File:   "go.uber.org/cff/internal/tests/inout/inout.go",
+				Line:   80,
+				Column: 8,
+			}
+			flowEmitter = cff.NopFlowEmitter()
+
+			schedInfo = &cff.SchedulerInfo{
+				Name:      flowInfo.Name,
+				Directive: cff.FlowDirective,
+				File:      flowInfo.File,
+				Line:      flowInfo.Line,
+				Column:    flowInfo.Column,
+			}
+
+			// possibly unused
+			_ = flowInfo
+		)
+
+		startTime := time.Now()
+		defer func() { flowEmitter.FlowDone(ctx, time.Since(startTime)) }()
+
+		schedEmitter := emitter.SchedulerInit(schedInfo)
+
+		sched := cff.NewScheduler(
+			cff.SchedulerParams{
+				Emitter: schedEmitter,
+			},
+		)
+
+		var tasks []*struct {
+			emitter cff.TaskEmitter
+			ran     cff.AtomicBool
+			run     func(context.Context) error
+			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		}
+		defer func() {
+			for _, t := range tasks {
+				if !t.ran.Load() {
+					t.emitter.TaskSkipped(ctx, t.outcome.SkipReason(ctx, err))
+				}
+			}
+		}()
+
+		// go.uber.org/cff/internal/tests/inout/inout.go:83:4
+		var (
+			v1 string
+			v6 bool
+		)
+
+		task3 := new(struct {
+			emitter cff.TaskEmitter
+			ran     cff.AtomicBool
+			run     func(context.Context) error
+			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		})
+		task3.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/inout/inout.go",
+			Line:   83,
+			Column: 4,
+		}
+		task3.emitter = cff.NopTaskEmitter()
+		task3.run = func(ctx context.Context) (err error) {
+			taskEmitter := task3.emitter
+			startTime := time.Now()
+			defer func() {
+				task3.outcome.Finish(err)
+				if task3.ran.Load() {
+					taskEmitter.TaskDone(ctx, time.Since(startTime))
+				}
+			}()
+
+			var task3Out0 FallbackResults
+			// Unpack cff.Out and cff.Maybe results after recovering from panics.
+			defer func() {
+				if err != nil {
+					return
+				}
+				v1 = task3Out0.Value
+				v6 = task3Out0.Ok
+			}()
+
+			defer func() {
+				recovered := recover()
+				if recovered != nil {
+					taskEmitter.TaskPanicRecovered(ctx, recovered)
+					task3Out0, err = _89_21, nil
+				}
+			}()
+
+			defer task3.ran.Store(true)
+
+			err = cff.Intercept(ctx, interceptor, task3.outcome.Info, func(ctx context.Context) (err error) {
+				task3Out0, err = _83_4()
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskErrorRecovered(ctx, err)
+				task3Out0, err = _89_21, nil
+			} else {
+				taskEmitter.TaskSuccess(ctx)
+			}
+
+			return
+		}
+
+		task3.job = sched.Enqueue(ctx, cff.Job{
+			Run: task3.run,
+		})
+		tasks = append(tasks, task3)
+
+		if err := sched.Wait(ctx); err != nil {
+			flowEmitter.FlowError(ctx, err)
+			cff.RethrowPanic(err, false)
+			return err
+		}
+
+		*(_81_15) = v1 // string
+		*(_81_23) = v6 // bool
+
+		flowEmitter.FlowSuccess(ctx)
+		return nil
+	}()
+	return value, ok, err
+}
+
+// PredicateParams uses a parameter object in a predicate.
+func PredicateParams(name string, count int) (n int64, err error) {
+	err = func() (err error) {
+
+		_97_17 := context.Background()
+
+		_98_14 := name
+
+		_98_20 := count
+
+		_99_15 := &n
+
+		_101_4 := func(p Params) int64 { return int64(len(p.Name) * p.Count) }
+
+		_102_18 := func(p Params) bool { return p.Count > 0 }
+		ctx := _97_17
+		var v1 string = _98_14
+		var v2 int = _98_20
+		emitter := cff.NopEmitter()
+		interceptor := cff.InterceptorStack()
+
+		var (
+			flowInfo = &cff.FlowInfo{
+				File:   "go.uber.org/cff/internal/tests/inout/inout.go",
+				Line:   97,
+				Column: 8,
+			}
+			flowEmitter = cff.NopFlowEmitter()
+
+			schedInfo = &cff.SchedulerInfo{
+				Name:      flowInfo.Name,
+				Directive: cff.FlowDirective,
+				File:      flowInfo.File,
+				Line:      flowInfo.Line,
+				Column:    flowInfo.Column,
+			}
+
+			// possibly unused
+			_ = flowInfo
+		)
+
+		startTime := time.Now()
+		defer func() { flowEmitter.FlowDone(ctx, time.Since(startTime)) }()
+
+		schedEmitter := emitter.SchedulerInit(schedInfo)
+
+		sched := cff.NewScheduler(
+			cff.SchedulerParams{
+				Emitter: schedEmitter,
+			},
+		)
+
+		var tasks []*struct {
+			emitter cff.TaskEmitter
+			ran     cff.AtomicBool
+			run     func(context.Context) error
+			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		}
+		defer func() {
+			for _, t := range tasks {
+				if !t.ran.Load() {
+					t.emitter.TaskSkipped(ctx, t.outcome.SkipReason(ctx, err))
+				}
+			}
+		}()
+
+		// go.uber.org/cff/internal/tests/inout/inout.go:102:4
+		var p0 bool
+		var p0PanicRecover interface{}
+		var p0PanicStacktrace []byte
+		_ = p0PanicStacktrace // possibly unused.
+		pred1 := new(struct {
+			ran cff.AtomicBool
+			run func(context.Context) error
+			job *cff.ScheduledJob
+		})
+		pred1.run = func(ctx context.Context) (err error) {
+			defer func() {
+				if recovered := recover(); recovered != nil {
+					p0PanicRecover = recovered
+					p0PanicStacktrace = debug.Stack()
+				}
+			}()
+			p0 = _102_18(Params{Name: v1, Count: v2})
+			return nil
+		}
+
+		pred1.job = sched.Enqueue(ctx, cff.Job{
+			Run: pred1.run,
+		})
+
+		// go.uber.org/cff/internal/tests/inout/inout.go:101:4
+		var (
+			v4 int64
+		)
+
+		task4 := new(struct {
+			emitter cff.TaskEmitter
+			ran     cff.AtomicBool
+			run     func(context.Context) error
+			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		})
+		task4.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/inout/inout.go",
+			Line:   101,
+			Column: 4,
+		}
+		task4.emitter = cff.NopTaskEmitter()
+		task4.run = func(ctx context.Context) (err error) {
+			taskEmitter := task4.emitter
+			startTime := time.Now()
+			defer func() {
+				task4.outcome.Finish(err)
+				if task4.ran.Load() {
+					taskEmitter.TaskDone(ctx, time.Since(startTime))
+				}
+			}()
+
+			defer func() {
+				recovered := recover()
+				var stacktrace []byte
+				if recovered != nil {
+					stacktrace = debug.Stack()
+				}
+				if recovered == nil && p0PanicRecover != nil {
+					recovered = p0PanicRecover
+					stacktrace = p0PanicStacktrace
+				}
+				if recovered != nil {
+					taskEmitter.TaskPanic(ctx, recovered)
+					err = &cff.PanicError{
+						Value:      recovered,
+						Stacktrace: stacktrace,
+					}
+				}
+			}()
+
+			if !p0 {
+				task4.outcome.Skip(cff.SkipPredicateFalse)
+				return nil
+			}
+
+			defer task4.ran.Store(true)
+
+			err = cff.Intercept(ctx, interceptor, task4.outcome.Info, func(ctx context.Context) (err error) {
+				v4 = _101_4(Params{Name: v1, Count: v2})
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return err
+			} else {
+				taskEmitter.TaskSuccess(ctx)
+			}
+
+			return
+		}
+
+		task4.job = sched.Enqueue(ctx, cff.Job{
+			Run: task4.run,
+			Dependencies: []*cff.ScheduledJob{
+				pred1.job,
+			},
+		})
+		tasks = append(tasks, task4)
+
+		if err := sched.Wait(ctx); err != nil {
+			flowEmitter.FlowError(ctx, err)
+			cff.RethrowPanic(err, false)
+			return err
+		}
+
+		*(_99_15) = v4 // int64
+
+		flowEmitter.FlowSuccess(ctx)
+		return nil
+	}()
+	return n, err
+}
@@ -0,0 +1,51 @@
+package inout
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGreet(t *testing.T) {
+	greeting, length, err := Greet("world", 2)
+	require.NoError(t, err)
+	assert.Equal(t, Greeting("hello world x2"), greeting)
+	assert.Equal(t, int64(len(greeting)), length)
+}
+
+func TestUnexported(t *testing.T) {
+	got, err := Unexported("foobar")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("foo"), got)
+}
+
+func TestFallback(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		value, ok, err := Fallback(false)
+		require.NoError(t, err)
+		assert.Equal(t, "value", value)
+		assert.True(t, ok)
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		value, ok, err := Fallback(true)
+		require.NoError(t, err)
+		assert.Equal(t, "fallback", value)
+		assert.False(t, ok)
+	})
+}
+
+func TestPredicateParams(t *testing.T) {
+	t.Run("run", func(t *testing.T) {
+		got, err := PredicateParams("foo", 2)
+		require.NoError(t, err)
+		assert.Equal(t, int64(6), got)
+	})
+
+	t.Run("skip", func(t *testing.T) {
+		got, err := PredicateParams("foo", 0)
+		require.NoError(t, err)
+		assert.Zero(t, got)
+	})
+}
@@ -35,6 +35,7 @@ func JoinTwo[A, B, C any](
 		_27_12 := fn
 		ctx := _23_18
 		emitter := cff.NopEmitter()
+		interceptor := cff.InterceptorStack()
 
 		var (
 			flowInfo = &cff.FlowInfo{
@@ -72,11 +73,13 @@ func JoinTwo[A, B, C any](
 			ran     cff.AtomicBool
 			run     func(context.Context) error
 			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
 		}
 		defer func() {
 			for _, t := range tasks {
 				if !t.ran.Load() {
-					t.emitter.TaskSkipped(ctx, err)
+					t.emitter.TaskSkipped(ctx, t.outcome.SkipReason(ctx, err))
 				}
 			}
 		}()
@@ -85,17 +88,26 @@ func JoinTwo[A, B, C any](
 		var (
 			v1 A
 		)
+
 		task0 := new(struct {
 			emitter cff.TaskEmitter
 			ran     cff.AtomicBool
 			run     func(context.Context) error
 			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
 		})
+		task0.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/insidegeneric/producer.go",
+			Line:   25,
+			Column: 12,
+		}
 		task0.emitter = cff.NopTaskEmitter()
 		task0.run = func(ctx context.Context) (err error) {
 			taskEmitter := task0.emitter
 			startTime := time.Now()
 			defer func() {
+				task0.outcome.Finish(err)
 				if task0.ran.Load() {
 					taskEmitter.TaskDone(ctx, time.Since(startTime))
 				}
@@ -114,8 +126,10 @@ func JoinTwo[A, B, C any](
 
 			defer task0.ran.Store(true)
 
-			v1, err = _25_12(ctx)
-
+			err = cff.Intercept(ctx, interceptor, task0.outcome.Info, func(ctx context.Context) (err error) {
+				v1, err = _25_12(ctx)
+				return
+			})
 			if err != nil {
 				taskEmitter.TaskError(ctx, err)
 				return err
@@ -135,17 +149,26 @@ func JoinTwo[A, B, C any](
 		var (
 			v2 B
 		)
+
 		task1 := new(struct {
 			emitter cff.TaskEmitter
 			ran     cff.AtomicBool
 			run     func(context.Context) error
 			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
 		})
+		task1.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/insidegeneric/producer.go",
+			Line:   26,
+			Column: 12,
+		}
 		task1.emitter = cff.NopTaskEmitter()
 		task1.run = func(ctx context.Context) (err error) {
 			taskEmitter := task1.emitter
 			startTime := time.Now()
 			defer func() {
+				task1.outcome.Finish(err)
 				if task1.ran.Load() {
 					taskEmitter.TaskDone(ctx, time.Since(startTime))
 				}
@@ -164,8 +187,10 @@ func JoinTwo[A, B, C any](
 
 			defer task1.ran.Store(true)
 
-			v2, err = _26_12(ctx)
-
+			err = cff.Intercept(ctx, interceptor, task1.outcome.Info, func(ctx context.Context) (err error) {
+				v2, err = _26_12(ctx)
+				return
+			})
 			if err != nil {
 				taskEmitter.TaskError(ctx, err)
 				return err
@@ -185,17 +210,30 @@ func JoinTwo[A, B, C any](
 		var (
 			v3 C
 		)
+
 		task2 := new(struct {
 			emitter cff.TaskEmitter
 			ran     cff.AtomicBool
 			run     func(context.Context) error
 			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
 		})
+		task2.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/insidegeneric/producer.go",
+			Line:   27,
+			Column: 12,
+		}
+		task2.outcome.Dependencies = []*cff.TaskOutcome{
+			&task0.outcome,
+			&task1.outcome,
+		}
 		task2.emitter = cff.NopTaskEmitter()
 		task2.run = func(ctx context.Context) (err error) {
 			taskEmitter := task2.emitter
 			startTime := time.Now()
 			defer func() {
+				task2.outcome.Finish(err)
 				if task2.ran.Load() {
 					taskEmitter.TaskDone(ctx, time.Since(startTime))
 				}
@@ -214,9 +252,16 @@ func JoinTwo[A, B, C any](
 
 			defer task2.ran.Store(true)
 
-			v3 = _27_12(v1, v2)
-
-			taskEmitter.TaskSuccess(ctx)
+			err = cff.Intercept(ctx, interceptor, task2.outcome.Info, func(ctx context.Context) (err error) {
+				v3 = _27_12(v1, v2)
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return err
+			} else {
+				taskEmitter.TaskSuccess(ctx)
+			}
 
 			return
 		}
@@ -232,6 +277,7 @@ func JoinTwo[A, B, C any](
 
 		if err := sched.Wait(ctx); err != nil {
 			flowEmitter.FlowError(ctx, err)
+			cff.RethrowPanic(err, false)
 			return err
 		}
 
@@ -260,6 +306,7 @@ func JoinMany[T any](producers ...Producer[T]) ([]T, error) {
 		_43_4 := producers
 		ctx := _36_22
 		emitter := cff.NopEmitter()
+		interceptor := cff.InterceptorStack()
 
 		var (
 			parallelInfo = &cff.ParallelInfo{
@@ -304,17 +351,27 @@ func JoinMany[T any](producers ...Producer[T]) ([]T, error) {
 			emitter cff.TaskEmitter
 			fn      func(context.Context) error
 			ran     cff.AtomicBool
+
+			outcome cff.TaskOutcome // reports why the task was skipped
 		}
 		defer func() {
 			for _, t := range tasks {
-				if !t.ran.Load() {
-					t.emitter.TaskSkipped(ctx, err)
+				// Tasks that are still running report their own outcome
+				// once they finish.
+				if !t.ran.Load() && !t.outcome.Running() {
+					t.emitter.TaskSkipped(ctx, t.outcome.SkipReason(ctx, err))
 				}
 			}
 		}()
 
 		// go.uber.org/cff/internal/tests/insidegeneric/producer.go:37:3
 		sliceTask3Slice := _43_4
+		sliceTask3Info := &cff.TaskInfo{
+			Name:   parallelInfo.Name,
+			File:   "go.uber.org/cff/internal/tests/insidegeneric/producer.go",
+			Line:   37,
+			Column: 3,
+		}
 		for idx, val := range sliceTask3Slice {
 			idx := idx
 			val := val
@@ -322,6 +379,8 @@ func JoinMany[T any](producers ...Producer[T]) ([]T, error) {
 				emitter cff.TaskEmitter
 				fn      func(context.Context) error
 				ran     cff.AtomicBool
+
+				outcome cff.TaskOutcome // reports why the task was skipped
 			})
 			sliceTask3.fn = func(ctx context.Context) (err error) {
 				defer func() {
@@ -333,8 +392,10 @@ func JoinMany[T any](producers ...Producer[T]) ([]T, error) {
 						}
 					}
 				}()
-				err = _38_4(ctx, idx, val)
-				return
+				return cff.Intercept(ctx, interceptor, sliceTask3Info, func(ctx context.Context) (err error) {
+					err = _38_4(ctx, idx, val)
+					return
+				})
 			}
 			sched.Enqueue(ctx, cff.Job{
 				Run: sliceTask3.fn,
@@ -343,6 +404,7 @@ func JoinMany[T any](producers ...Producer[T]) ([]T, error) {
 
 		if err := sched.Wait(ctx); err != nil {
 			parallelEmitter.ParallelError(ctx, err)
+			cff.RethrowPanic(err, false)
 			return err
 		}
 		parallelEmitter.ParallelSuccess(ctx)
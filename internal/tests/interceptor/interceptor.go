@@ -0,0 +1,157 @@
+//go:build cff
+// +build cff
+
+// Package interceptor tests tasks wrapped by cff.WithInterceptor
+// and cff.RegisterInterceptor.
+package interceptor
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+
+	"go.uber.org/cff"
+)
+
+type tagKey struct{}
+
+// Tag returns the tags added to ctx by interceptors built with Tagger.
+func Tag(ctx context.Context) string {
+	s, _ := ctx.Value(tagKey{}).(string)
+	return s
+}
+
+// Tagger returns an interceptor that appends tag to the tags of the
+// context of each task, and records the names of the tasks it wraps.
+type Tagger struct {
+	tag string
+
+	mu    sync.Mutex
+	Tasks []string
+}
+
+// NewTagger builds a Tagger with the given tag.
+func NewTagger(tag string) *Tagger {
+	return &Tagger{tag: tag}
+}
+
+// Intercept is a cff.Interceptor.
+func (t *Tagger) Intercept(ctx context.Context, info *cff.TaskInfo, next func(context.Context) error) error {
+	t.mu.Lock()
+	t.Tasks = append(t.Tasks, info.Name)
+	t.mu.Unlock()
+
+	return next(context.WithValue(ctx, tagKey{}, Tag(ctx)+t.tag))
+}
+
+// Deny is an interceptor that fails the tasks with the given names
+// without running them.
+func Deny(names ...string) cff.Interceptor {
+	return func(ctx context.Context, info *cff.TaskInfo, next func(context.Context) error) error {
+		for _, n := range names {
+			if n == info.Name {
+				return errors.New("denied " + n)
+			}
+		}
+		return next(ctx)
+	}
+}
+
+type count int
+
+// Flow runs a flow with two interceptors, and returns the tags seen by
+// its last task.
+func Flow(ctx context.Context, a, b cff.Interceptor) (string, error) {
+	var s string
+	err := cff.Flow(ctx,
+		cff.WithInterceptor(a),
+		cff.WithInterceptor(b),
+		cff.Results(&s),
+		cff.Task(
+			func(ctx context.Context) count {
+				return count(len(Tag(ctx)))
+			},
+			cff.Instrument("count"),
+		),
+		cff.Task(
+			func(ctx context.Context, c count) (string, error) {
+				return Tag(ctx) + strconv.Itoa(int(c)), nil
+			},
+			cff.Instrument("tags"),
+		),
+		cff.WithEmitter(cff.NopEmitter()),
+	)
+	return s, err
+}
+
+// Fallback runs a flow with an interceptor, recovering its failures with
+// cff.FallbackWith.
+func Fallback(ctx context.Context, i cff.Interceptor) (string, error) {
+	var s string
+	err := cff.Flow(ctx,
+		cff.WithInterceptor(i),
+		cff.Results(&s),
+		cff.Task(
+			func() (string, error) {
+				return "task", nil
+			},
+			cff.Instrument("task"),
+			cff.FallbackWith("fallback"),
+		),
+		cff.WithEmitter(cff.NopEmitter()),
+	)
+	return s, err
+}
+
+// Parallel runs a Parallel with a task, a slice, and a map, all wrapped by
+// the given interceptor, and returns the tags seen by each of them.
+func Parallel(ctx context.Context, i cff.Interceptor) ([]string, error) {
+	var (
+		mu   sync.Mutex
+		tags []string
+	)
+	record := func(ctx context.Context, name string) {
+		mu.Lock()
+		defer mu.Unlock()
+		tags = append(tags, name+":"+Tag(ctx))
+	}
+
+	err := cff.Parallel(ctx,
+		cff.WithInterceptor(i),
+		cff.Task(
+			func(ctx context.Context) {
+				record(ctx, "task")
+			},
+			cff.Instrument("task"),
+		),
+		cff.Slice(
+			func(ctx context.Context, s string) {
+				record(ctx, s)
+			},
+			[]string{"slice"},
+		),
+		cff.Map(
+			func(ctx context.Context, k string, _ int) {
+				record(ctx, k)
+			},
+			map[string]int{"map": 1},
+		),
+		cff.InstrumentParallel("parallel"),
+		cff.WithEmitter(cff.NopEmitter()),
+	)
+	return tags, err
+}
+
+// Unintercepted runs a flow without interceptors of its own,
+// and returns the tags seen by its task.
+func Unintercepted(ctx context.Context) (string, error) {
+	var s string
+	err := cff.Flow(ctx,
+		cff.Results(&s),
+		cff.Task(func(ctx context.Context) string {
+			return Tag(ctx)
+		}),
+	)
+	return s, err
+}
@@ -0,0 +1,841 @@
+//go:build !cff
+// +build !cff
+
+// Package interceptor tests tasks wrapped by cff.WithInterceptor
+// and cff.RegisterInterceptor.
+package interceptor
+
+import (
+	"context"
+	"errors"
+	"runtime/debug"
+	"strconv"
+	"sync"
+	"time"
+
+	"go.uber.org/cff"
+)
+
+type tagKey struct{}
+
+// Tag returns the tags added to ctx by interceptors built with Tagger.
+func Tag(ctx context.Context) string {
+	s, _ := ctx.Value(tagKey{}).(string)
+	return s
+}
+
+// Tagger returns an interceptor that appends tag to the tags of the
+// context of each task, and records the names of the tasks it wraps.
+type Tagger struct {
+	tag string
+
+	mu    sync.Mutex
+	Tasks []string
+}
+
+// NewTagger builds a Tagger with the given tag.
+func NewTagger(tag string) *Tagger {
+	return &Tagger{tag: tag}
+}
+
+// Intercept is a cff.Interceptor.
+func (t *Tagger) Intercept(ctx context.Context, info *cff.TaskInfo, next func(context.Context) error) error {
+	t.mu.Lock()
+	t.Tasks = append(t.Tasks, info.Name)
+	t.mu.Unlock()
+
+	return next(context.WithValue(ctx, tagKey{}, Tag(ctx)+t.tag))
+}
+
+// Deny is an interceptor that fails the tasks with the given names
+// without running them.
+func Deny(names ...string) cff.Interceptor {
+	return func(ctx context.Context, info *cff.TaskInfo, next func(context.Context) error) error {
+		for _, n := range names {
+			if n == info.Name {
+				return errors.New("denied " + n)
+			}
+		}
+		return next(ctx)
+	}
+}
+
+type count int
+
+// Flow runs a flow with two interceptors, and returns the tags seen by
+// its last task.
+func Flow(ctx context.Context, a, b cff.Interceptor) (string, error) {
+	var s string
+	err := func() (err error) {
+
+		_67_18 := ctx
+
+		_68_23 := a
+
+		_69_23 := b
+
+		_70_15 := &s
+
+		_72_4 := func(ctx context.Context) count {
+			return count(len(Tag(ctx)))
+		}
+
+		_75_19 := "count"
+
+		_78_4 := func(ctx context.Context, c count) (string, error) {
+			return Tag(ctx) + strconv.Itoa(int(c)), nil
+		}
+
+		_81_19 := "tags"
+
+		_83_19 := cff.NopEmitter()
+		ctx := _67_18
+		emitter := cff.EmitterStack(_83_19)
+		interceptor := cff.InterceptorStack(_68_23, _69_23)
+
+		var (
+			flowInfo = &cff.FlowInfo{
+				File:   "go.uber.org/cff/internal/tests/interceptor/interceptor.go",
+				Line:   67,
+				Column: 9,
+			}
+			flowEmitter = cff.NopFlowEmitter()
+
+			schedInfo = &cff.SchedulerInfo{
+				Name:      flowInfo.Name,
+				Directive: cff.FlowDirective,
+				File:      flowInfo.File,
+				Line:      flowInfo.Line,
+				Column:    flowInfo.Column,
+			}
+
+			// possibly unused
+			_ = flowInfo
+		)
+
+		startTime := time.Now()
+		defer func() { flowEmitter.FlowDone(ctx, time.Since(startTime)) }()
+
+		schedEmitter := emitter.SchedulerInit(schedInfo)
+
+		sched := cff.NewScheduler(
+			cff.SchedulerParams{
+				Emitter: schedEmitter,
+			},
+		)
+
+		var tasks []*struct {
+			emitter cff.TaskEmitter
+			ran     cff.AtomicBool
+			run     func(context.Context) error
+			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		}
+		defer func() {
+			for _, t := range tasks {
+				if !t.ran.Load() {
+					t.emitter.TaskSkipped(ctx, t.outcome.SkipReason(ctx, err))
+				}
+			}
+		}()
+
+		// go.uber.org/cff/internal/tests/interceptor/interceptor.go:72:4
+		var (
+			v1 count
+		)
+
+		task0 := new(struct {
+			emitter cff.TaskEmitter
+			ran     cff.AtomicBool
+			run     func(context.Context) error
+			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		})
+		task0.outcome.Info = &cff.TaskInfo{
+			Name:   _75_19,
+			File:   "go.uber.org/cff/internal/tests/interceptor/interceptor.go",
+			Line:   72,
+			Column: 4,
+		}
+		task0.emitter = emitter.TaskInit(
+			task0.outcome.Info,
+			&cff.DirectiveInfo{
+				Name:      flowInfo.Name,
+				Directive: cff.FlowDirective,
+				File:      flowInfo.File,
+				Line:      flowInfo.Line,
+				Column:    flowInfo.Column,
+			},
+		)
+		task0.run = func(ctx context.Context) (err error) {
+			taskEmitter := task0.emitter
+			startTime := time.Now()
+			defer func() {
+				task0.outcome.Finish(err)
+				if task0.ran.Load() {
+					taskEmitter.TaskDone(ctx, time.Since(startTime))
+				}
+			}()
+
+			defer func() {
+				recovered := recover()
+				if recovered != nil {
+					taskEmitter.TaskPanic(ctx, recovered)
+					err = &cff.PanicError{
+						Value:      recovered,
+						Stacktrace: debug.Stack(),
+					}
+				}
+			}()
+
+			defer task0.ran.Store(true)
+
+			err = cff.Intercept(ctx, interceptor, task0.outcome.Info, func(ctx context.Context) (err error) {
+				v1 = _72_4(ctx)
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return err
+			} else {
+				taskEmitter.TaskSuccess(ctx)
+			}
+
+			return
+		}
+
+		task0.job = sched.Enqueue(ctx, cff.Job{
+			Run: task0.run,
+		})
+		tasks = append(tasks, task0)
+
+		// go.uber.org/cff/internal/tests/interceptor/interceptor.go:78:4
+		var (
+			v2 string
+		)
+
+		task1 := new(struct {
+			emitter cff.TaskEmitter
+			ran     cff.AtomicBool
+			run     func(context.Context) error
+			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		})
+		task1.outcome.Info = &cff.TaskInfo{
+			Name:   _81_19,
+			File:   "go.uber.org/cff/internal/tests/interceptor/interceptor.go",
+			Line:   78,
+			Column: 4,
+		}
+		task1.outcome.Dependencies = []*cff.TaskOutcome{
+			&task0.outcome,
+		}
+		task1.emitter = emitter.TaskInit(
+			task1.outcome.Info,
+			&cff.DirectiveInfo{
+				Name:      flowInfo.Name,
+				Directive: cff.FlowDirective,
+				File:      flowInfo.File,
+				Line:      flowInfo.Line,
+				Column:    flowInfo.Column,
+			},
+		)
+		task1.run = func(ctx context.Context) (err error) {
+			taskEmitter := task1.emitter
+			startTime := time.Now()
+			defer func() {
+				task1.outcome.Finish(err)
+				if task1.ran.Load() {
+					taskEmitter.TaskDone(ctx, time.Since(startTime))
+				}
+			}()
+
+			defer func() {
+				recovered := recover()
+				if recovered != nil {
+					taskEmitter.TaskPanic(ctx, recovered)
+					err = &cff.PanicError{
+						Value:      recovered,
+						Stacktrace: debug.Stack(),
+					}
+				}
+			}()
+
+			defer task1.ran.Store(true)
+
+			err = cff.Intercept(ctx, interceptor, task1.outcome.Info, func(ctx context.Context) (err error) {
+				v2, err = _78_4(ctx, v1)
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return err
+			} else {
+				taskEmitter.TaskSuccess(ctx)
+			}
+
+			return
+		}
+
+		task1.job = sched.Enqueue(ctx, cff.Job{
+			Run: task1.run,
+			Dependencies: []*cff.ScheduledJob{
+				task0.job,
+			},
+		})
+		tasks = append(tasks, task1)
+
+		if err := sched.Wait(ctx); err != nil {
+			flowEmitter.FlowError(ctx, err)
+			cff.RethrowPanic(err, false)
+			return err
+		}
+
+		*(_70_15) = v2 // string
+
+		flowEmitter.FlowSuccess(ctx)
+		return nil
+	}()
+	return s, err
+}
+
+// Fallback runs a flow with an interceptor, recovering its failures with
+// cff.FallbackWith.
+func Fallback(ctx context.Context, i cff.Interceptor) (string, error) {
+	var s string
+	err := func() (err error) {
+
+		_92_18 := ctx
+
+		_93_23 := i
+
+		_94_15 := &s
+
+		_96_4 := func() (string, error) {
+			return "task", nil
+		}
+
+		_99_19 := "task"
+
+		_100_21 := "fallback"
+
+		_102_19 := cff.NopEmitter()
+		ctx := _92_18
+		emitter := cff.EmitterStack(_102_19)
+		interceptor := cff.InterceptorStack(_93_23)
+
+		var (
+			flowInfo = &cff.FlowInfo{
+				File:   "go.uber.org/cff/internal/tests/interceptor/interceptor.go",
+				Line:   92,
+				Column: 9,
+			}
+			flowEmitter = cff.NopFlowEmitter()
+
+			schedInfo = &cff.SchedulerInfo{
+				Name:      flowInfo.Name,
+				Directive: cff.FlowDirective,
+				File:      flowInfo.File,
+				Line:      flowInfo.Line,
+				Column:    flowInfo.Column,
+			}
+
+			// possibly unused
+			_ = flowInfo
+		)
+
+		startTime := time.Now()
+		defer func() { flowEmitter.FlowDone(ctx, time.Since(startTime)) }()
+
+		schedEmitter := emitter.SchedulerInit(schedInfo)
+
+		sched := cff.NewScheduler(
+			cff.SchedulerParams{
+				Emitter: schedEmitter,
+			},
+		)
+
+		var tasks []*struct {
+			emitter cff.TaskEmitter
+			ran     cff.AtomicBool
+			run     func(context.Context) error
+			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		}
+		defer func() {
+			for _, t := range tasks {
+				if !t.ran.Load() {
+					t.emitter.TaskSkipped(ctx, t.outcome.SkipReason(ctx, err))
+				}
+			}
+		}()
+
+		// go.uber.org/cff/internal/tests/interceptor/interceptor.go:96:4
+		var (
+			v2 string
+		)
+
+		task2 := new(struct {
+			emitter cff.TaskEmitter
+			ran     cff.AtomicBool
+			run     func(context.Context) error
+			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		})
+		task2.outcome.Info = &cff.TaskInfo{
+			Name:   _99_19,
+			File:   "go.uber.org/cff/internal/tests/interceptor/interceptor.go",
+			Line:   96,
+			Column: 4,
+		}
+		task2.emitter = emitter.TaskInit(
+			task2.outcome.Info,
+			&cff.DirectiveInfo{
+				Name:      flowInfo.Name,
+				Directive: cff.FlowDirective,
+				File:      flowInfo.File,
+				Line:      flowInfo.Line,
+				Column:    flowInfo.Column,
+			},
+		)
+		task2.run = func(ctx context.Context) (err error) {
+			taskEmitter := task2.emitter
+			startTime := time.Now()
+			defer func() {
+				task2.outcome.Finish(err)
+				if task2.ran.Load() {
+					taskEmitter.TaskDone(ctx, time.Since(startTime))
+				}
+			}()
+
+			defer func() {
+				recovered := recover()
+				if recovered != nil {
+					taskEmitter.TaskPanicRecovered(ctx, recovered)
+					v2, err = _100_21, nil
+				}
+			}()
+
+			defer task2.ran.Store(true)
+
+			err = cff.Intercept(ctx, interceptor, task2.outcome.Info, func(ctx context.Context) (err error) {
+				v2, err = _96_4()
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskErrorRecovered(ctx, err)
+				v2, err = _100_21, nil
+			} else {
+				taskEmitter.TaskSuccess(ctx)
+			}
+
+			return
+		}
+
+		task2.job = sched.Enqueue(ctx, cff.Job{
+			Run: task2.run,
+		})
+		tasks = append(tasks, task2)
+
+		if err := sched.Wait(ctx); err != nil {
+			flowEmitter.FlowError(ctx, err)
+			cff.RethrowPanic(err, false)
+			return err
+		}
+
+		*(_94_15) = v2 // string
+
+		flowEmitter.FlowSuccess(ctx)
+		return nil
+	}()
+	return s, err
+}
+
+// Parallel runs a Parallel with a task, a slice, and a map, all wrapped by
+// the given interceptor, and returns the tags seen by each of them.
+func Parallel(ctx context.Context, i cff.Interceptor) ([]string, error) {
+	var (
+		mu   sync.Mutex
+		tags []string
+	)
+	record := func(ctx context.Context, name string) {
+		mu.Lock()
+		defer mu.Unlock()
+		tags = append(tags, name+":"+Tag(ctx))
+	}
+
+	err := func() (err error) {
+
+		_120_22 := ctx
+
+		_121_23 := i
+
+		_123_4 := func(ctx context.Context) {
+			record(ctx, "task")
+		}
+
+		_126_19 := "task"
+
+		_129_4 := func(ctx context.Context, s string) {
+			record(ctx, s)
+		}
+
+		_132_4 := []string{"slice"}
+
+		_135_4 := func(ctx context.Context, k string, _ int) {
+			record(ctx, k)
+		}
+
+		_138_4 := map[string]int{"map": 1}
+
+		_140_26 := "parallel"
+
+		_141_19 := cff.NopEmitter()
+		ctx := _120_22
+		emitter := cff.EmitterStack(_141_19)
+		interceptor := cff.InterceptorStack(_121_23)
+
+		var (
+			parallelInfo = &cff.ParallelInfo{
+				Name:   _140_26,
+				File:   "go.uber.org/cff/internal/tests/interceptor/interceptor.go",
+				Line:   120,
+				Column: 9,
+			}
+			directiveInfo = &cff.DirectiveInfo{
+				Name:      parallelInfo.Name,
+				Directive: cff.ParallelDirective,
+				File:      parallelInfo.File,
+				Line:      parallelInfo.Line,
+				Column:    parallelInfo.Column,
+			}
+			parallelEmitter = emitter.ParallelInit(parallelInfo)
+
+			schedInfo = &cff.SchedulerInfo{
+				Name:      parallelInfo.Name,
+				Directive: cff.ParallelDirective,
+				File:      parallelInfo.File,
+				Line:      parallelInfo.Line,
+				Column:    parallelInfo.Column,
+			}
+
+			// possibly unused
+			_ = parallelInfo
+			_ = directiveInfo
+		)
+
+		startTime := time.Now()
+		defer func() { parallelEmitter.ParallelDone(ctx, time.Since(startTime)) }()
+
+		schedEmitter := emitter.SchedulerInit(schedInfo)
+
+		sched := cff.NewScheduler(
+			cff.SchedulerParams{
+				Emitter: schedEmitter,
+			},
+		)
+
+		var tasks []*struct {
+			emitter cff.TaskEmitter
+			fn      func(context.Context) error
+			ran     cff.AtomicBool
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		}
+		defer func() {
+			for _, t := range tasks {
+				// Tasks that are still running report their own outcome
+				// once they finish.
+				if !t.ran.Load() && !t.outcome.Running() {
+					t.emitter.TaskSkipped(ctx, t.outcome.SkipReason(ctx, err))
+				}
+			}
+		}()
+
+		// go.uber.org/cff/internal/tests/interceptor/interceptor.go:123:4
+		task3 := new(struct {
+			emitter cff.TaskEmitter
+			fn      func(context.Context) error
+			ran     cff.AtomicBool
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		})
+		task3.outcome.Info = &cff.TaskInfo{
+			Name:   _126_19,
+			File:   "go.uber.org/cff/internal/tests/interceptor/interceptor.go",
+			Line:   123,
+			Column: 4,
+		}
+		task3.emitter = emitter.TaskInit(task3.outcome.Info, directiveInfo)
+		task3.fn = func(ctx context.Context) (err error) {
+			task3.outcome.Start()
+			taskEmitter := task3.emitter
+			startTime := time.Now()
+			defer func() {
+				task3.outcome.Finish(err)
+				if task3.ran.Load() {
+					taskEmitter.TaskDone(ctx, time.Since(startTime))
+				}
+			}()
+
+			defer func() {
+				recovered := recover()
+				if recovered != nil {
+					taskEmitter.TaskPanic(ctx, recovered)
+					err = &cff.PanicError{
+						Value:      recovered,
+						Stacktrace: debug.Stack(),
+					}
+				}
+			}()
+
+			defer task3.ran.Store(true)
+
+			err = cff.Intercept(ctx, interceptor, task3.outcome.Info, func(ctx context.Context) (err error) {
+				_123_4(ctx)
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return
+			}
+			taskEmitter.TaskSuccess(ctx)
+			return
+		}
+
+		sched.Enqueue(ctx, cff.Job{
+			Run: task3.fn,
+		})
+		tasks = append(tasks, task3)
+
+		// go.uber.org/cff/internal/tests/interceptor/interceptor.go:128:3
+		sliceTask4Slice := _132_4
+		sliceTask4Info := &cff.TaskInfo{
+			Name:   parallelInfo.Name,
+			File:   "go.uber.org/cff/internal/tests/interceptor/interceptor.go",
+			Line:   128,
+			Column: 3,
+		}
+		for _, val := range sliceTask4Slice {
+
+			val := val
+			sliceTask4 := new(struct {
+				emitter cff.TaskEmitter
+				fn      func(context.Context) error
+				ran     cff.AtomicBool
+
+				outcome cff.TaskOutcome // reports why the task was skipped
+			})
+			sliceTask4.fn = func(ctx context.Context) (err error) {
+				defer func() {
+					recovered := recover()
+					if recovered != nil {
+						err = &cff.PanicError{
+							Value:      recovered,
+							Stacktrace: debug.Stack(),
+						}
+					}
+				}()
+				return cff.Intercept(ctx, interceptor, sliceTask4Info, func(ctx context.Context) (err error) {
+					_129_4(ctx, val)
+					return
+				})
+			}
+			sched.Enqueue(ctx, cff.Job{
+				Run: sliceTask4.fn,
+			})
+		}
+
+		mapTask5Info := &cff.TaskInfo{
+			Name:   parallelInfo.Name,
+			File:   "go.uber.org/cff/internal/tests/interceptor/interceptor.go",
+			Line:   134,
+			Column: 3,
+		}
+		// go.uber.org/cff/internal/tests/interceptor/interceptor.go:134:3
+		for key, val := range _138_4 {
+			key := key
+			val := val
+			mapTask5 := new(struct {
+				emitter cff.TaskEmitter
+				fn      func(context.Context) error
+				ran     cff.AtomicBool
+
+				outcome cff.TaskOutcome // reports why the task was skipped
+			})
+			mapTask5.fn = func(ctx context.Context) (err error) {
+				defer func() {
+					recovered := recover()
+					if recovered != nil {
+						err = &cff.PanicError{
+							Value:      recovered,
+							Stacktrace: debug.Stack(),
+						}
+					}
+				}()
+
+				return cff.Intercept(ctx, interceptor, mapTask5Info, func(ctx context.Context) (err error) {
+					_135_4(ctx, key, val)
+					return
+				})
+			}
+
+			sched.Enqueue(ctx, cff.Job{
+				Run: mapTask5.fn,
+			})
+		}
+
+		if err := sched.Wait(ctx); err != nil {
+			parallelEmitter.ParallelError(ctx, err)
+			cff.RethrowPanic(err, false)
+			return err
+		}
+		parallelEmitter.ParallelSuccess(ctx)
+		return nil /*line interceptor.go:141*/
+	}()
+	return tags, err
+}
+
+// Unintercepted runs a flow without interceptors of its own,
+// and returns the tags seen by its task.
+func Unintercepted(ctx context.Context) (string, error) {
+	var s string
+	err := func() (err error) {
+
+		_150_18 := ctx
+
+		_151_15 := &s
+
+		_152_12 := func(ctx context.Context) string {
+			return Tag(ctx)
+		}
+		ctx := _150_18
+		emitter := cff.NopEmitter()
+		interceptor := cff.InterceptorStack()
+
+		var (
+			flowInfo = &cff.FlowInfo{
+				File:   "go.uber.org/cff/internal/tests/interceptor/interceptor.go",
+				Line:   150,
+				Column: 9,
+			}
+			flowEmitter = cff.NopFlowEmitter()
+
+			schedInfo = &cff.SchedulerInfo{
+				Name:      flowInfo.Name,
+				Directive: cff.FlowDirective,
+				File:      flowInfo.File,
+				Line:      flowInfo.Line,
+				Column:    flowInfo.Column,
+			}
+
+			// possibly unused
+			_ = flowInfo
+		)
+
+		startTime := time.Now()
+		defer func() { flowEmitter.FlowDone(ctx, time.Since(startTime)) }()
+
+		schedEmitter := emitter.SchedulerInit(schedInfo)
+
+		sched := cff.NewScheduler(
+			cff.SchedulerParams{
+				Emitter: schedEmitter,
+			},
+		)
+
+		var tasks []*struct {
+			emitter cff.TaskEmitter
+			ran     cff.AtomicBool
+			run     func(context.Context) error
+			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		}
+		defer func() {
+			for _, t := range tasks {
+				if !t.ran.Load() {
+					t.emitter.TaskSkipped(ctx, t.outcome.SkipReason(ctx, err))
+				}
+			}
+		}()
+
+		// go.uber.org/cff/internal/tests/interceptor/interceptor.go:152:12
+		var (
+			v2 string
+		)
+
+		task6 := new(struct {
+			emitter cff.TaskEmitter
+			ran     cff.AtomicBool
+			run     func(context.Context) error
+			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		})
+		task6.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/interceptor/interceptor.go",
+			Line:   152,
+			Column: 12,
+		}
+		task6.emitter = cff.NopTaskEmitter()
+		task6.run = func(ctx context.Context) (err error) {
+			taskEmitter := task6.emitter
+			startTime := time.Now()
+			defer func() {
+				task6.outcome.Finish(err)
+				if task6.ran.Load() {
+					taskEmitter.TaskDone(ctx, time.Since(startTime))
+				}
+			}()
+
+			defer func() {
+				recovered := recover()
+				if recovered != nil {
+					taskEmitter.TaskPanic(ctx, recovered)
+					err = &cff.PanicError{
+						Value:      recovered,
+						Stacktrace: debug.Stack(),
+					}
+				}
+			}()
+
+			defer task6.ran.Store(true)
+
+			err = cff.Intercept(ctx, interceptor, task6.outcome.Info, func(ctx context.Context) (err error) {
+				v2 = _152_12(ctx)
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return err
+			} else {
+				taskEmitter.TaskSuccess(ctx)
+			}
+
+			return
+		}
+
+		task6.job = sched.Enqueue(ctx, cff.Job{
+			Run: task6.run,
+		})
+		tasks = append(tasks, task6)
+
+		if err := sched.Wait(ctx); err != nil {
+			flowEmitter.FlowError(ctx, err)
+			cff.RethrowPanic(err, false)
+			return err
+		}
+
+		*(_151_15) = v2 // string
+
+		flowEmitter.FlowSuccess(ctx)
+		return nil
+	}()
+	return s, err
+}
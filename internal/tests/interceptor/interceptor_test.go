@@ -0,0 +1,72 @@
+package interceptor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/cff"
+)
+
+func TestFlow(t *testing.T) {
+	a, b := NewTagger("a"), NewTagger("b")
+
+	s, err := Flow(context.Background(), a.Intercept, b.Intercept)
+	require.NoError(t, err)
+	assert.Equal(t, "ab2", s, "interceptors must be applied in order")
+	assert.Equal(t, []string{"count", "tags"}, a.Tasks)
+	assert.Equal(t, []string{"count", "tags"}, b.Tasks)
+}
+
+func TestFlow_Denied(t *testing.T) {
+	_, err := Flow(context.Background(), NewTagger("a").Intercept, Deny("count"))
+	assert.EqualError(t, err, "denied count",
+		"interceptor must be able to fail tasks that don't return errors")
+}
+
+func TestFallback(t *testing.T) {
+	s, err := Fallback(context.Background(), Deny("task"))
+	require.NoError(t, err)
+	assert.Equal(t, "fallback", s)
+}
+
+func TestParallel(t *testing.T) {
+	tags, err := Parallel(context.Background(), NewTagger("a").Intercept)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"task:a", "slice:a", "map:a"}, tags)
+}
+
+func TestParallel_Denied(t *testing.T) {
+	_, err := Parallel(context.Background(), Deny("task", "parallel"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "denied")
+}
+
+func TestRegisterInterceptor(t *testing.T) {
+	unregister := cff.RegisterInterceptor(NewTagger("global").Intercept)
+	defer unregister()
+
+	t.Run("flow", func(t *testing.T) {
+		s, err := Flow(context.Background(), NewTagger("a").Intercept, NewTagger("b").Intercept)
+		require.NoError(t, err)
+		assert.Equal(t, "globalab8", s, "global interceptors must be outermost")
+	})
+
+	t.Run("parallel", func(t *testing.T) {
+		tags, err := Parallel(context.Background(), NewTagger("a").Intercept)
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{"task:globala", "slice:globala", "map:globala"}, tags)
+	})
+
+	t.Run("without interceptors", func(t *testing.T) {
+		s, err := Unintercepted(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, "global", s)
+	})
+
+	unregister()
+	s, err := Unintercepted(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, s)
+}
@@ -0,0 +1,126 @@
+//go:build cff
+// +build cff
+
+// Package lazy tests flows that use cff.Lazy.
+package lazy
+
+import (
+	"context"
+	"strconv"
+	"sync"
+
+	"go.uber.org/cff"
+)
+
+// Recorder records the names of tasks that ran.
+type Recorder struct {
+	mu    sync.Mutex
+	names []string
+}
+
+// Record records that the named task ran.
+func (r *Recorder) Record(name string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.names = append(r.names, name)
+}
+
+// Names returns the names of the tasks that ran.
+func (r *Recorder) Names() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string(nil), r.names...)
+}
+
+// Key identifies a record.
+type Key int
+
+// Name is the name of a record.
+type Name string
+
+// Size is the size of a record.
+type Size int64
+
+// Owner is the owner of a record.
+type Owner string
+
+// Tasks returns the tasks shared by the flows of this package.
+//
+// Name and Size are loaded together.
+// Owner is loaded separately, and a log entry is written for every request.
+var _tasks = cff.Bundle(
+	cff.Task(func(r *Recorder, k Key) (Name, Size) {
+		r.Record("load")
+		return Name("record" + strconv.Itoa(int(k))), Size(k * 10)
+	}),
+	cff.Task(func(r *Recorder, k Key) Owner {
+		r.Record("owner")
+		return Owner("user" + strconv.Itoa(int(k)))
+	}),
+	cff.Task(func(r *Recorder, k Key) {
+		r.Record("log")
+	}, cff.Invoke(true)),
+)
+
+// GetName runs only the tasks needed to get the name of a record.
+func GetName(ctx context.Context, r *Recorder, k Key) (Name, error) {
+	var name Name
+	err := cff.Flow(ctx,
+		cff.Params(r, k),
+		cff.Results(&name),
+		cff.Use(_tasks),
+		cff.Lazy(),
+	)
+	return name, err
+}
+
+// GetOwner runs only the tasks needed to get the owner of a record.
+func GetOwner(ctx context.Context, r *Recorder, k Key) (Owner, error) {
+	var owner Owner
+	err := cff.Flow(ctx,
+		cff.Params(r, k),
+		cff.Results(&owner),
+		cff.Use(_tasks),
+		cff.Lazy(),
+	)
+	return owner, err
+}
+
+// Prefix is prepended to descriptions.
+type Prefix string
+
+// Factor scales sizes.
+type Factor float64
+
+// Describe runs only the tasks needed to describe a record.
+// The tasks are specified in the flow itself.
+// The factor is consumed only by an omitted task,
+// and the suffix is referenced only by the predicate of that task.
+func Describe(ctx context.Context, r *Recorder, k Key, prefix Prefix) (string, error) {
+	suffix := "!"
+	var desc string
+	err := cff.Flow(ctx,
+		cff.Params(k, prefix, Factor(2)),
+		cff.Results(&desc),
+		cff.Task(func(k Key) Name {
+			r.Record("name")
+			return Name(strconv.Itoa(int(k)))
+		}),
+		cff.Task(func(k Key) Size {
+			r.Record("size")
+			return Size(k)
+		}),
+		cff.Task(func(prefix Prefix, n Name) string {
+			r.Record("describe")
+			return string(prefix) + string(n)
+		}),
+		cff.Task(func(s Size, f Factor) float64 {
+			r.Record("unused")
+			return float64(s) * float64(f)
+		}, cff.Predicate(func(s Size) bool {
+			return len(suffix) > 0
+		})),
+		cff.Lazy(),
+	)
+	return desc, err
+}
@@ -0,0 +1,726 @@
+//go:build !cff
+// +build !cff
+
+// Package lazy tests flows that use cff.Lazy.
+package lazy
+
+import (
+	"context"
+	"runtime/debug"
+	"strconv"
+	"sync"
+	"time"
+
+	"go.uber.org/cff"
+)
+
+// Recorder records the names of tasks that ran.
+type Recorder struct {
+	mu    sync.Mutex
+	names []string
+}
+
+// Record records that the named task ran.
+func (r *Recorder) Record(name string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.names = append(r.names, name)
+}
+
+// Names returns the names of the tasks that ran.
+func (r *Recorder) Names() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string(nil), r.names...)
+}
+
+// Key identifies a record.
+type Key int
+
+// Name is the name of a record.
+type Name string
+
+// Size is the size of a record.
+type Size int64
+
+// Owner is the owner of a record.
+type Owner string
+
+// Tasks returns the tasks shared by the flows of this package.
+//
+// Name and Size are loaded together.
+// Owner is loaded separately, and a log entry is written for every request.
+var _tasks = cff.TaskBundle{}
+
+// GetName runs only the tasks needed to get the name of a record.
+func GetName(ctx context.Context, r *Recorder, k Key) (Name, error) {
+	var name Name
+	err := func() (err error) {
+
+		_52_11 := func(r *Recorder, k Key) (Name, Size) {
+			r.Record("load")
+			return Name("record" + strconv.Itoa(int(k))), Size(k * 10)
+		}
+
+		_60_11 := func(r *Recorder, k Key) {
+			r.Record("log")
+		}
+
+		_68_18 := ctx
+
+		_69_14 := r
+
+		_69_17 := k
+
+		_70_15 := &name
+		ctx := _68_18
+		var v1 *Recorder = _69_14
+		var v2 Key = _69_17
+		emitter := cff.NopEmitter()
+		interceptor := cff.InterceptorStack()
+
+		var (
+			flowInfo = &cff.FlowInfo{
+				File:   "go.uber.org/cff/internal/tests/lazy/lazy.go",
+				Line:   68,
+				Column: 9,
+			}
+			flowEmitter = cff.NopFlowEmitter()
+
+			schedInfo = &cff.SchedulerInfo{
+				Name:      flowInfo.Name,
+				Directive: cff.FlowDirective,
+				File:      flowInfo.File,
+				Line:      flowInfo.Line,
+				Column:    flowInfo.Column,
+			}
+
+			// possibly unused
+			_ = flowInfo
+		)
+
+		startTime := time.Now()
+		defer func() { flowEmitter.FlowDone(ctx, time.Since(startTime)) }()
+
+		schedEmitter := emitter.SchedulerInit(schedInfo)
+
+		sched := cff.NewScheduler(
+			cff.SchedulerParams{
+				Emitter: schedEmitter,
+			},
+		)
+
+		var tasks []*struct {
+			emitter cff.TaskEmitter
+			ran     cff.AtomicBool
+			run     func(context.Context) error
+			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		}
+		defer func() {
+			for _, t := range tasks {
+				if !t.ran.Load() {
+					t.emitter.TaskSkipped(ctx, t.outcome.SkipReason(ctx, err))
+				}
+			}
+		}()
+
+		// go.uber.org/cff/internal/tests/lazy/lazy.go:52:11
+		var (
+			v3 Name
+			v4 Size
+		)
+		// Outputs of tasks added with cff.Use or of lazy flows
+		// may be unused.
+		_ = v3
+		_ = v4
+
+		task0 := new(struct {
+			emitter cff.TaskEmitter
+			ran     cff.AtomicBool
+			run     func(context.Context) error
+			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		})
+		task0.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/lazy/lazy.go",
+			Line:   52,
+			Column: 11,
+		}
+		task0.emitter = cff.NopTaskEmitter()
+		task0.run = func(ctx context.Context) (err error) {
+			taskEmitter := task0.emitter
+			startTime := time.Now()
+			defer func() {
+				task0.outcome.Finish(err)
+				if task0.ran.Load() {
+					taskEmitter.TaskDone(ctx, time.Since(startTime))
+				}
+			}()
+
+			defer func() {
+				recovered := recover()
+				if recovered != nil {
+					taskEmitter.TaskPanic(ctx, recovered)
+					err = &cff.PanicError{
+						Value:      recovered,
+						Stacktrace: debug.Stack(),
+					}
+				}
+			}()
+
+			defer task0.ran.Store(true)
+
+			err = cff.Intercept(ctx, interceptor, task0.outcome.Info, func(ctx context.Context) (err error) {
+				v3, v4 = _52_11(v1, v2)
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return err
+			} else {
+				taskEmitter.TaskSuccess(ctx)
+			}
+
+			return
+		}
+
+		task0.job = sched.Enqueue(ctx, cff.Job{
+			Run: task0.run,
+		})
+		tasks = append(tasks, task0)
+
+		// go.uber.org/cff/internal/tests/lazy/lazy.go:60:11
+		task2 := new(struct {
+			emitter cff.TaskEmitter
+			ran     cff.AtomicBool
+			run     func(context.Context) error
+			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		})
+		task2.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/lazy/lazy.go",
+			Line:   60,
+			Column: 11,
+		}
+		task2.emitter = cff.NopTaskEmitter()
+		task2.run = func(ctx context.Context) (err error) {
+			taskEmitter := task2.emitter
+			startTime := time.Now()
+			defer func() {
+				task2.outcome.Finish(err)
+				if task2.ran.Load() {
+					taskEmitter.TaskDone(ctx, time.Since(startTime))
+				}
+			}()
+
+			defer func() {
+				recovered := recover()
+				if recovered != nil {
+					taskEmitter.TaskPanic(ctx, recovered)
+					err = &cff.PanicError{
+						Value:      recovered,
+						Stacktrace: debug.Stack(),
+					}
+				}
+			}()
+
+			defer task2.ran.Store(true)
+
+			err = cff.Intercept(ctx, interceptor, task2.outcome.Info, func(ctx context.Context) (err error) {
+				_60_11(v1, v2)
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return err
+			} else {
+				taskEmitter.TaskSuccess(ctx)
+			}
+
+			return
+		}
+
+		task2.job = sched.Enqueue(ctx, cff.Job{
+			Run: task2.run,
+		})
+		tasks = append(tasks, task2)
+
+		if err := sched.Wait(ctx); err != nil {
+			flowEmitter.FlowError(ctx, err)
+			cff.RethrowPanic(err, false)
+			return err
+		}
+
+		*(_70_15) = v3 // go.uber.org/cff/internal/tests/lazy.Name
+
+		flowEmitter.FlowSuccess(ctx)
+		return nil
+	}()
+	return name, err
+}
+
+// GetOwner runs only the tasks needed to get the owner of a record.
+func GetOwner(ctx context.Context, r *Recorder, k Key) (Owner, error) {
+	var owner Owner
+	err := func() (err error) {
+
+		_56_11 := func(r *Recorder, k Key) Owner {
+			r.Record("owner")
+			return Owner("user" + strconv.Itoa(int(k)))
+		}
+
+		_60_11 := func(r *Recorder, k Key) {
+			r.Record("log")
+		}
+
+		_80_18 := ctx
+
+		_81_14 := r
+
+		_81_17 := k
+
+		_82_15 := &owner
+		ctx := _80_18
+		var v1 *Recorder = _81_14
+		var v2 Key = _81_17
+		emitter := cff.NopEmitter()
+		interceptor := cff.InterceptorStack()
+
+		var (
+			flowInfo = &cff.FlowInfo{
+				File:   "go.uber.org/cff/internal/tests/lazy/lazy.go",
+				Line:   80,
+				Column: 9,
+			}
+			flowEmitter = cff.NopFlowEmitter()
+
+			schedInfo = &cff.SchedulerInfo{
+				Name:      flowInfo.Name,
+				Directive: cff.FlowDirective,
+				File:      flowInfo.File,
+				Line:      flowInfo.Line,
+				Column:    flowInfo.Column,
+			}
+
+			// possibly unused
+			_ = flowInfo
+		)
+
+		startTime := time.Now()
+		defer func() { flowEmitter.FlowDone(ctx, time.Since(startTime)) }()
+
+		schedEmitter := emitter.SchedulerInit(schedInfo)
+
+		sched := cff.NewScheduler(
+			cff.SchedulerParams{
+				Emitter: schedEmitter,
+			},
+		)
+
+		var tasks []*struct {
+			emitter cff.TaskEmitter
+			ran     cff.AtomicBool
+			run     func(context.Context) error
+			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		}
+		defer func() {
+			for _, t := range tasks {
+				if !t.ran.Load() {
+					t.emitter.TaskSkipped(ctx, t.outcome.SkipReason(ctx, err))
+				}
+			}
+		}()
+
+		// go.uber.org/cff/internal/tests/lazy/lazy.go:56:11
+		var (
+			v5 Owner
+		)
+		// Outputs of tasks added with cff.Use or of lazy flows
+		// may be unused.
+		_ = v5
+
+		task4 := new(struct {
+			emitter cff.TaskEmitter
+			ran     cff.AtomicBool
+			run     func(context.Context) error
+			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		})
+		task4.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/lazy/lazy.go",
+			Line:   56,
+			Column: 11,
+		}
+		task4.emitter = cff.NopTaskEmitter()
+		task4.run = func(ctx context.Context) (err error) {
+			taskEmitter := task4.emitter
+			startTime := time.Now()
+			defer func() {
+				task4.outcome.Finish(err)
+				if task4.ran.Load() {
+					taskEmitter.TaskDone(ctx, time.Since(startTime))
+				}
+			}()
+
+			defer func() {
+				recovered := recover()
+				if recovered != nil {
+					taskEmitter.TaskPanic(ctx, recovered)
+					err = &cff.PanicError{
+						Value:      recovered,
+						Stacktrace: debug.Stack(),
+					}
+				}
+			}()
+
+			defer task4.ran.Store(true)
+
+			err = cff.Intercept(ctx, interceptor, task4.outcome.Info, func(ctx context.Context) (err error) {
+				v5 = _56_11(v1, v2)
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return err
+			} else {
+				taskEmitter.TaskSuccess(ctx)
+			}
+
+			return
+		}
+
+		task4.job = sched.Enqueue(ctx, cff.Job{
+			Run: task4.run,
+		})
+		tasks = append(tasks, task4)
+
+		// go.uber.org/cff/internal/tests/lazy/lazy.go:60:11
+		task5 := new(struct {
+			emitter cff.TaskEmitter
+			ran     cff.AtomicBool
+			run     func(context.Context) error
+			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		})
+		task5.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/lazy/lazy.go",
+			Line:   60,
+			Column: 11,
+		}
+		task5.emitter = cff.NopTaskEmitter()
+		task5.run = func(ctx context.Context) (err error) {
+			taskEmitter := task5.emitter
+			startTime := time.Now()
+			defer func() {
+				task5.outcome.Finish(err)
+				if task5.ran.Load() {
+					taskEmitter.TaskDone(ctx, time.Since(startTime))
+				}
+			}()
+
+			defer func() {
+				recovered := recover()
+				if recovered != nil {
+					taskEmitter.TaskPanic(ctx, recovered)
+					err = &cff.PanicError{
+						Value:      recovered,
+						Stacktrace: debug.Stack(),
+					}
+				}
+			}()
+
+			defer task5.ran.Store(true)
+
+			err = cff.Intercept(ctx, interceptor, task5.outcome.Info, func(ctx context.Context) (err error) {
+				_60_11(v1, v2)
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return err
+			} else {
+				taskEmitter.TaskSuccess(ctx)
+			}
+
+			return
+		}
+
+		task5.job = sched.Enqueue(ctx, cff.Job{
+			Run: task5.run,
+		})
+		tasks = append(tasks, task5)
+
+		if err := sched.Wait(ctx); err != nil {
+			flowEmitter.FlowError(ctx, err)
+			cff.RethrowPanic(err, false)
+			return err
+		}
+
+		*(_82_15) = v5 // go.uber.org/cff/internal/tests/lazy.Owner
+
+		flowEmitter.FlowSuccess(ctx)
+		return nil
+	}()
+	return owner, err
+}
+
+// Prefix is prepended to descriptions.
+type Prefix string
+
+// Factor scales sizes.
+type Factor float64
+
+// Describe runs only the tasks needed to describe a record.
+// The tasks are specified in the flow itself.
+// The factor is consumed only by an omitted task,
+// and the suffix is referenced only by the predicate of that task.
+func Describe(ctx context.Context, r *Recorder, k Key, prefix Prefix) (string, error) {
+	suffix := "!"
+	var desc string
+	err := func() (err error) {
+
+		_102_18 := ctx
+
+		_103_14 := k
+
+		_103_17 := prefix
+
+		_103_25 := Factor(2)
+
+		_104_15 := &desc
+
+		_105_12 := func(k Key) Name {
+			r.Record("name")
+			return Name(strconv.Itoa(int(k)))
+		}
+
+		_109_12 := func(k Key) Size {
+			r.Record("size")
+			return Size(k)
+		}
+
+		_113_12 := func(prefix Prefix, n Name) string {
+			r.Record("describe")
+			return string(prefix) + string(n)
+		}
+
+		_117_12 := func(s Size, f Factor) float64 {
+			r.Record("unused")
+			return float64(s) * float64(f)
+		}
+
+		_120_20 := func(s Size) bool {
+			return len(suffix) > 0
+		}
+		ctx := _102_18
+		var v2 Key = _103_14
+		var v6 Prefix = _103_17
+		_ = _109_12 // omitted by cff.Lazy
+		_ = _117_12 // omitted by cff.Lazy
+		_ = _120_20 // omitted by cff.Lazy
+		_ = _103_25 // omitted by cff.Lazy
+		emitter := cff.NopEmitter()
+		interceptor := cff.InterceptorStack()
+
+		var (
+			flowInfo = &cff.FlowInfo{
+				File:   "go.uber.org/cff/internal/tests/lazy/lazy.go",
+				Line:   102,
+				Column: 9,
+			}
+			flowEmitter = cff.NopFlowEmitter()
+
+			schedInfo = &cff.SchedulerInfo{
+				Name:      flowInfo.Name,
+				Directive: cff.FlowDirective,
+				File:      flowInfo.File,
+				Line:      flowInfo.Line,
+				Column:    flowInfo.Column,
+			}
+
+			// possibly unused
+			_ = flowInfo
+		)
+
+		startTime := time.Now()
+		defer func() { flowEmitter.FlowDone(ctx, time.Since(startTime)) }()
+
+		schedEmitter := emitter.SchedulerInit(schedInfo)
+
+		sched := cff.NewScheduler(
+			cff.SchedulerParams{
+				Emitter: schedEmitter,
+			},
+		)
+
+		var tasks []*struct {
+			emitter cff.TaskEmitter
+			ran     cff.AtomicBool
+			run     func(context.Context) error
+			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		}
+		defer func() {
+			for _, t := range tasks {
+				if !t.ran.Load() {
+					t.emitter.TaskSkipped(ctx, t.outcome.SkipReason(ctx, err))
+				}
+			}
+		}()
+
+		// go.uber.org/cff/internal/tests/lazy/lazy.go:105:12
+		var (
+			v3 Name
+		)
+		// Outputs of tasks added with cff.Use or of lazy flows
+		// may be unused.
+		_ = v3
+
+		task6 := new(struct {
+			emitter cff.TaskEmitter
+			ran     cff.AtomicBool
+			run     func(context.Context) error
+			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		})
+		task6.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/lazy/lazy.go",
+			Line:   105,
+			Column: 12,
+		}
+		task6.emitter = cff.NopTaskEmitter()
+		task6.run = func(ctx context.Context) (err error) {
+			taskEmitter := task6.emitter
+			startTime := time.Now()
+			defer func() {
+				task6.outcome.Finish(err)
+				if task6.ran.Load() {
+					taskEmitter.TaskDone(ctx, time.Since(startTime))
+				}
+			}()
+
+			defer func() {
+				recovered := recover()
+				if recovered != nil {
+					taskEmitter.TaskPanic(ctx, recovered)
+					err = &cff.PanicError{
+						Value:      recovered,
+						Stacktrace: debug.Stack(),
+					}
+				}
+			}()
+
+			defer task6.ran.Store(true)
+
+			err = cff.Intercept(ctx, interceptor, task6.outcome.Info, func(ctx context.Context) (err error) {
+				v3 = _105_12(v2)
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return err
+			} else {
+				taskEmitter.TaskSuccess(ctx)
+			}
+
+			return
+		}
+
+		task6.job = sched.Enqueue(ctx, cff.Job{
+			Run: task6.run,
+		})
+		tasks = append(tasks, task6)
+
+		// go.uber.org/cff/internal/tests/lazy/lazy.go:113:12
+		var (
+			v7 string
+		)
+		// Outputs of tasks added with cff.Use or of lazy flows
+		// may be unused.
+		_ = v7
+
+		task8 := new(struct {
+			emitter cff.TaskEmitter
+			ran     cff.AtomicBool
+			run     func(context.Context) error
+			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		})
+		task8.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/lazy/lazy.go",
+			Line:   113,
+			Column: 12,
+		}
+		task8.outcome.Dependencies = []*cff.TaskOutcome{
+			&task6.outcome,
+		}
+		task8.emitter = cff.NopTaskEmitter()
+		task8.run = func(ctx context.Context) (err error) {
+			taskEmitter := task8.emitter
+			startTime := time.Now()
+			defer func() {
+				task8.outcome.Finish(err)
+				if task8.ran.Load() {
+					taskEmitter.TaskDone(ctx, time.Since(startTime))
+				}
+			}()
+
+			defer func() {
+				recovered := recover()
+				if recovered != nil {
+					taskEmitter.TaskPanic(ctx, recovered)
+					err = &cff.PanicError{
+						Value:      recovered,
+						Stacktrace: debug.Stack(),
+					}
+				}
+			}()
+
+			defer task8.ran.Store(true)
+
+			err = cff.Intercept(ctx, interceptor, task8.outcome.Info, func(ctx context.Context) (err error) {
+				v7 = _113_12(v6, v3)
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return err
+			} else {
+				taskEmitter.TaskSuccess(ctx)
+			}
+
+			return
+		}
+
+		task8.job = sched.Enqueue(ctx, cff.Job{
+			Run: task8.run,
+			Dependencies: []*cff.ScheduledJob{
+				task6.job,
+			},
+		})
+		tasks = append(tasks, task8)
+
+		if err := sched.Wait(ctx); err != nil {
+			flowEmitter.FlowError(ctx, err)
+			cff.RethrowPanic(err, false)
+			return err
+		}
+
+		*(_104_15) = v7 // string
+
+		flowEmitter.FlowSuccess(ctx)
+		return nil
+	}()
+	return desc, err
+}
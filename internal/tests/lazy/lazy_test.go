@@ -0,0 +1,33 @@
+package lazy
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetName(t *testing.T) {
+	var r Recorder
+	name, err := GetName(context.Background(), &r, 1)
+	require.NoError(t, err)
+	assert.Equal(t, Name("record1"), name)
+	assert.ElementsMatch(t, []string{"load", "log"}, r.Names())
+}
+
+func TestGetOwner(t *testing.T) {
+	var r Recorder
+	owner, err := GetOwner(context.Background(), &r, 2)
+	require.NoError(t, err)
+	assert.Equal(t, Owner("user2"), owner)
+	assert.ElementsMatch(t, []string{"owner", "log"}, r.Names())
+}
+
+func TestDescribe(t *testing.T) {
+	var r Recorder
+	desc, err := Describe(context.Background(), &r, 3, "#")
+	require.NoError(t, err)
+	assert.Equal(t, "#3", desc)
+	assert.Equal(t, []string{"name", "describe"}, r.Names())
+}
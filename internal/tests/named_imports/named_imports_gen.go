@@ -27,6 +27,7 @@ func run(ctx newctx.Context) error {
 		ctx := _14_20
 		var v1 string = _15_16
 		emitter := cffv2.NopEmitter()
+		interceptor := cffv2.InterceptorStack()
 
 		var (
 			flowInfo = &cffv2.FlowInfo{
@@ -64,11 +65,13 @@ func run(ctx newctx.Context) error {
 			ran     cffv2.AtomicBool
 			run     func(newctx.Context) error
 			job     *cffv2.ScheduledJob
+
+			outcome cffv2.TaskOutcome // reports why the task was skipped
 		}
 		defer func() {
 			for _, t := range tasks {
 				if !t.ran.Load() {
-					t.emitter.TaskSkipped(ctx, err)
+					t.emitter.TaskSkipped(ctx, t.outcome.SkipReason(ctx, err))
 				}
 			}
 		}()
@@ -77,17 +80,26 @@ func run(ctx newctx.Context) error {
 		var (
 			v2 struct{}
 		)
+
 		task0 := new(struct {
 			emitter cffv2.TaskEmitter
 			ran     cffv2.AtomicBool
 			run     func(newctx.Context) error
 			job     *cffv2.ScheduledJob
+
+			outcome cffv2.TaskOutcome // reports why the task was skipped
 		})
+		task0.outcome.Info = &cffv2.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/named_imports/named_imports.go",
+			Line:   18,
+			Column: 4,
+		}
 		task0.emitter = cffv2.NopTaskEmitter()
 		task0.run = func(ctx newctx.Context) (err error) {
 			taskEmitter := task0.emitter
 			startTime := time.Now()
 			defer func() {
+				task0.outcome.Finish(err)
 				if task0.ran.Load() {
 					taskEmitter.TaskDone(ctx, time.Since(startTime))
 				}
@@ -106,9 +118,16 @@ func run(ctx newctx.Context) error {
 
 			defer task0.ran.Store(true)
 
-			v2 = _18_4(v1)
-
-			taskEmitter.TaskSuccess(ctx)
+			err = cffv2.Intercept(ctx, interceptor, task0.outcome.Info, func(ctx newctx.Context) (err error) {
+				v2 = _18_4(v1)
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return err
+			} else {
+				taskEmitter.TaskSuccess(ctx)
+			}
 
 			return
 		}
@@ -120,6 +139,7 @@ func run(ctx newctx.Context) error {
 
 		if err := sched.Wait(ctx); err != nil {
 			flowEmitter.FlowError(ctx, err)
+			cffv2.RethrowPanic(err, false)
 			return err
 		}
 
@@ -0,0 +1,108 @@
+//go:build cff
+// +build cff
+
+// Package optional tests tasks marked with cff.Optional.
+package optional
+
+import (
+	"context"
+	"errors"
+
+	"go.uber.org/cff"
+)
+
+// Profile is the result of the flows in this package.
+type Profile struct {
+	Name   string
+	Avatar string
+
+	// Whether the avatar was produced.
+	HasAvatar bool
+}
+
+// Mode specifies how the avatar task behaves.
+type Mode int
+
+// Modes for the avatar task.
+const (
+	Succeed Mode = iota
+	Fail
+	Panic
+)
+
+func avatar(mode Mode) (string, error) {
+	switch mode {
+	case Fail:
+		return "partial", errors.New("great sadness")
+	case Panic:
+		panic("great sadness")
+	default:
+		return "avatar.png", nil
+	}
+}
+
+// Zero builds a Profile from an optional task
+// whose consumer receives the output as-is.
+func Zero(e cff.Emitter, mode Mode) (*Profile, error) {
+	var p *Profile
+	err := cff.Flow(context.Background(),
+		cff.Params(mode),
+		cff.Results(&p),
+		cff.WithEmitter(e),
+		cff.Task(avatar, cff.Optional(), cff.Instrument("avatar")),
+		cff.Task(func(avatar string) *Profile {
+			return &Profile{Name: "foo", Avatar: avatar}
+		}),
+	)
+	return p, err
+}
+
+// Maybe builds a Profile from an optional task
+// whose consumer receives the output as a cff.Maybe.
+func Maybe(e cff.Emitter, mode Mode) (*Profile, error) {
+	var p *Profile
+	err := cff.Flow(context.Background(),
+		cff.Params(mode),
+		cff.Results(&p),
+		cff.WithEmitter(e),
+		cff.Task(avatar, cff.Optional(), cff.Instrument("avatar")),
+		cff.Task(func(avatar cff.Maybe[string]) *Profile {
+			a, ok := avatar.Get()
+			return &Profile{Name: "foo", Avatar: a, HasAvatar: ok}
+		}),
+	)
+	return p, err
+}
+
+// NoError runs an optional task that has no error result.
+func NoError(mode Mode) (*Profile, error) {
+	var p *Profile
+	err := cff.Flow(context.Background(),
+		cff.Params(mode),
+		cff.Results(&p),
+		cff.Task(func(mode Mode) string {
+			s, err := avatar(mode)
+			if err != nil {
+				panic(err)
+			}
+			return s
+		}, cff.Optional()),
+		cff.Task(func(avatar cff.Maybe[string]) *Profile {
+			return &Profile{Name: "foo", Avatar: avatar.Value, HasAvatar: avatar.Valid}
+		}),
+	)
+	return p, err
+}
+
+// MaybeParam consumes a value from cff.Params as a cff.Maybe.
+func MaybeParam(name string) (*Profile, error) {
+	var p *Profile
+	err := cff.Flow(context.Background(),
+		cff.Params(name),
+		cff.Results(&p),
+		cff.Task(func(name cff.Maybe[string]) *Profile {
+			return &Profile{Name: name.Value, HasAvatar: name.Valid}
+		}),
+	)
+	return p, err
+}
@@ -0,0 +1,75 @@
+package optional
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/cff/internal/emittertest"
+)
+
+func TestZero(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		e := emittertest.NewRecorder()
+		p, err := Zero(e, Succeed)
+		require.NoError(t, err)
+		assert.Equal(t, &Profile{Name: "foo", Avatar: "avatar.png"}, p)
+		assert.Empty(t, e.EventsOf(emittertest.TaskErrorRecovered))
+		assert.Empty(t, e.EventsOf(emittertest.TaskPanicRecovered))
+	})
+
+	t.Run("error", func(t *testing.T) {
+		e := emittertest.NewRecorder()
+		p, err := Zero(e, Fail)
+		require.NoError(t, err)
+		assert.Equal(t, &Profile{Name: "foo"}, p,
+			"partial results of a failed task must not be used")
+		errs := e.Errors(emittertest.TaskErrorRecovered)
+		require.Len(t, errs, 1)
+		assert.EqualError(t, errs[0], "great sadness")
+	})
+
+	t.Run("panic", func(t *testing.T) {
+		e := emittertest.NewRecorder()
+		p, err := Zero(e, Panic)
+		require.NoError(t, err)
+		assert.Equal(t, &Profile{Name: "foo"}, p)
+		assert.Equal(t, []interface{}{"great sadness"}, e.Values(emittertest.TaskPanicRecovered))
+	})
+}
+
+func TestMaybe(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		p, err := Maybe(emittertest.NewRecorder(), Succeed)
+		require.NoError(t, err)
+		assert.Equal(t, &Profile{Name: "foo", Avatar: "avatar.png", HasAvatar: true}, p)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		p, err := Maybe(emittertest.NewRecorder(), Fail)
+		require.NoError(t, err)
+		assert.Equal(t, &Profile{Name: "foo"}, p)
+	})
+
+	t.Run("panic", func(t *testing.T) {
+		p, err := Maybe(emittertest.NewRecorder(), Panic)
+		require.NoError(t, err)
+		assert.Equal(t, &Profile{Name: "foo"}, p)
+	})
+}
+
+func TestNoError(t *testing.T) {
+	p, err := NoError(Succeed)
+	require.NoError(t, err)
+	assert.Equal(t, &Profile{Name: "foo", Avatar: "avatar.png", HasAvatar: true}, p)
+
+	p, err = NoError(Panic)
+	require.NoError(t, err)
+	assert.Equal(t, &Profile{Name: "foo"}, p)
+}
+
+func TestMaybeParam(t *testing.T) {
+	p, err := MaybeParam("bar")
+	require.NoError(t, err)
+	assert.Equal(t, &Profile{Name: "bar", HasAvatar: true}, p)
+}
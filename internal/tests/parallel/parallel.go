@@ -6,8 +6,10 @@ package parallel
 import (
 	"context"
 	"errors"
+	"fmt"
 	"os"
 	"sync"
+	"time"
 
 	"go.uber.org/cff"
 )
@@ -433,6 +435,106 @@ func SliceEndWithCtxAndErr(src []int, sliceFn func(idx, val int) error, sliceEnd
 	return err
 }
 
+// SliceEndContinueOnError runs cff.Slice in parallel with ContinueOnError
+// and calls sliceEndFn after all items in the slice were attempted.
+func SliceEndContinueOnError(src []int, sliceFn func(idx, val int) error, sliceEndFn func()) (err error) {
+	err = cff.Parallel(
+		context.Background(),
+		cff.Concurrency(2),
+		cff.ContinueOnError(true),
+		cff.Slice(
+			sliceFn,
+			src,
+			cff.SliceEnd(sliceEndFn),
+		),
+	)
+	return err
+}
+
+// SliceEndElementErrors runs cff.Slice in parallel and passes the errors of
+// the items that failed to sliceEndFn.
+func SliceEndElementErrors(
+	src []int,
+	sliceFn func(idx, val int) error,
+	sliceEndFn func(context.Context, cff.ElementErrors[int]) error,
+) (err error) {
+	err = cff.Parallel(
+		context.Background(),
+		cff.Concurrency(2),
+		cff.Slice(
+			sliceFn,
+			src,
+			cff.SliceEnd(sliceEndFn),
+		),
+	)
+	return err
+}
+
+// SliceBatch runs cff.Slice in parallel on batches of the given size,
+// doubling the values of src into target.
+func SliceBatch(src, target []int, size int) error {
+	return cff.Parallel(
+		context.Background(),
+		cff.Concurrency(2),
+		cff.Slice(
+			func(idx, val int) {
+				target[idx] = val * 2
+			},
+			src,
+			cff.Batch(size),
+		),
+	)
+}
+
+// SliceBatchContinueOnError runs cff.Slice in parallel on batches of the
+// given size, failing for negative values.
+// It records the indexes of the elements that were attempted.
+func SliceBatchContinueOnError(src []int, size int, keepgoing bool, attempted *sync.Map) error {
+	return cff.Parallel(
+		context.Background(),
+		cff.Concurrency(2),
+		cff.ContinueOnError(keepgoing),
+		cff.Slice(
+			func(idx, val int) error {
+				attempted.Store(idx, struct{}{})
+				if val < 0 {
+					return fmt.Errorf("negative value at %v", idx)
+				}
+				return nil
+			},
+			src,
+			cff.Batch(size),
+		),
+	)
+}
+
+// SliceBatchElementErrors runs cff.Slice in parallel on batches of the
+// given size, and returns the errors of the elements that failed:
+// negative values fail, and zero values panic.
+func SliceBatchElementErrors(src []int, size int) (errs cff.ElementErrors[int], err error) {
+	err = cff.Parallel(
+		context.Background(),
+		cff.Concurrency(2),
+		cff.Slice(
+			func(val int) error {
+				switch {
+				case val < 0:
+					return errors.New("negative value")
+				case val == 0:
+					panic("zero value")
+				}
+				return nil
+			},
+			src,
+			cff.Batch(size),
+			cff.SliceEnd(func(e cff.ElementErrors[int]) {
+				errs = e
+			}),
+		),
+	)
+	return errs, err
+}
+
 // AssignMapItems runs cff.Map in parallel to populate the provided slices.
 func AssignMapItems(src map[string]int, keys []string, values []int, keepgoing bool) error {
 	return cff.Parallel(
@@ -500,3 +602,36 @@ func ForEachMapItemContext[K comparable, V any](
 		cff.Map(fn, src, cff.MapEnd(after)),
 	)
 }
+
+// ForEachMapItemElementErrors is a variant of ForEachMapItem that passes the
+// errors of the items that failed to the provided function.
+func ForEachMapItemElementErrors[K comparable, V any](
+	src map[K]V,
+	fn func(K, V) error,
+	after func(cff.ElementErrors[K]) error,
+) error {
+	return cff.Parallel(
+		context.Background(),
+		cff.Concurrency(2),
+		cff.ContinueOnError(true),
+		cff.Map(fn, src, cff.MapEnd(after)),
+	)
+}
+
+// FirstSuccessPredicate races a task whose predicate is false against one
+// that fails after the given delay. Neither task succeeds.
+func FirstSuccessPredicate(delay time.Duration) error {
+	return cff.Parallel(
+		context.Background(),
+		cff.Concurrency(2),
+		cff.Task(
+			func() {},
+			cff.Predicate(func() bool { return false }),
+		),
+		cff.Task(func() error {
+			time.Sleep(delay)
+			return errors.New("great sadness")
+		}),
+		cff.FirstSuccess(),
+	)
+}
@@ -6,6 +6,7 @@ package parallel
 import (
 	"context"
 	"errors"
+	"fmt"
 	"os"
 	"runtime/debug"
 	"sync"
@@ -19,28 +20,29 @@ import (
 func TasksAndTask(m *sync.Map) error {
 	return func() (err error) {
 
-		_19_3 := context.Background()
+		_21_3 := context.Background()
 
-		_20_19 := 2
+		_22_19 := 2
 
-		_22_4 := func() {
+		_24_4 := func() {
 			m.Store("foo", "bar")
 		}
 
-		_25_4 := func(_ context.Context) {
+		_27_4 := func(_ context.Context) {
 			m.Store("fiz", "buzz")
 		}
 
-		_30_4 := func(_ context.Context) {
+		_32_4 := func(_ context.Context) {
 			m.Store("go", "lang")
 		}
-		ctx := _19_3
+		ctx := _21_3
 		emitter := cff.NopEmitter()
+		interceptor := cff.InterceptorStack()
 
 		var (
 			parallelInfo = &cff.ParallelInfo{
 				File:   "go.uber.org/cff/internal/tests/parallel/parallel.go",
-				Line:   18,
+				Line:   20,
 				Column: 9,
 			}
 			directiveInfo = &cff.DirectiveInfo{
@@ -72,7 +74,7 @@ func TasksAndTask(m *sync.Map) error {
 
 		sched := cff.NewScheduler(
 			cff.SchedulerParams{
-				Concurrency: _20_19, Emitter: schedEmitter,
+				Concurrency: _22_19, Emitter: schedEmitter,
 			},
 		)
 
@@ -80,26 +82,39 @@ func TasksAndTask(m *sync.Map) error {
 			emitter cff.TaskEmitter
 			fn      func(context.Context) error
 			ran     cff.AtomicBool
+
+			outcome cff.TaskOutcome // reports why the task was skipped
 		}
 		defer func() {
 			for _, t := range tasks {
-				if !t.ran.Load() {
-					t.emitter.TaskSkipped(ctx, err)
+				// Tasks that are still running report their own outcome
+				// once they finish.
+				if !t.ran.Load() && !t.outcome.Running() {
+					t.emitter.TaskSkipped(ctx, t.outcome.SkipReason(ctx, err))
 				}
 			}
 		}()
 
-		// go.uber.org/cff/internal/tests/parallel/parallel.go:22:4
+		// go.uber.org/cff/internal/tests/parallel/parallel.go:24:4
 		task0 := new(struct {
 			emitter cff.TaskEmitter
 			fn      func(context.Context) error
 			ran     cff.AtomicBool
+
+			outcome cff.TaskOutcome // reports why the task was skipped
 		})
+		task0.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/parallel/parallel.go",
+			Line:   24,
+			Column: 4,
+		}
 		task0.emitter = cff.NopTaskEmitter()
 		task0.fn = func(ctx context.Context) (err error) {
+			task0.outcome.Start()
 			taskEmitter := task0.emitter
 			startTime := time.Now()
 			defer func() {
+				task0.outcome.Finish(err)
 				if task0.ran.Load() {
 					taskEmitter.TaskDone(ctx, time.Since(startTime))
 				}
@@ -118,8 +133,14 @@ func TasksAndTask(m *sync.Map) error {
 
 			defer task0.ran.Store(true)
 
-			_22_4()
-
+			err = cff.Intercept(ctx, interceptor, task0.outcome.Info, func(ctx context.Context) (err error) {
+				_24_4()
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return
+			}
 			taskEmitter.TaskSuccess(ctx)
 			return
 		}
@@ -129,17 +150,26 @@ func TasksAndTask(m *sync.Map) error {
 		})
 		tasks = append(tasks, task0)
 
-		// go.uber.org/cff/internal/tests/parallel/parallel.go:25:4
+		// go.uber.org/cff/internal/tests/parallel/parallel.go:27:4
 		task1 := new(struct {
 			emitter cff.TaskEmitter
 			fn      func(context.Context) error
 			ran     cff.AtomicBool
+
+			outcome cff.TaskOutcome // reports why the task was skipped
 		})
+		task1.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/parallel/parallel.go",
+			Line:   27,
+			Column: 4,
+		}
 		task1.emitter = cff.NopTaskEmitter()
 		task1.fn = func(ctx context.Context) (err error) {
+			task1.outcome.Start()
 			taskEmitter := task1.emitter
 			startTime := time.Now()
 			defer func() {
+				task1.outcome.Finish(err)
 				if task1.ran.Load() {
 					taskEmitter.TaskDone(ctx, time.Since(startTime))
 				}
@@ -158,8 +188,14 @@ func TasksAndTask(m *sync.Map) error {
 
 			defer task1.ran.Store(true)
 
-			_25_4(ctx)
-
+			err = cff.Intercept(ctx, interceptor, task1.outcome.Info, func(ctx context.Context) (err error) {
+				_27_4(ctx)
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return
+			}
 			taskEmitter.TaskSuccess(ctx)
 			return
 		}
@@ -169,17 +205,26 @@ func TasksAndTask(m *sync.Map) error {
 		})
 		tasks = append(tasks, task1)
 
-		// go.uber.org/cff/internal/tests/parallel/parallel.go:30:4
+		// go.uber.org/cff/internal/tests/parallel/parallel.go:32:4
 		task2 := new(struct {
 			emitter cff.TaskEmitter
 			fn      func(context.Context) error
 			ran     cff.AtomicBool
+
+			outcome cff.TaskOutcome // reports why the task was skipped
 		})
+		task2.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/parallel/parallel.go",
+			Line:   32,
+			Column: 4,
+		}
 		task2.emitter = cff.NopTaskEmitter()
 		task2.fn = func(ctx context.Context) (err error) {
+			task2.outcome.Start()
 			taskEmitter := task2.emitter
 			startTime := time.Now()
 			defer func() {
+				task2.outcome.Finish(err)
 				if task2.ran.Load() {
 					taskEmitter.TaskDone(ctx, time.Since(startTime))
 				}
@@ -198,8 +243,14 @@ func TasksAndTask(m *sync.Map) error {
 
 			defer task2.ran.Store(true)
 
-			_30_4(ctx)
-
+			err = cff.Intercept(ctx, interceptor, task2.outcome.Info, func(ctx context.Context) (err error) {
+				_32_4(ctx)
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return
+			}
 			taskEmitter.TaskSuccess(ctx)
 			return
 		}
@@ -211,10 +262,11 @@ func TasksAndTask(m *sync.Map) error {
 
 		if err := sched.Wait(ctx); err != nil {
 			parallelEmitter.ParallelError(ctx, err)
+			cff.RethrowPanic(err, false)
 			return err
 		}
 		parallelEmitter.ParallelSuccess(ctx)
-		return nil /*line parallel.go:33*/
+		return nil /*line parallel.go:35*/
 	}()
 }
 
@@ -222,20 +274,21 @@ func TasksAndTask(m *sync.Map) error {
 func TasksWithError() error {
 	return func() (err error) {
 
-		_40_3 := context.Background()
+		_42_3 := context.Background()
 
-		_41_19 := 2
+		_43_19 := 2
 
-		_43_4 := func() error {
+		_45_4 := func() error {
 			return errors.New("sad times")
 		}
-		ctx := _40_3
+		ctx := _42_3
 		emitter := cff.NopEmitter()
+		interceptor := cff.InterceptorStack()
 
 		var (
 			parallelInfo = &cff.ParallelInfo{
 				File:   "go.uber.org/cff/internal/tests/parallel/parallel.go",
-				Line:   39,
+				Line:   41,
 				Column: 9,
 			}
 			directiveInfo = &cff.DirectiveInfo{
@@ -267,7 +320,7 @@ func TasksWithError() error {
 
 		sched := cff.NewScheduler(
 			cff.SchedulerParams{
-				Concurrency: _41_19, Emitter: schedEmitter,
+				Concurrency: _43_19, Emitter: schedEmitter,
 			},
 		)
 
@@ -275,26 +328,39 @@ func TasksWithError() error {
 			emitter cff.TaskEmitter
 			fn      func(context.Context) error
 			ran     cff.AtomicBool
+
+			outcome cff.TaskOutcome // reports why the task was skipped
 		}
 		defer func() {
 			for _, t := range tasks {
-				if !t.ran.Load() {
-					t.emitter.TaskSkipped(ctx, err)
+				// Tasks that are still running report their own outcome
+				// once they finish.
+				if !t.ran.Load() && !t.outcome.Running() {
+					t.emitter.TaskSkipped(ctx, t.outcome.SkipReason(ctx, err))
 				}
 			}
 		}()
 
-		// go.uber.org/cff/internal/tests/parallel/parallel.go:43:4
+		// go.uber.org/cff/internal/tests/parallel/parallel.go:45:4
 		task3 := new(struct {
 			emitter cff.TaskEmitter
 			fn      func(context.Context) error
 			ran     cff.AtomicBool
+
+			outcome cff.TaskOutcome // reports why the task was skipped
 		})
+		task3.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/parallel/parallel.go",
+			Line:   45,
+			Column: 4,
+		}
 		task3.emitter = cff.NopTaskEmitter()
 		task3.fn = func(ctx context.Context) (err error) {
+			task3.outcome.Start()
 			taskEmitter := task3.emitter
 			startTime := time.Now()
 			defer func() {
+				task3.outcome.Finish(err)
 				if task3.ran.Load() {
 					taskEmitter.TaskDone(ctx, time.Since(startTime))
 				}
@@ -313,8 +379,10 @@ func TasksWithError() error {
 
 			defer task3.ran.Store(true)
 
-			err = _43_4()
-
+			err = cff.Intercept(ctx, interceptor, task3.outcome.Info, func(ctx context.Context) (err error) {
+				err = _45_4()
+				return
+			})
 			if err != nil {
 				taskEmitter.TaskError(ctx, err)
 				return
@@ -330,10 +398,11 @@ func TasksWithError() error {
 
 		if err := sched.Wait(ctx); err != nil {
 			parallelEmitter.ParallelError(ctx, err)
+			cff.RethrowPanic(err, false)
 			return err
 		}
 		parallelEmitter.ParallelSuccess(ctx)
-		return nil /*line parallel.go:46*/
+		return nil /*line parallel.go:48*/
 	}()
 }
 
@@ -341,20 +410,21 @@ func TasksWithError() error {
 func TasksWithPanic() error {
 	return func() (err error) {
 
-		_53_3 := context.Background()
+		_55_3 := context.Background()
 
-		_54_19 := 2
+		_56_19 := 2
 
-		_56_4 := func() {
+		_58_4 := func() {
 			panic("sad times")
 		}
-		ctx := _53_3
+		ctx := _55_3
 		emitter := cff.NopEmitter()
+		interceptor := cff.InterceptorStack()
 
 		var (
 			parallelInfo = &cff.ParallelInfo{
 				File:   "go.uber.org/cff/internal/tests/parallel/parallel.go",
-				Line:   52,
+				Line:   54,
 				Column: 9,
 			}
 			directiveInfo = &cff.DirectiveInfo{
@@ -386,7 +456,7 @@ func TasksWithPanic() error {
 
 		sched := cff.NewScheduler(
 			cff.SchedulerParams{
-				Concurrency: _54_19, Emitter: schedEmitter,
+				Concurrency: _56_19, Emitter: schedEmitter,
 			},
 		)
 
@@ -394,26 +464,39 @@ func TasksWithPanic() error {
 			emitter cff.TaskEmitter
 			fn      func(context.Context) error
 			ran     cff.AtomicBool
+
+			outcome cff.TaskOutcome // reports why the task was skipped
 		}
 		defer func() {
 			for _, t := range tasks {
-				if !t.ran.Load() {
-					t.emitter.TaskSkipped(ctx, err)
+				// Tasks that are still running report their own outcome
+				// once they finish.
+				if !t.ran.Load() && !t.outcome.Running() {
+					t.emitter.TaskSkipped(ctx, t.outcome.SkipReason(ctx, err))
 				}
 			}
 		}()
 
-		// go.uber.org/cff/internal/tests/parallel/parallel.go:56:4
+		// go.uber.org/cff/internal/tests/parallel/parallel.go:58:4
 		task4 := new(struct {
 			emitter cff.TaskEmitter
 			fn      func(context.Context) error
 			ran     cff.AtomicBool
+
+			outcome cff.TaskOutcome // reports why the task was skipped
 		})
+		task4.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/parallel/parallel.go",
+			Line:   58,
+			Column: 4,
+		}
 		task4.emitter = cff.NopTaskEmitter()
 		task4.fn = func(ctx context.Context) (err error) {
+			task4.outcome.Start()
 			taskEmitter := task4.emitter
 			startTime := time.Now()
 			defer func() {
+				task4.outcome.Finish(err)
 				if task4.ran.Load() {
 					taskEmitter.TaskDone(ctx, time.Since(startTime))
 				}
@@ -432,8 +515,14 @@ func TasksWithPanic() error {
 
 			defer task4.ran.Store(true)
 
-			_56_4()
-
+			err = cff.Intercept(ctx, interceptor, task4.outcome.Info, func(ctx context.Context) (err error) {
+				_58_4()
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return
+			}
 			taskEmitter.TaskSuccess(ctx)
 			return
 		}
@@ -445,10 +534,11 @@ func TasksWithPanic() error {
 
 		if err := sched.Wait(ctx); err != nil {
 			parallelEmitter.ParallelError(ctx, err)
+			cff.RethrowPanic(err, false)
 			return err
 		}
 		parallelEmitter.ParallelSuccess(ctx)
-		return nil /*line parallel.go:59*/
+		return nil /*line parallel.go:61*/
 	}()
 }
 
@@ -461,22 +551,23 @@ func MultipleTasks(c chan<- string) error {
 	}
 	return func() (err error) {
 
-		_71_3 := context.Background()
+		_73_3 := context.Background()
 
-		_72_19 := 2
+		_74_19 := 2
 
-		_74_4 := func() {
+		_76_4 := func() {
 			c <- "multiple"
 		}
 
-		_79_4 := send
-		ctx := _71_3
+		_81_4 := send
+		ctx := _73_3
 		emitter := cff.NopEmitter()
+		interceptor := cff.InterceptorStack()
 
 		var (
 			parallelInfo = &cff.ParallelInfo{
 				File:   "go.uber.org/cff/internal/tests/parallel/parallel.go",
-				Line:   70,
+				Line:   72,
 				Column: 9,
 			}
 			directiveInfo = &cff.DirectiveInfo{
@@ -508,7 +599,7 @@ func MultipleTasks(c chan<- string) error {
 
 		sched := cff.NewScheduler(
 			cff.SchedulerParams{
-				Concurrency: _72_19, Emitter: schedEmitter,
+				Concurrency: _74_19, Emitter: schedEmitter,
 			},
 		)
 
@@ -516,26 +607,39 @@ func MultipleTasks(c chan<- string) error {
 			emitter cff.TaskEmitter
 			fn      func(context.Context) error
 			ran     cff.AtomicBool
+
+			outcome cff.TaskOutcome // reports why the task was skipped
 		}
 		defer func() {
 			for _, t := range tasks {
-				if !t.ran.Load() {
-					t.emitter.TaskSkipped(ctx, err)
+				// Tasks that are still running report their own outcome
+				// once they finish.
+				if !t.ran.Load() && !t.outcome.Running() {
+					t.emitter.TaskSkipped(ctx, t.outcome.SkipReason(ctx, err))
 				}
 			}
 		}()
 
-		// go.uber.org/cff/internal/tests/parallel/parallel.go:74:4
+		// go.uber.org/cff/internal/tests/parallel/parallel.go:76:4
 		task5 := new(struct {
 			emitter cff.TaskEmitter
 			fn      func(context.Context) error
 			ran     cff.AtomicBool
+
+			outcome cff.TaskOutcome // reports why the task was skipped
 		})
+		task5.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/parallel/parallel.go",
+			Line:   76,
+			Column: 4,
+		}
 		task5.emitter = cff.NopTaskEmitter()
 		task5.fn = func(ctx context.Context) (err error) {
+			task5.outcome.Start()
 			taskEmitter := task5.emitter
 			startTime := time.Now()
 			defer func() {
+				task5.outcome.Finish(err)
 				if task5.ran.Load() {
 					taskEmitter.TaskDone(ctx, time.Since(startTime))
 				}
@@ -554,8 +658,14 @@ func MultipleTasks(c chan<- string) error {
 
 			defer task5.ran.Store(true)
 
-			_74_4()
-
+			err = cff.Intercept(ctx, interceptor, task5.outcome.Info, func(ctx context.Context) (err error) {
+				_76_4()
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return
+			}
 			taskEmitter.TaskSuccess(ctx)
 			return
 		}
@@ -565,17 +675,26 @@ func MultipleTasks(c chan<- string) error {
 		})
 		tasks = append(tasks, task5)
 
-		// go.uber.org/cff/internal/tests/parallel/parallel.go:79:4
+		// go.uber.org/cff/internal/tests/parallel/parallel.go:81:4
 		task6 := new(struct {
 			emitter cff.TaskEmitter
 			fn      func(context.Context) error
 			ran     cff.AtomicBool
+
+			outcome cff.TaskOutcome // reports why the task was skipped
 		})
+		task6.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/parallel/parallel.go",
+			Line:   81,
+			Column: 4,
+		}
 		task6.emitter = cff.NopTaskEmitter()
 		task6.fn = func(ctx context.Context) (err error) {
+			task6.outcome.Start()
 			taskEmitter := task6.emitter
 			startTime := time.Now()
 			defer func() {
+				task6.outcome.Finish(err)
 				if task6.ran.Load() {
 					taskEmitter.TaskDone(ctx, time.Since(startTime))
 				}
@@ -594,8 +713,10 @@ func MultipleTasks(c chan<- string) error {
 
 			defer task6.ran.Store(true)
 
-			err = _79_4(ctx)
-
+			err = cff.Intercept(ctx, interceptor, task6.outcome.Info, func(ctx context.Context) (err error) {
+				err = _81_4(ctx)
+				return
+			})
 			if err != nil {
 				taskEmitter.TaskError(ctx, err)
 				return
@@ -611,10 +732,11 @@ func MultipleTasks(c chan<- string) error {
 
 		if err := sched.Wait(ctx); err != nil {
 			parallelEmitter.ParallelError(ctx, err)
+			cff.RethrowPanic(err, false)
 			return err
 		}
 		parallelEmitter.ParallelSuccess(ctx)
-		return nil /*line parallel.go:80*/
+		return nil /*line parallel.go:82*/
 	}()
 }
 
@@ -623,20 +745,21 @@ func MultipleTasks(c chan<- string) error {
 func ContextErrorBefore(ctx context.Context, src, target []int) error {
 	return func() (err error) {
 
-		_88_3 := ctx
+		_90_3 := ctx
 
-		_89_19 := 2
+		_91_19 := 2
 
-		_91_4 := func() {
+		_93_4 := func() {
 			target[0] = src[0]
 		}
-		ctx := _88_3
+		ctx := _90_3
 		emitter := cff.NopEmitter()
+		interceptor := cff.InterceptorStack()
 
 		var (
 			parallelInfo = &cff.ParallelInfo{
 				File:   "go.uber.org/cff/internal/tests/parallel/parallel.go",
-				Line:   87,
+				Line:   89,
 				Column: 9,
 			}
 			directiveInfo = &cff.DirectiveInfo{
@@ -668,7 +791,7 @@ func ContextErrorBefore(ctx context.Context, src, target []int) error {
 
 		sched := cff.NewScheduler(
 			cff.SchedulerParams{
-				Concurrency: _89_19, Emitter: schedEmitter,
+				Concurrency: _91_19, Emitter: schedEmitter,
 			},
 		)
 
@@ -676,26 +799,39 @@ func ContextErrorBefore(ctx context.Context, src, target []int) error {
 			emitter cff.TaskEmitter
 			fn      func(context.Context) error
 			ran     cff.AtomicBool
+
+			outcome cff.TaskOutcome // reports why the task was skipped
 		}
 		defer func() {
 			for _, t := range tasks {
-				if !t.ran.Load() {
-					t.emitter.TaskSkipped(ctx, err)
+				// Tasks that are still running report their own outcome
+				// once they finish.
+				if !t.ran.Load() && !t.outcome.Running() {
+					t.emitter.TaskSkipped(ctx, t.outcome.SkipReason(ctx, err))
 				}
 			}
 		}()
 
-		// go.uber.org/cff/internal/tests/parallel/parallel.go:91:4
+		// go.uber.org/cff/internal/tests/parallel/parallel.go:93:4
 		task7 := new(struct {
 			emitter cff.TaskEmitter
 			fn      func(context.Context) error
 			ran     cff.AtomicBool
+
+			outcome cff.TaskOutcome // reports why the task was skipped
 		})
+		task7.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/parallel/parallel.go",
+			Line:   93,
+			Column: 4,
+		}
 		task7.emitter = cff.NopTaskEmitter()
 		task7.fn = func(ctx context.Context) (err error) {
+			task7.outcome.Start()
 			taskEmitter := task7.emitter
 			startTime := time.Now()
 			defer func() {
+				task7.outcome.Finish(err)
 				if task7.ran.Load() {
 					taskEmitter.TaskDone(ctx, time.Since(startTime))
 				}
@@ -714,8 +850,14 @@ func ContextErrorBefore(ctx context.Context, src, target []int) error {
 
 			defer task7.ran.Store(true)
 
-			_91_4()
-
+			err = cff.Intercept(ctx, interceptor, task7.outcome.Info, func(ctx context.Context) (err error) {
+				_93_4()
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return
+			}
 			taskEmitter.TaskSuccess(ctx)
 			return
 		}
@@ -727,10 +869,11 @@ func ContextErrorBefore(ctx context.Context, src, target []int) error {
 
 		if err := sched.Wait(ctx); err != nil {
 			parallelEmitter.ParallelError(ctx, err)
+			cff.RethrowPanic(err, false)
 			return err
 		}
 		parallelEmitter.ParallelSuccess(ctx)
-		return nil /*line parallel.go:94*/
+		return nil /*line parallel.go:96*/
 	}()
 }
 
@@ -740,29 +883,30 @@ func ContextErrorInFlight(ctx context.Context, cancel func(), src, target []int)
 	blocker := make(chan struct{})
 	return func() (err error) {
 
-		_103_3 := ctx
+		_105_3 := ctx
 
-		_104_19 := 2
+		_106_19 := 2
 
-		_108_4 := func() {
+		_110_4 := func() {
 			cancel()
 			close(blocker)
 		}
 
-		_112_4 := func() {
+		_114_4 := func() {
 			<-blocker
 		}
 
-		_115_4 := func() {
+		_117_4 := func() {
 			target[0] = src[0]
 		}
-		ctx := _103_3
+		ctx := _105_3
 		emitter := cff.NopEmitter()
+		interceptor := cff.InterceptorStack()
 
 		var (
 			parallelInfo = &cff.ParallelInfo{
 				File:   "go.uber.org/cff/internal/tests/parallel/parallel.go",
-				Line:   102,
+				Line:   104,
 				Column: 9,
 			}
 			directiveInfo = &cff.DirectiveInfo{
@@ -794,7 +938,7 @@ func ContextErrorInFlight(ctx context.Context, cancel func(), src, target []int)
 
 		sched := cff.NewScheduler(
 			cff.SchedulerParams{
-				Concurrency: _104_19, Emitter: schedEmitter,
+				Concurrency: _106_19, Emitter: schedEmitter,
 			},
 		)
 
@@ -802,26 +946,39 @@ func ContextErrorInFlight(ctx context.Context, cancel func(), src, target []int)
 			emitter cff.TaskEmitter
 			fn      func(context.Context) error
 			ran     cff.AtomicBool
+
+			outcome cff.TaskOutcome // reports why the task was skipped
 		}
 		defer func() {
 			for _, t := range tasks {
-				if !t.ran.Load() {
-					t.emitter.TaskSkipped(ctx, err)
+				// Tasks that are still running report their own outcome
+				// once they finish.
+				if !t.ran.Load() && !t.outcome.Running() {
+					t.emitter.TaskSkipped(ctx, t.outcome.SkipReason(ctx, err))
 				}
 			}
 		}()
 
-		// go.uber.org/cff/internal/tests/parallel/parallel.go:108:4
+		// go.uber.org/cff/internal/tests/parallel/parallel.go:110:4
 		task8 := new(struct {
 			emitter cff.TaskEmitter
 			fn      func(context.Context) error
 			ran     cff.AtomicBool
+
+			outcome cff.TaskOutcome // reports why the task was skipped
 		})
+		task8.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/parallel/parallel.go",
+			Line:   110,
+			Column: 4,
+		}
 		task8.emitter = cff.NopTaskEmitter()
 		task8.fn = func(ctx context.Context) (err error) {
+			task8.outcome.Start()
 			taskEmitter := task8.emitter
 			startTime := time.Now()
 			defer func() {
+				task8.outcome.Finish(err)
 				if task8.ran.Load() {
 					taskEmitter.TaskDone(ctx, time.Since(startTime))
 				}
@@ -840,8 +997,14 @@ func ContextErrorInFlight(ctx context.Context, cancel func(), src, target []int)
 
 			defer task8.ran.Store(true)
 
-			_108_4()
-
+			err = cff.Intercept(ctx, interceptor, task8.outcome.Info, func(ctx context.Context) (err error) {
+				_110_4()
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return
+			}
 			taskEmitter.TaskSuccess(ctx)
 			return
 		}
@@ -851,17 +1014,26 @@ func ContextErrorInFlight(ctx context.Context, cancel func(), src, target []int)
 		})
 		tasks = append(tasks, task8)
 
-		// go.uber.org/cff/internal/tests/parallel/parallel.go:112:4
+		// go.uber.org/cff/internal/tests/parallel/parallel.go:114:4
 		task9 := new(struct {
 			emitter cff.TaskEmitter
 			fn      func(context.Context) error
 			ran     cff.AtomicBool
+
+			outcome cff.TaskOutcome // reports why the task was skipped
 		})
+		task9.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/parallel/parallel.go",
+			Line:   114,
+			Column: 4,
+		}
 		task9.emitter = cff.NopTaskEmitter()
 		task9.fn = func(ctx context.Context) (err error) {
+			task9.outcome.Start()
 			taskEmitter := task9.emitter
 			startTime := time.Now()
 			defer func() {
+				task9.outcome.Finish(err)
 				if task9.ran.Load() {
 					taskEmitter.TaskDone(ctx, time.Since(startTime))
 				}
@@ -880,8 +1052,14 @@ func ContextErrorInFlight(ctx context.Context, cancel func(), src, target []int)
 
 			defer task9.ran.Store(true)
 
-			_112_4()
-
+			err = cff.Intercept(ctx, interceptor, task9.outcome.Info, func(ctx context.Context) (err error) {
+				_114_4()
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return
+			}
 			taskEmitter.TaskSuccess(ctx)
 			return
 		}
@@ -891,17 +1069,26 @@ func ContextErrorInFlight(ctx context.Context, cancel func(), src, target []int)
 		})
 		tasks = append(tasks, task9)
 
-		// go.uber.org/cff/internal/tests/parallel/parallel.go:115:4
+		// go.uber.org/cff/internal/tests/parallel/parallel.go:117:4
 		task10 := new(struct {
 			emitter cff.TaskEmitter
 			fn      func(context.Context) error
 			ran     cff.AtomicBool
+
+			outcome cff.TaskOutcome // reports why the task was skipped
 		})
+		task10.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/parallel/parallel.go",
+			Line:   117,
+			Column: 4,
+		}
 		task10.emitter = cff.NopTaskEmitter()
 		task10.fn = func(ctx context.Context) (err error) {
+			task10.outcome.Start()
 			taskEmitter := task10.emitter
 			startTime := time.Now()
 			defer func() {
+				task10.outcome.Finish(err)
 				if task10.ran.Load() {
 					taskEmitter.TaskDone(ctx, time.Since(startTime))
 				}
@@ -920,8 +1107,14 @@ func ContextErrorInFlight(ctx context.Context, cancel func(), src, target []int)
 
 			defer task10.ran.Store(true)
 
-			_115_4()
-
+			err = cff.Intercept(ctx, interceptor, task10.outcome.Info, func(ctx context.Context) (err error) {
+				_117_4()
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return
+			}
 			taskEmitter.TaskSuccess(ctx)
 			return
 		}
@@ -933,10 +1126,11 @@ func ContextErrorInFlight(ctx context.Context, cancel func(), src, target []int)
 
 		if err := sched.Wait(ctx); err != nil {
 			parallelEmitter.ParallelError(ctx, err)
+			cff.RethrowPanic(err, false)
 			return err
 		}
 		parallelEmitter.ParallelSuccess(ctx)
-		return nil /*line parallel.go:118*/
+		return nil /*line parallel.go:120*/
 	}()
 }
 
@@ -944,20 +1138,21 @@ func ContextErrorInFlight(ctx context.Context, cancel func(), src, target []int)
 func TaskWithError() error {
 	return func() (err error) {
 
-		_125_3 := context.Background()
+		_127_3 := context.Background()
 
-		_126_19 := 2
+		_128_19 := 2
 
-		_128_4 := func() error {
+		_130_4 := func() error {
 			return errors.New("sad times")
 		}
-		ctx := _125_3
+		ctx := _127_3
 		emitter := cff.NopEmitter()
+		interceptor := cff.InterceptorStack()
 
 		var (
 			parallelInfo = &cff.ParallelInfo{
 				File:   "go.uber.org/cff/internal/tests/parallel/parallel.go",
-				Line:   124,
+				Line:   126,
 				Column: 9,
 			}
 			directiveInfo = &cff.DirectiveInfo{
@@ -989,7 +1184,7 @@ func TaskWithError() error {
 
 		sched := cff.NewScheduler(
 			cff.SchedulerParams{
-				Concurrency: _126_19, Emitter: schedEmitter,
+				Concurrency: _128_19, Emitter: schedEmitter,
 			},
 		)
 
@@ -997,26 +1192,39 @@ func TaskWithError() error {
 			emitter cff.TaskEmitter
 			fn      func(context.Context) error
 			ran     cff.AtomicBool
+
+			outcome cff.TaskOutcome // reports why the task was skipped
 		}
 		defer func() {
 			for _, t := range tasks {
-				if !t.ran.Load() {
-					t.emitter.TaskSkipped(ctx, err)
+				// Tasks that are still running report their own outcome
+				// once they finish.
+				if !t.ran.Load() && !t.outcome.Running() {
+					t.emitter.TaskSkipped(ctx, t.outcome.SkipReason(ctx, err))
 				}
 			}
 		}()
 
-		// go.uber.org/cff/internal/tests/parallel/parallel.go:128:4
+		// go.uber.org/cff/internal/tests/parallel/parallel.go:130:4
 		task11 := new(struct {
 			emitter cff.TaskEmitter
 			fn      func(context.Context) error
 			ran     cff.AtomicBool
+
+			outcome cff.TaskOutcome // reports why the task was skipped
 		})
+		task11.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/parallel/parallel.go",
+			Line:   130,
+			Column: 4,
+		}
 		task11.emitter = cff.NopTaskEmitter()
 		task11.fn = func(ctx context.Context) (err error) {
+			task11.outcome.Start()
 			taskEmitter := task11.emitter
 			startTime := time.Now()
 			defer func() {
+				task11.outcome.Finish(err)
 				if task11.ran.Load() {
 					taskEmitter.TaskDone(ctx, time.Since(startTime))
 				}
@@ -1035,8 +1243,10 @@ func TaskWithError() error {
 
 			defer task11.ran.Store(true)
 
-			err = _128_4()
-
+			err = cff.Intercept(ctx, interceptor, task11.outcome.Info, func(ctx context.Context) (err error) {
+				err = _130_4()
+				return
+			})
 			if err != nil {
 				taskEmitter.TaskError(ctx, err)
 				return
@@ -1052,10 +1262,11 @@ func TaskWithError() error {
 
 		if err := sched.Wait(ctx); err != nil {
 			parallelEmitter.ParallelError(ctx, err)
+			cff.RethrowPanic(err, false)
 			return err
 		}
 		parallelEmitter.ParallelSuccess(ctx)
-		return nil /*line parallel.go:131*/
+		return nil /*line parallel.go:133*/
 	}()
 }
 
@@ -1063,20 +1274,21 @@ func TaskWithError() error {
 func TaskWithPanic() error {
 	return func() (err error) {
 
-		_138_3 := context.Background()
+		_140_3 := context.Background()
 
-		_139_19 := 2
+		_141_19 := 2
 
-		_141_4 := func() {
+		_143_4 := func() {
 			panic("sad times")
 		}
-		ctx := _138_3
+		ctx := _140_3
 		emitter := cff.NopEmitter()
+		interceptor := cff.InterceptorStack()
 
 		var (
 			parallelInfo = &cff.ParallelInfo{
 				File:   "go.uber.org/cff/internal/tests/parallel/parallel.go",
-				Line:   137,
+				Line:   139,
 				Column: 9,
 			}
 			directiveInfo = &cff.DirectiveInfo{
@@ -1108,7 +1320,7 @@ func TaskWithPanic() error {
 
 		sched := cff.NewScheduler(
 			cff.SchedulerParams{
-				Concurrency: _139_19, Emitter: schedEmitter,
+				Concurrency: _141_19, Emitter: schedEmitter,
 			},
 		)
 
@@ -1116,26 +1328,39 @@ func TaskWithPanic() error {
 			emitter cff.TaskEmitter
 			fn      func(context.Context) error
 			ran     cff.AtomicBool
+
+			outcome cff.TaskOutcome // reports why the task was skipped
 		}
 		defer func() {
 			for _, t := range tasks {
-				if !t.ran.Load() {
-					t.emitter.TaskSkipped(ctx, err)
+				// Tasks that are still running report their own outcome
+				// once they finish.
+				if !t.ran.Load() && !t.outcome.Running() {
+					t.emitter.TaskSkipped(ctx, t.outcome.SkipReason(ctx, err))
 				}
 			}
 		}()
 
-		// go.uber.org/cff/internal/tests/parallel/parallel.go:141:4
+		// go.uber.org/cff/internal/tests/parallel/parallel.go:143:4
 		task12 := new(struct {
 			emitter cff.TaskEmitter
 			fn      func(context.Context) error
 			ran     cff.AtomicBool
+
+			outcome cff.TaskOutcome // reports why the task was skipped
 		})
+		task12.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/parallel/parallel.go",
+			Line:   143,
+			Column: 4,
+		}
 		task12.emitter = cff.NopTaskEmitter()
 		task12.fn = func(ctx context.Context) (err error) {
+			task12.outcome.Start()
 			taskEmitter := task12.emitter
 			startTime := time.Now()
 			defer func() {
+				task12.outcome.Finish(err)
 				if task12.ran.Load() {
 					taskEmitter.TaskDone(ctx, time.Since(startTime))
 				}
@@ -1154,8 +1379,14 @@ func TaskWithPanic() error {
 
 			defer task12.ran.Store(true)
 
-			_141_4()
-
+			err = cff.Intercept(ctx, interceptor, task12.outcome.Info, func(ctx context.Context) (err error) {
+				_143_4()
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return
+			}
 			taskEmitter.TaskSuccess(ctx)
 			return
 		}
@@ -1167,10 +1398,11 @@ func TaskWithPanic() error {
 
 		if err := sched.Wait(ctx); err != nil {
 			parallelEmitter.ParallelError(ctx, err)
+			cff.RethrowPanic(err, false)
 			return err
 		}
 		parallelEmitter.ParallelSuccess(ctx)
-		return nil /*line parallel.go:144*/
+		return nil /*line parallel.go:146*/
 	}()
 }
 
@@ -1184,22 +1416,23 @@ func MultipleTask(src, target []int) error {
 	}
 	return func() (err error) {
 
-		_157_3 := context.Background()
+		_159_3 := context.Background()
 
-		_158_19 := 2
+		_160_19 := 2
 
-		_160_4 := func() {
+		_162_4 := func() {
 			target[0] = src[0]
 		}
 
-		_165_4 := send
-		ctx := _157_3
+		_167_4 := send
+		ctx := _159_3
 		emitter := cff.NopEmitter()
+		interceptor := cff.InterceptorStack()
 
 		var (
 			parallelInfo = &cff.ParallelInfo{
 				File:   "go.uber.org/cff/internal/tests/parallel/parallel.go",
-				Line:   156,
+				Line:   158,
 				Column: 9,
 			}
 			directiveInfo = &cff.DirectiveInfo{
@@ -1231,7 +1464,7 @@ func MultipleTask(src, target []int) error {
 
 		sched := cff.NewScheduler(
 			cff.SchedulerParams{
-				Concurrency: _158_19, Emitter: schedEmitter,
+				Concurrency: _160_19, Emitter: schedEmitter,
 			},
 		)
 
@@ -1239,26 +1472,39 @@ func MultipleTask(src, target []int) error {
 			emitter cff.TaskEmitter
 			fn      func(context.Context) error
 			ran     cff.AtomicBool
+
+			outcome cff.TaskOutcome // reports why the task was skipped
 		}
 		defer func() {
 			for _, t := range tasks {
-				if !t.ran.Load() {
-					t.emitter.TaskSkipped(ctx, err)
+				// Tasks that are still running report their own outcome
+				// once they finish.
+				if !t.ran.Load() && !t.outcome.Running() {
+					t.emitter.TaskSkipped(ctx, t.outcome.SkipReason(ctx, err))
 				}
 			}
 		}()
 
-		// go.uber.org/cff/internal/tests/parallel/parallel.go:160:4
+		// go.uber.org/cff/internal/tests/parallel/parallel.go:162:4
 		task13 := new(struct {
 			emitter cff.TaskEmitter
 			fn      func(context.Context) error
 			ran     cff.AtomicBool
+
+			outcome cff.TaskOutcome // reports why the task was skipped
 		})
+		task13.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/parallel/parallel.go",
+			Line:   162,
+			Column: 4,
+		}
 		task13.emitter = cff.NopTaskEmitter()
 		task13.fn = func(ctx context.Context) (err error) {
+			task13.outcome.Start()
 			taskEmitter := task13.emitter
 			startTime := time.Now()
 			defer func() {
+				task13.outcome.Finish(err)
 				if task13.ran.Load() {
 					taskEmitter.TaskDone(ctx, time.Since(startTime))
 				}
@@ -1277,8 +1523,14 @@ func MultipleTask(src, target []int) error {
 
 			defer task13.ran.Store(true)
 
-			_160_4()
-
+			err = cff.Intercept(ctx, interceptor, task13.outcome.Info, func(ctx context.Context) (err error) {
+				_162_4()
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return
+			}
 			taskEmitter.TaskSuccess(ctx)
 			return
 		}
@@ -1288,17 +1540,26 @@ func MultipleTask(src, target []int) error {
 		})
 		tasks = append(tasks, task13)
 
-		// go.uber.org/cff/internal/tests/parallel/parallel.go:165:4
+		// go.uber.org/cff/internal/tests/parallel/parallel.go:167:4
 		task14 := new(struct {
 			emitter cff.TaskEmitter
 			fn      func(context.Context) error
 			ran     cff.AtomicBool
+
+			outcome cff.TaskOutcome // reports why the task was skipped
 		})
+		task14.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/parallel/parallel.go",
+			Line:   167,
+			Column: 4,
+		}
 		task14.emitter = cff.NopTaskEmitter()
 		task14.fn = func(ctx context.Context) (err error) {
+			task14.outcome.Start()
 			taskEmitter := task14.emitter
 			startTime := time.Now()
 			defer func() {
+				task14.outcome.Finish(err)
 				if task14.ran.Load() {
 					taskEmitter.TaskDone(ctx, time.Since(startTime))
 				}
@@ -1317,8 +1578,10 @@ func MultipleTask(src, target []int) error {
 
 			defer task14.ran.Store(true)
 
-			err = _165_4(ctx)
-
+			err = cff.Intercept(ctx, interceptor, task14.outcome.Info, func(ctx context.Context) (err error) {
+				err = _167_4(ctx)
+				return
+			})
 			if err != nil {
 				taskEmitter.TaskError(ctx, err)
 				return
@@ -1334,10 +1597,11 @@ func MultipleTask(src, target []int) error {
 
 		if err := sched.Wait(ctx); err != nil {
 			parallelEmitter.ParallelError(ctx, err)
+			cff.RethrowPanic(err, false)
 			return err
 		}
 		parallelEmitter.ParallelSuccess(ctx)
-		return nil /*line parallel.go:166*/
+		return nil /*line parallel.go:168*/
 	}()
 }
 
@@ -1349,42 +1613,43 @@ func ContinueOnError(src []int, target []int) error {
 	blockerB := make(chan struct{})
 	return func() (err error) {
 
-		_177_3 := context.Background()
+		_179_3 := context.Background()
 
-		_178_19 := 2
+		_180_19 := 2
 
-		_179_23 := true
+		_181_23 := true
 
-		_181_4 := func(_ context.Context) error {
+		_183_4 := func(_ context.Context) error {
 			close(blockerA)
 			return errors.New("sad times")
 		}
 
-		_185_4 := func() {
+		_187_4 := func() {
 
 			<-blockerA
 			target[0] = src[0]
 		}
 
-		_193_4 := func() {
+		_195_4 := func() {
 
 			<-blockerA
 			close(blockerB)
 			panic("sadder times")
 		}
 
-		_201_4 := func() {
+		_203_4 := func() {
 
 			<-blockerB
 			target[1] = src[1]
 		}
-		ctx := _177_3
+		ctx := _179_3
 		emitter := cff.NopEmitter()
+		interceptor := cff.InterceptorStack()
 
 		var (
 			parallelInfo = &cff.ParallelInfo{
 				File:   "go.uber.org/cff/internal/tests/parallel/parallel.go",
-				Line:   176,
+				Line:   178,
 				Column: 9,
 			}
 			directiveInfo = &cff.DirectiveInfo{
@@ -1416,8 +1681,8 @@ func ContinueOnError(src []int, target []int) error {
 
 		sched := cff.NewScheduler(
 			cff.SchedulerParams{
-				Concurrency: _178_19, Emitter: schedEmitter,
-				ContinueOnError: _179_23,
+				Concurrency: _180_19, Emitter: schedEmitter,
+				ContinueOnError: _181_23,
 			},
 		)
 
@@ -1425,26 +1690,39 @@ func ContinueOnError(src []int, target []int) error {
 			emitter cff.TaskEmitter
 			fn      func(context.Context) error
 			ran     cff.AtomicBool
+
+			outcome cff.TaskOutcome // reports why the task was skipped
 		}
 		defer func() {
 			for _, t := range tasks {
-				if !t.ran.Load() {
-					t.emitter.TaskSkipped(ctx, err)
+				// Tasks that are still running report their own outcome
+				// once they finish.
+				if !t.ran.Load() && !t.outcome.Running() {
+					t.emitter.TaskSkipped(ctx, t.outcome.SkipReason(ctx, err))
 				}
 			}
 		}()
 
-		// go.uber.org/cff/internal/tests/parallel/parallel.go:181:4
+		// go.uber.org/cff/internal/tests/parallel/parallel.go:183:4
 		task15 := new(struct {
 			emitter cff.TaskEmitter
 			fn      func(context.Context) error
 			ran     cff.AtomicBool
+
+			outcome cff.TaskOutcome // reports why the task was skipped
 		})
+		task15.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/parallel/parallel.go",
+			Line:   183,
+			Column: 4,
+		}
 		task15.emitter = cff.NopTaskEmitter()
 		task15.fn = func(ctx context.Context) (err error) {
+			task15.outcome.Start()
 			taskEmitter := task15.emitter
 			startTime := time.Now()
 			defer func() {
+				task15.outcome.Finish(err)
 				if task15.ran.Load() {
 					taskEmitter.TaskDone(ctx, time.Since(startTime))
 				}
@@ -1463,8 +1741,10 @@ func ContinueOnError(src []int, target []int) error {
 
 			defer task15.ran.Store(true)
 
-			err = _181_4(ctx)
-
+			err = cff.Intercept(ctx, interceptor, task15.outcome.Info, func(ctx context.Context) (err error) {
+				err = _183_4(ctx)
+				return
+			})
 			if err != nil {
 				taskEmitter.TaskError(ctx, err)
 				return
@@ -1478,17 +1758,26 @@ func ContinueOnError(src []int, target []int) error {
 		})
 		tasks = append(tasks, task15)
 
-		// go.uber.org/cff/internal/tests/parallel/parallel.go:185:4
+		// go.uber.org/cff/internal/tests/parallel/parallel.go:187:4
 		task16 := new(struct {
 			emitter cff.TaskEmitter
 			fn      func(context.Context) error
 			ran     cff.AtomicBool
+
+			outcome cff.TaskOutcome // reports why the task was skipped
 		})
+		task16.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/parallel/parallel.go",
+			Line:   187,
+			Column: 4,
+		}
 		task16.emitter = cff.NopTaskEmitter()
 		task16.fn = func(ctx context.Context) (err error) {
+			task16.outcome.Start()
 			taskEmitter := task16.emitter
 			startTime := time.Now()
 			defer func() {
+				task16.outcome.Finish(err)
 				if task16.ran.Load() {
 					taskEmitter.TaskDone(ctx, time.Since(startTime))
 				}
@@ -1507,8 +1796,14 @@ func ContinueOnError(src []int, target []int) error {
 
 			defer task16.ran.Store(true)
 
-			_185_4()
-
+			err = cff.Intercept(ctx, interceptor, task16.outcome.Info, func(ctx context.Context) (err error) {
+				_187_4()
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return
+			}
 			taskEmitter.TaskSuccess(ctx)
 			return
 		}
@@ -1518,17 +1813,26 @@ func ContinueOnError(src []int, target []int) error {
 		})
 		tasks = append(tasks, task16)
 
-		// go.uber.org/cff/internal/tests/parallel/parallel.go:193:4
+		// go.uber.org/cff/internal/tests/parallel/parallel.go:195:4
 		task17 := new(struct {
 			emitter cff.TaskEmitter
 			fn      func(context.Context) error
 			ran     cff.AtomicBool
+
+			outcome cff.TaskOutcome // reports why the task was skipped
 		})
+		task17.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/parallel/parallel.go",
+			Line:   195,
+			Column: 4,
+		}
 		task17.emitter = cff.NopTaskEmitter()
 		task17.fn = func(ctx context.Context) (err error) {
+			task17.outcome.Start()
 			taskEmitter := task17.emitter
 			startTime := time.Now()
 			defer func() {
+				task17.outcome.Finish(err)
 				if task17.ran.Load() {
 					taskEmitter.TaskDone(ctx, time.Since(startTime))
 				}
@@ -1547,8 +1851,14 @@ func ContinueOnError(src []int, target []int) error {
 
 			defer task17.ran.Store(true)
 
-			_193_4()
-
+			err = cff.Intercept(ctx, interceptor, task17.outcome.Info, func(ctx context.Context) (err error) {
+				_195_4()
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return
+			}
 			taskEmitter.TaskSuccess(ctx)
 			return
 		}
@@ -1558,17 +1868,26 @@ func ContinueOnError(src []int, target []int) error {
 		})
 		tasks = append(tasks, task17)
 
-		// go.uber.org/cff/internal/tests/parallel/parallel.go:201:4
+		// go.uber.org/cff/internal/tests/parallel/parallel.go:203:4
 		task18 := new(struct {
 			emitter cff.TaskEmitter
 			fn      func(context.Context) error
 			ran     cff.AtomicBool
+
+			outcome cff.TaskOutcome // reports why the task was skipped
 		})
+		task18.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/parallel/parallel.go",
+			Line:   203,
+			Column: 4,
+		}
 		task18.emitter = cff.NopTaskEmitter()
 		task18.fn = func(ctx context.Context) (err error) {
+			task18.outcome.Start()
 			taskEmitter := task18.emitter
 			startTime := time.Now()
 			defer func() {
+				task18.outcome.Finish(err)
 				if task18.ran.Load() {
 					taskEmitter.TaskDone(ctx, time.Since(startTime))
 				}
@@ -1587,8 +1906,14 @@ func ContinueOnError(src []int, target []int) error {
 
 			defer task18.ran.Store(true)
 
-			_201_4()
-
+			err = cff.Intercept(ctx, interceptor, task18.outcome.Info, func(ctx context.Context) (err error) {
+				_203_4()
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return
+			}
 			taskEmitter.TaskSuccess(ctx)
 			return
 		}
@@ -1600,10 +1925,11 @@ func ContinueOnError(src []int, target []int) error {
 
 		if err := sched.Wait(ctx); err != nil {
 			parallelEmitter.ParallelError(ctx, err)
+			cff.RethrowPanic(err, false)
 			return err
 		}
 		parallelEmitter.ParallelSuccess(ctx)
-		return nil /*line parallel.go:207*/
+		return nil /*line parallel.go:209*/
 	}()
 }
 
@@ -1617,38 +1943,39 @@ func ContinueOnErrorBoolExpr(src, target []int, fn func() bool) error {
 	blockerB := make(chan struct{})
 	return func() (err error) {
 
-		_220_3 := context.Background()
+		_222_3 := context.Background()
 
-		_221_19 := 2
+		_223_19 := 2
 
-		_222_23 := fn()
+		_224_23 := fn()
 
-		_224_4 := func(_ context.Context) error {
+		_226_4 := func(_ context.Context) error {
 			close(blockerA)
 
 			_, err := os.Open("non-existing")
 			return err
 		}
 
-		_231_4 := func() {
+		_233_4 := func() {
 
 			<-blockerA
 			target[0] = src[0]
 			close(blockerB)
 		}
 
-		_240_4 := func() {
+		_242_4 := func() {
 
 			<-blockerB
 			target[1] = src[1]
 		}
-		ctx := _220_3
+		ctx := _222_3
 		emitter := cff.NopEmitter()
+		interceptor := cff.InterceptorStack()
 
 		var (
 			parallelInfo = &cff.ParallelInfo{
 				File:   "go.uber.org/cff/internal/tests/parallel/parallel.go",
-				Line:   219,
+				Line:   221,
 				Column: 9,
 			}
 			directiveInfo = &cff.DirectiveInfo{
@@ -1680,8 +2007,8 @@ func ContinueOnErrorBoolExpr(src, target []int, fn func() bool) error {
 
 		sched := cff.NewScheduler(
 			cff.SchedulerParams{
-				Concurrency: _221_19, Emitter: schedEmitter,
-				ContinueOnError: _222_23,
+				Concurrency: _223_19, Emitter: schedEmitter,
+				ContinueOnError: _224_23,
 			},
 		)
 
@@ -1689,26 +2016,39 @@ func ContinueOnErrorBoolExpr(src, target []int, fn func() bool) error {
 			emitter cff.TaskEmitter
 			fn      func(context.Context) error
 			ran     cff.AtomicBool
+
+			outcome cff.TaskOutcome // reports why the task was skipped
 		}
 		defer func() {
 			for _, t := range tasks {
-				if !t.ran.Load() {
-					t.emitter.TaskSkipped(ctx, err)
+				// Tasks that are still running report their own outcome
+				// once they finish.
+				if !t.ran.Load() && !t.outcome.Running() {
+					t.emitter.TaskSkipped(ctx, t.outcome.SkipReason(ctx, err))
 				}
 			}
 		}()
 
-		// go.uber.org/cff/internal/tests/parallel/parallel.go:224:4
+		// go.uber.org/cff/internal/tests/parallel/parallel.go:226:4
 		task19 := new(struct {
 			emitter cff.TaskEmitter
 			fn      func(context.Context) error
 			ran     cff.AtomicBool
+
+			outcome cff.TaskOutcome // reports why the task was skipped
 		})
+		task19.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/parallel/parallel.go",
+			Line:   226,
+			Column: 4,
+		}
 		task19.emitter = cff.NopTaskEmitter()
 		task19.fn = func(ctx context.Context) (err error) {
+			task19.outcome.Start()
 			taskEmitter := task19.emitter
 			startTime := time.Now()
 			defer func() {
+				task19.outcome.Finish(err)
 				if task19.ran.Load() {
 					taskEmitter.TaskDone(ctx, time.Since(startTime))
 				}
@@ -1727,8 +2067,10 @@ func ContinueOnErrorBoolExpr(src, target []int, fn func() bool) error {
 
 			defer task19.ran.Store(true)
 
-			err = _224_4(ctx)
-
+			err = cff.Intercept(ctx, interceptor, task19.outcome.Info, func(ctx context.Context) (err error) {
+				err = _226_4(ctx)
+				return
+			})
 			if err != nil {
 				taskEmitter.TaskError(ctx, err)
 				return
@@ -1742,17 +2084,26 @@ func ContinueOnErrorBoolExpr(src, target []int, fn func() bool) error {
 		})
 		tasks = append(tasks, task19)
 
-		// go.uber.org/cff/internal/tests/parallel/parallel.go:231:4
+		// go.uber.org/cff/internal/tests/parallel/parallel.go:233:4
 		task20 := new(struct {
 			emitter cff.TaskEmitter
 			fn      func(context.Context) error
 			ran     cff.AtomicBool
+
+			outcome cff.TaskOutcome // reports why the task was skipped
 		})
+		task20.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/parallel/parallel.go",
+			Line:   233,
+			Column: 4,
+		}
 		task20.emitter = cff.NopTaskEmitter()
 		task20.fn = func(ctx context.Context) (err error) {
+			task20.outcome.Start()
 			taskEmitter := task20.emitter
 			startTime := time.Now()
 			defer func() {
+				task20.outcome.Finish(err)
 				if task20.ran.Load() {
 					taskEmitter.TaskDone(ctx, time.Since(startTime))
 				}
@@ -1771,8 +2122,14 @@ func ContinueOnErrorBoolExpr(src, target []int, fn func() bool) error {
 
 			defer task20.ran.Store(true)
 
-			_231_4()
-
+			err = cff.Intercept(ctx, interceptor, task20.outcome.Info, func(ctx context.Context) (err error) {
+				_233_4()
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return
+			}
 			taskEmitter.TaskSuccess(ctx)
 			return
 		}
@@ -1782,17 +2139,26 @@ func ContinueOnErrorBoolExpr(src, target []int, fn func() bool) error {
 		})
 		tasks = append(tasks, task20)
 
-		// go.uber.org/cff/internal/tests/parallel/parallel.go:240:4
+		// go.uber.org/cff/internal/tests/parallel/parallel.go:242:4
 		task21 := new(struct {
 			emitter cff.TaskEmitter
 			fn      func(context.Context) error
 			ran     cff.AtomicBool
+
+			outcome cff.TaskOutcome // reports why the task was skipped
 		})
+		task21.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/parallel/parallel.go",
+			Line:   242,
+			Column: 4,
+		}
 		task21.emitter = cff.NopTaskEmitter()
 		task21.fn = func(ctx context.Context) (err error) {
+			task21.outcome.Start()
 			taskEmitter := task21.emitter
 			startTime := time.Now()
 			defer func() {
+				task21.outcome.Finish(err)
 				if task21.ran.Load() {
 					taskEmitter.TaskDone(ctx, time.Since(startTime))
 				}
@@ -1811,8 +2177,14 @@ func ContinueOnErrorBoolExpr(src, target []int, fn func() bool) error {
 
 			defer task21.ran.Store(true)
 
-			_240_4()
-
+			err = cff.Intercept(ctx, interceptor, task21.outcome.Info, func(ctx context.Context) (err error) {
+				_242_4()
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return
+			}
 			taskEmitter.TaskSuccess(ctx)
 			return
 		}
@@ -1824,10 +2196,11 @@ func ContinueOnErrorBoolExpr(src, target []int, fn func() bool) error {
 
 		if err := sched.Wait(ctx); err != nil {
 			parallelEmitter.ParallelError(ctx, err)
+			cff.RethrowPanic(err, false)
 			return err
 		}
 		parallelEmitter.ParallelSuccess(ctx)
-		return nil /*line parallel.go:247*/
+		return nil /*line parallel.go:249*/
 	}()
 }
 
@@ -1835,22 +2208,23 @@ func ContinueOnErrorBoolExpr(src, target []int, fn func() bool) error {
 func ContinueOnErrorCancelled(ctx context.Context, src []int, target []int) error {
 	return func() (err error) {
 
-		_254_3 := ctx
+		_256_3 := ctx
 
-		_255_19 := 2
+		_257_19 := 2
 
-		_256_23 := true
+		_258_23 := true
 
-		_258_4 := func() {
+		_260_4 := func() {
 			target[0] = src[0]
 		}
-		ctx := _254_3
+		ctx := _256_3
 		emitter := cff.NopEmitter()
+		interceptor := cff.InterceptorStack()
 
 		var (
 			parallelInfo = &cff.ParallelInfo{
 				File:   "go.uber.org/cff/internal/tests/parallel/parallel.go",
-				Line:   253,
+				Line:   255,
 				Column: 9,
 			}
 			directiveInfo = &cff.DirectiveInfo{
@@ -1882,8 +2256,8 @@ func ContinueOnErrorCancelled(ctx context.Context, src []int, target []int) erro
 
 		sched := cff.NewScheduler(
 			cff.SchedulerParams{
-				Concurrency: _255_19, Emitter: schedEmitter,
-				ContinueOnError: _256_23,
+				Concurrency: _257_19, Emitter: schedEmitter,
+				ContinueOnError: _258_23,
 			},
 		)
 
@@ -1891,26 +2265,39 @@ func ContinueOnErrorCancelled(ctx context.Context, src []int, target []int) erro
 			emitter cff.TaskEmitter
 			fn      func(context.Context) error
 			ran     cff.AtomicBool
+
+			outcome cff.TaskOutcome // reports why the task was skipped
 		}
 		defer func() {
 			for _, t := range tasks {
-				if !t.ran.Load() {
-					t.emitter.TaskSkipped(ctx, err)
+				// Tasks that are still running report their own outcome
+				// once they finish.
+				if !t.ran.Load() && !t.outcome.Running() {
+					t.emitter.TaskSkipped(ctx, t.outcome.SkipReason(ctx, err))
 				}
 			}
 		}()
 
-		// go.uber.org/cff/internal/tests/parallel/parallel.go:258:4
+		// go.uber.org/cff/internal/tests/parallel/parallel.go:260:4
 		task22 := new(struct {
 			emitter cff.TaskEmitter
 			fn      func(context.Context) error
 			ran     cff.AtomicBool
+
+			outcome cff.TaskOutcome // reports why the task was skipped
 		})
+		task22.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/parallel/parallel.go",
+			Line:   260,
+			Column: 4,
+		}
 		task22.emitter = cff.NopTaskEmitter()
 		task22.fn = func(ctx context.Context) (err error) {
+			task22.outcome.Start()
 			taskEmitter := task22.emitter
 			startTime := time.Now()
 			defer func() {
+				task22.outcome.Finish(err)
 				if task22.ran.Load() {
 					taskEmitter.TaskDone(ctx, time.Since(startTime))
 				}
@@ -1929,8 +2316,14 @@ func ContinueOnErrorCancelled(ctx context.Context, src []int, target []int) erro
 
 			defer task22.ran.Store(true)
 
-			_258_4()
-
+			err = cff.Intercept(ctx, interceptor, task22.outcome.Info, func(ctx context.Context) (err error) {
+				_260_4()
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return
+			}
 			taskEmitter.TaskSuccess(ctx)
 			return
 		}
@@ -1942,10 +2335,11 @@ func ContinueOnErrorCancelled(ctx context.Context, src []int, target []int) erro
 
 		if err := sched.Wait(ctx); err != nil {
 			parallelEmitter.ParallelError(ctx, err)
+			cff.RethrowPanic(err, false)
 			return err
 		}
 		parallelEmitter.ParallelSuccess(ctx)
-		return nil /*line parallel.go:261*/
+		return nil /*line parallel.go:263*/
 	}()
 }
 
@@ -1955,31 +2349,32 @@ func ContinueOnErrorCancelledDuring(ctx context.Context, cancel func(), src []in
 	blocker := make(chan struct{})
 	return func() (err error) {
 
-		_270_3 := ctx
+		_272_3 := ctx
 
-		_271_19 := 2
+		_273_19 := 2
 
-		_272_23 := true
+		_274_23 := true
 
-		_276_4 := func() {
+		_278_4 := func() {
 			cancel()
 			close(blocker)
 		}
 
-		_280_4 := func() {
+		_282_4 := func() {
 			<-blocker
 		}
 
-		_285_4 := func() {
+		_287_4 := func() {
 			target[0] = src[0]
 		}
-		ctx := _270_3
+		ctx := _272_3
 		emitter := cff.NopEmitter()
+		interceptor := cff.InterceptorStack()
 
 		var (
 			parallelInfo = &cff.ParallelInfo{
 				File:   "go.uber.org/cff/internal/tests/parallel/parallel.go",
-				Line:   269,
+				Line:   271,
 				Column: 9,
 			}
 			directiveInfo = &cff.DirectiveInfo{
@@ -2011,8 +2406,8 @@ func ContinueOnErrorCancelledDuring(ctx context.Context, cancel func(), src []in
 
 		sched := cff.NewScheduler(
 			cff.SchedulerParams{
-				Concurrency: _271_19, Emitter: schedEmitter,
-				ContinueOnError: _272_23,
+				Concurrency: _273_19, Emitter: schedEmitter,
+				ContinueOnError: _274_23,
 			},
 		)
 
@@ -2020,26 +2415,39 @@ func ContinueOnErrorCancelledDuring(ctx context.Context, cancel func(), src []in
 			emitter cff.TaskEmitter
 			fn      func(context.Context) error
 			ran     cff.AtomicBool
+
+			outcome cff.TaskOutcome // reports why the task was skipped
 		}
 		defer func() {
 			for _, t := range tasks {
-				if !t.ran.Load() {
-					t.emitter.TaskSkipped(ctx, err)
+				// Tasks that are still running report their own outcome
+				// once they finish.
+				if !t.ran.Load() && !t.outcome.Running() {
+					t.emitter.TaskSkipped(ctx, t.outcome.SkipReason(ctx, err))
 				}
 			}
 		}()
 
-		// go.uber.org/cff/internal/tests/parallel/parallel.go:276:4
+		// go.uber.org/cff/internal/tests/parallel/parallel.go:278:4
 		task23 := new(struct {
 			emitter cff.TaskEmitter
 			fn      func(context.Context) error
 			ran     cff.AtomicBool
+
+			outcome cff.TaskOutcome // reports why the task was skipped
 		})
+		task23.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/parallel/parallel.go",
+			Line:   278,
+			Column: 4,
+		}
 		task23.emitter = cff.NopTaskEmitter()
 		task23.fn = func(ctx context.Context) (err error) {
+			task23.outcome.Start()
 			taskEmitter := task23.emitter
 			startTime := time.Now()
 			defer func() {
+				task23.outcome.Finish(err)
 				if task23.ran.Load() {
 					taskEmitter.TaskDone(ctx, time.Since(startTime))
 				}
@@ -2058,8 +2466,14 @@ func ContinueOnErrorCancelledDuring(ctx context.Context, cancel func(), src []in
 
 			defer task23.ran.Store(true)
 
-			_276_4()
-
+			err = cff.Intercept(ctx, interceptor, task23.outcome.Info, func(ctx context.Context) (err error) {
+				_278_4()
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return
+			}
 			taskEmitter.TaskSuccess(ctx)
 			return
 		}
@@ -2069,17 +2483,26 @@ func ContinueOnErrorCancelledDuring(ctx context.Context, cancel func(), src []in
 		})
 		tasks = append(tasks, task23)
 
-		// go.uber.org/cff/internal/tests/parallel/parallel.go:280:4
+		// go.uber.org/cff/internal/tests/parallel/parallel.go:282:4
 		task24 := new(struct {
 			emitter cff.TaskEmitter
 			fn      func(context.Context) error
 			ran     cff.AtomicBool
+
+			outcome cff.TaskOutcome // reports why the task was skipped
 		})
+		task24.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/parallel/parallel.go",
+			Line:   282,
+			Column: 4,
+		}
 		task24.emitter = cff.NopTaskEmitter()
 		task24.fn = func(ctx context.Context) (err error) {
+			task24.outcome.Start()
 			taskEmitter := task24.emitter
 			startTime := time.Now()
 			defer func() {
+				task24.outcome.Finish(err)
 				if task24.ran.Load() {
 					taskEmitter.TaskDone(ctx, time.Since(startTime))
 				}
@@ -2098,8 +2521,14 @@ func ContinueOnErrorCancelledDuring(ctx context.Context, cancel func(), src []in
 
 			defer task24.ran.Store(true)
 
-			_280_4()
-
+			err = cff.Intercept(ctx, interceptor, task24.outcome.Info, func(ctx context.Context) (err error) {
+				_282_4()
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return
+			}
 			taskEmitter.TaskSuccess(ctx)
 			return
 		}
@@ -2109,17 +2538,26 @@ func ContinueOnErrorCancelledDuring(ctx context.Context, cancel func(), src []in
 		})
 		tasks = append(tasks, task24)
 
-		// go.uber.org/cff/internal/tests/parallel/parallel.go:285:4
+		// go.uber.org/cff/internal/tests/parallel/parallel.go:287:4
 		task25 := new(struct {
 			emitter cff.TaskEmitter
 			fn      func(context.Context) error
 			ran     cff.AtomicBool
+
+			outcome cff.TaskOutcome // reports why the task was skipped
 		})
+		task25.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/parallel/parallel.go",
+			Line:   287,
+			Column: 4,
+		}
 		task25.emitter = cff.NopTaskEmitter()
 		task25.fn = func(ctx context.Context) (err error) {
+			task25.outcome.Start()
 			taskEmitter := task25.emitter
 			startTime := time.Now()
 			defer func() {
+				task25.outcome.Finish(err)
 				if task25.ran.Load() {
 					taskEmitter.TaskDone(ctx, time.Since(startTime))
 				}
@@ -2138,8 +2576,14 @@ func ContinueOnErrorCancelledDuring(ctx context.Context, cancel func(), src []in
 
 			defer task25.ran.Store(true)
 
-			_285_4()
-
+			err = cff.Intercept(ctx, interceptor, task25.outcome.Info, func(ctx context.Context) (err error) {
+				_287_4()
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return
+			}
 			taskEmitter.TaskSuccess(ctx)
 			return
 		}
@@ -2151,10 +2595,11 @@ func ContinueOnErrorCancelledDuring(ctx context.Context, cancel func(), src []in
 
 		if err := sched.Wait(ctx); err != nil {
 			parallelEmitter.ParallelError(ctx, err)
+			cff.RethrowPanic(err, false)
 			return err
 		}
 		parallelEmitter.ParallelSuccess(ctx)
-		return nil /*line parallel.go:288*/
+		return nil /*line parallel.go:290*/
 	}()
 }
 
@@ -2163,29 +2608,30 @@ func ContinueOnErrorCancelledDuring(ctx context.Context, cancel func(), src []in
 func SliceMultiple(srcA, srcB, targetA, targetB []int) error {
 	return func() (err error) {
 
-		_296_3 := context.Background()
+		_298_3 := context.Background()
 
-		_297_19 := 2
+		_299_19 := 2
 
-		_299_4 := func(idx int, val int) error {
+		_301_4 := func(idx int, val int) error {
 			targetA[idx] = val
 			return nil
 		}
 
-		_303_4 := srcA
+		_305_4 := srcA
 
-		_306_4 := func(_ context.Context, idx int, val int) {
+		_308_4 := func(_ context.Context, idx int, val int) {
 			targetB[idx] = val
 		}
 
-		_309_4 := srcB
-		ctx := _296_3
+		_311_4 := srcB
+		ctx := _298_3
 		emitter := cff.NopEmitter()
+		interceptor := cff.InterceptorStack()
 
 		var (
 			parallelInfo = &cff.ParallelInfo{
 				File:   "go.uber.org/cff/internal/tests/parallel/parallel.go",
-				Line:   295,
+				Line:   297,
 				Column: 9,
 			}
 			directiveInfo = &cff.DirectiveInfo{
@@ -2217,7 +2663,7 @@ func SliceMultiple(srcA, srcB, targetA, targetB []int) error {
 
 		sched := cff.NewScheduler(
 			cff.SchedulerParams{
-				Concurrency: _297_19, Emitter: schedEmitter,
+				Concurrency: _299_19, Emitter: schedEmitter,
 			},
 		)
 
@@ -2225,17 +2671,27 @@ func SliceMultiple(srcA, srcB, targetA, targetB []int) error {
 			emitter cff.TaskEmitter
 			fn      func(context.Context) error
 			ran     cff.AtomicBool
+
+			outcome cff.TaskOutcome // reports why the task was skipped
 		}
 		defer func() {
 			for _, t := range tasks {
-				if !t.ran.Load() {
-					t.emitter.TaskSkipped(ctx, err)
+				// Tasks that are still running report their own outcome
+				// once they finish.
+				if !t.ran.Load() && !t.outcome.Running() {
+					t.emitter.TaskSkipped(ctx, t.outcome.SkipReason(ctx, err))
 				}
 			}
 		}()
 
-		// go.uber.org/cff/internal/tests/parallel/parallel.go:298:3
-		sliceTask26Slice := _303_4
+		// go.uber.org/cff/internal/tests/parallel/parallel.go:300:3
+		sliceTask26Slice := _305_4
+		sliceTask26Info := &cff.TaskInfo{
+			Name:   parallelInfo.Name,
+			File:   "go.uber.org/cff/internal/tests/parallel/parallel.go",
+			Line:   300,
+			Column: 3,
+		}
 		for idx, val := range sliceTask26Slice {
 			idx := idx
 			val := val
@@ -2243,6 +2699,8 @@ func SliceMultiple(srcA, srcB, targetA, targetB []int) error {
 				emitter cff.TaskEmitter
 				fn      func(context.Context) error
 				ran     cff.AtomicBool
+
+				outcome cff.TaskOutcome // reports why the task was skipped
 			})
 			sliceTask26.fn = func(ctx context.Context) (err error) {
 				defer func() {
@@ -2254,16 +2712,24 @@ func SliceMultiple(srcA, srcB, targetA, targetB []int) error {
 						}
 					}
 				}()
-				err = _299_4(idx, val)
-				return
+				return cff.Intercept(ctx, interceptor, sliceTask26Info, func(ctx context.Context) (err error) {
+					err = _301_4(idx, val)
+					return
+				})
 			}
 			sched.Enqueue(ctx, cff.Job{
 				Run: sliceTask26.fn,
 			})
 		}
 
-		// go.uber.org/cff/internal/tests/parallel/parallel.go:305:3
-		sliceTask27Slice := _309_4
+		// go.uber.org/cff/internal/tests/parallel/parallel.go:307:3
+		sliceTask27Slice := _311_4
+		sliceTask27Info := &cff.TaskInfo{
+			Name:   parallelInfo.Name,
+			File:   "go.uber.org/cff/internal/tests/parallel/parallel.go",
+			Line:   307,
+			Column: 3,
+		}
 		for idx, val := range sliceTask27Slice {
 			idx := idx
 			val := val
@@ -2271,6 +2737,8 @@ func SliceMultiple(srcA, srcB, targetA, targetB []int) error {
 				emitter cff.TaskEmitter
 				fn      func(context.Context) error
 				ran     cff.AtomicBool
+
+				outcome cff.TaskOutcome // reports why the task was skipped
 			})
 			sliceTask27.fn = func(ctx context.Context) (err error) {
 				defer func() {
@@ -2282,8 +2750,10 @@ func SliceMultiple(srcA, srcB, targetA, targetB []int) error {
 						}
 					}
 				}()
-				_306_4(ctx, idx, val)
-				return
+				return cff.Intercept(ctx, interceptor, sliceTask27Info, func(ctx context.Context) (err error) {
+					_308_4(ctx, idx, val)
+					return
+				})
 			}
 			sched.Enqueue(ctx, cff.Job{
 				Run: sliceTask27.fn,
@@ -2292,10 +2762,11 @@ func SliceMultiple(srcA, srcB, targetA, targetB []int) error {
 
 		if err := sched.Wait(ctx); err != nil {
 			parallelEmitter.ParallelError(ctx, err)
+			cff.RethrowPanic(err, false)
 			return err
 		}
 		parallelEmitter.ParallelSuccess(ctx)
-		return nil /*line parallel.go:310*/
+		return nil /*line parallel.go:312*/
 	}()
 }
 
@@ -2303,29 +2774,30 @@ func SliceMultiple(srcA, srcB, targetA, targetB []int) error {
 func SliceNoIndex(srcA, srcB, targetA, targetB []int) error {
 	return func() (err error) {
 
-		_317_3 := context.Background()
+		_319_3 := context.Background()
 
-		_318_19 := 2
+		_320_19 := 2
 
-		_320_4 := func(val int) error {
+		_322_4 := func(val int) error {
 			targetA[val] = val
 			return nil
 		}
 
-		_324_4 := srcA
+		_326_4 := srcA
 
-		_327_4 := func(_ context.Context, val int) {
+		_329_4 := func(_ context.Context, val int) {
 			targetB[val] = val
 		}
 
-		_330_4 := srcB
-		ctx := _317_3
+		_332_4 := srcB
+		ctx := _319_3
 		emitter := cff.NopEmitter()
+		interceptor := cff.InterceptorStack()
 
 		var (
 			parallelInfo = &cff.ParallelInfo{
 				File:   "go.uber.org/cff/internal/tests/parallel/parallel.go",
-				Line:   316,
+				Line:   318,
 				Column: 9,
 			}
 			directiveInfo = &cff.DirectiveInfo{
@@ -2357,7 +2829,7 @@ func SliceNoIndex(srcA, srcB, targetA, targetB []int) error {
 
 		sched := cff.NewScheduler(
 			cff.SchedulerParams{
-				Concurrency: _318_19, Emitter: schedEmitter,
+				Concurrency: _320_19, Emitter: schedEmitter,
 			},
 		)
 
@@ -2365,17 +2837,27 @@ func SliceNoIndex(srcA, srcB, targetA, targetB []int) error {
 			emitter cff.TaskEmitter
 			fn      func(context.Context) error
 			ran     cff.AtomicBool
+
+			outcome cff.TaskOutcome // reports why the task was skipped
 		}
 		defer func() {
 			for _, t := range tasks {
-				if !t.ran.Load() {
-					t.emitter.TaskSkipped(ctx, err)
+				// Tasks that are still running report their own outcome
+				// once they finish.
+				if !t.ran.Load() && !t.outcome.Running() {
+					t.emitter.TaskSkipped(ctx, t.outcome.SkipReason(ctx, err))
 				}
 			}
 		}()
 
-		// go.uber.org/cff/internal/tests/parallel/parallel.go:319:3
-		sliceTask28Slice := _324_4
+		// go.uber.org/cff/internal/tests/parallel/parallel.go:321:3
+		sliceTask28Slice := _326_4
+		sliceTask28Info := &cff.TaskInfo{
+			Name:   parallelInfo.Name,
+			File:   "go.uber.org/cff/internal/tests/parallel/parallel.go",
+			Line:   321,
+			Column: 3,
+		}
 		for _, val := range sliceTask28Slice {
 
 			val := val
@@ -2383,6 +2865,8 @@ func SliceNoIndex(srcA, srcB, targetA, targetB []int) error {
 				emitter cff.TaskEmitter
 				fn      func(context.Context) error
 				ran     cff.AtomicBool
+
+				outcome cff.TaskOutcome // reports why the task was skipped
 			})
 			sliceTask28.fn = func(ctx context.Context) (err error) {
 				defer func() {
@@ -2394,16 +2878,24 @@ func SliceNoIndex(srcA, srcB, targetA, targetB []int) error {
 						}
 					}
 				}()
-				err = _320_4(val)
-				return
+				return cff.Intercept(ctx, interceptor, sliceTask28Info, func(ctx context.Context) (err error) {
+					err = _322_4(val)
+					return
+				})
 			}
 			sched.Enqueue(ctx, cff.Job{
 				Run: sliceTask28.fn,
 			})
 		}
 
-		// go.uber.org/cff/internal/tests/parallel/parallel.go:326:3
-		sliceTask29Slice := _330_4
+		// go.uber.org/cff/internal/tests/parallel/parallel.go:328:3
+		sliceTask29Slice := _332_4
+		sliceTask29Info := &cff.TaskInfo{
+			Name:   parallelInfo.Name,
+			File:   "go.uber.org/cff/internal/tests/parallel/parallel.go",
+			Line:   328,
+			Column: 3,
+		}
 		for _, val := range sliceTask29Slice {
 
 			val := val
@@ -2411,6 +2903,8 @@ func SliceNoIndex(srcA, srcB, targetA, targetB []int) error {
 				emitter cff.TaskEmitter
 				fn      func(context.Context) error
 				ran     cff.AtomicBool
+
+				outcome cff.TaskOutcome // reports why the task was skipped
 			})
 			sliceTask29.fn = func(ctx context.Context) (err error) {
 				defer func() {
@@ -2422,8 +2916,10 @@ func SliceNoIndex(srcA, srcB, targetA, targetB []int) error {
 						}
 					}
 				}()
-				_327_4(ctx, val)
-				return
+				return cff.Intercept(ctx, interceptor, sliceTask29Info, func(ctx context.Context) (err error) {
+					_329_4(ctx, val)
+					return
+				})
 			}
 			sched.Enqueue(ctx, cff.Job{
 				Run: sliceTask29.fn,
@@ -2432,10 +2928,11 @@ func SliceNoIndex(srcA, srcB, targetA, targetB []int) error {
 
 		if err := sched.Wait(ctx); err != nil {
 			parallelEmitter.ParallelError(ctx, err)
+			cff.RethrowPanic(err, false)
 			return err
 		}
 		parallelEmitter.ParallelSuccess(ctx)
-		return nil /*line parallel.go:331*/
+		return nil /*line parallel.go:333*/
 	}()
 }
 
@@ -2445,23 +2942,24 @@ type manyInts []int
 func SliceWrapped(src, target manyInts) error {
 	return func() (err error) {
 
-		_340_3 := context.Background()
+		_342_3 := context.Background()
 
-		_341_19 := 2
+		_343_19 := 2
 
-		_343_4 := func(idx int, val int) error {
+		_345_4 := func(idx int, val int) error {
 			target[idx] = val
 			return nil
 		}
 
-		_347_4 := src
-		ctx := _340_3
+		_349_4 := src
+		ctx := _342_3
 		emitter := cff.NopEmitter()
+		interceptor := cff.InterceptorStack()
 
 		var (
 			parallelInfo = &cff.ParallelInfo{
 				File:   "go.uber.org/cff/internal/tests/parallel/parallel.go",
-				Line:   339,
+				Line:   341,
 				Column: 9,
 			}
 			directiveInfo = &cff.DirectiveInfo{
@@ -2493,7 +2991,7 @@ func SliceWrapped(src, target manyInts) error {
 
 		sched := cff.NewScheduler(
 			cff.SchedulerParams{
-				Concurrency: _341_19, Emitter: schedEmitter,
+				Concurrency: _343_19, Emitter: schedEmitter,
 			},
 		)
 
@@ -2501,17 +2999,27 @@ func SliceWrapped(src, target manyInts) error {
 			emitter cff.TaskEmitter
 			fn      func(context.Context) error
 			ran     cff.AtomicBool
+
+			outcome cff.TaskOutcome // reports why the task was skipped
 		}
 		defer func() {
 			for _, t := range tasks {
-				if !t.ran.Load() {
-					t.emitter.TaskSkipped(ctx, err)
+				// Tasks that are still running report their own outcome
+				// once they finish.
+				if !t.ran.Load() && !t.outcome.Running() {
+					t.emitter.TaskSkipped(ctx, t.outcome.SkipReason(ctx, err))
 				}
 			}
 		}()
 
-		// go.uber.org/cff/internal/tests/parallel/parallel.go:342:3
-		sliceTask30Slice := _347_4
+		// go.uber.org/cff/internal/tests/parallel/parallel.go:344:3
+		sliceTask30Slice := _349_4
+		sliceTask30Info := &cff.TaskInfo{
+			Name:   parallelInfo.Name,
+			File:   "go.uber.org/cff/internal/tests/parallel/parallel.go",
+			Line:   344,
+			Column: 3,
+		}
 		for idx, val := range sliceTask30Slice {
 			idx := idx
 			val := val
@@ -2519,6 +3027,8 @@ func SliceWrapped(src, target manyInts) error {
 				emitter cff.TaskEmitter
 				fn      func(context.Context) error
 				ran     cff.AtomicBool
+
+				outcome cff.TaskOutcome // reports why the task was skipped
 			})
 			sliceTask30.fn = func(ctx context.Context) (err error) {
 				defer func() {
@@ -2530,8 +3040,10 @@ func SliceWrapped(src, target manyInts) error {
 						}
 					}
 				}()
-				err = _343_4(idx, val)
-				return
+				return cff.Intercept(ctx, interceptor, sliceTask30Info, func(ctx context.Context) (err error) {
+					err = _345_4(idx, val)
+					return
+				})
 			}
 			sched.Enqueue(ctx, cff.Job{
 				Run: sliceTask30.fn,
@@ -2540,10 +3052,11 @@ func SliceWrapped(src, target manyInts) error {
 
 		if err := sched.Wait(ctx); err != nil {
 			parallelEmitter.ParallelError(ctx, err)
+			cff.RethrowPanic(err, false)
 			return err
 		}
 		parallelEmitter.ParallelSuccess(ctx)
-		return nil /*line parallel.go:348*/
+		return nil /*line parallel.go:350*/
 	}()
 }
 
@@ -2552,13 +3065,13 @@ func SliceWrapped(src, target manyInts) error {
 func AssignSliceItems(src, target []string, keepgoing bool) error {
 	return func() (err error) {
 
-		_356_3 := context.Background()
+		_358_3 := context.Background()
 
-		_357_19 := 2
+		_359_19 := 2
 
-		_358_23 := keepgoing
+		_360_23 := keepgoing
 
-		_360_4 := func(idx int, val string) error {
+		_362_4 := func(idx int, val string) error {
 			target[idx] = val
 			switch val {
 			case "error":
@@ -2570,14 +3083,15 @@ func AssignSliceItems(src, target []string, keepgoing bool) error {
 			}
 		}
 
-		_371_4 := src
-		ctx := _356_3
+		_373_4 := src
+		ctx := _358_3
 		emitter := cff.NopEmitter()
+		interceptor := cff.InterceptorStack()
 
 		var (
 			parallelInfo = &cff.ParallelInfo{
 				File:   "go.uber.org/cff/internal/tests/parallel/parallel.go",
-				Line:   355,
+				Line:   357,
 				Column: 9,
 			}
 			directiveInfo = &cff.DirectiveInfo{
@@ -2609,8 +3123,8 @@ func AssignSliceItems(src, target []string, keepgoing bool) error {
 
 		sched := cff.NewScheduler(
 			cff.SchedulerParams{
-				Concurrency: _357_19, Emitter: schedEmitter,
-				ContinueOnError: _358_23,
+				Concurrency: _359_19, Emitter: schedEmitter,
+				ContinueOnError: _360_23,
 			},
 		)
 
@@ -2618,17 +3132,27 @@ func AssignSliceItems(src, target []string, keepgoing bool) error {
 			emitter cff.TaskEmitter
 			fn      func(context.Context) error
 			ran     cff.AtomicBool
+
+			outcome cff.TaskOutcome // reports why the task was skipped
 		}
 		defer func() {
 			for _, t := range tasks {
-				if !t.ran.Load() {
-					t.emitter.TaskSkipped(ctx, err)
+				// Tasks that are still running report their own outcome
+				// once they finish.
+				if !t.ran.Load() && !t.outcome.Running() {
+					t.emitter.TaskSkipped(ctx, t.outcome.SkipReason(ctx, err))
 				}
 			}
 		}()
 
-		// go.uber.org/cff/internal/tests/parallel/parallel.go:359:3
-		sliceTask31Slice := _371_4
+		// go.uber.org/cff/internal/tests/parallel/parallel.go:361:3
+		sliceTask31Slice := _373_4
+		sliceTask31Info := &cff.TaskInfo{
+			Name:   parallelInfo.Name,
+			File:   "go.uber.org/cff/internal/tests/parallel/parallel.go",
+			Line:   361,
+			Column: 3,
+		}
 		for idx, val := range sliceTask31Slice {
 			idx := idx
 			val := val
@@ -2636,6 +3160,8 @@ func AssignSliceItems(src, target []string, keepgoing bool) error {
 				emitter cff.TaskEmitter
 				fn      func(context.Context) error
 				ran     cff.AtomicBool
+
+				outcome cff.TaskOutcome // reports why the task was skipped
 			})
 			sliceTask31.fn = func(ctx context.Context) (err error) {
 				defer func() {
@@ -2647,8 +3173,10 @@ func AssignSliceItems(src, target []string, keepgoing bool) error {
 						}
 					}
 				}()
-				err = _360_4(idx, val)
-				return
+				return cff.Intercept(ctx, interceptor, sliceTask31Info, func(ctx context.Context) (err error) {
+					err = _362_4(idx, val)
+					return
+				})
 			}
 			sched.Enqueue(ctx, cff.Job{
 				Run: sliceTask31.fn,
@@ -2657,10 +3185,11 @@ func AssignSliceItems(src, target []string, keepgoing bool) error {
 
 		if err := sched.Wait(ctx); err != nil {
 			parallelEmitter.ParallelError(ctx, err)
+			cff.RethrowPanic(err, false)
 			return err
 		}
 		parallelEmitter.ParallelSuccess(ctx)
-		return nil /*line parallel.go:372*/
+		return nil /*line parallel.go:374*/
 	}()
 }
 
@@ -2669,22 +3198,23 @@ func AssignSliceItems(src, target []string, keepgoing bool) error {
 func SliceEnd(src []int, sliceFn func(idx, val int) error, sliceEndFn func()) (err error) {
 	err = func() (err error) {
 
-		_380_3 := context.Background()
+		_382_3 := context.Background()
 
-		_381_19 := 2
+		_383_19 := 2
 
-		_383_4 := sliceFn
+		_385_4 := sliceFn
 
-		_384_4 := src
+		_386_4 := src
 
-		_385_17 := sliceEndFn
-		ctx := _380_3
+		_387_17 := sliceEndFn
+		ctx := _382_3
 		emitter := cff.NopEmitter()
+		interceptor := cff.InterceptorStack()
 
 		var (
 			parallelInfo = &cff.ParallelInfo{
 				File:   "go.uber.org/cff/internal/tests/parallel/parallel.go",
-				Line:   379,
+				Line:   381,
 				Column: 8,
 			}
 			directiveInfo = &cff.DirectiveInfo{
@@ -2716,7 +3246,7 @@ func SliceEnd(src []int, sliceFn func(idx, val int) error, sliceEndFn func()) (e
 
 		sched := cff.NewScheduler(
 			cff.SchedulerParams{
-				Concurrency: _381_19, Emitter: schedEmitter,
+				Concurrency: _383_19, Emitter: schedEmitter,
 			},
 		)
 
@@ -2724,17 +3254,27 @@ func SliceEnd(src []int, sliceFn func(idx, val int) error, sliceEndFn func()) (e
 			emitter cff.TaskEmitter
 			fn      func(context.Context) error
 			ran     cff.AtomicBool
+
+			outcome cff.TaskOutcome // reports why the task was skipped
 		}
 		defer func() {
 			for _, t := range tasks {
-				if !t.ran.Load() {
-					t.emitter.TaskSkipped(ctx, err)
+				// Tasks that are still running report their own outcome
+				// once they finish.
+				if !t.ran.Load() && !t.outcome.Running() {
+					t.emitter.TaskSkipped(ctx, t.outcome.SkipReason(ctx, err))
 				}
 			}
 		}()
 
-		// go.uber.org/cff/internal/tests/parallel/parallel.go:382:3
-		sliceTask32Slice := _384_4
+		// go.uber.org/cff/internal/tests/parallel/parallel.go:384:3
+		sliceTask32Slice := _386_4
+		sliceTask32Info := &cff.TaskInfo{
+			Name:   parallelInfo.Name,
+			File:   "go.uber.org/cff/internal/tests/parallel/parallel.go",
+			Line:   384,
+			Column: 3,
+		}
 		sliceTask32Jobs := make([]*cff.ScheduledJob, len(sliceTask32Slice))
 		for idx, val := range sliceTask32Slice {
 			idx := idx
@@ -2743,6 +3283,8 @@ func SliceEnd(src []int, sliceFn func(idx, val int) error, sliceEndFn func()) (e
 				emitter cff.TaskEmitter
 				fn      func(context.Context) error
 				ran     cff.AtomicBool
+
+				outcome cff.TaskOutcome // reports why the task was skipped
 			})
 			sliceTask32.fn = func(ctx context.Context) (err error) {
 				defer func() {
@@ -2754,8 +3296,10 @@ func SliceEnd(src []int, sliceFn func(idx, val int) error, sliceEndFn func()) (e
 						}
 					}
 				}()
-				err = _383_4(idx, val)
-				return
+				return cff.Intercept(ctx, interceptor, sliceTask32Info, func(ctx context.Context) (err error) {
+					err = _385_4(idx, val)
+					return
+				})
 			}
 			sliceTask32Jobs[idx] = sched.Enqueue(ctx, cff.Job{
 				Run: sliceTask32.fn,
@@ -2764,6 +3308,7 @@ func SliceEnd(src []int, sliceFn func(idx, val int) error, sliceEndFn func()) (e
 
 		sched.Enqueue(ctx, cff.Job{
 			Dependencies: sliceTask32Jobs,
+			AlwaysRun:    true,
 			Run: func(ctx context.Context) (err error) {
 				defer func() {
 					recovered := recover()
@@ -2775,17 +3320,18 @@ func SliceEnd(src []int, sliceFn func(idx, val int) error, sliceEndFn func()) (e
 					}
 				}()
 
-				_385_17()
+				_387_17()
 				return
 			},
 		})
 
 		if err := sched.Wait(ctx); err != nil {
 			parallelEmitter.ParallelError(ctx, err)
+			cff.RethrowPanic(err, false)
 			return err
 		}
 		parallelEmitter.ParallelSuccess(ctx)
-		return nil /*line parallel.go:386*/
+		return nil /*line parallel.go:388*/
 	}()
 	return err
 }
@@ -2795,22 +3341,23 @@ func SliceEnd(src []int, sliceFn func(idx, val int) error, sliceEndFn func()) (e
 func SliceEndWithErr(src []int, sliceFn func(idx, val int) error, sliceEndFn func() error) (err error) {
 	err = func() (err error) {
 
-		_395_3 := context.Background()
+		_397_3 := context.Background()
 
-		_396_19 := 2
+		_398_19 := 2
 
-		_398_4 := sliceFn
+		_400_4 := sliceFn
 
-		_399_4 := src
+		_401_4 := src
 
-		_400_17 := sliceEndFn
-		ctx := _395_3
+		_402_17 := sliceEndFn
+		ctx := _397_3
 		emitter := cff.NopEmitter()
+		interceptor := cff.InterceptorStack()
 
 		var (
 			parallelInfo = &cff.ParallelInfo{
 				File:   "go.uber.org/cff/internal/tests/parallel/parallel.go",
-				Line:   394,
+				Line:   396,
 				Column: 8,
 			}
 			directiveInfo = &cff.DirectiveInfo{
@@ -2842,7 +3389,7 @@ func SliceEndWithErr(src []int, sliceFn func(idx, val int) error, sliceEndFn fun
 
 		sched := cff.NewScheduler(
 			cff.SchedulerParams{
-				Concurrency: _396_19, Emitter: schedEmitter,
+				Concurrency: _398_19, Emitter: schedEmitter,
 			},
 		)
 
@@ -2850,17 +3397,27 @@ func SliceEndWithErr(src []int, sliceFn func(idx, val int) error, sliceEndFn fun
 			emitter cff.TaskEmitter
 			fn      func(context.Context) error
 			ran     cff.AtomicBool
+
+			outcome cff.TaskOutcome // reports why the task was skipped
 		}
 		defer func() {
 			for _, t := range tasks {
-				if !t.ran.Load() {
-					t.emitter.TaskSkipped(ctx, err)
+				// Tasks that are still running report their own outcome
+				// once they finish.
+				if !t.ran.Load() && !t.outcome.Running() {
+					t.emitter.TaskSkipped(ctx, t.outcome.SkipReason(ctx, err))
 				}
 			}
 		}()
 
-		// go.uber.org/cff/internal/tests/parallel/parallel.go:397:3
-		sliceTask33Slice := _399_4
+		// go.uber.org/cff/internal/tests/parallel/parallel.go:399:3
+		sliceTask33Slice := _401_4
+		sliceTask33Info := &cff.TaskInfo{
+			Name:   parallelInfo.Name,
+			File:   "go.uber.org/cff/internal/tests/parallel/parallel.go",
+			Line:   399,
+			Column: 3,
+		}
 		sliceTask33Jobs := make([]*cff.ScheduledJob, len(sliceTask33Slice))
 		for idx, val := range sliceTask33Slice {
 			idx := idx
@@ -2869,6 +3426,8 @@ func SliceEndWithErr(src []int, sliceFn func(idx, val int) error, sliceEndFn fun
 				emitter cff.TaskEmitter
 				fn      func(context.Context) error
 				ran     cff.AtomicBool
+
+				outcome cff.TaskOutcome // reports why the task was skipped
 			})
 			sliceTask33.fn = func(ctx context.Context) (err error) {
 				defer func() {
@@ -2880,8 +3439,10 @@ func SliceEndWithErr(src []int, sliceFn func(idx, val int) error, sliceEndFn fun
 						}
 					}
 				}()
-				err = _398_4(idx, val)
-				return
+				return cff.Intercept(ctx, interceptor, sliceTask33Info, func(ctx context.Context) (err error) {
+					err = _400_4(idx, val)
+					return
+				})
 			}
 			sliceTask33Jobs[idx] = sched.Enqueue(ctx, cff.Job{
 				Run: sliceTask33.fn,
@@ -2890,6 +3451,7 @@ func SliceEndWithErr(src []int, sliceFn func(idx, val int) error, sliceEndFn fun
 
 		sched.Enqueue(ctx, cff.Job{
 			Dependencies: sliceTask33Jobs,
+			AlwaysRun:    true,
 			Run: func(ctx context.Context) (err error) {
 				defer func() {
 					recovered := recover()
@@ -2901,17 +3463,18 @@ func SliceEndWithErr(src []int, sliceFn func(idx, val int) error, sliceEndFn fun
 					}
 				}()
 
-				err = _400_17()
+				err = _402_17()
 				return
 			},
 		})
 
 		if err := sched.Wait(ctx); err != nil {
 			parallelEmitter.ParallelError(ctx, err)
+			cff.RethrowPanic(err, false)
 			return err
 		}
 		parallelEmitter.ParallelSuccess(ctx)
-		return nil /*line parallel.go:401*/
+		return nil /*line parallel.go:403*/
 	}()
 	return err
 }
@@ -2921,22 +3484,23 @@ func SliceEndWithErr(src []int, sliceFn func(idx, val int) error, sliceEndFn fun
 func SliceEndWithCtx(src []int, sliceFn func(idx, val int) error, sliceEndFn func(context.Context)) (err error) {
 	err = func() (err error) {
 
-		_410_3 := context.Background()
+		_412_3 := context.Background()
 
-		_411_19 := 2
+		_413_19 := 2
 
-		_413_4 := sliceFn
+		_415_4 := sliceFn
 
-		_414_4 := src
+		_416_4 := src
 
-		_415_17 := sliceEndFn
-		ctx := _410_3
+		_417_17 := sliceEndFn
+		ctx := _412_3
 		emitter := cff.NopEmitter()
+		interceptor := cff.InterceptorStack()
 
 		var (
 			parallelInfo = &cff.ParallelInfo{
 				File:   "go.uber.org/cff/internal/tests/parallel/parallel.go",
-				Line:   409,
+				Line:   411,
 				Column: 8,
 			}
 			directiveInfo = &cff.DirectiveInfo{
@@ -2968,7 +3532,7 @@ func SliceEndWithCtx(src []int, sliceFn func(idx, val int) error, sliceEndFn fun
 
 		sched := cff.NewScheduler(
 			cff.SchedulerParams{
-				Concurrency: _411_19, Emitter: schedEmitter,
+				Concurrency: _413_19, Emitter: schedEmitter,
 			},
 		)
 
@@ -2976,17 +3540,27 @@ func SliceEndWithCtx(src []int, sliceFn func(idx, val int) error, sliceEndFn fun
 			emitter cff.TaskEmitter
 			fn      func(context.Context) error
 			ran     cff.AtomicBool
+
+			outcome cff.TaskOutcome // reports why the task was skipped
 		}
 		defer func() {
 			for _, t := range tasks {
-				if !t.ran.Load() {
-					t.emitter.TaskSkipped(ctx, err)
+				// Tasks that are still running report their own outcome
+				// once they finish.
+				if !t.ran.Load() && !t.outcome.Running() {
+					t.emitter.TaskSkipped(ctx, t.outcome.SkipReason(ctx, err))
 				}
 			}
 		}()
 
-		// go.uber.org/cff/internal/tests/parallel/parallel.go:412:3
-		sliceTask34Slice := _414_4
+		// go.uber.org/cff/internal/tests/parallel/parallel.go:414:3
+		sliceTask34Slice := _416_4
+		sliceTask34Info := &cff.TaskInfo{
+			Name:   parallelInfo.Name,
+			File:   "go.uber.org/cff/internal/tests/parallel/parallel.go",
+			Line:   414,
+			Column: 3,
+		}
 		sliceTask34Jobs := make([]*cff.ScheduledJob, len(sliceTask34Slice))
 		for idx, val := range sliceTask34Slice {
 			idx := idx
@@ -2995,6 +3569,8 @@ func SliceEndWithCtx(src []int, sliceFn func(idx, val int) error, sliceEndFn fun
 				emitter cff.TaskEmitter
 				fn      func(context.Context) error
 				ran     cff.AtomicBool
+
+				outcome cff.TaskOutcome // reports why the task was skipped
 			})
 			sliceTask34.fn = func(ctx context.Context) (err error) {
 				defer func() {
@@ -3006,17 +3582,1042 @@ func SliceEndWithCtx(src []int, sliceFn func(idx, val int) error, sliceEndFn fun
 						}
 					}
 				}()
-				err = _413_4(idx, val)
+				return cff.Intercept(ctx, interceptor, sliceTask34Info, func(ctx context.Context) (err error) {
+					err = _415_4(idx, val)
+					return
+				})
+			}
+			sliceTask34Jobs[idx] = sched.Enqueue(ctx, cff.Job{
+				Run: sliceTask34.fn,
+			})
+		}
+
+		sched.Enqueue(ctx, cff.Job{
+			Dependencies: sliceTask34Jobs,
+			AlwaysRun:    true,
+			Run: func(ctx context.Context) (err error) {
+				defer func() {
+					recovered := recover()
+					if recovered != nil {
+						err = &cff.PanicError{
+							Value:      recovered,
+							Stacktrace: debug.Stack(),
+						}
+					}
+				}()
+
+				_417_17(ctx)
+				return
+			},
+		})
+
+		if err := sched.Wait(ctx); err != nil {
+			parallelEmitter.ParallelError(ctx, err)
+			cff.RethrowPanic(err, false)
+			return err
+		}
+		parallelEmitter.ParallelSuccess(ctx)
+		return nil /*line parallel.go:418*/
+	}()
+	return err
+}
+
+// SliceEndWithCtxAndErr runs cff.Slice in parallel and calls an erroring sliceEndFn
+// after all items in the slice have finished.
+func SliceEndWithCtxAndErr(src []int, sliceFn func(idx, val int) error, sliceEndFn func(context.Context) error) (err error) {
+	err = func() (err error) {
+
+		_427_3 := context.Background()
+
+		_428_19 := 2
+
+		_430_4 := sliceFn
+
+		_431_4 := src
+
+		_432_17 := sliceEndFn
+		ctx := _427_3
+		emitter := cff.NopEmitter()
+		interceptor := cff.InterceptorStack()
+
+		var (
+			parallelInfo = &cff.ParallelInfo{
+				File:   "go.uber.org/cff/internal/tests/parallel/parallel.go",
+				Line:   426,
+				Column: 8,
+			}
+			directiveInfo = &cff.DirectiveInfo{
+				Name:      parallelInfo.Name,
+				Directive: cff.ParallelDirective,
+				File:      parallelInfo.File,
+				Line:      parallelInfo.Line,
+				Column:    parallelInfo.Column,
+			}
+			parallelEmitter = cff.NopParallelEmitter()
+
+			schedInfo = &cff.SchedulerInfo{
+				Name:      parallelInfo.Name,
+				Directive: cff.ParallelDirective,
+				File:      parallelInfo.File,
+				Line:      parallelInfo.Line,
+				Column:    parallelInfo.Column,
+			}
+
+			// possibly unused
+			_ = parallelInfo
+			_ = directiveInfo
+		)
+
+		startTime := time.Now()
+		defer func() { parallelEmitter.ParallelDone(ctx, time.Since(startTime)) }()
+
+		schedEmitter := emitter.SchedulerInit(schedInfo)
+
+		sched := cff.NewScheduler(
+			cff.SchedulerParams{
+				Concurrency: _428_19, Emitter: schedEmitter,
+			},
+		)
+
+		var tasks []*struct {
+			emitter cff.TaskEmitter
+			fn      func(context.Context) error
+			ran     cff.AtomicBool
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		}
+		defer func() {
+			for _, t := range tasks {
+				// Tasks that are still running report their own outcome
+				// once they finish.
+				if !t.ran.Load() && !t.outcome.Running() {
+					t.emitter.TaskSkipped(ctx, t.outcome.SkipReason(ctx, err))
+				}
+			}
+		}()
+
+		// go.uber.org/cff/internal/tests/parallel/parallel.go:429:3
+		sliceTask35Slice := _431_4
+		sliceTask35Info := &cff.TaskInfo{
+			Name:   parallelInfo.Name,
+			File:   "go.uber.org/cff/internal/tests/parallel/parallel.go",
+			Line:   429,
+			Column: 3,
+		}
+		sliceTask35Jobs := make([]*cff.ScheduledJob, len(sliceTask35Slice))
+		for idx, val := range sliceTask35Slice {
+			idx := idx
+			val := val
+			sliceTask35 := new(struct {
+				emitter cff.TaskEmitter
+				fn      func(context.Context) error
+				ran     cff.AtomicBool
+
+				outcome cff.TaskOutcome // reports why the task was skipped
+			})
+			sliceTask35.fn = func(ctx context.Context) (err error) {
+				defer func() {
+					recovered := recover()
+					if recovered != nil {
+						err = &cff.PanicError{
+							Value:      recovered,
+							Stacktrace: debug.Stack(),
+						}
+					}
+				}()
+				return cff.Intercept(ctx, interceptor, sliceTask35Info, func(ctx context.Context) (err error) {
+					err = _430_4(idx, val)
+					return
+				})
+			}
+			sliceTask35Jobs[idx] = sched.Enqueue(ctx, cff.Job{
+				Run: sliceTask35.fn,
+			})
+		}
+
+		sched.Enqueue(ctx, cff.Job{
+			Dependencies: sliceTask35Jobs,
+			AlwaysRun:    true,
+			Run: func(ctx context.Context) (err error) {
+				defer func() {
+					recovered := recover()
+					if recovered != nil {
+						err = &cff.PanicError{
+							Value:      recovered,
+							Stacktrace: debug.Stack(),
+						}
+					}
+				}()
+
+				err = _432_17(ctx)
+				return
+			},
+		})
+
+		if err := sched.Wait(ctx); err != nil {
+			parallelEmitter.ParallelError(ctx, err)
+			cff.RethrowPanic(err, false)
+			return err
+		}
+		parallelEmitter.ParallelSuccess(ctx)
+		return nil /*line parallel.go:433*/
+	}()
+	return err
+}
+
+// SliceEndContinueOnError runs cff.Slice in parallel with ContinueOnError
+// and calls sliceEndFn after all items in the slice were attempted.
+func SliceEndContinueOnError(src []int, sliceFn func(idx, val int) error, sliceEndFn func()) (err error) {
+	err = func() (err error) {
+
+		_442_3 := context.Background()
+
+		_443_19 := 2
+
+		_444_23 := true
+
+		_446_4 := sliceFn
+
+		_447_4 := src
+
+		_448_17 := sliceEndFn
+		ctx := _442_3
+		emitter := cff.NopEmitter()
+		interceptor := cff.InterceptorStack()
+
+		var (
+			parallelInfo = &cff.ParallelInfo{
+				File:   "go.uber.org/cff/internal/tests/parallel/parallel.go",
+				Line:   441,
+				Column: 8,
+			}
+			directiveInfo = &cff.DirectiveInfo{
+				Name:      parallelInfo.Name,
+				Directive: cff.ParallelDirective,
+				File:      parallelInfo.File,
+				Line:      parallelInfo.Line,
+				Column:    parallelInfo.Column,
+			}
+			parallelEmitter = cff.NopParallelEmitter()
+
+			schedInfo = &cff.SchedulerInfo{
+				Name:      parallelInfo.Name,
+				Directive: cff.ParallelDirective,
+				File:      parallelInfo.File,
+				Line:      parallelInfo.Line,
+				Column:    parallelInfo.Column,
+			}
+
+			// possibly unused
+			_ = parallelInfo
+			_ = directiveInfo
+		)
+
+		startTime := time.Now()
+		defer func() { parallelEmitter.ParallelDone(ctx, time.Since(startTime)) }()
+
+		schedEmitter := emitter.SchedulerInit(schedInfo)
+
+		sched := cff.NewScheduler(
+			cff.SchedulerParams{
+				Concurrency: _443_19, Emitter: schedEmitter,
+				ContinueOnError: _444_23,
+			},
+		)
+
+		var tasks []*struct {
+			emitter cff.TaskEmitter
+			fn      func(context.Context) error
+			ran     cff.AtomicBool
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		}
+		defer func() {
+			for _, t := range tasks {
+				// Tasks that are still running report their own outcome
+				// once they finish.
+				if !t.ran.Load() && !t.outcome.Running() {
+					t.emitter.TaskSkipped(ctx, t.outcome.SkipReason(ctx, err))
+				}
+			}
+		}()
+
+		// go.uber.org/cff/internal/tests/parallel/parallel.go:445:3
+		sliceTask36Slice := _447_4
+		sliceTask36Info := &cff.TaskInfo{
+			Name:   parallelInfo.Name,
+			File:   "go.uber.org/cff/internal/tests/parallel/parallel.go",
+			Line:   445,
+			Column: 3,
+		}
+		sliceTask36Jobs := make([]*cff.ScheduledJob, len(sliceTask36Slice))
+		for idx, val := range sliceTask36Slice {
+			idx := idx
+			val := val
+			sliceTask36 := new(struct {
+				emitter cff.TaskEmitter
+				fn      func(context.Context) error
+				ran     cff.AtomicBool
+
+				outcome cff.TaskOutcome // reports why the task was skipped
+			})
+			sliceTask36.fn = func(ctx context.Context) (err error) {
+				defer func() {
+					recovered := recover()
+					if recovered != nil {
+						err = &cff.PanicError{
+							Value:      recovered,
+							Stacktrace: debug.Stack(),
+						}
+					}
+				}()
+				return cff.Intercept(ctx, interceptor, sliceTask36Info, func(ctx context.Context) (err error) {
+					err = _446_4(idx, val)
+					return
+				})
+			}
+			sliceTask36Jobs[idx] = sched.Enqueue(ctx, cff.Job{
+				Run: sliceTask36.fn,
+			})
+		}
+
+		sched.Enqueue(ctx, cff.Job{
+			Dependencies: sliceTask36Jobs,
+			AlwaysRun:    true,
+			Run: func(ctx context.Context) (err error) {
+				defer func() {
+					recovered := recover()
+					if recovered != nil {
+						err = &cff.PanicError{
+							Value:      recovered,
+							Stacktrace: debug.Stack(),
+						}
+					}
+				}()
+
+				_448_17()
+				return
+			},
+		})
+
+		if err := sched.Wait(ctx); err != nil {
+			parallelEmitter.ParallelError(ctx, err)
+			cff.RethrowPanic(err, false)
+			return err
+		}
+		parallelEmitter.ParallelSuccess(ctx)
+		return nil /*line parallel.go:449*/
+	}()
+	return err
+}
+
+// SliceEndElementErrors runs cff.Slice in parallel and passes the errors of
+// the items that failed to sliceEndFn.
+func SliceEndElementErrors(
+	src []int,
+	sliceFn func(idx, val int) error,
+	sliceEndFn func(context.Context, cff.ElementErrors[int]) error,
+) (err error) {
+	err = func() (err error) {
+
+		_462_3 := context.Background()
+
+		_463_19 := 2
+
+		_465_4 := sliceFn
+
+		_466_4 := src
+
+		_467_17 := sliceEndFn
+		ctx := _462_3
+		emitter := cff.NopEmitter()
+		interceptor := cff.InterceptorStack()
+
+		var (
+			parallelInfo = &cff.ParallelInfo{
+				File:   "go.uber.org/cff/internal/tests/parallel/parallel.go",
+				Line:   461,
+				Column: 8,
+			}
+			directiveInfo = &cff.DirectiveInfo{
+				Name:      parallelInfo.Name,
+				Directive: cff.ParallelDirective,
+				File:      parallelInfo.File,
+				Line:      parallelInfo.Line,
+				Column:    parallelInfo.Column,
+			}
+			parallelEmitter = cff.NopParallelEmitter()
+
+			schedInfo = &cff.SchedulerInfo{
+				Name:      parallelInfo.Name,
+				Directive: cff.ParallelDirective,
+				File:      parallelInfo.File,
+				Line:      parallelInfo.Line,
+				Column:    parallelInfo.Column,
+			}
+
+			// possibly unused
+			_ = parallelInfo
+			_ = directiveInfo
+		)
+
+		startTime := time.Now()
+		defer func() { parallelEmitter.ParallelDone(ctx, time.Since(startTime)) }()
+
+		schedEmitter := emitter.SchedulerInit(schedInfo)
+
+		sched := cff.NewScheduler(
+			cff.SchedulerParams{
+				Concurrency: _463_19, Emitter: schedEmitter,
+			},
+		)
+
+		var tasks []*struct {
+			emitter cff.TaskEmitter
+			fn      func(context.Context) error
+			ran     cff.AtomicBool
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		}
+		defer func() {
+			for _, t := range tasks {
+				// Tasks that are still running report their own outcome
+				// once they finish.
+				if !t.ran.Load() && !t.outcome.Running() {
+					t.emitter.TaskSkipped(ctx, t.outcome.SkipReason(ctx, err))
+				}
+			}
+		}()
+
+		// go.uber.org/cff/internal/tests/parallel/parallel.go:464:3
+		sliceTask37Slice := _466_4
+		// Errors of elements, passed to the SliceEnd function.
+		var (
+			sliceTask37ErrsMu sync.Mutex
+			sliceTask37Errs   = make(cff.ElementErrors[int])
+		)
+		sliceTask37Info := &cff.TaskInfo{
+			Name:   parallelInfo.Name,
+			File:   "go.uber.org/cff/internal/tests/parallel/parallel.go",
+			Line:   464,
+			Column: 3,
+		}
+		sliceTask37Jobs := make([]*cff.ScheduledJob, len(sliceTask37Slice))
+		for idx, val := range sliceTask37Slice {
+			idx := idx
+			val := val
+			sliceTask37 := new(struct {
+				emitter cff.TaskEmitter
+				fn      func(context.Context) error
+				ran     cff.AtomicBool
+
+				outcome cff.TaskOutcome // reports why the task was skipped
+			})
+			sliceTask37.fn = func(ctx context.Context) (err error) {
+				defer func() {
+					recovered := recover()
+					if recovered != nil {
+						err = &cff.PanicError{
+							Value:      recovered,
+							Stacktrace: debug.Stack(),
+						}
+					}
+					if err != nil {
+						sliceTask37ErrsMu.Lock()
+						sliceTask37Errs[idx] = err
+						sliceTask37ErrsMu.Unlock()
+						err = nil
+					}
+				}()
+				return cff.Intercept(ctx, interceptor, sliceTask37Info, func(ctx context.Context) (err error) {
+					err = _465_4(idx, val)
+					return
+				})
+			}
+			sliceTask37Jobs[idx] = sched.Enqueue(ctx, cff.Job{
+				Run: sliceTask37.fn,
+			})
+		}
+
+		sched.Enqueue(ctx, cff.Job{
+			Dependencies: sliceTask37Jobs,
+			AlwaysRun:    true,
+			Run: func(ctx context.Context) (err error) {
+				defer func() {
+					recovered := recover()
+					if recovered != nil {
+						err = &cff.PanicError{
+							Value:      recovered,
+							Stacktrace: debug.Stack(),
+						}
+					}
+				}()
+
+				err = _467_17(ctx, sliceTask37Errs)
+				return
+			},
+		})
+
+		if err := sched.Wait(ctx); err != nil {
+			parallelEmitter.ParallelError(ctx, err)
+			cff.RethrowPanic(err, false)
+			return err
+		}
+		parallelEmitter.ParallelSuccess(ctx)
+		return nil /*line parallel.go:468*/
+	}()
+	return err
+}
+
+// SliceBatch runs cff.Slice in parallel on batches of the given size,
+// doubling the values of src into target.
+func SliceBatch(src, target []int, size int) error {
+	return func() (err error) {
+
+		_477_3 := context.Background()
+
+		_478_19 := 2
+
+		_480_4 := func(idx, val int) {
+			target[idx] = val * 2
+		}
+
+		_483_4 := src
+
+		_484_14 := size
+		ctx := _477_3
+		emitter := cff.NopEmitter()
+		interceptor := cff.InterceptorStack()
+
+		var (
+			parallelInfo = &cff.ParallelInfo{
+				File:   "go.uber.org/cff/internal/tests/parallel/parallel.go",
+				Line:   476,
+				Column: 9,
+			}
+			directiveInfo = &cff.DirectiveInfo{
+				Name:      parallelInfo.Name,
+				Directive: cff.ParallelDirective,
+				File:      parallelInfo.File,
+				Line:      parallelInfo.Line,
+				Column:    parallelInfo.Column,
+			}
+			parallelEmitter = cff.NopParallelEmitter()
+
+			schedInfo = &cff.SchedulerInfo{
+				Name:      parallelInfo.Name,
+				Directive: cff.ParallelDirective,
+				File:      parallelInfo.File,
+				Line:      parallelInfo.Line,
+				Column:    parallelInfo.Column,
+			}
+
+			// possibly unused
+			_ = parallelInfo
+			_ = directiveInfo
+		)
+
+		startTime := time.Now()
+		defer func() { parallelEmitter.ParallelDone(ctx, time.Since(startTime)) }()
+
+		schedEmitter := emitter.SchedulerInit(schedInfo)
+
+		sched := cff.NewScheduler(
+			cff.SchedulerParams{
+				Concurrency: _478_19, Emitter: schedEmitter,
+			},
+		)
+
+		var tasks []*struct {
+			emitter cff.TaskEmitter
+			fn      func(context.Context) error
+			ran     cff.AtomicBool
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		}
+		defer func() {
+			for _, t := range tasks {
+				// Tasks that are still running report their own outcome
+				// once they finish.
+				if !t.ran.Load() && !t.outcome.Running() {
+					t.emitter.TaskSkipped(ctx, t.outcome.SkipReason(ctx, err))
+				}
+			}
+		}()
+
+		// go.uber.org/cff/internal/tests/parallel/parallel.go:479:3
+		sliceTask38Slice := _483_4
+		sliceTask38Info := &cff.TaskInfo{
+			Name:   parallelInfo.Name,
+			File:   "go.uber.org/cff/internal/tests/parallel/parallel.go",
+			Line:   479,
+			Column: 3,
+		}
+		sliceTask38Fn := func(ctx context.Context, idx int, val int) (err error) {
+			defer func() {
+				recovered := recover()
+				if recovered != nil {
+					err = &cff.PanicError{
+						Value:      recovered,
+						Stacktrace: debug.Stack(),
+					}
+				}
+			}()
+			return cff.Intercept(ctx, interceptor, sliceTask38Info, func(ctx context.Context) (err error) {
+				_480_4(idx, val)
+				return
+			})
+		}
+		sliceTask38Batch := _484_14
+		if sliceTask38Batch < 1 {
+			sliceTask38Batch = 1
+		}
+		for start := 0; start < len(sliceTask38Slice); start += sliceTask38Batch {
+			start, end := start, start+sliceTask38Batch
+			if end > len(sliceTask38Slice) {
+				end = len(sliceTask38Slice)
+			}
+			sched.Enqueue(ctx, cff.Job{
+				Run: func(ctx context.Context) (err error) {
+					for idx := start; idx < end; idx++ {
+						if elemErr := sliceTask38Fn(ctx, idx, sliceTask38Slice[idx]); elemErr != nil {
+							err = cff.AppendError(err, elemErr)
+							return
+						}
+					}
+					return
+				},
+			})
+		}
+
+		if err := sched.Wait(ctx); err != nil {
+			parallelEmitter.ParallelError(ctx, err)
+			cff.RethrowPanic(err, false)
+			return err
+		}
+		parallelEmitter.ParallelSuccess(ctx)
+		return nil /*line parallel.go:485*/
+	}()
+}
+
+// SliceBatchContinueOnError runs cff.Slice in parallel on batches of the
+// given size, failing for negative values.
+// It records the indexes of the elements that were attempted.
+func SliceBatchContinueOnError(src []int, size int, keepgoing bool, attempted *sync.Map) error {
+	return func() (err error) {
+
+		_494_3 := context.Background()
+
+		_495_19 := 2
+
+		_496_23 := keepgoing
+
+		_498_4 := func(idx, val int) error {
+			attempted.Store(idx, struct{}{})
+			if val < 0 {
+				return fmt.Errorf("negative value at %v", idx)
+			}
+			return nil
+		}
+
+		_505_4 := src
+
+		_506_14 := size
+		ctx := _494_3
+		emitter := cff.NopEmitter()
+		interceptor := cff.InterceptorStack()
+
+		var (
+			parallelInfo = &cff.ParallelInfo{
+				File:   "go.uber.org/cff/internal/tests/parallel/parallel.go",
+				Line:   493,
+				Column: 9,
+			}
+			directiveInfo = &cff.DirectiveInfo{
+				Name:      parallelInfo.Name,
+				Directive: cff.ParallelDirective,
+				File:      parallelInfo.File,
+				Line:      parallelInfo.Line,
+				Column:    parallelInfo.Column,
+			}
+			parallelEmitter = cff.NopParallelEmitter()
+
+			schedInfo = &cff.SchedulerInfo{
+				Name:      parallelInfo.Name,
+				Directive: cff.ParallelDirective,
+				File:      parallelInfo.File,
+				Line:      parallelInfo.Line,
+				Column:    parallelInfo.Column,
+			}
+
+			// possibly unused
+			_ = parallelInfo
+			_ = directiveInfo
+		)
+
+		startTime := time.Now()
+		defer func() { parallelEmitter.ParallelDone(ctx, time.Since(startTime)) }()
+
+		schedEmitter := emitter.SchedulerInit(schedInfo)
+
+		sched := cff.NewScheduler(
+			cff.SchedulerParams{
+				Concurrency: _495_19, Emitter: schedEmitter,
+				ContinueOnError: _496_23,
+			},
+		)
+
+		var tasks []*struct {
+			emitter cff.TaskEmitter
+			fn      func(context.Context) error
+			ran     cff.AtomicBool
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		}
+		defer func() {
+			for _, t := range tasks {
+				// Tasks that are still running report their own outcome
+				// once they finish.
+				if !t.ran.Load() && !t.outcome.Running() {
+					t.emitter.TaskSkipped(ctx, t.outcome.SkipReason(ctx, err))
+				}
+			}
+		}()
+
+		// go.uber.org/cff/internal/tests/parallel/parallel.go:497:3
+		sliceTask39Slice := _505_4
+		sliceTask39Info := &cff.TaskInfo{
+			Name:   parallelInfo.Name,
+			File:   "go.uber.org/cff/internal/tests/parallel/parallel.go",
+			Line:   497,
+			Column: 3,
+		}
+		sliceTask39Fn := func(ctx context.Context, idx int, val int) (err error) {
+			defer func() {
+				recovered := recover()
+				if recovered != nil {
+					err = &cff.PanicError{
+						Value:      recovered,
+						Stacktrace: debug.Stack(),
+					}
+				}
+			}()
+			return cff.Intercept(ctx, interceptor, sliceTask39Info, func(ctx context.Context) (err error) {
+				err = _498_4(idx, val)
+				return
+			})
+		}
+		sliceTask39Batch := _506_14
+		if sliceTask39Batch < 1 {
+			sliceTask39Batch = 1
+		}
+		for start := 0; start < len(sliceTask39Slice); start += sliceTask39Batch {
+			start, end := start, start+sliceTask39Batch
+			if end > len(sliceTask39Slice) {
+				end = len(sliceTask39Slice)
+			}
+			sched.Enqueue(ctx, cff.Job{
+				Run: func(ctx context.Context) (err error) {
+					for idx := start; idx < end; idx++ {
+						if elemErr := sliceTask39Fn(ctx, idx, sliceTask39Slice[idx]); elemErr != nil {
+							err = cff.AppendError(err, elemErr)
+							if !_496_23 {
+								return
+							}
+						}
+					}
+					return
+				},
+			})
+		}
+
+		if err := sched.Wait(ctx); err != nil {
+			parallelEmitter.ParallelError(ctx, err)
+			cff.RethrowPanic(err, false)
+			return err
+		}
+		parallelEmitter.ParallelSuccess(ctx)
+		return nil /*line parallel.go:507*/
+	}()
+}
+
+// SliceBatchElementErrors runs cff.Slice in parallel on batches of the
+// given size, and returns the errors of the elements that failed:
+// negative values fail, and zero values panic.
+func SliceBatchElementErrors(src []int, size int) (errs cff.ElementErrors[int], err error) {
+	err = func() (err error) {
+
+		_516_3 := context.Background()
+
+		_517_19 := 2
+
+		_519_4 := func(val int) error {
+			switch {
+			case val < 0:
+				return errors.New("negative value")
+			case val == 0:
+				panic("zero value")
+			}
+			return nil
+		}
+
+		_528_4 := src
+
+		_529_14 := size
+
+		_530_17 := func(e cff.ElementErrors[int]) {
+			errs = e
+		}
+		ctx := _516_3
+		emitter := cff.NopEmitter()
+		interceptor := cff.InterceptorStack()
+
+		var (
+			parallelInfo = &cff.ParallelInfo{
+				File:   "go.uber.org/cff/internal/tests/parallel/parallel.go",
+				Line:   515,
+				Column: 8,
+			}
+			directiveInfo = &cff.DirectiveInfo{
+				Name:      parallelInfo.Name,
+				Directive: cff.ParallelDirective,
+				File:      parallelInfo.File,
+				Line:      parallelInfo.Line,
+				Column:    parallelInfo.Column,
+			}
+			parallelEmitter = cff.NopParallelEmitter()
+
+			schedInfo = &cff.SchedulerInfo{
+				Name:      parallelInfo.Name,
+				Directive: cff.ParallelDirective,
+				File:      parallelInfo.File,
+				Line:      parallelInfo.Line,
+				Column:    parallelInfo.Column,
+			}
+
+			// possibly unused
+			_ = parallelInfo
+			_ = directiveInfo
+		)
+
+		startTime := time.Now()
+		defer func() { parallelEmitter.ParallelDone(ctx, time.Since(startTime)) }()
+
+		schedEmitter := emitter.SchedulerInit(schedInfo)
+
+		sched := cff.NewScheduler(
+			cff.SchedulerParams{
+				Concurrency: _517_19, Emitter: schedEmitter,
+			},
+		)
+
+		var tasks []*struct {
+			emitter cff.TaskEmitter
+			fn      func(context.Context) error
+			ran     cff.AtomicBool
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		}
+		defer func() {
+			for _, t := range tasks {
+				// Tasks that are still running report their own outcome
+				// once they finish.
+				if !t.ran.Load() && !t.outcome.Running() {
+					t.emitter.TaskSkipped(ctx, t.outcome.SkipReason(ctx, err))
+				}
+			}
+		}()
+
+		// go.uber.org/cff/internal/tests/parallel/parallel.go:518:3
+		sliceTask40Slice := _528_4
+		// Errors of elements, passed to the SliceEnd function.
+		var (
+			sliceTask40ErrsMu sync.Mutex
+			sliceTask40Errs   = make(cff.ElementErrors[int])
+		)
+		sliceTask40Info := &cff.TaskInfo{
+			Name:   parallelInfo.Name,
+			File:   "go.uber.org/cff/internal/tests/parallel/parallel.go",
+			Line:   518,
+			Column: 3,
+		}
+		sliceTask40Fn := func(ctx context.Context, idx int, val int) (err error) {
+			defer func() {
+				recovered := recover()
+				if recovered != nil {
+					err = &cff.PanicError{
+						Value:      recovered,
+						Stacktrace: debug.Stack(),
+					}
+				}
+				if err != nil {
+					sliceTask40ErrsMu.Lock()
+					sliceTask40Errs[idx] = err
+					sliceTask40ErrsMu.Unlock()
+					err = nil
+				}
+			}()
+			return cff.Intercept(ctx, interceptor, sliceTask40Info, func(ctx context.Context) (err error) {
+				err = _519_4(val)
+				return
+			})
+		}
+		sliceTask40Batch := _529_14
+		if sliceTask40Batch < 1 {
+			sliceTask40Batch = 1
+		}
+		sliceTask40Jobs := make([]*cff.ScheduledJob, 0, (len(sliceTask40Slice)+sliceTask40Batch-1)/sliceTask40Batch)
+		for start := 0; start < len(sliceTask40Slice); start += sliceTask40Batch {
+			start, end := start, start+sliceTask40Batch
+			if end > len(sliceTask40Slice) {
+				end = len(sliceTask40Slice)
+			}
+			sliceTask40Jobs = append(sliceTask40Jobs, sched.Enqueue(ctx, cff.Job{
+				Run: func(ctx context.Context) (err error) {
+					for idx := start; idx < end; idx++ {
+						if elemErr := sliceTask40Fn(ctx, idx, sliceTask40Slice[idx]); elemErr != nil {
+							err = cff.AppendError(err, elemErr)
+							return
+						}
+					}
+					return
+				},
+			}))
+		}
+
+		sched.Enqueue(ctx, cff.Job{
+			Dependencies: sliceTask40Jobs,
+			AlwaysRun:    true,
+			Run: func(ctx context.Context) (err error) {
+				defer func() {
+					recovered := recover()
+					if recovered != nil {
+						err = &cff.PanicError{
+							Value:      recovered,
+							Stacktrace: debug.Stack(),
+						}
+					}
+				}()
+
+				_530_17(sliceTask40Errs)
 				return
+			},
+		})
+
+		if err := sched.Wait(ctx); err != nil {
+			parallelEmitter.ParallelError(ctx, err)
+			cff.RethrowPanic(err, false)
+			return err
+		}
+		parallelEmitter.ParallelSuccess(ctx)
+		return nil /*line parallel.go:533*/
+	}()
+	return errs, err
+}
+
+// AssignMapItems runs cff.Map in parallel to populate the provided slices.
+func AssignMapItems(src map[string]int, keys []string, values []int, keepgoing bool) error {
+	return func() (err error) {
+
+		_541_3 := context.Background()
+
+		_542_19 := 2
+
+		_543_23 := keepgoing
+
+		_545_4 := func(key string, val int) error {
+			switch key {
+			case "error":
+				return errors.New("sad times")
+			case "panic":
+				panic("sadder times")
+			default:
+				keys[val] = key
+				values[val] = val
+				return nil
+			}
+		}
+
+		_557_4 := src
+		ctx := _541_3
+		emitter := cff.NopEmitter()
+		interceptor := cff.InterceptorStack()
+
+		var (
+			parallelInfo = &cff.ParallelInfo{
+				File:   "go.uber.org/cff/internal/tests/parallel/parallel.go",
+				Line:   540,
+				Column: 9,
+			}
+			directiveInfo = &cff.DirectiveInfo{
+				Name:      parallelInfo.Name,
+				Directive: cff.ParallelDirective,
+				File:      parallelInfo.File,
+				Line:      parallelInfo.Line,
+				Column:    parallelInfo.Column,
+			}
+			parallelEmitter = cff.NopParallelEmitter()
+
+			schedInfo = &cff.SchedulerInfo{
+				Name:      parallelInfo.Name,
+				Directive: cff.ParallelDirective,
+				File:      parallelInfo.File,
+				Line:      parallelInfo.Line,
+				Column:    parallelInfo.Column,
 			}
-			sliceTask34Jobs[idx] = sched.Enqueue(ctx, cff.Job{
-				Run: sliceTask34.fn,
-			})
+
+			// possibly unused
+			_ = parallelInfo
+			_ = directiveInfo
+		)
+
+		startTime := time.Now()
+		defer func() { parallelEmitter.ParallelDone(ctx, time.Since(startTime)) }()
+
+		schedEmitter := emitter.SchedulerInit(schedInfo)
+
+		sched := cff.NewScheduler(
+			cff.SchedulerParams{
+				Concurrency: _542_19, Emitter: schedEmitter,
+				ContinueOnError: _543_23,
+			},
+		)
+
+		var tasks []*struct {
+			emitter cff.TaskEmitter
+			fn      func(context.Context) error
+			ran     cff.AtomicBool
+
+			outcome cff.TaskOutcome // reports why the task was skipped
 		}
+		defer func() {
+			for _, t := range tasks {
+				// Tasks that are still running report their own outcome
+				// once they finish.
+				if !t.ran.Load() && !t.outcome.Running() {
+					t.emitter.TaskSkipped(ctx, t.outcome.SkipReason(ctx, err))
+				}
+			}
+		}()
 
-		sched.Enqueue(ctx, cff.Job{
-			Dependencies: sliceTask34Jobs,
-			Run: func(ctx context.Context) (err error) {
+		mapTask41Info := &cff.TaskInfo{
+			Name:   parallelInfo.Name,
+			File:   "go.uber.org/cff/internal/tests/parallel/parallel.go",
+			Line:   544,
+			Column: 3,
+		}
+		// go.uber.org/cff/internal/tests/parallel/parallel.go:544:3
+		for key, val := range _557_4 {
+			key := key
+			val := val
+			mapTask41 := new(struct {
+				emitter cff.TaskEmitter
+				fn      func(context.Context) error
+				ran     cff.AtomicBool
+
+				outcome cff.TaskOutcome // reports why the task was skipped
+			})
+			mapTask41.fn = func(ctx context.Context) (err error) {
 				defer func() {
 					recovered := recover()
 					if recovered != nil {
@@ -3027,43 +4628,55 @@ func SliceEndWithCtx(src []int, sliceFn func(idx, val int) error, sliceEndFn fun
 					}
 				}()
 
-				_415_17(ctx)
-				return
-			},
-		})
+				return cff.Intercept(ctx, interceptor, mapTask41Info, func(ctx context.Context) (err error) {
+					err = _545_4(key, val)
+					return
+				})
+			}
+
+			sched.Enqueue(ctx, cff.Job{
+				Run: mapTask41.fn,
+			})
+		}
 
 		if err := sched.Wait(ctx); err != nil {
 			parallelEmitter.ParallelError(ctx, err)
+			cff.RethrowPanic(err, false)
 			return err
 		}
 		parallelEmitter.ParallelSuccess(ctx)
-		return nil /*line parallel.go:416*/
+		return nil /*line parallel.go:558*/
 	}()
-	return err
 }
 
-// SliceEndWithCtxAndErr runs cff.Slice in parallel and calls an erroring sliceEndFn
-// after all items in the slice have finished.
-func SliceEndWithCtxAndErr(src []int, sliceFn func(idx, val int) error, sliceEndFn func(context.Context) error) (err error) {
-	err = func() (err error) {
+// ForEachMapItem runs the given function on all items of the given map
+// concurrently, and then the provided function when all items have been
+// processed.
+func ForEachMapItem[K comparable, V any](
+	src map[K]V,
+	fn func(K, V),
+	after func(),
+) error {
+	return func() (err error) {
 
-		_425_3 := context.Background()
+		_571_3 := context.Background()
 
-		_426_19 := 2
+		_572_19 := 2
 
-		_428_4 := sliceFn
+		_573_11 := fn
 
-		_429_4 := src
+		_573_15 := src
 
-		_430_17 := sliceEndFn
-		ctx := _425_3
+		_573_31 := after
+		ctx := _571_3
 		emitter := cff.NopEmitter()
+		interceptor := cff.InterceptorStack()
 
 		var (
 			parallelInfo = &cff.ParallelInfo{
 				File:   "go.uber.org/cff/internal/tests/parallel/parallel.go",
-				Line:   424,
-				Column: 8,
+				Line:   570,
+				Column: 9,
 			}
 			directiveInfo = &cff.DirectiveInfo{
 				Name:      parallelInfo.Name,
@@ -3094,7 +4707,7 @@ func SliceEndWithCtxAndErr(src []int, sliceFn func(idx, val int) error, sliceEnd
 
 		sched := cff.NewScheduler(
 			cff.SchedulerParams{
-				Concurrency: _426_19, Emitter: schedEmitter,
+				Concurrency: _572_19, Emitter: schedEmitter,
 			},
 		)
 
@@ -3102,27 +4715,38 @@ func SliceEndWithCtxAndErr(src []int, sliceFn func(idx, val int) error, sliceEnd
 			emitter cff.TaskEmitter
 			fn      func(context.Context) error
 			ran     cff.AtomicBool
+
+			outcome cff.TaskOutcome // reports why the task was skipped
 		}
 		defer func() {
 			for _, t := range tasks {
-				if !t.ran.Load() {
-					t.emitter.TaskSkipped(ctx, err)
+				// Tasks that are still running report their own outcome
+				// once they finish.
+				if !t.ran.Load() && !t.outcome.Running() {
+					t.emitter.TaskSkipped(ctx, t.outcome.SkipReason(ctx, err))
 				}
 			}
 		}()
 
-		// go.uber.org/cff/internal/tests/parallel/parallel.go:427:3
-		sliceTask35Slice := _429_4
-		sliceTask35Jobs := make([]*cff.ScheduledJob, len(sliceTask35Slice))
-		for idx, val := range sliceTask35Slice {
-			idx := idx
+		mapTask42Jobs := make([]*cff.ScheduledJob, 0, len(_573_15))
+		mapTask42Info := &cff.TaskInfo{
+			Name:   parallelInfo.Name,
+			File:   "go.uber.org/cff/internal/tests/parallel/parallel.go",
+			Line:   573,
+			Column: 3,
+		}
+		// go.uber.org/cff/internal/tests/parallel/parallel.go:573:3
+		for key, val := range _573_15 {
+			key := key
 			val := val
-			sliceTask35 := new(struct {
+			mapTask42 := new(struct {
 				emitter cff.TaskEmitter
 				fn      func(context.Context) error
 				ran     cff.AtomicBool
+
+				outcome cff.TaskOutcome // reports why the task was skipped
 			})
-			sliceTask35.fn = func(ctx context.Context) (err error) {
+			mapTask42.fn = func(ctx context.Context) (err error) {
 				defer func() {
 					recovered := recover()
 					if recovered != nil {
@@ -3132,16 +4756,21 @@ func SliceEndWithCtxAndErr(src []int, sliceFn func(idx, val int) error, sliceEnd
 						}
 					}
 				}()
-				err = _428_4(idx, val)
-				return
+
+				return cff.Intercept(ctx, interceptor, mapTask42Info, func(ctx context.Context) (err error) {
+					_573_11(key, val)
+					return
+				})
 			}
-			sliceTask35Jobs[idx] = sched.Enqueue(ctx, cff.Job{
-				Run: sliceTask35.fn,
-			})
+
+			mapTask42Jobs = append(mapTask42Jobs, sched.Enqueue(ctx, cff.Job{
+				Run: mapTask42.fn,
+			}))
 		}
 
 		sched.Enqueue(ctx, cff.Job{
-			Dependencies: sliceTask35Jobs,
+			Dependencies: mapTask42Jobs,
+			AlwaysRun:    true,
 			Run: func(ctx context.Context) (err error) {
 				defer func() {
 					recovered := recover()
@@ -3153,52 +4782,47 @@ func SliceEndWithCtxAndErr(src []int, sliceFn func(idx, val int) error, sliceEnd
 					}
 				}()
 
-				err = _430_17(ctx)
+				_573_31()
 				return
 			},
 		})
 
 		if err := sched.Wait(ctx); err != nil {
 			parallelEmitter.ParallelError(ctx, err)
+			cff.RethrowPanic(err, false)
 			return err
 		}
 		parallelEmitter.ParallelSuccess(ctx)
-		return nil /*line parallel.go:431*/
+		return nil /*line parallel.go:573*/
 	}()
-	return err
 }
 
-// AssignMapItems runs cff.Map in parallel to populate the provided slices.
-func AssignMapItems(src map[string]int, keys []string, values []int, keepgoing bool) error {
+// ForEachMapItemError is a variant of ForEachMapItem where all functions
+// can return an error.
+func ForEachMapItemError[K comparable, V any](
+	src map[K]V,
+	fn func(K, V) error,
+	after func() error,
+) error {
 	return func() (err error) {
 
-		_439_3 := context.Background()
+		_585_3 := context.Background()
 
-		_440_19 := 2
+		_586_19 := 2
 
-		_441_23 := keepgoing
+		_587_11 := fn
 
-		_443_4 := func(key string, val int) error {
-			switch key {
-			case "error":
-				return errors.New("sad times")
-			case "panic":
-				panic("sadder times")
-			default:
-				keys[val] = key
-				values[val] = val
-				return nil
-			}
-		}
+		_587_15 := src
 
-		_455_4 := src
-		ctx := _439_3
+		_587_31 := after
+		ctx := _585_3
 		emitter := cff.NopEmitter()
+		interceptor := cff.InterceptorStack()
 
 		var (
 			parallelInfo = &cff.ParallelInfo{
 				File:   "go.uber.org/cff/internal/tests/parallel/parallel.go",
-				Line:   438,
+				Line:   584,
 				Column: 9,
 			}
 			directiveInfo = &cff.DirectiveInfo{
@@ -3230,8 +4854,7 @@ func AssignMapItems(src map[string]int, keys []string, values []int, keepgoing b
 
 		sched := cff.NewScheduler(
 			cff.SchedulerParams{
-				Concurrency: _440_19, Emitter: schedEmitter,
-				ContinueOnError: _441_23,
+				Concurrency: _586_19, Emitter: schedEmitter,
 			},
 		)
 
@@ -3239,25 +4862,38 @@ func AssignMapItems(src map[string]int, keys []string, values []int, keepgoing b
 			emitter cff.TaskEmitter
 			fn      func(context.Context) error
 			ran     cff.AtomicBool
+
+			outcome cff.TaskOutcome // reports why the task was skipped
 		}
 		defer func() {
 			for _, t := range tasks {
-				if !t.ran.Load() {
-					t.emitter.TaskSkipped(ctx, err)
+				// Tasks that are still running report their own outcome
+				// once they finish.
+				if !t.ran.Load() && !t.outcome.Running() {
+					t.emitter.TaskSkipped(ctx, t.outcome.SkipReason(ctx, err))
 				}
 			}
 		}()
 
-		// go.uber.org/cff/internal/tests/parallel/parallel.go:442:3
-		for key, val := range _455_4 {
+		mapTask43Jobs := make([]*cff.ScheduledJob, 0, len(_587_15))
+		mapTask43Info := &cff.TaskInfo{
+			Name:   parallelInfo.Name,
+			File:   "go.uber.org/cff/internal/tests/parallel/parallel.go",
+			Line:   587,
+			Column: 3,
+		}
+		// go.uber.org/cff/internal/tests/parallel/parallel.go:587:3
+		for key, val := range _587_15 {
 			key := key
 			val := val
-			mapTask36 := new(struct {
+			mapTask43 := new(struct {
 				emitter cff.TaskEmitter
 				fn      func(context.Context) error
 				ran     cff.AtomicBool
+
+				outcome cff.TaskOutcome // reports why the task was skipped
 			})
-			mapTask36.fn = func(ctx context.Context) (err error) {
+			mapTask43.fn = func(ctx context.Context) (err error) {
 				defer func() {
 					recovered := recover()
 					if recovered != nil {
@@ -3268,50 +4904,73 @@ func AssignMapItems(src map[string]int, keys []string, values []int, keepgoing b
 					}
 				}()
 
-				err = _443_4(key, val)
-				return
+				return cff.Intercept(ctx, interceptor, mapTask43Info, func(ctx context.Context) (err error) {
+					err = _587_11(key, val)
+					return
+				})
 			}
 
-			sched.Enqueue(ctx, cff.Job{
-				Run: mapTask36.fn,
-			})
+			mapTask43Jobs = append(mapTask43Jobs, sched.Enqueue(ctx, cff.Job{
+				Run: mapTask43.fn,
+			}))
 		}
 
+		sched.Enqueue(ctx, cff.Job{
+			Dependencies: mapTask43Jobs,
+			AlwaysRun:    true,
+			Run: func(ctx context.Context) (err error) {
+				defer func() {
+					recovered := recover()
+					if recovered != nil {
+						err = &cff.PanicError{
+							Value:      recovered,
+							Stacktrace: debug.Stack(),
+						}
+					}
+				}()
+
+				err = _587_31()
+				return
+			},
+		})
+
 		if err := sched.Wait(ctx); err != nil {
 			parallelEmitter.ParallelError(ctx, err)
+			cff.RethrowPanic(err, false)
 			return err
 		}
 		parallelEmitter.ParallelSuccess(ctx)
-		return nil /*line parallel.go:456*/
+		return nil /*line parallel.go:587*/
 	}()
 }
 
-// ForEachMapItem runs the given function on all items of the given map
-// concurrently, and then the provided function when all items have been
-// processed.
-func ForEachMapItem[K comparable, V any](
+// ForEachMapItemContext is a variant of ForEachMapItem where all functions
+// accept a context.
+func ForEachMapItemContext[K comparable, V any](
+	ctx context.Context,
 	src map[K]V,
-	fn func(K, V),
-	after func(),
+	fn func(context.Context, K, V),
+	after func(context.Context),
 ) error {
 	return func() (err error) {
 
-		_469_3 := context.Background()
+		_600_3 := ctx
 
-		_470_19 := 2
+		_601_19 := 2
 
-		_471_11 := fn
+		_602_11 := fn
 
-		_471_15 := src
+		_602_15 := src
 
-		_471_31 := after
-		ctx := _469_3
+		_602_31 := after
+		ctx := _600_3
 		emitter := cff.NopEmitter()
+		interceptor := cff.InterceptorStack()
 
 		var (
 			parallelInfo = &cff.ParallelInfo{
 				File:   "go.uber.org/cff/internal/tests/parallel/parallel.go",
-				Line:   468,
+				Line:   599,
 				Column: 9,
 			}
 			directiveInfo = &cff.DirectiveInfo{
@@ -3343,7 +5002,7 @@ func ForEachMapItem[K comparable, V any](
 
 		sched := cff.NewScheduler(
 			cff.SchedulerParams{
-				Concurrency: _470_19, Emitter: schedEmitter,
+				Concurrency: _601_19, Emitter: schedEmitter,
 			},
 		)
 
@@ -3351,26 +5010,38 @@ func ForEachMapItem[K comparable, V any](
 			emitter cff.TaskEmitter
 			fn      func(context.Context) error
 			ran     cff.AtomicBool
+
+			outcome cff.TaskOutcome // reports why the task was skipped
 		}
 		defer func() {
 			for _, t := range tasks {
-				if !t.ran.Load() {
-					t.emitter.TaskSkipped(ctx, err)
+				// Tasks that are still running report their own outcome
+				// once they finish.
+				if !t.ran.Load() && !t.outcome.Running() {
+					t.emitter.TaskSkipped(ctx, t.outcome.SkipReason(ctx, err))
 				}
 			}
 		}()
 
-		mapTask37Jobs := make([]*cff.ScheduledJob, 0, len(_471_15))
-		// go.uber.org/cff/internal/tests/parallel/parallel.go:471:3
-		for key, val := range _471_15 {
+		mapTask44Jobs := make([]*cff.ScheduledJob, 0, len(_602_15))
+		mapTask44Info := &cff.TaskInfo{
+			Name:   parallelInfo.Name,
+			File:   "go.uber.org/cff/internal/tests/parallel/parallel.go",
+			Line:   602,
+			Column: 3,
+		}
+		// go.uber.org/cff/internal/tests/parallel/parallel.go:602:3
+		for key, val := range _602_15 {
 			key := key
 			val := val
-			mapTask37 := new(struct {
+			mapTask44 := new(struct {
 				emitter cff.TaskEmitter
 				fn      func(context.Context) error
 				ran     cff.AtomicBool
+
+				outcome cff.TaskOutcome // reports why the task was skipped
 			})
-			mapTask37.fn = func(ctx context.Context) (err error) {
+			mapTask44.fn = func(ctx context.Context) (err error) {
 				defer func() {
 					recovered := recover()
 					if recovered != nil {
@@ -3381,17 +5052,20 @@ func ForEachMapItem[K comparable, V any](
 					}
 				}()
 
-				_471_11(key, val)
-				return
+				return cff.Intercept(ctx, interceptor, mapTask44Info, func(ctx context.Context) (err error) {
+					_602_11(ctx, key, val)
+					return
+				})
 			}
 
-			mapTask37Jobs = append(mapTask37Jobs, sched.Enqueue(ctx, cff.Job{
-				Run: mapTask37.fn,
+			mapTask44Jobs = append(mapTask44Jobs, sched.Enqueue(ctx, cff.Job{
+				Run: mapTask44.fn,
 			}))
 		}
 
 		sched.Enqueue(ctx, cff.Job{
-			Dependencies: mapTask37Jobs,
+			Dependencies: mapTask44Jobs,
+			AlwaysRun:    true,
 			Run: func(ctx context.Context) (err error) {
 				defer func() {
 					recovered := recover()
@@ -3403,45 +5077,49 @@ func ForEachMapItem[K comparable, V any](
 					}
 				}()
 
-				_471_31()
+				_602_31(ctx)
 				return
 			},
 		})
 
 		if err := sched.Wait(ctx); err != nil {
 			parallelEmitter.ParallelError(ctx, err)
+			cff.RethrowPanic(err, false)
 			return err
 		}
 		parallelEmitter.ParallelSuccess(ctx)
-		return nil /*line parallel.go:471*/
+		return nil /*line parallel.go:602*/
 	}()
 }
 
-// ForEachMapItemError is a variant of ForEachMapItem where all functions
-// can return an error.
-func ForEachMapItemError[K comparable, V any](
+// ForEachMapItemElementErrors is a variant of ForEachMapItem that passes the
+// errors of the items that failed to the provided function.
+func ForEachMapItemElementErrors[K comparable, V any](
 	src map[K]V,
 	fn func(K, V) error,
-	after func() error,
+	after func(cff.ElementErrors[K]) error,
 ) error {
 	return func() (err error) {
 
-		_483_3 := context.Background()
+		_614_3 := context.Background()
 
-		_484_19 := 2
+		_615_19 := 2
 
-		_485_11 := fn
+		_616_23 := true
 
-		_485_15 := src
+		_617_11 := fn
 
-		_485_31 := after
-		ctx := _483_3
+		_617_15 := src
+
+		_617_31 := after
+		ctx := _614_3
 		emitter := cff.NopEmitter()
+		interceptor := cff.InterceptorStack()
 
 		var (
 			parallelInfo = &cff.ParallelInfo{
 				File:   "go.uber.org/cff/internal/tests/parallel/parallel.go",
-				Line:   482,
+				Line:   613,
 				Column: 9,
 			}
 			directiveInfo = &cff.DirectiveInfo{
@@ -3473,7 +5151,8 @@ func ForEachMapItemError[K comparable, V any](
 
 		sched := cff.NewScheduler(
 			cff.SchedulerParams{
-				Concurrency: _484_19, Emitter: schedEmitter,
+				Concurrency: _615_19, Emitter: schedEmitter,
+				ContinueOnError: _616_23,
 			},
 		)
 
@@ -3481,26 +5160,43 @@ func ForEachMapItemError[K comparable, V any](
 			emitter cff.TaskEmitter
 			fn      func(context.Context) error
 			ran     cff.AtomicBool
+
+			outcome cff.TaskOutcome // reports why the task was skipped
 		}
 		defer func() {
 			for _, t := range tasks {
-				if !t.ran.Load() {
-					t.emitter.TaskSkipped(ctx, err)
+				// Tasks that are still running report their own outcome
+				// once they finish.
+				if !t.ran.Load() && !t.outcome.Running() {
+					t.emitter.TaskSkipped(ctx, t.outcome.SkipReason(ctx, err))
 				}
 			}
 		}()
 
-		mapTask38Jobs := make([]*cff.ScheduledJob, 0, len(_485_15))
-		// go.uber.org/cff/internal/tests/parallel/parallel.go:485:3
-		for key, val := range _485_15 {
+		mapTask45Jobs := make([]*cff.ScheduledJob, 0, len(_617_15))
+		// Errors of elements, passed to the MapEnd function.
+		var (
+			mapTask45ErrsMu sync.Mutex
+			mapTask45Errs   = make(cff.ElementErrors[K])
+		)
+		mapTask45Info := &cff.TaskInfo{
+			Name:   parallelInfo.Name,
+			File:   "go.uber.org/cff/internal/tests/parallel/parallel.go",
+			Line:   617,
+			Column: 3,
+		}
+		// go.uber.org/cff/internal/tests/parallel/parallel.go:617:3
+		for key, val := range _617_15 {
 			key := key
 			val := val
-			mapTask38 := new(struct {
+			mapTask45 := new(struct {
 				emitter cff.TaskEmitter
 				fn      func(context.Context) error
 				ran     cff.AtomicBool
+
+				outcome cff.TaskOutcome // reports why the task was skipped
 			})
-			mapTask38.fn = func(ctx context.Context) (err error) {
+			mapTask45.fn = func(ctx context.Context) (err error) {
 				defer func() {
 					recovered := recover()
 					if recovered != nil {
@@ -3509,19 +5205,28 @@ func ForEachMapItemError[K comparable, V any](
 							Stacktrace: debug.Stack(),
 						}
 					}
+					if err != nil {
+						mapTask45ErrsMu.Lock()
+						mapTask45Errs[key] = err
+						mapTask45ErrsMu.Unlock()
+						err = nil
+					}
 				}()
 
-				err = _485_11(key, val)
-				return
+				return cff.Intercept(ctx, interceptor, mapTask45Info, func(ctx context.Context) (err error) {
+					err = _617_11(key, val)
+					return
+				})
 			}
 
-			mapTask38Jobs = append(mapTask38Jobs, sched.Enqueue(ctx, cff.Job{
-				Run: mapTask38.fn,
+			mapTask45Jobs = append(mapTask45Jobs, sched.Enqueue(ctx, cff.Job{
+				Run: mapTask45.fn,
 			}))
 		}
 
 		sched.Enqueue(ctx, cff.Job{
-			Dependencies: mapTask38Jobs,
+			Dependencies: mapTask45Jobs,
+			AlwaysRun:    true,
 			Run: func(ctx context.Context) (err error) {
 				defer func() {
 					recovered := recover()
@@ -3533,46 +5238,50 @@ func ForEachMapItemError[K comparable, V any](
 					}
 				}()
 
-				err = _485_31()
+				err = _617_31(mapTask45Errs)
 				return
 			},
 		})
 
 		if err := sched.Wait(ctx); err != nil {
 			parallelEmitter.ParallelError(ctx, err)
+			cff.RethrowPanic(err, false)
 			return err
 		}
 		parallelEmitter.ParallelSuccess(ctx)
-		return nil /*line parallel.go:485*/
+		return nil /*line parallel.go:617*/
 	}()
 }
 
-// ForEachMapItemContext is a variant of ForEachMapItem where all functions
-// accept a context.
-func ForEachMapItemContext[K comparable, V any](
-	ctx context.Context,
-	src map[K]V,
-	fn func(context.Context, K, V),
-	after func(context.Context),
-) error {
+// FirstSuccessPredicate races a task whose predicate is false against one
+// that fails after the given delay. Neither task succeeds.
+func FirstSuccessPredicate(delay time.Duration) error {
 	return func() (err error) {
 
-		_498_3 := ctx
+		_625_3 := context.Background()
 
-		_499_19 := 2
+		_626_19 := 2
 
-		_500_11 := fn
+		_628_4 := func() {}
 
-		_500_15 := src
+		_629_18 := func() bool { return false }
 
-		_500_31 := after
-		ctx := _498_3
+		_631_12 := func() error {
+			time.Sleep(delay)
+			return errors.New("great sadness")
+		}
+		ctx := _625_3
+		// Cancels tasks that are still running once the quorum is reached.
+		// This runs after all other deferred functions.
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
 		emitter := cff.NopEmitter()
+		interceptor := cff.InterceptorStack()
 
 		var (
 			parallelInfo = &cff.ParallelInfo{
 				File:   "go.uber.org/cff/internal/tests/parallel/parallel.go",
-				Line:   497,
+				Line:   624,
 				Column: 9,
 			}
 			directiveInfo = &cff.DirectiveInfo{
@@ -3604,7 +5313,9 @@ func ForEachMapItemContext[K comparable, V any](
 
 		sched := cff.NewScheduler(
 			cff.SchedulerParams{
-				Concurrency: _499_19, Emitter: schedEmitter,
+				Concurrency: _626_19, Emitter: schedEmitter,
+
+				Quorum: 1,
 			},
 		)
 
@@ -3612,68 +5323,141 @@ func ForEachMapItemContext[K comparable, V any](
 			emitter cff.TaskEmitter
 			fn      func(context.Context) error
 			ran     cff.AtomicBool
+
+			outcome cff.TaskOutcome // reports why the task was skipped
 		}
 		defer func() {
 			for _, t := range tasks {
-				if !t.ran.Load() {
-					t.emitter.TaskSkipped(ctx, err)
+				// Tasks that are still running report their own outcome
+				// once they finish.
+				if !t.ran.Load() && !t.outcome.Running() {
+					t.emitter.TaskSkipped(ctx, t.outcome.SkipReason(ctx, err))
 				}
 			}
 		}()
 
-		mapTask39Jobs := make([]*cff.ScheduledJob, 0, len(_500_15))
-		// go.uber.org/cff/internal/tests/parallel/parallel.go:500:3
-		for key, val := range _500_15 {
-			key := key
-			val := val
-			mapTask39 := new(struct {
-				emitter cff.TaskEmitter
-				fn      func(context.Context) error
-				ran     cff.AtomicBool
-			})
-			mapTask39.fn = func(ctx context.Context) (err error) {
-				defer func() {
-					recovered := recover()
-					if recovered != nil {
-						err = &cff.PanicError{
-							Value:      recovered,
-							Stacktrace: debug.Stack(),
-						}
+		// go.uber.org/cff/internal/tests/parallel/parallel.go:628:4
+		task46 := new(struct {
+			emitter cff.TaskEmitter
+			fn      func(context.Context) error
+			ran     cff.AtomicBool
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		})
+		task46.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/parallel/parallel.go",
+			Line:   628,
+			Column: 4,
+		}
+		task46.emitter = cff.NopTaskEmitter()
+		task46.fn = func(ctx context.Context) (err error) {
+			task46.outcome.Start()
+			taskEmitter := task46.emitter
+			startTime := time.Now()
+			defer func() {
+				task46.outcome.Finish(err)
+				if task46.ran.Load() {
+					taskEmitter.TaskDone(ctx, time.Since(startTime))
+				}
+			}()
+
+			defer func() {
+				recovered := recover()
+				if recovered != nil {
+					taskEmitter.TaskPanic(ctx, recovered)
+					err = &cff.PanicError{
+						Value:      recovered,
+						Stacktrace: debug.Stack(),
 					}
-				}()
+				}
+			}()
 
-				_500_11(ctx, key, val)
-				return
+			p := _629_18()
+			if !p {
+				task46.outcome.Skip(cff.SkipPredicateFalse)
+				return cff.ErrNotSucceeded
 			}
 
-			mapTask39Jobs = append(mapTask39Jobs, sched.Enqueue(ctx, cff.Job{
-				Run: mapTask39.fn,
-			}))
+			defer task46.ran.Store(true)
+
+			err = cff.Intercept(ctx, interceptor, task46.outcome.Info, func(ctx context.Context) (err error) {
+				_628_4()
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return
+			}
+			taskEmitter.TaskSuccess(ctx)
+			return
 		}
 
 		sched.Enqueue(ctx, cff.Job{
-			Dependencies: mapTask39Jobs,
-			Run: func(ctx context.Context) (err error) {
-				defer func() {
-					recovered := recover()
-					if recovered != nil {
-						err = &cff.PanicError{
-							Value:      recovered,
-							Stacktrace: debug.Stack(),
-						}
+			Run: task46.fn,
+		})
+		tasks = append(tasks, task46)
+
+		// go.uber.org/cff/internal/tests/parallel/parallel.go:631:12
+		task47 := new(struct {
+			emitter cff.TaskEmitter
+			fn      func(context.Context) error
+			ran     cff.AtomicBool
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		})
+		task47.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/parallel/parallel.go",
+			Line:   631,
+			Column: 12,
+		}
+		task47.emitter = cff.NopTaskEmitter()
+		task47.fn = func(ctx context.Context) (err error) {
+			task47.outcome.Start()
+			taskEmitter := task47.emitter
+			startTime := time.Now()
+			defer func() {
+				task47.outcome.Finish(err)
+				if task47.ran.Load() {
+					taskEmitter.TaskDone(ctx, time.Since(startTime))
+				}
+			}()
+
+			defer func() {
+				recovered := recover()
+				if recovered != nil {
+					taskEmitter.TaskPanic(ctx, recovered)
+					err = &cff.PanicError{
+						Value:      recovered,
+						Stacktrace: debug.Stack(),
 					}
-				}()
+				}
+			}()
 
-				_500_31(ctx)
+			defer task47.ran.Store(true)
+
+			err = cff.Intercept(ctx, interceptor, task47.outcome.Info, func(ctx context.Context) (err error) {
+				err = _631_12()
 				return
-			},
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return
+			}
+			taskEmitter.TaskSuccess(ctx)
+			return
+		}
+
+		sched.Enqueue(ctx, cff.Job{
+			Run: task47.fn,
 		})
+		tasks = append(tasks, task47)
 
 		if err := sched.Wait(ctx); err != nil {
 			parallelEmitter.ParallelError(ctx, err)
+			cff.RethrowPanic(err, false)
 			return err
 		}
 		parallelEmitter.ParallelSuccess(ctx)
-		return nil /*line parallel.go:500*/
+		return nil /*line parallel.go:635*/
 	}()
 }
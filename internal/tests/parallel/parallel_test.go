@@ -556,6 +556,140 @@ func TestSliceEndWithCtxAndErr(t *testing.T) {
 	}
 }
 
+func TestSliceEndContinueOnError(t *testing.T) {
+	errSadTimes := errors.New("sad times")
+	src := []int{1, -1, 3, -2}
+	target := make([]int, len(src))
+
+	var calledEnd bool
+	err := SliceEndContinueOnError(src, func(idx, val int) error {
+		switch val {
+		case -1:
+			return errSadTimes
+		case -2:
+			panic("sadder times")
+		}
+		target[idx] = val
+		return nil
+	}, func() {
+		calledEnd = true
+	})
+	assert.ErrorIs(t, err, errSadTimes)
+	assert.ErrorContains(t, err, "panic: sadder times")
+	assert.True(t, calledEnd, "SliceEnd must run after all items were attempted")
+	assert.Equal(t, []int{1, 0, 3, 0}, target)
+}
+
+func TestSliceEndElementErrors(t *testing.T) {
+	errSadTimes := errors.New("sad times")
+	assignItemsFn := func(idx, val int) error {
+		switch val {
+		case -1:
+			return errSadTimes
+		case -2:
+			panic("sadder times")
+		}
+		return nil
+	}
+
+	t.Run("no errors", func(t *testing.T) {
+		err := SliceEndElementErrors([]int{1, 2, 3}, assignItemsFn,
+			func(_ context.Context, errs cff.ElementErrors[int]) error {
+				assert.Empty(t, errs)
+				return nil
+			})
+		require.NoError(t, err)
+	})
+
+	t.Run("errors handled", func(t *testing.T) {
+		var got cff.ElementErrors[int]
+		err := SliceEndElementErrors([]int{1, -1, 3, -2}, assignItemsFn,
+			func(_ context.Context, errs cff.ElementErrors[int]) error {
+				got = errs
+				return nil
+			})
+		require.NoError(t, err)
+
+		require.Len(t, got, 2)
+		assert.ErrorIs(t, got[1], errSadTimes)
+		var panicErr *cff.PanicError
+		require.ErrorAs(t, got[3], &panicErr)
+		assert.Equal(t, "sadder times", panicErr.Value)
+	})
+
+	t.Run("errors rejected", func(t *testing.T) {
+		errTooMany := errors.New("too many failures")
+		err := SliceEndElementErrors([]int{-1, -1, 3}, assignItemsFn,
+			func(_ context.Context, errs cff.ElementErrors[int]) error {
+				if len(errs) > 1 {
+					return errTooMany
+				}
+				return nil
+			})
+		assert.ErrorIs(t, err, errTooMany)
+		assert.NotErrorIs(t, err, errSadTimes)
+	})
+}
+
+func TestSliceBatch(t *testing.T) {
+	src := []int{1, 2, 3, 4, 5, 6, 7}
+	for _, size := range []int{-1, 1, 3, 7, 10} {
+		t.Run(fmt.Sprint(size), func(t *testing.T) {
+			target := make([]int, len(src))
+			require.NoError(t, SliceBatch(src, target, size))
+			assert.Equal(t, []int{2, 4, 6, 8, 10, 12, 14}, target)
+		})
+	}
+}
+
+func TestSliceBatchContinueOnError(t *testing.T) {
+	src := []int{1, -1, 2, -1, 3}
+
+	t.Run("stops batch", func(t *testing.T) {
+		var attempted sync.Map
+		err := SliceBatchContinueOnError(src, len(src), false, &attempted)
+		assert.EqualError(t, err, "negative value at 1")
+		assert.ElementsMatch(t, []int{0, 1}, maps.Keys(syncMapToMap(&attempted)))
+	})
+
+	t.Run("continues batch", func(t *testing.T) {
+		var attempted sync.Map
+		err := SliceBatchContinueOnError(src, 2, true, &attempted)
+		assert.ElementsMatch(t, []string{
+			"negative value at 1",
+			"negative value at 3",
+		}, errorMessages(multierr.Errors(err)))
+		assert.ElementsMatch(t, []int{0, 1, 2, 3, 4}, maps.Keys(syncMapToMap(&attempted)))
+	})
+}
+
+func TestSliceBatchElementErrors(t *testing.T) {
+	errs, err := SliceBatchElementErrors([]int{1, -1, 2, 0, 3}, 2)
+	require.NoError(t, err)
+	require.Len(t, errs, 2)
+	assert.EqualError(t, errs[1], "negative value")
+	var panicErr *cff.PanicError
+	require.ErrorAs(t, errs[3], &panicErr)
+	assert.Equal(t, "zero value", panicErr.Value)
+}
+
+func syncMapToMap(m *sync.Map) map[int]struct{} {
+	out := make(map[int]struct{})
+	m.Range(func(k, _ any) bool {
+		out[k.(int)] = struct{}{}
+		return true
+	})
+	return out
+}
+
+func errorMessages(errs []error) []string {
+	msgs := make([]string, len(errs))
+	for i, err := range errs {
+		msgs[i] = err.Error()
+	}
+	return msgs
+}
+
 func TestMap(t *testing.T) {
 	src := map[string]int{
 		"test": 0,
@@ -717,6 +851,24 @@ func TestMapEnd_ReturnsError(t *testing.T) {
 	})
 }
 
+func TestMapEnd_ElementErrors(t *testing.T) {
+	m := map[string]int{"a": 1, "b": 2, "c": 3, "d": 4}
+	giveErr := errors.New("great sadness")
+
+	var got cff.ElementErrors[string]
+	err := ForEachMapItemElementErrors(m, func(s string, _ int) error {
+		if s == "b" || s == "c" {
+			return giveErr
+		}
+		return nil
+	}, func(errs cff.ElementErrors[string]) error {
+		got = errs
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, cff.ElementErrors[string]{"b": giveErr, "c": giveErr}, got)
+}
+
 func TestMapEnd_HasContext(t *testing.T) {
 	m := map[string]int{"a": 1, "b": 2, "c": 3, "d": 4}
 
@@ -756,3 +908,13 @@ func TestMapEnd_HasContext(t *testing.T) {
 		assert.ErrorIs(t, err, context.Canceled)
 	})
 }
+
+func TestFirstSuccessPredicate(t *testing.T) {
+	// A task whose predicate is false doesn't count as a success.
+	err := FirstSuccessPredicate(10 * time.Millisecond)
+
+	var qerr *cff.QuorumError
+	require.ErrorAs(t, err, &qerr)
+	assert.Equal(t, 0, qerr.Succeeded)
+	assert.ErrorContains(t, err, "great sadness")
+}
@@ -0,0 +1,145 @@
+//go:build cff
+// +build cff
+
+// Package paralleloptions tests cff.Parallel with predicates and fallbacks.
+package paralleloptions
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+
+	"go.uber.org/cff"
+)
+
+// Gated runs two tasks, each gated by a predicate.
+// It reports which tasks ran.
+func Gated(ctx context.Context, e cff.Emitter, runFoo, runBar bool) ([]string, error) {
+	var (
+		mu  sync.Mutex
+		ran []string
+	)
+	record := func(name string) {
+		mu.Lock()
+		defer mu.Unlock()
+		ran = append(ran, name)
+	}
+
+	err := cff.Parallel(ctx,
+		cff.WithEmitter(e),
+		cff.Task(
+			func() { record("foo") },
+			cff.Predicate(func() bool { return runFoo }),
+			cff.Instrument("foo"),
+		),
+		cff.Task(
+			func(ctx context.Context) error {
+				record("bar")
+				return nil
+			},
+			cff.Predicate(func(context.Context) (bool, error) { return runBar, nil }),
+			cff.Instrument("bar"),
+		),
+	)
+	return ran, err
+}
+
+// FailingPredicate runs a task whose predicate fails with the given error.
+func FailingPredicate(ctx context.Context, predErr error) error {
+	return cff.Parallel(ctx,
+		cff.Task(
+			func() {},
+			cff.Predicate(func(context.Context) (bool, error) { return false, predErr }),
+		),
+	)
+}
+
+// PredicateOnError runs a task whose predicate fails with the given error.
+// The task runs if onError is true.
+func PredicateOnError(ctx context.Context, predErr error, onError bool) (ran bool, _ error) {
+	return ran, cff.Parallel(ctx,
+		cff.Task(
+			func() { ran = true },
+			cff.Predicate(func() (bool, error) { return true, predErr }),
+			cff.PredicateOnError(onError),
+		),
+	)
+}
+
+// Fallback runs a task that fails with the given error and a task that
+// panics, both recovered with cff.FallbackWith.
+func Fallback(ctx context.Context, e cff.Emitter, taskErr error) error {
+	return cff.Parallel(ctx,
+		cff.WithEmitter(e),
+		cff.Task(
+			func() error { return taskErr },
+			cff.FallbackWith(),
+			cff.Instrument("fails"),
+		),
+		cff.Task(
+			func() error { panic("great sadness") },
+			cff.FallbackWith(),
+			cff.Instrument("panics"),
+		),
+	)
+}
+
+// SliceFallback formats the given numbers, failing for negative numbers
+// and panicking for zero.
+// Failed numbers are formatted as "?" by the fallback.
+func SliceFallback(ctx context.Context, e cff.Emitter, nums []int) ([]string, error) {
+	out := make([]string, len(nums))
+	err := cff.Parallel(ctx,
+		cff.WithEmitter(e),
+		cff.InstrumentParallel("SliceFallback"),
+		cff.Slice(
+			func(idx, n int) error {
+				if n == 0 {
+					panic("zero")
+				}
+				if n < 0 {
+					return errors.New("negative")
+				}
+				out[idx] = strconv.Itoa(n)
+				return nil
+			},
+			nums,
+			cff.SliceFallback(func(idx, n int, err error) {
+				out[idx] = "?"
+			}),
+		),
+	)
+	return out, err
+}
+
+// MapFallback formats the values of the given map, failing for negative
+// numbers.
+// The errors are recorded by the fallback.
+func MapFallback(ctx context.Context, nums map[string]int) (map[string]string, map[string]error, error) {
+	var (
+		mu   sync.Mutex
+		out  = make(map[string]string)
+		errs = make(map[string]error)
+	)
+	err := cff.Parallel(ctx,
+		cff.Map(
+			func(k string, n int) error {
+				if n < 0 {
+					return errors.New("negative")
+				}
+				mu.Lock()
+				defer mu.Unlock()
+				out[k] = strconv.Itoa(n)
+				return nil
+			},
+			nums,
+			cff.MapFallback(func(_ context.Context, k string, _ int, err error) {
+				mu.Lock()
+				defer mu.Unlock()
+				errs[k] = err
+			}),
+		),
+	)
+	return out, errs, err
+}
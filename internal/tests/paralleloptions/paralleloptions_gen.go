@@ -0,0 +1,1042 @@
+//go:build !cff
+// +build !cff
+
+// Package paralleloptions tests cff.Parallel with predicates and fallbacks.
+package paralleloptions
+
+import (
+	"context"
+	"errors"
+	"runtime/debug"
+	"strconv"
+	"sync"
+	"time"
+
+	"go.uber.org/cff"
+)
+
+// Gated runs two tasks, each gated by a predicate.
+// It reports which tasks ran.
+func Gated(ctx context.Context, e cff.Emitter, runFoo, runBar bool) ([]string, error) {
+	var (
+		mu  sync.Mutex
+		ran []string
+	)
+	record := func(name string) {
+		mu.Lock()
+		defer mu.Unlock()
+		ran = append(ran, name)
+	}
+
+	err := func() (err error) {
+
+		_29_22 := ctx
+
+		_30_19 := e
+
+		_32_4 := func() { record("foo") }
+
+		_33_18 := func() bool { return runFoo }
+
+		_34_19 := "foo"
+
+		_37_4 := func(ctx context.Context) error {
+			record("bar")
+			return nil
+		}
+
+		_41_18 := func(context.Context) (bool, error) { return runBar, nil }
+
+		_42_19 := "bar"
+		ctx := _29_22
+		emitter := cff.EmitterStack(_30_19)
+		interceptor := cff.InterceptorStack()
+
+		var (
+			parallelInfo = &cff.ParallelInfo{
+				File:   "go.uber.org/cff/internal/tests/paralleloptions/paralleloptions.go",
+				Line:   29,
+				Column: 9,
+			}
+			directiveInfo = &cff.DirectiveInfo{
+				Name:      parallelInfo.Name,
+				Directive: cff.ParallelDirective,
+				File:      parallelInfo.File,
+				Line:      parallelInfo.Line,
+				Column:    parallelInfo.Column,
+			}
+			parallelEmitter = cff.NopParallelEmitter()
+
+			schedInfo = &cff.SchedulerInfo{
+				Name:      parallelInfo.Name,
+				Directive: cff.ParallelDirective,
+				File:      parallelInfo.File,
+				Line:      parallelInfo.Line,
+				Column:    parallelInfo.Column,
+			}
+
+			// possibly unused
+			_ = parallelInfo
+			_ = directiveInfo
+		)
+
+		startTime := time.Now()
+		defer func() { parallelEmitter.ParallelDone(ctx, time.Since(startTime)) }()
+
+		schedEmitter := emitter.SchedulerInit(schedInfo)
+
+		sched := cff.NewScheduler(
+			cff.SchedulerParams{
+				Emitter: schedEmitter,
+			},
+		)
+
+		var tasks []*struct {
+			emitter cff.TaskEmitter
+			fn      func(context.Context) error
+			ran     cff.AtomicBool
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		}
+		defer func() {
+			for _, t := range tasks {
+				// Tasks that are still running report their own outcome
+				// once they finish.
+				if !t.ran.Load() && !t.outcome.Running() {
+					t.emitter.TaskSkipped(ctx, t.outcome.SkipReason(ctx, err))
+				}
+			}
+		}()
+
+		// go.uber.org/cff/internal/tests/paralleloptions/paralleloptions.go:32:4
+		task0 := new(struct {
+			emitter cff.TaskEmitter
+			fn      func(context.Context) error
+			ran     cff.AtomicBool
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		})
+		task0.outcome.Info = &cff.TaskInfo{
+			Name:   _34_19,
+			File:   "go.uber.org/cff/internal/tests/paralleloptions/paralleloptions.go",
+			Line:   32,
+			Column: 4,
+		}
+		task0.emitter = emitter.TaskInit(task0.outcome.Info, directiveInfo)
+		task0.fn = func(ctx context.Context) (err error) {
+			task0.outcome.Start()
+			taskEmitter := task0.emitter
+			startTime := time.Now()
+			defer func() {
+				task0.outcome.Finish(err)
+				if task0.ran.Load() {
+					taskEmitter.TaskDone(ctx, time.Since(startTime))
+				}
+			}()
+
+			defer func() {
+				recovered := recover()
+				if recovered != nil {
+					taskEmitter.TaskPanic(ctx, recovered)
+					err = &cff.PanicError{
+						Value:      recovered,
+						Stacktrace: debug.Stack(),
+					}
+				}
+			}()
+
+			p := _33_18()
+			if !p {
+				task0.outcome.Skip(cff.SkipPredicateFalse)
+				return nil
+			}
+
+			defer task0.ran.Store(true)
+
+			err = cff.Intercept(ctx, interceptor, task0.outcome.Info, func(ctx context.Context) (err error) {
+				_32_4()
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return
+			}
+			taskEmitter.TaskSuccess(ctx)
+			return
+		}
+
+		sched.Enqueue(ctx, cff.Job{
+			Run: task0.fn,
+		})
+		tasks = append(tasks, task0)
+
+		// go.uber.org/cff/internal/tests/paralleloptions/paralleloptions.go:37:4
+		task1 := new(struct {
+			emitter cff.TaskEmitter
+			fn      func(context.Context) error
+			ran     cff.AtomicBool
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		})
+		task1.outcome.Info = &cff.TaskInfo{
+			Name:   _42_19,
+			File:   "go.uber.org/cff/internal/tests/paralleloptions/paralleloptions.go",
+			Line:   37,
+			Column: 4,
+		}
+		task1.emitter = emitter.TaskInit(task1.outcome.Info, directiveInfo)
+		task1.fn = func(ctx context.Context) (err error) {
+			task1.outcome.Start()
+			taskEmitter := task1.emitter
+			startTime := time.Now()
+			defer func() {
+				task1.outcome.Finish(err)
+				if task1.ran.Load() {
+					taskEmitter.TaskDone(ctx, time.Since(startTime))
+				}
+			}()
+
+			defer func() {
+				recovered := recover()
+				if recovered != nil {
+					taskEmitter.TaskPanic(ctx, recovered)
+					err = &cff.PanicError{
+						Value:      recovered,
+						Stacktrace: debug.Stack(),
+					}
+				}
+			}()
+
+			p, pErr := _41_18(ctx)
+			if pErr != nil {
+				pErr = &cff.PredicateError{
+					Err:    pErr,
+					File:   "go.uber.org/cff/internal/tests/paralleloptions/paralleloptions.go",
+					Line:   41,
+					Column: 4,
+				}
+				defer task1.ran.Store(true)
+				taskEmitter.TaskError(ctx, pErr)
+				return pErr
+			}
+			if !p {
+				task1.outcome.Skip(cff.SkipPredicateFalse)
+				return nil
+			}
+
+			defer task1.ran.Store(true)
+
+			err = cff.Intercept(ctx, interceptor, task1.outcome.Info, func(ctx context.Context) (err error) {
+				err = _37_4(ctx)
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return
+			}
+			taskEmitter.TaskSuccess(ctx)
+			return
+		}
+
+		sched.Enqueue(ctx, cff.Job{
+			Run: task1.fn,
+		})
+		tasks = append(tasks, task1)
+
+		if err := sched.Wait(ctx); err != nil {
+			parallelEmitter.ParallelError(ctx, err)
+			cff.RethrowPanic(err, false)
+			return err
+		}
+		parallelEmitter.ParallelSuccess(ctx)
+		return nil /*line paralleloptions.go:43*/
+	}()
+	return ran, err
+}
+
+// FailingPredicate runs a task whose predicate fails with the given error.
+func FailingPredicate(ctx context.Context, predErr error) error {
+	return func() (err error) {
+
+		_50_22 := ctx
+
+		_52_4 := func() {}
+
+		_53_18 := func(context.Context) (bool, error) { return false, predErr }
+		ctx := _50_22
+		emitter := cff.NopEmitter()
+		interceptor := cff.InterceptorStack()
+
+		var (
+			parallelInfo = &cff.ParallelInfo{
+				File:   "go.uber.org/cff/internal/tests/paralleloptions/paralleloptions.go",
+				Line:   50,
+				Column: 9,
+			}
+			directiveInfo = &cff.DirectiveInfo{
+				Name:      parallelInfo.Name,
+				Directive: cff.ParallelDirective,
+				File:      parallelInfo.File,
+				Line:      parallelInfo.Line,
+				Column:    parallelInfo.Column,
+			}
+			parallelEmitter = cff.NopParallelEmitter()
+
+			schedInfo = &cff.SchedulerInfo{
+				Name:      parallelInfo.Name,
+				Directive: cff.ParallelDirective,
+				File:      parallelInfo.File,
+				Line:      parallelInfo.Line,
+				Column:    parallelInfo.Column,
+			}
+
+			// possibly unused
+			_ = parallelInfo
+			_ = directiveInfo
+		)
+
+		startTime := time.Now()
+		defer func() { parallelEmitter.ParallelDone(ctx, time.Since(startTime)) }()
+
+		schedEmitter := emitter.SchedulerInit(schedInfo)
+
+		sched := cff.NewScheduler(
+			cff.SchedulerParams{
+				Emitter: schedEmitter,
+			},
+		)
+
+		var tasks []*struct {
+			emitter cff.TaskEmitter
+			fn      func(context.Context) error
+			ran     cff.AtomicBool
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		}
+		defer func() {
+			for _, t := range tasks {
+				// Tasks that are still running report their own outcome
+				// once they finish.
+				if !t.ran.Load() && !t.outcome.Running() {
+					t.emitter.TaskSkipped(ctx, t.outcome.SkipReason(ctx, err))
+				}
+			}
+		}()
+
+		// go.uber.org/cff/internal/tests/paralleloptions/paralleloptions.go:52:4
+		task2 := new(struct {
+			emitter cff.TaskEmitter
+			fn      func(context.Context) error
+			ran     cff.AtomicBool
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		})
+		task2.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/paralleloptions/paralleloptions.go",
+			Line:   52,
+			Column: 4,
+		}
+		task2.emitter = cff.NopTaskEmitter()
+		task2.fn = func(ctx context.Context) (err error) {
+			task2.outcome.Start()
+			taskEmitter := task2.emitter
+			startTime := time.Now()
+			defer func() {
+				task2.outcome.Finish(err)
+				if task2.ran.Load() {
+					taskEmitter.TaskDone(ctx, time.Since(startTime))
+				}
+			}()
+
+			defer func() {
+				recovered := recover()
+				if recovered != nil {
+					taskEmitter.TaskPanic(ctx, recovered)
+					err = &cff.PanicError{
+						Value:      recovered,
+						Stacktrace: debug.Stack(),
+					}
+				}
+			}()
+
+			p, pErr := _53_18(ctx)
+			if pErr != nil {
+				pErr = &cff.PredicateError{
+					Err:    pErr,
+					File:   "go.uber.org/cff/internal/tests/paralleloptions/paralleloptions.go",
+					Line:   53,
+					Column: 4,
+				}
+				defer task2.ran.Store(true)
+				taskEmitter.TaskError(ctx, pErr)
+				return pErr
+			}
+			if !p {
+				task2.outcome.Skip(cff.SkipPredicateFalse)
+				return nil
+			}
+
+			defer task2.ran.Store(true)
+
+			err = cff.Intercept(ctx, interceptor, task2.outcome.Info, func(ctx context.Context) (err error) {
+				_52_4()
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return
+			}
+			taskEmitter.TaskSuccess(ctx)
+			return
+		}
+
+		sched.Enqueue(ctx, cff.Job{
+			Run: task2.fn,
+		})
+		tasks = append(tasks, task2)
+
+		if err := sched.Wait(ctx); err != nil {
+			parallelEmitter.ParallelError(ctx, err)
+			cff.RethrowPanic(err, false)
+			return err
+		}
+		parallelEmitter.ParallelSuccess(ctx)
+		return nil /*line paralleloptions.go:54*/
+	}()
+}
+
+// PredicateOnError runs a task whose predicate fails with the given error.
+// The task runs if onError is true.
+func PredicateOnError(ctx context.Context, predErr error, onError bool) (ran bool, _ error) {
+	return ran, func() (err error) {
+
+		_61_27 := ctx
+
+		_63_4 := func() { ran = true }
+
+		_64_18 := func() (bool, error) { return true, predErr }
+
+		_65_25 := onError
+		ctx := _61_27
+		emitter := cff.NopEmitter()
+		interceptor := cff.InterceptorStack()
+
+		var (
+			parallelInfo = &cff.ParallelInfo{
+				File:   "go.uber.org/cff/internal/tests/paralleloptions/paralleloptions.go",
+				Line:   61,
+				Column: 14,
+			}
+			directiveInfo = &cff.DirectiveInfo{
+				Name:      parallelInfo.Name,
+				Directive: cff.ParallelDirective,
+				File:      parallelInfo.File,
+				Line:      parallelInfo.Line,
+				Column:    parallelInfo.Column,
+			}
+			parallelEmitter = cff.NopParallelEmitter()
+
+			schedInfo = &cff.SchedulerInfo{
+				Name:      parallelInfo.Name,
+				Directive: cff.ParallelDirective,
+				File:      parallelInfo.File,
+				Line:      parallelInfo.Line,
+				Column:    parallelInfo.Column,
+			}
+
+			// possibly unused
+			_ = parallelInfo
+			_ = directiveInfo
+		)
+
+		startTime := time.Now()
+		defer func() { parallelEmitter.ParallelDone(ctx, time.Since(startTime)) }()
+
+		schedEmitter := emitter.SchedulerInit(schedInfo)
+
+		sched := cff.NewScheduler(
+			cff.SchedulerParams{
+				Emitter: schedEmitter,
+			},
+		)
+
+		var tasks []*struct {
+			emitter cff.TaskEmitter
+			fn      func(context.Context) error
+			ran     cff.AtomicBool
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		}
+		defer func() {
+			for _, t := range tasks {
+				// Tasks that are still running report their own outcome
+				// once they finish.
+				if !t.ran.Load() && !t.outcome.Running() {
+					t.emitter.TaskSkipped(ctx, t.outcome.SkipReason(ctx, err))
+				}
+			}
+		}()
+
+		// go.uber.org/cff/internal/tests/paralleloptions/paralleloptions.go:63:4
+		task3 := new(struct {
+			emitter cff.TaskEmitter
+			fn      func(context.Context) error
+			ran     cff.AtomicBool
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		})
+		task3.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/paralleloptions/paralleloptions.go",
+			Line:   63,
+			Column: 4,
+		}
+		task3.emitter = cff.NopTaskEmitter()
+		task3.fn = func(ctx context.Context) (err error) {
+			task3.outcome.Start()
+			taskEmitter := task3.emitter
+			startTime := time.Now()
+			defer func() {
+				task3.outcome.Finish(err)
+				if task3.ran.Load() {
+					taskEmitter.TaskDone(ctx, time.Since(startTime))
+				}
+			}()
+
+			defer func() {
+				recovered := recover()
+				if recovered != nil {
+					taskEmitter.TaskPanic(ctx, recovered)
+					err = &cff.PanicError{
+						Value:      recovered,
+						Stacktrace: debug.Stack(),
+					}
+				}
+			}()
+
+			p, pErr := _64_18()
+			if pErr != nil {
+				pErr = &cff.PredicateError{
+					Err:    pErr,
+					File:   "go.uber.org/cff/internal/tests/paralleloptions/paralleloptions.go",
+					Line:   64,
+					Column: 4,
+				}
+				taskEmitter.TaskErrorRecovered(ctx, pErr)
+				p = _65_25
+			}
+			if !p {
+				task3.outcome.Skip(cff.SkipPredicateFalse)
+				return nil
+			}
+
+			defer task3.ran.Store(true)
+
+			err = cff.Intercept(ctx, interceptor, task3.outcome.Info, func(ctx context.Context) (err error) {
+				_63_4()
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return
+			}
+			taskEmitter.TaskSuccess(ctx)
+			return
+		}
+
+		sched.Enqueue(ctx, cff.Job{
+			Run: task3.fn,
+		})
+		tasks = append(tasks, task3)
+
+		if err := sched.Wait(ctx); err != nil {
+			parallelEmitter.ParallelError(ctx, err)
+			cff.RethrowPanic(err, false)
+			return err
+		}
+		parallelEmitter.ParallelSuccess(ctx)
+		return nil /*line paralleloptions.go:66*/
+	}()
+}
+
+// Fallback runs a task that fails with the given error and a task that
+// panics, both recovered with cff.FallbackWith.
+func Fallback(ctx context.Context, e cff.Emitter, taskErr error) error {
+	return func() (err error) {
+
+		_73_22 := ctx
+
+		_74_19 := e
+
+		_76_4 := func() error { return taskErr }
+
+		_78_19 := "fails"
+
+		_81_4 := func() error { panic("great sadness") }
+
+		_83_19 := "panics"
+		ctx := _73_22
+		emitter := cff.EmitterStack(_74_19)
+		interceptor := cff.InterceptorStack()
+
+		var (
+			parallelInfo = &cff.ParallelInfo{
+				File:   "go.uber.org/cff/internal/tests/paralleloptions/paralleloptions.go",
+				Line:   73,
+				Column: 9,
+			}
+			directiveInfo = &cff.DirectiveInfo{
+				Name:      parallelInfo.Name,
+				Directive: cff.ParallelDirective,
+				File:      parallelInfo.File,
+				Line:      parallelInfo.Line,
+				Column:    parallelInfo.Column,
+			}
+			parallelEmitter = cff.NopParallelEmitter()
+
+			schedInfo = &cff.SchedulerInfo{
+				Name:      parallelInfo.Name,
+				Directive: cff.ParallelDirective,
+				File:      parallelInfo.File,
+				Line:      parallelInfo.Line,
+				Column:    parallelInfo.Column,
+			}
+
+			// possibly unused
+			_ = parallelInfo
+			_ = directiveInfo
+		)
+
+		startTime := time.Now()
+		defer func() { parallelEmitter.ParallelDone(ctx, time.Since(startTime)) }()
+
+		schedEmitter := emitter.SchedulerInit(schedInfo)
+
+		sched := cff.NewScheduler(
+			cff.SchedulerParams{
+				Emitter: schedEmitter,
+			},
+		)
+
+		var tasks []*struct {
+			emitter cff.TaskEmitter
+			fn      func(context.Context) error
+			ran     cff.AtomicBool
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		}
+		defer func() {
+			for _, t := range tasks {
+				// Tasks that are still running report their own outcome
+				// once they finish.
+				if !t.ran.Load() && !t.outcome.Running() {
+					t.emitter.TaskSkipped(ctx, t.outcome.SkipReason(ctx, err))
+				}
+			}
+		}()
+
+		// go.uber.org/cff/internal/tests/paralleloptions/paralleloptions.go:76:4
+		task4 := new(struct {
+			emitter cff.TaskEmitter
+			fn      func(context.Context) error
+			ran     cff.AtomicBool
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		})
+		task4.outcome.Info = &cff.TaskInfo{
+			Name:   _78_19,
+			File:   "go.uber.org/cff/internal/tests/paralleloptions/paralleloptions.go",
+			Line:   76,
+			Column: 4,
+		}
+		task4.emitter = emitter.TaskInit(task4.outcome.Info, directiveInfo)
+		task4.fn = func(ctx context.Context) (err error) {
+			task4.outcome.Start()
+			taskEmitter := task4.emitter
+			startTime := time.Now()
+			defer func() {
+				task4.outcome.Finish(err)
+				if task4.ran.Load() {
+					taskEmitter.TaskDone(ctx, time.Since(startTime))
+				}
+			}()
+
+			defer func() {
+				recovered := recover()
+				if recovered != nil {
+					taskEmitter.TaskPanicRecovered(ctx, recovered)
+				}
+			}()
+
+			defer task4.ran.Store(true)
+
+			err = cff.Intercept(ctx, interceptor, task4.outcome.Info, func(ctx context.Context) (err error) {
+				err = _76_4()
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskErrorRecovered(ctx, err)
+				return nil
+			}
+			taskEmitter.TaskSuccess(ctx)
+			return
+		}
+
+		sched.Enqueue(ctx, cff.Job{
+			Run: task4.fn,
+		})
+		tasks = append(tasks, task4)
+
+		// go.uber.org/cff/internal/tests/paralleloptions/paralleloptions.go:81:4
+		task5 := new(struct {
+			emitter cff.TaskEmitter
+			fn      func(context.Context) error
+			ran     cff.AtomicBool
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		})
+		task5.outcome.Info = &cff.TaskInfo{
+			Name:   _83_19,
+			File:   "go.uber.org/cff/internal/tests/paralleloptions/paralleloptions.go",
+			Line:   81,
+			Column: 4,
+		}
+		task5.emitter = emitter.TaskInit(task5.outcome.Info, directiveInfo)
+		task5.fn = func(ctx context.Context) (err error) {
+			task5.outcome.Start()
+			taskEmitter := task5.emitter
+			startTime := time.Now()
+			defer func() {
+				task5.outcome.Finish(err)
+				if task5.ran.Load() {
+					taskEmitter.TaskDone(ctx, time.Since(startTime))
+				}
+			}()
+
+			defer func() {
+				recovered := recover()
+				if recovered != nil {
+					taskEmitter.TaskPanicRecovered(ctx, recovered)
+				}
+			}()
+
+			defer task5.ran.Store(true)
+
+			err = cff.Intercept(ctx, interceptor, task5.outcome.Info, func(ctx context.Context) (err error) {
+				err = _81_4()
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskErrorRecovered(ctx, err)
+				return nil
+			}
+			taskEmitter.TaskSuccess(ctx)
+			return
+		}
+
+		sched.Enqueue(ctx, cff.Job{
+			Run: task5.fn,
+		})
+		tasks = append(tasks, task5)
+
+		if err := sched.Wait(ctx); err != nil {
+			parallelEmitter.ParallelError(ctx, err)
+			cff.RethrowPanic(err, false)
+			return err
+		}
+		parallelEmitter.ParallelSuccess(ctx)
+		return nil /*line paralleloptions.go:84*/
+	}()
+}
+
+// SliceFallback formats the given numbers, failing for negative numbers
+// and panicking for zero.
+// Failed numbers are formatted as "?" by the fallback.
+func SliceFallback(ctx context.Context, e cff.Emitter, nums []int) ([]string, error) {
+	out := make([]string, len(nums))
+	err := func() (err error) {
+
+		_93_22 := ctx
+
+		_94_19 := e
+
+		_95_26 := "SliceFallback"
+
+		_97_4 := func(idx, n int) error {
+			if n == 0 {
+				panic("zero")
+			}
+			if n < 0 {
+				return errors.New("negative")
+			}
+			out[idx] = strconv.Itoa(n)
+			return nil
+		}
+
+		_107_4 := nums
+
+		_108_22 := func(idx, n int, err error) {
+			out[idx] = "?"
+		}
+		ctx := _93_22
+		emitter := cff.EmitterStack(_94_19)
+		interceptor := cff.InterceptorStack()
+
+		var (
+			parallelInfo = &cff.ParallelInfo{
+				Name:   _95_26,
+				File:   "go.uber.org/cff/internal/tests/paralleloptions/paralleloptions.go",
+				Line:   93,
+				Column: 9,
+			}
+			directiveInfo = &cff.DirectiveInfo{
+				Name:      parallelInfo.Name,
+				Directive: cff.ParallelDirective,
+				File:      parallelInfo.File,
+				Line:      parallelInfo.Line,
+				Column:    parallelInfo.Column,
+			}
+			parallelEmitter = emitter.ParallelInit(parallelInfo)
+
+			schedInfo = &cff.SchedulerInfo{
+				Name:      parallelInfo.Name,
+				Directive: cff.ParallelDirective,
+				File:      parallelInfo.File,
+				Line:      parallelInfo.Line,
+				Column:    parallelInfo.Column,
+			}
+
+			// possibly unused
+			_ = parallelInfo
+			_ = directiveInfo
+		)
+
+		startTime := time.Now()
+		defer func() { parallelEmitter.ParallelDone(ctx, time.Since(startTime)) }()
+
+		schedEmitter := emitter.SchedulerInit(schedInfo)
+
+		sched := cff.NewScheduler(
+			cff.SchedulerParams{
+				Emitter: schedEmitter,
+			},
+		)
+
+		var tasks []*struct {
+			emitter cff.TaskEmitter
+			fn      func(context.Context) error
+			ran     cff.AtomicBool
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		}
+		defer func() {
+			for _, t := range tasks {
+				// Tasks that are still running report their own outcome
+				// once they finish.
+				if !t.ran.Load() && !t.outcome.Running() {
+					t.emitter.TaskSkipped(ctx, t.outcome.SkipReason(ctx, err))
+				}
+			}
+		}()
+
+		// go.uber.org/cff/internal/tests/paralleloptions/paralleloptions.go:96:3
+		sliceTask6Slice := _107_4
+		sliceTask6Info := &cff.TaskInfo{
+			Name:   parallelInfo.Name,
+			File:   "go.uber.org/cff/internal/tests/paralleloptions/paralleloptions.go",
+			Line:   96,
+			Column: 3,
+		}
+		// Reports failures of elements recovered by the fallback.
+		sliceTask6Emitter := emitter.TaskInit(sliceTask6Info, directiveInfo)
+		for idx, val := range sliceTask6Slice {
+			idx := idx
+			val := val
+			sliceTask6 := new(struct {
+				emitter cff.TaskEmitter
+				fn      func(context.Context) error
+				ran     cff.AtomicBool
+
+				outcome cff.TaskOutcome // reports why the task was skipped
+			})
+			sliceTask6.fn = func(ctx context.Context) (err error) {
+				defer func() {
+					recovered := recover()
+					if recovered != nil {
+						sliceTask6Emitter.TaskPanicRecovered(ctx, recovered)
+						err = &cff.PanicError{
+							Value:      recovered,
+							Stacktrace: debug.Stack(),
+						}
+					}
+					if err != nil {
+						if recovered == nil {
+							sliceTask6Emitter.TaskErrorRecovered(ctx, err)
+						}
+						_108_22(idx, val, err)
+						err = nil
+					}
+				}()
+				return cff.Intercept(ctx, interceptor, sliceTask6Info, func(ctx context.Context) (err error) {
+					err = _97_4(idx, val)
+					return
+				})
+			}
+			sched.Enqueue(ctx, cff.Job{
+				Run: sliceTask6.fn,
+			})
+		}
+
+		if err := sched.Wait(ctx); err != nil {
+			parallelEmitter.ParallelError(ctx, err)
+			cff.RethrowPanic(err, false)
+			return err
+		}
+		parallelEmitter.ParallelSuccess(ctx)
+		return nil /*line paralleloptions.go:111*/
+	}()
+	return out, err
+}
+
+// MapFallback formats the values of the given map, failing for negative
+// numbers.
+// The errors are recorded by the fallback.
+func MapFallback(ctx context.Context, nums map[string]int) (map[string]string, map[string]error, error) {
+	var (
+		mu   sync.Mutex
+		out  = make(map[string]string)
+		errs = make(map[string]error)
+	)
+	err := func() (err error) {
+
+		_125_22 := ctx
+
+		_127_4 := func(k string, n int) error {
+			if n < 0 {
+				return errors.New("negative")
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			out[k] = strconv.Itoa(n)
+			return nil
+		}
+
+		_136_4 := nums
+
+		_137_20 := func(_ context.Context, k string, _ int, err error) {
+			mu.Lock()
+			defer mu.Unlock()
+			errs[k] = err
+		}
+		ctx := _125_22
+		emitter := cff.NopEmitter()
+		interceptor := cff.InterceptorStack()
+
+		var (
+			parallelInfo = &cff.ParallelInfo{
+				File:   "go.uber.org/cff/internal/tests/paralleloptions/paralleloptions.go",
+				Line:   125,
+				Column: 9,
+			}
+			directiveInfo = &cff.DirectiveInfo{
+				Name:      parallelInfo.Name,
+				Directive: cff.ParallelDirective,
+				File:      parallelInfo.File,
+				Line:      parallelInfo.Line,
+				Column:    parallelInfo.Column,
+			}
+			parallelEmitter = cff.NopParallelEmitter()
+
+			schedInfo = &cff.SchedulerInfo{
+				Name:      parallelInfo.Name,
+				Directive: cff.ParallelDirective,
+				File:      parallelInfo.File,
+				Line:      parallelInfo.Line,
+				Column:    parallelInfo.Column,
+			}
+
+			// possibly unused
+			_ = parallelInfo
+			_ = directiveInfo
+		)
+
+		startTime := time.Now()
+		defer func() { parallelEmitter.ParallelDone(ctx, time.Since(startTime)) }()
+
+		schedEmitter := emitter.SchedulerInit(schedInfo)
+
+		sched := cff.NewScheduler(
+			cff.SchedulerParams{
+				Emitter: schedEmitter,
+			},
+		)
+
+		var tasks []*struct {
+			emitter cff.TaskEmitter
+			fn      func(context.Context) error
+			ran     cff.AtomicBool
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		}
+		defer func() {
+			for _, t := range tasks {
+				// Tasks that are still running report their own outcome
+				// once they finish.
+				if !t.ran.Load() && !t.outcome.Running() {
+					t.emitter.TaskSkipped(ctx, t.outcome.SkipReason(ctx, err))
+				}
+			}
+		}()
+
+		mapTask7Info := &cff.TaskInfo{
+			Name:   parallelInfo.Name,
+			File:   "go.uber.org/cff/internal/tests/paralleloptions/paralleloptions.go",
+			Line:   126,
+			Column: 3,
+		}
+		// go.uber.org/cff/internal/tests/paralleloptions/paralleloptions.go:126:3
+		for key, val := range _136_4 {
+			key := key
+			val := val
+			mapTask7 := new(struct {
+				emitter cff.TaskEmitter
+				fn      func(context.Context) error
+				ran     cff.AtomicBool
+
+				outcome cff.TaskOutcome // reports why the task was skipped
+			})
+			mapTask7.fn = func(ctx context.Context) (err error) {
+				defer func() {
+					recovered := recover()
+					if recovered != nil {
+						err = &cff.PanicError{
+							Value:      recovered,
+							Stacktrace: debug.Stack(),
+						}
+					}
+					if err != nil {
+						_137_20(ctx, key, val, err)
+						err = nil
+					}
+				}()
+
+				return cff.Intercept(ctx, interceptor, mapTask7Info, func(ctx context.Context) (err error) {
+					err = _127_4(key, val)
+					return
+				})
+			}
+
+			sched.Enqueue(ctx, cff.Job{
+				Run: mapTask7.fn,
+			})
+		}
+
+		if err := sched.Wait(ctx); err != nil {
+			parallelEmitter.ParallelError(ctx, err)
+			cff.RethrowPanic(err, false)
+			return err
+		}
+		parallelEmitter.ParallelSuccess(ctx)
+		return nil /*line paralleloptions.go:142*/
+	}()
+	return out, errs, err
+}
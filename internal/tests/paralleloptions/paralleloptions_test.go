@@ -0,0 +1,86 @@
+package paralleloptions
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/cff"
+	"go.uber.org/cff/internal/emittertest"
+)
+
+func TestGated(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("both", func(t *testing.T) {
+		e := emittertest.NewRecorder()
+		ran, err := Gated(ctx, e, true, true)
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{"foo", "bar"}, ran)
+	})
+
+	t.Run("one", func(t *testing.T) {
+		e := emittertest.NewRecorder()
+		ran, err := Gated(ctx, e, false, true)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"bar"}, ran)
+		assert.Equal(t, []string{"bar"}, e.Names(emittertest.TaskSuccess))
+		assert.Equal(t, map[string]cff.SkipKind{
+			"foo": cff.SkipPredicateFalse,
+		}, e.SkipKinds())
+	})
+}
+
+func TestFailingPredicate(t *testing.T) {
+	sadness := errors.New("great sadness")
+	err := FailingPredicate(context.Background(), sadness)
+	require.ErrorIs(t, err, sadness)
+
+	var predErr *cff.PredicateError
+	require.ErrorAs(t, err, &predErr)
+	assert.Contains(t, predErr.File, "paralleloptions.go")
+}
+
+func TestPredicateOnError(t *testing.T) {
+	ctx := context.Background()
+	sadness := errors.New("great sadness")
+
+	t.Run("false", func(t *testing.T) {
+		ran, err := PredicateOnError(ctx, sadness, false)
+		require.NoError(t, err)
+		assert.False(t, ran)
+	})
+
+	t.Run("true", func(t *testing.T) {
+		ran, err := PredicateOnError(ctx, sadness, true)
+		require.NoError(t, err)
+		assert.True(t, ran)
+	})
+}
+
+func TestFallback(t *testing.T) {
+	e := emittertest.NewRecorder()
+	require.NoError(t, Fallback(context.Background(), e, errors.New("great sadness")))
+	assert.Equal(t, []string{"fails"}, e.Names(emittertest.TaskErrorRecovered))
+	assert.Equal(t, []string{"panics"}, e.Names(emittertest.TaskPanicRecovered))
+	assert.Empty(t, e.EventsOf(emittertest.TaskSuccess))
+}
+
+func TestSliceFallback(t *testing.T) {
+	e := emittertest.NewRecorder()
+	out, err := SliceFallback(context.Background(), e, []int{1, -2, 0, 4})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"1", "?", "?", "4"}, out)
+	assert.Equal(t, []string{"SliceFallback"}, e.Names(emittertest.TaskErrorRecovered))
+	assert.Equal(t, []string{"SliceFallback"}, e.Names(emittertest.TaskPanicRecovered))
+}
+
+func TestMapFallback(t *testing.T) {
+	out, errs, err := MapFallback(context.Background(), map[string]int{"a": 1, "b": -2})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"a": "1"}, out)
+	require.Len(t, errs, 1)
+	assert.EqualError(t, errs["b"], "negative")
+}
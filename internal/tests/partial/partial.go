@@ -0,0 +1,122 @@
+//go:build cff
+// +build cff
+
+// Package partial tests Flows with cff.ContinueOnError
+// that produce partial results.
+package partial
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"go.uber.org/cff"
+)
+
+// Request is the input to the flows in this package.
+type Request struct {
+	FailUser    bool
+	FailProfile bool
+	Panic       bool
+}
+
+// User is a result of the flows in this package.
+type User struct{ Name string }
+
+// Profile is a result of the flows in this package.
+// It depends on the User.
+type Profile struct{ Bio string }
+
+// Settings is a result of the flows in this package.
+// It does not depend on anything.
+type Settings struct{ Theme string }
+
+func getUser(req *Request) (*User, error) {
+	if req.FailUser {
+		return nil, errors.New("user failed")
+	}
+	return &User{Name: "foo"}, nil
+}
+
+func getProfile(req *Request, u *User) (*Profile, error) {
+	if req.FailProfile {
+		return nil, errors.New("profile failed")
+	}
+	return &Profile{Bio: "bio of " + u.Name}, nil
+}
+
+func getSettings(req *Request) *Settings {
+	if req.Panic {
+		panic("great sadness")
+	}
+	return &Settings{Theme: "dark"}
+}
+
+// Aggregate runs a best-effort flow that fills in whichever results it
+// can produce.
+func Aggregate(ctx context.Context, req *Request) (u *User, p *Profile, s *Settings, err error) {
+	err = cff.Flow(ctx,
+		cff.Params(req),
+		cff.Results(&u, &p, &s),
+		cff.Task(getUser),
+		cff.Task(getProfile),
+		cff.Task(getSettings),
+		cff.ContinueOnError(true),
+	)
+	return u, p, s, err
+}
+
+// AggregateMaybe is a variant of Aggregate that reports
+// which results were produced.
+func AggregateMaybe(ctx context.Context, req *Request) (
+	u cff.Maybe[*User],
+	p cff.Maybe[*Profile],
+	s cff.Maybe[*Settings],
+	err error,
+) {
+	err = cff.Flow(ctx,
+		cff.Params(req),
+		cff.Results(&u, &p, &s),
+		cff.Task(getUser),
+		cff.Task(getProfile),
+		cff.Task(getSettings),
+		cff.ContinueOnError(true),
+	)
+	return u, p, s, err
+}
+
+// AggregateStopOnError is a variant of Aggregate that decides at runtime
+// whether to continue on error.
+// The provided results are left unchanged on failure
+// if continueOnError is false.
+func AggregateStopOnError(ctx context.Context, req *Request, continueOnError bool) (u *User, s *Settings, err error) {
+	u = &User{Name: "unchanged"}
+	s = &Settings{Theme: "unchanged"}
+	err = cff.Flow(ctx,
+		cff.Params(req),
+		cff.Results(&u, &s),
+		cff.Task(getUser),
+		cff.Task(getSettings),
+		cff.ContinueOnError(continueOnError),
+	)
+	return u, s, err
+}
+
+// ParamResult is a flow that returns one of its parameters as-is,
+// alongside a result that may not be produced.
+func ParamResult(ctx context.Context, req *Request) (
+	r cff.Maybe[*Request],
+	n cff.Maybe[int],
+	err error,
+) {
+	err = cff.Flow(ctx,
+		cff.Params(req),
+		cff.Results(&r, &n),
+		cff.Task(func(u *User) (int, error) {
+			return strconv.Atoi(u.Name)
+		}),
+		cff.Task(getUser),
+		cff.ContinueOnError(true),
+	)
+	return r, n, err
+}
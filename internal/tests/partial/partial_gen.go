@@ -0,0 +1,1153 @@
+//go:build !cff
+// +build !cff
+
+// Package partial tests Flows with cff.ContinueOnError
+// that produce partial results.
+package partial
+
+import (
+	"context"
+	"errors"
+	"runtime/debug"
+	"strconv"
+	"time"
+
+	"go.uber.org/cff"
+)
+
+// Request is the input to the flows in this package.
+type Request struct {
+	FailUser    bool
+	FailProfile bool
+	Panic       bool
+}
+
+// User is a result of the flows in this package.
+type User struct{ Name string }
+
+// Profile is a result of the flows in this package.
+// It depends on the User.
+type Profile struct{ Bio string }
+
+// Settings is a result of the flows in this package.
+// It does not depend on anything.
+type Settings struct{ Theme string }
+
+func getUser(req *Request) (*User, error) {
+	if req.FailUser {
+		return nil, errors.New("user failed")
+	}
+	return &User{Name: "foo"}, nil
+}
+
+func getProfile(req *Request, u *User) (*Profile, error) {
+	if req.FailProfile {
+		return nil, errors.New("profile failed")
+	}
+	return &Profile{Bio: "bio of " + u.Name}, nil
+}
+
+func getSettings(req *Request) *Settings {
+	if req.Panic {
+		panic("great sadness")
+	}
+	return &Settings{Theme: "dark"}
+}
+
+// Aggregate runs a best-effort flow that fills in whichever results it
+// can produce.
+func Aggregate(ctx context.Context, req *Request) (u *User, p *Profile, s *Settings, err error) {
+	err = func() (err error) {
+
+		_58_17 := ctx
+
+		_59_14 := req
+
+		_60_15 := &u
+
+		_60_19 := &p
+
+		_60_23 := &s
+
+		_61_12 := getUser
+
+		_62_12 := getProfile
+
+		_63_12 := getSettings
+
+		_64_23 := true
+		ctx := _58_17
+		var v1 *Request = _59_14
+		emitter := cff.NopEmitter()
+		interceptor := cff.InterceptorStack()
+
+		var (
+			flowInfo = &cff.FlowInfo{
+				File:   "go.uber.org/cff/internal/tests/partial/partial.go",
+				Line:   58,
+				Column: 8,
+			}
+			flowEmitter = cff.NopFlowEmitter()
+
+			schedInfo = &cff.SchedulerInfo{
+				Name:      flowInfo.Name,
+				Directive: cff.FlowDirective,
+				File:      flowInfo.File,
+				Line:      flowInfo.Line,
+				Column:    flowInfo.Column,
+			}
+
+			// possibly unused
+			_ = flowInfo
+		)
+
+		startTime := time.Now()
+		defer func() { flowEmitter.FlowDone(ctx, time.Since(startTime)) }()
+
+		schedEmitter := emitter.SchedulerInit(schedInfo)
+
+		sched := cff.NewScheduler(
+			cff.SchedulerParams{
+				ContinueOnError: _64_23, Emitter: schedEmitter,
+			},
+		)
+
+		var tasks []*struct {
+			emitter cff.TaskEmitter
+			ran     cff.AtomicBool
+			run     func(context.Context) error
+			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		}
+		defer func() {
+			for _, t := range tasks {
+				if !t.ran.Load() {
+					t.emitter.TaskSkipped(ctx, t.outcome.SkipReason(ctx, err))
+				}
+			}
+		}()
+
+		// go.uber.org/cff/internal/tests/partial/partial.go:61:12
+		var (
+			v2 *User
+		)
+
+		var task0Produced bool
+		task0 := new(struct {
+			emitter cff.TaskEmitter
+			ran     cff.AtomicBool
+			run     func(context.Context) error
+			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		})
+		task0.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/partial/partial.go",
+			Line:   61,
+			Column: 12,
+		}
+		task0.emitter = cff.NopTaskEmitter()
+		task0.run = func(ctx context.Context) (err error) {
+			taskEmitter := task0.emitter
+			startTime := time.Now()
+			defer func() {
+				task0.outcome.Finish(err)
+				if task0.ran.Load() {
+					taskEmitter.TaskDone(ctx, time.Since(startTime))
+				}
+			}()
+
+			defer func() {
+				recovered := recover()
+				if recovered != nil {
+					taskEmitter.TaskPanic(ctx, recovered)
+					err = &cff.PanicError{
+						Value:      recovered,
+						Stacktrace: debug.Stack(),
+					}
+				}
+			}()
+
+			defer task0.ran.Store(true)
+
+			err = cff.Intercept(ctx, interceptor, task0.outcome.Info, func(ctx context.Context) (err error) {
+				v2, err = _61_12(v1)
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return err
+			} else {
+				taskEmitter.TaskSuccess(ctx)
+			}
+
+			task0Produced = true
+
+			return
+		}
+
+		task0.job = sched.Enqueue(ctx, cff.Job{
+			Run: task0.run,
+		})
+		tasks = append(tasks, task0)
+
+		// go.uber.org/cff/internal/tests/partial/partial.go:62:12
+		var (
+			v3 *Profile
+		)
+
+		var task1Produced bool
+		task1 := new(struct {
+			emitter cff.TaskEmitter
+			ran     cff.AtomicBool
+			run     func(context.Context) error
+			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		})
+		task1.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/partial/partial.go",
+			Line:   62,
+			Column: 12,
+		}
+		task1.outcome.Dependencies = []*cff.TaskOutcome{
+			&task0.outcome,
+		}
+		task1.emitter = cff.NopTaskEmitter()
+		task1.run = func(ctx context.Context) (err error) {
+			taskEmitter := task1.emitter
+			startTime := time.Now()
+			defer func() {
+				task1.outcome.Finish(err)
+				if task1.ran.Load() {
+					taskEmitter.TaskDone(ctx, time.Since(startTime))
+				}
+			}()
+
+			defer func() {
+				recovered := recover()
+				if recovered != nil {
+					taskEmitter.TaskPanic(ctx, recovered)
+					err = &cff.PanicError{
+						Value:      recovered,
+						Stacktrace: debug.Stack(),
+					}
+				}
+			}()
+
+			defer task1.ran.Store(true)
+
+			err = cff.Intercept(ctx, interceptor, task1.outcome.Info, func(ctx context.Context) (err error) {
+				v3, err = _62_12(v1, v2)
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return err
+			} else {
+				taskEmitter.TaskSuccess(ctx)
+			}
+
+			task1Produced = true
+
+			return
+		}
+
+		task1.job = sched.Enqueue(ctx, cff.Job{
+			Run: task1.run,
+			Dependencies: []*cff.ScheduledJob{
+				task0.job,
+			},
+		})
+		tasks = append(tasks, task1)
+
+		// go.uber.org/cff/internal/tests/partial/partial.go:63:12
+		var (
+			v4 *Settings
+		)
+
+		var task2Produced bool
+		task2 := new(struct {
+			emitter cff.TaskEmitter
+			ran     cff.AtomicBool
+			run     func(context.Context) error
+			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		})
+		task2.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/partial/partial.go",
+			Line:   63,
+			Column: 12,
+		}
+		task2.emitter = cff.NopTaskEmitter()
+		task2.run = func(ctx context.Context) (err error) {
+			taskEmitter := task2.emitter
+			startTime := time.Now()
+			defer func() {
+				task2.outcome.Finish(err)
+				if task2.ran.Load() {
+					taskEmitter.TaskDone(ctx, time.Since(startTime))
+				}
+			}()
+
+			defer func() {
+				recovered := recover()
+				if recovered != nil {
+					taskEmitter.TaskPanic(ctx, recovered)
+					err = &cff.PanicError{
+						Value:      recovered,
+						Stacktrace: debug.Stack(),
+					}
+				}
+			}()
+
+			defer task2.ran.Store(true)
+
+			err = cff.Intercept(ctx, interceptor, task2.outcome.Info, func(ctx context.Context) (err error) {
+				v4 = _63_12(v1)
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return err
+			} else {
+				taskEmitter.TaskSuccess(ctx)
+			}
+
+			task2Produced = true
+
+			return
+		}
+
+		task2.job = sched.Enqueue(ctx, cff.Job{
+			Run: task2.run,
+		})
+		tasks = append(tasks, task2)
+
+		if err := sched.Wait(ctx); err != nil {
+			flowEmitter.FlowError(ctx, err)
+			cff.RethrowPanic(err, false)
+
+			// Write the results that were produced despite the failure.
+			// Tasks may still be running if the scheduler stopped early
+			// or the context was cancelled, so leave results unchanged
+			// in those cases.
+			if _64_23 && ctx.Err() == nil {
+				if task0Produced {
+					*(_60_15) = v2 // *go.uber.org/cff/internal/tests/partial.User
+				}
+				if task1Produced {
+					*(_60_19) = v3 // *go.uber.org/cff/internal/tests/partial.Profile
+				}
+				if task2Produced {
+					*(_60_23) = v4 // *go.uber.org/cff/internal/tests/partial.Settings
+				}
+			}
+			return err
+		}
+
+		*(_60_15) = v2 // *go.uber.org/cff/internal/tests/partial.User
+		*(_60_19) = v3 // *go.uber.org/cff/internal/tests/partial.Profile
+		*(_60_23) = v4 // *go.uber.org/cff/internal/tests/partial.Settings
+
+		flowEmitter.FlowSuccess(ctx)
+		return nil
+	}()
+	return u, p, s, err
+}
+
+// AggregateMaybe is a variant of Aggregate that reports
+// which results were produced.
+func AggregateMaybe(ctx context.Context, req *Request) (
+	u cff.Maybe[*User],
+	p cff.Maybe[*Profile],
+	s cff.Maybe[*Settings],
+	err error,
+) {
+	err = func() (err error) {
+
+		_77_17 := ctx
+
+		_78_14 := req
+
+		_79_15 := &u
+
+		_79_19 := &p
+
+		_79_23 := &s
+
+		_80_12 := getUser
+
+		_81_12 := getProfile
+
+		_82_12 := getSettings
+
+		_83_23 := true
+		ctx := _77_17
+		var v1 *Request = _78_14
+		emitter := cff.NopEmitter()
+		interceptor := cff.InterceptorStack()
+
+		var (
+			flowInfo = &cff.FlowInfo{
+				File:   "go.uber.org/cff/internal/tests/partial/partial.go",
+				Line:   77,
+				Column: 8,
+			}
+			flowEmitter = cff.NopFlowEmitter()
+
+			schedInfo = &cff.SchedulerInfo{
+				Name:      flowInfo.Name,
+				Directive: cff.FlowDirective,
+				File:      flowInfo.File,
+				Line:      flowInfo.Line,
+				Column:    flowInfo.Column,
+			}
+
+			// possibly unused
+			_ = flowInfo
+		)
+
+		startTime := time.Now()
+		defer func() { flowEmitter.FlowDone(ctx, time.Since(startTime)) }()
+
+		schedEmitter := emitter.SchedulerInit(schedInfo)
+
+		sched := cff.NewScheduler(
+			cff.SchedulerParams{
+				ContinueOnError: _83_23, Emitter: schedEmitter,
+			},
+		)
+
+		var tasks []*struct {
+			emitter cff.TaskEmitter
+			ran     cff.AtomicBool
+			run     func(context.Context) error
+			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		}
+		defer func() {
+			for _, t := range tasks {
+				if !t.ran.Load() {
+					t.emitter.TaskSkipped(ctx, t.outcome.SkipReason(ctx, err))
+				}
+			}
+		}()
+
+		// go.uber.org/cff/internal/tests/partial/partial.go:80:12
+		var (
+			v2 *User
+		)
+
+		var task3Produced bool
+		task3 := new(struct {
+			emitter cff.TaskEmitter
+			ran     cff.AtomicBool
+			run     func(context.Context) error
+			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		})
+		task3.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/partial/partial.go",
+			Line:   80,
+			Column: 12,
+		}
+		task3.emitter = cff.NopTaskEmitter()
+		task3.run = func(ctx context.Context) (err error) {
+			taskEmitter := task3.emitter
+			startTime := time.Now()
+			defer func() {
+				task3.outcome.Finish(err)
+				if task3.ran.Load() {
+					taskEmitter.TaskDone(ctx, time.Since(startTime))
+				}
+			}()
+
+			defer func() {
+				recovered := recover()
+				if recovered != nil {
+					taskEmitter.TaskPanic(ctx, recovered)
+					err = &cff.PanicError{
+						Value:      recovered,
+						Stacktrace: debug.Stack(),
+					}
+				}
+			}()
+
+			defer task3.ran.Store(true)
+
+			err = cff.Intercept(ctx, interceptor, task3.outcome.Info, func(ctx context.Context) (err error) {
+				v2, err = _80_12(v1)
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return err
+			} else {
+				taskEmitter.TaskSuccess(ctx)
+			}
+
+			task3Produced = true
+
+			return
+		}
+
+		task3.job = sched.Enqueue(ctx, cff.Job{
+			Run: task3.run,
+		})
+		tasks = append(tasks, task3)
+
+		// go.uber.org/cff/internal/tests/partial/partial.go:81:12
+		var (
+			v3 *Profile
+		)
+
+		var task4Produced bool
+		task4 := new(struct {
+			emitter cff.TaskEmitter
+			ran     cff.AtomicBool
+			run     func(context.Context) error
+			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		})
+		task4.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/partial/partial.go",
+			Line:   81,
+			Column: 12,
+		}
+		task4.outcome.Dependencies = []*cff.TaskOutcome{
+			&task3.outcome,
+		}
+		task4.emitter = cff.NopTaskEmitter()
+		task4.run = func(ctx context.Context) (err error) {
+			taskEmitter := task4.emitter
+			startTime := time.Now()
+			defer func() {
+				task4.outcome.Finish(err)
+				if task4.ran.Load() {
+					taskEmitter.TaskDone(ctx, time.Since(startTime))
+				}
+			}()
+
+			defer func() {
+				recovered := recover()
+				if recovered != nil {
+					taskEmitter.TaskPanic(ctx, recovered)
+					err = &cff.PanicError{
+						Value:      recovered,
+						Stacktrace: debug.Stack(),
+					}
+				}
+			}()
+
+			defer task4.ran.Store(true)
+
+			err = cff.Intercept(ctx, interceptor, task4.outcome.Info, func(ctx context.Context) (err error) {
+				v3, err = _81_12(v1, v2)
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return err
+			} else {
+				taskEmitter.TaskSuccess(ctx)
+			}
+
+			task4Produced = true
+
+			return
+		}
+
+		task4.job = sched.Enqueue(ctx, cff.Job{
+			Run: task4.run,
+			Dependencies: []*cff.ScheduledJob{
+				task3.job,
+			},
+		})
+		tasks = append(tasks, task4)
+
+		// go.uber.org/cff/internal/tests/partial/partial.go:82:12
+		var (
+			v4 *Settings
+		)
+
+		var task5Produced bool
+		task5 := new(struct {
+			emitter cff.TaskEmitter
+			ran     cff.AtomicBool
+			run     func(context.Context) error
+			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		})
+		task5.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/partial/partial.go",
+			Line:   82,
+			Column: 12,
+		}
+		task5.emitter = cff.NopTaskEmitter()
+		task5.run = func(ctx context.Context) (err error) {
+			taskEmitter := task5.emitter
+			startTime := time.Now()
+			defer func() {
+				task5.outcome.Finish(err)
+				if task5.ran.Load() {
+					taskEmitter.TaskDone(ctx, time.Since(startTime))
+				}
+			}()
+
+			defer func() {
+				recovered := recover()
+				if recovered != nil {
+					taskEmitter.TaskPanic(ctx, recovered)
+					err = &cff.PanicError{
+						Value:      recovered,
+						Stacktrace: debug.Stack(),
+					}
+				}
+			}()
+
+			defer task5.ran.Store(true)
+
+			err = cff.Intercept(ctx, interceptor, task5.outcome.Info, func(ctx context.Context) (err error) {
+				v4 = _82_12(v1)
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return err
+			} else {
+				taskEmitter.TaskSuccess(ctx)
+			}
+
+			task5Produced = true
+
+			return
+		}
+
+		task5.job = sched.Enqueue(ctx, cff.Job{
+			Run: task5.run,
+		})
+		tasks = append(tasks, task5)
+
+		if err := sched.Wait(ctx); err != nil {
+			flowEmitter.FlowError(ctx, err)
+			cff.RethrowPanic(err, false)
+
+			// Write the results that were produced despite the failure.
+			// Tasks may still be running if the scheduler stopped early
+			// or the context was cancelled, so leave results unchanged
+			// in those cases.
+			if _83_23 && ctx.Err() == nil {
+				*(_79_15) = cff.Maybe[*User]{
+					Value: v2,
+					Valid: task3Produced,
+				} // *go.uber.org/cff/internal/tests/partial.User
+				*(_79_19) = cff.Maybe[*Profile]{
+					Value: v3,
+					Valid: task4Produced,
+				} // *go.uber.org/cff/internal/tests/partial.Profile
+				*(_79_23) = cff.Maybe[*Settings]{
+					Value: v4,
+					Valid: task5Produced,
+				} // *go.uber.org/cff/internal/tests/partial.Settings
+			}
+			return err
+		}
+
+		*(_79_15) = cff.Maybe[*User]{
+			Value: v2,
+			Valid: task3Produced,
+		} // *go.uber.org/cff/internal/tests/partial.User
+		*(_79_19) = cff.Maybe[*Profile]{
+			Value: v3,
+			Valid: task4Produced,
+		} // *go.uber.org/cff/internal/tests/partial.Profile
+		*(_79_23) = cff.Maybe[*Settings]{
+			Value: v4,
+			Valid: task5Produced,
+		} // *go.uber.org/cff/internal/tests/partial.Settings
+
+		flowEmitter.FlowSuccess(ctx)
+		return nil
+	}()
+	return u, p, s, err
+}
+
+// AggregateStopOnError is a variant of Aggregate that decides at runtime
+// whether to continue on error.
+// The provided results are left unchanged on failure
+// if continueOnError is false.
+func AggregateStopOnError(ctx context.Context, req *Request, continueOnError bool) (u *User, s *Settings, err error) {
+	u = &User{Name: "unchanged"}
+	s = &Settings{Theme: "unchanged"}
+	err = func() (err error) {
+
+		_95_17 := ctx
+
+		_96_14 := req
+
+		_97_15 := &u
+
+		_97_19 := &s
+
+		_98_12 := getUser
+
+		_99_12 := getSettings
+
+		_100_23 := continueOnError
+		ctx := _95_17
+		var v1 *Request = _96_14
+		emitter := cff.NopEmitter()
+		interceptor := cff.InterceptorStack()
+
+		var (
+			flowInfo = &cff.FlowInfo{
+				File:   "go.uber.org/cff/internal/tests/partial/partial.go",
+				Line:   95,
+				Column: 8,
+			}
+			flowEmitter = cff.NopFlowEmitter()
+
+			schedInfo = &cff.SchedulerInfo{
+				Name:      flowInfo.Name,
+				Directive: cff.FlowDirective,
+				File:      flowInfo.File,
+				Line:      flowInfo.Line,
+				Column:    flowInfo.Column,
+			}
+
+			// possibly unused
+			_ = flowInfo
+		)
+
+		startTime := time.Now()
+		defer func() { flowEmitter.FlowDone(ctx, time.Since(startTime)) }()
+
+		schedEmitter := emitter.SchedulerInit(schedInfo)
+
+		sched := cff.NewScheduler(
+			cff.SchedulerParams{
+				ContinueOnError: _100_23, Emitter: schedEmitter,
+			},
+		)
+
+		var tasks []*struct {
+			emitter cff.TaskEmitter
+			ran     cff.AtomicBool
+			run     func(context.Context) error
+			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		}
+		defer func() {
+			for _, t := range tasks {
+				if !t.ran.Load() {
+					t.emitter.TaskSkipped(ctx, t.outcome.SkipReason(ctx, err))
+				}
+			}
+		}()
+
+		// go.uber.org/cff/internal/tests/partial/partial.go:98:12
+		var (
+			v2 *User
+		)
+
+		var task6Produced bool
+		task6 := new(struct {
+			emitter cff.TaskEmitter
+			ran     cff.AtomicBool
+			run     func(context.Context) error
+			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		})
+		task6.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/partial/partial.go",
+			Line:   98,
+			Column: 12,
+		}
+		task6.emitter = cff.NopTaskEmitter()
+		task6.run = func(ctx context.Context) (err error) {
+			taskEmitter := task6.emitter
+			startTime := time.Now()
+			defer func() {
+				task6.outcome.Finish(err)
+				if task6.ran.Load() {
+					taskEmitter.TaskDone(ctx, time.Since(startTime))
+				}
+			}()
+
+			defer func() {
+				recovered := recover()
+				if recovered != nil {
+					taskEmitter.TaskPanic(ctx, recovered)
+					err = &cff.PanicError{
+						Value:      recovered,
+						Stacktrace: debug.Stack(),
+					}
+				}
+			}()
+
+			defer task6.ran.Store(true)
+
+			err = cff.Intercept(ctx, interceptor, task6.outcome.Info, func(ctx context.Context) (err error) {
+				v2, err = _98_12(v1)
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return err
+			} else {
+				taskEmitter.TaskSuccess(ctx)
+			}
+
+			task6Produced = true
+
+			return
+		}
+
+		task6.job = sched.Enqueue(ctx, cff.Job{
+			Run: task6.run,
+		})
+		tasks = append(tasks, task6)
+
+		// go.uber.org/cff/internal/tests/partial/partial.go:99:12
+		var (
+			v4 *Settings
+		)
+
+		var task7Produced bool
+		task7 := new(struct {
+			emitter cff.TaskEmitter
+			ran     cff.AtomicBool
+			run     func(context.Context) error
+			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		})
+		task7.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/partial/partial.go",
+			Line:   99,
+			Column: 12,
+		}
+		task7.emitter = cff.NopTaskEmitter()
+		task7.run = func(ctx context.Context) (err error) {
+			taskEmitter := task7.emitter
+			startTime := time.Now()
+			defer func() {
+				task7.outcome.Finish(err)
+				if task7.ran.Load() {
+					taskEmitter.TaskDone(ctx, time.Since(startTime))
+				}
+			}()
+
+			defer func() {
+				recovered := recover()
+				if recovered != nil {
+					taskEmitter.TaskPanic(ctx, recovered)
+					err = &cff.PanicError{
+						Value:      recovered,
+						Stacktrace: debug.Stack(),
+					}
+				}
+			}()
+
+			defer task7.ran.Store(true)
+
+			err = cff.Intercept(ctx, interceptor, task7.outcome.Info, func(ctx context.Context) (err error) {
+				v4 = _99_12(v1)
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return err
+			} else {
+				taskEmitter.TaskSuccess(ctx)
+			}
+
+			task7Produced = true
+
+			return
+		}
+
+		task7.job = sched.Enqueue(ctx, cff.Job{
+			Run: task7.run,
+		})
+		tasks = append(tasks, task7)
+
+		if err := sched.Wait(ctx); err != nil {
+			flowEmitter.FlowError(ctx, err)
+			cff.RethrowPanic(err, false)
+
+			// Write the results that were produced despite the failure.
+			// Tasks may still be running if the scheduler stopped early
+			// or the context was cancelled, so leave results unchanged
+			// in those cases.
+			if _100_23 && ctx.Err() == nil {
+				if task6Produced {
+					*(_97_15) = v2 // *go.uber.org/cff/internal/tests/partial.User
+				}
+				if task7Produced {
+					*(_97_19) = v4 // *go.uber.org/cff/internal/tests/partial.Settings
+				}
+			}
+			return err
+		}
+
+		*(_97_15) = v2 // *go.uber.org/cff/internal/tests/partial.User
+		*(_97_19) = v4 // *go.uber.org/cff/internal/tests/partial.Settings
+
+		flowEmitter.FlowSuccess(ctx)
+		return nil
+	}()
+	return u, s, err
+}
+
+// ParamResult is a flow that returns one of its parameters as-is,
+// alongside a result that may not be produced.
+func ParamResult(ctx context.Context, req *Request) (
+	r cff.Maybe[*Request],
+	n cff.Maybe[int],
+	err error,
+) {
+	err = func() (err error) {
+
+		_112_17 := ctx
+
+		_113_14 := req
+
+		_114_15 := &r
+
+		_114_19 := &n
+
+		_115_12 := func(u *User) (int, error) {
+			return strconv.Atoi(u.Name)
+		}
+
+		_118_12 := getUser
+
+		_119_23 := true
+		ctx := _112_17
+		var v1 *Request = _113_14
+		emitter := cff.NopEmitter()
+		interceptor := cff.InterceptorStack()
+
+		var (
+			flowInfo = &cff.FlowInfo{
+				File:   "go.uber.org/cff/internal/tests/partial/partial.go",
+				Line:   112,
+				Column: 8,
+			}
+			flowEmitter = cff.NopFlowEmitter()
+
+			schedInfo = &cff.SchedulerInfo{
+				Name:      flowInfo.Name,
+				Directive: cff.FlowDirective,
+				File:      flowInfo.File,
+				Line:      flowInfo.Line,
+				Column:    flowInfo.Column,
+			}
+
+			// possibly unused
+			_ = flowInfo
+		)
+
+		startTime := time.Now()
+		defer func() { flowEmitter.FlowDone(ctx, time.Since(startTime)) }()
+
+		schedEmitter := emitter.SchedulerInit(schedInfo)
+
+		sched := cff.NewScheduler(
+			cff.SchedulerParams{
+				ContinueOnError: _119_23, Emitter: schedEmitter,
+			},
+		)
+
+		var tasks []*struct {
+			emitter cff.TaskEmitter
+			ran     cff.AtomicBool
+			run     func(context.Context) error
+			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		}
+		defer func() {
+			for _, t := range tasks {
+				if !t.ran.Load() {
+					t.emitter.TaskSkipped(ctx, t.outcome.SkipReason(ctx, err))
+				}
+			}
+		}()
+
+		// go.uber.org/cff/internal/tests/partial/partial.go:118:12
+		var (
+			v2 *User
+		)
+
+		task9 := new(struct {
+			emitter cff.TaskEmitter
+			ran     cff.AtomicBool
+			run     func(context.Context) error
+			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		})
+		task9.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/partial/partial.go",
+			Line:   118,
+			Column: 12,
+		}
+		task9.emitter = cff.NopTaskEmitter()
+		task9.run = func(ctx context.Context) (err error) {
+			taskEmitter := task9.emitter
+			startTime := time.Now()
+			defer func() {
+				task9.outcome.Finish(err)
+				if task9.ran.Load() {
+					taskEmitter.TaskDone(ctx, time.Since(startTime))
+				}
+			}()
+
+			defer func() {
+				recovered := recover()
+				if recovered != nil {
+					taskEmitter.TaskPanic(ctx, recovered)
+					err = &cff.PanicError{
+						Value:      recovered,
+						Stacktrace: debug.Stack(),
+					}
+				}
+			}()
+
+			defer task9.ran.Store(true)
+
+			err = cff.Intercept(ctx, interceptor, task9.outcome.Info, func(ctx context.Context) (err error) {
+				v2, err = _118_12(v1)
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return err
+			} else {
+				taskEmitter.TaskSuccess(ctx)
+			}
+
+			return
+		}
+
+		task9.job = sched.Enqueue(ctx, cff.Job{
+			Run: task9.run,
+		})
+		tasks = append(tasks, task9)
+
+		// go.uber.org/cff/internal/tests/partial/partial.go:115:12
+		var (
+			v5 int
+		)
+
+		var task8Produced bool
+		task8 := new(struct {
+			emitter cff.TaskEmitter
+			ran     cff.AtomicBool
+			run     func(context.Context) error
+			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		})
+		task8.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/partial/partial.go",
+			Line:   115,
+			Column: 12,
+		}
+		task8.outcome.Dependencies = []*cff.TaskOutcome{
+			&task9.outcome,
+		}
+		task8.emitter = cff.NopTaskEmitter()
+		task8.run = func(ctx context.Context) (err error) {
+			taskEmitter := task8.emitter
+			startTime := time.Now()
+			defer func() {
+				task8.outcome.Finish(err)
+				if task8.ran.Load() {
+					taskEmitter.TaskDone(ctx, time.Since(startTime))
+				}
+			}()
+
+			defer func() {
+				recovered := recover()
+				if recovered != nil {
+					taskEmitter.TaskPanic(ctx, recovered)
+					err = &cff.PanicError{
+						Value:      recovered,
+						Stacktrace: debug.Stack(),
+					}
+				}
+			}()
+
+			defer task8.ran.Store(true)
+
+			err = cff.Intercept(ctx, interceptor, task8.outcome.Info, func(ctx context.Context) (err error) {
+				v5, err = _115_12(v2)
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return err
+			} else {
+				taskEmitter.TaskSuccess(ctx)
+			}
+
+			task8Produced = true
+
+			return
+		}
+
+		task8.job = sched.Enqueue(ctx, cff.Job{
+			Run: task8.run,
+			Dependencies: []*cff.ScheduledJob{
+				task9.job,
+			},
+		})
+		tasks = append(tasks, task8)
+
+		if err := sched.Wait(ctx); err != nil {
+			flowEmitter.FlowError(ctx, err)
+			cff.RethrowPanic(err, false)
+
+			// Write the results that were produced despite the failure.
+			// Tasks may still be running if the scheduler stopped early
+			// or the context was cancelled, so leave results unchanged
+			// in those cases.
+			if _119_23 && ctx.Err() == nil {
+				*(_114_15) = cff.Maybe[*Request]{
+					Value: v1,
+					Valid: true,
+				} // *go.uber.org/cff/internal/tests/partial.Request
+				*(_114_19) = cff.Maybe[int]{
+					Value: v5,
+					Valid: task8Produced,
+				} // int
+			}
+			return err
+		}
+
+		*(_114_15) = cff.Maybe[*Request]{
+			Value: v1,
+			Valid: true,
+		} // *go.uber.org/cff/internal/tests/partial.Request
+		*(_114_19) = cff.Maybe[int]{
+			Value: v5,
+			Valid: task8Produced,
+		} // int
+
+		flowEmitter.FlowSuccess(ctx)
+		return nil
+	}()
+	return r, n, err
+}
@@ -0,0 +1,113 @@
+package partial
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/cff"
+	"go.uber.org/multierr"
+)
+
+func TestAggregate(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("success", func(t *testing.T) {
+		u, p, s, err := Aggregate(ctx, &Request{})
+		require.NoError(t, err)
+		assert.Equal(t, &User{Name: "foo"}, u)
+		assert.Equal(t, &Profile{Bio: "bio of foo"}, p)
+		assert.Equal(t, &Settings{Theme: "dark"}, s)
+	})
+
+	t.Run("leaf failed", func(t *testing.T) {
+		u, p, s, err := Aggregate(ctx, &Request{FailProfile: true})
+		require.EqualError(t, err, "profile failed")
+		assert.Equal(t, &User{Name: "foo"}, u)
+		assert.Nil(t, p)
+		assert.Equal(t, &Settings{Theme: "dark"}, s)
+	})
+
+	t.Run("dependency failed", func(t *testing.T) {
+		u, p, s, err := Aggregate(ctx, &Request{FailUser: true})
+		require.EqualError(t, err, "user failed",
+			"skipped dependents must not add errors")
+		assert.Nil(t, u)
+		assert.Nil(t, p)
+		assert.Equal(t, &Settings{Theme: "dark"}, s)
+	})
+
+	t.Run("multiple failures", func(t *testing.T) {
+		u, p, s, err := Aggregate(ctx, &Request{FailProfile: true, Panic: true})
+		require.Error(t, err)
+		errs := multierr.Errors(err)
+		require.Len(t, errs, 2)
+
+		var pe *cff.PanicError
+		assert.ErrorAs(t, err, &pe)
+		assert.ErrorContains(t, err, "profile failed")
+
+		assert.Equal(t, &User{Name: "foo"}, u)
+		assert.Nil(t, p)
+		assert.Nil(t, s)
+	})
+}
+
+func TestAggregateMaybe(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("success", func(t *testing.T) {
+		u, p, s, err := AggregateMaybe(ctx, &Request{})
+		require.NoError(t, err)
+		assert.Equal(t, cff.Some(&User{Name: "foo"}), u)
+		assert.Equal(t, cff.Some(&Profile{Bio: "bio of foo"}), p)
+		assert.Equal(t, cff.Some(&Settings{Theme: "dark"}), s)
+	})
+
+	t.Run("dependency failed", func(t *testing.T) {
+		u, p, s, err := AggregateMaybe(ctx, &Request{FailUser: true})
+		require.EqualError(t, err, "user failed")
+		assert.False(t, u.Valid)
+		assert.False(t, p.Valid)
+		assert.Equal(t, cff.Some(&Settings{Theme: "dark"}), s)
+	})
+}
+
+func TestAggregateStopOnError(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("continue", func(t *testing.T) {
+		u, s, err := AggregateStopOnError(ctx, &Request{FailUser: true}, true)
+		require.EqualError(t, err, "user failed")
+		assert.Equal(t, &User{Name: "unchanged"}, u,
+			"results that weren't produced must be left unchanged")
+		assert.Equal(t, &Settings{Theme: "dark"}, s)
+	})
+
+	t.Run("stop", func(t *testing.T) {
+		u, s, err := AggregateStopOnError(ctx, &Request{FailUser: true}, false)
+		require.EqualError(t, err, "user failed")
+		assert.Equal(t, &User{Name: "unchanged"}, u)
+		assert.Equal(t, &Settings{Theme: "unchanged"}, s)
+	})
+
+	t.Run("cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(ctx)
+		cancel()
+
+		u, s, err := AggregateStopOnError(ctx, &Request{}, true)
+		require.ErrorIs(t, err, context.Canceled)
+		assert.Equal(t, &User{Name: "unchanged"}, u)
+		assert.Equal(t, &Settings{Theme: "unchanged"}, s)
+	})
+}
+
+func TestParamResult(t *testing.T) {
+	req := &Request{}
+	r, n, err := ParamResult(context.Background(), req)
+	require.Error(t, err, "user name is not a number")
+	assert.Equal(t, cff.Some(req), r,
+		"parameters must always be valid")
+	assert.False(t, n.Valid)
+}
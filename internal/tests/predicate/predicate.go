@@ -5,6 +5,7 @@ package predicate
 
 import (
 	"context"
+	"errors"
 
 	"go.uber.org/cff"
 )
@@ -178,3 +179,110 @@ func PanickedWithFallback() (string, error) {
 	)
 	return s, err
 }
+
+// Failing is a flow that runs a task with a predicate that fails with the
+// given error.
+func Failing(predErr error) error {
+	var s string
+	return cff.Flow(
+		context.Background(),
+		cff.Results(&s),
+		cff.Task(
+			func() string {
+				return "foo"
+			},
+			cff.Predicate(func(ctx context.Context) (bool, error) {
+				return true, predErr
+			}),
+		),
+	)
+}
+
+// FailingWithFallback is a flow that runs a task with a failing predicate
+// and a fallback.
+// The task itself doesn't return an error.
+func FailingWithFallback(emitter cff.Emitter) (string, error) {
+	var s string
+	err := cff.Flow(
+		context.Background(),
+		cff.WithEmitter(emitter),
+		cff.Results(&s),
+		cff.Task(
+			func() string {
+				return "foo"
+			},
+			cff.Predicate(func() (bool, error) {
+				return true, errors.New("great sadness")
+			}),
+			cff.FallbackWith("predicate-fallback"),
+			cff.Instrument("task"),
+		),
+	)
+	return s, err
+}
+
+// FailingOptional is a flow that runs an optional task with a failing
+// predicate.
+func FailingOptional() (cff.Maybe[string], error) {
+	var s cff.Maybe[string]
+	err := cff.Flow(
+		context.Background(),
+		cff.Results(&s),
+		cff.Task(
+			func() string {
+				return "foo"
+			},
+			cff.Predicate(func() (bool, error) {
+				return true, errors.New("great sadness")
+			}),
+			cff.Optional(),
+		),
+	)
+	return s, err
+}
+
+// FailingWithDefault is a flow that runs a task with a failing predicate
+// that defaults to the given value.
+func FailingWithDefault(emitter cff.Emitter, value bool) (string, error) {
+	var s string
+	err := cff.Flow(
+		context.Background(),
+		cff.WithEmitter(emitter),
+		cff.Results(&s),
+		cff.Task(
+			func() (string, error) {
+				return "foo", nil
+			},
+			cff.Predicate(func() (bool, error) {
+				return !value, errors.New("great sadness")
+			}),
+			cff.PredicateOnError(value),
+			cff.Instrument("task"),
+		),
+	)
+	return s, err
+}
+
+// FailingCase is a flow with a cff.Switch whose first case has a predicate
+// that fails with the given error.
+// The predicate defaults to false, so the default case runs if it fails.
+func FailingCase(predErr error) (string, error) {
+	var s string
+	err := cff.Flow(
+		context.Background(),
+		cff.Results(&s),
+		cff.Switch(
+			cff.Case(
+				func() (bool, error) {
+					return true, predErr
+				},
+				func() string { return "case" },
+				cff.PredicateOnError(false),
+			),
+			cff.Default(func() string {
+				return "default"
+			}),
+		),
+	)
+	return s, err
+}
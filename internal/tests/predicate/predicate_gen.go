@@ -5,6 +5,7 @@ package predicate
 
 import (
 	"context"
+	"errors"
 	"runtime/debug"
 	"time"
 
@@ -17,23 +18,24 @@ func Simple(f func(), pred bool) error {
 	var s string
 	return func() (err error) {
 
-		_17_3 := context.Background()
+		_18_3 := context.Background()
 
-		_18_15 := &s
+		_19_15 := &s
 
-		_20_4 := func() string {
+		_21_4 := func() string {
 			f()
 			return "foo"
 		}
 
-		_24_18 := func() bool { return pred }
-		ctx := _17_3
+		_25_18 := func() bool { return pred }
+		ctx := _18_3
 		emitter := cff.NopEmitter()
+		interceptor := cff.InterceptorStack()
 
 		var (
 			flowInfo = &cff.FlowInfo{
 				File:   "go.uber.org/cff/internal/tests/predicate/predicate.go",
-				Line:   16,
+				Line:   17,
 				Column: 9,
 			}
 			flowEmitter = cff.NopFlowEmitter()
@@ -66,16 +68,18 @@ func Simple(f func(), pred bool) error {
 			ran     cff.AtomicBool
 			run     func(context.Context) error
 			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
 		}
 		defer func() {
 			for _, t := range tasks {
 				if !t.ran.Load() {
-					t.emitter.TaskSkipped(ctx, err)
+					t.emitter.TaskSkipped(ctx, t.outcome.SkipReason(ctx, err))
 				}
 			}
 		}()
 
-		// go.uber.org/cff/internal/tests/predicate/predicate.go:24:4
+		// go.uber.org/cff/internal/tests/predicate/predicate.go:25:4
 		var p0 bool
 		var p0PanicRecover interface{}
 		var p0PanicStacktrace []byte
@@ -92,7 +96,7 @@ func Simple(f func(), pred bool) error {
 					p0PanicStacktrace = debug.Stack()
 				}
 			}()
-			p0 = _24_18()
+			p0 = _25_18()
 			return nil
 		}
 
@@ -100,21 +104,30 @@ func Simple(f func(), pred bool) error {
 			Run: pred1.run,
 		})
 
-		// go.uber.org/cff/internal/tests/predicate/predicate.go:20:4
+		// go.uber.org/cff/internal/tests/predicate/predicate.go:21:4
 		var (
 			v1 string
 		)
+
 		task0 := new(struct {
 			emitter cff.TaskEmitter
 			ran     cff.AtomicBool
 			run     func(context.Context) error
 			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
 		})
+		task0.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/predicate/predicate.go",
+			Line:   21,
+			Column: 4,
+		}
 		task0.emitter = cff.NopTaskEmitter()
 		task0.run = func(ctx context.Context) (err error) {
 			taskEmitter := task0.emitter
 			startTime := time.Now()
 			defer func() {
+				task0.outcome.Finish(err)
 				if task0.ran.Load() {
 					taskEmitter.TaskDone(ctx, time.Since(startTime))
 				}
@@ -140,14 +153,22 @@ func Simple(f func(), pred bool) error {
 			}()
 
 			if !p0 {
+				task0.outcome.Skip(cff.SkipPredicateFalse)
 				return nil
 			}
 
 			defer task0.ran.Store(true)
 
-			v1 = _20_4()
-
-			taskEmitter.TaskSuccess(ctx)
+			err = cff.Intercept(ctx, interceptor, task0.outcome.Info, func(ctx context.Context) (err error) {
+				v1 = _21_4()
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return err
+			} else {
+				taskEmitter.TaskSuccess(ctx)
+			}
 
 			return
 		}
@@ -162,10 +183,11 @@ func Simple(f func(), pred bool) error {
 
 		if err := sched.Wait(ctx); err != nil {
 			flowEmitter.FlowError(ctx, err)
+			cff.RethrowPanic(err, false)
 			return err
 		}
 
-		*(_18_15) = v1 // string
+		*(_19_15) = v1 // string
 
 		flowEmitter.FlowSuccess(ctx)
 		return nil
@@ -178,27 +200,28 @@ func SimpleWithContextTask() error {
 	var s string
 	return func() (err error) {
 
-		_34_3 := context.Background()
+		_35_3 := context.Background()
 
-		_35_15 := &s
+		_36_15 := &s
 
-		_36_14 := int64(2)
+		_37_14 := int64(2)
 
-		_38_4 := func(ctx context.Context) string {
+		_39_4 := func(ctx context.Context) string {
 			return "foo"
 		}
 
-		_42_5 := func(int64) bool {
+		_43_5 := func(int64) bool {
 			return false
 		}
-		ctx := _34_3
-		var v2 int64 = _36_14
+		ctx := _35_3
+		var v2 int64 = _37_14
 		emitter := cff.NopEmitter()
+		interceptor := cff.InterceptorStack()
 
 		var (
 			flowInfo = &cff.FlowInfo{
 				File:   "go.uber.org/cff/internal/tests/predicate/predicate.go",
-				Line:   33,
+				Line:   34,
 				Column: 9,
 			}
 			flowEmitter = cff.NopFlowEmitter()
@@ -231,16 +254,18 @@ func SimpleWithContextTask() error {
 			ran     cff.AtomicBool
 			run     func(context.Context) error
 			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
 		}
 		defer func() {
 			for _, t := range tasks {
 				if !t.ran.Load() {
-					t.emitter.TaskSkipped(ctx, err)
+					t.emitter.TaskSkipped(ctx, t.outcome.SkipReason(ctx, err))
 				}
 			}
 		}()
 
-		// go.uber.org/cff/internal/tests/predicate/predicate.go:41:4
+		// go.uber.org/cff/internal/tests/predicate/predicate.go:42:4
 		var p0 bool
 		var p0PanicRecover interface{}
 		var p0PanicStacktrace []byte
@@ -257,7 +282,7 @@ func SimpleWithContextTask() error {
 					p0PanicStacktrace = debug.Stack()
 				}
 			}()
-			p0 = _42_5(v2)
+			p0 = _43_5(v2)
 			return nil
 		}
 
@@ -265,21 +290,30 @@ func SimpleWithContextTask() error {
 			Run: pred1.run,
 		})
 
-		// go.uber.org/cff/internal/tests/predicate/predicate.go:38:4
+		// go.uber.org/cff/internal/tests/predicate/predicate.go:39:4
 		var (
 			v1 string
 		)
+
 		task1 := new(struct {
 			emitter cff.TaskEmitter
 			ran     cff.AtomicBool
 			run     func(context.Context) error
 			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
 		})
+		task1.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/predicate/predicate.go",
+			Line:   39,
+			Column: 4,
+		}
 		task1.emitter = cff.NopTaskEmitter()
 		task1.run = func(ctx context.Context) (err error) {
 			taskEmitter := task1.emitter
 			startTime := time.Now()
 			defer func() {
+				task1.outcome.Finish(err)
 				if task1.ran.Load() {
 					taskEmitter.TaskDone(ctx, time.Since(startTime))
 				}
@@ -305,14 +339,22 @@ func SimpleWithContextTask() error {
 			}()
 
 			if !p0 {
+				task1.outcome.Skip(cff.SkipPredicateFalse)
 				return nil
 			}
 
 			defer task1.ran.Store(true)
 
-			v1 = _38_4(ctx)
-
-			taskEmitter.TaskSuccess(ctx)
+			err = cff.Intercept(ctx, interceptor, task1.outcome.Info, func(ctx context.Context) (err error) {
+				v1 = _39_4(ctx)
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return err
+			} else {
+				taskEmitter.TaskSuccess(ctx)
+			}
 
 			return
 		}
@@ -327,10 +369,11 @@ func SimpleWithContextTask() error {
 
 		if err := sched.Wait(ctx); err != nil {
 			flowEmitter.FlowError(ctx, err)
+			cff.RethrowPanic(err, false)
 			return err
 		}
 
-		*(_35_15) = v1 // string
+		*(_36_15) = v1 // string
 
 		flowEmitter.FlowSuccess(ctx)
 		return nil
@@ -343,27 +386,28 @@ func SimpleWithContextPredicate() error {
 	var s string
 	return func() (err error) {
 
-		_54_3 := context.Background()
+		_55_3 := context.Background()
 
-		_55_15 := &s
+		_56_15 := &s
 
-		_56_14 := int64(2)
+		_57_14 := int64(2)
 
-		_58_4 := func() string {
+		_59_4 := func() string {
 			return "foo"
 		}
 
-		_62_5 := func(context.Context, int64) bool {
+		_63_5 := func(context.Context, int64) bool {
 			return false
 		}
-		ctx := _54_3
-		var v2 int64 = _56_14
+		ctx := _55_3
+		var v2 int64 = _57_14
 		emitter := cff.NopEmitter()
+		interceptor := cff.InterceptorStack()
 
 		var (
 			flowInfo = &cff.FlowInfo{
 				File:   "go.uber.org/cff/internal/tests/predicate/predicate.go",
-				Line:   53,
+				Line:   54,
 				Column: 9,
 			}
 			flowEmitter = cff.NopFlowEmitter()
@@ -396,16 +440,18 @@ func SimpleWithContextPredicate() error {
 			ran     cff.AtomicBool
 			run     func(context.Context) error
 			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
 		}
 		defer func() {
 			for _, t := range tasks {
 				if !t.ran.Load() {
-					t.emitter.TaskSkipped(ctx, err)
+					t.emitter.TaskSkipped(ctx, t.outcome.SkipReason(ctx, err))
 				}
 			}
 		}()
 
-		// go.uber.org/cff/internal/tests/predicate/predicate.go:61:4
+		// go.uber.org/cff/internal/tests/predicate/predicate.go:62:4
 		var p0 bool
 		var p0PanicRecover interface{}
 		var p0PanicStacktrace []byte
@@ -422,7 +468,7 @@ func SimpleWithContextPredicate() error {
 					p0PanicStacktrace = debug.Stack()
 				}
 			}()
-			p0 = _62_5(ctx, v2)
+			p0 = _63_5(ctx, v2)
 			return nil
 		}
 
@@ -430,21 +476,30 @@ func SimpleWithContextPredicate() error {
 			Run: pred1.run,
 		})
 
-		// go.uber.org/cff/internal/tests/predicate/predicate.go:58:4
+		// go.uber.org/cff/internal/tests/predicate/predicate.go:59:4
 		var (
 			v1 string
 		)
+
 		task2 := new(struct {
 			emitter cff.TaskEmitter
 			ran     cff.AtomicBool
 			run     func(context.Context) error
 			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
 		})
+		task2.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/predicate/predicate.go",
+			Line:   59,
+			Column: 4,
+		}
 		task2.emitter = cff.NopTaskEmitter()
 		task2.run = func(ctx context.Context) (err error) {
 			taskEmitter := task2.emitter
 			startTime := time.Now()
 			defer func() {
+				task2.outcome.Finish(err)
 				if task2.ran.Load() {
 					taskEmitter.TaskDone(ctx, time.Since(startTime))
 				}
@@ -470,14 +525,22 @@ func SimpleWithContextPredicate() error {
 			}()
 
 			if !p0 {
+				task2.outcome.Skip(cff.SkipPredicateFalse)
 				return nil
 			}
 
 			defer task2.ran.Store(true)
 
-			v1 = _58_4()
-
-			taskEmitter.TaskSuccess(ctx)
+			err = cff.Intercept(ctx, interceptor, task2.outcome.Info, func(ctx context.Context) (err error) {
+				v1 = _59_4()
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return err
+			} else {
+				taskEmitter.TaskSuccess(ctx)
+			}
 
 			return
 		}
@@ -492,10 +555,11 @@ func SimpleWithContextPredicate() error {
 
 		if err := sched.Wait(ctx); err != nil {
 			flowEmitter.FlowError(ctx, err)
+			cff.RethrowPanic(err, false)
 			return err
 		}
 
-		*(_55_15) = v1 // string
+		*(_56_15) = v1 // string
 
 		flowEmitter.FlowSuccess(ctx)
 		return nil
@@ -508,27 +572,28 @@ func SimpleWithContextTaskAndPredicate() error {
 	var s string
 	return func() (err error) {
 
-		_74_3 := context.Background()
+		_75_3 := context.Background()
 
-		_75_15 := &s
+		_76_15 := &s
 
-		_76_14 := int64(2)
+		_77_14 := int64(2)
 
-		_78_4 := func(ctx context.Context) string {
+		_79_4 := func(ctx context.Context) string {
 			return "foo"
 		}
 
-		_82_5 := func(context.Context, int64) bool {
+		_83_5 := func(context.Context, int64) bool {
 			return false
 		}
-		ctx := _74_3
-		var v2 int64 = _76_14
+		ctx := _75_3
+		var v2 int64 = _77_14
 		emitter := cff.NopEmitter()
+		interceptor := cff.InterceptorStack()
 
 		var (
 			flowInfo = &cff.FlowInfo{
 				File:   "go.uber.org/cff/internal/tests/predicate/predicate.go",
-				Line:   73,
+				Line:   74,
 				Column: 9,
 			}
 			flowEmitter = cff.NopFlowEmitter()
@@ -561,16 +626,18 @@ func SimpleWithContextTaskAndPredicate() error {
 			ran     cff.AtomicBool
 			run     func(context.Context) error
 			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
 		}
 		defer func() {
 			for _, t := range tasks {
 				if !t.ran.Load() {
-					t.emitter.TaskSkipped(ctx, err)
+					t.emitter.TaskSkipped(ctx, t.outcome.SkipReason(ctx, err))
 				}
 			}
 		}()
 
-		// go.uber.org/cff/internal/tests/predicate/predicate.go:81:4
+		// go.uber.org/cff/internal/tests/predicate/predicate.go:82:4
 		var p0 bool
 		var p0PanicRecover interface{}
 		var p0PanicStacktrace []byte
@@ -587,7 +654,7 @@ func SimpleWithContextTaskAndPredicate() error {
 					p0PanicStacktrace = debug.Stack()
 				}
 			}()
-			p0 = _82_5(ctx, v2)
+			p0 = _83_5(ctx, v2)
 			return nil
 		}
 
@@ -595,21 +662,30 @@ func SimpleWithContextTaskAndPredicate() error {
 			Run: pred1.run,
 		})
 
-		// go.uber.org/cff/internal/tests/predicate/predicate.go:78:4
+		// go.uber.org/cff/internal/tests/predicate/predicate.go:79:4
 		var (
 			v1 string
 		)
+
 		task3 := new(struct {
 			emitter cff.TaskEmitter
 			ran     cff.AtomicBool
 			run     func(context.Context) error
 			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
 		})
+		task3.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/predicate/predicate.go",
+			Line:   79,
+			Column: 4,
+		}
 		task3.emitter = cff.NopTaskEmitter()
 		task3.run = func(ctx context.Context) (err error) {
 			taskEmitter := task3.emitter
 			startTime := time.Now()
 			defer func() {
+				task3.outcome.Finish(err)
 				if task3.ran.Load() {
 					taskEmitter.TaskDone(ctx, time.Since(startTime))
 				}
@@ -635,14 +711,22 @@ func SimpleWithContextTaskAndPredicate() error {
 			}()
 
 			if !p0 {
+				task3.outcome.Skip(cff.SkipPredicateFalse)
 				return nil
 			}
 
 			defer task3.ran.Store(true)
 
-			v1 = _78_4(ctx)
-
-			taskEmitter.TaskSuccess(ctx)
+			err = cff.Intercept(ctx, interceptor, task3.outcome.Info, func(ctx context.Context) (err error) {
+				v1 = _79_4(ctx)
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return err
+			} else {
+				taskEmitter.TaskSuccess(ctx)
+			}
 
 			return
 		}
@@ -657,10 +741,11 @@ func SimpleWithContextTaskAndPredicate() error {
 
 		if err := sched.Wait(ctx); err != nil {
 			flowEmitter.FlowError(ctx, err)
+			cff.RethrowPanic(err, false)
 			return err
 		}
 
-		*(_75_15) = v1 // string
+		*(_76_15) = v1 // string
 
 		flowEmitter.FlowSuccess(ctx)
 		return nil
@@ -677,29 +762,30 @@ func ExtraDependencies() error {
 	var out t3
 	return func() (err error) {
 
-		_98_3 := context.Background()
+		_99_3 := context.Background()
 
-		_99_14 := int(42)
+		_100_14 := int(42)
 
-		_100_15 := &out
+		_101_15 := &out
 
-		_102_4 := func(int) t1 { return t1{} }
+		_103_4 := func(int) t1 { return t1{} }
 
-		_104_4 := func() t2 { return t2{} }
+		_105_4 := func() t2 { return t2{} }
 
-		_106_4 := func(t2) t3 { return t3{} }
+		_107_4 := func(t2) t3 { return t3{} }
 
-		_108_5 := func(int, t1) bool {
+		_109_5 := func(int, t1) bool {
 			return true
 		}
-		ctx := _98_3
-		var v3 int = _99_14
+		ctx := _99_3
+		var v3 int = _100_14
 		emitter := cff.NopEmitter()
+		interceptor := cff.InterceptorStack()
 
 		var (
 			flowInfo = &cff.FlowInfo{
 				File:   "go.uber.org/cff/internal/tests/predicate/predicate.go",
-				Line:   97,
+				Line:   98,
 				Column: 9,
 			}
 			flowEmitter = cff.NopFlowEmitter()
@@ -732,30 +818,41 @@ func ExtraDependencies() error {
 			ran     cff.AtomicBool
 			run     func(context.Context) error
 			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
 		}
 		defer func() {
 			for _, t := range tasks {
 				if !t.ran.Load() {
-					t.emitter.TaskSkipped(ctx, err)
+					t.emitter.TaskSkipped(ctx, t.outcome.SkipReason(ctx, err))
 				}
 			}
 		}()
 
-		// go.uber.org/cff/internal/tests/predicate/predicate.go:102:4
+		// go.uber.org/cff/internal/tests/predicate/predicate.go:103:4
 		var (
 			v4 t1
 		)
+
 		task4 := new(struct {
 			emitter cff.TaskEmitter
 			ran     cff.AtomicBool
 			run     func(context.Context) error
 			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
 		})
+		task4.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/predicate/predicate.go",
+			Line:   103,
+			Column: 4,
+		}
 		task4.emitter = cff.NopTaskEmitter()
 		task4.run = func(ctx context.Context) (err error) {
 			taskEmitter := task4.emitter
 			startTime := time.Now()
 			defer func() {
+				task4.outcome.Finish(err)
 				if task4.ran.Load() {
 					taskEmitter.TaskDone(ctx, time.Since(startTime))
 				}
@@ -774,9 +871,16 @@ func ExtraDependencies() error {
 
 			defer task4.ran.Store(true)
 
-			v4 = _102_4(v3)
-
-			taskEmitter.TaskSuccess(ctx)
+			err = cff.Intercept(ctx, interceptor, task4.outcome.Info, func(ctx context.Context) (err error) {
+				v4 = _103_4(v3)
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return err
+			} else {
+				taskEmitter.TaskSuccess(ctx)
+			}
 
 			return
 		}
@@ -786,21 +890,30 @@ func ExtraDependencies() error {
 		})
 		tasks = append(tasks, task4)
 
-		// go.uber.org/cff/internal/tests/predicate/predicate.go:104:4
+		// go.uber.org/cff/internal/tests/predicate/predicate.go:105:4
 		var (
 			v5 t2
 		)
+
 		task5 := new(struct {
 			emitter cff.TaskEmitter
 			ran     cff.AtomicBool
 			run     func(context.Context) error
 			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
 		})
+		task5.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/predicate/predicate.go",
+			Line:   105,
+			Column: 4,
+		}
 		task5.emitter = cff.NopTaskEmitter()
 		task5.run = func(ctx context.Context) (err error) {
 			taskEmitter := task5.emitter
 			startTime := time.Now()
 			defer func() {
+				task5.outcome.Finish(err)
 				if task5.ran.Load() {
 					taskEmitter.TaskDone(ctx, time.Since(startTime))
 				}
@@ -819,9 +932,16 @@ func ExtraDependencies() error {
 
 			defer task5.ran.Store(true)
 
-			v5 = _104_4()
-
-			taskEmitter.TaskSuccess(ctx)
+			err = cff.Intercept(ctx, interceptor, task5.outcome.Info, func(ctx context.Context) (err error) {
+				v5 = _105_4()
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return err
+			} else {
+				taskEmitter.TaskSuccess(ctx)
+			}
 
 			return
 		}
@@ -831,7 +951,7 @@ func ExtraDependencies() error {
 		})
 		tasks = append(tasks, task5)
 
-		// go.uber.org/cff/internal/tests/predicate/predicate.go:107:4
+		// go.uber.org/cff/internal/tests/predicate/predicate.go:108:4
 		var p0 bool
 		var p0PanicRecover interface{}
 		var p0PanicStacktrace []byte
@@ -848,7 +968,7 @@ func ExtraDependencies() error {
 					p0PanicStacktrace = debug.Stack()
 				}
 			}()
-			p0 = _108_5(v3, v4)
+			p0 = _109_5(v3, v4)
 			return nil
 		}
 
@@ -859,21 +979,34 @@ func ExtraDependencies() error {
 			},
 		})
 
-		// go.uber.org/cff/internal/tests/predicate/predicate.go:106:4
+		// go.uber.org/cff/internal/tests/predicate/predicate.go:107:4
 		var (
 			v6 t3
 		)
+
 		task6 := new(struct {
 			emitter cff.TaskEmitter
 			ran     cff.AtomicBool
 			run     func(context.Context) error
 			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
 		})
+		task6.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/predicate/predicate.go",
+			Line:   107,
+			Column: 4,
+		}
+		task6.outcome.Dependencies = []*cff.TaskOutcome{
+			&task5.outcome,
+			&task4.outcome,
+		}
 		task6.emitter = cff.NopTaskEmitter()
 		task6.run = func(ctx context.Context) (err error) {
 			taskEmitter := task6.emitter
 			startTime := time.Now()
 			defer func() {
+				task6.outcome.Finish(err)
 				if task6.ran.Load() {
 					taskEmitter.TaskDone(ctx, time.Since(startTime))
 				}
@@ -899,14 +1032,22 @@ func ExtraDependencies() error {
 			}()
 
 			if !p0 {
+				task6.outcome.Skip(cff.SkipPredicateFalse)
 				return nil
 			}
 
 			defer task6.ran.Store(true)
 
-			v6 = _106_4(v5)
-
-			taskEmitter.TaskSuccess(ctx)
+			err = cff.Intercept(ctx, interceptor, task6.outcome.Info, func(ctx context.Context) (err error) {
+				v6 = _107_4(v5)
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return err
+			} else {
+				taskEmitter.TaskSuccess(ctx)
+			}
 
 			return
 		}
@@ -922,10 +1063,11 @@ func ExtraDependencies() error {
 
 		if err := sched.Wait(ctx); err != nil {
 			flowEmitter.FlowError(ctx, err)
+			cff.RethrowPanic(err, false)
 			return err
 		}
 
-		*(_100_15) = v6 // go.uber.org/cff/internal/tests/predicate.t3
+		*(_101_15) = v6 // go.uber.org/cff/internal/tests/predicate.t3
 
 		flowEmitter.FlowSuccess(ctx)
 		return nil
@@ -939,30 +1081,31 @@ func MultiplePredicates() error {
 	var b bool
 	return func() (err error) {
 
-		_122_3 := context.Background()
+		_123_3 := context.Background()
 
-		_123_15 := &s
+		_124_15 := &s
 
-		_123_19 := &b
+		_124_19 := &b
 
-		_125_4 := func() string {
+		_126_4 := func() string {
 			return "foo"
 		}
 
-		_128_18 := func() bool { return true }
+		_129_18 := func() bool { return true }
 
-		_131_4 := func() bool {
+		_132_4 := func() bool {
 			return true
 		}
 
-		_134_18 := func() bool { return false }
-		ctx := _122_3
+		_135_18 := func() bool { return false }
+		ctx := _123_3
 		emitter := cff.NopEmitter()
+		interceptor := cff.InterceptorStack()
 
 		var (
 			flowInfo = &cff.FlowInfo{
 				File:   "go.uber.org/cff/internal/tests/predicate/predicate.go",
-				Line:   121,
+				Line:   122,
 				Column: 9,
 			}
 			flowEmitter = cff.NopFlowEmitter()
@@ -995,16 +1138,18 @@ func MultiplePredicates() error {
 			ran     cff.AtomicBool
 			run     func(context.Context) error
 			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
 		}
 		defer func() {
 			for _, t := range tasks {
 				if !t.ran.Load() {
-					t.emitter.TaskSkipped(ctx, err)
+					t.emitter.TaskSkipped(ctx, t.outcome.SkipReason(ctx, err))
 				}
 			}
 		}()
 
-		// go.uber.org/cff/internal/tests/predicate/predicate.go:128:4
+		// go.uber.org/cff/internal/tests/predicate/predicate.go:129:4
 		var p0 bool
 		var p0PanicRecover interface{}
 		var p0PanicStacktrace []byte
@@ -1021,7 +1166,7 @@ func MultiplePredicates() error {
 					p0PanicStacktrace = debug.Stack()
 				}
 			}()
-			p0 = _128_18()
+			p0 = _129_18()
 			return nil
 		}
 
@@ -1029,21 +1174,30 @@ func MultiplePredicates() error {
 			Run: pred1.run,
 		})
 
-		// go.uber.org/cff/internal/tests/predicate/predicate.go:125:4
+		// go.uber.org/cff/internal/tests/predicate/predicate.go:126:4
 		var (
 			v1 string
 		)
+
 		task7 := new(struct {
 			emitter cff.TaskEmitter
 			ran     cff.AtomicBool
 			run     func(context.Context) error
 			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
 		})
+		task7.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/predicate/predicate.go",
+			Line:   126,
+			Column: 4,
+		}
 		task7.emitter = cff.NopTaskEmitter()
 		task7.run = func(ctx context.Context) (err error) {
 			taskEmitter := task7.emitter
 			startTime := time.Now()
 			defer func() {
+				task7.outcome.Finish(err)
 				if task7.ran.Load() {
 					taskEmitter.TaskDone(ctx, time.Since(startTime))
 				}
@@ -1069,14 +1223,22 @@ func MultiplePredicates() error {
 			}()
 
 			if !p0 {
+				task7.outcome.Skip(cff.SkipPredicateFalse)
 				return nil
 			}
 
 			defer task7.ran.Store(true)
 
-			v1 = _125_4()
-
-			taskEmitter.TaskSuccess(ctx)
+			err = cff.Intercept(ctx, interceptor, task7.outcome.Info, func(ctx context.Context) (err error) {
+				v1 = _126_4()
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return err
+			} else {
+				taskEmitter.TaskSuccess(ctx)
+			}
 
 			return
 		}
@@ -1089,7 +1251,7 @@ func MultiplePredicates() error {
 		})
 		tasks = append(tasks, task7)
 
-		// go.uber.org/cff/internal/tests/predicate/predicate.go:134:4
+		// go.uber.org/cff/internal/tests/predicate/predicate.go:135:4
 		var p1 bool
 		var p1PanicRecover interface{}
 		var p1PanicStacktrace []byte
@@ -1106,7 +1268,7 @@ func MultiplePredicates() error {
 					p1PanicStacktrace = debug.Stack()
 				}
 			}()
-			p1 = _134_18()
+			p1 = _135_18()
 			return nil
 		}
 
@@ -1114,21 +1276,30 @@ func MultiplePredicates() error {
 			Run: pred2.run,
 		})
 
-		// go.uber.org/cff/internal/tests/predicate/predicate.go:131:4
+		// go.uber.org/cff/internal/tests/predicate/predicate.go:132:4
 		var (
 			v7 bool
 		)
+
 		task8 := new(struct {
 			emitter cff.TaskEmitter
 			ran     cff.AtomicBool
 			run     func(context.Context) error
 			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
 		})
+		task8.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/predicate/predicate.go",
+			Line:   132,
+			Column: 4,
+		}
 		task8.emitter = cff.NopTaskEmitter()
 		task8.run = func(ctx context.Context) (err error) {
 			taskEmitter := task8.emitter
 			startTime := time.Now()
 			defer func() {
+				task8.outcome.Finish(err)
 				if task8.ran.Load() {
 					taskEmitter.TaskDone(ctx, time.Since(startTime))
 				}
@@ -1154,14 +1325,22 @@ func MultiplePredicates() error {
 			}()
 
 			if !p1 {
+				task8.outcome.Skip(cff.SkipPredicateFalse)
 				return nil
 			}
 
 			defer task8.ran.Store(true)
 
-			v7 = _131_4()
-
-			taskEmitter.TaskSuccess(ctx)
+			err = cff.Intercept(ctx, interceptor, task8.outcome.Info, func(ctx context.Context) (err error) {
+				v7 = _132_4()
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return err
+			} else {
+				taskEmitter.TaskSuccess(ctx)
+			}
 
 			return
 		}
@@ -1176,11 +1355,12 @@ func MultiplePredicates() error {
 
 		if err := sched.Wait(ctx); err != nil {
 			flowEmitter.FlowError(ctx, err)
+			cff.RethrowPanic(err, false)
 			return err
 		}
 
-		*(_123_15) = v1 // string
-		*(_123_19) = v7 // bool
+		*(_124_15) = v1 // string
+		*(_124_19) = v7 // bool
 
 		flowEmitter.FlowSuccess(ctx)
 		return nil
@@ -1192,25 +1372,26 @@ func Panicked() error {
 	var s string
 	return func() (err error) {
 
-		_143_3 := context.Background()
+		_144_3 := context.Background()
 
-		_144_15 := &s
+		_145_15 := &s
 
-		_146_4 := func(ctx context.Context) string {
+		_147_4 := func(ctx context.Context) string {
 			return "foo"
 		}
 
-		_150_5 := func() bool {
+		_151_5 := func() bool {
 			panic("sad times")
 			return true
 		}
-		ctx := _143_3
+		ctx := _144_3
 		emitter := cff.NopEmitter()
+		interceptor := cff.InterceptorStack()
 
 		var (
 			flowInfo = &cff.FlowInfo{
 				File:   "go.uber.org/cff/internal/tests/predicate/predicate.go",
-				Line:   142,
+				Line:   143,
 				Column: 9,
 			}
 			flowEmitter = cff.NopFlowEmitter()
@@ -1243,16 +1424,18 @@ func Panicked() error {
 			ran     cff.AtomicBool
 			run     func(context.Context) error
 			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
 		}
 		defer func() {
 			for _, t := range tasks {
 				if !t.ran.Load() {
-					t.emitter.TaskSkipped(ctx, err)
+					t.emitter.TaskSkipped(ctx, t.outcome.SkipReason(ctx, err))
 				}
 			}
 		}()
 
-		// go.uber.org/cff/internal/tests/predicate/predicate.go:149:4
+		// go.uber.org/cff/internal/tests/predicate/predicate.go:150:4
 		var p0 bool
 		var p0PanicRecover interface{}
 		var p0PanicStacktrace []byte
@@ -1269,7 +1452,7 @@ func Panicked() error {
 					p0PanicStacktrace = debug.Stack()
 				}
 			}()
-			p0 = _150_5()
+			p0 = _151_5()
 			return nil
 		}
 
@@ -1277,21 +1460,30 @@ func Panicked() error {
 			Run: pred1.run,
 		})
 
-		// go.uber.org/cff/internal/tests/predicate/predicate.go:146:4
+		// go.uber.org/cff/internal/tests/predicate/predicate.go:147:4
 		var (
 			v1 string
 		)
+
 		task9 := new(struct {
 			emitter cff.TaskEmitter
 			ran     cff.AtomicBool
 			run     func(context.Context) error
 			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
 		})
+		task9.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/predicate/predicate.go",
+			Line:   147,
+			Column: 4,
+		}
 		task9.emitter = cff.NopTaskEmitter()
 		task9.run = func(ctx context.Context) (err error) {
 			taskEmitter := task9.emitter
 			startTime := time.Now()
 			defer func() {
+				task9.outcome.Finish(err)
 				if task9.ran.Load() {
 					taskEmitter.TaskDone(ctx, time.Since(startTime))
 				}
@@ -1317,14 +1509,22 @@ func Panicked() error {
 			}()
 
 			if !p0 {
+				task9.outcome.Skip(cff.SkipPredicateFalse)
 				return nil
 			}
 
 			defer task9.ran.Store(true)
 
-			v1 = _146_4(ctx)
-
-			taskEmitter.TaskSuccess(ctx)
+			err = cff.Intercept(ctx, interceptor, task9.outcome.Info, func(ctx context.Context) (err error) {
+				v1 = _147_4(ctx)
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return err
+			} else {
+				taskEmitter.TaskSuccess(ctx)
+			}
 
 			return
 		}
@@ -1339,10 +1539,11 @@ func Panicked() error {
 
 		if err := sched.Wait(ctx); err != nil {
 			flowEmitter.FlowError(ctx, err)
+			cff.RethrowPanic(err, false)
 			return err
 		}
 
-		*(_144_15) = v1 // string
+		*(_145_15) = v1 // string
 
 		flowEmitter.FlowSuccess(ctx)
 		return nil
@@ -1355,27 +1556,28 @@ func PanickedWithFallback() (string, error) {
 	var s string
 	err := func() (err error) {
 
-		_164_3 := context.Background()
+		_165_3 := context.Background()
 
-		_165_15 := &s
+		_166_15 := &s
 
-		_167_4 := func(ctx context.Context) (string, error) {
+		_168_4 := func(ctx context.Context) (string, error) {
 			return "foo", nil
 		}
 
-		_171_5 := func() bool {
+		_172_5 := func() bool {
 			panic("sad times")
 			return true
 		}
 
-		_176_21 := "predicate-fallback"
-		ctx := _164_3
+		_177_21 := "predicate-fallback"
+		ctx := _165_3
 		emitter := cff.NopEmitter()
+		interceptor := cff.InterceptorStack()
 
 		var (
 			flowInfo = &cff.FlowInfo{
 				File:   "go.uber.org/cff/internal/tests/predicate/predicate.go",
-				Line:   163,
+				Line:   164,
 				Column: 9,
 			}
 			flowEmitter = cff.NopFlowEmitter()
@@ -1408,16 +1610,18 @@ func PanickedWithFallback() (string, error) {
 			ran     cff.AtomicBool
 			run     func(context.Context) error
 			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
 		}
 		defer func() {
 			for _, t := range tasks {
 				if !t.ran.Load() {
-					t.emitter.TaskSkipped(ctx, err)
+					t.emitter.TaskSkipped(ctx, t.outcome.SkipReason(ctx, err))
 				}
 			}
 		}()
 
-		// go.uber.org/cff/internal/tests/predicate/predicate.go:170:4
+		// go.uber.org/cff/internal/tests/predicate/predicate.go:171:4
 		var p0 bool
 		var p0PanicRecover interface{}
 		var p0PanicStacktrace []byte
@@ -1434,7 +1638,7 @@ func PanickedWithFallback() (string, error) {
 					p0PanicStacktrace = debug.Stack()
 				}
 			}()
-			p0 = _171_5()
+			p0 = _172_5()
 			return nil
 		}
 
@@ -1442,21 +1646,30 @@ func PanickedWithFallback() (string, error) {
 			Run: pred1.run,
 		})
 
-		// go.uber.org/cff/internal/tests/predicate/predicate.go:167:4
+		// go.uber.org/cff/internal/tests/predicate/predicate.go:168:4
 		var (
 			v1 string
 		)
+
 		task10 := new(struct {
 			emitter cff.TaskEmitter
 			ran     cff.AtomicBool
 			run     func(context.Context) error
 			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
 		})
+		task10.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/predicate/predicate.go",
+			Line:   168,
+			Column: 4,
+		}
 		task10.emitter = cff.NopTaskEmitter()
 		task10.run = func(ctx context.Context) (err error) {
 			taskEmitter := task10.emitter
 			startTime := time.Now()
 			defer func() {
+				task10.outcome.Finish(err)
 				if task10.ran.Load() {
 					taskEmitter.TaskDone(ctx, time.Since(startTime))
 				}
@@ -1470,21 +1683,24 @@ func PanickedWithFallback() (string, error) {
 				}
 				if recovered != nil {
 					taskEmitter.TaskPanicRecovered(ctx, recovered)
-					v1, err = _176_21, nil
+					v1, err = _177_21, nil
 				}
 			}()
 
 			if !p0 {
+				task10.outcome.Skip(cff.SkipPredicateFalse)
 				return nil
 			}
 
 			defer task10.ran.Store(true)
 
-			v1, err = _167_4(ctx)
-
+			err = cff.Intercept(ctx, interceptor, task10.outcome.Info, func(ctx context.Context) (err error) {
+				v1, err = _168_4(ctx)
+				return
+			})
 			if err != nil {
 				taskEmitter.TaskErrorRecovered(ctx, err)
-				v1, err = _176_21, nil
+				v1, err = _177_21, nil
 			} else {
 				taskEmitter.TaskSuccess(ctx)
 			}
@@ -1502,10 +1718,1127 @@ func PanickedWithFallback() (string, error) {
 
 		if err := sched.Wait(ctx); err != nil {
 			flowEmitter.FlowError(ctx, err)
+			cff.RethrowPanic(err, false)
+			return err
+		}
+
+		*(_166_15) = v1 // string
+
+		flowEmitter.FlowSuccess(ctx)
+		return nil
+	}()
+	return s, err
+}
+
+// Failing is a flow that runs a task with a predicate that fails with the
+// given error.
+func Failing(predErr error) error {
+	var s string
+	return func() (err error) {
+
+		_188_3 := context.Background()
+
+		_189_15 := &s
+
+		_191_4 := func() string {
+			return "foo"
+		}
+
+		_194_18 := func(ctx context.Context) (bool, error) {
+			return true, predErr
+		}
+		ctx := _188_3
+		emitter := cff.NopEmitter()
+		interceptor := cff.InterceptorStack()
+
+		var (
+			flowInfo = &cff.FlowInfo{
+				File:   "go.uber.org/cff/internal/tests/predicate/predicate.go",
+				Line:   187,
+				Column: 9,
+			}
+			flowEmitter = cff.NopFlowEmitter()
+
+			schedInfo = &cff.SchedulerInfo{
+				Name:      flowInfo.Name,
+				Directive: cff.FlowDirective,
+				File:      flowInfo.File,
+				Line:      flowInfo.Line,
+				Column:    flowInfo.Column,
+			}
+
+			// possibly unused
+			_ = flowInfo
+		)
+
+		startTime := time.Now()
+		defer func() { flowEmitter.FlowDone(ctx, time.Since(startTime)) }()
+
+		schedEmitter := emitter.SchedulerInit(schedInfo)
+
+		sched := cff.NewScheduler(
+			cff.SchedulerParams{
+				Emitter: schedEmitter,
+			},
+		)
+
+		var tasks []*struct {
+			emitter cff.TaskEmitter
+			ran     cff.AtomicBool
+			run     func(context.Context) error
+			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		}
+		defer func() {
+			for _, t := range tasks {
+				if !t.ran.Load() {
+					t.emitter.TaskSkipped(ctx, t.outcome.SkipReason(ctx, err))
+				}
+			}
+		}()
+
+		// go.uber.org/cff/internal/tests/predicate/predicate.go:194:4
+		var p0 bool
+		var p0Err error
+		var p0PanicRecover interface{}
+		var p0PanicStacktrace []byte
+		_ = p0PanicStacktrace // possibly unused.
+		pred1 := new(struct {
+			ran cff.AtomicBool
+			run func(context.Context) error
+			job *cff.ScheduledJob
+		})
+		pred1.run = func(ctx context.Context) (err error) {
+			defer func() {
+				if recovered := recover(); recovered != nil {
+					p0PanicRecover = recovered
+					p0PanicStacktrace = debug.Stack()
+				}
+			}()
+			var predErr error
+			p0, predErr = _194_18(ctx)
+			if predErr != nil {
+				p0Err = &cff.PredicateError{
+					Err:    predErr,
+					File:   "go.uber.org/cff/internal/tests/predicate/predicate.go",
+					Line:   194,
+					Column: 4,
+				}
+			}
+			return nil
+		}
+
+		pred1.job = sched.Enqueue(ctx, cff.Job{
+			Run: pred1.run,
+		})
+
+		// go.uber.org/cff/internal/tests/predicate/predicate.go:191:4
+		var (
+			v1 string
+		)
+
+		task11 := new(struct {
+			emitter cff.TaskEmitter
+			ran     cff.AtomicBool
+			run     func(context.Context) error
+			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		})
+		task11.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/predicate/predicate.go",
+			Line:   191,
+			Column: 4,
+		}
+		task11.emitter = cff.NopTaskEmitter()
+		task11.run = func(ctx context.Context) (err error) {
+			taskEmitter := task11.emitter
+			startTime := time.Now()
+			defer func() {
+				task11.outcome.Finish(err)
+				if task11.ran.Load() {
+					taskEmitter.TaskDone(ctx, time.Since(startTime))
+				}
+			}()
+
+			defer func() {
+				recovered := recover()
+				var stacktrace []byte
+				if recovered != nil {
+					stacktrace = debug.Stack()
+				}
+				if recovered == nil && p0PanicRecover != nil {
+					recovered = p0PanicRecover
+					stacktrace = p0PanicStacktrace
+				}
+				if recovered != nil {
+					taskEmitter.TaskPanic(ctx, recovered)
+					err = &cff.PanicError{
+						Value:      recovered,
+						Stacktrace: stacktrace,
+					}
+				}
+			}()
+
+			if p0Err != nil {
+				defer task11.ran.Store(true)
+				taskEmitter.TaskError(ctx, p0Err)
+				return p0Err
+			}
+
+			if !p0 {
+				task11.outcome.Skip(cff.SkipPredicateFalse)
+				return nil
+			}
+
+			defer task11.ran.Store(true)
+
+			err = cff.Intercept(ctx, interceptor, task11.outcome.Info, func(ctx context.Context) (err error) {
+				v1 = _191_4()
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return err
+			} else {
+				taskEmitter.TaskSuccess(ctx)
+			}
+
+			return
+		}
+
+		task11.job = sched.Enqueue(ctx, cff.Job{
+			Run: task11.run,
+			Dependencies: []*cff.ScheduledJob{
+				pred1.job,
+			},
+		})
+		tasks = append(tasks, task11)
+
+		if err := sched.Wait(ctx); err != nil {
+			flowEmitter.FlowError(ctx, err)
+			cff.RethrowPanic(err, false)
+			return err
+		}
+
+		*(_189_15) = v1 // string
+
+		flowEmitter.FlowSuccess(ctx)
+		return nil
+	}()
+}
+
+// FailingWithFallback is a flow that runs a task with a failing predicate
+// and a fallback.
+// The task itself doesn't return an error.
+func FailingWithFallback(emitter cff.Emitter) (string, error) {
+	var s string
+	err := func() (err error) {
+
+		_207_3 := context.Background()
+
+		_208_19 := emitter
+
+		_209_15 := &s
+
+		_211_4 := func() string {
+			return "foo"
+		}
+
+		_214_18 := func() (bool, error) {
+			return true, errors.New("great sadness")
+		}
+
+		_217_21 := "predicate-fallback"
+
+		_218_19 := "task"
+		ctx := _207_3
+		emitter := cff.EmitterStack(_208_19)
+		interceptor := cff.InterceptorStack()
+
+		var (
+			flowInfo = &cff.FlowInfo{
+				File:   "go.uber.org/cff/internal/tests/predicate/predicate.go",
+				Line:   206,
+				Column: 9,
+			}
+			flowEmitter = cff.NopFlowEmitter()
+
+			schedInfo = &cff.SchedulerInfo{
+				Name:      flowInfo.Name,
+				Directive: cff.FlowDirective,
+				File:      flowInfo.File,
+				Line:      flowInfo.Line,
+				Column:    flowInfo.Column,
+			}
+
+			// possibly unused
+			_ = flowInfo
+		)
+
+		startTime := time.Now()
+		defer func() { flowEmitter.FlowDone(ctx, time.Since(startTime)) }()
+
+		schedEmitter := emitter.SchedulerInit(schedInfo)
+
+		sched := cff.NewScheduler(
+			cff.SchedulerParams{
+				Emitter: schedEmitter,
+			},
+		)
+
+		var tasks []*struct {
+			emitter cff.TaskEmitter
+			ran     cff.AtomicBool
+			run     func(context.Context) error
+			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		}
+		defer func() {
+			for _, t := range tasks {
+				if !t.ran.Load() {
+					t.emitter.TaskSkipped(ctx, t.outcome.SkipReason(ctx, err))
+				}
+			}
+		}()
+
+		// go.uber.org/cff/internal/tests/predicate/predicate.go:214:4
+		var p0 bool
+		var p0Err error
+		var p0PanicRecover interface{}
+		var p0PanicStacktrace []byte
+		_ = p0PanicStacktrace // possibly unused.
+		pred1 := new(struct {
+			ran cff.AtomicBool
+			run func(context.Context) error
+			job *cff.ScheduledJob
+		})
+		pred1.run = func(ctx context.Context) (err error) {
+			defer func() {
+				if recovered := recover(); recovered != nil {
+					p0PanicRecover = recovered
+					p0PanicStacktrace = debug.Stack()
+				}
+			}()
+			var predErr error
+			p0, predErr = _214_18()
+			if predErr != nil {
+				p0Err = &cff.PredicateError{
+					Err:    predErr,
+					File:   "go.uber.org/cff/internal/tests/predicate/predicate.go",
+					Line:   214,
+					Column: 4,
+				}
+			}
+			return nil
+		}
+
+		pred1.job = sched.Enqueue(ctx, cff.Job{
+			Run: pred1.run,
+		})
+
+		// go.uber.org/cff/internal/tests/predicate/predicate.go:211:4
+		var (
+			v1 string
+		)
+
+		task12 := new(struct {
+			emitter cff.TaskEmitter
+			ran     cff.AtomicBool
+			run     func(context.Context) error
+			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		})
+		task12.outcome.Info = &cff.TaskInfo{
+			Name:   _218_19,
+			File:   "go.uber.org/cff/internal/tests/predicate/predicate.go",
+			Line:   211,
+			Column: 4,
+		}
+		task12.emitter = emitter.TaskInit(
+			task12.outcome.Info,
+			&cff.DirectiveInfo{
+				Name:      flowInfo.Name,
+				Directive: cff.FlowDirective,
+				File:      flowInfo.File,
+				Line:      flowInfo.Line,
+				Column:    flowInfo.Column,
+			},
+		)
+		task12.run = func(ctx context.Context) (err error) {
+			taskEmitter := task12.emitter
+			startTime := time.Now()
+			defer func() {
+				task12.outcome.Finish(err)
+				if task12.ran.Load() {
+					taskEmitter.TaskDone(ctx, time.Since(startTime))
+				}
+			}()
+
+			defer func() {
+				recovered := recover()
+
+				if recovered == nil && p0PanicRecover != nil {
+					recovered = p0PanicRecover
+				}
+				if recovered != nil {
+					taskEmitter.TaskPanicRecovered(ctx, recovered)
+					v1 = _217_21
+				}
+			}()
+
+			if p0Err != nil {
+				defer task12.ran.Store(true)
+				taskEmitter.TaskErrorRecovered(ctx, p0Err)
+				v1 = _217_21
+				return nil
+			}
+
+			if !p0 {
+				task12.outcome.Skip(cff.SkipPredicateFalse)
+				return nil
+			}
+
+			defer task12.ran.Store(true)
+
+			err = cff.Intercept(ctx, interceptor, task12.outcome.Info, func(ctx context.Context) (err error) {
+				v1 = _211_4()
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskErrorRecovered(ctx, err)
+				v1 = _217_21
+				err = nil
+			} else {
+				taskEmitter.TaskSuccess(ctx)
+			}
+
+			return
+		}
+
+		task12.job = sched.Enqueue(ctx, cff.Job{
+			Run: task12.run,
+			Dependencies: []*cff.ScheduledJob{
+				pred1.job,
+			},
+		})
+		tasks = append(tasks, task12)
+
+		if err := sched.Wait(ctx); err != nil {
+			flowEmitter.FlowError(ctx, err)
+			cff.RethrowPanic(err, false)
+			return err
+		}
+
+		*(_209_15) = v1 // string
+
+		flowEmitter.FlowSuccess(ctx)
+		return nil
+	}()
+	return s, err
+}
+
+// FailingOptional is a flow that runs an optional task with a failing
+// predicate.
+func FailingOptional() (cff.Maybe[string], error) {
+	var s cff.Maybe[string]
+	err := func() (err error) {
+
+		_229_3 := context.Background()
+
+		_230_15 := &s
+
+		_232_4 := func() string {
+			return "foo"
+		}
+
+		_235_18 := func() (bool, error) {
+			return true, errors.New("great sadness")
+		}
+		ctx := _229_3
+		emitter := cff.NopEmitter()
+		interceptor := cff.InterceptorStack()
+
+		var (
+			flowInfo = &cff.FlowInfo{
+				File:   "go.uber.org/cff/internal/tests/predicate/predicate.go",
+				Line:   228,
+				Column: 9,
+			}
+			flowEmitter = cff.NopFlowEmitter()
+
+			schedInfo = &cff.SchedulerInfo{
+				Name:      flowInfo.Name,
+				Directive: cff.FlowDirective,
+				File:      flowInfo.File,
+				Line:      flowInfo.Line,
+				Column:    flowInfo.Column,
+			}
+
+			// possibly unused
+			_ = flowInfo
+		)
+
+		startTime := time.Now()
+		defer func() { flowEmitter.FlowDone(ctx, time.Since(startTime)) }()
+
+		schedEmitter := emitter.SchedulerInit(schedInfo)
+
+		sched := cff.NewScheduler(
+			cff.SchedulerParams{
+				Emitter: schedEmitter,
+			},
+		)
+
+		var tasks []*struct {
+			emitter cff.TaskEmitter
+			ran     cff.AtomicBool
+			run     func(context.Context) error
+			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		}
+		defer func() {
+			for _, t := range tasks {
+				if !t.ran.Load() {
+					t.emitter.TaskSkipped(ctx, t.outcome.SkipReason(ctx, err))
+				}
+			}
+		}()
+
+		// go.uber.org/cff/internal/tests/predicate/predicate.go:235:4
+		var p0 bool
+		var p0Err error
+		var p0PanicRecover interface{}
+		var p0PanicStacktrace []byte
+		_ = p0PanicStacktrace // possibly unused.
+		pred1 := new(struct {
+			ran cff.AtomicBool
+			run func(context.Context) error
+			job *cff.ScheduledJob
+		})
+		pred1.run = func(ctx context.Context) (err error) {
+			defer func() {
+				if recovered := recover(); recovered != nil {
+					p0PanicRecover = recovered
+					p0PanicStacktrace = debug.Stack()
+				}
+			}()
+			var predErr error
+			p0, predErr = _235_18()
+			if predErr != nil {
+				p0Err = &cff.PredicateError{
+					Err:    predErr,
+					File:   "go.uber.org/cff/internal/tests/predicate/predicate.go",
+					Line:   235,
+					Column: 4,
+				}
+			}
+			return nil
+		}
+
+		pred1.job = sched.Enqueue(ctx, cff.Job{
+			Run: pred1.run,
+		})
+
+		// go.uber.org/cff/internal/tests/predicate/predicate.go:232:4
+		var (
+			v1 string
+		)
+
+		var task13Produced bool
+		task13 := new(struct {
+			emitter cff.TaskEmitter
+			ran     cff.AtomicBool
+			run     func(context.Context) error
+			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		})
+		task13.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/predicate/predicate.go",
+			Line:   232,
+			Column: 4,
+		}
+		task13.emitter = cff.NopTaskEmitter()
+		task13.run = func(ctx context.Context) (err error) {
+			taskEmitter := task13.emitter
+			startTime := time.Now()
+			defer func() {
+				task13.outcome.Finish(err)
+				if task13.ran.Load() {
+					taskEmitter.TaskDone(ctx, time.Since(startTime))
+				}
+			}()
+
+			defer func() {
+				recovered := recover()
+
+				if recovered == nil && p0PanicRecover != nil {
+					recovered = p0PanicRecover
+				}
+				if recovered != nil {
+					taskEmitter.TaskPanicRecovered(ctx, recovered)
+					v1 = *new(string)
+				}
+			}()
+
+			if p0Err != nil {
+				defer task13.ran.Store(true)
+				taskEmitter.TaskErrorRecovered(ctx, p0Err)
+				v1 = *new(string)
+				return nil
+			}
+
+			if !p0 {
+				task13.outcome.Skip(cff.SkipPredicateFalse)
+				return nil
+			}
+
+			defer task13.ran.Store(true)
+
+			err = cff.Intercept(ctx, interceptor, task13.outcome.Info, func(ctx context.Context) (err error) {
+				v1 = _232_4()
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskErrorRecovered(ctx, err)
+				v1 = *new(string)
+				return nil
+			} else {
+				taskEmitter.TaskSuccess(ctx)
+			}
+
+			task13Produced = true
+
+			return
+		}
+
+		task13.job = sched.Enqueue(ctx, cff.Job{
+			Run: task13.run,
+			Dependencies: []*cff.ScheduledJob{
+				pred1.job,
+			},
+		})
+		tasks = append(tasks, task13)
+
+		if err := sched.Wait(ctx); err != nil {
+			flowEmitter.FlowError(ctx, err)
+			cff.RethrowPanic(err, false)
+			return err
+		}
+
+		*(_230_15) = cff.Maybe[string]{
+			Value: v1,
+			Valid: task13Produced,
+		} // string
+
+		flowEmitter.FlowSuccess(ctx)
+		return nil
+	}()
+	return s, err
+}
+
+// FailingWithDefault is a flow that runs a task with a failing predicate
+// that defaults to the given value.
+func FailingWithDefault(emitter cff.Emitter, value bool) (string, error) {
+	var s string
+	err := func() (err error) {
+
+		_249_3 := context.Background()
+
+		_250_19 := emitter
+
+		_251_15 := &s
+
+		_253_4 := func() (string, error) {
+			return "foo", nil
+		}
+
+		_256_18 := func() (bool, error) {
+			return !value, errors.New("great sadness")
+		}
+
+		_259_25 := value
+
+		_260_19 := "task"
+		ctx := _249_3
+		emitter := cff.EmitterStack(_250_19)
+		interceptor := cff.InterceptorStack()
+
+		var (
+			flowInfo = &cff.FlowInfo{
+				File:   "go.uber.org/cff/internal/tests/predicate/predicate.go",
+				Line:   248,
+				Column: 9,
+			}
+			flowEmitter = cff.NopFlowEmitter()
+
+			schedInfo = &cff.SchedulerInfo{
+				Name:      flowInfo.Name,
+				Directive: cff.FlowDirective,
+				File:      flowInfo.File,
+				Line:      flowInfo.Line,
+				Column:    flowInfo.Column,
+			}
+
+			// possibly unused
+			_ = flowInfo
+		)
+
+		startTime := time.Now()
+		defer func() { flowEmitter.FlowDone(ctx, time.Since(startTime)) }()
+
+		schedEmitter := emitter.SchedulerInit(schedInfo)
+
+		sched := cff.NewScheduler(
+			cff.SchedulerParams{
+				Emitter: schedEmitter,
+			},
+		)
+
+		var tasks []*struct {
+			emitter cff.TaskEmitter
+			ran     cff.AtomicBool
+			run     func(context.Context) error
+			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		}
+		defer func() {
+			for _, t := range tasks {
+				if !t.ran.Load() {
+					t.emitter.TaskSkipped(ctx, t.outcome.SkipReason(ctx, err))
+				}
+			}
+		}()
+
+		// go.uber.org/cff/internal/tests/predicate/predicate.go:256:4
+		var p0 bool
+		var p0Err error
+		var p0PanicRecover interface{}
+		var p0PanicStacktrace []byte
+		_ = p0PanicStacktrace // possibly unused.
+		pred1 := new(struct {
+			ran cff.AtomicBool
+			run func(context.Context) error
+			job *cff.ScheduledJob
+		})
+		pred1.run = func(ctx context.Context) (err error) {
+			defer func() {
+				if recovered := recover(); recovered != nil {
+					p0PanicRecover = recovered
+					p0PanicStacktrace = debug.Stack()
+				}
+			}()
+			var predErr error
+			p0, predErr = _256_18()
+			if predErr != nil {
+				p0Err = &cff.PredicateError{
+					Err:    predErr,
+					File:   "go.uber.org/cff/internal/tests/predicate/predicate.go",
+					Line:   256,
+					Column: 4,
+				}
+			}
+			return nil
+		}
+
+		pred1.job = sched.Enqueue(ctx, cff.Job{
+			Run: pred1.run,
+		})
+
+		// go.uber.org/cff/internal/tests/predicate/predicate.go:253:4
+		var (
+			v1 string
+		)
+
+		task14 := new(struct {
+			emitter cff.TaskEmitter
+			ran     cff.AtomicBool
+			run     func(context.Context) error
+			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		})
+		task14.outcome.Info = &cff.TaskInfo{
+			Name:   _260_19,
+			File:   "go.uber.org/cff/internal/tests/predicate/predicate.go",
+			Line:   253,
+			Column: 4,
+		}
+		task14.emitter = emitter.TaskInit(
+			task14.outcome.Info,
+			&cff.DirectiveInfo{
+				Name:      flowInfo.Name,
+				Directive: cff.FlowDirective,
+				File:      flowInfo.File,
+				Line:      flowInfo.Line,
+				Column:    flowInfo.Column,
+			},
+		)
+		task14.run = func(ctx context.Context) (err error) {
+			taskEmitter := task14.emitter
+			startTime := time.Now()
+			defer func() {
+				task14.outcome.Finish(err)
+				if task14.ran.Load() {
+					taskEmitter.TaskDone(ctx, time.Since(startTime))
+				}
+			}()
+
+			defer func() {
+				recovered := recover()
+				var stacktrace []byte
+				if recovered != nil {
+					stacktrace = debug.Stack()
+				}
+				if recovered == nil && p0PanicRecover != nil {
+					recovered = p0PanicRecover
+					stacktrace = p0PanicStacktrace
+				}
+				if recovered != nil {
+					taskEmitter.TaskPanic(ctx, recovered)
+					err = &cff.PanicError{
+						Value:      recovered,
+						Stacktrace: stacktrace,
+					}
+				}
+			}()
+
+			if p0Err != nil {
+				taskEmitter.TaskErrorRecovered(ctx, p0Err)
+				p0 = _259_25
+			}
+
+			if !p0 {
+				task14.outcome.Skip(cff.SkipPredicateFalse)
+				return nil
+			}
+
+			defer task14.ran.Store(true)
+
+			err = cff.Intercept(ctx, interceptor, task14.outcome.Info, func(ctx context.Context) (err error) {
+				v1, err = _253_4()
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return err
+			} else {
+				taskEmitter.TaskSuccess(ctx)
+			}
+
+			return
+		}
+
+		task14.job = sched.Enqueue(ctx, cff.Job{
+			Run: task14.run,
+			Dependencies: []*cff.ScheduledJob{
+				pred1.job,
+			},
+		})
+		tasks = append(tasks, task14)
+
+		if err := sched.Wait(ctx); err != nil {
+			flowEmitter.FlowError(ctx, err)
+			cff.RethrowPanic(err, false)
+			return err
+		}
+
+		*(_251_15) = v1 // string
+
+		flowEmitter.FlowSuccess(ctx)
+		return nil
+	}()
+	return s, err
+}
+
+// FailingCase is a flow with a cff.Switch whose first case has a predicate
+// that fails with the given error.
+// The predicate defaults to false, so the default case runs if it fails.
+func FailingCase(predErr error) (string, error) {
+	var s string
+	err := func() (err error) {
+
+		_272_3 := context.Background()
+
+		_273_15 := &s
+
+		_276_5 := func() (bool, error) {
+			return true, predErr
+		}
+
+		_279_5 := func() string { return "case" }
+
+		_280_26 := false
+
+		_282_16 := func() string {
+			return "default"
+		}
+		ctx := _272_3
+		emitter := cff.NopEmitter()
+		interceptor := cff.InterceptorStack()
+
+		var (
+			flowInfo = &cff.FlowInfo{
+				File:   "go.uber.org/cff/internal/tests/predicate/predicate.go",
+				Line:   271,
+				Column: 9,
+			}
+			flowEmitter = cff.NopFlowEmitter()
+
+			schedInfo = &cff.SchedulerInfo{
+				Name:      flowInfo.Name,
+				Directive: cff.FlowDirective,
+				File:      flowInfo.File,
+				Line:      flowInfo.Line,
+				Column:    flowInfo.Column,
+			}
+
+			// possibly unused
+			_ = flowInfo
+		)
+
+		startTime := time.Now()
+		defer func() { flowEmitter.FlowDone(ctx, time.Since(startTime)) }()
+
+		schedEmitter := emitter.SchedulerInit(schedInfo)
+
+		sched := cff.NewScheduler(
+			cff.SchedulerParams{
+				Emitter: schedEmitter,
+			},
+		)
+
+		var tasks []*struct {
+			emitter cff.TaskEmitter
+			ran     cff.AtomicBool
+			run     func(context.Context) error
+			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		}
+		defer func() {
+			for _, t := range tasks {
+				if !t.ran.Load() {
+					t.emitter.TaskSkipped(ctx, t.outcome.SkipReason(ctx, err))
+				}
+			}
+		}()
+
+		// go.uber.org/cff/internal/tests/predicate/predicate.go:275:4
+		var p0 bool
+		var p0Err error
+		var p0PanicRecover interface{}
+		var p0PanicStacktrace []byte
+		_ = p0PanicStacktrace // possibly unused.
+		pred1 := new(struct {
+			ran cff.AtomicBool
+			run func(context.Context) error
+			job *cff.ScheduledJob
+		})
+		pred1.run = func(ctx context.Context) (err error) {
+			defer func() {
+				if recovered := recover(); recovered != nil {
+					p0PanicRecover = recovered
+					p0PanicStacktrace = debug.Stack()
+				}
+			}()
+			var predErr error
+			p0, predErr = _276_5()
+			if predErr != nil {
+				p0Err = &cff.PredicateError{
+					Err:    predErr,
+					File:   "go.uber.org/cff/internal/tests/predicate/predicate.go",
+					Line:   275,
+					Column: 4,
+				}
+			}
+			return nil
+		}
+
+		pred1.job = sched.Enqueue(ctx, cff.Job{
+			Run: pred1.run,
+		})
+
+		// go.uber.org/cff/internal/tests/predicate/predicate.go:274:3
+		var (
+			v1 string
+		)
+
+		// go.uber.org/cff/internal/tests/predicate/predicate.go:279:5
+		task15 := new(struct {
+			emitter cff.TaskEmitter
+			ran     cff.AtomicBool
+			run     func(context.Context) error
+			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		})
+		task15.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/predicate/predicate.go",
+			Line:   279,
+			Column: 5,
+		}
+		task15.emitter = cff.NopTaskEmitter()
+		task15.run = func(ctx context.Context) (err error) {
+			taskEmitter := task15.emitter
+			startTime := time.Now()
+			defer func() {
+				task15.outcome.Finish(err)
+				if task15.ran.Load() {
+					taskEmitter.TaskDone(ctx, time.Since(startTime))
+				}
+			}()
+
+			defer func() {
+				recovered := recover()
+				if recovered != nil {
+					taskEmitter.TaskPanic(ctx, recovered)
+					err = &cff.PanicError{
+						Value:      recovered,
+						Stacktrace: debug.Stack(),
+					}
+				}
+			}()
+
+			defer task15.ran.Store(true)
+
+			err = cff.Intercept(ctx, interceptor, task15.outcome.Info, func(ctx context.Context) (err error) {
+				v1 = _279_5()
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return err
+			} else {
+				taskEmitter.TaskSuccess(ctx)
+			}
+
+			return
+		}
+
+		tasks = append(tasks, task15)
+
+		// go.uber.org/cff/internal/tests/predicate/predicate.go:282:16
+		task16 := new(struct {
+			emitter cff.TaskEmitter
+			ran     cff.AtomicBool
+			run     func(context.Context) error
+			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		})
+		task16.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/predicate/predicate.go",
+			Line:   282,
+			Column: 16,
+		}
+		task16.emitter = cff.NopTaskEmitter()
+		task16.run = func(ctx context.Context) (err error) {
+			taskEmitter := task16.emitter
+			startTime := time.Now()
+			defer func() {
+				task16.outcome.Finish(err)
+				if task16.ran.Load() {
+					taskEmitter.TaskDone(ctx, time.Since(startTime))
+				}
+			}()
+
+			defer func() {
+				recovered := recover()
+				if recovered != nil {
+					taskEmitter.TaskPanic(ctx, recovered)
+					err = &cff.PanicError{
+						Value:      recovered,
+						Stacktrace: debug.Stack(),
+					}
+				}
+			}()
+
+			defer task16.ran.Store(true)
+
+			err = cff.Intercept(ctx, interceptor, task16.outcome.Info, func(ctx context.Context) (err error) {
+				v1 = _282_16()
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return err
+			} else {
+				taskEmitter.TaskSuccess(ctx)
+			}
+
+			return
+		}
+
+		tasks = append(tasks, task16)
+		task17 := new(struct {
+			emitter cff.TaskEmitter
+			ran     cff.AtomicBool
+			run     func(context.Context) error
+			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		})
+		task17.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/predicate/predicate.go",
+			Line:   274,
+			Column: 3,
+		}
+		task15.outcome.Dependencies = task17.outcome.Dependencies
+		task16.outcome.Dependencies = task17.outcome.Dependencies
+		task17.run = func(ctx context.Context) (err error) {
+			defer func() {
+				task17.outcome.Finish(err)
+				if err != nil {
+					return
+				}
+
+				// Tasks of the Switch that were not selected.
+				if !task15.ran.Load() {
+					task15.outcome.Skip(cff.SkipPredicateFalse)
+				}
+				if !task16.ran.Load() {
+					task16.outcome.Skip(cff.SkipPredicateFalse)
+				}
+			}()
+			if p0PanicRecover != nil {
+				task15.emitter.TaskPanic(ctx, p0PanicRecover)
+				return &cff.PanicError{
+					Value:      p0PanicRecover,
+					Stacktrace: p0PanicStacktrace,
+				}
+			}
+			if p0Err != nil {
+				task15.emitter.TaskErrorRecovered(ctx, p0Err)
+				p0 = _280_26
+			}
+			if p0 {
+				return task15.run(ctx)
+			}
+
+			return task16.run(ctx)
+		}
+
+		task17.job = sched.Enqueue(ctx, cff.Job{
+			Run: task17.run,
+			Dependencies: []*cff.ScheduledJob{
+				pred1.job,
+			},
+		})
+
+		if err := sched.Wait(ctx); err != nil {
+			flowEmitter.FlowError(ctx, err)
+			cff.RethrowPanic(err, false)
 			return err
 		}
 
-		*(_165_15) = v1 // string
+		*(_273_15) = v1 // string
 
 		flowEmitter.FlowSuccess(ctx)
 		return nil
@@ -1,11 +1,13 @@
 package predicate
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/cff"
+	"go.uber.org/cff/internal/emittertest"
 )
 
 func TestSimplePredicate(t *testing.T) {
@@ -75,3 +77,62 @@ func TestPanicFallback(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, s, "predicate-fallback")
 }
+
+func TestFailing(t *testing.T) {
+	require.NoError(t, Failing(nil))
+
+	predErr := errors.New("great sadness")
+	err := Failing(predErr)
+	require.Error(t, err)
+	assert.ErrorIs(t, err, predErr)
+
+	var pe *cff.PredicateError
+	require.ErrorAs(t, err, &pe)
+	assert.Equal(t, "go.uber.org/cff/internal/tests/predicate/predicate.go", pe.File)
+	assert.NotZero(t, pe.Line)
+}
+
+func TestFailingWithFallback(t *testing.T) {
+	e := emittertest.NewRecorder()
+	s, err := FailingWithFallback(e)
+	require.NoError(t, err)
+	assert.Equal(t, "predicate-fallback", s)
+	assert.Empty(t, e.EventsOf(emittertest.TaskError))
+	recovered := e.Errors(emittertest.TaskErrorRecovered)
+	require.Len(t, recovered, 1)
+	assert.ErrorContains(t, recovered[0], "great sadness")
+}
+
+func TestFailingOptional(t *testing.T) {
+	s, err := FailingOptional()
+	require.NoError(t, err)
+	assert.False(t, s.Valid)
+}
+
+func TestFailingWithDefault(t *testing.T) {
+	t.Run("true", func(t *testing.T) {
+		e := emittertest.NewRecorder()
+		s, err := FailingWithDefault(e, true)
+		require.NoError(t, err)
+		assert.Equal(t, "foo", s)
+		assert.Len(t, e.EventsOf(emittertest.TaskErrorRecovered), 1)
+	})
+
+	t.Run("false", func(t *testing.T) {
+		e := emittertest.NewRecorder()
+		s, err := FailingWithDefault(e, false)
+		require.NoError(t, err)
+		assert.Empty(t, s)
+		assert.Len(t, e.EventsOf(emittertest.TaskErrorRecovered), 1)
+	})
+}
+
+func TestFailingCase(t *testing.T) {
+	s, err := FailingCase(nil)
+	require.NoError(t, err)
+	assert.Equal(t, "case", s)
+
+	s, err = FailingCase(errors.New("great sadness"))
+	require.NoError(t, err)
+	assert.Equal(t, "default", s)
+}
@@ -0,0 +1,130 @@
+//go:build cff
+// +build cff
+
+// Package quorum tests Parallels that stop early with cff.Quorum and
+// cff.FirstSuccess.
+package quorum
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"go.uber.org/cff"
+)
+
+// FirstSuccess races a task that hangs until it's cancelled against
+// one that fails and one that succeeds.
+// cancelled is closed when the hanging task is cancelled.
+func FirstSuccess(ctx context.Context, cancelled chan<- struct{}) error {
+	started := make(chan struct{})
+	return cff.Parallel(ctx,
+		cff.Concurrency(3),
+		cff.Task(func(ctx context.Context) error {
+			close(started)
+			<-ctx.Done()
+			close(cancelled)
+			return ctx.Err()
+		}),
+		cff.Task(func() error {
+			return errors.New("great sadness")
+		}),
+		cff.Task(func() {
+			<-started
+		}),
+		cff.FirstSuccess(),
+	)
+}
+
+// Replicas writes to the given replicas until k of them succeed,
+// and reports the replicas that succeeded.
+// Writes to replicas named "down" fail.
+func Replicas(ctx context.Context, replicas []string, k int) ([]string, error) {
+	var (
+		mu      sync.Mutex
+		written []string
+	)
+	err := cff.Parallel(ctx,
+		cff.Concurrency(1),
+		cff.Slice(
+			func(replica string) error {
+				if replica == "down" {
+					return errors.New("replica is down")
+				}
+				mu.Lock()
+				defer mu.Unlock()
+				written = append(written, replica)
+				return nil
+			},
+			replicas,
+		),
+		cff.Quorum(k),
+	)
+	return written, err
+}
+
+// Skipped runs three instrumented tasks one at a time.
+// The first one succeeds, so the others are skipped.
+func Skipped(ctx context.Context, e cff.Emitter) error {
+	return cff.Parallel(ctx,
+		cff.Concurrency(1),
+		cff.WithEmitter(e),
+		cff.Task(func() {}, cff.Instrument("first")),
+		cff.Task(func() {}, cff.Instrument("second")),
+		cff.Task(func() {}, cff.Instrument("third")),
+		cff.FirstSuccess(),
+	)
+}
+
+// Recovered runs tasks that fail and are recovered by their fallbacks.
+// None of them succeed.
+func Recovered(ctx context.Context) error {
+	return cff.Parallel(ctx,
+		cff.Task(
+			func() error { return errors.New("great sadness") },
+			cff.FallbackWith(),
+		),
+		cff.Task(
+			func() error { panic("great sadness") },
+			cff.FallbackWith(),
+		),
+		cff.Slice(
+			func(int) error { return errors.New("great sadness") },
+			[]int{1, 2},
+			cff.SliceFallback(func(int, error) {}),
+		),
+		cff.Slice(
+			func(int) error { return errors.New("great sadness") },
+			[]int{3, 4},
+			cff.SliceFallback(func(int, error) {}),
+			cff.Batch(2),
+		),
+		cff.Map(
+			func(string, int) error { return errors.New("great sadness") },
+			map[string]int{"a": 1},
+			cff.MapFallback(func(string, int, error) {}),
+		),
+		cff.FirstSuccess(),
+	)
+}
+
+// InFlight runs two instrumented tasks.
+// "fast" succeeds once "slow" has started,
+// and "slow" fails once release is closed.
+func InFlight(ctx context.Context, e cff.Emitter, release <-chan struct{}) error {
+	started := make(chan struct{})
+	return cff.Parallel(ctx,
+		cff.Concurrency(2),
+		cff.WithEmitter(e),
+		cff.Task(
+			func() error {
+				close(started)
+				<-release
+				return errors.New("great sadness")
+			},
+			cff.Instrument("slow"),
+		),
+		cff.Task(func() { <-started }, cff.Instrument("fast")),
+		cff.FirstSuccess(),
+	)
+}
@@ -0,0 +1,1264 @@
+//go:build !cff
+// +build !cff
+
+// Package quorum tests Parallels that stop early with cff.Quorum and
+// cff.FirstSuccess.
+package quorum
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"sync"
+	"time"
+
+	"go.uber.org/cff"
+)
+
+// FirstSuccess races a task that hangs until it's cancelled against
+// one that fails and one that succeeds.
+// cancelled is closed when the hanging task is cancelled.
+func FirstSuccess(ctx context.Context, cancelled chan<- struct{}) error {
+	started := make(chan struct{})
+	return func() (err error) {
+
+		_21_22 := ctx
+
+		_22_19 := 3
+
+		_23_12 := func(ctx context.Context) error {
+			close(started)
+			<-ctx.Done()
+			close(cancelled)
+			return ctx.Err()
+		}
+
+		_29_12 := func() error {
+			return errors.New("great sadness")
+		}
+
+		_32_12 := func() {
+			<-started
+		}
+		ctx := _21_22
+		// Cancels tasks that are still running once the quorum is reached.
+		// This runs after all other deferred functions.
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		emitter := cff.NopEmitter()
+		interceptor := cff.InterceptorStack()
+
+		var (
+			parallelInfo = &cff.ParallelInfo{
+				File:   "go.uber.org/cff/internal/tests/quorum/quorum.go",
+				Line:   21,
+				Column: 9,
+			}
+			directiveInfo = &cff.DirectiveInfo{
+				Name:      parallelInfo.Name,
+				Directive: cff.ParallelDirective,
+				File:      parallelInfo.File,
+				Line:      parallelInfo.Line,
+				Column:    parallelInfo.Column,
+			}
+			parallelEmitter = cff.NopParallelEmitter()
+
+			schedInfo = &cff.SchedulerInfo{
+				Name:      parallelInfo.Name,
+				Directive: cff.ParallelDirective,
+				File:      parallelInfo.File,
+				Line:      parallelInfo.Line,
+				Column:    parallelInfo.Column,
+			}
+
+			// possibly unused
+			_ = parallelInfo
+			_ = directiveInfo
+		)
+
+		startTime := time.Now()
+		defer func() { parallelEmitter.ParallelDone(ctx, time.Since(startTime)) }()
+
+		schedEmitter := emitter.SchedulerInit(schedInfo)
+
+		sched := cff.NewScheduler(
+			cff.SchedulerParams{
+				Concurrency: _22_19, Emitter: schedEmitter,
+
+				Quorum: 1,
+			},
+		)
+
+		var tasks []*struct {
+			emitter cff.TaskEmitter
+			fn      func(context.Context) error
+			ran     cff.AtomicBool
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		}
+		defer func() {
+			for _, t := range tasks {
+				// Tasks that are still running report their own outcome
+				// once they finish.
+				if !t.ran.Load() && !t.outcome.Running() {
+					t.emitter.TaskSkipped(ctx, t.outcome.SkipReason(ctx, err))
+				}
+			}
+		}()
+
+		// go.uber.org/cff/internal/tests/quorum/quorum.go:23:12
+		task0 := new(struct {
+			emitter cff.TaskEmitter
+			fn      func(context.Context) error
+			ran     cff.AtomicBool
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		})
+		task0.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/quorum/quorum.go",
+			Line:   23,
+			Column: 12,
+		}
+		task0.emitter = cff.NopTaskEmitter()
+		task0.fn = func(ctx context.Context) (err error) {
+			task0.outcome.Start()
+			taskEmitter := task0.emitter
+			startTime := time.Now()
+			defer func() {
+				task0.outcome.Finish(err)
+				if task0.ran.Load() {
+					taskEmitter.TaskDone(ctx, time.Since(startTime))
+				}
+			}()
+
+			defer func() {
+				recovered := recover()
+				if recovered != nil {
+					taskEmitter.TaskPanic(ctx, recovered)
+					err = &cff.PanicError{
+						Value:      recovered,
+						Stacktrace: debug.Stack(),
+					}
+				}
+			}()
+
+			defer task0.ran.Store(true)
+
+			err = cff.Intercept(ctx, interceptor, task0.outcome.Info, func(ctx context.Context) (err error) {
+				err = _23_12(ctx)
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return
+			}
+			taskEmitter.TaskSuccess(ctx)
+			return
+		}
+
+		sched.Enqueue(ctx, cff.Job{
+			Run: task0.fn,
+		})
+		tasks = append(tasks, task0)
+
+		// go.uber.org/cff/internal/tests/quorum/quorum.go:29:12
+		task1 := new(struct {
+			emitter cff.TaskEmitter
+			fn      func(context.Context) error
+			ran     cff.AtomicBool
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		})
+		task1.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/quorum/quorum.go",
+			Line:   29,
+			Column: 12,
+		}
+		task1.emitter = cff.NopTaskEmitter()
+		task1.fn = func(ctx context.Context) (err error) {
+			task1.outcome.Start()
+			taskEmitter := task1.emitter
+			startTime := time.Now()
+			defer func() {
+				task1.outcome.Finish(err)
+				if task1.ran.Load() {
+					taskEmitter.TaskDone(ctx, time.Since(startTime))
+				}
+			}()
+
+			defer func() {
+				recovered := recover()
+				if recovered != nil {
+					taskEmitter.TaskPanic(ctx, recovered)
+					err = &cff.PanicError{
+						Value:      recovered,
+						Stacktrace: debug.Stack(),
+					}
+				}
+			}()
+
+			defer task1.ran.Store(true)
+
+			err = cff.Intercept(ctx, interceptor, task1.outcome.Info, func(ctx context.Context) (err error) {
+				err = _29_12()
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return
+			}
+			taskEmitter.TaskSuccess(ctx)
+			return
+		}
+
+		sched.Enqueue(ctx, cff.Job{
+			Run: task1.fn,
+		})
+		tasks = append(tasks, task1)
+
+		// go.uber.org/cff/internal/tests/quorum/quorum.go:32:12
+		task2 := new(struct {
+			emitter cff.TaskEmitter
+			fn      func(context.Context) error
+			ran     cff.AtomicBool
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		})
+		task2.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/quorum/quorum.go",
+			Line:   32,
+			Column: 12,
+		}
+		task2.emitter = cff.NopTaskEmitter()
+		task2.fn = func(ctx context.Context) (err error) {
+			task2.outcome.Start()
+			taskEmitter := task2.emitter
+			startTime := time.Now()
+			defer func() {
+				task2.outcome.Finish(err)
+				if task2.ran.Load() {
+					taskEmitter.TaskDone(ctx, time.Since(startTime))
+				}
+			}()
+
+			defer func() {
+				recovered := recover()
+				if recovered != nil {
+					taskEmitter.TaskPanic(ctx, recovered)
+					err = &cff.PanicError{
+						Value:      recovered,
+						Stacktrace: debug.Stack(),
+					}
+				}
+			}()
+
+			defer task2.ran.Store(true)
+
+			err = cff.Intercept(ctx, interceptor, task2.outcome.Info, func(ctx context.Context) (err error) {
+				_32_12()
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return
+			}
+			taskEmitter.TaskSuccess(ctx)
+			return
+		}
+
+		sched.Enqueue(ctx, cff.Job{
+			Run: task2.fn,
+		})
+		tasks = append(tasks, task2)
+
+		if err := sched.Wait(ctx); err != nil {
+			parallelEmitter.ParallelError(ctx, err)
+			cff.RethrowPanic(err, false)
+			return err
+		}
+		parallelEmitter.ParallelSuccess(ctx)
+		return nil /*line quorum.go:35*/
+	}()
+}
+
+// Replicas writes to the given replicas until k of them succeed,
+// and reports the replicas that succeeded.
+// Writes to replicas named "down" fail.
+func Replicas(ctx context.Context, replicas []string, k int) ([]string, error) {
+	var (
+		mu      sync.Mutex
+		written []string
+	)
+	err := func() (err error) {
+
+		_47_22 := ctx
+
+		_48_19 := 1
+
+		_50_4 := func(replica string) error {
+			if replica == "down" {
+				return errors.New("replica is down")
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			written = append(written, replica)
+			return nil
+		}
+
+		_59_4 := replicas
+
+		_61_14 := k
+		ctx := _47_22
+		// Cancels tasks that are still running once the quorum is reached.
+		// This runs after all other deferred functions.
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		emitter := cff.NopEmitter()
+		interceptor := cff.InterceptorStack()
+
+		var (
+			parallelInfo = &cff.ParallelInfo{
+				File:   "go.uber.org/cff/internal/tests/quorum/quorum.go",
+				Line:   47,
+				Column: 9,
+			}
+			directiveInfo = &cff.DirectiveInfo{
+				Name:      parallelInfo.Name,
+				Directive: cff.ParallelDirective,
+				File:      parallelInfo.File,
+				Line:      parallelInfo.Line,
+				Column:    parallelInfo.Column,
+			}
+			parallelEmitter = cff.NopParallelEmitter()
+
+			schedInfo = &cff.SchedulerInfo{
+				Name:      parallelInfo.Name,
+				Directive: cff.ParallelDirective,
+				File:      parallelInfo.File,
+				Line:      parallelInfo.Line,
+				Column:    parallelInfo.Column,
+			}
+
+			// possibly unused
+			_ = parallelInfo
+			_ = directiveInfo
+		)
+
+		startTime := time.Now()
+		defer func() { parallelEmitter.ParallelDone(ctx, time.Since(startTime)) }()
+
+		quorum := _61_14
+		if quorum < 1 {
+			err := fmt.Errorf("cff.Quorum expects at least 1 task, got %d", quorum)
+			parallelEmitter.ParallelError(ctx, err)
+			return err
+		}
+
+		schedEmitter := emitter.SchedulerInit(schedInfo)
+
+		sched := cff.NewScheduler(
+			cff.SchedulerParams{
+				Concurrency: _48_19, Emitter: schedEmitter,
+
+				Quorum: quorum,
+			},
+		)
+
+		var tasks []*struct {
+			emitter cff.TaskEmitter
+			fn      func(context.Context) error
+			ran     cff.AtomicBool
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		}
+		defer func() {
+			for _, t := range tasks {
+				// Tasks that are still running report their own outcome
+				// once they finish.
+				if !t.ran.Load() && !t.outcome.Running() {
+					t.emitter.TaskSkipped(ctx, t.outcome.SkipReason(ctx, err))
+				}
+			}
+		}()
+
+		// go.uber.org/cff/internal/tests/quorum/quorum.go:49:3
+		sliceTask3Slice := _59_4
+		sliceTask3Info := &cff.TaskInfo{
+			Name:   parallelInfo.Name,
+			File:   "go.uber.org/cff/internal/tests/quorum/quorum.go",
+			Line:   49,
+			Column: 3,
+		}
+		for _, val := range sliceTask3Slice {
+
+			val := val
+			sliceTask3 := new(struct {
+				emitter cff.TaskEmitter
+				fn      func(context.Context) error
+				ran     cff.AtomicBool
+
+				outcome cff.TaskOutcome // reports why the task was skipped
+			})
+			sliceTask3.fn = func(ctx context.Context) (err error) {
+				defer func() {
+					recovered := recover()
+					if recovered != nil {
+						err = &cff.PanicError{
+							Value:      recovered,
+							Stacktrace: debug.Stack(),
+						}
+					}
+				}()
+				return cff.Intercept(ctx, interceptor, sliceTask3Info, func(ctx context.Context) (err error) {
+					err = _50_4(val)
+					return
+				})
+			}
+			sched.Enqueue(ctx, cff.Job{
+				Run: sliceTask3.fn,
+			})
+		}
+
+		if err := sched.Wait(ctx); err != nil {
+			parallelEmitter.ParallelError(ctx, err)
+			cff.RethrowPanic(err, false)
+			return err
+		}
+		parallelEmitter.ParallelSuccess(ctx)
+		return nil /*line quorum.go:61*/
+	}()
+	return written, err
+}
+
+// Skipped runs three instrumented tasks one at a time.
+// The first one succeeds, so the others are skipped.
+func Skipped(ctx context.Context, e cff.Emitter) error {
+	return func() (err error) {
+
+		_69_22 := ctx
+
+		_70_19 := 1
+
+		_71_19 := e
+
+		_72_12 := func() {}
+
+		_72_38 := "first"
+
+		_73_12 := func() {}
+
+		_73_38 := "second"
+
+		_74_12 := func() {}
+
+		_74_38 := "third"
+		ctx := _69_22
+		// Cancels tasks that are still running once the quorum is reached.
+		// This runs after all other deferred functions.
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		emitter := cff.EmitterStack(_71_19)
+		interceptor := cff.InterceptorStack()
+
+		var (
+			parallelInfo = &cff.ParallelInfo{
+				File:   "go.uber.org/cff/internal/tests/quorum/quorum.go",
+				Line:   69,
+				Column: 9,
+			}
+			directiveInfo = &cff.DirectiveInfo{
+				Name:      parallelInfo.Name,
+				Directive: cff.ParallelDirective,
+				File:      parallelInfo.File,
+				Line:      parallelInfo.Line,
+				Column:    parallelInfo.Column,
+			}
+			parallelEmitter = cff.NopParallelEmitter()
+
+			schedInfo = &cff.SchedulerInfo{
+				Name:      parallelInfo.Name,
+				Directive: cff.ParallelDirective,
+				File:      parallelInfo.File,
+				Line:      parallelInfo.Line,
+				Column:    parallelInfo.Column,
+			}
+
+			// possibly unused
+			_ = parallelInfo
+			_ = directiveInfo
+		)
+
+		startTime := time.Now()
+		defer func() { parallelEmitter.ParallelDone(ctx, time.Since(startTime)) }()
+
+		schedEmitter := emitter.SchedulerInit(schedInfo)
+
+		sched := cff.NewScheduler(
+			cff.SchedulerParams{
+				Concurrency: _70_19, Emitter: schedEmitter,
+
+				Quorum: 1,
+			},
+		)
+
+		var tasks []*struct {
+			emitter cff.TaskEmitter
+			fn      func(context.Context) error
+			ran     cff.AtomicBool
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		}
+		defer func() {
+			for _, t := range tasks {
+				// Tasks that are still running report their own outcome
+				// once they finish.
+				if !t.ran.Load() && !t.outcome.Running() {
+					t.emitter.TaskSkipped(ctx, t.outcome.SkipReason(ctx, err))
+				}
+			}
+		}()
+
+		// go.uber.org/cff/internal/tests/quorum/quorum.go:72:12
+		task4 := new(struct {
+			emitter cff.TaskEmitter
+			fn      func(context.Context) error
+			ran     cff.AtomicBool
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		})
+		task4.outcome.Info = &cff.TaskInfo{
+			Name:   _72_38,
+			File:   "go.uber.org/cff/internal/tests/quorum/quorum.go",
+			Line:   72,
+			Column: 12,
+		}
+		task4.emitter = emitter.TaskInit(task4.outcome.Info, directiveInfo)
+		task4.fn = func(ctx context.Context) (err error) {
+			task4.outcome.Start()
+			taskEmitter := task4.emitter
+			startTime := time.Now()
+			defer func() {
+				task4.outcome.Finish(err)
+				if task4.ran.Load() {
+					taskEmitter.TaskDone(ctx, time.Since(startTime))
+				}
+			}()
+
+			defer func() {
+				recovered := recover()
+				if recovered != nil {
+					taskEmitter.TaskPanic(ctx, recovered)
+					err = &cff.PanicError{
+						Value:      recovered,
+						Stacktrace: debug.Stack(),
+					}
+				}
+			}()
+
+			defer task4.ran.Store(true)
+
+			err = cff.Intercept(ctx, interceptor, task4.outcome.Info, func(ctx context.Context) (err error) {
+				_72_12()
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return
+			}
+			taskEmitter.TaskSuccess(ctx)
+			return
+		}
+
+		sched.Enqueue(ctx, cff.Job{
+			Run: task4.fn,
+		})
+		tasks = append(tasks, task4)
+
+		// go.uber.org/cff/internal/tests/quorum/quorum.go:73:12
+		task5 := new(struct {
+			emitter cff.TaskEmitter
+			fn      func(context.Context) error
+			ran     cff.AtomicBool
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		})
+		task5.outcome.Info = &cff.TaskInfo{
+			Name:   _73_38,
+			File:   "go.uber.org/cff/internal/tests/quorum/quorum.go",
+			Line:   73,
+			Column: 12,
+		}
+		task5.emitter = emitter.TaskInit(task5.outcome.Info, directiveInfo)
+		task5.fn = func(ctx context.Context) (err error) {
+			task5.outcome.Start()
+			taskEmitter := task5.emitter
+			startTime := time.Now()
+			defer func() {
+				task5.outcome.Finish(err)
+				if task5.ran.Load() {
+					taskEmitter.TaskDone(ctx, time.Since(startTime))
+				}
+			}()
+
+			defer func() {
+				recovered := recover()
+				if recovered != nil {
+					taskEmitter.TaskPanic(ctx, recovered)
+					err = &cff.PanicError{
+						Value:      recovered,
+						Stacktrace: debug.Stack(),
+					}
+				}
+			}()
+
+			defer task5.ran.Store(true)
+
+			err = cff.Intercept(ctx, interceptor, task5.outcome.Info, func(ctx context.Context) (err error) {
+				_73_12()
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return
+			}
+			taskEmitter.TaskSuccess(ctx)
+			return
+		}
+
+		sched.Enqueue(ctx, cff.Job{
+			Run: task5.fn,
+		})
+		tasks = append(tasks, task5)
+
+		// go.uber.org/cff/internal/tests/quorum/quorum.go:74:12
+		task6 := new(struct {
+			emitter cff.TaskEmitter
+			fn      func(context.Context) error
+			ran     cff.AtomicBool
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		})
+		task6.outcome.Info = &cff.TaskInfo{
+			Name:   _74_38,
+			File:   "go.uber.org/cff/internal/tests/quorum/quorum.go",
+			Line:   74,
+			Column: 12,
+		}
+		task6.emitter = emitter.TaskInit(task6.outcome.Info, directiveInfo)
+		task6.fn = func(ctx context.Context) (err error) {
+			task6.outcome.Start()
+			taskEmitter := task6.emitter
+			startTime := time.Now()
+			defer func() {
+				task6.outcome.Finish(err)
+				if task6.ran.Load() {
+					taskEmitter.TaskDone(ctx, time.Since(startTime))
+				}
+			}()
+
+			defer func() {
+				recovered := recover()
+				if recovered != nil {
+					taskEmitter.TaskPanic(ctx, recovered)
+					err = &cff.PanicError{
+						Value:      recovered,
+						Stacktrace: debug.Stack(),
+					}
+				}
+			}()
+
+			defer task6.ran.Store(true)
+
+			err = cff.Intercept(ctx, interceptor, task6.outcome.Info, func(ctx context.Context) (err error) {
+				_74_12()
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return
+			}
+			taskEmitter.TaskSuccess(ctx)
+			return
+		}
+
+		sched.Enqueue(ctx, cff.Job{
+			Run: task6.fn,
+		})
+		tasks = append(tasks, task6)
+
+		if err := sched.Wait(ctx); err != nil {
+			parallelEmitter.ParallelError(ctx, err)
+			cff.RethrowPanic(err, false)
+			return err
+		}
+		parallelEmitter.ParallelSuccess(ctx)
+		return nil /*line quorum.go:75*/
+	}()
+}
+
+// Recovered runs tasks that fail and are recovered by their fallbacks.
+// None of them succeed.
+func Recovered(ctx context.Context) error {
+	return func() (err error) {
+
+		_82_22 := ctx
+
+		_84_4 := func() error { return errors.New("great sadness") }
+
+		_88_4 := func() error { panic("great sadness") }
+
+		_92_4 := func(int) error { return errors.New("great sadness") }
+
+		_93_4 := []int{1, 2}
+
+		_94_22 := func(int, error) {}
+
+		_97_4 := func(int) error { return errors.New("great sadness") }
+
+		_98_4 := []int{3, 4}
+
+		_99_22 := func(int, error) {}
+
+		_100_14 := 2
+
+		_103_4 := func(string, int) error { return errors.New("great sadness") }
+
+		_104_4 := map[string]int{"a": 1}
+
+		_105_20 := func(string, int, error) {}
+		ctx := _82_22
+		// Cancels tasks that are still running once the quorum is reached.
+		// This runs after all other deferred functions.
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		emitter := cff.NopEmitter()
+		interceptor := cff.InterceptorStack()
+
+		var (
+			parallelInfo = &cff.ParallelInfo{
+				File:   "go.uber.org/cff/internal/tests/quorum/quorum.go",
+				Line:   82,
+				Column: 9,
+			}
+			directiveInfo = &cff.DirectiveInfo{
+				Name:      parallelInfo.Name,
+				Directive: cff.ParallelDirective,
+				File:      parallelInfo.File,
+				Line:      parallelInfo.Line,
+				Column:    parallelInfo.Column,
+			}
+			parallelEmitter = cff.NopParallelEmitter()
+
+			schedInfo = &cff.SchedulerInfo{
+				Name:      parallelInfo.Name,
+				Directive: cff.ParallelDirective,
+				File:      parallelInfo.File,
+				Line:      parallelInfo.Line,
+				Column:    parallelInfo.Column,
+			}
+
+			// possibly unused
+			_ = parallelInfo
+			_ = directiveInfo
+		)
+
+		startTime := time.Now()
+		defer func() { parallelEmitter.ParallelDone(ctx, time.Since(startTime)) }()
+
+		schedEmitter := emitter.SchedulerInit(schedInfo)
+
+		sched := cff.NewScheduler(
+			cff.SchedulerParams{
+				Emitter: schedEmitter,
+
+				Quorum: 1,
+			},
+		)
+
+		var tasks []*struct {
+			emitter cff.TaskEmitter
+			fn      func(context.Context) error
+			ran     cff.AtomicBool
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		}
+		defer func() {
+			for _, t := range tasks {
+				// Tasks that are still running report their own outcome
+				// once they finish.
+				if !t.ran.Load() && !t.outcome.Running() {
+					t.emitter.TaskSkipped(ctx, t.outcome.SkipReason(ctx, err))
+				}
+			}
+		}()
+
+		// go.uber.org/cff/internal/tests/quorum/quorum.go:84:4
+		task7 := new(struct {
+			emitter cff.TaskEmitter
+			fn      func(context.Context) error
+			ran     cff.AtomicBool
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		})
+		task7.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/quorum/quorum.go",
+			Line:   84,
+			Column: 4,
+		}
+		task7.emitter = cff.NopTaskEmitter()
+		task7.fn = func(ctx context.Context) (err error) {
+			task7.outcome.Start()
+			taskEmitter := task7.emitter
+			startTime := time.Now()
+			defer func() {
+				task7.outcome.Finish(err)
+				if task7.ran.Load() {
+					taskEmitter.TaskDone(ctx, time.Since(startTime))
+				}
+			}()
+
+			defer func() {
+				recovered := recover()
+				if recovered != nil {
+					taskEmitter.TaskPanicRecovered(ctx, recovered)
+					err = cff.ErrNotSucceeded
+				}
+			}()
+
+			defer task7.ran.Store(true)
+
+			err = cff.Intercept(ctx, interceptor, task7.outcome.Info, func(ctx context.Context) (err error) {
+				err = _84_4()
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskErrorRecovered(ctx, err)
+				return cff.ErrNotSucceeded
+			}
+			taskEmitter.TaskSuccess(ctx)
+			return
+		}
+
+		sched.Enqueue(ctx, cff.Job{
+			Run: task7.fn,
+		})
+		tasks = append(tasks, task7)
+
+		// go.uber.org/cff/internal/tests/quorum/quorum.go:88:4
+		task8 := new(struct {
+			emitter cff.TaskEmitter
+			fn      func(context.Context) error
+			ran     cff.AtomicBool
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		})
+		task8.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/quorum/quorum.go",
+			Line:   88,
+			Column: 4,
+		}
+		task8.emitter = cff.NopTaskEmitter()
+		task8.fn = func(ctx context.Context) (err error) {
+			task8.outcome.Start()
+			taskEmitter := task8.emitter
+			startTime := time.Now()
+			defer func() {
+				task8.outcome.Finish(err)
+				if task8.ran.Load() {
+					taskEmitter.TaskDone(ctx, time.Since(startTime))
+				}
+			}()
+
+			defer func() {
+				recovered := recover()
+				if recovered != nil {
+					taskEmitter.TaskPanicRecovered(ctx, recovered)
+					err = cff.ErrNotSucceeded
+				}
+			}()
+
+			defer task8.ran.Store(true)
+
+			err = cff.Intercept(ctx, interceptor, task8.outcome.Info, func(ctx context.Context) (err error) {
+				err = _88_4()
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskErrorRecovered(ctx, err)
+				return cff.ErrNotSucceeded
+			}
+			taskEmitter.TaskSuccess(ctx)
+			return
+		}
+
+		sched.Enqueue(ctx, cff.Job{
+			Run: task8.fn,
+		})
+		tasks = append(tasks, task8)
+
+		// go.uber.org/cff/internal/tests/quorum/quorum.go:91:3
+		sliceTask9Slice := _93_4
+		sliceTask9Info := &cff.TaskInfo{
+			Name:   parallelInfo.Name,
+			File:   "go.uber.org/cff/internal/tests/quorum/quorum.go",
+			Line:   91,
+			Column: 3,
+		}
+		for _, val := range sliceTask9Slice {
+
+			val := val
+			sliceTask9 := new(struct {
+				emitter cff.TaskEmitter
+				fn      func(context.Context) error
+				ran     cff.AtomicBool
+
+				outcome cff.TaskOutcome // reports why the task was skipped
+			})
+			sliceTask9.fn = func(ctx context.Context) (err error) {
+				defer func() {
+					recovered := recover()
+					if recovered != nil {
+						err = &cff.PanicError{
+							Value:      recovered,
+							Stacktrace: debug.Stack(),
+						}
+					}
+					if err != nil {
+						_94_22(val, err)
+						err = cff.ErrNotSucceeded
+					}
+				}()
+				return cff.Intercept(ctx, interceptor, sliceTask9Info, func(ctx context.Context) (err error) {
+					err = _92_4(val)
+					return
+				})
+			}
+			sched.Enqueue(ctx, cff.Job{
+				Run: sliceTask9.fn,
+			})
+		}
+
+		// go.uber.org/cff/internal/tests/quorum/quorum.go:96:3
+		sliceTask10Slice := _98_4
+		sliceTask10Info := &cff.TaskInfo{
+			Name:   parallelInfo.Name,
+			File:   "go.uber.org/cff/internal/tests/quorum/quorum.go",
+			Line:   96,
+			Column: 3,
+		}
+		sliceTask10Fn := func(ctx context.Context, idx int, val int) (err error) {
+			defer func() {
+				recovered := recover()
+				if recovered != nil {
+					err = &cff.PanicError{
+						Value:      recovered,
+						Stacktrace: debug.Stack(),
+					}
+				}
+				if err != nil {
+					_99_22(val, err)
+					err = cff.ErrNotSucceeded
+				}
+			}()
+			return cff.Intercept(ctx, interceptor, sliceTask10Info, func(ctx context.Context) (err error) {
+				err = _97_4(val)
+				return
+			})
+		}
+		sliceTask10Batch := _100_14
+		if sliceTask10Batch < 1 {
+			sliceTask10Batch = 1
+		}
+		for start := 0; start < len(sliceTask10Slice); start += sliceTask10Batch {
+			start, end := start, start+sliceTask10Batch
+			if end > len(sliceTask10Slice) {
+				end = len(sliceTask10Slice)
+			}
+			sched.Enqueue(ctx, cff.Job{
+				Run: func(ctx context.Context) (err error) {
+					// The batch counts towards the quorum only if all
+					// its elements succeeded.
+					notSucceeded := false
+					for idx := start; idx < end; idx++ {
+						if elemErr := sliceTask10Fn(ctx, idx, sliceTask10Slice[idx]); elemErr != nil {
+							if elemErr == cff.ErrNotSucceeded {
+								notSucceeded = true
+								continue
+							}
+							err = cff.AppendError(err, elemErr)
+							return
+						}
+					}
+					if notSucceeded {
+						err = cff.ErrNotSucceeded
+					}
+					return
+				},
+			})
+		}
+
+		mapTask11Info := &cff.TaskInfo{
+			Name:   parallelInfo.Name,
+			File:   "go.uber.org/cff/internal/tests/quorum/quorum.go",
+			Line:   102,
+			Column: 3,
+		}
+		// go.uber.org/cff/internal/tests/quorum/quorum.go:102:3
+		for key, val := range _104_4 {
+			key := key
+			val := val
+			mapTask11 := new(struct {
+				emitter cff.TaskEmitter
+				fn      func(context.Context) error
+				ran     cff.AtomicBool
+
+				outcome cff.TaskOutcome // reports why the task was skipped
+			})
+			mapTask11.fn = func(ctx context.Context) (err error) {
+				defer func() {
+					recovered := recover()
+					if recovered != nil {
+						err = &cff.PanicError{
+							Value:      recovered,
+							Stacktrace: debug.Stack(),
+						}
+					}
+					if err != nil {
+						_105_20(key, val, err)
+						err = cff.ErrNotSucceeded
+					}
+				}()
+
+				return cff.Intercept(ctx, interceptor, mapTask11Info, func(ctx context.Context) (err error) {
+					err = _103_4(key, val)
+					return
+				})
+			}
+
+			sched.Enqueue(ctx, cff.Job{
+				Run: mapTask11.fn,
+			})
+		}
+
+		if err := sched.Wait(ctx); err != nil {
+			parallelEmitter.ParallelError(ctx, err)
+			cff.RethrowPanic(err, false)
+			return err
+		}
+		parallelEmitter.ParallelSuccess(ctx)
+		return nil /*line quorum.go:107*/
+	}()
+}
+
+// InFlight runs two instrumented tasks.
+// "fast" succeeds once "slow" has started,
+// and "slow" fails once release is closed.
+func InFlight(ctx context.Context, e cff.Emitter, release <-chan struct{}) error {
+	started := make(chan struct{})
+	return func() (err error) {
+
+		_116_22 := ctx
+
+		_117_19 := 2
+
+		_118_19 := e
+
+		_120_4 := func() error {
+			close(started)
+			<-release
+			return errors.New("great sadness")
+		}
+
+		_125_19 := "slow"
+
+		_127_12 := func() { <-started }
+
+		_127_49 := "fast"
+		ctx := _116_22
+		// Cancels tasks that are still running once the quorum is reached.
+		// This runs after all other deferred functions.
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		emitter := cff.EmitterStack(_118_19)
+		interceptor := cff.InterceptorStack()
+
+		var (
+			parallelInfo = &cff.ParallelInfo{
+				File:   "go.uber.org/cff/internal/tests/quorum/quorum.go",
+				Line:   116,
+				Column: 9,
+			}
+			directiveInfo = &cff.DirectiveInfo{
+				Name:      parallelInfo.Name,
+				Directive: cff.ParallelDirective,
+				File:      parallelInfo.File,
+				Line:      parallelInfo.Line,
+				Column:    parallelInfo.Column,
+			}
+			parallelEmitter = cff.NopParallelEmitter()
+
+			schedInfo = &cff.SchedulerInfo{
+				Name:      parallelInfo.Name,
+				Directive: cff.ParallelDirective,
+				File:      parallelInfo.File,
+				Line:      parallelInfo.Line,
+				Column:    parallelInfo.Column,
+			}
+
+			// possibly unused
+			_ = parallelInfo
+			_ = directiveInfo
+		)
+
+		startTime := time.Now()
+		defer func() { parallelEmitter.ParallelDone(ctx, time.Since(startTime)) }()
+
+		schedEmitter := emitter.SchedulerInit(schedInfo)
+
+		sched := cff.NewScheduler(
+			cff.SchedulerParams{
+				Concurrency: _117_19, Emitter: schedEmitter,
+
+				Quorum: 1,
+			},
+		)
+
+		var tasks []*struct {
+			emitter cff.TaskEmitter
+			fn      func(context.Context) error
+			ran     cff.AtomicBool
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		}
+		defer func() {
+			for _, t := range tasks {
+				// Tasks that are still running report their own outcome
+				// once they finish.
+				if !t.ran.Load() && !t.outcome.Running() {
+					t.emitter.TaskSkipped(ctx, t.outcome.SkipReason(ctx, err))
+				}
+			}
+		}()
+
+		// go.uber.org/cff/internal/tests/quorum/quorum.go:120:4
+		task12 := new(struct {
+			emitter cff.TaskEmitter
+			fn      func(context.Context) error
+			ran     cff.AtomicBool
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		})
+		task12.outcome.Info = &cff.TaskInfo{
+			Name:   _125_19,
+			File:   "go.uber.org/cff/internal/tests/quorum/quorum.go",
+			Line:   120,
+			Column: 4,
+		}
+		task12.emitter = emitter.TaskInit(task12.outcome.Info, directiveInfo)
+		task12.fn = func(ctx context.Context) (err error) {
+			task12.outcome.Start()
+			taskEmitter := task12.emitter
+			startTime := time.Now()
+			defer func() {
+				task12.outcome.Finish(err)
+				if task12.ran.Load() {
+					taskEmitter.TaskDone(ctx, time.Since(startTime))
+				}
+			}()
+
+			defer func() {
+				recovered := recover()
+				if recovered != nil {
+					taskEmitter.TaskPanic(ctx, recovered)
+					err = &cff.PanicError{
+						Value:      recovered,
+						Stacktrace: debug.Stack(),
+					}
+				}
+			}()
+
+			defer task12.ran.Store(true)
+
+			err = cff.Intercept(ctx, interceptor, task12.outcome.Info, func(ctx context.Context) (err error) {
+				err = _120_4()
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return
+			}
+			taskEmitter.TaskSuccess(ctx)
+			return
+		}
+
+		sched.Enqueue(ctx, cff.Job{
+			Run: task12.fn,
+		})
+		tasks = append(tasks, task12)
+
+		// go.uber.org/cff/internal/tests/quorum/quorum.go:127:12
+		task13 := new(struct {
+			emitter cff.TaskEmitter
+			fn      func(context.Context) error
+			ran     cff.AtomicBool
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		})
+		task13.outcome.Info = &cff.TaskInfo{
+			Name:   _127_49,
+			File:   "go.uber.org/cff/internal/tests/quorum/quorum.go",
+			Line:   127,
+			Column: 12,
+		}
+		task13.emitter = emitter.TaskInit(task13.outcome.Info, directiveInfo)
+		task13.fn = func(ctx context.Context) (err error) {
+			task13.outcome.Start()
+			taskEmitter := task13.emitter
+			startTime := time.Now()
+			defer func() {
+				task13.outcome.Finish(err)
+				if task13.ran.Load() {
+					taskEmitter.TaskDone(ctx, time.Since(startTime))
+				}
+			}()
+
+			defer func() {
+				recovered := recover()
+				if recovered != nil {
+					taskEmitter.TaskPanic(ctx, recovered)
+					err = &cff.PanicError{
+						Value:      recovered,
+						Stacktrace: debug.Stack(),
+					}
+				}
+			}()
+
+			defer task13.ran.Store(true)
+
+			err = cff.Intercept(ctx, interceptor, task13.outcome.Info, func(ctx context.Context) (err error) {
+				_127_12()
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return
+			}
+			taskEmitter.TaskSuccess(ctx)
+			return
+		}
+
+		sched.Enqueue(ctx, cff.Job{
+			Run: task13.fn,
+		})
+		tasks = append(tasks, task13)
+
+		if err := sched.Wait(ctx); err != nil {
+			parallelEmitter.ParallelError(ctx, err)
+			cff.RethrowPanic(err, false)
+			return err
+		}
+		parallelEmitter.ParallelSuccess(ctx)
+		return nil /*line quorum.go:128*/
+	}()
+}
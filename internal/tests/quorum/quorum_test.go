@@ -0,0 +1,92 @@
+package quorum
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/cff"
+	"go.uber.org/cff/internal/emittertest"
+)
+
+func TestFirstSuccess(t *testing.T) {
+	cancelled := make(chan struct{})
+	require.NoError(t, FirstSuccess(context.Background(), cancelled))
+
+	select {
+	case <-cancelled:
+	case <-time.After(time.Second):
+		t.Fatal("hanging task was not cancelled")
+	}
+}
+
+func TestReplicas(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("quorum", func(t *testing.T) {
+		written, err := Replicas(ctx, []string{"a", "down", "b", "c", "d"}, 2)
+		require.NoError(t, err)
+		assert.Subset(t, written, []string{"a", "b"})
+		// "c" may start before the scheduler finds out that "b"
+		// succeeded, but "d" never does.
+		assert.NotContains(t, written, "d",
+			"must stop once enough replicas succeed")
+	})
+
+	t.Run("not reached", func(t *testing.T) {
+		written, err := Replicas(ctx, []string{"a", "down", "down"}, 2)
+		assert.Equal(t, []string{"a"}, written)
+
+		var qerr *cff.QuorumError
+		require.ErrorAs(t, err, &qerr)
+		assert.Equal(t, 1, qerr.Succeeded)
+		assert.Equal(t, 2, qerr.Quorum)
+		assert.ErrorContains(t, err, "replica is down")
+	})
+
+	t.Run("invalid quorum", func(t *testing.T) {
+		written, err := Replicas(ctx, []string{"a"}, 0)
+		assert.Empty(t, written)
+		assert.EqualError(t, err, "cff.Quorum expects at least 1 task, got 0")
+	})
+}
+
+func TestRecovered(t *testing.T) {
+	// Tasks recovered by fallbacks don't count towards the quorum.
+	err := Recovered(context.Background())
+
+	var qerr *cff.QuorumError
+	require.ErrorAs(t, err, &qerr)
+	assert.Equal(t, 0, qerr.Succeeded)
+	assert.Equal(t, 1, qerr.Quorum)
+}
+
+func TestSkipped(t *testing.T) {
+	e := emittertest.NewRecorder()
+	require.NoError(t, Skipped(context.Background(), e))
+
+	// The second task may start before the scheduler finds out that
+	// the first one succeeded, but the third never does.
+	skips := e.SkipKinds()
+	assert.Equal(t, cff.SkipQuorumReached, skips["third"])
+	for name, kind := range skips {
+		assert.Equal(t, cff.SkipQuorumReached, kind, "task %q", name)
+	}
+}
+
+func TestInFlight(t *testing.T) {
+	e := emittertest.NewRecorder()
+	release := make(chan struct{})
+	require.NoError(t, InFlight(context.Background(), e, release))
+
+	// "slow" was running when the quorum was reached:
+	// it isn't skipped, and reports its own outcome once it finishes.
+	close(release)
+	assert.Eventually(t, func() bool {
+		return len(e.EventsOf(emittertest.TaskError)) > 0
+	}, time.Second, time.Millisecond, "in-flight task did not report its failure")
+	assert.Equal(t, []string{"slow"}, e.Names(emittertest.TaskError))
+	assert.Empty(t, e.EventsOf(emittertest.TaskSkipped))
+}
@@ -0,0 +1,65 @@
+//go:build cff
+// +build cff
+
+// Package ratelimit tests cff.RateLimit.
+package ratelimit
+
+import (
+	"context"
+	"strconv"
+
+	"go.uber.org/cff"
+)
+
+// Fanout runs a task, the elements of a slice, and the elements of a map
+// in a Parallel limited by the given limiter.
+// Each of them is also limited by its own limiter.
+func Fanout(
+	ctx context.Context,
+	parallel, task, slice, mapl cff.Limiter,
+	ids []int,
+	names map[string]int,
+) error {
+	return cff.Parallel(ctx,
+		cff.RateLimit(parallel),
+		cff.Task(
+			func() {},
+			cff.RateLimit(task),
+		),
+		cff.Slice(
+			func(int) {},
+			ids,
+			cff.RateLimit(slice),
+		),
+		cff.Map(
+			func(string, int) {},
+			names,
+			cff.RateLimit(mapl),
+		),
+	)
+}
+
+// BatchedSlice processes the given ids in batches, limited by the given
+// limiter.
+func BatchedSlice(ctx context.Context, l cff.Limiter, ids []int, size int) error {
+	return cff.Parallel(ctx,
+		cff.Slice(
+			func(int) {},
+			ids,
+			cff.Batch(size),
+			cff.RateLimit(l),
+		),
+	)
+}
+
+// FlowTask formats the given number in a Flow,
+// limiting the task that does so with the given limiter.
+func FlowTask(ctx context.Context, l cff.Limiter, n int) (string, error) {
+	var out string
+	err := cff.Flow(ctx,
+		cff.Params(n),
+		cff.Results(&out),
+		cff.Task(strconv.Itoa, cff.RateLimit(l)),
+	)
+	return out, err
+}
@@ -0,0 +1,525 @@
+//go:build !cff
+// +build !cff
+
+// Package ratelimit tests cff.RateLimit.
+package ratelimit
+
+import (
+	"context"
+	"runtime/debug"
+	"strconv"
+	"time"
+
+	"go.uber.org/cff"
+)
+
+// Fanout runs a task, the elements of a slice, and the elements of a map
+// in a Parallel limited by the given limiter.
+// Each of them is also limited by its own limiter.
+func Fanout(
+	ctx context.Context,
+	parallel, task, slice, mapl cff.Limiter,
+	ids []int,
+	names map[string]int,
+) error {
+	return func() (err error) {
+
+		_23_22 := ctx
+
+		_24_17 := parallel
+
+		_26_4 := func() {}
+
+		_27_18 := task
+
+		_30_4 := func(int) {}
+
+		_31_4 := ids
+
+		_32_18 := slice
+
+		_35_4 := func(string, int) {}
+
+		_36_4 := names
+
+		_37_18 := mapl
+		ctx := _23_22
+		emitter := cff.NopEmitter()
+		interceptor := cff.InterceptorStack()
+
+		var (
+			parallelInfo = &cff.ParallelInfo{
+				File:   "go.uber.org/cff/internal/tests/ratelimit/ratelimit.go",
+				Line:   23,
+				Column: 9,
+			}
+			directiveInfo = &cff.DirectiveInfo{
+				Name:      parallelInfo.Name,
+				Directive: cff.ParallelDirective,
+				File:      parallelInfo.File,
+				Line:      parallelInfo.Line,
+				Column:    parallelInfo.Column,
+			}
+			parallelEmitter = cff.NopParallelEmitter()
+
+			schedInfo = &cff.SchedulerInfo{
+				Name:      parallelInfo.Name,
+				Directive: cff.ParallelDirective,
+				File:      parallelInfo.File,
+				Line:      parallelInfo.Line,
+				Column:    parallelInfo.Column,
+			}
+
+			// possibly unused
+			_ = parallelInfo
+			_ = directiveInfo
+		)
+
+		startTime := time.Now()
+		defer func() { parallelEmitter.ParallelDone(ctx, time.Since(startTime)) }()
+
+		schedEmitter := emitter.SchedulerInit(schedInfo)
+
+		sched := cff.NewScheduler(
+			cff.SchedulerParams{
+				Emitter: schedEmitter,
+
+				Limiter: _24_17,
+			},
+		)
+
+		var tasks []*struct {
+			emitter cff.TaskEmitter
+			fn      func(context.Context) error
+			ran     cff.AtomicBool
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		}
+		defer func() {
+			for _, t := range tasks {
+				// Tasks that are still running report their own outcome
+				// once they finish.
+				if !t.ran.Load() && !t.outcome.Running() {
+					t.emitter.TaskSkipped(ctx, t.outcome.SkipReason(ctx, err))
+				}
+			}
+		}()
+
+		// go.uber.org/cff/internal/tests/ratelimit/ratelimit.go:26:4
+		task0 := new(struct {
+			emitter cff.TaskEmitter
+			fn      func(context.Context) error
+			ran     cff.AtomicBool
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		})
+		task0.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/ratelimit/ratelimit.go",
+			Line:   26,
+			Column: 4,
+		}
+		task0.emitter = cff.NopTaskEmitter()
+		task0.fn = func(ctx context.Context) (err error) {
+			task0.outcome.Start()
+			taskEmitter := task0.emitter
+			startTime := time.Now()
+			defer func() {
+				task0.outcome.Finish(err)
+				if task0.ran.Load() {
+					taskEmitter.TaskDone(ctx, time.Since(startTime))
+				}
+			}()
+
+			defer func() {
+				recovered := recover()
+				if recovered != nil {
+					taskEmitter.TaskPanic(ctx, recovered)
+					err = &cff.PanicError{
+						Value:      recovered,
+						Stacktrace: debug.Stack(),
+					}
+				}
+			}()
+
+			defer task0.ran.Store(true)
+
+			err = cff.Intercept(ctx, interceptor, task0.outcome.Info, func(ctx context.Context) (err error) {
+				_26_4()
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return
+			}
+			taskEmitter.TaskSuccess(ctx)
+			return
+		}
+
+		sched.Enqueue(ctx, cff.Job{
+			Run:     task0.fn,
+			Limiter: _27_18,
+		})
+		tasks = append(tasks, task0)
+
+		// go.uber.org/cff/internal/tests/ratelimit/ratelimit.go:29:3
+		sliceTask1Slice := _31_4
+		sliceTask1Info := &cff.TaskInfo{
+			Name:   parallelInfo.Name,
+			File:   "go.uber.org/cff/internal/tests/ratelimit/ratelimit.go",
+			Line:   29,
+			Column: 3,
+		}
+		for _, val := range sliceTask1Slice {
+
+			val := val
+			sliceTask1 := new(struct {
+				emitter cff.TaskEmitter
+				fn      func(context.Context) error
+				ran     cff.AtomicBool
+
+				outcome cff.TaskOutcome // reports why the task was skipped
+			})
+			sliceTask1.fn = func(ctx context.Context) (err error) {
+				defer func() {
+					recovered := recover()
+					if recovered != nil {
+						err = &cff.PanicError{
+							Value:      recovered,
+							Stacktrace: debug.Stack(),
+						}
+					}
+				}()
+				return cff.Intercept(ctx, interceptor, sliceTask1Info, func(ctx context.Context) (err error) {
+					_30_4(val)
+					return
+				})
+			}
+			sched.Enqueue(ctx, cff.Job{
+				Run:     sliceTask1.fn,
+				Limiter: _32_18,
+			})
+		}
+
+		mapTask2Info := &cff.TaskInfo{
+			Name:   parallelInfo.Name,
+			File:   "go.uber.org/cff/internal/tests/ratelimit/ratelimit.go",
+			Line:   34,
+			Column: 3,
+		}
+		// go.uber.org/cff/internal/tests/ratelimit/ratelimit.go:34:3
+		for key, val := range _36_4 {
+			key := key
+			val := val
+			mapTask2 := new(struct {
+				emitter cff.TaskEmitter
+				fn      func(context.Context) error
+				ran     cff.AtomicBool
+
+				outcome cff.TaskOutcome // reports why the task was skipped
+			})
+			mapTask2.fn = func(ctx context.Context) (err error) {
+				defer func() {
+					recovered := recover()
+					if recovered != nil {
+						err = &cff.PanicError{
+							Value:      recovered,
+							Stacktrace: debug.Stack(),
+						}
+					}
+				}()
+
+				return cff.Intercept(ctx, interceptor, mapTask2Info, func(ctx context.Context) (err error) {
+					_35_4(key, val)
+					return
+				})
+			}
+
+			sched.Enqueue(ctx, cff.Job{
+				Run:     mapTask2.fn,
+				Limiter: _37_18,
+			})
+		}
+
+		if err := sched.Wait(ctx); err != nil {
+			parallelEmitter.ParallelError(ctx, err)
+			cff.RethrowPanic(err, false)
+			return err
+		}
+		parallelEmitter.ParallelSuccess(ctx)
+		return nil /*line ratelimit.go:38*/
+	}()
+}
+
+// BatchedSlice processes the given ids in batches, limited by the given
+// limiter.
+func BatchedSlice(ctx context.Context, l cff.Limiter, ids []int, size int) error {
+	return func() (err error) {
+
+		_45_22 := ctx
+
+		_47_4 := func(int) {}
+
+		_48_4 := ids
+
+		_49_14 := size
+
+		_50_18 := l
+		ctx := _45_22
+		emitter := cff.NopEmitter()
+		interceptor := cff.InterceptorStack()
+
+		var (
+			parallelInfo = &cff.ParallelInfo{
+				File:   "go.uber.org/cff/internal/tests/ratelimit/ratelimit.go",
+				Line:   45,
+				Column: 9,
+			}
+			directiveInfo = &cff.DirectiveInfo{
+				Name:      parallelInfo.Name,
+				Directive: cff.ParallelDirective,
+				File:      parallelInfo.File,
+				Line:      parallelInfo.Line,
+				Column:    parallelInfo.Column,
+			}
+			parallelEmitter = cff.NopParallelEmitter()
+
+			schedInfo = &cff.SchedulerInfo{
+				Name:      parallelInfo.Name,
+				Directive: cff.ParallelDirective,
+				File:      parallelInfo.File,
+				Line:      parallelInfo.Line,
+				Column:    parallelInfo.Column,
+			}
+
+			// possibly unused
+			_ = parallelInfo
+			_ = directiveInfo
+		)
+
+		startTime := time.Now()
+		defer func() { parallelEmitter.ParallelDone(ctx, time.Since(startTime)) }()
+
+		schedEmitter := emitter.SchedulerInit(schedInfo)
+
+		sched := cff.NewScheduler(
+			cff.SchedulerParams{
+				Emitter: schedEmitter,
+			},
+		)
+
+		var tasks []*struct {
+			emitter cff.TaskEmitter
+			fn      func(context.Context) error
+			ran     cff.AtomicBool
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		}
+		defer func() {
+			for _, t := range tasks {
+				// Tasks that are still running report their own outcome
+				// once they finish.
+				if !t.ran.Load() && !t.outcome.Running() {
+					t.emitter.TaskSkipped(ctx, t.outcome.SkipReason(ctx, err))
+				}
+			}
+		}()
+
+		// go.uber.org/cff/internal/tests/ratelimit/ratelimit.go:46:3
+		sliceTask3Slice := _48_4
+		sliceTask3Info := &cff.TaskInfo{
+			Name:   parallelInfo.Name,
+			File:   "go.uber.org/cff/internal/tests/ratelimit/ratelimit.go",
+			Line:   46,
+			Column: 3,
+		}
+		sliceTask3Fn := func(ctx context.Context, idx int, val int) (err error) {
+			defer func() {
+				recovered := recover()
+				if recovered != nil {
+					err = &cff.PanicError{
+						Value:      recovered,
+						Stacktrace: debug.Stack(),
+					}
+				}
+			}()
+			return cff.Intercept(ctx, interceptor, sliceTask3Info, func(ctx context.Context) (err error) {
+				_47_4(val)
+				return
+			})
+		}
+		sliceTask3Batch := _49_14
+		if sliceTask3Batch < 1 {
+			sliceTask3Batch = 1
+		}
+		for start := 0; start < len(sliceTask3Slice); start += sliceTask3Batch {
+			start, end := start, start+sliceTask3Batch
+			if end > len(sliceTask3Slice) {
+				end = len(sliceTask3Slice)
+			}
+			sched.Enqueue(ctx, cff.Job{
+				Limiter: _50_18,
+				Run: func(ctx context.Context) (err error) {
+					for idx := start; idx < end; idx++ {
+						if elemErr := sliceTask3Fn(ctx, idx, sliceTask3Slice[idx]); elemErr != nil {
+							err = cff.AppendError(err, elemErr)
+							return
+						}
+					}
+					return
+				},
+			})
+		}
+
+		if err := sched.Wait(ctx); err != nil {
+			parallelEmitter.ParallelError(ctx, err)
+			cff.RethrowPanic(err, false)
+			return err
+		}
+		parallelEmitter.ParallelSuccess(ctx)
+		return nil /*line ratelimit.go:51*/
+	}()
+}
+
+// FlowTask formats the given number in a Flow,
+// limiting the task that does so with the given limiter.
+func FlowTask(ctx context.Context, l cff.Limiter, n int) (string, error) {
+	var out string
+	err := func() (err error) {
+
+		_59_18 := ctx
+
+		_60_14 := n
+
+		_61_15 := &out
+
+		_62_12 := strconv.Itoa
+
+		_62_40 := l
+		ctx := _59_18
+		var v1 int = _60_14
+		emitter := cff.NopEmitter()
+		interceptor := cff.InterceptorStack()
+
+		var (
+			flowInfo = &cff.FlowInfo{
+				File:   "go.uber.org/cff/internal/tests/ratelimit/ratelimit.go",
+				Line:   59,
+				Column: 9,
+			}
+			flowEmitter = cff.NopFlowEmitter()
+
+			schedInfo = &cff.SchedulerInfo{
+				Name:      flowInfo.Name,
+				Directive: cff.FlowDirective,
+				File:      flowInfo.File,
+				Line:      flowInfo.Line,
+				Column:    flowInfo.Column,
+			}
+
+			// possibly unused
+			_ = flowInfo
+		)
+
+		startTime := time.Now()
+		defer func() { flowEmitter.FlowDone(ctx, time.Since(startTime)) }()
+
+		schedEmitter := emitter.SchedulerInit(schedInfo)
+
+		sched := cff.NewScheduler(
+			cff.SchedulerParams{
+				Emitter: schedEmitter,
+			},
+		)
+
+		var tasks []*struct {
+			emitter cff.TaskEmitter
+			ran     cff.AtomicBool
+			run     func(context.Context) error
+			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		}
+		defer func() {
+			for _, t := range tasks {
+				if !t.ran.Load() {
+					t.emitter.TaskSkipped(ctx, t.outcome.SkipReason(ctx, err))
+				}
+			}
+		}()
+
+		// go.uber.org/cff/internal/tests/ratelimit/ratelimit.go:62:12
+		var (
+			v2 string
+		)
+
+		task4 := new(struct {
+			emitter cff.TaskEmitter
+			ran     cff.AtomicBool
+			run     func(context.Context) error
+			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		})
+		task4.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/ratelimit/ratelimit.go",
+			Line:   62,
+			Column: 12,
+		}
+		task4.emitter = cff.NopTaskEmitter()
+		task4.run = func(ctx context.Context) (err error) {
+			taskEmitter := task4.emitter
+			startTime := time.Now()
+			defer func() {
+				task4.outcome.Finish(err)
+				if task4.ran.Load() {
+					taskEmitter.TaskDone(ctx, time.Since(startTime))
+				}
+			}()
+
+			defer func() {
+				recovered := recover()
+				if recovered != nil {
+					taskEmitter.TaskPanic(ctx, recovered)
+					err = &cff.PanicError{
+						Value:      recovered,
+						Stacktrace: debug.Stack(),
+					}
+				}
+			}()
+
+			defer task4.ran.Store(true)
+
+			err = cff.Intercept(ctx, interceptor, task4.outcome.Info, func(ctx context.Context) (err error) {
+				v2 = _62_12(v1)
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return err
+			} else {
+				taskEmitter.TaskSuccess(ctx)
+			}
+
+			return
+		}
+
+		task4.job = sched.Enqueue(ctx, cff.Job{
+			Run: task4.run,
+
+			Limiter: _62_40,
+		})
+		tasks = append(tasks, task4)
+
+		if err := sched.Wait(ctx); err != nil {
+			flowEmitter.FlowError(ctx, err)
+			cff.RethrowPanic(err, false)
+			return err
+		}
+
+		*(_61_15) = v2 // string
+
+		flowEmitter.FlowSuccess(ctx)
+		return nil
+	}()
+	return out, err
+}
@@ -0,0 +1,74 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/cff"
+)
+
+// countingLimiter is a cff.Limiter that counts calls to Wait,
+// failing with err if it's non-nil.
+type countingLimiter struct {
+	calls atomic.Int32
+	err   error
+}
+
+func (l *countingLimiter) Wait(context.Context) error {
+	l.calls.Add(1)
+	return l.err
+}
+
+func TestFanout(t *testing.T) {
+	var parallel, task, slice, mapl countingLimiter
+	err := Fanout(context.Background(),
+		&parallel, &task, &slice, &mapl,
+		[]int{1, 2, 3},
+		map[string]int{"a": 1, "b": 2},
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t, int32(6), parallel.calls.Load(), "parallel")
+	assert.Equal(t, int32(1), task.calls.Load(), "task")
+	assert.Equal(t, int32(3), slice.calls.Load(), "slice")
+	assert.Equal(t, int32(2), mapl.calls.Load(), "map")
+}
+
+func TestBatchedSlice(t *testing.T) {
+	var l countingLimiter
+	require.NoError(t, BatchedSlice(context.Background(), &l, []int{1, 2, 3, 4, 5}, 2))
+	assert.Equal(t, int32(3), l.calls.Load())
+}
+
+func TestFlowTask(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		var l countingLimiter
+		got, err := FlowTask(context.Background(), &l, 42)
+		require.NoError(t, err)
+		assert.Equal(t, "42", got)
+		assert.Equal(t, int32(1), l.calls.Load())
+	})
+
+	t.Run("limiter fails", func(t *testing.T) {
+		giveErr := errors.New("great sadness")
+		_, err := FlowTask(context.Background(), &countingLimiter{err: giveErr}, 42)
+		assert.ErrorIs(t, err, giveErr)
+	})
+
+	t.Run("token bucket", func(t *testing.T) {
+		l := cff.NewTokenBucket(0.001, 1)
+		_, err := FlowTask(context.Background(), l, 1)
+		require.NoError(t, err)
+
+		// The bucket is empty, so the task waits until the context ends.
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		_, err = FlowTask(ctx, l, 2)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+}
@@ -0,0 +1,70 @@
+//go:build cff
+// +build cff
+
+// Package rethrow tests Flows and Parallels with cff.RethrowPanics.
+package rethrow
+
+import (
+	"context"
+	"errors"
+
+	"go.uber.org/cff"
+)
+
+// Flow runs a flow with a task that panics and rethrows the panic.
+func Flow(e cff.Emitter) (s string, err error) {
+	err = cff.Flow(context.Background(),
+		cff.Results(&s),
+		cff.WithEmitter(e),
+		cff.Task(func() string {
+			panic("great sadness")
+		}, cff.Instrument("panic")),
+		cff.RethrowPanics(),
+	)
+	return s, err
+}
+
+// FlowDefault runs a flow with a task that panics,
+// relying on the process-wide default to decide whether to rethrow it.
+func FlowDefault() (s string, err error) {
+	err = cff.Flow(context.Background(),
+		cff.Results(&s),
+		cff.Task(func() string {
+			panic("great sadness")
+		}),
+	)
+	return s, err
+}
+
+// FlowError runs a flow with a task that fails without panicking.
+func FlowError() (s string, err error) {
+	err = cff.Flow(context.Background(),
+		cff.Results(&s),
+		cff.Task(func() (string, error) {
+			return "", errors.New("great sadness")
+		}),
+		cff.RethrowPanics(),
+	)
+	return s, err
+}
+
+// Parallel runs a parallel with a task that panics and rethrows the panic.
+func Parallel(e cff.Emitter) error {
+	return cff.Parallel(context.Background(),
+		cff.WithEmitter(e),
+		cff.Task(func() {
+			panic("great sadness")
+		}, cff.Instrument("panic")),
+		cff.RethrowPanics(),
+	)
+}
+
+// ParallelDefault runs a parallel with a task that panics,
+// relying on the process-wide default to decide whether to rethrow it.
+func ParallelDefault() error {
+	return cff.Parallel(context.Background(),
+		cff.Task(func() {
+			panic("great sadness")
+		}),
+	)
+}
@@ -0,0 +1,72 @@
+package rethrow
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/cff"
+	"go.uber.org/cff/internal/emittertest"
+)
+
+// recoverPanic runs f and returns the value it panicked with, if any.
+func recoverPanic(f func()) (v interface{}) {
+	defer func() {
+		v = recover()
+	}()
+	f()
+	return nil
+}
+
+func TestFlow(t *testing.T) {
+	e := emittertest.NewRecorder()
+	v := recoverPanic(func() {
+		_, _ = Flow(e)
+	})
+	assert.Equal(t, "great sadness", v, "must rethrow the original value")
+	assert.Equal(t, []interface{}{"great sadness"}, e.Values(emittertest.TaskPanic),
+		"emitter must be informed before the panic is rethrown")
+}
+
+func TestFlowError(t *testing.T) {
+	_, err := FlowError()
+	assert.EqualError(t, err, "great sadness",
+		"errors must not be turned into panics")
+}
+
+func TestParallel(t *testing.T) {
+	e := emittertest.NewRecorder()
+	v := recoverPanic(func() {
+		_ = Parallel(e)
+	})
+	assert.Equal(t, "great sadness", v, "must rethrow the original value")
+	assert.Equal(t, []interface{}{"great sadness"}, e.Values(emittertest.TaskPanic),
+		"emitter must be informed before the panic is rethrown")
+}
+
+func TestDefault(t *testing.T) {
+	t.Run("disabled", func(t *testing.T) {
+		_, err := FlowDefault()
+		var pe *cff.PanicError
+		require.ErrorAs(t, err, &pe)
+		assert.Equal(t, "great sadness", pe.Value)
+
+		require.ErrorAs(t, ParallelDefault(), &pe)
+		assert.Equal(t, "great sadness", pe.Value)
+	})
+
+	t.Run("enabled", func(t *testing.T) {
+		cff.SetRethrowPanics(true)
+		defer cff.SetRethrowPanics(false)
+
+		v := recoverPanic(func() {
+			_, _ = FlowDefault()
+		})
+		assert.Equal(t, "great sadness", v, "flow must panic")
+
+		v = recoverPanic(func() {
+			_ = ParallelDefault()
+		})
+		assert.Equal(t, "great sadness", v, "parallel must panic")
+	})
+}
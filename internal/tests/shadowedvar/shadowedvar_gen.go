@@ -28,6 +28,7 @@ func CtxConflict(ctx string) (string, error) {
 		}
 		ctx := _17_3
 		emitter := cff2.NopEmitter()
+		interceptor := cff2.InterceptorStack()
 
 		var (
 			flowInfo = &cff2.FlowInfo{
@@ -65,11 +66,13 @@ func CtxConflict(ctx string) (string, error) {
 			ran     cff2.AtomicBool
 			run     func(context.Context) error
 			job     *cff2.ScheduledJob
+
+			outcome cff2.TaskOutcome // reports why the task was skipped
 		}
 		defer func() {
 			for _, t := range tasks {
 				if !t.ran.Load() {
-					t.emitter.TaskSkipped(ctx, err)
+					t.emitter.TaskSkipped(ctx, t.outcome.SkipReason(ctx, err))
 				}
 			}
 		}()
@@ -78,17 +81,26 @@ func CtxConflict(ctx string) (string, error) {
 		var (
 			v1 string
 		)
+
 		task0 := new(struct {
 			emitter cff2.TaskEmitter
 			ran     cff2.AtomicBool
 			run     func(context.Context) error
 			job     *cff2.ScheduledJob
+
+			outcome cff2.TaskOutcome // reports why the task was skipped
 		})
+		task0.outcome.Info = &cff2.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/shadowedvar/shadowedvar.go",
+			Line:   19,
+			Column: 13,
+		}
 		task0.emitter = cff2.NopTaskEmitter()
 		task0.run = func(ctx context.Context) (err error) {
 			taskEmitter := task0.emitter
 			startTime := time.Now()
 			defer func() {
+				task0.outcome.Finish(err)
 				if task0.ran.Load() {
 					taskEmitter.TaskDone(ctx, time.Since(startTime))
 				}
@@ -107,8 +119,10 @@ func CtxConflict(ctx string) (string, error) {
 
 			defer task0.ran.Store(true)
 
-			v1, err = _19_13()
-
+			err = cff2.Intercept(ctx, interceptor, task0.outcome.Info, func(ctx context.Context) (err error) {
+				v1, err = _19_13()
+				return
+			})
 			if err != nil {
 				taskEmitter.TaskError(ctx, err)
 				return err
@@ -126,6 +140,7 @@ func CtxConflict(ctx string) (string, error) {
 
 		if err := sched.Wait(ctx); err != nil {
 			flowEmitter.FlowError(ctx, err)
+			cff2.RethrowPanic(err, false)
 			return err
 		}
 
@@ -156,6 +171,7 @@ func CtxConflictParallel(ctx string) (string, string, error) {
 		}
 		ctx := _35_3
 		emitter := cff2.NopEmitter()
+		interceptor := cff2.InterceptorStack()
 
 		var (
 			parallelInfo = &cff2.ParallelInfo{
@@ -200,11 +216,15 @@ func CtxConflictParallel(ctx string) (string, string, error) {
 			emitter cff2.TaskEmitter
 			fn      func(context.Context) error
 			ran     cff2.AtomicBool
+
+			outcome cff2.TaskOutcome // reports why the task was skipped
 		}
 		defer func() {
 			for _, t := range tasks {
-				if !t.ran.Load() {
-					t.emitter.TaskSkipped(ctx, err)
+				// Tasks that are still running report their own outcome
+				// once they finish.
+				if !t.ran.Load() && !t.outcome.Running() {
+					t.emitter.TaskSkipped(ctx, t.outcome.SkipReason(ctx, err))
 				}
 			}
 		}()
@@ -214,12 +234,21 @@ func CtxConflictParallel(ctx string) (string, string, error) {
 			emitter cff2.TaskEmitter
 			fn      func(context.Context) error
 			ran     cff2.AtomicBool
+
+			outcome cff2.TaskOutcome // reports why the task was skipped
 		})
+		task1.outcome.Info = &cff2.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/shadowedvar/shadowedvar.go",
+			Line:   36,
+			Column: 13,
+		}
 		task1.emitter = cff2.NopTaskEmitter()
 		task1.fn = func(ctx context.Context) (err error) {
+			task1.outcome.Start()
 			taskEmitter := task1.emitter
 			startTime := time.Now()
 			defer func() {
+				task1.outcome.Finish(err)
 				if task1.ran.Load() {
 					taskEmitter.TaskDone(ctx, time.Since(startTime))
 				}
@@ -238,8 +267,14 @@ func CtxConflictParallel(ctx string) (string, string, error) {
 
 			defer task1.ran.Store(true)
 
-			_36_13()
-
+			err = cff2.Intercept(ctx, interceptor, task1.outcome.Info, func(ctx context.Context) (err error) {
+				_36_13()
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return
+			}
 			taskEmitter.TaskSuccess(ctx)
 			return
 		}
@@ -254,12 +289,21 @@ func CtxConflictParallel(ctx string) (string, string, error) {
 			emitter cff2.TaskEmitter
 			fn      func(context.Context) error
 			ran     cff2.AtomicBool
+
+			outcome cff2.TaskOutcome // reports why the task was skipped
 		})
+		task2.outcome.Info = &cff2.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/shadowedvar/shadowedvar.go",
+			Line:   39,
+			Column: 13,
+		}
 		task2.emitter = cff2.NopTaskEmitter()
 		task2.fn = func(ctx context.Context) (err error) {
+			task2.outcome.Start()
 			taskEmitter := task2.emitter
 			startTime := time.Now()
 			defer func() {
+				task2.outcome.Finish(err)
 				if task2.ran.Load() {
 					taskEmitter.TaskDone(ctx, time.Since(startTime))
 				}
@@ -278,8 +322,14 @@ func CtxConflictParallel(ctx string) (string, string, error) {
 
 			defer task2.ran.Store(true)
 
-			_39_13()
-
+			err = cff2.Intercept(ctx, interceptor, task2.outcome.Info, func(ctx context.Context) (err error) {
+				_39_13()
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return
+			}
 			taskEmitter.TaskSuccess(ctx)
 			return
 		}
@@ -291,6 +341,7 @@ func CtxConflictParallel(ctx string) (string, string, error) {
 
 		if err := sched.Wait(ctx); err != nil {
 			parallelEmitter.ParallelError(ctx, err)
+			cff2.RethrowPanic(err, false)
 			return err
 		}
 		parallelEmitter.ParallelSuccess(ctx)
@@ -316,6 +367,7 @@ func CtxConflictSlice(ctx string, target []string) error {
 		_57_4 := target
 		ctx := _50_3
 		emitter := cff2.NopEmitter()
+		interceptor := cff2.InterceptorStack()
 
 		var (
 			parallelInfo = &cff2.ParallelInfo{
@@ -360,17 +412,27 @@ func CtxConflictSlice(ctx string, target []string) error {
 			emitter cff2.TaskEmitter
 			fn      func(context.Context) error
 			ran     cff2.AtomicBool
+
+			outcome cff2.TaskOutcome // reports why the task was skipped
 		}
 		defer func() {
 			for _, t := range tasks {
-				if !t.ran.Load() {
-					t.emitter.TaskSkipped(ctx, err)
+				// Tasks that are still running report their own outcome
+				// once they finish.
+				if !t.ran.Load() && !t.outcome.Running() {
+					t.emitter.TaskSkipped(ctx, t.outcome.SkipReason(ctx, err))
 				}
 			}
 		}()
 
 		// go.uber.org/cff/internal/tests/shadowedvar/shadowedvar.go:52:3
 		sliceTask3Slice := _57_4
+		sliceTask3Info := &cff2.TaskInfo{
+			Name:   parallelInfo.Name,
+			File:   "go.uber.org/cff/internal/tests/shadowedvar/shadowedvar.go",
+			Line:   52,
+			Column: 3,
+		}
 		for idx, val := range sliceTask3Slice {
 			idx := idx
 			val := val
@@ -378,6 +440,8 @@ func CtxConflictSlice(ctx string, target []string) error {
 				emitter cff2.TaskEmitter
 				fn      func(context.Context) error
 				ran     cff2.AtomicBool
+
+				outcome cff2.TaskOutcome // reports why the task was skipped
 			})
 			sliceTask3.fn = func(ctx context.Context) (err error) {
 				defer func() {
@@ -389,8 +453,10 @@ func CtxConflictSlice(ctx string, target []string) error {
 						}
 					}
 				}()
-				err = _53_4(idx, val)
-				return
+				return cff2.Intercept(ctx, interceptor, sliceTask3Info, func(ctx context.Context) (err error) {
+					err = _53_4(idx, val)
+					return
+				})
 			}
 			sched.Enqueue(ctx, cff2.Job{
 				Run: sliceTask3.fn,
@@ -399,6 +465,7 @@ func CtxConflictSlice(ctx string, target []string) error {
 
 		if err := sched.Wait(ctx); err != nil {
 			parallelEmitter.ParallelError(ctx, err)
+			cff2.RethrowPanic(err, false)
 			return err
 		}
 		parallelEmitter.ParallelSuccess(ctx)
@@ -423,6 +490,7 @@ func CtxConflictMap(ctx int, input map[int]int) ([]int, error) {
 		_73_4 := input
 		ctx := _67_3
 		emitter := cff2.NopEmitter()
+		interceptor := cff2.InterceptorStack()
 
 		var (
 			parallelInfo = &cff2.ParallelInfo{
@@ -467,15 +535,25 @@ func CtxConflictMap(ctx int, input map[int]int) ([]int, error) {
 			emitter cff2.TaskEmitter
 			fn      func(context.Context) error
 			ran     cff2.AtomicBool
+
+			outcome cff2.TaskOutcome // reports why the task was skipped
 		}
 		defer func() {
 			for _, t := range tasks {
-				if !t.ran.Load() {
-					t.emitter.TaskSkipped(ctx, err)
+				// Tasks that are still running report their own outcome
+				// once they finish.
+				if !t.ran.Load() && !t.outcome.Running() {
+					t.emitter.TaskSkipped(ctx, t.outcome.SkipReason(ctx, err))
 				}
 			}
 		}()
 
+		mapTask4Info := &cff2.TaskInfo{
+			Name:   parallelInfo.Name,
+			File:   "go.uber.org/cff/internal/tests/shadowedvar/shadowedvar.go",
+			Line:   69,
+			Column: 3,
+		}
 		// go.uber.org/cff/internal/tests/shadowedvar/shadowedvar.go:69:3
 		for key, val := range _73_4 {
 			key := key
@@ -484,6 +562,8 @@ func CtxConflictMap(ctx int, input map[int]int) ([]int, error) {
 				emitter cff2.TaskEmitter
 				fn      func(context.Context) error
 				ran     cff2.AtomicBool
+
+				outcome cff2.TaskOutcome // reports why the task was skipped
 			})
 			mapTask4.fn = func(ctx context.Context) (err error) {
 				defer func() {
@@ -496,8 +576,10 @@ func CtxConflictMap(ctx int, input map[int]int) ([]int, error) {
 					}
 				}()
 
-				_70_4(key, val)
-				return
+				return cff2.Intercept(ctx, interceptor, mapTask4Info, func(ctx context.Context) (err error) {
+					_70_4(key, val)
+					return
+				})
 			}
 
 			sched.Enqueue(ctx, cff2.Job{
@@ -507,6 +589,7 @@ func CtxConflictMap(ctx int, input map[int]int) ([]int, error) {
 
 		if err := sched.Wait(ctx); err != nil {
 			parallelEmitter.ParallelError(ctx, err)
+			cff2.RethrowPanic(err, false)
 			return err
 		}
 		parallelEmitter.ParallelSuccess(ctx)
@@ -534,6 +617,7 @@ func PredicateCtxConflict(f func(), ctx bool) error {
 		_92_19 := func() bool { return ctx }
 		ctx := _85_3
 		emitter := cff2.NopEmitter()
+		interceptor := cff2.InterceptorStack()
 
 		var (
 			flowInfo = &cff2.FlowInfo{
@@ -571,11 +655,13 @@ func PredicateCtxConflict(f func(), ctx bool) error {
 			ran     cff2.AtomicBool
 			run     func(context.Context) error
 			job     *cff2.ScheduledJob
+
+			outcome cff2.TaskOutcome // reports why the task was skipped
 		}
 		defer func() {
 			for _, t := range tasks {
 				if !t.ran.Load() {
-					t.emitter.TaskSkipped(ctx, err)
+					t.emitter.TaskSkipped(ctx, t.outcome.SkipReason(ctx, err))
 				}
 			}
 		}()
@@ -609,17 +695,26 @@ func PredicateCtxConflict(f func(), ctx bool) error {
 		var (
 			v1 string
 		)
+
 		task5 := new(struct {
 			emitter cff2.TaskEmitter
 			ran     cff2.AtomicBool
 			run     func(context.Context) error
 			job     *cff2.ScheduledJob
+
+			outcome cff2.TaskOutcome // reports why the task was skipped
 		})
+		task5.outcome.Info = &cff2.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/shadowedvar/shadowedvar.go",
+			Line:   88,
+			Column: 4,
+		}
 		task5.emitter = cff2.NopTaskEmitter()
 		task5.run = func(ctx context.Context) (err error) {
 			taskEmitter := task5.emitter
 			startTime := time.Now()
 			defer func() {
+				task5.outcome.Finish(err)
 				if task5.ran.Load() {
 					taskEmitter.TaskDone(ctx, time.Since(startTime))
 				}
@@ -645,14 +740,22 @@ func PredicateCtxConflict(f func(), ctx bool) error {
 			}()
 
 			if !p0 {
+				task5.outcome.Skip(cff2.SkipPredicateFalse)
 				return nil
 			}
 
 			defer task5.ran.Store(true)
 
-			v1 = _88_4()
-
-			taskEmitter.TaskSuccess(ctx)
+			err = cff2.Intercept(ctx, interceptor, task5.outcome.Info, func(ctx context.Context) (err error) {
+				v1 = _88_4()
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return err
+			} else {
+				taskEmitter.TaskSuccess(ctx)
+			}
 
 			return
 		}
@@ -667,6 +770,7 @@ func PredicateCtxConflict(f func(), ctx bool) error {
 
 		if err := sched.Wait(ctx); err != nil {
 			flowEmitter.FlowError(ctx, err)
+			cff2.RethrowPanic(err, false)
 			return err
 		}
 
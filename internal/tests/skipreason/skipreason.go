@@ -0,0 +1,63 @@
+//go:build cff
+// +build cff
+
+// Package skipreason tests the reasons reported to emitters for tasks that
+// were skipped.
+package skipreason
+
+import (
+	"context"
+	"errors"
+
+	"go.uber.org/cff"
+)
+
+// Request configures a Sync.
+type Request struct {
+	FailFetch bool
+	Notify    bool
+}
+
+// Data is fetched by Sync.
+type Data string
+
+// Record is built from Data.
+type Record string
+
+// Sync fetches data, transforms it into a record, and stores the record.
+// It sends a notification if requested.
+func Sync(ctx context.Context, e cff.Emitter, req Request) error {
+	return cff.Flow(ctx,
+		cff.Params(req),
+		cff.WithEmitter(e),
+		cff.ContinueOnError(true),
+		cff.Task(
+			func(req Request) (Data, error) {
+				if req.FailFetch {
+					return "", errors.New("fetch failed")
+				}
+				return "data", nil
+			},
+			cff.Instrument("fetch"),
+		),
+		cff.Task(
+			func(d Data) Record {
+				return Record(d)
+			},
+			cff.Instrument("transform"),
+		),
+		cff.Task(
+			func(Record) {},
+			cff.Invoke(true),
+			cff.Instrument("store"),
+		),
+		cff.Task(
+			func() {},
+			cff.Invoke(true),
+			cff.Predicate(func(req Request) bool {
+				return req.Notify
+			}),
+			cff.Instrument("notify"),
+		),
+	)
+}
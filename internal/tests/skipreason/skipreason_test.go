@@ -0,0 +1,67 @@
+package skipreason
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/cff"
+	"go.uber.org/cff/internal/emittertest"
+)
+
+func TestSync(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("predicate false", func(t *testing.T) {
+		e := emittertest.NewRecorder()
+		require.NoError(t, Sync(ctx, e, Request{}))
+		assert.Equal(t, map[string]cff.SkipKind{
+			"notify": cff.SkipPredicateFalse,
+		}, e.SkipKinds())
+		assert.True(t, e.SkipReasons()["notify"].Expected())
+	})
+
+	t.Run("nothing skipped", func(t *testing.T) {
+		e := emittertest.NewRecorder()
+		require.NoError(t, Sync(ctx, e, Request{Notify: true}))
+		assert.Empty(t, e.SkipKinds())
+	})
+
+	t.Run("dependency failed", func(t *testing.T) {
+		e := emittertest.NewRecorder()
+		err := Sync(ctx, e, Request{FailFetch: true, Notify: true})
+		require.Error(t, err)
+		assert.Equal(t, map[string]cff.SkipKind{
+			"transform": cff.SkipDependencyFailed,
+			"store":     cff.SkipDependencySkipped,
+		}, e.SkipKinds())
+
+		reasons := e.SkipReasons()
+		transform := reasons["transform"]
+		assert.False(t, transform.Expected())
+		require.NotNil(t, transform.Dependency)
+		assert.Equal(t, "fetch", transform.Dependency.Name)
+		assert.EqualError(t, transform.Err, "fetch failed")
+
+		store := reasons["store"]
+		require.NotNil(t, store.Dependency)
+		assert.Equal(t, "transform", store.Dependency.Name)
+		assert.Equal(t, transform, store.Err)
+	})
+
+	t.Run("context cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(ctx)
+		cancel()
+
+		e := emittertest.NewRecorder()
+		err := Sync(ctx, e, Request{Notify: true})
+		require.ErrorIs(t, err, context.Canceled)
+		assert.Equal(t, map[string]cff.SkipKind{
+			"fetch":     cff.SkipContextCancelled,
+			"transform": cff.SkipContextCancelled,
+			"store":     cff.SkipContextCancelled,
+			"notify":    cff.SkipContextCancelled,
+		}, e.SkipKinds())
+	})
+}
@@ -0,0 +1,116 @@
+//go:build cff
+// +build cff
+
+// Package subflow tests flows with tasks grouped by cff.Subflow.
+package subflow
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/cff"
+)
+
+// Limited runs three tasks in a subflow limited to one task at a time,
+// in a flow that would otherwise run them all concurrently.
+// It reports the highest number of subflow tasks that ran at the same time.
+func Limited(ctx context.Context) (int, error) {
+	var running, peak int64
+	track := func() {
+		n := atomic.AddInt64(&running, 1)
+		defer atomic.AddInt64(&running, -1)
+		for {
+			p := atomic.LoadInt64(&peak)
+			if n <= p || atomic.CompareAndSwapInt64(&peak, p, n) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	var s string
+	err := cff.Flow(ctx,
+		cff.Concurrency(4),
+		cff.Results(&s),
+		cff.Subflow(
+			cff.Concurrency(1),
+			cff.Task(func() int {
+				track()
+				return 1
+			}),
+			cff.Task(func() int64 {
+				track()
+				return 2
+			}),
+			cff.Task(func() uint {
+				track()
+				return 3
+			}),
+		),
+		cff.Task(func(a int, b int64, c uint) string {
+			return strconv.Itoa(a + int(b) + int(c))
+		}),
+	)
+	if err != nil {
+		return 0, err
+	}
+	if s != "6" {
+		return 0, errors.New("unexpected result: " + s)
+	}
+	return int(peak), nil
+}
+
+// Partial runs a subflow that continues on error.
+// The failing task's dependent is skipped,
+// but the independent task of the flow still runs.
+func Partial(ctx context.Context, fail bool) (ran []string, err error) {
+	var s string
+	err = cff.Flow(ctx,
+		cff.Concurrency(1),
+		cff.Params(fail),
+		cff.Results(&s),
+		cff.Subflow(
+			cff.ContinueOnError(true),
+			cff.Task(func(fail bool) (int, error) {
+				ran = append(ran, "load")
+				if fail {
+					return 0, errors.New("great sadness")
+				}
+				return 42, nil
+			}),
+			cff.Task(func(int) string {
+				ran = append(ran, "format")
+				return "42"
+			}),
+		),
+		cff.Task(func() {
+			ran = append(ran, "other")
+		}, cff.Invoke(true)),
+	)
+	return ran, err
+}
+
+// Instrumented runs instrumented tasks inside and outside a named subflow.
+// The subflow's tasks are reported to both emitters.
+func Instrumented(ctx context.Context, flowEmitter, subflowEmitter cff.Emitter) (string, error) {
+	var s string
+	err := cff.Flow(ctx,
+		cff.InstrumentFlow("parent"),
+		cff.WithEmitter(flowEmitter),
+		cff.Results(&s),
+		cff.Subflow(
+			cff.InstrumentFlow("child"),
+			cff.WithEmitter(subflowEmitter),
+			cff.Task(func() int {
+				return 42
+			}, cff.Instrument("inner")),
+		),
+		cff.Task(func(i int) string {
+			return strconv.Itoa(i)
+		}, cff.Instrument("outer")),
+	)
+	return s, err
+}
@@ -0,0 +1,69 @@
+package subflow
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/cff"
+	"go.uber.org/cff/internal/emittertest"
+)
+
+// directives returns the directives of the tasks initialized with e
+// by task name.
+func directives(e *emittertest.Recorder) map[string]*cff.DirectiveInfo {
+	ds := make(map[string]*cff.DirectiveInfo)
+	for _, ev := range e.EventsOf(emittertest.TaskInit) {
+		ds[ev.Task.Name] = ev.Directive
+	}
+	return ds
+}
+
+func TestLimited(t *testing.T) {
+	peak, err := Limited(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, peak)
+}
+
+func TestPartial(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		ran, err := Partial(context.Background(), false)
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{"load", "format", "other"}, ran)
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		ran, err := Partial(context.Background(), true)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "great sadness")
+		assert.ElementsMatch(t, []string{"load", "other"}, ran)
+	})
+}
+
+func TestInstrumented(t *testing.T) {
+	flowEmitter := emittertest.NewRecorder()
+	subflowEmitter := emittertest.NewRecorder()
+
+	s, err := Instrumented(context.Background(), flowEmitter, subflowEmitter)
+	require.NoError(t, err)
+	assert.Equal(t, "42", s)
+	flowDirectives := directives(flowEmitter)
+	subflowDirectives := directives(subflowEmitter)
+
+	inner := flowDirectives["inner"]
+	require.NotNil(t, inner)
+	assert.Equal(t, "child", inner.Name)
+	assert.Equal(t, cff.SubflowDirective, inner.Directive)
+	require.NotNil(t, inner.Parent)
+	assert.Equal(t, "parent", inner.Parent.Name)
+	assert.Equal(t, cff.FlowDirective, inner.Parent.Directive)
+	assert.Same(t, inner, subflowDirectives["inner"])
+
+	outer := flowDirectives["outer"]
+	require.NotNil(t, outer)
+	assert.Equal(t, "parent", outer.Name)
+	assert.Equal(t, cff.FlowDirective, outer.Directive)
+	assert.Nil(t, outer.Parent)
+	assert.NotContains(t, subflowDirectives, "outer")
+}
@@ -0,0 +1,147 @@
+//go:build cff
+// +build cff
+
+// Package switchcase tests flows with cff.Switch.
+package switchcase
+
+import (
+	"context"
+	"errors"
+
+	"go.uber.org/cff"
+)
+
+// Source specifies where the user is read from.
+type Source int
+
+// Sources of users.
+const (
+	Cache Source = iota
+	Database
+	Fail
+	Panic
+)
+
+// Request is the input to the flows in this package.
+type Request struct {
+	ID     int
+	Source Source
+}
+
+// User is the result of the flows in this package.
+type User struct {
+	ID   int
+	From string
+}
+
+// Cached is the cache the user may be read from.
+type Cached map[int]string
+
+// Lookup reads a user using one of many alternative tasks.
+func Lookup(ctx context.Context, e cff.Emitter, req *Request) (*User, error) {
+	var u *User
+	err := cff.Flow(ctx,
+		cff.Params(req),
+		cff.Results(&u),
+		cff.WithEmitter(e),
+		cff.Task(func() Cached {
+			return Cached{1: "cache"}
+		}),
+		cff.Switch(
+			cff.Case(
+				func(req *Request) bool { return req.Source == Cache },
+				func(c Cached, req *Request) *User {
+					return &User{ID: req.ID, From: c[req.ID]}
+				},
+				cff.Instrument("cache"),
+			),
+			cff.Case(
+				func(req *Request) bool { return req.Source == Fail },
+				func(req *Request) (*User, error) {
+					return nil, errors.New("great sadness")
+				},
+				cff.Instrument("fail"),
+			),
+			cff.Case(
+				func(req *Request) bool {
+					if req.Source == Panic {
+						panic("great sadness")
+					}
+					return false
+				},
+				func(req *Request) *User {
+					return &User{ID: req.ID, From: "unreachable"}
+				},
+				cff.Instrument("panic"),
+			),
+			cff.Default(
+				func(ctx context.Context, req *Request) (*User, error) {
+					return &User{ID: req.ID, From: "database"}, nil
+				},
+				cff.Instrument("database"),
+			),
+		),
+	)
+	return u, err
+}
+
+// NoDefault is a flow with a cff.Switch without a cff.Default.
+// It reports whether the user was found.
+func NoDefault(ctx context.Context, req *Request) (cff.Maybe[*User], string, error) {
+	var (
+		u    cff.Maybe[*User]
+		name string
+	)
+	err := cff.Flow(ctx,
+		cff.Params(req),
+		cff.Results(&u, &name),
+		cff.Switch(
+			cff.Case(
+				func(req *Request) bool { return req.Source == Cache },
+				func(req *Request) *User {
+					return &User{ID: req.ID, From: "cache"}
+				},
+			),
+		),
+		cff.Task(func(u *User) string {
+			if u == nil {
+				return "unknown"
+			}
+			return u.From
+		}),
+	)
+	return u, name, err
+}
+
+// Results is a result object produced by the tasks of a switch.
+type Results struct {
+	cff.Out
+
+	User *User
+	Name string
+}
+
+// Objects is a flow with a cff.Switch whose tasks produce the same types
+// in different forms.
+func Objects(ctx context.Context, useObject bool) (*User, string, error) {
+	var (
+		u    *User
+		name string
+	)
+	err := cff.Flow(ctx,
+		cff.Params(useObject),
+		cff.Results(&u, &name),
+		cff.Switch(
+			cff.Case(
+				func(useObject bool) bool { return useObject },
+				func() Results {
+					return Results{User: &User{ID: 1}, Name: "object"}
+				},
+			),
+			cff.Default(func() (string, *User) {
+				return "plain", &User{ID: 2}
+			}),
+		),
+	)
+	return u, name, err
+}
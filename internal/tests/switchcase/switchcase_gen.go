@@ -0,0 +1,1241 @@
+//go:build !cff
+// +build !cff
+
+// Package switchcase tests flows with cff.Switch.
+package switchcase
+
+import (
+	"context"
+	"errors"
+	"runtime/debug"
+	"time"
+
+	"go.uber.org/cff"
+)
+
+// Source specifies where the user is read from.
+type Source int
+
+// Sources of users.
+const (
+	Cache Source = iota
+	Database
+	Fail
+	Panic
+)
+
+// Request is the input to the flows in this package.
+type Request struct {
+	ID     int
+	Source Source
+}
+
+// User is the result of the flows in this package.
+type User struct {
+	ID   int
+	From string
+}
+
+// Cached is the cache the user may be read from.
+type Cached map[int]string
+
+// Lookup reads a user using one of many alternative tasks.
+func Lookup(ctx context.Context, e cff.Emitter, req *Request) (*User, error) {
+	var u *User
+	err := func() (err error) {
+
+		_43_18 := ctx
+
+		_44_14 := req
+
+		_45_15 := &u
+
+		_46_19 := e
+
+		_47_12 := func() Cached {
+			return Cached{1: "cache"}
+		}
+
+		_52_5 := func(req *Request) bool { return req.Source == Cache }
+
+		_53_5 := func(c Cached, req *Request) *User {
+			return &User{ID: req.ID, From: c[req.ID]}
+		}
+
+		_56_20 := "cache"
+
+		_59_5 := func(req *Request) bool { return req.Source == Fail }
+
+		_60_5 := func(req *Request) (*User, error) {
+			return nil, errors.New("great sadness")
+		}
+
+		_63_20 := "fail"
+
+		_66_5 := func(req *Request) bool {
+			if req.Source == Panic {
+				panic("great sadness")
+			}
+			return false
+		}
+
+		_72_5 := func(req *Request) *User {
+			return &User{ID: req.ID, From: "unreachable"}
+		}
+
+		_75_20 := "panic"
+
+		_78_5 := func(ctx context.Context, req *Request) (*User, error) {
+			return &User{ID: req.ID, From: "database"}, nil
+		}
+
+		_81_20 := "database"
+		ctx := _43_18
+		var v1 *Request = _44_14
+		emitter := cff.EmitterStack(_46_19)
+		interceptor := cff.InterceptorStack()
+
+		var (
+			flowInfo = &cff.FlowInfo{
+				File:   "go.uber.org/cff/internal/tests/switchcase/switchcase.go",
+				Line:   43,
+				Column: 9,
+			}
+			flowEmitter = cff.NopFlowEmitter()
+
+			schedInfo = &cff.SchedulerInfo{
+				Name:      flowInfo.Name,
+				Directive: cff.FlowDirective,
+				File:      flowInfo.File,
+				Line:      flowInfo.Line,
+				Column:    flowInfo.Column,
+			}
+
+			// possibly unused
+			_ = flowInfo
+		)
+
+		startTime := time.Now()
+		defer func() { flowEmitter.FlowDone(ctx, time.Since(startTime)) }()
+
+		schedEmitter := emitter.SchedulerInit(schedInfo)
+
+		sched := cff.NewScheduler(
+			cff.SchedulerParams{
+				Emitter: schedEmitter,
+			},
+		)
+
+		var tasks []*struct {
+			emitter cff.TaskEmitter
+			ran     cff.AtomicBool
+			run     func(context.Context) error
+			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		}
+		defer func() {
+			for _, t := range tasks {
+				if !t.ran.Load() {
+					t.emitter.TaskSkipped(ctx, t.outcome.SkipReason(ctx, err))
+				}
+			}
+		}()
+
+		// go.uber.org/cff/internal/tests/switchcase/switchcase.go:47:12
+		var (
+			v2 Cached
+		)
+
+		task0 := new(struct {
+			emitter cff.TaskEmitter
+			ran     cff.AtomicBool
+			run     func(context.Context) error
+			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		})
+		task0.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/switchcase/switchcase.go",
+			Line:   47,
+			Column: 12,
+		}
+		task0.emitter = cff.NopTaskEmitter()
+		task0.run = func(ctx context.Context) (err error) {
+			taskEmitter := task0.emitter
+			startTime := time.Now()
+			defer func() {
+				task0.outcome.Finish(err)
+				if task0.ran.Load() {
+					taskEmitter.TaskDone(ctx, time.Since(startTime))
+				}
+			}()
+
+			defer func() {
+				recovered := recover()
+				if recovered != nil {
+					taskEmitter.TaskPanic(ctx, recovered)
+					err = &cff.PanicError{
+						Value:      recovered,
+						Stacktrace: debug.Stack(),
+					}
+				}
+			}()
+
+			defer task0.ran.Store(true)
+
+			err = cff.Intercept(ctx, interceptor, task0.outcome.Info, func(ctx context.Context) (err error) {
+				v2 = _47_12()
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return err
+			} else {
+				taskEmitter.TaskSuccess(ctx)
+			}
+
+			return
+		}
+
+		task0.job = sched.Enqueue(ctx, cff.Job{
+			Run: task0.run,
+		})
+		tasks = append(tasks, task0)
+
+		// go.uber.org/cff/internal/tests/switchcase/switchcase.go:51:4
+		var p0 bool
+		var p0PanicRecover interface{}
+		var p0PanicStacktrace []byte
+		_ = p0PanicStacktrace // possibly unused.
+		pred1 := new(struct {
+			ran cff.AtomicBool
+			run func(context.Context) error
+			job *cff.ScheduledJob
+		})
+		pred1.run = func(ctx context.Context) (err error) {
+			defer func() {
+				if recovered := recover(); recovered != nil {
+					p0PanicRecover = recovered
+					p0PanicStacktrace = debug.Stack()
+				}
+			}()
+			p0 = _52_5(v1)
+			return nil
+		}
+
+		pred1.job = sched.Enqueue(ctx, cff.Job{
+			Run: pred1.run,
+		})
+
+		// go.uber.org/cff/internal/tests/switchcase/switchcase.go:58:4
+		var p1 bool
+		var p1PanicRecover interface{}
+		var p1PanicStacktrace []byte
+		_ = p1PanicStacktrace // possibly unused.
+		pred2 := new(struct {
+			ran cff.AtomicBool
+			run func(context.Context) error
+			job *cff.ScheduledJob
+		})
+		pred2.run = func(ctx context.Context) (err error) {
+			defer func() {
+				if recovered := recover(); recovered != nil {
+					p1PanicRecover = recovered
+					p1PanicStacktrace = debug.Stack()
+				}
+			}()
+			p1 = _59_5(v1)
+			return nil
+		}
+
+		pred2.job = sched.Enqueue(ctx, cff.Job{
+			Run: pred2.run,
+		})
+
+		// go.uber.org/cff/internal/tests/switchcase/switchcase.go:65:4
+		var p2 bool
+		var p2PanicRecover interface{}
+		var p2PanicStacktrace []byte
+		_ = p2PanicStacktrace // possibly unused.
+		pred3 := new(struct {
+			ran cff.AtomicBool
+			run func(context.Context) error
+			job *cff.ScheduledJob
+		})
+		pred3.run = func(ctx context.Context) (err error) {
+			defer func() {
+				if recovered := recover(); recovered != nil {
+					p2PanicRecover = recovered
+					p2PanicStacktrace = debug.Stack()
+				}
+			}()
+			p2 = _66_5(v1)
+			return nil
+		}
+
+		pred3.job = sched.Enqueue(ctx, cff.Job{
+			Run: pred3.run,
+		})
+
+		// go.uber.org/cff/internal/tests/switchcase/switchcase.go:50:3
+		var (
+			v3 *User
+		)
+
+		// go.uber.org/cff/internal/tests/switchcase/switchcase.go:53:5
+		task1 := new(struct {
+			emitter cff.TaskEmitter
+			ran     cff.AtomicBool
+			run     func(context.Context) error
+			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		})
+		task1.outcome.Info = &cff.TaskInfo{
+			Name:   _56_20,
+			File:   "go.uber.org/cff/internal/tests/switchcase/switchcase.go",
+			Line:   53,
+			Column: 5,
+		}
+		task1.emitter = emitter.TaskInit(
+			task1.outcome.Info,
+			&cff.DirectiveInfo{
+				Name:      flowInfo.Name,
+				Directive: cff.FlowDirective,
+				File:      flowInfo.File,
+				Line:      flowInfo.Line,
+				Column:    flowInfo.Column,
+			},
+		)
+		task1.run = func(ctx context.Context) (err error) {
+			taskEmitter := task1.emitter
+			startTime := time.Now()
+			defer func() {
+				task1.outcome.Finish(err)
+				if task1.ran.Load() {
+					taskEmitter.TaskDone(ctx, time.Since(startTime))
+				}
+			}()
+
+			defer func() {
+				recovered := recover()
+				if recovered != nil {
+					taskEmitter.TaskPanic(ctx, recovered)
+					err = &cff.PanicError{
+						Value:      recovered,
+						Stacktrace: debug.Stack(),
+					}
+				}
+			}()
+
+			defer task1.ran.Store(true)
+
+			err = cff.Intercept(ctx, interceptor, task1.outcome.Info, func(ctx context.Context) (err error) {
+				v3 = _53_5(v2, v1)
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return err
+			} else {
+				taskEmitter.TaskSuccess(ctx)
+			}
+
+			return
+		}
+
+		tasks = append(tasks, task1)
+
+		// go.uber.org/cff/internal/tests/switchcase/switchcase.go:60:5
+		task2 := new(struct {
+			emitter cff.TaskEmitter
+			ran     cff.AtomicBool
+			run     func(context.Context) error
+			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		})
+		task2.outcome.Info = &cff.TaskInfo{
+			Name:   _63_20,
+			File:   "go.uber.org/cff/internal/tests/switchcase/switchcase.go",
+			Line:   60,
+			Column: 5,
+		}
+		task2.emitter = emitter.TaskInit(
+			task2.outcome.Info,
+			&cff.DirectiveInfo{
+				Name:      flowInfo.Name,
+				Directive: cff.FlowDirective,
+				File:      flowInfo.File,
+				Line:      flowInfo.Line,
+				Column:    flowInfo.Column,
+			},
+		)
+		task2.run = func(ctx context.Context) (err error) {
+			taskEmitter := task2.emitter
+			startTime := time.Now()
+			defer func() {
+				task2.outcome.Finish(err)
+				if task2.ran.Load() {
+					taskEmitter.TaskDone(ctx, time.Since(startTime))
+				}
+			}()
+
+			defer func() {
+				recovered := recover()
+				if recovered != nil {
+					taskEmitter.TaskPanic(ctx, recovered)
+					err = &cff.PanicError{
+						Value:      recovered,
+						Stacktrace: debug.Stack(),
+					}
+				}
+			}()
+
+			defer task2.ran.Store(true)
+
+			err = cff.Intercept(ctx, interceptor, task2.outcome.Info, func(ctx context.Context) (err error) {
+				v3, err = _60_5(v1)
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return err
+			} else {
+				taskEmitter.TaskSuccess(ctx)
+			}
+
+			return
+		}
+
+		tasks = append(tasks, task2)
+
+		// go.uber.org/cff/internal/tests/switchcase/switchcase.go:72:5
+		task3 := new(struct {
+			emitter cff.TaskEmitter
+			ran     cff.AtomicBool
+			run     func(context.Context) error
+			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		})
+		task3.outcome.Info = &cff.TaskInfo{
+			Name:   _75_20,
+			File:   "go.uber.org/cff/internal/tests/switchcase/switchcase.go",
+			Line:   72,
+			Column: 5,
+		}
+		task3.emitter = emitter.TaskInit(
+			task3.outcome.Info,
+			&cff.DirectiveInfo{
+				Name:      flowInfo.Name,
+				Directive: cff.FlowDirective,
+				File:      flowInfo.File,
+				Line:      flowInfo.Line,
+				Column:    flowInfo.Column,
+			},
+		)
+		task3.run = func(ctx context.Context) (err error) {
+			taskEmitter := task3.emitter
+			startTime := time.Now()
+			defer func() {
+				task3.outcome.Finish(err)
+				if task3.ran.Load() {
+					taskEmitter.TaskDone(ctx, time.Since(startTime))
+				}
+			}()
+
+			defer func() {
+				recovered := recover()
+				if recovered != nil {
+					taskEmitter.TaskPanic(ctx, recovered)
+					err = &cff.PanicError{
+						Value:      recovered,
+						Stacktrace: debug.Stack(),
+					}
+				}
+			}()
+
+			defer task3.ran.Store(true)
+
+			err = cff.Intercept(ctx, interceptor, task3.outcome.Info, func(ctx context.Context) (err error) {
+				v3 = _72_5(v1)
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return err
+			} else {
+				taskEmitter.TaskSuccess(ctx)
+			}
+
+			return
+		}
+
+		tasks = append(tasks, task3)
+
+		// go.uber.org/cff/internal/tests/switchcase/switchcase.go:78:5
+		task4 := new(struct {
+			emitter cff.TaskEmitter
+			ran     cff.AtomicBool
+			run     func(context.Context) error
+			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		})
+		task4.outcome.Info = &cff.TaskInfo{
+			Name:   _81_20,
+			File:   "go.uber.org/cff/internal/tests/switchcase/switchcase.go",
+			Line:   78,
+			Column: 5,
+		}
+		task4.emitter = emitter.TaskInit(
+			task4.outcome.Info,
+			&cff.DirectiveInfo{
+				Name:      flowInfo.Name,
+				Directive: cff.FlowDirective,
+				File:      flowInfo.File,
+				Line:      flowInfo.Line,
+				Column:    flowInfo.Column,
+			},
+		)
+		task4.run = func(ctx context.Context) (err error) {
+			taskEmitter := task4.emitter
+			startTime := time.Now()
+			defer func() {
+				task4.outcome.Finish(err)
+				if task4.ran.Load() {
+					taskEmitter.TaskDone(ctx, time.Since(startTime))
+				}
+			}()
+
+			defer func() {
+				recovered := recover()
+				if recovered != nil {
+					taskEmitter.TaskPanic(ctx, recovered)
+					err = &cff.PanicError{
+						Value:      recovered,
+						Stacktrace: debug.Stack(),
+					}
+				}
+			}()
+
+			defer task4.ran.Store(true)
+
+			err = cff.Intercept(ctx, interceptor, task4.outcome.Info, func(ctx context.Context) (err error) {
+				v3, err = _78_5(ctx, v1)
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return err
+			} else {
+				taskEmitter.TaskSuccess(ctx)
+			}
+
+			return
+		}
+
+		tasks = append(tasks, task4)
+		task5 := new(struct {
+			emitter cff.TaskEmitter
+			ran     cff.AtomicBool
+			run     func(context.Context) error
+			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		})
+		task5.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/switchcase/switchcase.go",
+			Line:   50,
+			Column: 3,
+		}
+		task5.outcome.Dependencies = []*cff.TaskOutcome{
+			&task0.outcome,
+		}
+		task1.outcome.Dependencies = task5.outcome.Dependencies
+		task2.outcome.Dependencies = task5.outcome.Dependencies
+		task3.outcome.Dependencies = task5.outcome.Dependencies
+		task4.outcome.Dependencies = task5.outcome.Dependencies
+		task5.run = func(ctx context.Context) (err error) {
+			defer func() {
+				task5.outcome.Finish(err)
+				if err != nil {
+					return
+				}
+
+				// Tasks of the Switch that were not selected.
+				if !task1.ran.Load() {
+					task1.outcome.Skip(cff.SkipPredicateFalse)
+				}
+				if !task2.ran.Load() {
+					task2.outcome.Skip(cff.SkipPredicateFalse)
+				}
+				if !task3.ran.Load() {
+					task3.outcome.Skip(cff.SkipPredicateFalse)
+				}
+				if !task4.ran.Load() {
+					task4.outcome.Skip(cff.SkipPredicateFalse)
+				}
+			}()
+			if p0PanicRecover != nil {
+				task1.emitter.TaskPanic(ctx, p0PanicRecover)
+				return &cff.PanicError{
+					Value:      p0PanicRecover,
+					Stacktrace: p0PanicStacktrace,
+				}
+			}
+			if p0 {
+				return task1.run(ctx)
+			}
+			if p1PanicRecover != nil {
+				task2.emitter.TaskPanic(ctx, p1PanicRecover)
+				return &cff.PanicError{
+					Value:      p1PanicRecover,
+					Stacktrace: p1PanicStacktrace,
+				}
+			}
+			if p1 {
+				return task2.run(ctx)
+			}
+			if p2PanicRecover != nil {
+				task3.emitter.TaskPanic(ctx, p2PanicRecover)
+				return &cff.PanicError{
+					Value:      p2PanicRecover,
+					Stacktrace: p2PanicStacktrace,
+				}
+			}
+			if p2 {
+				return task3.run(ctx)
+			}
+
+			return task4.run(ctx)
+		}
+
+		task5.job = sched.Enqueue(ctx, cff.Job{
+			Run: task5.run,
+			Dependencies: []*cff.ScheduledJob{
+				task0.job,
+				pred1.job,
+				pred2.job,
+				pred3.job,
+			},
+		})
+
+		if err := sched.Wait(ctx); err != nil {
+			flowEmitter.FlowError(ctx, err)
+			cff.RethrowPanic(err, false)
+			return err
+		}
+
+		*(_45_15) = v3 // *go.uber.org/cff/internal/tests/switchcase.User
+
+		flowEmitter.FlowSuccess(ctx)
+		return nil
+	}()
+	return u, err
+}
+
+// NoDefault is a flow with a cff.Switch without a cff.Default.
+// It reports whether the user was found.
+func NoDefault(ctx context.Context, req *Request) (cff.Maybe[*User], string, error) {
+	var (
+		u    cff.Maybe[*User]
+		name string
+	)
+	err := func() (err error) {
+
+		_95_18 := ctx
+
+		_96_14 := req
+
+		_97_15 := &u
+
+		_97_19 := &name
+
+		_100_5 := func(req *Request) bool { return req.Source == Cache }
+
+		_101_5 := func(req *Request) *User {
+			return &User{ID: req.ID, From: "cache"}
+		}
+
+		_106_12 := func(u *User) string {
+			if u == nil {
+				return "unknown"
+			}
+			return u.From
+		}
+		ctx := _95_18
+		var v1 *Request = _96_14
+		emitter := cff.NopEmitter()
+		interceptor := cff.InterceptorStack()
+
+		var (
+			flowInfo = &cff.FlowInfo{
+				File:   "go.uber.org/cff/internal/tests/switchcase/switchcase.go",
+				Line:   95,
+				Column: 9,
+			}
+			flowEmitter = cff.NopFlowEmitter()
+
+			schedInfo = &cff.SchedulerInfo{
+				Name:      flowInfo.Name,
+				Directive: cff.FlowDirective,
+				File:      flowInfo.File,
+				Line:      flowInfo.Line,
+				Column:    flowInfo.Column,
+			}
+
+			// possibly unused
+			_ = flowInfo
+		)
+
+		startTime := time.Now()
+		defer func() { flowEmitter.FlowDone(ctx, time.Since(startTime)) }()
+
+		schedEmitter := emitter.SchedulerInit(schedInfo)
+
+		sched := cff.NewScheduler(
+			cff.SchedulerParams{
+				Emitter: schedEmitter,
+			},
+		)
+
+		var tasks []*struct {
+			emitter cff.TaskEmitter
+			ran     cff.AtomicBool
+			run     func(context.Context) error
+			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		}
+		defer func() {
+			for _, t := range tasks {
+				if !t.ran.Load() {
+					t.emitter.TaskSkipped(ctx, t.outcome.SkipReason(ctx, err))
+				}
+			}
+		}()
+
+		// go.uber.org/cff/internal/tests/switchcase/switchcase.go:99:4
+		var p0 bool
+		var p0PanicRecover interface{}
+		var p0PanicStacktrace []byte
+		_ = p0PanicStacktrace // possibly unused.
+		pred1 := new(struct {
+			ran cff.AtomicBool
+			run func(context.Context) error
+			job *cff.ScheduledJob
+		})
+		pred1.run = func(ctx context.Context) (err error) {
+			defer func() {
+				if recovered := recover(); recovered != nil {
+					p0PanicRecover = recovered
+					p0PanicStacktrace = debug.Stack()
+				}
+			}()
+			p0 = _100_5(v1)
+			return nil
+		}
+
+		pred1.job = sched.Enqueue(ctx, cff.Job{
+			Run: pred1.run,
+		})
+
+		// go.uber.org/cff/internal/tests/switchcase/switchcase.go:98:3
+		var (
+			v3 *User
+		)
+
+		var task7Produced bool
+
+		// go.uber.org/cff/internal/tests/switchcase/switchcase.go:101:5
+		var task6Produced bool
+		task6 := new(struct {
+			emitter cff.TaskEmitter
+			ran     cff.AtomicBool
+			run     func(context.Context) error
+			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		})
+		task6.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/switchcase/switchcase.go",
+			Line:   101,
+			Column: 5,
+		}
+		task6.emitter = cff.NopTaskEmitter()
+		task6.run = func(ctx context.Context) (err error) {
+			taskEmitter := task6.emitter
+			startTime := time.Now()
+			defer func() {
+				task6.outcome.Finish(err)
+				if task6.ran.Load() {
+					taskEmitter.TaskDone(ctx, time.Since(startTime))
+				}
+			}()
+
+			defer func() {
+				recovered := recover()
+				if recovered != nil {
+					taskEmitter.TaskPanic(ctx, recovered)
+					err = &cff.PanicError{
+						Value:      recovered,
+						Stacktrace: debug.Stack(),
+					}
+				}
+			}()
+
+			defer task6.ran.Store(true)
+
+			err = cff.Intercept(ctx, interceptor, task6.outcome.Info, func(ctx context.Context) (err error) {
+				v3 = _101_5(v1)
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return err
+			} else {
+				taskEmitter.TaskSuccess(ctx)
+			}
+
+			task6Produced = true
+
+			return
+		}
+
+		tasks = append(tasks, task6)
+		task7 := new(struct {
+			emitter cff.TaskEmitter
+			ran     cff.AtomicBool
+			run     func(context.Context) error
+			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		})
+		task7.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/switchcase/switchcase.go",
+			Line:   98,
+			Column: 3,
+		}
+		task6.outcome.Dependencies = task7.outcome.Dependencies
+		task7.run = func(ctx context.Context) (err error) {
+			defer func() {
+				task7.outcome.Finish(err)
+				if err != nil {
+					return
+				}
+
+				// Tasks of the Switch that were not selected.
+				if !task6.ran.Load() {
+					task6.outcome.Skip(cff.SkipPredicateFalse)
+				}
+			}()
+			if p0PanicRecover != nil {
+				task6.emitter.TaskPanic(ctx, p0PanicRecover)
+				return &cff.PanicError{
+					Value:      p0PanicRecover,
+					Stacktrace: p0PanicStacktrace,
+				}
+			}
+			if p0 {
+				err = task6.run(ctx)
+				task7Produced = task6Produced
+				return err
+			}
+
+			return nil
+		}
+
+		task7.job = sched.Enqueue(ctx, cff.Job{
+			Run: task7.run,
+			Dependencies: []*cff.ScheduledJob{
+				pred1.job,
+			},
+		})
+
+		// go.uber.org/cff/internal/tests/switchcase/switchcase.go:106:12
+		var (
+			v4 string
+		)
+
+		task8 := new(struct {
+			emitter cff.TaskEmitter
+			ran     cff.AtomicBool
+			run     func(context.Context) error
+			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		})
+		task8.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/switchcase/switchcase.go",
+			Line:   106,
+			Column: 12,
+		}
+		task8.outcome.Dependencies = []*cff.TaskOutcome{
+			&task7.outcome,
+		}
+		task8.emitter = cff.NopTaskEmitter()
+		task8.run = func(ctx context.Context) (err error) {
+			taskEmitter := task8.emitter
+			startTime := time.Now()
+			defer func() {
+				task8.outcome.Finish(err)
+				if task8.ran.Load() {
+					taskEmitter.TaskDone(ctx, time.Since(startTime))
+				}
+			}()
+
+			defer func() {
+				recovered := recover()
+				if recovered != nil {
+					taskEmitter.TaskPanic(ctx, recovered)
+					err = &cff.PanicError{
+						Value:      recovered,
+						Stacktrace: debug.Stack(),
+					}
+				}
+			}()
+
+			defer task8.ran.Store(true)
+
+			err = cff.Intercept(ctx, interceptor, task8.outcome.Info, func(ctx context.Context) (err error) {
+				v4 = _106_12(v3)
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return err
+			} else {
+				taskEmitter.TaskSuccess(ctx)
+			}
+
+			return
+		}
+
+		task8.job = sched.Enqueue(ctx, cff.Job{
+			Run: task8.run,
+			Dependencies: []*cff.ScheduledJob{
+				task7.job,
+			},
+		})
+		tasks = append(tasks, task8)
+
+		if err := sched.Wait(ctx); err != nil {
+			flowEmitter.FlowError(ctx, err)
+			cff.RethrowPanic(err, false)
+			return err
+		}
+
+		*(_97_15) = cff.Maybe[*User]{
+			Value: v3,
+			Valid: task7Produced,
+		} // *go.uber.org/cff/internal/tests/switchcase.User
+		*(_97_19) = v4 // string
+
+		flowEmitter.FlowSuccess(ctx)
+		return nil
+	}()
+	return u, name, err
+}
+
+// Results is a result object produced by the tasks of a switch.
+type Results struct {
+	cff.Out
+
+	User *User
+	Name string
+}
+
+// Objects is a flow with a cff.Switch whose tasks produce the same types
+// in different forms.
+func Objects(ctx context.Context, useObject bool) (*User, string, error) {
+	var (
+		u    *User
+		name string
+	)
+	err := func() (err error) {
+
+		_131_18 := ctx
+
+		_132_14 := useObject
+
+		_133_15 := &u
+
+		_133_19 := &name
+
+		_136_5 := func(useObject bool) bool { return useObject }
+
+		_137_5 := func() Results {
+			return Results{User: &User{ID: 1}, Name: "object"}
+		}
+
+		_141_16 := func() (string, *User) {
+			return "plain", &User{ID: 2}
+		}
+		ctx := _131_18
+		var v5 bool = _132_14
+		emitter := cff.NopEmitter()
+		interceptor := cff.InterceptorStack()
+
+		var (
+			flowInfo = &cff.FlowInfo{
+				File:   "go.uber.org/cff/internal/tests/switchcase/switchcase.go",
+				Line:   131,
+				Column: 9,
+			}
+			flowEmitter = cff.NopFlowEmitter()
+
+			schedInfo = &cff.SchedulerInfo{
+				Name:      flowInfo.Name,
+				Directive: cff.FlowDirective,
+				File:      flowInfo.File,
+				Line:      flowInfo.Line,
+				Column:    flowInfo.Column,
+			}
+
+			// possibly unused
+			_ = flowInfo
+		)
+
+		startTime := time.Now()
+		defer func() { flowEmitter.FlowDone(ctx, time.Since(startTime)) }()
+
+		schedEmitter := emitter.SchedulerInit(schedInfo)
+
+		sched := cff.NewScheduler(
+			cff.SchedulerParams{
+				Emitter: schedEmitter,
+			},
+		)
+
+		var tasks []*struct {
+			emitter cff.TaskEmitter
+			ran     cff.AtomicBool
+			run     func(context.Context) error
+			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		}
+		defer func() {
+			for _, t := range tasks {
+				if !t.ran.Load() {
+					t.emitter.TaskSkipped(ctx, t.outcome.SkipReason(ctx, err))
+				}
+			}
+		}()
+
+		// go.uber.org/cff/internal/tests/switchcase/switchcase.go:135:4
+		var p0 bool
+		var p0PanicRecover interface{}
+		var p0PanicStacktrace []byte
+		_ = p0PanicStacktrace // possibly unused.
+		pred1 := new(struct {
+			ran cff.AtomicBool
+			run func(context.Context) error
+			job *cff.ScheduledJob
+		})
+		pred1.run = func(ctx context.Context) (err error) {
+			defer func() {
+				if recovered := recover(); recovered != nil {
+					p0PanicRecover = recovered
+					p0PanicStacktrace = debug.Stack()
+				}
+			}()
+			p0 = _136_5(v5)
+			return nil
+		}
+
+		pred1.job = sched.Enqueue(ctx, cff.Job{
+			Run: pred1.run,
+		})
+
+		// go.uber.org/cff/internal/tests/switchcase/switchcase.go:134:3
+		var (
+			v3 *User
+			v4 string
+		)
+
+		// go.uber.org/cff/internal/tests/switchcase/switchcase.go:137:5
+		task9 := new(struct {
+			emitter cff.TaskEmitter
+			ran     cff.AtomicBool
+			run     func(context.Context) error
+			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		})
+		task9.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/switchcase/switchcase.go",
+			Line:   137,
+			Column: 5,
+		}
+		task9.emitter = cff.NopTaskEmitter()
+		task9.run = func(ctx context.Context) (err error) {
+			taskEmitter := task9.emitter
+			startTime := time.Now()
+			defer func() {
+				task9.outcome.Finish(err)
+				if task9.ran.Load() {
+					taskEmitter.TaskDone(ctx, time.Since(startTime))
+				}
+			}()
+
+			var task9Out0 Results
+			// Unpack cff.Out and cff.Maybe results after recovering from panics.
+			defer func() {
+				if err != nil {
+					return
+				}
+				v3 = task9Out0.User
+				v4 = task9Out0.Name
+			}()
+
+			defer func() {
+				recovered := recover()
+				if recovered != nil {
+					taskEmitter.TaskPanic(ctx, recovered)
+					err = &cff.PanicError{
+						Value:      recovered,
+						Stacktrace: debug.Stack(),
+					}
+				}
+			}()
+
+			defer task9.ran.Store(true)
+
+			err = cff.Intercept(ctx, interceptor, task9.outcome.Info, func(ctx context.Context) (err error) {
+				task9Out0 = _137_5()
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return err
+			} else {
+				taskEmitter.TaskSuccess(ctx)
+			}
+
+			return
+		}
+
+		tasks = append(tasks, task9)
+
+		// go.uber.org/cff/internal/tests/switchcase/switchcase.go:141:16
+		task10 := new(struct {
+			emitter cff.TaskEmitter
+			ran     cff.AtomicBool
+			run     func(context.Context) error
+			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		})
+		task10.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/switchcase/switchcase.go",
+			Line:   141,
+			Column: 16,
+		}
+		task10.emitter = cff.NopTaskEmitter()
+		task10.run = func(ctx context.Context) (err error) {
+			taskEmitter := task10.emitter
+			startTime := time.Now()
+			defer func() {
+				task10.outcome.Finish(err)
+				if task10.ran.Load() {
+					taskEmitter.TaskDone(ctx, time.Since(startTime))
+				}
+			}()
+
+			defer func() {
+				recovered := recover()
+				if recovered != nil {
+					taskEmitter.TaskPanic(ctx, recovered)
+					err = &cff.PanicError{
+						Value:      recovered,
+						Stacktrace: debug.Stack(),
+					}
+				}
+			}()
+
+			defer task10.ran.Store(true)
+
+			err = cff.Intercept(ctx, interceptor, task10.outcome.Info, func(ctx context.Context) (err error) {
+				v4, v3 = _141_16()
+				return
+			})
+			if err != nil {
+				taskEmitter.TaskError(ctx, err)
+				return err
+			} else {
+				taskEmitter.TaskSuccess(ctx)
+			}
+
+			return
+		}
+
+		tasks = append(tasks, task10)
+		task11 := new(struct {
+			emitter cff.TaskEmitter
+			ran     cff.AtomicBool
+			run     func(context.Context) error
+			job     *cff.ScheduledJob
+
+			outcome cff.TaskOutcome // reports why the task was skipped
+		})
+		task11.outcome.Info = &cff.TaskInfo{
+			File:   "go.uber.org/cff/internal/tests/switchcase/switchcase.go",
+			Line:   134,
+			Column: 3,
+		}
+		task9.outcome.Dependencies = task11.outcome.Dependencies
+		task10.outcome.Dependencies = task11.outcome.Dependencies
+		task11.run = func(ctx context.Context) (err error) {
+			defer func() {
+				task11.outcome.Finish(err)
+				if err != nil {
+					return
+				}
+
+				// Tasks of the Switch that were not selected.
+				if !task9.ran.Load() {
+					task9.outcome.Skip(cff.SkipPredicateFalse)
+				}
+				if !task10.ran.Load() {
+					task10.outcome.Skip(cff.SkipPredicateFalse)
+				}
+			}()
+			if p0PanicRecover != nil {
+				task9.emitter.TaskPanic(ctx, p0PanicRecover)
+				return &cff.PanicError{
+					Value:      p0PanicRecover,
+					Stacktrace: p0PanicStacktrace,
+				}
+			}
+			if p0 {
+				return task9.run(ctx)
+			}
+
+			return task10.run(ctx)
+		}
+
+		task11.job = sched.Enqueue(ctx, cff.Job{
+			Run: task11.run,
+			Dependencies: []*cff.ScheduledJob{
+				pred1.job,
+			},
+		})
+
+		if err := sched.Wait(ctx); err != nil {
+			flowEmitter.FlowError(ctx, err)
+			cff.RethrowPanic(err, false)
+			return err
+		}
+
+		*(_133_15) = v3 // *go.uber.org/cff/internal/tests/switchcase.User
+		*(_133_19) = v4 // string
+
+		flowEmitter.FlowSuccess(ctx)
+		return nil
+	}()
+	return u, name, err
+}
@@ -0,0 +1,83 @@
+package switchcase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/cff"
+	"go.uber.org/cff/internal/emittertest"
+)
+
+func TestLookup(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("cache", func(t *testing.T) {
+		e := emittertest.NewRecorder()
+		u, err := Lookup(ctx, e, &Request{ID: 1, Source: Cache})
+		require.NoError(t, err)
+		assert.Equal(t, &User{ID: 1, From: "cache"}, u)
+		assert.Equal(t, []string{"cache"}, e.Names(emittertest.TaskSuccess))
+		assert.ElementsMatch(t, []string{"fail", "panic", "database"}, e.Names(emittertest.TaskSkipped))
+	})
+
+	t.Run("default", func(t *testing.T) {
+		e := emittertest.NewRecorder()
+		u, err := Lookup(ctx, e, &Request{ID: 2, Source: Database})
+		require.NoError(t, err)
+		assert.Equal(t, &User{ID: 2, From: "database"}, u)
+		assert.Equal(t, []string{"database"}, e.Names(emittertest.TaskSuccess))
+		assert.ElementsMatch(t, []string{"cache", "fail", "panic"}, e.Names(emittertest.TaskSkipped))
+	})
+
+	t.Run("error", func(t *testing.T) {
+		_, err := Lookup(ctx, emittertest.NewRecorder(), &Request{ID: 3, Source: Fail})
+		assert.EqualError(t, err, "great sadness")
+	})
+
+	t.Run("predicate panic", func(t *testing.T) {
+		e := emittertest.NewRecorder()
+		_, err := Lookup(ctx, e, &Request{ID: 4, Source: Panic})
+		var pe *cff.PanicError
+		require.ErrorAs(t, err, &pe)
+		assert.Equal(t, "great sadness", pe.Value)
+
+		panics := e.EventsOf(emittertest.TaskPanic)
+		require.Len(t, panics, 1, "panic must be reported to the emitter")
+		assert.Equal(t, "panic", panics[0].Task.Name)
+		assert.Equal(t, "great sadness", panics[0].Value)
+	})
+}
+
+func TestNoDefault(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("matched", func(t *testing.T) {
+		u, name, err := NoDefault(ctx, &Request{ID: 1, Source: Cache})
+		require.NoError(t, err)
+		assert.Equal(t, cff.Some(&User{ID: 1, From: "cache"}), u)
+		assert.Equal(t, "cache", name)
+	})
+
+	t.Run("not matched", func(t *testing.T) {
+		u, name, err := NoDefault(ctx, &Request{ID: 1, Source: Database})
+		require.NoError(t, err)
+		assert.False(t, u.Valid)
+		assert.Equal(t, "unknown", name)
+	})
+}
+
+func TestObjects(t *testing.T) {
+	ctx := context.Background()
+
+	u, name, err := Objects(ctx, true)
+	require.NoError(t, err)
+	assert.Equal(t, &User{ID: 1}, u)
+	assert.Equal(t, "object", name)
+
+	u, name, err = Objects(ctx, false)
+	require.NoError(t, err)
+	assert.Equal(t, &User{ID: 2}, u)
+	assert.Equal(t, "plain", name)
+}
@@ -9,9 +9,12 @@ func isContext(t types.Type) bool {
 	}
 
 	o := named.Obj()
-	return o.Pkg().Path() == "context" && o.Name() == "Context"
+	return o.Pkg() != nil && o.Pkg().Path() == "context" && o.Name() == "Context"
 }
 
+// errorType is the built-in error type.
+var errorType = types.Universe.Lookup("error").Type()
+
 func isError(t types.Type) bool {
 	n, ok := t.(*types.Named)
 	if !ok {
@@ -20,3 +23,25 @@ func isError(t types.Type) bool {
 	o := n.Obj()
 	return o.Pkg() == nil && o.Name() == "error"
 }
+
+// embedsCffType reports whether the struct embeds the type with the given
+// name from the cff package.
+func embedsCffType(st *types.Struct, name string) bool {
+	for i := 0; i < st.NumFields(); i++ {
+		if f := st.Field(i); f.Embedded() && isCffType(f.Type(), name) {
+			return true
+		}
+	}
+	return false
+}
+
+// isCffType reports whether t is the named type with the given name from
+// the cff package.
+func isCffType(t types.Type, name string) bool {
+	named, ok := t.(*types.Named)
+	if !ok {
+		return false
+	}
+	o := named.Obj()
+	return o.Name() == name && isPackagePathEquivalent(o.Pkg(), cffImportPath)
+}
@@ -0,0 +1,113 @@
+package cff
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+)
+
+// Limiter limits the rate at which tasks run.
+// Tasks wait for the limiter before they run.
+// See [RateLimit] for details.
+//
+// *rate.Limiter from golang.org/x/time/rate satisfies this interface,
+// as does [TokenBucket].
+type Limiter interface {
+	// Wait blocks until a task may run.
+	// It fails if the context ends first.
+	Wait(ctx context.Context) error
+}
+
+// TokenBucket is a [Limiter] that allows tasks to run at a steady rate,
+// with bursts of up to a fixed number of tasks.
+//
+// Build one with [NewTokenBucket].
+// A TokenBucket is safe for concurrent use,
+// and may be shared between Flows and Parallels.
+type TokenBucket struct {
+	rate  float64 // tokens added per second
+	burst float64 // maximum number of tokens
+
+	mu     sync.Mutex
+	tokens float64   // available tokens; negative if tokens were reserved
+	last   time.Time // when tokens was last updated
+
+	now func() time.Time // used by tests
+}
+
+var _ Limiter = (*TokenBucket)(nil)
+
+// NewTokenBucket builds a TokenBucket that allows perSecond tasks to run
+// every second, with bursts of up to burst tasks.
+// The bucket starts full.
+//
+//	limiter := cff.NewTokenBucket(200, 1) // 200 tasks per second, one at a time
+//
+// NewTokenBucket panics if perSecond is not positive.
+// Values of burst less than 1 are treated as 1.
+func NewTokenBucket(perSecond float64, burst int) *TokenBucket {
+	if perSecond <= 0 {
+		panic(fmt.Sprintf("cff.NewTokenBucket: rate must be positive, got %v", perSecond))
+	}
+	if burst < 1 {
+		burst = 1
+	}
+	b := &TokenBucket{
+		rate:   perSecond,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		now:    time.Now,
+	}
+	b.last = b.now()
+	return b
+}
+
+// Wait blocks until a token is available, taking it from the bucket.
+// It fails if the context ends first.
+func (b *TokenBucket) Wait(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	delay := b.reserve()
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		b.cancel()
+		return ctx.Err()
+	}
+}
+
+// reserve takes a token from the bucket,
+// reporting how long the caller must wait before using it.
+func (b *TokenBucket) reserve() time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := b.now()
+	b.tokens = math.Min(b.burst, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+	b.last = now
+
+	b.tokens--
+	if b.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-b.tokens / b.rate * float64(time.Second))
+}
+
+// cancel returns a token reserved by a caller that stopped waiting for it.
+func (b *TokenBucket) cancel() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.tokens = math.Min(b.burst, b.tokens+1)
+}
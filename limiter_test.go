@@ -0,0 +1,60 @@
+package cff
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTokenBucketReserve(t *testing.T) {
+	now := time.Unix(0, 0)
+	b := NewTokenBucket(10, 2)
+	b.now = func() time.Time { return now }
+	b.last = now
+
+	// The bucket starts full.
+	assert.Zero(t, b.reserve())
+	assert.Zero(t, b.reserve())
+
+	// Tokens are reserved in order.
+	assert.Equal(t, 100*time.Millisecond, b.reserve())
+	assert.Equal(t, 200*time.Millisecond, b.reserve())
+
+	// The bucket refills over time, up to the burst.
+	now = now.Add(time.Second)
+	assert.Zero(t, b.reserve())
+	assert.Zero(t, b.reserve())
+	assert.Equal(t, 100*time.Millisecond, b.reserve())
+}
+
+func TestTokenBucketWait(t *testing.T) {
+	b := NewTokenBucket(1000, 1)
+
+	start := time.Now()
+	for i := 0; i < 5; i++ {
+		assert.NoError(t, b.Wait(context.Background()))
+	}
+	assert.GreaterOrEqual(t, time.Since(start), 4*time.Millisecond)
+}
+
+func TestTokenBucketWaitCancelled(t *testing.T) {
+	b := NewTokenBucket(0.001, 1)
+	assert.NoError(t, b.Wait(context.Background()))
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, b.Wait(ctx), context.DeadlineExceeded)
+
+	// The token reserved by the cancelled call was returned.
+	assert.InDelta(t, 0, b.tokens, 0.01)
+
+	cancel()
+	assert.ErrorIs(t, b.Wait(ctx), context.DeadlineExceeded)
+}
+
+func TestNewTokenBucket(t *testing.T) {
+	assert.Panics(t, func() { NewTokenBucket(0, 1) })
+	assert.Equal(t, float64(1), NewTokenBucket(1, 0).burst)
+}
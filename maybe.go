@@ -0,0 +1,59 @@
+package cff
+
+import "errors"
+
+// ErrNotProduced is wrapped by the [SkipReason] passed to
+// [TaskEmitter].TaskSkipped for tasks of a [Flow] that were skipped because
+// a value they depend on was not produced.
+var ErrNotProduced = errors.New("cff: a value that the task depends on was not produced")
+
+// Maybe holds a value that may not have been produced.
+//
+// Tasks in a [Flow] may accept a Maybe[T] in place of any input T.
+// The Maybe is valid only if the task that provides T ran successfully.
+// It is invalid if that task failed and was marked [Optional],
+// if it was skipped because of a [Predicate],
+// or if it reported that it did not produce T.
+//
+// Tasks may return a Maybe[T] in place of T
+// to report whether they produced T.
+// The following are equivalent:
+//
+//	cff.Task(func(u *User) cff.Maybe[*Manager] {
+//		// ...
+//	})
+//
+//	cff.Task(func(u *User) (*Manager, bool) {
+//		// ...
+//	})
+//
+// If such a task does not produce T,
+// tasks that depend on T are skipped as if their [Predicate] returned false,
+// and reported to emitters as skipped with [SkipNotProduced].
+// Tasks that accept a Maybe[T] in place of T run with an invalid Maybe.
+//
+//	cff.Task(func(avatar cff.Maybe[*Avatar]) *Profile {
+//		// ...
+//	})
+//
+// Similarly, pointers to Maybe[T] may be passed to [Results]
+// in place of pointers to T.
+// The result is valid only if it was produced.
+type Maybe[T any] struct {
+	// Value is the value held by this Maybe.
+	// It is the zero value of T if Valid is false.
+	Value T
+
+	// Valid reports whether Value was produced.
+	Valid bool
+}
+
+// Some builds a valid Maybe holding the given value.
+func Some[T any](v T) Maybe[T] {
+	return Maybe[T]{Value: v, Valid: true}
+}
+
+// Get returns the value held by this Maybe and whether it is valid.
+func (m Maybe[T]) Get() (T, bool) {
+	return m.Value, m.Valid
+}
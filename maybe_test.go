@@ -0,0 +1,22 @@
+package cff
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMaybe(t *testing.T) {
+	t.Run("zero", func(t *testing.T) {
+		var m Maybe[string]
+		v, ok := m.Get()
+		assert.False(t, ok)
+		assert.Empty(t, v)
+	})
+
+	t.Run("some", func(t *testing.T) {
+		v, ok := Some("foo").Get()
+		assert.True(t, ok)
+		assert.Equal(t, "foo", v)
+	})
+}
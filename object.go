@@ -0,0 +1,58 @@
+package cff
+
+// In may be embedded into a struct to mark it as a parameter object
+// for a [Flow] task.
+// Each field of a parameter object is resolved as a separate input of the
+// task.
+//
+//	type UserParams struct {
+//		cff.In
+//
+//		Client  *http.Client
+//		Session *Session
+//	}
+//
+//	cff.Task(func(p UserParams) (*User, error) {
+//		// ...
+//	})
+//
+// The task above behaves as if it was declared as,
+//
+//	func(*http.Client, *Session) (*User, error)
+//
+// Fields of a parameter object must be exported,
+// or the struct must be declared in the same package as the Flow.
+type In struct{ _ inOnly }
+
+// Out may be embedded into a struct to mark it as a result object
+// for a [Flow] task.
+// Each field of a result object is provided as a separate output of the
+// task.
+//
+//	type UserResults struct {
+//		cff.Out
+//
+//		User    *User
+//		Profile *Profile
+//	}
+//
+//	cff.Task(func(...) (UserResults, error) {
+//		// ...
+//	})
+//
+// The task above behaves as if it was declared as,
+//
+//	func(...) (*User, *Profile, error)
+//
+// [FallbackWith] accepts the result object as-is.
+//
+// Fields of a result object must be exported,
+// or the struct must be declared in the same package as the Flow.
+type Out struct{ _ outOnly }
+
+// inOnly and outOnly prevent In and Out from being converted into
+// each other.
+type (
+	inOnly  struct{}
+	outOnly struct{}
+)
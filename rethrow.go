@@ -0,0 +1,64 @@
+package cff
+
+import (
+	"errors"
+	"sync/atomic"
+)
+
+// _rethrowPanics is the process-wide default for RethrowPanics.
+var _rethrowPanics atomic.Bool
+
+// _rethrowHook is called with panics before they're rethrown, if set.
+var _rethrowHook atomic.Pointer[func(*PanicError)]
+
+// SetRethrowPanics changes the process-wide default for [RethrowPanics].
+// If enabled, all Flows and Parallels rethrow task panics
+// on the calling goroutine, even if they don't specify RethrowPanics.
+//
+// This is intended to be called once during program initialization.
+func SetRethrowPanics(enabled bool) {
+	_rethrowPanics.Store(enabled)
+}
+
+// SetRethrowPanicHook installs a function that is called with the
+// [PanicError] of each panic rethrown because of [RethrowPanics],
+// right before it's rethrown.
+// The rethrown value doesn't carry the stack trace of the task that
+// panicked, so use this to record it:
+//
+//	cff.SetRethrowPanicHook(func(pe *cff.PanicError) {
+//		log.Printf("task panicked: %v\n%s", pe.Value, pe.Stacktrace)
+//	})
+//
+// Pass nil to remove the hook.
+//
+// This is intended to be called once during program initialization.
+func SetRethrowPanicHook(hook func(*PanicError)) {
+	if hook == nil {
+		_rethrowHook.Store(nil)
+	} else {
+		_rethrowHook.Store(&hook)
+	}
+}
+
+// RethrowPanic panics with the original value of the [PanicError] held by
+// err, if any, if rethrow is true or if panics are rethrown by default
+// (see [SetRethrowPanics]).
+// The hook installed with [SetRethrowPanicHook], if any, is called first.
+//
+// This is intended to be used by cff's generated code.
+// Do not use directly.
+// This can change without warning.
+func RethrowPanic(err error, rethrow bool) {
+	if !rethrow && !_rethrowPanics.Load() {
+		return
+	}
+
+	var pe *PanicError
+	if errors.As(err, &pe) {
+		if hook := _rethrowHook.Load(); hook != nil {
+			(*hook)(pe)
+		}
+		panic(pe.Value)
+	}
+}
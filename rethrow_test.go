@@ -0,0 +1,77 @@
+package cff
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type customPanic struct{ msg string }
+
+func TestRethrowPanic(t *testing.T) {
+	pe := &PanicError{Value: "great sadness", Stacktrace: []byte("stack")}
+
+	t.Run("disabled", func(t *testing.T) {
+		assert.NotPanics(t, func() {
+			RethrowPanic(pe, false)
+		})
+	})
+
+	t.Run("enabled", func(t *testing.T) {
+		assert.PanicsWithValue(t, "great sadness", func() {
+			RethrowPanic(fmt.Errorf("wrapped: %w", pe), true)
+		})
+	})
+
+	t.Run("hook", func(t *testing.T) {
+		var got *PanicError
+		SetRethrowPanicHook(func(pe *PanicError) { got = pe })
+		defer SetRethrowPanicHook(nil)
+
+		assert.PanicsWithValue(t, "great sadness", func() {
+			RethrowPanic(fmt.Errorf("wrapped: %w", pe), true)
+		})
+		assert.Same(t, pe, got, "hook must receive the stack of the task")
+	})
+
+	t.Run("hook disabled", func(t *testing.T) {
+		called := false
+		SetRethrowPanicHook(func(*PanicError) { called = true })
+		defer SetRethrowPanicHook(nil)
+
+		RethrowPanic(pe, false)
+		assert.False(t, called, "hook must not be called if not rethrown")
+	})
+
+	t.Run("original value", func(t *testing.T) {
+		want := customPanic{msg: "great sadness"}
+
+		var got interface{}
+		func() {
+			defer func() { got = recover() }()
+			RethrowPanic(&PanicError{Value: want}, true)
+		}()
+
+		v, ok := got.(customPanic)
+		if assert.True(t, ok, "expected customPanic, got %T", got) {
+			assert.Equal(t, want, v)
+		}
+	})
+
+	t.Run("not a panic", func(t *testing.T) {
+		assert.NotPanics(t, func() {
+			RethrowPanic(errors.New("great sadness"), true)
+		})
+	})
+
+	t.Run("default", func(t *testing.T) {
+		SetRethrowPanics(true)
+		defer SetRethrowPanics(false)
+
+		assert.PanicsWithValue(t, "great sadness", func() {
+			RethrowPanic(pe, false)
+		})
+	})
+}
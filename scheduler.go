@@ -26,6 +26,13 @@ type AtomicBool = atomic.Bool
 // TODO(abg): For Go 1.19 or newer, we can use sync/atomic.Bool
 // which drops one more dependency for users.
 
+// JobGroup limits the number of jobs that run concurrently.
+//
+// This is intended to be used by cff's generated code.
+// Do not use directly.
+// This can change without warning.
+type JobGroup = scheduler.Group
+
 // ScheduledJob is a job that has been scheduled for execution with the cff
 // scheduler.
 //
@@ -34,6 +41,15 @@ type AtomicBool = atomic.Bool
 // This can change without warning.
 type ScheduledJob = scheduler.ScheduledJob
 
+// ErrNotSucceeded is returned by tasks of a Parallel with a quorum
+// that finished without failing, but that must not count towards
+// the quorum because they were skipped or recovered from a failure.
+//
+// This is intended to be used by cff's generated code.
+// Do not use directly.
+// This can change without warning.
+var ErrNotSucceeded = scheduler.ErrNotSucceeded
+
 // SchedulerParams configures the cff scheduler.
 //
 // This is intended to be used by cff's generated code.
@@ -48,6 +64,12 @@ type SchedulerParams struct {
 	// ContinueOnError when true directs the scheduler to continue running
 	// through job errors.
 	ContinueOnError bool
+	// Limiter, if non-nil, limits the rate at which the scheduler runs
+	// jobs.
+	Limiter Limiter
+	// Quorum, if positive, is the number of jobs that must succeed for the
+	// scheduler to stop with success without running the remaining jobs.
+	Quorum int
 }
 
 // NewScheduler starts up a cff scheduler for use by Flow or Parallel.
@@ -66,6 +88,8 @@ func NewScheduler(p SchedulerParams) *scheduler.Scheduler {
 		Concurrency:     p.Concurrency,
 		Emitter:         adaptSchedulerEmitter(p.Emitter),
 		ContinueOnError: p.ContinueOnError,
+		Limiter:         p.Limiter,
+		Quorum:          p.Quorum,
 	}
 	return cfg.New()
 }
@@ -27,4 +27,23 @@ type State struct {
 	// Concurrency is the number of workers the scheduler can process tasks
 	// with.
 	Concurrency int
+
+	// Groups reports the occupancy of the named Groups of jobs
+	// enqueued with the scheduler, in the order they were first used.
+	Groups []GroupState
+}
+
+// GroupState describes the occupancy of a named Group of jobs.
+type GroupState struct {
+	// Name of the group.
+	Name string
+
+	// Running is the number of jobs of the group that are executing.
+	// If this is consistently equal to Concurrency, jobs of this group
+	// are waiting for each other: consider raising the limit.
+	Running int
+
+	// Concurrency is the maximum number of jobs of the group that may
+	// run at the same time. Values less than 1 impose no limit.
+	Concurrency int
 }
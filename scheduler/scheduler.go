@@ -33,13 +33,20 @@
 //
 // If any of the enqueued jobs failed,
 // the remaining jobs will be aborted and Wait will return the error.
-// This may be changed by setting [Config].ContinueOnError.
+// This may be changed by setting [Config].ContinueOnError,
+// or [Job].ContinueOnError for individual jobs.
+//
+// To limit how many of a set of jobs run at the same time,
+// enqueue them with the same [Group].
+//
+// To stop as soon as enough jobs have succeeded, set [Config].Quorum.
 package scheduler
 
 import (
 	"container/list"
 	"context"
 	"errors"
+	"fmt"
 	"runtime"
 	"time"
 
@@ -60,7 +67,7 @@ const (
 // IMPLEMENTATION NOTES
 // --------------------
 //
-// There are three kinds of goroutines at play here.
+// There are four kinds of goroutines at play here.
 //
 // Caller
 //   This is the goroutine that calls scheduler.New(n), Scheduler.Enqueue,
@@ -78,6 +85,12 @@ const (
 //   to be executed, posts them to workers, and processes results coming from
 //   these workers.
 //
+// Limiter Waiters
+//   Jobs that have a Limiter wait for it on a goroutine of their own
+//   before the Scheduler Loop considers them ready, so that they don't
+//   occupy a worker while they wait. These post jobs back to the
+//   Scheduler Loop through the admittedc channel.
+//
 // We can keep the core scheduler logic lockless because all state management
 // is deferred to the same goroutine: the Scheduler Loop. DO NOT read or write
 // internal state outside that goroutine.
@@ -118,6 +131,15 @@ type jobResult struct {
 // because their dependencies have errored or are marked invalid.
 var errJobInvalid = errors.New("job invalid")
 
+// ErrNotSucceeded may be returned by a job that finished without failing
+// but that must not count towards the [Config].Quorum,
+// for example because it chose not to do its work,
+// or because it recovered from a failure.
+//
+// The scheduler treats such a job as neither failed nor succeeded:
+// jobs that depend on it still run, and Wait doesn't report it.
+var ErrNotSucceeded = errors.New("job did not succeed")
+
 // worker implements the logic for a worker goroutine. Workers may read from
 // the ScheduledJob but they MUST NOT modify it. All output from workers should
 // be sent up to the scheduler via jobResult. The scheduler loop is the only
@@ -148,6 +170,10 @@ func worker(readyc <-chan *ScheduledJob, donec chan<- jobResult) {
 		} else if j.invalid {
 			// Don't run if marked as invalid.
 			res.Err = errJobInvalid
+		} else if j.limitErr != nil {
+			// Don't run if the context ended while waiting for
+			// the limiters.
+			res.Err = j.limitErr
 		} else {
 			res.Err = j.run(j.ctx)
 		}
@@ -157,6 +183,36 @@ func worker(readyc <-chan *ScheduledJob, donec chan<- jobResult) {
 	exitCleanly = true
 }
 
+// limiterWaiter implements the logic for the goroutine that waits for the
+// limiters of a job before the job may be posted to workers.
+// It runs outside the workers so that jobs waiting for their limiters
+// don't occupy any of them.
+//
+// Like workers, it MUST NOT modify the ScheduledJob.
+// It posts the outcome of the wait to admittedc,
+// unless the Scheduler Loop has exited.
+func limiterWaiter(j *ScheduledJob, sched Limiter, admittedc chan<- jobResult, finishedc <-chan struct{}) {
+	res := jobResult{Job: j, Err: waitLimiters(j.ctx, sched, j.limiter)}
+	select {
+	case admittedc <- res:
+	case <-finishedc:
+	}
+}
+
+// waitLimiters waits for the scheduler's limiter and then for the job's
+// limiter, skipping those that are nil.
+func waitLimiters(ctx context.Context, sched, job Limiter) error {
+	if sched != nil {
+		if err := sched.Wait(ctx); err != nil {
+			return err
+		}
+	}
+	if job != nil {
+		return job.Wait(ctx)
+	}
+	return nil
+}
+
 // Scheduler schedules jobs for a cff flow or parallel.
 type Scheduler struct {
 	// Closed when the Scheduler Loop exits.
@@ -179,6 +235,13 @@ type Scheduler struct {
 	// Workers post results of executed jobs to this channel.
 	donec <-chan jobResult
 
+	// Limits the rate at which all jobs run, if non-nil.
+	limiter Limiter
+
+	// Goroutines waiting for the limiters of jobs post jobs that may run
+	// to this channel.
+	admittedc chan jobResult
+
 	// Concurrency is the number of workers the scheduler can process tasks
 	// with.
 	concurrency int
@@ -186,6 +249,10 @@ type Scheduler struct {
 	// If true when a job fails, directs the scheduler to record its failure,
 	// invalidate all jobs that depend on the failed job, and keep running.
 	continueOnError bool
+
+	// If positive, number of jobs that must succeed for the scheduler to
+	// stop with success.
+	quorum int
 }
 
 // Config stores parameters the scheduler should run with and is the
@@ -209,6 +276,52 @@ type Config struct {
 	// record its failure, invalidate all jobs that depend on the failed job,
 	// and keep running.
 	ContinueOnError bool
+
+	// Limiter, if non-nil, limits the rate at which the jobs of the
+	// scheduler run.
+	// This is independent of Concurrency: jobs wait for the limiter
+	// before they're posted to workers,
+	// so jobs that are waiting don't occupy any of them.
+	Limiter Limiter
+
+	// Quorum, if positive, directs the scheduler to stop with success
+	// as soon as this many jobs have succeeded,
+	// without running the remaining jobs.
+	// Jobs that return ErrNotSucceeded don't count as successes.
+	// Failed jobs don't stop the scheduler
+	// until it's no longer possible for enough jobs to succeed;
+	// Wait then returns a *QuorumError.
+	Quorum int
+}
+
+// QuorumError is returned by [Scheduler.Wait]
+// if fewer jobs succeeded than the [Config].Quorum.
+type QuorumError struct {
+	Succeeded int // number of jobs that succeeded
+	Quorum    int // number of jobs that had to succeed
+
+	// Err combines the errors of jobs that failed, if any.
+	Err error
+}
+
+func (e *QuorumError) Error() string {
+	msg := fmt.Sprintf("quorum not reached: %d of %d tasks succeeded", e.Succeeded, e.Quorum)
+	if e.Err != nil {
+		msg += ": " + e.Err.Error()
+	}
+	return msg
+}
+
+// Unwrap returns the errors of the jobs that failed, if any.
+func (e *QuorumError) Unwrap() error {
+	return e.Err
+}
+
+// Limiter limits the rate at which jobs run.
+type Limiter interface {
+	// Wait blocks until a job may run.
+	// It fails if the context ends first.
+	Wait(ctx context.Context) error
 }
 
 // New starts a scheduler with a fixed number of goroutines.
@@ -259,9 +372,12 @@ func (c Config) New() *Scheduler {
 		enqueuec:        enqueuec,
 		readyc:          readyc,
 		donec:           donec,
+		limiter:         c.Limiter,
+		admittedc:       make(chan jobResult),
 		finishedc:       make(chan struct{}),
 		concurrency:     c.Concurrency,
 		continueOnError: c.ContinueOnError,
+		quorum:          c.Quorum,
 	}
 
 	// We lie to the caller about the number of goroutines. Spawn one
@@ -280,6 +396,65 @@ type Job struct {
 	// Dependencies are previously enqueued jobs that must run before this
 	// job.
 	Dependencies []*ScheduledJob
+
+	// ContinueOnError, if true when this job fails, directs the scheduler
+	// to record its failure, invalidate all jobs that depend on it,
+	// and keep running, even if the scheduler was not configured to
+	// continue on errors.
+	ContinueOnError bool
+
+	// AlwaysRun, if true, runs this job once its dependencies have
+	// finished even if some of them failed or were invalidated,
+	// instead of invalidating it.
+	//
+	// This has an effect only if the scheduler keeps running after
+	// the failure of a dependency: with ContinueOnError.
+	AlwaysRun bool
+
+	// Groups limit the number of jobs that run concurrently with this job.
+	// The job runs only while none of its groups is running as many jobs
+	// as it may.
+	Groups []*Group
+
+	// Limiter, if non-nil, limits the rate at which this job and other
+	// jobs that share the limiter run,
+	// in addition to the Limiter of the scheduler.
+	Limiter Limiter
+}
+
+// Group limits the number of jobs that run concurrently.
+// Jobs that share a Group run at most Concurrency at a time,
+// in addition to the limit imposed by the scheduler's concurrency.
+//
+// A Group may be used only by jobs of a single scheduler.
+type Group struct {
+	// Name identifies the group in the State reported to the Emitter.
+	// Only named groups are reported.
+	Name string
+
+	// Concurrency is the maximum number of jobs of the group that may run
+	// at the same time. Values less than 1 impose no limit.
+	Concurrency int
+
+	// Number of jobs of the group that are executing.
+	// This may be read or written only by the Scheduler Loop.
+	running int
+}
+
+// full reports whether the group is running as many jobs as it may.
+func (g *Group) full() bool {
+	return g.Concurrency > 0 && g.running >= g.Concurrency
+}
+
+// anyFull reports whether any of the groups is running as many jobs as
+// it may.
+func anyFull(groups []*Group) bool {
+	for _, g := range groups {
+		if g.full() {
+			return true
+		}
+	}
+	return false
 }
 
 // ScheduledJob is a job that has been scheduled for execution by the
@@ -288,9 +463,13 @@ type ScheduledJob struct {
 	// The following fields are initialized in Scheduler.Enqueue. These
 	// are read-only. They MUST NOT be changed once initialized.
 
-	ctx  context.Context
-	run  func(context.Context) error
-	deps []*ScheduledJob
+	ctx             context.Context
+	run             func(context.Context) error
+	deps            []*ScheduledJob
+	continueOnError bool
+	alwaysRun       bool
+	groups          []*Group
+	limiter         Limiter
 
 	// The following fields track the internal state of the job. These are
 	// read-write, but only within Scheduler.run. DO NOT read or write
@@ -301,6 +480,7 @@ type ScheduledJob struct {
 	done      bool            // whether this was run, regardless of success or failure
 	err       error           // the job error, if encountered when the job ran
 	invalid   bool            // whether the job is marked invalid and should not run
+	limitErr  error           // failure waiting for the limiters, if any
 
 	// NOTE: DO NOT add methods to ScheduledJob. There's danger of using
 	// methods that read or write internal state outside the Scheduler.run
@@ -318,9 +498,13 @@ func (s *Scheduler) Enqueue(ctx context.Context, j Job) *ScheduledJob {
 	// places a partially initialized object into the enqueuec channel,
 	// and the Scheduler Loop initializes the rest of it.
 	pj := &ScheduledJob{
-		ctx:  ctx,
-		run:  j.Run,
-		deps: j.Dependencies,
+		ctx:             ctx,
+		run:             j.Run,
+		deps:            j.Dependencies,
+		continueOnError: j.ContinueOnError,
+		alwaysRun:       j.AlwaysRun,
+		groups:          j.Groups,
+		limiter:         j.Limiter,
 	}
 	s.enqueuec <- pj // panics if closed
 	return pj
@@ -384,12 +568,34 @@ func (s *Scheduler) run(emitter Emitter, freq time.Duration) {
 	// Number of jobs waiting for other jobs to finish.
 	waiting := 0
 
+	// admit moves a job with no outstanding dependencies to `ready`,
+	// first waiting for its limiters, if any, on a separate goroutine.
+	admit := func(job *ScheduledJob) {
+		if job.invalid || (s.limiter == nil && job.limiter == nil) {
+			ready.PushBack(job)
+			return
+		}
+		go limiterWaiter(job, s.limiter, s.admittedc, s.finishedc)
+	}
+
+	// Number of jobs that succeeded. Tracked only with a quorum.
+	succeeded := 0
+
+	// Named groups of enqueued jobs in the order they were first seen.
+	// These are reported to the emitter.
+	var (
+		namedGroups    []*Group
+		seenNamedGroup = make(map[*Group]struct{})
+	)
+
 	// Tracks whether we're still expecting new Enqueue calls. After this
 	// is set to nil, we don't expect new Enqueue requests.
 	enqueuec := s.enqueuec
 
 	for {
 		// If there's at least one job ready to be executed, grab it.
+		// Jobs with a full group are skipped until a job of that
+		// group finishes.
 		// If no jobs are ready, this leaves `readyc` as nil. Trying
 		// to insert into a nil channel never resolves so the select
 		// will never pick that path.
@@ -398,10 +604,13 @@ func (s *Scheduler) run(emitter Emitter, freq time.Duration) {
 			nextEl *list.Element
 			next   *ScheduledJob
 		)
-		if ready.Len() > 0 {
-			nextEl = ready.Front()
-			next = nextEl.Value.(*ScheduledJob)
-		} else {
+		for el := ready.Front(); el != nil; el = el.Next() {
+			if job := el.Value.(*ScheduledJob); !anyFull(job.groups) {
+				nextEl, next = el, job
+				break
+			}
+		}
+		if next == nil {
 			readyc = nil
 		}
 
@@ -412,6 +621,9 @@ func (s *Scheduler) run(emitter Emitter, freq time.Duration) {
 			ready.Remove(nextEl)
 
 			ongoing++
+			for _, g := range next.groups {
+				g.running++
+			}
 
 		case job, ok := <-enqueuec:
 			// Wait was called and the enqueue channel was closed.
@@ -426,10 +638,10 @@ func (s *Scheduler) run(emitter Emitter, freq time.Duration) {
 			// unless they've already been run.
 			//
 			// If a dependency has already run and errored, mark the job as
-			// invalid.
+			// invalid unless it runs regardless.
 			for _, dep := range job.deps {
 				if dep.done {
-					if dep.err != nil {
+					if dep.err != nil && !job.alwaysRun {
 						job.invalid = true
 					}
 					continue
@@ -438,11 +650,18 @@ func (s *Scheduler) run(emitter Emitter, freq time.Duration) {
 				job.remaining++
 			}
 
+			for _, g := range job.groups {
+				if _, seen := seenNamedGroup[g]; g.Name != "" && !seen {
+					seenNamedGroup[g] = struct{}{}
+					namedGroups = append(namedGroups, g)
+				}
+			}
+
 			pending++
 
 			// No outstanding dependencies. Ready to run.
 			if job.remaining == 0 {
-				ready.PushBack(job)
+				admit(job)
 			} else {
 				waiting++
 			}
@@ -453,23 +672,46 @@ func (s *Scheduler) run(emitter Emitter, freq time.Duration) {
 
 			pending--
 			ongoing--
+			for _, g := range job.groups {
+				g.running--
+			}
+
+			// Jobs that didn't succeed didn't fail either.
+			notSucceeded := errors.Is(res.Err, ErrNotSucceeded)
+			if notSucceeded {
+				res.Err = nil
+			}
+
+			if res.Err == nil && !notSucceeded && s.quorum > 0 {
+				succeeded++
+				if succeeded >= s.quorum {
+					// Enough jobs succeeded: failures of other jobs
+					// don't matter.
+					s.err = nil
+					return
+				}
+			}
 
 			if err := res.Err; err != nil {
 				job.err = err
+				invalid := errors.Is(err, errJobInvalid)
 
 				// Record the failure and return early if the job
-				// failed.
-				if !s.continueOnError {
+				// failed, unless other jobs may still reach
+				// the quorum.
+				if !s.continueOnError && !job.continueOnError && !invalid && s.quorum == 0 {
 					s.err = err
 					return
 				}
 				// With continueOnError, mark invalid directly dependent jobs,
 				// append non-sentinel errors, and continue the scheduler loop.
-				if !errors.Is(err, errJobInvalid) {
+				if !invalid {
 					s.err = multierr.Append(s.err, err)
 				}
 				for _, consumer := range job.consumers {
-					consumer.invalid = true
+					if !consumer.alwaysRun {
+						consumer.invalid = true
+					}
 				}
 			}
 
@@ -480,10 +722,17 @@ func (s *Scheduler) run(emitter Emitter, freq time.Duration) {
 				consumer.remaining--
 				if consumer.remaining == 0 {
 					waiting--
-					ready.PushBack(consumer)
+					admit(consumer)
 				}
 			}
 
+		case res := <-s.admittedc:
+			// The job's limiters allow it to run, or the context
+			// ended while waiting for them.
+			// Workers fail the job without running it in that case.
+			res.Job.limitErr = res.Err
+			ready.PushBack(res.Job)
+
 		case <-tickerC:
 			// If emitter is nil, tickerC will be a nil channel that
 			// never resolves.
@@ -496,10 +745,22 @@ func (s *Scheduler) run(emitter Emitter, freq time.Duration) {
 					Waiting:     waiting,
 					IdleWorkers: idleWorkers(s.concurrency, ongoing),
 					Concurrency: s.concurrency,
+					Groups:      groupStates(namedGroups),
 				},
 			)
 		}
 
+		// Once no new enqueues are allowed, exit if the remaining jobs
+		// cannot reach the quorum even if they all succeed.
+		if s.quorum > 0 && enqueuec == nil && succeeded+pending < s.quorum {
+			s.err = &QuorumError{
+				Succeeded: succeeded,
+				Quorum:    s.quorum,
+				Err:       s.err,
+			}
+			return
+		}
+
 		// If all enqueued jobs have been finished and no new enqueues
 		// are allowed, we can exit.
 		if pending == 0 && enqueuec == nil {
@@ -508,6 +769,24 @@ func (s *Scheduler) run(emitter Emitter, freq time.Duration) {
 	}
 }
 
+// groupStates reports the state of the given groups.
+// It returns nil if there are no groups.
+func groupStates(groups []*Group) []GroupState {
+	if len(groups) == 0 {
+		return nil
+	}
+
+	states := make([]GroupState, len(groups))
+	for i, g := range groups {
+		states[i] = GroupState{
+			Name:        g.Name,
+			Running:     g.running,
+			Concurrency: g.Concurrency,
+		}
+	}
+	return states
+}
+
 // Wait waits for all scheduled jobs to finish by default it returns the first
 // error encountered, if any.
 //
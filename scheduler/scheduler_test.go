@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"reflect"
 	"runtime"
 	"sync"
 	"sync/atomic"
@@ -299,6 +300,411 @@ func TestScheduler_ContinueOnError(t *testing.T) {
 	})
 }
 
+func TestScheduler_JobContinueOnError(t *testing.T) {
+	t.Parallel()
+
+	sched := Config{Concurrency: 1}.New()
+
+	failed := sched.Enqueue(context.Background(), Job{
+		Run: func(context.Context) error {
+			return errors.New("sad times")
+		},
+		ContinueOnError: true,
+	})
+
+	// Depends on the failed job, so it must not run.
+	var consumerRan atomic.Bool
+	consumer := sched.Enqueue(context.Background(), Job{
+		Run: func(context.Context) error {
+			consumerRan.Store(true)
+			return nil
+		},
+		Dependencies: []*ScheduledJob{failed},
+	})
+
+	// Invalidated transitively.
+	var transitiveRan atomic.Bool
+	sched.Enqueue(context.Background(), Job{
+		Run: func(context.Context) error {
+			transitiveRan.Store(true)
+			return nil
+		},
+		Dependencies: []*ScheduledJob{consumer},
+	})
+
+	// Independent of the failed job, so the scheduler keeps running it.
+	var independentRan atomic.Bool
+	sched.Enqueue(context.Background(), Job{
+		Run: func(context.Context) error {
+			independentRan.Store(true)
+			return nil
+		},
+	})
+
+	err := sched.Wait(context.Background())
+	assert.EqualError(t, err, "sad times")
+	assert.False(t, consumerRan.Load(), "consumer must not run")
+	assert.False(t, transitiveRan.Load(), "transitive consumer must not run")
+	assert.True(t, independentRan.Load(), "independent job must run")
+}
+
+func TestScheduler_AlwaysRun(t *testing.T) {
+	t.Parallel()
+
+	sched := Config{Concurrency: 2, ContinueOnError: true}.New()
+
+	failed := sched.Enqueue(context.Background(), Job{
+		Run: func(context.Context) error {
+			return errors.New("sad times")
+		},
+	})
+	invalid := sched.Enqueue(context.Background(), Job{
+		Run: func(context.Context) error {
+			return nil
+		},
+		Dependencies: []*ScheduledJob{failed},
+	})
+	succeeded := sched.Enqueue(context.Background(), Job{
+		Run: func(context.Context) error {
+			return nil
+		},
+	})
+
+	// Runs even though one dependency failed and another was invalidated.
+	var endRan atomic.Bool
+	sched.Enqueue(context.Background(), Job{
+		Run: func(context.Context) error {
+			endRan.Store(true)
+			return nil
+		},
+		Dependencies: []*ScheduledJob{failed, invalid, succeeded},
+		AlwaysRun:    true,
+	})
+
+	err := sched.Wait(context.Background())
+	assert.EqualError(t, err, "sad times")
+	assert.True(t, endRan.Load(), "job must run")
+}
+
+func TestScheduler_Group(t *testing.T) {
+	t.Parallel()
+
+	const (
+		numJobs   = 10
+		groupSize = 2
+	)
+
+	sched := Config{Concurrency: numJobs}.New()
+	group := &Group{Concurrency: groupSize}
+
+	var running, maxRunning atomic.Int32
+	for i := 0; i < numJobs; i++ {
+		sched.Enqueue(context.Background(), Job{
+			Run: func(context.Context) error {
+				n := running.Add(1)
+				defer running.Add(-1)
+				for {
+					max := maxRunning.Load()
+					if n <= max || maxRunning.CompareAndSwap(max, n) {
+						break
+					}
+				}
+				time.Sleep(time.Millisecond)
+				return nil
+			},
+			Groups: []*Group{group},
+		})
+	}
+
+	// Jobs outside the group are not limited by it.
+	var ungroupedRan atomic.Bool
+	sched.Enqueue(context.Background(), Job{
+		Run: func(context.Context) error {
+			ungroupedRan.Store(true)
+			return nil
+		},
+	})
+
+	assert.NoError(t, sched.Wait(context.Background()))
+	assert.LessOrEqual(t, maxRunning.Load(), int32(groupSize),
+		"no more than %v jobs of the group may run at once", groupSize)
+	assert.True(t, ungroupedRan.Load())
+}
+
+func TestScheduler_GroupsOverlap(t *testing.T) {
+	t.Parallel()
+
+	sched := Config{Concurrency: 4}.New()
+	a := &Group{Concurrency: 1}
+	b := &Group{Concurrency: 1}
+
+	// The job in both groups must not run alongside the job of either.
+	var running, maxRunning atomic.Int32
+	run := func(context.Context) error {
+		n := running.Add(1)
+		defer running.Add(-1)
+		for {
+			max := maxRunning.Load()
+			if n <= max || maxRunning.CompareAndSwap(max, n) {
+				break
+			}
+		}
+		time.Sleep(time.Millisecond)
+		return nil
+	}
+	sched.Enqueue(context.Background(), Job{Run: run, Groups: []*Group{a}})
+	sched.Enqueue(context.Background(), Job{Run: run, Groups: []*Group{a, b}})
+	sched.Enqueue(context.Background(), Job{Run: run, Groups: []*Group{b}})
+
+	assert.NoError(t, sched.Wait(context.Background()))
+	assert.LessOrEqual(t, maxRunning.Load(), int32(2))
+}
+
+// Test that the scheduler reports the occupancy of named groups.
+func TestScheduler_EmitGroups(t *testing.T) {
+	t.Parallel()
+
+	emitter, statec := newChannelEmitter()
+
+	sched := Config{
+		Concurrency:         2,
+		Emitter:             emitter,
+		StateFlushFrequency: time.Millisecond,
+	}.New()
+
+	db := &Group{Name: "db", Concurrency: 1}
+	unnamed := &Group{Concurrency: 2}
+
+	blockerA := newBlocker()
+	sched.Enqueue(context.Background(), Job{
+		Run:    blockerA.Run,
+		Groups: []*Group{unnamed, db},
+	})
+	blockerA.AwaitRunning()
+
+	// B is ready but waits for A to free the group.
+	blockerB := newBlocker()
+	sched.Enqueue(context.Background(), Job{
+		Run:    blockerB.Run,
+		Groups: []*Group{db},
+	})
+
+	assert.Equal(t, State{
+		Pending:     2,
+		Ready:       1,
+		IdleWorkers: 1,
+		Concurrency: 2,
+		Groups: []GroupState{
+			{Name: "db", Running: 1, Concurrency: 1},
+		},
+	}, awaitStableState(t, statec))
+
+	blockerA.UnblockAndWait()
+	blockerB.AwaitRunning()
+	blockerB.UnblockAndWait()
+
+	assert.NoError(t, sched.Wait(context.Background()))
+}
+
+func TestScheduler_Quorum(t *testing.T) {
+	t.Parallel()
+
+	sched := Config{Concurrency: 3, Quorum: 1}.New()
+
+	// Neither a failure nor a job that doesn't finish stops the
+	// scheduler from succeeding once enough jobs have.
+	blocker := newBlocker()
+	defer blocker.UnblockAndWait()
+	sched.Enqueue(context.Background(), Job{Run: blocker.Run})
+	sched.Enqueue(context.Background(), Job{
+		Run: func(context.Context) error {
+			return errors.New("great sadness")
+		},
+	})
+	sched.Enqueue(context.Background(), Job{
+		Run: func(context.Context) error { return nil },
+	})
+
+	assert.NoError(t, sched.Wait(context.Background()))
+}
+
+func TestScheduler_QuorumNotReached(t *testing.T) {
+	t.Parallel()
+
+	sched := Config{Concurrency: 1, Quorum: 2}.New()
+	sched.Enqueue(context.Background(), Job{
+		Run: func(context.Context) error { return nil },
+	})
+	sched.Enqueue(context.Background(), Job{
+		Run: func(context.Context) error {
+			return errors.New("great sadness")
+		},
+	})
+
+	err := sched.Wait(context.Background())
+	var qerr *QuorumError
+	if assert.ErrorAs(t, err, &qerr) {
+		assert.Equal(t, 1, qerr.Succeeded)
+		assert.Equal(t, 2, qerr.Quorum)
+	}
+	assert.EqualError(t, err, "quorum not reached: 1 of 2 tasks succeeded: great sadness")
+}
+
+func TestScheduler_QuorumNotSucceeded(t *testing.T) {
+	t.Parallel()
+
+	sched := Config{Concurrency: 1, Quorum: 1}.New()
+	notSucceeded := sched.Enqueue(context.Background(), Job{
+		Run: func(context.Context) error { return ErrNotSucceeded },
+	})
+	var dependentRan atomic.Bool
+	sched.Enqueue(context.Background(), Job{
+		Run: func(context.Context) error {
+			dependentRan.Store(true)
+			return errors.New("great sadness")
+		},
+		Dependencies: []*ScheduledJob{notSucceeded},
+	})
+
+	err := sched.Wait(context.Background())
+	assert.EqualError(t, err, "quorum not reached: 0 of 1 tasks succeeded: great sadness",
+		"job that didn't succeed must not count towards the quorum")
+	assert.True(t, dependentRan.Load(), "jobs that depend on it must run")
+}
+
+func TestScheduler_NotSucceededWithoutQuorum(t *testing.T) {
+	t.Parallel()
+
+	sched := Config{Concurrency: 1}.New()
+	sched.Enqueue(context.Background(), Job{
+		Run: func(context.Context) error { return ErrNotSucceeded },
+	})
+	assert.NoError(t, sched.Wait(context.Background()))
+}
+
+func TestScheduler_QuorumTooFewJobs(t *testing.T) {
+	t.Parallel()
+
+	sched := Config{Quorum: 3}.New()
+	release := make(chan struct{})
+	defer close(release)
+	sched.Enqueue(context.Background(), Job{
+		Run: func(context.Context) error {
+			<-release
+			return nil
+		},
+	})
+
+	// Wait fails without waiting for the job
+	// because the quorum cannot be reached.
+	assert.EqualError(t, sched.Wait(context.Background()),
+		"quorum not reached: 0 of 3 tasks succeeded")
+}
+
+func TestScheduler_Limiter(t *testing.T) {
+	t.Parallel()
+
+	var schedLimiter, jobLimiter countingLimiter
+	sched := Config{Concurrency: 2, Limiter: &schedLimiter}.New()
+
+	var ran atomic.Int32
+	for i := 0; i < 3; i++ {
+		sched.Enqueue(context.Background(), Job{
+			Run: func(context.Context) error {
+				ran.Add(1)
+				return nil
+			},
+		})
+	}
+	sched.Enqueue(context.Background(), Job{
+		Run: func(context.Context) error {
+			ran.Add(1)
+			return nil
+		},
+		Limiter: &jobLimiter,
+	})
+
+	assert.NoError(t, sched.Wait(context.Background()))
+	assert.Equal(t, int32(4), ran.Load())
+	assert.Equal(t, int32(4), schedLimiter.calls.Load(), "scheduler limiter applies to all jobs")
+	assert.Equal(t, int32(1), jobLimiter.calls.Load(), "job limiter applies to its job")
+}
+
+func TestScheduler_LimiterFails(t *testing.T) {
+	t.Parallel()
+
+	sched := Config{Concurrency: 1}.New()
+	sched.Enqueue(context.Background(), Job{
+		Run: func(context.Context) error {
+			t.Error("job must not run")
+			return nil
+		},
+		Limiter: &countingLimiter{err: errors.New("sad times")},
+	})
+	assert.EqualError(t, sched.Wait(context.Background()), "sad times")
+}
+
+func TestScheduler_LimiterDoesNotOccupyWorkers(t *testing.T) {
+	t.Parallel()
+
+	// The only worker must remain free to run other jobs
+	// while a job waits for its limiter.
+	sched := Config{Concurrency: 1}.New()
+
+	limiter := make(chanLimiter)
+	var limitedRan atomic.Bool
+	sched.Enqueue(context.Background(), Job{
+		Run: func(context.Context) error {
+			limitedRan.Store(true)
+			return nil
+		},
+		Limiter: limiter,
+	})
+
+	unlimitedRan := make(chan struct{})
+	sched.Enqueue(context.Background(), Job{
+		Run: func(context.Context) error {
+			close(unlimitedRan)
+			return nil
+		},
+	})
+
+	select {
+	case <-unlimitedRan:
+	case <-time.After(5 * time.Second):
+		t.Fatal("unlimited job was blocked by the rate-limited job")
+	}
+	assert.False(t, limitedRan.Load(), "rate-limited job must not run before the limiter allows it")
+
+	close(limiter)
+	assert.NoError(t, sched.Wait(context.Background()))
+	assert.True(t, limitedRan.Load())
+}
+
+// chanLimiter is a Limiter that blocks until it's closed.
+type chanLimiter chan struct{}
+
+func (l chanLimiter) Wait(ctx context.Context) error {
+	select {
+	case <-l:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// countingLimiter is a Limiter that counts calls to Wait,
+// failing with err if it's non-nil.
+type countingLimiter struct {
+	calls atomic.Int32
+	err   error
+}
+
+func (l *countingLimiter) Wait(context.Context) error {
+	l.calls.Add(1)
+	return l.err
+}
+
 func TestScheduler_Wait(t *testing.T) {
 	t.Parallel()
 
@@ -672,7 +1078,7 @@ attempt:
 	for i := 0; i < maxAttempts; i++ {
 		for run := 1; run < stableN; run++ {
 			s := <-ch
-			if prevState != s {
+			if !reflect.DeepEqual(prevState, s) {
 				prevState = s
 				continue attempt
 			}
@@ -0,0 +1,239 @@
+package cff
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// SkipKind identifies why a task was skipped.
+type SkipKind int
+
+const (
+	// SkipPredicateFalse marks tasks that were skipped because their
+	// [Predicate] returned false,
+	// or tasks of a [Switch] whose case was not selected.
+	SkipPredicateFalse SkipKind = iota + 1
+
+	// SkipNotProduced marks tasks that were skipped because a value they
+	// depend on was not produced.
+	// The SkipReason for these tasks wraps [ErrNotProduced].
+	SkipNotProduced
+
+	// SkipDependencyFailed marks tasks that were skipped because a task
+	// they depend on failed.
+	SkipDependencyFailed
+
+	// SkipDependencySkipped marks tasks that were skipped because a task
+	// they depend on did not run.
+	SkipDependencySkipped
+
+	// SkipContextCancelled marks tasks that were skipped because the
+	// context was cancelled before they could run.
+	SkipContextCancelled
+
+	// SkipFlowAborted marks tasks that were skipped because the [Flow] or
+	// [Parallel] stopped running tasks after an unrelated task failed.
+	SkipFlowAborted
+
+	// SkipQuorumReached marks tasks that were skipped because enough
+	// other tasks of a [Parallel] succeeded before they started.
+	// Tasks that were already running aren't skipped.
+	// See [Quorum].
+	SkipQuorumReached
+)
+
+// String returns a short description of the kind of skip.
+func (k SkipKind) String() string {
+	switch k {
+	case SkipPredicateFalse:
+		return "predicate false"
+	case SkipNotProduced:
+		return "not produced"
+	case SkipDependencyFailed:
+		return "dependency failed"
+	case SkipDependencySkipped:
+		return "dependency skipped"
+	case SkipContextCancelled:
+		return "context cancelled"
+	case SkipFlowAborted:
+		return "flow aborted"
+	case SkipQuorumReached:
+		return "quorum reached"
+	}
+	return "unknown"
+}
+
+// SkipReason describes why a task was skipped.
+// It is the error passed to [TaskEmitter].TaskSkipped.
+//
+//	func (e *myTaskEmitter) TaskSkipped(ctx context.Context, err error) {
+//		var reason *cff.SkipReason
+//		if errors.As(err, &reason) && reason.Expected() {
+//			e.expectedSkips.Inc()
+//		} else {
+//			e.skips.Inc()
+//		}
+//	}
+type SkipReason struct {
+	Kind SkipKind
+
+	// Dependency is the task whose failure or skip caused this task to
+	// be skipped.
+	// It is set only for SkipDependencyFailed and SkipDependencySkipped.
+	// Name is empty if the dependency is not instrumented.
+	Dependency *TaskInfo
+
+	// Err is the error that caused the task to be skipped, if any:
+	//
+	//   - for SkipNotProduced, ErrNotProduced
+	//   - for SkipDependencyFailed, the error of the dependency
+	//   - for SkipDependencySkipped, the SkipReason of the dependency
+	//   - for SkipContextCancelled, the error of the context
+	//   - for SkipFlowAborted, the error the Flow or Parallel failed with
+	Err error
+}
+
+var _ error = (*SkipReason)(nil)
+
+func (r *SkipReason) Error() string {
+	msg := "task skipped: " + r.Kind.String()
+	if d := r.Dependency; d != nil {
+		if d.Name != "" {
+			msg += fmt.Sprintf(" (%v)", d.Name)
+		} else {
+			msg += fmt.Sprintf(" (%v:%d:%d)", d.File, d.Line, d.Column)
+		}
+	}
+	if r.Err != nil {
+		msg += ": " + r.Err.Error()
+	}
+	return msg
+}
+
+// Unwrap returns the error that caused the task to be skipped, if any.
+func (r *SkipReason) Unwrap() error {
+	return r.Err
+}
+
+// Expected reports whether the task was skipped by design,
+// because of a predicate, because a value was not produced,
+// or because a quorum was reached,
+// rather than because of a failure elsewhere.
+func (r *SkipReason) Expected() bool {
+	switch r.Kind {
+	case SkipPredicateFalse, SkipNotProduced, SkipQuorumReached:
+		return true
+	}
+	return false
+}
+
+// TaskOutcome records how a task of a Flow or Parallel finished
+// so that it may be reported why the task or the tasks that depend on it
+// were skipped.
+//
+// This is intended to be used by cff's generated code.
+// Do not use directly.
+// This can change without warning.
+type TaskOutcome struct {
+	// Info identifies the task to emitters of tasks that depend on it.
+	Info *TaskInfo
+
+	// Dependencies are the tasks that must finish successfully
+	// before this task may run.
+	Dependencies []*TaskOutcome
+
+	mu      sync.Mutex
+	started bool     // whether the scheduler started running the task
+	done    bool     // whether the scheduler ran the task
+	err     error    // error the task failed with, if any
+	skip    SkipKind // why the task chose not to run, if it did
+}
+
+// Start records that the scheduler started running the task.
+func (o *TaskOutcome) Start() {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	o.started = true
+}
+
+// Running reports whether the task started running but hasn't finished.
+// Such a task reports its own outcome once it finishes,
+// so it must not be reported as skipped.
+func (o *TaskOutcome) Running() bool {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	return o.started && !o.done
+}
+
+// Finish records that the scheduler ran the task and that it finished
+// with the given error.
+func (o *TaskOutcome) Finish(err error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	o.started = true
+	o.done = true
+	o.err = err
+}
+
+// Skip records that the task chose not to run.
+// Only the first call to Skip has any effect.
+func (o *TaskOutcome) Skip(kind SkipKind) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if o.skip == 0 {
+		o.skip = kind
+	}
+}
+
+func (o *TaskOutcome) state() (done bool, skip SkipKind, err error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	return o.done, o.skip, o.err
+}
+
+// SkipReason reports why the task was skipped,
+// given the context and the error the Flow or Parallel finished with.
+func (o *TaskOutcome) SkipReason(ctx context.Context, err error) *SkipReason {
+	switch _, skip, _ := o.state(); skip {
+	case 0:
+		// Not skipped by the task itself.
+	case SkipNotProduced:
+		return &SkipReason{Kind: skip, Err: ErrNotProduced}
+	default:
+		return &SkipReason{Kind: skip}
+	}
+
+	// Dependencies that failed are the most direct cause.
+	// Otherwise, if the context was cancelled, dependencies that didn't
+	// run were skipped because of that too.
+	for _, dep := range o.Dependencies {
+		if _, _, depErr := dep.state(); depErr != nil {
+			return &SkipReason{Kind: SkipDependencyFailed, Dependency: dep.Info, Err: depErr}
+		}
+	}
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return &SkipReason{Kind: SkipContextCancelled, Err: ctxErr}
+	}
+	for _, dep := range o.Dependencies {
+		if done, _, _ := dep.state(); !done {
+			return &SkipReason{
+				Kind:       SkipDependencySkipped,
+				Dependency: dep.Info,
+				Err:        dep.SkipReason(ctx, err),
+			}
+		}
+	}
+
+	// A Parallel that succeeded without starting the task stopped early
+	// because it reached its quorum.
+	if err == nil {
+		return &SkipReason{Kind: SkipQuorumReached}
+	}
+	return &SkipReason{Kind: SkipFlowAborted, Err: err}
+}
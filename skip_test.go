@@ -0,0 +1,167 @@
+package cff
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSkipKindString(t *testing.T) {
+	tests := []struct {
+		give SkipKind
+		want string
+	}{
+		{SkipPredicateFalse, "predicate false"},
+		{SkipNotProduced, "not produced"},
+		{SkipDependencyFailed, "dependency failed"},
+		{SkipDependencySkipped, "dependency skipped"},
+		{SkipContextCancelled, "context cancelled"},
+		{SkipFlowAborted, "flow aborted"},
+		{SkipQuorumReached, "quorum reached"},
+		{SkipKind(0), "unknown"},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, tt.give.String())
+	}
+}
+
+func TestSkipReasonError(t *testing.T) {
+	err := errors.New("great sadness")
+	tests := []struct {
+		desc string
+		give *SkipReason
+		want string
+	}{
+		{
+			desc: "predicate",
+			give: &SkipReason{Kind: SkipPredicateFalse},
+			want: "task skipped: predicate false",
+		},
+		{
+			desc: "named dependency",
+			give: &SkipReason{
+				Kind:       SkipDependencyFailed,
+				Dependency: &TaskInfo{Name: "foo", File: "foo.go", Line: 12, Column: 5},
+				Err:        err,
+			},
+			want: "task skipped: dependency failed (foo): great sadness",
+		},
+		{
+			desc: "unnamed dependency",
+			give: &SkipReason{
+				Kind:       SkipDependencyFailed,
+				Dependency: &TaskInfo{File: "foo.go", Line: 12, Column: 5},
+				Err:        err,
+			},
+			want: "task skipped: dependency failed (foo.go:12:5): great sadness",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.give.Error())
+		})
+	}
+}
+
+func TestTaskOutcomeSkipReason(t *testing.T) {
+	ctx := context.Background()
+	flowErr := errors.New("great sadness")
+
+	t.Run("predicate false", func(t *testing.T) {
+		var o TaskOutcome
+		o.Skip(SkipPredicateFalse)
+		o.Finish(nil)
+
+		r := o.SkipReason(ctx, nil)
+		assert.Equal(t, &SkipReason{Kind: SkipPredicateFalse}, r)
+		assert.True(t, r.Expected())
+	})
+
+	t.Run("not produced", func(t *testing.T) {
+		var o TaskOutcome
+		o.Skip(SkipNotProduced)
+		o.Skip(SkipPredicateFalse) // ignored
+		o.Finish(nil)
+
+		r := o.SkipReason(ctx, nil)
+		assert.Equal(t, SkipNotProduced, r.Kind)
+		assert.ErrorIs(t, r, ErrNotProduced)
+		assert.True(t, r.Expected())
+	})
+
+	t.Run("dependency failed", func(t *testing.T) {
+		skipped := &TaskOutcome{Info: &TaskInfo{Name: "skipped"}}
+		failed := &TaskOutcome{Info: &TaskInfo{Name: "failed"}}
+		failed.Finish(flowErr)
+
+		// The failed dependency is reported even if a skipped one comes
+		// first.
+		o := TaskOutcome{Dependencies: []*TaskOutcome{skipped, failed}}
+		r := o.SkipReason(ctx, flowErr)
+		assert.Equal(t, SkipDependencyFailed, r.Kind)
+		assert.Equal(t, failed.Info, r.Dependency)
+		assert.ErrorIs(t, r, flowErr)
+		assert.False(t, r.Expected())
+	})
+
+	t.Run("dependency skipped", func(t *testing.T) {
+		failed := &TaskOutcome{Info: &TaskInfo{Name: "failed"}}
+		failed.Finish(flowErr)
+		skipped := &TaskOutcome{
+			Info:         &TaskInfo{Name: "skipped"},
+			Dependencies: []*TaskOutcome{failed},
+		}
+
+		o := TaskOutcome{Dependencies: []*TaskOutcome{skipped}}
+		r := o.SkipReason(ctx, flowErr)
+		assert.Equal(t, SkipDependencySkipped, r.Kind)
+		assert.Equal(t, skipped.Info, r.Dependency)
+
+		var cause *SkipReason
+		require.ErrorAs(t, r.Err, &cause)
+		assert.Equal(t, SkipDependencyFailed, cause.Kind)
+		assert.Equal(t, failed.Info, cause.Dependency)
+		assert.ErrorIs(t, r, flowErr)
+	})
+
+	t.Run("context cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(ctx)
+		cancel()
+
+		// Reported even if a dependency was skipped as well.
+		o := TaskOutcome{Dependencies: []*TaskOutcome{new(TaskOutcome)}}
+		r := o.SkipReason(ctx, context.Canceled)
+		assert.Equal(t, SkipContextCancelled, r.Kind)
+		assert.ErrorIs(t, r, context.Canceled)
+	})
+
+	t.Run("flow aborted", func(t *testing.T) {
+		var o TaskOutcome
+		r := o.SkipReason(ctx, flowErr)
+		assert.Equal(t, &SkipReason{Kind: SkipFlowAborted, Err: flowErr}, r)
+		assert.False(t, r.Expected())
+	})
+
+	t.Run("quorum reached", func(t *testing.T) {
+		var o TaskOutcome
+		r := o.SkipReason(ctx, nil)
+		assert.Equal(t, &SkipReason{Kind: SkipQuorumReached}, r)
+		assert.True(t, r.Expected())
+	})
+}
+
+func TestTaskOutcomeRunning(t *testing.T) {
+	var o TaskOutcome
+	assert.False(t, o.Running(), "not started")
+
+	o.Start()
+	assert.True(t, o.Running(), "started")
+
+	o.Finish(nil)
+	assert.False(t, o.Running(), "finished")
+}
@@ -0,0 +1,15 @@
+package cff
+
+// TaskRef refers to a task of a [Flow].
+// Attach a TaskRef to a task with [Ref],
+// and use it with [After] to order other tasks after it.
+//
+//	var commit cff.TaskRef
+//	err := cff.Flow(ctx,
+//		cff.Task(commitTx, cff.Invoke(true), cff.Ref(&commit)),
+//		cff.Task(writeAuditLog, cff.Invoke(true), cff.After(commit)),
+//	)
+//
+// A TaskRef holds no state.
+// It's used only to identify tasks during code generation.
+type TaskRef struct{}